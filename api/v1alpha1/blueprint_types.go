@@ -8,12 +8,14 @@ import (
 	"path"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	"github.com/fluxcd/pkg/apis/kustomize"
 	meta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/goccy/go-yaml"
 	"github.com/windsorcli/cli/pkg/constants"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,6 +33,26 @@ import (
 // them.
 const CrdLayerName = "crds"
 
+// Source kinds, one per Flux source object a blueprint Source can be applied as. A Source with no
+// explicit Kind resolves to SourceKindOCI or SourceKindGit from its URL (see SourceKind).
+const (
+	SourceKindGit    = "git"
+	SourceKindOCI    = "oci"
+	SourceKindHelm   = "helm"
+	SourceKindBucket = "bucket"
+)
+
+// ArgoCDSubstitutionPlugin is the Argo CD config management plugin ToArgoApplication selects for a
+// kustomization with substitutions, since Argo CD has no counterpart to Flux's postBuild
+// substitution. The plugin runs kustomize build with the Application's components, patches and
+// namespace parameters, then substitutes the ARGOCD_ENV_-prefixed variables into the output. The
+// rendered tree ships its definition for the Argo CD repo server to mount.
+const ArgoCDSubstitutionPlugin = "windsor-envsubst"
+
+// ArgoCDSyncWaveAnnotation orders Argo CD Applications; ToArgoApplication sets it from the
+// kustomization's dependency depth (see Blueprint.SyncWaves).
+const ArgoCDSyncWaveAnnotation = "argocd.argoproj.io/sync-wave"
+
 // IsCrdLayerName reports whether name belongs to the synthesized CRD layer namespace: the base
 // "crds" name or any per-source "crds-<source>" name.
 func IsCrdLayerName(name string) bool {
@@ -348,6 +370,43 @@ func (d *Decryption) DeepCopy() *Decryption {
 	return c
 }
 
+// HealthCheck names an object whose readiness gates a Kustomization's Ready condition, mapping to an
+// entry of Flux's spec.healthChecks. It lets a kustomization hold its dependents back until an object
+// it does not apply itself, such as a database cluster created by an operator, is actually usable.
+type HealthCheck struct {
+	// APIVersion of the object, e.g. "postgresql.cnpg.io/v1".
+	APIVersion string `yaml:"apiVersion,omitempty"`
+
+	// Kind of the object, e.g. "Cluster".
+	Kind string `yaml:"kind"`
+
+	// Name of the object.
+	Name string `yaml:"name"`
+
+	// Namespace of the object. Empty resolves to the Kustomization's own namespace.
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// HealthCheckExpr is a CEL readiness rule for one custom resource kind, mapping to an entry of Flux's
+// spec.healthCheckExprs. kustomize-controller evaluates it against every object of that kind it
+// assesses, so a resource whose status Flux cannot interpret on its own still gates readiness.
+type HealthCheckExpr struct {
+	// APIVersion of the custom resource, e.g. "cert-manager.io/v1".
+	APIVersion string `yaml:"apiVersion"`
+
+	// Kind of the custom resource, e.g. "Certificate".
+	Kind string `yaml:"kind"`
+
+	// Current is the CEL expression that holds once the resource has reached its desired state.
+	Current string `yaml:"current"`
+
+	// InProgress is the CEL expression that holds while the resource is still converging.
+	InProgress string `yaml:"inProgress,omitempty"`
+
+	// Failed is the CEL expression that holds once the resource has failed.
+	Failed string `yaml:"failed,omitempty"`
+}
+
 // Blueprint is a configuration blueprint for initializing a project.
 type Blueprint struct {
 	// Kind is the blueprint type, following Kubernetes conventions.
@@ -410,6 +469,31 @@ func CrdKustomizationName(source string) string {
 	return CrdLayerName + "-" + source
 }
 
+// ParseKustomizationDependency splits a kustomization dependsOn entry into the namespace and
+// name of the kustomization it refers to. A bare "name" returns an empty namespace and resolves
+// in the gitops namespace; "namespace/name" refers to a kustomization living in namespace.
+// Entries with an empty part or more than one slash are rejected.
+func ParseKustomizationDependency(dep string) (namespace, name string, err error) {
+	namespace, name, qualified := strings.Cut(dep, "/")
+	if !qualified {
+		namespace, name = "", dep
+	}
+	if name == "" || (qualified && namespace == "") || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid dependsOn entry %q: expected name or namespace/name", dep)
+	}
+	return namespace, name, nil
+}
+
+// KustomizationDependencyName returns the name part of a kustomization dependsOn entry.
+// Kustomization names are unique within a blueprint, so the name alone identifies a dependency
+// when ordering kustomizations.
+func KustomizationDependencyName(dep string) string {
+	if i := strings.LastIndex(dep, "/"); i >= 0 {
+		return dep[i+1:]
+	}
+	return dep
+}
+
 // Metadata describes a blueprint.
 type Metadata struct {
 	// Name is the blueprint's unique identifier.
@@ -445,9 +529,31 @@ type Source struct {
 	// Ref details the branch, tag, or commit to use.
 	Ref Reference `yaml:"ref,omitempty"`
 
+	// Kind selects the Flux source the source is applied as: git (GitRepository), oci
+	// (OCIRepository), helm (HelmRepository) or bucket (Bucket). When empty it is inferred from Url:
+	// oci:// URLs are oci sources and every other URL is a git source.
+	Kind string `yaml:"kind,omitempty"`
+
 	// SecretName is the secret for source access.
 	SecretName string `yaml:"secretName,omitempty"`
 
+	// SecretRef names the Secret holding credentials for the source. It takes precedence over
+	// SecretName.
+	SecretRef *SourceSecretRef `yaml:"secretRef,omitempty"`
+
+	// Interval overrides how often Flux polls the source for a new revision.
+	Interval *DurationString `yaml:"interval,omitempty"`
+
+	// Provider selects how Flux authenticates to oci, helm and bucket sources: generic (the
+	// default), aws, azure or gcp.
+	Provider string `yaml:"provider,omitempty"`
+
+	// BucketName is the bucket a bucket source fetches; Url is the bucket's endpoint.
+	BucketName string `yaml:"bucketName,omitempty"`
+
+	// Region is the bucket's region, for bucket sources whose provider requires one.
+	Region string `yaml:"region,omitempty"`
+
 	// Install determines if the source should be merged (components merged into final blueprint).
 	// Defaults to false if not specified.
 	// Supports expressions in facets: use "${some.condition ?? true}" for dynamic values.
@@ -460,6 +566,38 @@ type Source struct {
 	Crds []string `yaml:"crds,omitempty"`
 }
 
+// SourceSecretRef names the Secret holding a source's credentials, resolved in the gitops namespace.
+type SourceSecretRef struct {
+	// Name is the Secret's name.
+	Name string `yaml:"name"`
+}
+
+// SourceKind returns the kind of source: its explicit Kind when set, otherwise SourceKindOCI for an
+// oci:// URL and SourceKindGit for any other URL.
+func SourceKind(source Source) string {
+	if source.Kind != "" {
+		return source.Kind
+	}
+	if strings.HasPrefix(source.Url, "oci://") {
+		return SourceKindOCI
+	}
+	return SourceKindGit
+}
+
+// FluxSourceKind returns the kind of the Flux source object source is applied as: GitRepository,
+// OCIRepository, HelmRepository or Bucket.
+func FluxSourceKind(source Source) string {
+	switch SourceKind(source) {
+	case SourceKindOCI:
+		return "OCIRepository"
+	case SourceKindHelm:
+		return "HelmRepository"
+	case SourceKindBucket:
+		return "Bucket"
+	}
+	return "GitRepository"
+}
+
 // IsLocalTemplateSource returns true when the source is the template source with no URL (local context template).
 func IsLocalTemplateSource(source Source) bool {
 	return source.Name == "template" && source.Url == ""
@@ -600,15 +738,16 @@ type Kustomization struct {
 	Source string `yaml:"source,omitempty"`
 
 	// Namespace overrides the namespace where the Flux Kustomization object itself lives.
-	// When unset, the gitops namespace is used. DependsOn references always resolve in the
-	// gitops namespace, so cross-namespace dependencies are not currently supported.
+	// When unset, the gitops namespace is used. Kustomizations in other namespaces depend on
+	// this one with a "namespace/name" DependsOn entry.
 	Namespace string `yaml:"namespace,omitempty"`
 
 	// TargetNamespace populates spec.targetNamespace, instructing Flux to override the
 	// namespace of every resource reconciled by this kustomization.
 	TargetNamespace string `yaml:"targetNamespace,omitempty"`
 
-	// DependsOn lists dependencies of this kustomization.
+	// DependsOn lists dependencies of this kustomization. A bare name resolves in the gitops
+	// namespace; "namespace/name" names a kustomization in another namespace.
 	DependsOn []string `yaml:"dependsOn,omitempty"`
 
 	// Interval for applying the kustomization.
@@ -671,6 +810,15 @@ type Kustomization struct {
 	// Decryption configures in-cluster decryption for this kustomization's manifests, mapping to Flux's
 	// spec.decryption. Nil leaves decryption unset (Flux default: no decryption).
 	Decryption *Decryption `yaml:"decryption,omitempty"`
+
+	// HealthChecks lists objects whose readiness gates this kustomization's Ready condition, mapping
+	// to Flux's spec.healthChecks. Flux ignores health checks while wait is enabled, so when
+	// HealthChecks is set and Wait is not, the kustomization is emitted with wait disabled.
+	HealthChecks []HealthCheck `yaml:"healthChecks,omitempty"`
+
+	// HealthCheckExprs are CEL readiness rules for custom resource kinds, mapping to Flux's
+	// spec.healthCheckExprs. They apply to every object assessed through wait or HealthChecks.
+	HealthCheckExprs []HealthCheckExpr `yaml:"healthCheckExprs,omitempty"`
 }
 
 // FluxSystem is a system entry under a blueprint or facet's `flux:` list — a functional layer that
@@ -845,10 +993,22 @@ func (b *Blueprint) DeepCopy() *Blueprint {
 				Name:   source.Ref.Name,
 				Commit: source.Ref.Commit,
 			},
+			Kind:       source.Kind,
 			SecretName: source.SecretName,
+			Provider:   source.Provider,
+			BucketName: source.BucketName,
+			Region:     source.Region,
 			Install:    source.Install.DeepCopy(),
 			Crds:       slices.Clone(source.Crds),
 		}
+		if source.SecretRef != nil {
+			secretRefCopy := *source.SecretRef
+			sourcesCopy[i].SecretRef = &secretRefCopy
+		}
+		if source.Interval != nil {
+			intervalCopy := *source.Interval
+			sourcesCopy[i].Interval = &intervalCopy
+		}
 	}
 
 	terraformComponentsCopy := make([]TerraformComponent, len(b.TerraformComponents))
@@ -1079,7 +1239,7 @@ func (b *Blueprint) RemoveTerraformComponent(removal TerraformComponent) error {
 
 // RemoveKustomization removes specified non-index fields from an existing Kustomization.
 // It finds a kustomization matching the same Name, then removes patches, components, dependencies,
-// cleanup items, and substitutions that are specified in the removal kustomization.
+// cleanup items, substitutions, and health checks that are specified in the removal kustomization.
 // The index field (Name) is not affected. If no matching kustomization exists, no action is taken.
 func (b *Blueprint) RemoveKustomization(removal Kustomization) error {
 	for i, existing := range b.Kustomizations {
@@ -1215,6 +1375,63 @@ func (b *Blueprint) AllKustomizations() []Kustomization {
 	return all
 }
 
+// SyncWaves returns the Argo CD sync wave of each non-destroy-only kustomization: its dependency
+// depth, so a kustomization with no dependencies is in wave 0 and every other one is in the wave
+// after its deepest dependency. Dependencies on kustomizations the blueprint does not declare are
+// ignored, as in kustomization ordering.
+func (b *Blueprint) SyncWaves() map[string]int {
+	nameToIndex := make(map[string]int, len(b.Kustomizations))
+	for i, k := range b.Kustomizations {
+		if k.DestroyOnly != nil && *k.DestroyOnly {
+			continue
+		}
+		nameToIndex[k.Name] = i
+	}
+	waves := make(map[string]int, len(nameToIndex))
+	for name, i := range nameToIndex {
+		waves[name] = b.calculateDependencyDepth(i, nameToIndex)
+	}
+	return waves
+}
+
+// ToArgoSyncApplication returns the root Argo CD Application push mode applies when the gitops
+// engine is Argo CD, the counterpart of the root Flux Kustomization: named name in namespace, it
+// syncs treePath, the directory of the rendered tree holding the kustomizations' Applications,
+// from the blueprint's repository and prunes Applications dropped from it. Its sync applies the
+// Applications wave by wave (see SyncWaves). It carries no resources finalizer, so deleting it
+// leaves the Applications in place. Returns an error when the blueprint declares no repository
+// Argo CD can sync from.
+func (b *Blueprint) ToArgoSyncApplication(name, namespace, treePath string) (map[string]any, error) {
+	repoURL, revision, err := argoSourceLocation(b.Metadata.Name, b.Metadata.Name, b.Repository, nil)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"spec": map[string]any{
+			"project": "default",
+			"source": map[string]any{
+				"repoURL":        repoURL,
+				"targetRevision": revision,
+				"path":           treePath,
+			},
+			"destination": map[string]any{"server": "https://kubernetes.default.svc", "namespace": namespace},
+			"syncPolicy": map[string]any{
+				"automated": map[string]any{"prune": true, "selfHeal": true},
+				"retry": map[string]any{
+					"limit": int64(-1),
+					"backoff": map[string]any{
+						"duration":    constants.DefaultFluxKustomizationRetryInterval.String(),
+						"maxDuration": constants.DefaultFluxKustomizationTimeout.String(),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 // TierNames returns the compiled Kustomization names this system produces (its install tier, if
 // any, followed by each resources variant), in compiled order.
 func (sys FluxSystem) TierNames() []string {
@@ -1337,6 +1554,29 @@ func applySystemTierDefaults(k *Kustomization, sys FluxSystem) {
 	k.Substitute = nil
 }
 
+// ObjectNamespace returns the namespace the Flux Kustomization object lives in: k.Namespace when
+// set, otherwise gitopsNamespace.
+func (k *Kustomization) ObjectNamespace(gitopsNamespace string) string {
+	if k.Namespace != "" {
+		return k.Namespace
+	}
+	return gitopsNamespace
+}
+
+// SourceName returns the name of the source the kustomization builds from: its Source, or
+// defaultSourceName when it names none, or names the template source while no remote template
+// source is declared.
+func (k *Kustomization) SourceName(defaultSourceName string, sources []Source) string {
+	sourceName := k.Source
+	if sourceName == "" {
+		sourceName = defaultSourceName
+	}
+	if sourceName == "template" && !HasRemoteTemplateSource(sources) {
+		sourceName = defaultSourceName
+	}
+	return sourceName
+}
+
 // DeepCopy creates a deep copy of the Kustomization object.
 func (k *Kustomization) DeepCopy() *Kustomization {
 	if k == nil {
@@ -1344,27 +1584,29 @@ func (k *Kustomization) DeepCopy() *Kustomization {
 	}
 
 	return &Kustomization{
-		Name:            k.Name,
-		Path:            k.Path,
-		Source:          k.Source,
-		Namespace:       k.Namespace,
-		TargetNamespace: k.TargetNamespace,
-		DependsOn:       slices.Clone(k.DependsOn),
-		Interval:        k.Interval,
-		RetryInterval:   k.RetryInterval,
-		Timeout:         k.Timeout,
-		Patches:         slices.Clone(k.Patches),
-		Wait:            k.Wait,
-		Force:           k.Force,
-		Prune:           k.Prune,
-		Components:      slices.Clone(k.Components),
-		Destroy:         k.Destroy.DeepCopy(),
-		DestroyOnly:     k.DestroyOnly,
-		Enabled:         k.Enabled.DeepCopy(),
-		Substitutions:   maps.Clone(k.Substitutions),
-		Substitute:      maps.Clone(k.Substitute),
-		Secrets:         cloneSecretData(k.Secrets),
-		Decryption:      k.Decryption.DeepCopy(),
+		Name:             k.Name,
+		Path:             k.Path,
+		Source:           k.Source,
+		Namespace:        k.Namespace,
+		TargetNamespace:  k.TargetNamespace,
+		DependsOn:        slices.Clone(k.DependsOn),
+		Interval:         k.Interval,
+		RetryInterval:    k.RetryInterval,
+		Timeout:          k.Timeout,
+		Patches:          slices.Clone(k.Patches),
+		Wait:             k.Wait,
+		Force:            k.Force,
+		Prune:            k.Prune,
+		Components:       slices.Clone(k.Components),
+		Destroy:          k.Destroy.DeepCopy(),
+		DestroyOnly:      k.DestroyOnly,
+		Enabled:          k.Enabled.DeepCopy(),
+		Substitutions:    maps.Clone(k.Substitutions),
+		Substitute:       maps.Clone(k.Substitute),
+		Secrets:          cloneSecretData(k.Secrets),
+		Decryption:       k.Decryption.DeepCopy(),
+		HealthChecks:     slices.Clone(k.HealthChecks),
+		HealthCheckExprs: slices.Clone(k.HealthCheckExprs),
 	}
 }
 
@@ -1416,11 +1658,11 @@ func (s *FluxSystem) DeepCopy() *FluxSystem {
 // ToFluxKustomization converts a blueprint Kustomization to a Flux Kustomization.
 // It takes the default namespace for the kustomization (overridden per-kustomization
 // by k.Namespace when set), the default source name to use if no source is specified,
-// and the list of sources to determine the source kind (GitRepository or OCIRepository).
+// and the list of sources to determine the source kind (see FluxSourceKind).
 // A Path-less k defaults to k.Name, matching FluxSystem's own path-defaulting.
 // k.TargetNamespace is passed through to spec.targetNamespace so Flux rewrites the
-// namespace of every reconciled resource. DependsOn references are always resolved
-// in the default namespace.
+// namespace of every reconciled resource. Bare DependsOn names resolve in the default
+// namespace; "namespace/name" entries are emitted with their own namespace.
 // The default Interval depends on whether k resolves to the blueprint's own repository or a
 // named vendor source (see constants.FluxKustomizationInterval); mode is accepted for call-site
 // stability but no longer affects it. A blueprint-level Interval override always wins.
@@ -1432,9 +1674,13 @@ func (s *FluxSystem) DeepCopy() *FluxSystem {
 func (k *Kustomization) ToFluxKustomization(namespace string, defaultSourceName string, sources []Source, mode constants.GitopsMode, configMaps ...map[string]map[string]string) kustomizev1.Kustomization {
 	dependsOn := make([]kustomizev1.DependencyReference, len(k.DependsOn))
 	for idx, dep := range k.DependsOn {
+		depNamespace, depName, err := ParseKustomizationDependency(dep)
+		if err != nil || depNamespace == "" {
+			depNamespace, depName = namespace, KustomizationDependencyName(dep)
+		}
 		dependsOn[idx] = kustomizev1.DependencyReference{
-			Name:      dep,
-			Namespace: namespace,
+			Name:      depName,
+			Namespace: depNamespace,
 		}
 	}
 
@@ -1445,30 +1691,17 @@ func (k *Kustomization) ToFluxKustomization(namespace string, defaultSourceName
 		sourceRefNamespace = namespace
 	}
 
-	sourceName := k.Source
-	if sourceName == "" {
-		sourceName = defaultSourceName
-	}
-	if sourceName == "template" && !HasRemoteTemplateSource(sources) {
-		sourceName = defaultSourceName
-	}
+	sourceName := k.SourceName(defaultSourceName, sources)
 
 	sourceKind := "GitRepository"
 	for _, source := range sources {
-		if source.Name == sourceName && strings.HasPrefix(source.Url, "oci://") {
-			sourceKind = "OCIRepository"
+		if source.Name == sourceName {
+			sourceKind = FluxSourceKind(source)
 			break
 		}
 	}
 
-	path := k.Path
-	if path == "" {
-		path = k.Name
-	}
-	path = strings.ReplaceAll(path, "\\", "/")
-	if path != "kustomize" && !strings.HasPrefix(path, "kustomize/") {
-		path = "kustomize/" + path
-	}
+	path := k.sourcePath()
 
 	interval := metav1.Duration{Duration: constants.FluxKustomizationInterval(sourceName == defaultSourceName)}
 	if k.Interval != nil && k.Interval.Duration != 0 {
@@ -1488,6 +1721,8 @@ func (k *Kustomization) ToFluxKustomization(namespace string, defaultSourceName
 	wait := constants.DefaultFluxKustomizationWait
 	if k.Wait != nil {
 		wait = *k.Wait
+	} else if len(k.HealthChecks) > 0 {
+		wait = false
 	}
 
 	force := constants.DefaultFluxKustomizationForce
@@ -1564,6 +1799,29 @@ func (k *Kustomization) ToFluxKustomization(namespace string, defaultSourceName
 		}
 	}
 
+	var healthChecks []meta.NamespacedObjectKindReference
+	for _, hc := range k.HealthChecks {
+		healthChecks = append(healthChecks, meta.NamespacedObjectKindReference{
+			APIVersion: hc.APIVersion,
+			Kind:       hc.Kind,
+			Name:       hc.Name,
+			Namespace:  hc.Namespace,
+		})
+	}
+
+	var healthCheckExprs []kustomize.CustomHealthCheck
+	for _, expr := range k.HealthCheckExprs {
+		healthCheckExprs = append(healthCheckExprs, kustomize.CustomHealthCheck{
+			APIVersion: expr.APIVersion,
+			Kind:       expr.Kind,
+			HealthCheckExpressions: kustomize.HealthCheckExpressions{
+				Current:    expr.Current,
+				InProgress: expr.InProgress,
+				Failed:     expr.Failed,
+			},
+		})
+	}
+
 	return kustomizev1.Kustomization{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Kustomization",
@@ -1579,28 +1837,241 @@ func (k *Kustomization) ToFluxKustomization(namespace string, defaultSourceName
 				Name:      sourceName,
 				Namespace: sourceRefNamespace,
 			},
-			Path:            path,
-			DependsOn:       dependsOn,
-			Interval:        interval,
-			RetryInterval:   &retryInterval,
-			Timeout:         &timeout,
-			Wait:            wait,
-			Force:           force,
-			Prune:           prune,
-			DeletionPolicy:  deletionPolicy,
-			Patches:         patches,
-			Components:      k.Components,
-			PostBuild:       postBuild,
-			TargetNamespace: k.TargetNamespace,
-			Decryption:      decryption,
+			Path:             path,
+			DependsOn:        dependsOn,
+			Interval:         interval,
+			RetryInterval:    &retryInterval,
+			Timeout:          &timeout,
+			Wait:             wait,
+			Force:            force,
+			Prune:            prune,
+			DeletionPolicy:   deletionPolicy,
+			Patches:          patches,
+			Components:       k.Components,
+			PostBuild:        postBuild,
+			TargetNamespace:  k.TargetNamespace,
+			Decryption:       decryption,
+			HealthChecks:     healthChecks,
+			HealthCheckExprs: healthCheckExprs,
+		},
+	}
+}
+
+// ToArgoApplication converts the kustomization to an Argo CD Application, the counterpart of
+// ToFluxKustomization for contexts whose gitops engine is Argo CD. The Application lives in
+// namespace (the Argo CD namespace) and is returned as an unstructured object, as the CLI carries
+// no Argo CD API dependency. Its source is the resolved source's repository and revision — the
+// blueprint's repository for defaultSourceName — at the same path Flux would build. Components,
+// patches and TargetNamespace map to the kustomize source and the destination namespace. wave is
+// set as the sync-wave annotation (see Blueprint.SyncWaves), which orders the Applications within
+// the sync of the root Application (see Blueprint.ToArgoSyncApplication) that manages them. Argo
+// CD assesses no Application health of its own, so later waves wait on earlier ones only where
+// the Argo CD instance carries the argoproj.io/Application health check push mode installs; Wait
+// has no per-Application mapping beyond that. Prune maps to automated pruning, Force to a forced
+// replace, RetryInterval and Timeout to the retry backoff, and Destroy (default true) to the
+// resources finalizer that deletes the Application's resources with it. Substitutions, merged
+// over the blueprint-level configMaps as Flux's substituteFrom would apply them, select
+// ArgoCDSubstitutionPlugin with the values as its env and the components, patches and target
+// namespace as its parameters; CRD layers never substitute. Returns an error when the source is a
+// Helm repository, bucket or local template, which Argo CD cannot build a kustomization from.
+func (k *Kustomization) ToArgoApplication(namespace string, defaultSourceName string, repository Repository, sources []Source, wave int, configMaps ...map[string]map[string]string) (map[string]any, error) {
+	sourceName := k.SourceName(defaultSourceName, sources)
+	repoURL, revision, err := argoSourceLocation(sourceName, defaultSourceName, repository, sources)
+	if err != nil {
+		return nil, fmt.Errorf("kustomization %s: %w", k.Name, err)
+	}
+
+	patches := make([]any, 0, len(k.Patches))
+	for _, p := range k.Patches {
+		if p.Patch == "" {
+			continue
+		}
+		patch := map[string]any{"patch": p.Patch}
+		if p.Target != nil {
+			target := map[string]any{}
+			for key, value := range map[string]string{"kind": p.Target.Kind, "name": p.Target.Name, "namespace": p.Target.Namespace} {
+				if value != "" {
+					target[key] = value
+				}
+			}
+			patch["target"] = target
+		}
+		patches = append(patches, patch)
+	}
+	components := make([]any, len(k.Components))
+	for i, c := range k.Components {
+		components[i] = c
+	}
+
+	substitutions := map[string]string{}
+	if !IsCrdLayerName(k.Name) {
+		if len(configMaps) > 0 {
+			for _, name := range slices.Sorted(maps.Keys(configMaps[0])) {
+				maps.Copy(substitutions, configMaps[0][name])
+			}
+		}
+		maps.Copy(substitutions, k.Substitutions)
+	}
+
+	source := map[string]any{
+		"repoURL":        repoURL,
+		"targetRevision": revision,
+		"path":           k.sourcePath(),
+	}
+	if len(substitutions) > 0 {
+		env := make([]any, 0, len(substitutions))
+		for _, name := range slices.Sorted(maps.Keys(substitutions)) {
+			env = append(env, map[string]any{"name": name, "value": substitutions[name]})
+		}
+		parameters := []any{map[string]any{"name": "components", "array": components}}
+		if len(patches) > 0 {
+			data, err := yaml.Marshal(patches)
+			if err != nil {
+				return nil, fmt.Errorf("kustomization %s: error encoding patches: %w", k.Name, err)
+			}
+			parameters = append(parameters, map[string]any{"name": "patches", "string": string(data)})
+		}
+		if k.TargetNamespace != "" {
+			parameters = append(parameters, map[string]any{"name": "namespace", "string": k.TargetNamespace})
+		}
+		source["plugin"] = map[string]any{"name": ArgoCDSubstitutionPlugin, "env": env, "parameters": parameters}
+	} else {
+		kustomize := map[string]any{}
+		if len(components) > 0 {
+			kustomize["components"] = components
+		}
+		if len(patches) > 0 {
+			kustomize["patches"] = patches
+		}
+		if k.TargetNamespace != "" {
+			kustomize["namespace"] = k.TargetNamespace
+		}
+		if len(kustomize) > 0 {
+			source["kustomize"] = kustomize
+		}
+	}
+
+	destination := map[string]any{"server": "https://kubernetes.default.svc"}
+	if k.TargetNamespace != "" {
+		destination["namespace"] = k.TargetNamespace
+	}
+
+	prune := true
+	if k.Prune != nil {
+		prune = *k.Prune
+	}
+	syncOptions := []any{}
+	if k.Force != nil && *k.Force {
+		syncOptions = append(syncOptions, "Force=true", "Replace=true")
+	}
+	retryInterval := constants.DefaultFluxKustomizationRetryInterval
+	if k.RetryInterval != nil && k.RetryInterval.Duration != 0 {
+		retryInterval = k.RetryInterval.Duration
+	}
+	timeout := constants.DefaultFluxKustomizationTimeout
+	if k.Timeout != nil && k.Timeout.Duration != 0 {
+		timeout = k.Timeout.Duration
+	}
+	syncPolicy := map[string]any{
+		"automated": map[string]any{"prune": prune, "selfHeal": true},
+		"retry": map[string]any{
+			"limit": int64(-1),
+			"backoff": map[string]any{
+				"duration":    retryInterval.String(),
+				"maxDuration": timeout.String(),
+			},
 		},
 	}
+	if len(syncOptions) > 0 {
+		syncPolicy["syncOptions"] = syncOptions
+	}
+
+	metadata := map[string]any{
+		"name":        k.Name,
+		"namespace":   namespace,
+		"annotations": map[string]any{ArgoCDSyncWaveAnnotation: strconv.Itoa(wave)},
+	}
+	if destroy := k.Destroy.ToBool(); destroy == nil || *destroy {
+		metadata["finalizers"] = []any{"resources-finalizer.argocd.argoproj.io"}
+	}
+
+	return map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   metadata,
+		"spec": map[string]any{
+			"project":     "default",
+			"source":      source,
+			"destination": destination,
+			"syncPolicy":  syncPolicy,
+		},
+	}, nil
 }
 
 // =============================================================================
 // Private Methods
 // =============================================================================
 
+// sourcePath returns the path the kustomization is built from within its source: Path, defaulting
+// to Name, under the kustomize/ directory.
+func (k *Kustomization) sourcePath() string {
+	path := k.Path
+	if path == "" {
+		path = k.Name
+	}
+	path = strings.ReplaceAll(path, "\\", "/")
+	if path != "kustomize" && !strings.HasPrefix(path, "kustomize/") {
+		path = "kustomize/" + path
+	}
+	return path
+}
+
+// argoSourceLocation returns the repoURL and targetRevision of an Argo CD Application source built
+// from the named source: the blueprint repository for defaultSourceName, otherwise the declared
+// git or OCI source. An OCI URL's tag moves to the revision. The revision is the first of the
+// reference's commit, semver, tag and branch that is set, falling back to HEAD for git and latest
+// for OCI. It errors for a source that is not declared or that Argo CD cannot build from.
+func argoSourceLocation(sourceName, defaultSourceName string, repository Repository, sources []Source) (string, string, error) {
+	var source Source
+	found := false
+	if sourceName == defaultSourceName && repository.Url != "" {
+		source, found = Source{Name: sourceName, Url: repository.Url, Ref: repository.Ref}, true
+	}
+	for _, s := range sources {
+		if !found && s.Name == sourceName {
+			source, found = s, true
+		}
+	}
+	if !found || IsLocalTemplateSource(source) {
+		return "", "", fmt.Errorf("source %q has no repository Argo CD can sync from", sourceName)
+	}
+
+	url := source.Url
+	revision := ""
+	switch kind := SourceKind(source); kind {
+	case SourceKindGit:
+	case SourceKindOCI:
+		if lastColon := strings.LastIndex(url, ":"); lastColon > len("oci://") && !strings.Contains(url[lastColon+1:], "/") {
+			url, revision = url[:lastColon], url[lastColon+1:]
+		}
+	default:
+		return "", "", fmt.Errorf("source %q is a %s source, which Argo CD cannot build a kustomization from", sourceName, kind)
+	}
+	for _, ref := range []string{source.Ref.Commit, source.Ref.SemVer, source.Ref.Tag, source.Ref.Branch} {
+		if ref != "" {
+			revision = ref
+			break
+		}
+	}
+	if revision == "" {
+		revision = "HEAD"
+		if SourceKind(source) == SourceKindOCI {
+			revision = "latest"
+		}
+	}
+	return url, revision, nil
+}
+
 // subtractStringSlice returns existing with every element also present in removal deleted.
 // Shared by subtractKustomizationFields (DependsOn, Components) and RemoveFluxSystem
 // (DependsOn), the two field-level removal entry points.
@@ -1613,9 +2084,10 @@ func subtractStringSlice(existing, removal []string) []string {
 	})
 }
 
-// subtractKustomizationFields returns existing with the patches, components, dependencies, and
-// substitutions named in removal stripped out. Patches match by Path or Patch content equality;
-// everything else matches by value/key. Fields removal leaves empty are left untouched on
+// subtractKustomizationFields returns existing with the patches, components, dependencies,
+// substitutions, and health checks named in removal stripped out. Patches match by Path or Patch
+// content equality; health check expressions match by apiVersion and kind; everything else matches
+// by value/key. Fields removal leaves empty are left untouched on
 // existing. The Name field is never touched, so this is safe to use on both indexed
 // (Blueprint.Kustomizations) and unindexed (a FluxSystem's Install tier) Kustomizations.
 func subtractKustomizationFields(existing, removal Kustomization) Kustomization {
@@ -1642,6 +2114,20 @@ func subtractKustomizationFields(existing, removal Kustomization) Kustomization
 		}
 	}
 
+	if len(removal.HealthChecks) > 0 {
+		existing.HealthChecks = slices.DeleteFunc(slices.Clone(existing.HealthChecks), func(hc HealthCheck) bool {
+			return slices.Contains(removal.HealthChecks, hc)
+		})
+	}
+
+	if len(removal.HealthCheckExprs) > 0 {
+		existing.HealthCheckExprs = slices.DeleteFunc(slices.Clone(existing.HealthCheckExprs), func(expr HealthCheckExpr) bool {
+			return slices.ContainsFunc(removal.HealthCheckExprs, func(r HealthCheckExpr) bool {
+				return r.APIVersion == expr.APIVersion && r.Kind == expr.Kind
+			})
+		})
+	}
+
 	return existing
 }
 
@@ -1827,11 +2313,29 @@ func MergeKustomizationFields(base, overlay Kustomization) Kustomization {
 	if overlay.Decryption != nil {
 		existing.Decryption = overlay.Decryption.DeepCopy()
 	}
+	existing.HealthChecks = slices.Clone(base.HealthChecks)
+	for _, hc := range overlay.HealthChecks {
+		if !slices.Contains(existing.HealthChecks, hc) {
+			existing.HealthChecks = append(existing.HealthChecks, hc)
+		}
+	}
+	existing.HealthCheckExprs = slices.Clone(base.HealthCheckExprs)
+	for _, expr := range overlay.HealthCheckExprs {
+		idx := slices.IndexFunc(existing.HealthCheckExprs, func(e HealthCheckExpr) bool {
+			return e.APIVersion == expr.APIVersion && e.Kind == expr.Kind
+		})
+		if idx >= 0 {
+			existing.HealthCheckExprs[idx] = expr
+		} else {
+			existing.HealthCheckExprs = append(existing.HealthCheckExprs, expr)
+		}
+	}
 	return existing
 }
 
 // sortKustomize reorders the Blueprint's Kustomizations so that dependencies precede dependents.
 // It first applies a topological sort to ensure dependency order, then groups kustomizations with similar name prefixes adjacently.
+// Qualified "namespace/name" dependencies are ordered by their name part.
 // Returns an error if a dependency cycle is detected.
 func (b *Blueprint) sortKustomize() error {
 	if len(b.Kustomizations) <= 1 {
@@ -1872,8 +2376,8 @@ func (b *Blueprint) basicTopologicalSort(nameToIndex map[string]int) []int {
 		}
 
 		visiting[componentIndex] = true
-		for _, depName := range b.Kustomizations[componentIndex].DependsOn {
-			if depIndex, exists := nameToIndex[depName]; exists {
+		for _, dep := range b.Kustomizations[componentIndex].DependsOn {
+			if depIndex, exists := nameToIndex[KustomizationDependencyName(dep)]; exists {
 				if err := visit(depIndex); err != nil {
 					visiting[componentIndex] = false
 					return err
@@ -1957,8 +2461,8 @@ func (b *Blueprint) calculateDependencyDepth(componentIndex int, nameToIndex map
 	}
 
 	maxDepth := 0
-	for _, depName := range k.DependsOn {
-		if depIndex, exists := nameToIndex[depName]; exists {
+	for _, dep := range k.DependsOn {
+		if depIndex, exists := nameToIndex[KustomizationDependencyName(dep)]; exists {
 			depth := b.calculateDependencyDepth(depIndex, nameToIndex)
 			if depth+1 > maxDepth {
 				maxDepth = depth + 1
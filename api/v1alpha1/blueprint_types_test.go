@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 	"testing"
@@ -147,6 +148,45 @@ func TestIsCrdLayerName(t *testing.T) {
 	})
 }
 
+func TestParseKustomizationDependency(t *testing.T) {
+	t.Run("SplitsQualifiedAndBareEntries", func(t *testing.T) {
+		// Given a bare and a namespace-qualified entry
+		cases := map[string][2]string{"platform": {"", "platform"}, "tenants/platform": {"tenants", "platform"}}
+		for dep, want := range cases {
+			// When parsed, each yields its namespace and name parts
+			namespace, name, err := ParseKustomizationDependency(dep)
+			if err != nil || namespace != want[0] || name != want[1] {
+				t.Errorf("%q: expected %q/%q, got %q/%q, %v", dep, want[0], want[1], namespace, name, err)
+			}
+			if got := KustomizationDependencyName(dep); got != want[1] {
+				t.Errorf("%q: expected name %q, got %q", dep, want[1], got)
+			}
+		}
+	})
+
+	t.Run("RejectsMalformedEntries", func(t *testing.T) {
+		// Given entries with empty parts or extra separators
+		for _, dep := range []string{"", "/platform", "tenants/", "a/b/c"} {
+			// When parsed, each is rejected
+			if _, _, err := ParseKustomizationDependency(dep); err == nil {
+				t.Errorf("expected %q to be rejected", dep)
+			}
+		}
+	})
+}
+
+func TestKustomization_ObjectNamespace(t *testing.T) {
+	t.Run("FallsBackToGitopsNamespace", func(t *testing.T) {
+		// Given kustomizations with and without a namespace override
+		if got := (&Kustomization{}).ObjectNamespace("system-gitops"); got != "system-gitops" {
+			t.Errorf("expected system-gitops, got %q", got)
+		}
+		if got := (&Kustomization{Namespace: "tenants"}).ObjectNamespace("system-gitops"); got != "tenants" {
+			t.Errorf("expected tenants, got %q", got)
+		}
+	})
+}
+
 func TestBlueprint_StrategicMerge(t *testing.T) {
 	t.Run("MergesTerraformComponentsStrategically", func(t *testing.T) {
 		// Given a base blueprint with terraform components
@@ -2656,6 +2696,41 @@ func TestHasRemoteTemplateSource(t *testing.T) {
 	})
 }
 
+func TestSourceKind(t *testing.T) {
+	t.Run("InfersKindFromURL", func(t *testing.T) {
+		if kind := SourceKind(Source{Url: "oci://ghcr.io/windsorcli/core:v1.0.0"}); kind != SourceKindOCI {
+			t.Errorf("Expected oci for an oci:// URL, got %q", kind)
+		}
+		if kind := SourceKind(Source{Url: "https://github.com/example/repo.git"}); kind != SourceKindGit {
+			t.Errorf("Expected git for an https URL, got %q", kind)
+		}
+	})
+
+	t.Run("ExplicitKindWinsOverURL", func(t *testing.T) {
+		source := Source{Kind: SourceKindHelm, Url: "oci://ghcr.io/example/charts"}
+		if kind := SourceKind(source); kind != SourceKindHelm {
+			t.Errorf("Expected helm, got %q", kind)
+		}
+		if kind := FluxSourceKind(source); kind != "HelmRepository" {
+			t.Errorf("Expected HelmRepository, got %q", kind)
+		}
+	})
+
+	t.Run("MapsEachKindToFluxSource", func(t *testing.T) {
+		want := map[string]string{
+			SourceKindGit:    "GitRepository",
+			SourceKindOCI:    "OCIRepository",
+			SourceKindHelm:   "HelmRepository",
+			SourceKindBucket: "Bucket",
+		}
+		for kind, fluxKind := range want {
+			if got := FluxSourceKind(Source{Kind: kind}); got != fluxKind {
+				t.Errorf("Expected %s for %s, got %s", fluxKind, kind, got)
+			}
+		}
+	})
+}
+
 func TestKustomization_ToFluxKustomization(t *testing.T) {
 	t.Run("BasicConversionWithDefaults", func(t *testing.T) {
 		kustomization := &Kustomization{
@@ -3128,14 +3203,35 @@ func TestKustomization_ToFluxKustomization(t *testing.T) {
 		// When converted
 		result := kustomization.ToFluxKustomization("default-ns", "default-source", []Source{}, constants.GitopsModePull)
 
-		// Then DependsOn references resolve in the default namespace, not the override.
-		// Cross-namespace dependencies are not currently supported and would require a
-		// richer DependsOn schema; this guards against accidentally changing that contract.
+		// Then bare DependsOn references resolve in the default namespace, not the override
 		if len(result.Spec.DependsOn) != 1 || result.Spec.DependsOn[0].Namespace != "default-ns" {
 			t.Errorf("Expected DependsOn[0] in default-ns, got %+v", result.Spec.DependsOn)
 		}
 	})
 
+	t.Run("QualifiedDependsOnUsesItsOwnNamespace", func(t *testing.T) {
+		// Given a kustomization depending on a kustomization in another namespace
+		kustomization := &Kustomization{
+			Name:      "test-kustomization",
+			Path:      "test/path",
+			DependsOn: []string{"tenants/platform", "dep1"},
+		}
+
+		// When converted
+		result := kustomization.ToFluxKustomization("default-ns", "default-source", []Source{}, constants.GitopsModePull)
+
+		// Then the qualified reference keeps its namespace and the bare one uses the default
+		if len(result.Spec.DependsOn) != 2 {
+			t.Fatalf("Expected 2 DependsOn references, got %+v", result.Spec.DependsOn)
+		}
+		if got := result.Spec.DependsOn[0]; got.Name != "platform" || got.Namespace != "tenants" {
+			t.Errorf("Expected tenants/platform, got %+v", got)
+		}
+		if got := result.Spec.DependsOn[1]; got.Name != "dep1" || got.Namespace != "default-ns" {
+			t.Errorf("Expected default-ns/dep1, got %+v", got)
+		}
+	})
+
 	t.Run("TargetNamespacePopulatesSpec", func(t *testing.T) {
 		// Given a kustomization that asks Flux to rewrite reconciled resource namespaces
 		kustomization := &Kustomization{
@@ -3221,6 +3317,20 @@ func TestKustomization_ToFluxKustomization(t *testing.T) {
 		}
 	})
 
+	t.Run("BucketSourceReferencedAsBucket", func(t *testing.T) {
+		// Given a kustomization reading from a bucket source
+		kustomization := &Kustomization{Name: "apps", Source: "manifests"}
+		sources := []Source{{Name: "manifests", Kind: SourceKindBucket, Url: "https://s3.amazonaws.com", BucketName: "platform"}}
+
+		// When converted
+		result := kustomization.ToFluxKustomization("ns", "src", sources, constants.GitopsModePull)
+
+		// Then the sourceRef kind is Bucket
+		if result.Spec.SourceRef.Kind != "Bucket" || result.Spec.SourceRef.Name != "manifests" {
+			t.Errorf("Expected sourceRef Bucket/manifests, got %+v", result.Spec.SourceRef)
+		}
+	})
+
 	t.Run("DecryptionUnsetByDefault", func(t *testing.T) {
 		// Given a kustomization with no Decryption
 		kustomization := &Kustomization{Name: "k", Path: "p"}
@@ -3276,6 +3386,395 @@ func TestKustomization_ToFluxKustomization(t *testing.T) {
 			t.Errorf("Expected nil secretRef, got %+v", result.Spec.Decryption.SecretRef)
 		}
 	})
+
+	t.Run("HealthChecksThreadedIntoSpec", func(t *testing.T) {
+		// Given a kustomization gating readiness on a CloudNativePG cluster with a CEL rule for its kind
+		kustomization := &Kustomization{
+			Name: "k",
+			Path: "p",
+			HealthChecks: []HealthCheck{
+				{APIVersion: "postgresql.cnpg.io/v1", Kind: "Cluster", Name: "db", Namespace: "data"},
+			},
+			HealthCheckExprs: []HealthCheckExpr{
+				{APIVersion: "postgresql.cnpg.io/v1", Kind: "Cluster", Current: "status.readyInstances == status.instances", Failed: "status.phase == 'Failed'"},
+			},
+		}
+
+		// When converted
+		result := kustomization.ToFluxKustomization("ns", "src", []Source{}, constants.GitopsModePull)
+
+		// Then spec.healthChecks and spec.healthCheckExprs carry the authored entries
+		if len(result.Spec.HealthChecks) != 1 {
+			t.Fatalf("Expected 1 health check, got %+v", result.Spec.HealthChecks)
+		}
+		hc := result.Spec.HealthChecks[0]
+		if hc.APIVersion != "postgresql.cnpg.io/v1" || hc.Kind != "Cluster" || hc.Name != "db" || hc.Namespace != "data" {
+			t.Errorf("Expected Cluster data/db health check, got %+v", hc)
+		}
+		if len(result.Spec.HealthCheckExprs) != 1 {
+			t.Fatalf("Expected 1 health check expression, got %+v", result.Spec.HealthCheckExprs)
+		}
+		expr := result.Spec.HealthCheckExprs[0]
+		if expr.Kind != "Cluster" || expr.Current != "status.readyInstances == status.instances" || expr.Failed != "status.phase == 'Failed'" || expr.InProgress != "" {
+			t.Errorf("Expected the Cluster expressions, got %+v", expr)
+		}
+
+		// And wait defaults off, since Flux ignores health checks while wait is enabled
+		if result.Spec.Wait {
+			t.Error("Expected wait disabled when health checks are set")
+		}
+	})
+
+	t.Run("ExplicitWaitBeatsHealthCheckDefault", func(t *testing.T) {
+		// Given a kustomization with health checks and wait explicitly enabled
+		wait := true
+		kustomization := &Kustomization{
+			Name:         "k",
+			Path:         "p",
+			Wait:         &wait,
+			HealthChecks: []HealthCheck{{Kind: "Deployment", Name: "api"}},
+		}
+
+		// When converted
+		result := kustomization.ToFluxKustomization("ns", "src", []Source{}, constants.GitopsModePull)
+
+		// Then the explicit wait is kept
+		if !result.Spec.Wait {
+			t.Error("Expected explicit wait to be kept")
+		}
+	})
+
+	t.Run("HealthChecksUnsetByDefault", func(t *testing.T) {
+		// Given a kustomization with no health checks
+		kustomization := &Kustomization{Name: "k", Path: "p"}
+
+		// When converted
+		result := kustomization.ToFluxKustomization("ns", "src", []Source{}, constants.GitopsModePull)
+
+		// Then neither field is emitted and wait keeps its default
+		if result.Spec.HealthChecks != nil || result.Spec.HealthCheckExprs != nil {
+			t.Errorf("Expected no health checks, got %+v / %+v", result.Spec.HealthChecks, result.Spec.HealthCheckExprs)
+		}
+		if result.Spec.Wait != constants.DefaultFluxKustomizationWait {
+			t.Errorf("Expected default wait %v, got %v", constants.DefaultFluxKustomizationWait, result.Spec.Wait)
+		}
+	})
+}
+
+func TestKustomization_ToArgoApplication(t *testing.T) {
+	repository := Repository{Url: "https://github.com/example/blueprint.git", Ref: Reference{Branch: "main"}}
+	sources := []Source{
+		{Name: "core", Url: "oci://ghcr.io/windsorcli/core:v0.6.0"},
+		{Name: "charts", Url: "https://charts.example.com", Kind: SourceKindHelm},
+	}
+	nested := func(obj map[string]any, fields ...string) any {
+		var current any = obj
+		for _, field := range fields {
+			m, ok := current.(map[string]any)
+			if !ok {
+				return nil
+			}
+			current = m[field]
+		}
+		return current
+	}
+
+	t.Run("MapsSourcePathAndKustomizeFields", func(t *testing.T) {
+		// Given a kustomization on the blueprint repository with components, a patch and a target namespace
+		kustomization := &Kustomization{
+			Name:            "ingress",
+			Components:      []string{"nginx"},
+			TargetNamespace: "system-ingress",
+			Patches:         []BlueprintPatch{{Patch: "- op: add", Target: &kustomize.Selector{Kind: "Deployment", Name: "nginx"}}},
+		}
+
+		// When converted in wave 2
+		app, err := kustomization.ToArgoApplication("argocd", "local", repository, sources, 2)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the source is the blueprint repository at the Flux path
+		if nested(app, "spec", "source", "repoURL") != repository.Url || nested(app, "spec", "source", "targetRevision") != "main" || nested(app, "spec", "source", "path") != "kustomize/ingress" {
+			t.Errorf("Unexpected source %v", nested(app, "spec", "source"))
+		}
+		// And the kustomize fields and destination carry the components, patch and namespace
+		if got := nested(app, "spec", "source", "kustomize", "components"); !reflect.DeepEqual(got, []any{"nginx"}) {
+			t.Errorf("Expected components [nginx], got %v", got)
+		}
+		if got := nested(app, "spec", "source", "kustomize", "patches").([]any); len(got) != 1 || nested(got[0].(map[string]any), "target", "kind") != "Deployment" {
+			t.Errorf("Expected the Deployment patch, got %v", got)
+		}
+		if nested(app, "spec", "source", "kustomize", "namespace") != "system-ingress" || nested(app, "spec", "destination", "namespace") != "system-ingress" {
+			t.Errorf("Expected target namespace system-ingress, got %v", nested(app, "spec"))
+		}
+		// And the wave, pruning and cascade-delete finalizer are set
+		if nested(app, "metadata", "annotations", ArgoCDSyncWaveAnnotation) != "2" {
+			t.Errorf("Expected sync wave 2, got %v", nested(app, "metadata", "annotations"))
+		}
+		if nested(app, "spec", "syncPolicy", "automated", "prune") != true {
+			t.Errorf("Expected automated prune, got %v", nested(app, "spec", "syncPolicy"))
+		}
+		if !reflect.DeepEqual(nested(app, "metadata", "finalizers"), []any{"resources-finalizer.argocd.argoproj.io"}) {
+			t.Errorf("Expected resources finalizer, got %v", nested(app, "metadata", "finalizers"))
+		}
+	})
+
+	t.Run("SplitsOCITagIntoRevision", func(t *testing.T) {
+		// Given a kustomization on an OCI source with a tagged URL
+		kustomization := &Kustomization{Name: "dns", Source: "core", Path: "dns"}
+
+		// When converted
+		app, err := kustomization.ToArgoApplication("argocd", "local", repository, sources, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the tag moves to the revision
+		if nested(app, "spec", "source", "repoURL") != "oci://ghcr.io/windsorcli/core" || nested(app, "spec", "source", "targetRevision") != "v0.6.0" {
+			t.Errorf("Unexpected source %v", nested(app, "spec", "source"))
+		}
+	})
+
+	t.Run("MapsSyncPolicyFields", func(t *testing.T) {
+		// Given a kustomization that does not prune, forces and overrides its timings
+		prune, force := false, true
+		kustomization := &Kustomization{
+			Name: "db", Prune: &prune, Force: &force, Destroy: boolExprPtr(false),
+			RetryInterval: &DurationString{Duration: time.Minute},
+			Timeout:       &DurationString{Duration: 20 * time.Minute},
+		}
+
+		// When converted
+		app, err := kustomization.ToArgoApplication("argocd", "local", repository, sources, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then pruning is off, the replace is forced, the backoff follows the timings and no finalizer is set
+		if nested(app, "spec", "syncPolicy", "automated", "prune") != false {
+			t.Errorf("Expected prune off, got %v", nested(app, "spec", "syncPolicy"))
+		}
+		if !reflect.DeepEqual(nested(app, "spec", "syncPolicy", "syncOptions"), []any{"Force=true", "Replace=true"}) {
+			t.Errorf("Expected forced replace, got %v", nested(app, "spec", "syncPolicy", "syncOptions"))
+		}
+		if nested(app, "spec", "syncPolicy", "retry", "backoff", "duration") != "1m0s" || nested(app, "spec", "syncPolicy", "retry", "backoff", "maxDuration") != "20m0s" {
+			t.Errorf("Unexpected backoff %v", nested(app, "spec", "syncPolicy", "retry"))
+		}
+		if nested(app, "metadata", "finalizers") != nil {
+			t.Errorf("Expected no finalizer when destroy is false, got %v", nested(app, "metadata", "finalizers"))
+		}
+	})
+
+	t.Run("SubstitutionsSelectPlugin", func(t *testing.T) {
+		// Given a kustomization with substitutions over a blueprint-level ConfigMap, and a component
+		kustomization := &Kustomization{Name: "dns", Components: []string{"coredns"}, Substitutions: map[string]string{"DOMAIN": "override"}}
+		configMaps := map[string]map[string]string{"values-common": {"DOMAIN": "common", "REGION": "eu"}}
+
+		// When converted
+		app, err := kustomization.ToArgoApplication("argocd", "local", repository, sources, 0, configMaps)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the substitution plugin carries the merged values, with the kustomization's winning, and the components
+		plugin, _ := nested(app, "spec", "source", "plugin").(map[string]any)
+		if plugin == nil || plugin["name"] != ArgoCDSubstitutionPlugin {
+			t.Fatalf("Expected the substitution plugin, got %v", nested(app, "spec", "source"))
+		}
+		wantEnv := []any{map[string]any{"name": "DOMAIN", "value": "override"}, map[string]any{"name": "REGION", "value": "eu"}}
+		if !reflect.DeepEqual(plugin["env"], wantEnv) {
+			t.Errorf("Expected env %v, got %v", wantEnv, plugin["env"])
+		}
+		wantParams := []any{map[string]any{"name": "components", "array": []any{"coredns"}}}
+		if !reflect.DeepEqual(plugin["parameters"], wantParams) {
+			t.Errorf("Expected parameters %v, got %v", wantParams, plugin["parameters"])
+		}
+		if nested(app, "spec", "source", "kustomize") != nil {
+			t.Error("Expected no kustomize source alongside the plugin")
+		}
+	})
+
+	t.Run("PluginCarriesTargetNamespace", func(t *testing.T) {
+		// Given a kustomization with substitutions and a target namespace
+		kustomization := &Kustomization{Name: "dns", TargetNamespace: "system-dns", Substitutions: map[string]string{"DOMAIN": "test"}}
+
+		// When converted
+		app, err := kustomization.ToArgoApplication("argocd", "local", repository, sources, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the plugin is given the namespace to set on the build, as the kustomize source would
+		wantParams := []any{
+			map[string]any{"name": "components", "array": []any{}},
+			map[string]any{"name": "namespace", "string": "system-dns"},
+		}
+		if got := nested(app, "spec", "source", "plugin", "parameters"); !reflect.DeepEqual(got, wantParams) {
+			t.Errorf("Expected parameters %v, got %v", wantParams, got)
+		}
+		if nested(app, "spec", "destination", "namespace") != "system-dns" {
+			t.Errorf("Expected destination namespace system-dns, got %v", nested(app, "spec", "destination"))
+		}
+	})
+
+	t.Run("CrdLayerNeverSubstitutes", func(t *testing.T) {
+		// Given the CRD layer and a blueprint-level ConfigMap
+		kustomization := &Kustomization{Name: CrdLayerName}
+
+		// When converted
+		app, err := kustomization.ToArgoApplication("argocd", "local", repository, sources, 0, map[string]map[string]string{"values-common": {"A": "b"}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then it builds with plain kustomize
+		if nested(app, "spec", "source", "plugin") != nil {
+			t.Error("Expected the CRD layer not to use the substitution plugin")
+		}
+	})
+
+	t.Run("ErrorForUnsupportedSource", func(t *testing.T) {
+		// Given a kustomization on a Helm repository source
+		kustomization := &Kustomization{Name: "charts", Source: "charts"}
+
+		// When converted
+		_, err := kustomization.ToArgoApplication("argocd", "local", repository, sources, 0)
+
+		// Then it fails naming the source kind
+		if err == nil || !strings.Contains(err.Error(), "helm source") {
+			t.Errorf("Expected helm source error, got %v", err)
+		}
+	})
+}
+
+func TestBlueprint_ToArgoSyncApplication(t *testing.T) {
+	t.Run("SyncsTreeFromBlueprintRepository", func(t *testing.T) {
+		// Given a blueprint with a repository
+		blueprint := &Blueprint{
+			Metadata:   Metadata{Name: "local"},
+			Repository: Repository{Url: "https://github.com/example/blueprint.git", Ref: Reference{Branch: "main"}},
+		}
+
+		// When the root Application is built for a rendered tree
+		app, err := blueprint.ToArgoSyncApplication("windsor-gitops-sync", "argocd", "clusters/local/kubernetes")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then it syncs the tree from the repository with pruning and no cascade-delete finalizer
+		spec := app["spec"].(map[string]any)
+		source := spec["source"].(map[string]any)
+		if source["repoURL"] != blueprint.Repository.Url || source["targetRevision"] != "main" || source["path"] != "clusters/local/kubernetes" {
+			t.Errorf("Unexpected source %v", source)
+		}
+		if automated := spec["syncPolicy"].(map[string]any)["automated"].(map[string]any); automated["prune"] != true {
+			t.Errorf("Expected automated prune, got %v", automated)
+		}
+		if _, ok := app["metadata"].(map[string]any)["finalizers"]; ok {
+			t.Error("Expected no finalizer on the root Application")
+		}
+	})
+
+	t.Run("ErrorWithoutRepository", func(t *testing.T) {
+		// Given a blueprint without a repository
+		blueprint := &Blueprint{Metadata: Metadata{Name: "local"}}
+
+		// When the root Application is built
+		_, err := blueprint.ToArgoSyncApplication("windsor-gitops-sync", "argocd", "clusters/local/kubernetes")
+
+		// Then it fails
+		if err == nil || !strings.Contains(err.Error(), "no repository") {
+			t.Errorf("Expected missing repository error, got %v", err)
+		}
+	})
+}
+
+func TestBlueprint_SyncWaves(t *testing.T) {
+	t.Run("DerivesWavesFromDependencyDepth", func(t *testing.T) {
+		// Given a chain, a fan-in, a cross-namespace dependency and a destroy-only kustomization
+		destroyOnly := true
+		blueprint := &Blueprint{Kustomizations: []Kustomization{
+			{Name: "crds"},
+			{Name: "cert-manager", DependsOn: []string{"crds"}},
+			{Name: "ingress", DependsOn: []string{"cert-manager", "crds"}},
+			{Name: "app", DependsOn: []string{"apps/ingress", "missing"}},
+			{Name: "cleanup", DestroyOnly: &destroyOnly},
+		}}
+
+		// When the sync waves are computed
+		waves := blueprint.SyncWaves()
+
+		// Then each kustomization is one wave after its deepest dependency and destroy-only ones are left out
+		want := map[string]int{"crds": 0, "cert-manager": 1, "ingress": 2, "app": 3}
+		if !reflect.DeepEqual(waves, want) {
+			t.Errorf("Expected %v, got %v", want, waves)
+		}
+	})
+}
+
+func TestKustomization_HealthChecks_MergeAndRemove(t *testing.T) {
+	dbCheck := HealthCheck{APIVersion: "postgresql.cnpg.io/v1", Kind: "Cluster", Name: "db"}
+	apiCheck := HealthCheck{Kind: "Deployment", Name: "api"}
+	certExpr := HealthCheckExpr{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Current: "status.conditions.all(c, c.type == 'Ready' && c.status == 'True')"}
+
+	t.Run("OverlayHealthChecksAppendWithoutDuplicates", func(t *testing.T) {
+		// Given a base with one health check and an overlay repeating it and adding another
+		base := Kustomization{Name: "k", HealthChecks: []HealthCheck{dbCheck}}
+		overlay := Kustomization{Name: "k", HealthChecks: []HealthCheck{dbCheck, apiCheck}}
+
+		// When merged
+		merged := MergeKustomizationFields(base, overlay)
+
+		// Then both checks are present once, base first
+		if len(merged.HealthChecks) != 2 || merged.HealthChecks[0] != dbCheck || merged.HealthChecks[1] != apiCheck {
+			t.Errorf("Expected db then api health checks, got %+v", merged.HealthChecks)
+		}
+	})
+
+	t.Run("OverlayExprReplacesSameKind", func(t *testing.T) {
+		// Given a base rule for Certificate and an overlay rule for the same kind
+		base := Kustomization{Name: "k", HealthCheckExprs: []HealthCheckExpr{certExpr}}
+		replacement := certExpr
+		replacement.Current = "status.ready"
+		overlay := Kustomization{Name: "k", HealthCheckExprs: []HealthCheckExpr{replacement}}
+
+		// When merged
+		merged := MergeKustomizationFields(base, overlay)
+
+		// Then the overlay rule replaces the base rule and the base slice is untouched
+		if len(merged.HealthCheckExprs) != 1 || merged.HealthCheckExprs[0].Current != "status.ready" {
+			t.Errorf("Expected the overlay Certificate rule, got %+v", merged.HealthCheckExprs)
+		}
+		if base.HealthCheckExprs[0].Current != certExpr.Current {
+			t.Errorf("Expected base rule unchanged, got %+v", base.HealthCheckExprs[0])
+		}
+	})
+
+	t.Run("RemoveKustomizationDropsHealthChecks", func(t *testing.T) {
+		// Given a blueprint kustomization with two health checks and a rule
+		bp := &Blueprint{Kustomizations: []Kustomization{{
+			Name:             "k",
+			HealthChecks:     []HealthCheck{dbCheck, apiCheck},
+			HealthCheckExprs: []HealthCheckExpr{certExpr},
+		}}}
+
+		// When a removal names one check and the rule's kind
+		err := bp.RemoveKustomization(Kustomization{
+			Name:             "k",
+			HealthChecks:     []HealthCheck{apiCheck},
+			HealthCheckExprs: []HealthCheckExpr{{APIVersion: "cert-manager.io/v1", Kind: "Certificate"}},
+		})
+
+		// Then only the other check remains
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		k := bp.Kustomizations[0]
+		if len(k.HealthChecks) != 1 || k.HealthChecks[0] != dbCheck || len(k.HealthCheckExprs) != 0 {
+			t.Errorf("Expected only the db check left, got %+v / %+v", k.HealthChecks, k.HealthCheckExprs)
+		}
+	})
 }
 
 func TestKustomization_Decryption_DeepCopyAndMerge(t *testing.T) {
@@ -0,0 +1,31 @@
+package v1alpha1
+
+// PolicyFile represents a policy file containing deny rules evaluated against Terraform and Flux plans.
+// Policy files are stored in contexts/_template/policies/*.yaml for the blueprint and in
+// contexts/<context>/policies/*.yaml for a single context. A context policy with the same name as a
+// blueprint policy replaces it.
+type PolicyFile struct {
+	// Policies is the list of policies defined in the file.
+	Policies []Policy `yaml:"policies"`
+}
+
+// Policy is a single deny rule. It is evaluated once for every planned Terraform resource change and
+// every Kustomization and Kubernetes resource change in a plan. When the optional When expression is
+// true and the Deny expression is true, the change violates the policy.
+type Policy struct {
+	// Name is the unique identifier for this policy. Required.
+	Name string `yaml:"name"`
+
+	// When is an optional expression that limits which changes the policy applies to.
+	// An empty When applies the policy to every change.
+	// Example: layer == "terraform" && type == "aws_db_instance"
+	When string `yaml:"when,omitempty"`
+
+	// Deny is the expression that flags a change as a violation. It must evaluate to a boolean. Required.
+	// Example: action == "delete" || action == "replace"
+	Deny string `yaml:"deny"`
+
+	// Message is shown when the policy denies a change. It may contain ${} expressions that are
+	// evaluated against the same change, e.g. "${address} may not be deleted".
+	Message string `yaml:"message,omitempty"`
+}
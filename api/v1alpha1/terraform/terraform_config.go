@@ -2,9 +2,10 @@ package terraform
 
 // TerraformConfig represents the Terraform configuration
 type TerraformConfig struct {
-	Enabled *bool          `yaml:"enabled,omitempty"`
-	Backend *BackendConfig `yaml:"backend,omitempty"`
-	Lock    *LockConfig    `yaml:"lock,omitempty"`
+	Enabled   *bool            `yaml:"enabled,omitempty"`
+	Backend   *BackendConfig   `yaml:"backend,omitempty"`
+	Lock      *LockConfig      `yaml:"lock,omitempty"`
+	Snapshots *SnapshotsConfig `yaml:"snapshots,omitempty"`
 }
 
 // LockConfig controls how terraform's per-state lock is acquired across init,
@@ -14,12 +15,28 @@ type LockConfig struct {
 	Timeout *string `yaml:"timeout,omitempty"`
 }
 
+// SnapshotsConfig controls the per-component state snapshots Windsor pulls before apply,
+// destroy, and state migration change anything. Enabled defaults to true; Keep caps how
+// many of the automatic apply and destroy snapshots are retained per component, oldest
+// removed first, and the consumer applies a default when unset.
+type SnapshotsConfig struct {
+	Enabled *bool `yaml:"enabled,omitempty"`
+	Keep    *int  `yaml:"keep,omitempty"`
+}
+
+// BackendConfig selects the terraform backend and carries its settings. Typed
+// blocks cover the backends Windsor models directly; the other backends
+// terraform ships (consul, cos, http, oss, pg, remote) take their settings
+// from the free-form Config map, whose values may contain expressions such
+// as secret().
 type BackendConfig struct {
 	Type       string             `yaml:"type"`
 	S3         *S3Backend         `yaml:"s3,omitempty"`
 	Kubernetes *KubernetesBackend `yaml:"kubernetes,omitempty"`
 	Local      *LocalBackend      `yaml:"local,omitempty"`
 	AzureRM    *AzureRMBackend    `yaml:"azurerm,omitempty"`
+	GCS        *GCSBackend        `yaml:"gcs,omitempty"`
+	Config     map[string]any     `yaml:"config,omitempty"`
 	Prefix     *string            `yaml:"prefix,omitempty"`
 }
 
@@ -133,9 +150,27 @@ type AzureRMBackend struct {
 	OidcTokenFilePath              *string `yaml:"oidc_token_file_path,omitempty"`
 }
 
+// GCSBackend represents the configuration for the Google Cloud Storage backend.
+// Prefix is a base path inside Bucket; each component's state is stored beneath
+// it at <prefix>/<component path>. Credentials default to the context's GCP
+// configuration when unset.
+// https://developer.hashicorp.com/terraform/language/backend/gcs#configuration-variables
+type GCSBackend struct {
+	Bucket                             *string   `yaml:"bucket,omitempty"`
+	Prefix                             *string   `yaml:"prefix,omitempty"`
+	Credentials                        *string   `yaml:"credentials,omitempty"`
+	AccessToken                        *string   `yaml:"access_token,omitempty"` // #nosec G117 - legitimate credential field for terraform backend
+	ImpersonateServiceAccount          *string   `yaml:"impersonate_service_account,omitempty"`
+	ImpersonateServiceAccountDelegates *[]string `yaml:"impersonate_service_account_delegates,omitempty"`
+	EncryptionKey                      *string   `yaml:"encryption_key,omitempty"`
+	KmsEncryptionKey                   *string   `yaml:"kms_encryption_key,omitempty"`
+	StorageCustomEndpoint              *string   `yaml:"storage_custom_endpoint,omitempty"`
+}
+
 // Merge performs a simple merge of the current TerraformConfig with another TerraformConfig.
-// Lock is merged field-by-field rather than swapped so that an overlay carrying `lock: {}`
-// (non-nil LockConfig with Timeout nil) does not silently blank out a base timeout.
+// Lock and Snapshots are merged field-by-field rather than swapped so that an overlay
+// carrying `lock: {}` (non-nil LockConfig with Timeout nil) does not silently blank out a
+// base timeout, and likewise for snapshot settings.
 func (base *TerraformConfig) Merge(overlay *TerraformConfig) {
 	if overlay.Enabled != nil {
 		base.Enabled = overlay.Enabled
@@ -151,12 +186,23 @@ func (base *TerraformConfig) Merge(overlay *TerraformConfig) {
 			base.Lock.Timeout = overlay.Lock.Timeout
 		}
 	}
+	if overlay.Snapshots != nil {
+		if base.Snapshots == nil {
+			base.Snapshots = &SnapshotsConfig{}
+		}
+		if overlay.Snapshots.Enabled != nil {
+			base.Snapshots.Enabled = overlay.Snapshots.Enabled
+		}
+		if overlay.Snapshots.Keep != nil {
+			base.Snapshots.Keep = overlay.Snapshots.Keep
+		}
+	}
 }
 
-// Copy creates a copy of the TerraformConfig object. Lock is deep-copied
-// (struct + Timeout string pointer) because Merge mutates LockConfig in-place;
+// Copy creates a copy of the TerraformConfig object. Lock and Snapshots are
+// deep-copied (struct + field pointers) because Merge mutates them in-place;
 // without the deep copy, a Copy()+Merge() chain would corrupt the original
-// through the shared *LockConfig pointer. Enabled and Backend remain shallow,
+// through the shared pointer. Enabled and Backend remain shallow,
 // matching the existing convention — neither is mutated in-place by Merge.
 func (c *TerraformConfig) Copy() *TerraformConfig {
 	if c == nil {
@@ -174,5 +220,17 @@ func (c *TerraformConfig) Copy() *TerraformConfig {
 		}
 		out.Lock = &lockCopy
 	}
+	if c.Snapshots != nil {
+		snapshotsCopy := *c.Snapshots
+		if c.Snapshots.Enabled != nil {
+			e := *c.Snapshots.Enabled
+			snapshotsCopy.Enabled = &e
+		}
+		if c.Snapshots.Keep != nil {
+			k := *c.Snapshots.Keep
+			snapshotsCopy.Keep = &k
+		}
+		out.Snapshots = &snapshotsCopy
+	}
 	return out
 }
@@ -121,6 +121,41 @@ func TestTerraformConfig_Merge(t *testing.T) {
 	})
 }
 
+func TestTerraformConfig_MergeSnapshots(t *testing.T) {
+	t.Run("MergeKeepsBaseKeepWhenOverlayOnlyDisables", func(t *testing.T) {
+		// Given a base that keeps 5 snapshots and an overlay that only disables them
+		keep := 5
+		base := &TerraformConfig{Snapshots: &SnapshotsConfig{Keep: &keep}}
+		overlay := &TerraformConfig{Snapshots: &SnapshotsConfig{Enabled: ptrBool(false)}}
+
+		// When merging
+		base.Merge(overlay)
+
+		// Then both settings survive on base
+		if base.Snapshots.Enabled == nil || *base.Snapshots.Enabled {
+			t.Fatalf("expected snapshots disabled, got %+v", base.Snapshots)
+		}
+		if base.Snapshots.Keep == nil || *base.Snapshots.Keep != 5 {
+			t.Fatalf("expected keep 5 to survive, got %+v", base.Snapshots)
+		}
+	})
+
+	t.Run("MergeInitialisesBaseSnapshotsWhenOnlyOverlayHasIt", func(t *testing.T) {
+		// Given a base with no snapshot settings
+		keep := 3
+		base := &TerraformConfig{}
+		overlay := &TerraformConfig{Snapshots: &SnapshotsConfig{Keep: &keep}}
+
+		// When merging
+		base.Merge(overlay)
+
+		// Then base.Snapshots is initialised from the overlay
+		if base.Snapshots == nil || base.Snapshots.Keep == nil || *base.Snapshots.Keep != 3 {
+			t.Fatalf("expected keep 3, got %+v", base.Snapshots)
+		}
+	})
+}
+
 func TestTerraformConfig_Copy(t *testing.T) {
 	t.Run("CopyWithNonNilValues", func(t *testing.T) {
 		original := &TerraformConfig{
@@ -200,6 +235,29 @@ func TestTerraformConfig_Copy(t *testing.T) {
 			t.Fatalf("copy did not pick up overlay timeout; got %+v", copied.Lock)
 		}
 	})
+
+	t.Run("CopyDeepCopiesSnapshots", func(t *testing.T) {
+		// Given a config with snapshot settings that gets copied, then merged with an overlay
+		keep := 5
+		original := &TerraformConfig{Snapshots: &SnapshotsConfig{Enabled: ptrBool(true), Keep: &keep}}
+		copied := original.Copy()
+		overlayKeep := 20
+		overlay := &TerraformConfig{Snapshots: &SnapshotsConfig{Keep: &overlayKeep}}
+
+		// When the merge runs against the copy
+		copied.Merge(overlay)
+
+		// Then the original is untouched and the copy carries the merged value
+		if original.Snapshots.Keep == nil || *original.Snapshots.Keep != 5 {
+			t.Fatalf("original Snapshots.Keep was mutated through the shared pointer; got %+v", original.Snapshots)
+		}
+		if copied.Snapshots.Keep == nil || *copied.Snapshots.Keep != 20 {
+			t.Fatalf("copy did not pick up overlay keep; got %+v", copied.Snapshots)
+		}
+		if copied.Snapshots.Enabled == nil || !*copied.Snapshots.Enabled {
+			t.Fatalf("copy lost enabled; got %+v", copied.Snapshots)
+		}
+	})
 }
 
 // Helper functions to create pointers for basic types
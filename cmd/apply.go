@@ -1,17 +1,29 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
 	"github.com/windsorcli/cli/pkg/provisioner/stacklock"
+	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
 	"github.com/windsorcli/cli/pkg/runtime/tools"
 )
 
-var applyWaitFlag bool  // Wait for kustomization resources to be ready after applying
-var applyPruneFlag bool // Remove kustomizations the blueprint no longer declares
+var applyWaitFlag bool      // Wait for kustomization resources to be ready after applying
+var applyPruneFlag bool     // Remove kustomizations the blueprint no longer declares
+var applyMaxConcurrency int // Maximum number of terraform components applied at once
+var applyPlanDir string     // Directory of saved terraform plans written by `windsor plan --out`
+var applyForce bool         // Apply even though blueprint resources are suspended
+var applyAllowDataLoss bool // Prune even when that deletes data-bearing objects
+
+var applyTargets []string        // Resource addresses to narrow a single-component apply to
+var applyReplace []string        // Resource addresses to force-replace in a single-component apply
+var applyRefreshOnly bool        // Reconcile a single component's state without changing resources
+var applyTerraformConfirm string // Component name acknowledging a partially converging targeted apply
 
 var applyCmd = &cobra.Command{
 	Use:   "apply",
@@ -20,13 +32,31 @@ var applyCmd = &cobra.Command{
 
 For workstation contexts, prefer 'windsor up' — it does the same work plus VM management.
 
-Pass --wait to block until kustomizations report ready. Pass --prune to also remove kustomizations the blueprint no longer declares, once the new set is Ready.`,
+Pass --wait to block until kustomizations report ready. Pass --prune to also remove kustomizations the blueprint no longer declares, once the new set is Ready. Every object they own is listed first as deleted, retained or orphaned, and the prune is refused when it would delete PersistentVolumeClaims or PersistentVolumes along with their data unless --allow-data-loss is passed.
+
+Pass --max-concurrency to apply independent terraform components in parallel. Components still wait for everything they depend on (dependsOn, terraform_output() references, and the backend tier); if one fails, components already running finish, nothing downstream of the failure starts, and the error lists what was applied, what failed, and what was skipped. Terraform output is captured rather than streamed while components run in parallel; a failed component's plan and apply output is printed when it fails.
+
+When the blueprint or context defines policies (policies/*.yaml), each terraform component's plan is checked before it is applied and the kustomization plans are checked before the blueprint is installed. A plan that violates a policy is refused and the violations are listed in the error. A kustomization that cannot be planned — its diff fails, or the flux or kustomize CLI is missing — is refused too, since its changes cannot be checked.
+
+Pass --plan with a directory written by 'windsor plan --out' to apply exactly the terraform plans saved there instead of planning again. The saved plans are applied one component at a time in dependency order. Apply is refused before anything runs if the plans were saved for another context or by another windsor version, if the composed blueprint changed, or if any component's inputs or module source changed since the plans were saved. Kustomizations are installed from the current blueprint as usual.
+
+Apply is refused when any blueprint kustomization or a HelmRelease it owns is suspended, for example by 'windsor suspend', and the suspensions are listed with who made them and why. Pass --force to apply anyway; suspended resources stay suspended and Flux does not reconcile them until they are resumed.
+
+When the context sets gitops.mode to push, the Flux objects are not applied directly. They are rendered as 'windsor render' would, without secrets or tfvars, and committed under gitops.path (default clusters/<context>) to the branch the blueprint repository's ref names. The commit message lists the blueprint sources, their versions and the changed files. The cluster then gets only the repository source and a root Kustomization that reconciles that directory, and secrets are placed directly as usual. Set gitops.review to push the commit to a windsor/<context>-<digest> branch instead; nothing is applied until it is merged, and the next apply places the secrets. A single kustomization cannot be applied on its own in push mode.
+
+When the context also sets gitops.engine to argocd, the committed tree holds an Argo CD Application per kustomization, in gitops.argocd.namespace (default argocd), for an existing Argo CD instance. In place of the Flux sync the cluster gets a root Application, windsor-gitops-sync, that syncs that directory, and an Application health check in argocd-cm so each sync wave waits for the Applications of the one before it to be healthy. Nothing is waited on; each declared secret must name its namespaces. The argocd engine is only supported in push mode.`,
 	Example: `# Apply everything and block until ready
 windsor apply --wait
 
+# Apply up to four independent terraform components at a time
+windsor apply --max-concurrency 4
+
 # Apply and remove kustomizations no longer declared
 windsor apply --prune
 
+# Apply the terraform plans saved by 'windsor plan --out'
+windsor apply --plan ./plan
+
 # Apply only the cluster terraform component
 windsor apply terraform cluster
 
@@ -64,10 +94,27 @@ windsor apply kustomize dns`,
 			fmt.Fprintln(cmd.ErrOrStderr(), "Warning: an upgrade is in progress or was interrupted for this context. apply will reconcile to the declared blueprint; run `windsor upgrade` to complete the version transition.")
 		}
 
+		if err := checkSuspensions(cmd, proj, blueprint, nil, applyForce); err != nil {
+			return err
+		}
+
+		proj.Provisioner.SetTerraformConcurrency(applyMaxConcurrency)
+
+		planDir := ""
+		if applyPlanDir != "" {
+			if planDir, err = filepath.Abs(applyPlanDir); err != nil {
+				return fmt.Errorf("error resolving plan directory: %w", err)
+			}
+		}
+
 		return stacklock.With(cmd.Context(), proj.Runtime, "apply", lockTimeout, func() error {
 			// 'apply' doesn't run the workstation prep that registers MakeApplyHook, so no
 			// onApply hooks fire and the halted return is always false. Ignore it.
-			if _, err := proj.Provisioner.Up(blueprint); err != nil {
+			if planDir != "" {
+				if err := proj.Provisioner.ApplySavedTerraformPlan(blueprint, planDir); err != nil {
+					return fmt.Errorf("error applying terraform: %w", err)
+				}
+			} else if _, err := proj.Provisioner.Up(blueprint); err != nil {
 				return fmt.Errorf("error applying terraform: %w", err)
 			}
 
@@ -86,6 +133,12 @@ windsor apply kustomize dns`,
 				return fmt.Errorf("error applying kustomize: %w", err)
 			}
 
+			// A change awaiting review has not reached the cluster, so there is nothing to wait
+			// for and nothing yet safe to prune.
+			if proj.Provisioner.AwaitingReview() {
+				return nil
+			}
+
 			// --prune removes kustomizations the blueprint no longer declares; wait for the
 			// desired set to be Ready first so migrated resources are adopted before a deletion.
 			// Without --prune, orphans are left in place and only reported.
@@ -94,7 +147,7 @@ windsor apply kustomize dns`,
 				return fmt.Errorf("error listing kustomizations to prune: %w", err)
 			}
 			if len(prunable) > 0 && !applyPruneFlag {
-				fmt.Fprintf(cmd.OutOrStdout(), "The following kustomizations are no longer declared; re-run with --prune to remove them:\n  %s\n", strings.Join(prunable, "\n  "))
+				fmt.Fprintf(cmd.OutOrStdout(), "The following kustomizations are no longer declared; re-run with --prune to remove them:\n  %s\n", strings.Join(kustomizationNames(prunable), "\n  "))
 			}
 			if (len(prunable) > 0 && applyPruneFlag) || applyWaitFlag {
 				if err := proj.Provisioner.Wait(cmd.Context(), blueprint); err != nil {
@@ -104,7 +157,7 @@ windsor apply kustomize dns`,
 			if len(prunable) == 0 || !applyPruneFlag {
 				return nil
 			}
-			return pruneOrphaned(cmd, proj, blueprint, prunable)
+			return pruneOrphaned(cmd, proj, blueprint, prunable, applyAllowDataLoss)
 		})
 	},
 }
@@ -113,12 +166,25 @@ var applyTerraformCmd = &cobra.Command{
 	Use:     "terraform <component>",
 	Aliases: []string{"tf"},
 	Short:   "Apply Terraform changes for a single component.",
-	Long:    `Run terraform apply for a single component. The <component> argument is required and must match a terraform component declared in the blueprint.`,
+	Long: `Run terraform apply for a single component. The <component> argument is required and must match a terraform component declared in the blueprint.
+
+Pass --target or --replace (each repeatable) with terraform resource addresses to narrow the apply to those resources or to force them to be recreated, and --refresh-only to reconcile the component's state with real infrastructure without changing any resources. The options are shown in the progress line.
+
+A targeted apply is checked against a plan of the whole component first. If that plan has changes outside the targets, the apply is refused and the pending changes are listed, since applying only the targets would leave the component partially converged. Pass --confirm with the component name to apply the targets anyway.`,
 	Example: `# Apply the cluster component
 windsor apply terraform cluster
 
 # Same, using the 'tf' alias
-windsor apply tf cluster`,
+windsor apply tf cluster
+
+# Recreate a single broken node
+windsor apply terraform cluster --replace 'aws_instance.node["worker-1"]'
+
+# Apply one DNS record while other changes in the component are pending
+windsor apply terraform dns --target aws_route53_record.api --confirm dns
+
+# Reconcile state with real infrastructure only
+windsor apply terraform cluster --refresh-only`,
 	Annotations: map[string]string{
 		"docs.seealso": "[`apply`](apply.md), [`plan terraform`](plan-terraform.md), [`destroy terraform`](destroy-terraform.md)",
 		"docs.source":  "cmd/apply.go",
@@ -127,11 +193,23 @@ windsor apply tf cluster`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		componentID := args[0]
+		if applyRefreshOnly && len(applyReplace) > 0 {
+			return fmt.Errorf("--replace cannot be combined with --refresh-only")
+		}
+		if applyTerraformConfirm != "" && applyTerraformConfirm != componentID {
+			return fmt.Errorf("confirmation failed: --confirm did not match %q", componentID)
+		}
+		opts := terraforminfra.ApplyOptions{
+			Targets:     applyTargets,
+			Replace:     applyReplace,
+			RefreshOnly: applyRefreshOnly,
+			Confirmed:   applyTerraformConfirm != "",
+		}
 
 		// `apply terraform <project>` only invokes terraform. Secrets backends are required
 		// because terraform can dereference 1Password / SOPS-encrypted values during plan/apply;
 		// docker, colima, and kubelogin are not exercised by this codepath.
-		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true})
+		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true, StackLock: true})
 		if err != nil {
 			return err
 		}
@@ -142,7 +220,11 @@ windsor apply tf cluster`,
 
 		blueprint := proj.Composer.BlueprintHandler.Generate()
 		return stacklock.With(cmd.Context(), proj.Runtime, "apply", lockTimeout, func() error {
-			if err := proj.Provisioner.Apply(blueprint, componentID); err != nil {
+			if err := proj.Provisioner.ApplyWithOptions(blueprint, componentID, opts); err != nil {
+				var partial *terraforminfra.PartialApplyError
+				if errors.As(err, &partial) {
+					return fmt.Errorf("error applying terraform for %s: %w; pass --confirm %s to apply the targets anyway", componentID, err, componentID)
+				}
 				return fmt.Errorf("error applying terraform for %s: %w", componentID, err)
 			}
 			return nil
@@ -156,7 +238,9 @@ var applyKustomizeCmd = &cobra.Command{
 	Short:   "Apply Flux kustomization(s) to the cluster.",
 	Long: `Apply a single Flux kustomization to the cluster by name, or all kustomizations when no argument is given.
 
-When a name is supplied with --wait, the wait scope is narrowed to only that kustomization.`,
+When a name is supplied with --wait, the wait scope is narrowed to only that kustomization.
+
+Apply is refused when a kustomization in scope or a HelmRelease it owns is suspended; pass --force to apply anyway.`,
 	Example: `# Apply all kustomizations
 windsor apply kustomize
 
@@ -177,7 +261,7 @@ windsor apply kustomize dns --wait`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// `apply kustomize` only talks to the cluster API and dereferences secrets; it does not
 		// invoke terraform or the local container runtime.
-		proj, err := prepareProject(cmd, tools.Requirements{Secrets: true, Kubelogin: true})
+		proj, err := prepareProject(cmd, tools.Requirements{Secrets: true, Kubelogin: true, StackLock: true})
 		if err != nil {
 			return err
 		}
@@ -202,6 +286,14 @@ windsor apply kustomize dns --wait`,
 			return fmt.Errorf("unresolved terraform_output() substitutions in scope; run `windsor apply` (or `windsor upgrade`) first so this apply doesn't overwrite a resolved ConfigMap value with raw expression text")
 		}
 
+		var suspendScope map[string]bool
+		if len(args) > 0 {
+			suspendScope = scope
+		}
+		if err := checkSuspensions(cmd, proj, blueprint, suspendScope, applyForce); err != nil {
+			return err
+		}
+
 		waitBlueprint := blueprint
 
 		return stacklock.With(cmd.Context(), proj.Runtime, "apply", lockTimeout, func() error {
@@ -266,7 +358,16 @@ func deferredSubstitutionsInScope(deferred map[string]bool, scope map[string]boo
 func init() {
 	applyCmd.Flags().BoolVar(&applyWaitFlag, "wait", false, "Wait for kustomization resources to be ready.")
 	applyCmd.Flags().BoolVar(&applyPruneFlag, "prune", false, "Remove kustomizations the blueprint no longer declares.")
+	applyCmd.Flags().IntVar(&applyMaxConcurrency, "max-concurrency", 1, "Maximum number of independent terraform components to apply at once.")
+	applyCmd.Flags().StringVar(&applyPlanDir, "plan", "", "Apply the terraform plans saved in this directory by 'windsor plan --out'.")
+	applyCmd.Flags().BoolVar(&applyForce, "force", false, "Apply even though blueprint resources are suspended.")
+	applyCmd.Flags().BoolVar(&applyAllowDataLoss, "allow-data-loss", false, "With --prune, delete PersistentVolumeClaims and PersistentVolumes and their data.")
+	applyTerraformCmd.Flags().StringArrayVar(&applyTargets, "target", nil, "Resource address to limit the apply to. May be repeated.")
+	applyTerraformCmd.Flags().StringArrayVar(&applyReplace, "replace", nil, "Resource address to force replacement of. May be repeated.")
+	applyTerraformCmd.Flags().BoolVar(&applyRefreshOnly, "refresh-only", false, "Update state to match real infrastructure without changing resources.")
+	applyTerraformCmd.Flags().StringVar(&applyTerraformConfirm, "confirm", "", "Component name to confirm a targeted apply that leaves other changes pending. Must match the component exactly; mismatches abort.")
 	applyKustomizeCmd.Flags().BoolVar(&applyWaitFlag, "wait", false, "Wait for kustomization resources to be ready.")
+	applyKustomizeCmd.Flags().BoolVar(&applyForce, "force", false, "Apply even though the kustomization's resources are suspended.")
 	applyCmd.AddCommand(applyTerraformCmd)
 	applyCmd.AddCommand(applyKustomizeCmd)
 	rootCmd.AddCommand(applyCmd)
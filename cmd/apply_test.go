@@ -10,6 +10,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -206,8 +207,8 @@ func TestApplyCmd_Prune(t *testing.T) {
 		t.Cleanup(func() { applyPruneFlag = false })
 		// Given a reconcile that would prune a kustomization
 		mocks := setupApplyTest(t)
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
-			return []string{"old-thing"}, nil
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}}, nil
 		}
 		pruned := false
 		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
@@ -240,8 +241,8 @@ func TestApplyCmd_Prune(t *testing.T) {
 		t.Cleanup(func() { applyPruneFlag = false })
 		// Given the same pending prune
 		mocks := setupApplyTest(t)
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
-			return []string{"old-thing"}, nil
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}}, nil
 		}
 		pruned := false
 		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
@@ -265,11 +266,84 @@ func TestApplyCmd_Prune(t *testing.T) {
 		}
 	})
 
+	t.Run("RefusesPruneThatDeletesData", func(t *testing.T) {
+		t.Cleanup(func() { applyPruneFlag, applyAllowDataLoss = false, false })
+		// Given a pending prune whose kustomization owns a claim that would be deleted with its data
+		mocks := setupApplyTest(t)
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-db"}}, nil
+		}
+		mocks.KubernetesManager.AnalyzePruneFunc = func(names []string, namespaces map[string]string) ([]kubernetes.PruneObject, error) {
+			return []kubernetes.PruneObject{
+				{InventoryEntry: kubernetes.InventoryEntry{Kind: "ConfigMap", Namespace: "db", Name: "settings"}, Kustomization: "old-db", Disposition: kubernetes.PruneRetain, Reason: "kustomize.toolkit.fluxcd.io/prune: disabled"},
+				{InventoryEntry: kubernetes.InventoryEntry{Kind: "PersistentVolumeClaim", Namespace: "db", Name: "data"}, Kustomization: "old-db", Disposition: kubernetes.PruneDelete, DataBearing: true},
+			}, nil
+		}
+		pruned := false
+		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+			pruned = true
+			return nil
+		}
+		proj := newApplyAllProject(mocks)
+
+		// When applying with --prune
+		var stdout bytes.Buffer
+		cmd := createTestApplyCmd()
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--prune"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the classification is shown, the prune is refused and nothing is deleted
+		if err == nil || !strings.Contains(err.Error(), "PersistentVolumeClaim db/data") || !strings.Contains(err.Error(), "--allow-data-loss") {
+			t.Errorf("Expected a data-loss refusal naming the claim, got %v", err)
+		}
+		if !strings.Contains(stdout.String(), "retain ConfigMap db/settings (old-db): kustomize.toolkit.fluxcd.io/prune: disabled") {
+			t.Errorf("Expected the retained object listed, got: %q", stdout.String())
+		}
+		if pruned {
+			t.Error("Expected prune to be refused")
+		}
+	})
+
+	t.Run("PrunesDataWithAllowDataLoss", func(t *testing.T) {
+		t.Cleanup(func() { applyPruneFlag, applyAllowDataLoss = false, false })
+		// Given the same data-bearing prune
+		mocks := setupApplyTest(t)
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-db"}}, nil
+		}
+		mocks.KubernetesManager.AnalyzePruneFunc = func(names []string, namespaces map[string]string) ([]kubernetes.PruneObject, error) {
+			return []kubernetes.PruneObject{
+				{InventoryEntry: kubernetes.InventoryEntry{Kind: "PersistentVolumeClaim", Namespace: "db", Name: "data"}, Kustomization: "old-db", Disposition: kubernetes.PruneDelete, DataBearing: true},
+			}, nil
+		}
+		pruned := false
+		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+			pruned = true
+			return nil
+		}
+		proj := newApplyAllProject(mocks)
+
+		// When applying with --prune --allow-data-loss
+		cmd := createTestApplyCmd()
+		cmd.SetArgs([]string{"--prune", "--allow-data-loss"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected the prune to proceed, got %v", err)
+		}
+
+		// Then the prune runs
+		if !pruned {
+			t.Error("Expected prune to run with --allow-data-loss")
+		}
+	})
+
 	t.Run("NoWaitOrPruneWhenNothingOrphaned", func(t *testing.T) {
 		// Given a reconcile that prunes nothing, with --wait unset
 		mocks := setupApplyTest(t)
 		listed := false
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
 			listed = true
 			return nil, nil
 		}
@@ -298,6 +372,96 @@ func TestApplyCmd_Prune(t *testing.T) {
 	})
 }
 
+func TestApplyCmd_Suspensions(t *testing.T) {
+	createTestApplyCmd := func() *cobra.Command { return makeApplyTestCmd(applyCmd) }
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	suspended := func(names []string, namespaces map[string]string) ([]kubernetes.SuspendedObject, error) {
+		return []kubernetes.SuspendedObject{{
+			Kind: "Kustomization", Namespace: "system-gitops", Name: "dns", Kustomization: "dns",
+			Suspension: &kubernetes.Suspension{By: "alice@laptop", Reason: "hotfixing coredns", At: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		}}, nil
+	}
+
+	t.Run("RefusesWhenSuspended", func(t *testing.T) {
+		// Given a suspended kustomization recorded by windsor
+		mocks := setupApplyTest(t)
+		seedKubeconfig(t, mocks)
+		mocks.KubernetesManager.GetSuspendedObjectsFunc = suspended
+		upCalled := false
+		mocks.TerraformStack.UpFunc = func(*blueprintv1alpha1.Blueprint, ...func(string) (bool, error)) (bool, error) {
+			upCalled = true
+			return false, nil
+		}
+		proj := newApplyAllProject(mocks)
+
+		// When applying without --force
+		cmd := createTestApplyCmd()
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then apply is refused before terraform runs, naming the suspension and how to lift it
+		if err == nil {
+			t.Fatal("Expected apply to be refused, got nil")
+		}
+		for _, want := range []string{"Kustomization system-gitops/dns", "alice@laptop", "hotfixing coredns", "windsor resume kustomize dns", "--force"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error to contain %q, got %v", want, err)
+			}
+		}
+		if upCalled {
+			t.Error("Expected terraform not to run")
+		}
+	})
+
+	t.Run("ForceWarnsAndProceeds", func(t *testing.T) {
+		t.Cleanup(func() { applyForce = false })
+		// Given a suspended kustomization
+		mocks := setupApplyTest(t)
+		seedKubeconfig(t, mocks)
+		mocks.KubernetesManager.GetSuspendedObjectsFunc = suspended
+		proj := newApplyAllProject(mocks)
+
+		// When applying with --force
+		var stderr bytes.Buffer
+		cmd := createTestApplyCmd()
+		cmd.SetErr(&stderr)
+		cmd.SetArgs([]string{"--force"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then apply proceeds and warns that the kustomization stays suspended
+		if err != nil {
+			t.Fatalf("Expected apply to proceed with --force, got %v", err)
+		}
+		if !strings.Contains(stderr.String(), "Flux will not reconcile them") || !strings.Contains(stderr.String(), "dns") {
+			t.Errorf("Expected a suspension warning on stderr, got: %q", stderr.String())
+		}
+	})
+
+	t.Run("IgnoresUnreadableCluster", func(t *testing.T) {
+		// Given a cluster whose suspensions cannot be read
+		mocks := setupApplyTest(t)
+		seedKubeconfig(t, mocks)
+		mocks.KubernetesManager.GetSuspendedObjectsFunc = func(names []string, namespaces map[string]string) ([]kubernetes.SuspendedObject, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+		proj := newApplyAllProject(mocks)
+
+		// When applying
+		cmd := createTestApplyCmd()
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the guard does not block the apply
+		if err != nil {
+			t.Errorf("Expected apply to proceed, got %v", err)
+		}
+	})
+}
+
 func TestApplyCmd(t *testing.T) {
 	createTestApplyCmd := func() *cobra.Command { return makeApplyTestCmd(applyCmd) }
 
@@ -340,6 +504,67 @@ func TestApplyCmd(t *testing.T) {
 		}
 	})
 
+	t.Run("PlanFlagAppliesSavedPlans", func(t *testing.T) {
+		t.Cleanup(func() { applyPlanDir = "" })
+		// Given terraform plans saved for the current blueprint
+		mocks := setupApplyTest(t)
+		mocks.TerraformStack.SavePlansFunc = func(bp *blueprintv1alpha1.Blueprint, dir string) ([]terraforminfra.TerraformComponentPlan, []terraforminfra.SavedPlan, error) {
+			return nil, []terraforminfra.SavedPlan{{ComponentID: "cluster", PlanFile: "cluster.tfplan"}}, nil
+		}
+		var applied []terraforminfra.SavedPlan
+		mocks.TerraformStack.ApplySavedPlansFunc = func(bp *blueprintv1alpha1.Blueprint, dir string, plans []terraforminfra.SavedPlan) error {
+			applied = plans
+			return nil
+		}
+		upCalled := false
+		mocks.TerraformStack.UpFunc = func(bp *blueprintv1alpha1.Blueprint, onApply ...func(id string) (bool, error)) (bool, error) {
+			upCalled = true
+			return false, nil
+		}
+		proj := newApplyAllProject(mocks)
+		planDir := t.TempDir()
+		if _, err := proj.Provisioner.SaveTerraformPlan(mocks.BlueprintHandler.Generate(), planDir); err != nil {
+			t.Fatalf("failed to save plan: %v", err)
+		}
+
+		// When applying with --plan
+		cmd := createTestApplyCmd()
+		ctx := context.WithValue(context.Background(), projectOverridesKey, proj)
+		cmd.SetArgs([]string{"--plan", planDir})
+		cmd.SetContext(ctx)
+		err := cmd.Execute()
+
+		// Then the saved plans are applied instead of a fresh terraform run
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if upCalled {
+			t.Error("Expected Up not to run when applying saved plans")
+		}
+		if len(applied) != 1 || applied[0].ComponentID != "cluster" {
+			t.Errorf("Expected the saved plan to be applied, got %+v", applied)
+		}
+	})
+
+	t.Run("PlanFlagRefusesMissingManifest", func(t *testing.T) {
+		t.Cleanup(func() { applyPlanDir = "" })
+		// Given a plan directory without a manifest
+		mocks := setupApplyTest(t)
+		proj := newApplyAllProject(mocks)
+
+		// When applying with --plan
+		cmd := createTestApplyCmd()
+		ctx := context.WithValue(context.Background(), projectOverridesKey, proj)
+		cmd.SetArgs([]string{"--plan", t.TempDir()})
+		cmd.SetContext(ctx)
+		err := cmd.Execute()
+
+		// Then the apply is refused
+		if err == nil || !strings.Contains(err.Error(), "error reading plan manifest") {
+			t.Errorf("Expected manifest error, got %v", err)
+		}
+	})
+
 	t.Run("ErrorUnexpectedArgs", func(t *testing.T) {
 		// Given a bare apply command with an unexpected positional argument
 		mocks := setupApplyTest(t)
@@ -626,6 +851,97 @@ func TestApplyTerraformCmd(t *testing.T) {
 			t.Error("Apply must not run when credential preflight fails")
 		}
 	})
+
+	t.Run("PassesApplyOptions", func(t *testing.T) {
+		// Given an apply terraform command with targeting flags
+		mocks := setupApplyTest(t)
+		t.Cleanup(func() { applyTargets, applyReplace, applyRefreshOnly, applyTerraformConfirm = nil, nil, false, "" })
+		var got terraforminfra.ApplyOptions
+		mocks.TerraformStack.ApplyWithOptionsFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string, opts terraforminfra.ApplyOptions) error {
+			got = opts
+			return nil
+		}
+		proj := newApplyProject(mocks)
+
+		// When executing with repeated --target and --replace and a matching --confirm
+		cmd := createTestApplyTerraformCmd()
+		cmd.SetArgs([]string{"dns", "--target", "a.one", "--target", "a.two", "--replace", "b.one", "--confirm", "dns"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the options reach the stack
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !slices.Equal(got.Targets, []string{"a.one", "a.two"}) || !slices.Equal(got.Replace, []string{"b.one"}) || !got.Confirmed {
+			t.Errorf("Unexpected apply options %+v", got)
+		}
+	})
+
+	t.Run("ConfirmMismatchAborts", func(t *testing.T) {
+		// Given a --confirm value that does not name the component
+		mocks := setupApplyTest(t)
+		t.Cleanup(func() { applyTargets, applyReplace, applyRefreshOnly, applyTerraformConfirm = nil, nil, false, "" })
+		applyCalled := false
+		mocks.TerraformStack.ApplyFunc = func(*blueprintv1alpha1.Blueprint, string) error {
+			applyCalled = true
+			return nil
+		}
+		proj := newApplyProject(mocks)
+
+		// When executing the targeted apply
+		cmd := createTestApplyTerraformCmd()
+		cmd.SetArgs([]string{"dns", "--target", "a.one", "--confirm", "cluster"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the apply is aborted before it runs
+		if err == nil || !strings.Contains(err.Error(), `--confirm did not match "dns"`) {
+			t.Errorf("Expected confirmation error, got %v", err)
+		}
+		if applyCalled {
+			t.Error("Apply must not run when --confirm does not match")
+		}
+	})
+
+	t.Run("PartialApplySuggestsConfirm", func(t *testing.T) {
+		// Given a targeted apply the stack refuses as partially converging
+		mocks := setupApplyTest(t)
+		t.Cleanup(func() { applyTargets, applyReplace, applyRefreshOnly, applyTerraformConfirm = nil, nil, false, "" })
+		mocks.TerraformStack.ApplyWithOptionsFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string, opts terraforminfra.ApplyOptions) error {
+			return &terraforminfra.PartialApplyError{ComponentID: componentID, Pending: []terraforminfra.ResourceChange{{Address: "a.two"}}}
+		}
+		proj := newApplyProject(mocks)
+
+		// When executing the targeted apply without --confirm
+		cmd := createTestApplyTerraformCmd()
+		cmd.SetArgs([]string{"dns", "--target", "a.one"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the error lists the pending change and how to confirm
+		if err == nil || !strings.Contains(err.Error(), "a.two") || !strings.Contains(err.Error(), "pass --confirm dns") {
+			t.Errorf("Expected partial apply error with confirm hint, got %v", err)
+		}
+	})
+
+	t.Run("RejectsReplaceWithRefreshOnly", func(t *testing.T) {
+		// Given --replace combined with --refresh-only
+		mocks := setupApplyTest(t)
+		t.Cleanup(func() { applyTargets, applyReplace, applyRefreshOnly, applyTerraformConfirm = nil, nil, false, "" })
+		proj := newApplyProject(mocks)
+
+		// When executing the apply
+		cmd := createTestApplyTerraformCmd()
+		cmd.SetArgs([]string{"dns", "--replace", "b.one", "--refresh-only"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the combination is rejected
+		if err == nil || !strings.Contains(err.Error(), "--replace cannot be combined with --refresh-only") {
+			t.Errorf("Expected flag combination error, got %v", err)
+		}
+	})
 }
 
 func TestApplyKustomizeCmd(t *testing.T) {
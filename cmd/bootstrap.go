@@ -253,6 +253,9 @@ windsor bootstrap prod --yes`,
 				fmt.Fprintf(os.Stderr, "⚠ Bootstrap applied; some kustomizations are not yet ready: %v\n", err)
 			}
 
+			if proj.Provisioner.AwaitingReview() {
+				return nil
+			}
 			if err := proj.Provisioner.WriteVersionMarker(blueprint); err != nil {
 				return fmt.Errorf("error writing version marker: %w", err)
 			}
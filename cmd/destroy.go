@@ -222,7 +222,7 @@ windsor destroy terraform --confirm=local`,
 		// `destroy terraform` only invokes terraform; kustomize/k8s/docker tools are not used.
 		// Skip-validation tolerates a deployed-but-misordered blueprint so teardown can run
 		// against a setup the validator would otherwise reject.
-		proj, err := prepareProjectSkipValidation(cmd, tools.Requirements{Terraform: true, Secrets: true})
+		proj, err := prepareProjectSkipValidation(cmd, tools.Requirements{Terraform: true, Secrets: true, StackLock: true})
 		if err != nil {
 			return err
 		}
@@ -338,7 +338,7 @@ windsor destroy kustomize --confirm=local`,
 		}
 		// `destroy kustomize` only talks to the cluster API; terraform/docker tools are not used.
 		// Skip-validation tolerates a deployed-but-misordered blueprint.
-		proj, err := prepareProjectSkipValidation(cmd, tools.Requirements{Secrets: true, Kubelogin: true})
+		proj, err := prepareProjectSkipValidation(cmd, tools.Requirements{Secrets: true, Kubelogin: true, StackLock: true})
 		if err != nil {
 			return err
 		}
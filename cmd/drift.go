@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/windsorcli/cli/pkg/provisioner"
+	"github.com/windsorcli/cli/pkg/provisioner/stacklock"
+	"github.com/windsorcli/cli/pkg/runtime/tools"
+	"github.com/windsorcli/cli/pkg/tui"
+	tuiplan "github.com/windsorcli/cli/pkg/tui/plan"
+)
+
+var driftNoColor bool
+var driftJSON bool
+var driftDetailedExitCode bool
+
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Detect out-of-band changes to deployed infrastructure.",
+	Long: `Report infrastructure that no longer matches what windsor last applied.
+
+Every enabled Terraform component runs 'terraform plan -refresh-only', which lists resources whose real state differs from the recorded state. Every kustomization checks the objects in the inventory flux recorded at its last reconcile: objects deleted out of band are reported as deletes, and objects edited by hand through kubectl, k9s or a browser console are reported as updates and marked "manual edits detected". Object contents are not compared with what flux applied, so edits made by scripts, operators or other API clients are not detected. Components with no state, or kustomizations not present in the cluster, are shown as '(no state)' / '(not deployed)'.
+
+Nothing is changed; neither state nor the cluster is written. Per-component failures are reported inline and do not stop the remaining components from being checked.
+
+With --detailed-exitcode the command exits 0 when nothing drifted, 2 when drift was found, and 1 when any component could not be checked.`,
+	Example: `# Drift report across both layers
+windsor drift
+
+# Machine-readable report
+windsor drift --json
+
+# Fail a scheduled CI job when drift is found
+windsor drift --detailed-exitcode`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`plan`](plan.md), [`apply`](apply.md), [`state`](state.md)",
+		"docs.source":  "cmd/drift.go",
+	},
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Drift runs refresh-only terraform plans and reads the cluster through the
+		// kubernetes API, so it needs the same read-side surface as `plan`.
+		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true, Kubelogin: true, StackLock: true})
+		if err != nil {
+			return err
+		}
+
+		if err := requireCloudAuth(cmd, proj); err != nil {
+			return err
+		}
+
+		blueprint := proj.Composer.BlueprintHandler.Generate()
+
+		var summary *provisioner.DriftSummary
+		if err := stacklock.WithMode(cmd.Context(), proj.Runtime, "drift", stacklock.Shared, lockTimeout, func() error {
+			return tui.WithProgress("Checking for drift...", func() error {
+				var driftErr error
+				summary, driftErr = proj.Provisioner.Drift(blueprint)
+				return driftErr
+			})
+		}); err != nil {
+			return fmt.Errorf("error checking for drift: %w", err)
+		}
+
+		if driftJSON {
+			if err := tuiplan.DriftSummaryJSON(cmd.OutOrStdout(), summary.Terraform, summary.Kustomize); err != nil {
+				return err
+			}
+		} else {
+			tuiplan.DriftSummary(cmd.OutOrStdout(), summary.Terraform, summary.Kustomize, driftNoColor || os.Getenv("NO_COLOR") != "")
+		}
+
+		if !driftDetailedExitCode {
+			return nil
+		}
+		if failed := driftFailures(summary); failed > 0 {
+			silenceErrorsOnAncestors(cmd)
+			return &exitCodeError{code: 1, err: fmt.Errorf("drift check failed for %d component(s)", failed)}
+		}
+		if tuiplan.HasDrift(summary.Terraform, summary.Kustomize) {
+			silenceErrorsOnAncestors(cmd)
+			return &exitCodeError{code: 2, err: fmt.Errorf("drift detected")}
+		}
+		return nil
+	},
+}
+
+// driftFailures counts the components whose drift check itself failed.
+func driftFailures(summary *provisioner.DriftSummary) int {
+	failed := 0
+	for _, p := range summary.Terraform {
+		if p.Err != nil {
+			failed++
+		}
+	}
+	for _, p := range summary.Kustomize {
+		if p.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+func init() {
+	driftCmd.Flags().BoolVar(&driftJSON, "json", false, "Output the drift report as JSON.")
+	driftCmd.Flags().BoolVar(&driftNoColor, "no-color", false, "Disable color output.")
+	driftCmd.Flags().BoolVar(&driftDetailedExitCode, "detailed-exitcode", false, "Exit 2 when drift is found and 1 when a component could not be checked.")
+	rootCmd.AddCommand(driftCmd)
+}
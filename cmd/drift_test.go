@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/composer"
+	"github.com/windsorcli/cli/pkg/project"
+	"github.com/windsorcli/cli/pkg/provisioner"
+	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// newDriftProject wires the plan mocks and a FluxStack mock into a project for drift tests.
+func newDriftProject(mocks *PlanMocks, fluxStack *fluxinfra.MockStack) *project.Project {
+	comp := composer.NewComposer(mocks.Runtime)
+	comp.BlueprintHandler = mocks.BlueprintHandler
+	mockProvisioner := provisioner.NewProvisioner(mocks.Runtime, comp.BlueprintHandler, &provisioner.Provisioner{
+		TerraformStack: mocks.TerraformStack,
+		FluxStack:      fluxStack,
+	})
+	return project.NewProject("", &project.Project{
+		Runtime:     mocks.Runtime,
+		Composer:    comp,
+		Provisioner: mockProvisioner,
+	})
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestDriftCmd(t *testing.T) {
+	createTestDriftCmd := func() *cobra.Command {
+		driftJSON = false
+		driftNoColor = false
+		driftDetailedExitCode = false
+		cmd := &cobra.Command{
+			Use:  "drift",
+			RunE: driftCmd.RunE,
+		}
+		driftCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		return cmd
+	}
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	drifted := func(bp *blueprintv1alpha1.Blueprint) []terraforminfra.TerraformComponentPlan {
+		return []terraforminfra.TerraformComponentPlan{{
+			ComponentID: "cluster",
+			Change:      1,
+			Resources:   []terraforminfra.ResourceChange{{Address: "aws_eks_cluster.main", Action: terraforminfra.ActionUpdate}},
+		}}
+	}
+
+	t.Run("RendersReportWithoutFailingOnDrift", func(t *testing.T) {
+		// Given a terraform component that has drifted
+		mocks := setupPlanTest(t)
+		mocks.TerraformStack.DriftSummaryFunc = drifted
+		proj := newDriftProject(mocks, fluxinfra.NewMockStack())
+
+		// When running drift without --detailed-exitcode
+		cmd := createTestDriftCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--no-color"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the report lists the drifted resource and the command succeeds
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(stdout.String(), "~ aws_eks_cluster.main") {
+			t.Errorf("Expected drifted resource in report, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("DetailedExitCodeReturnsTwoOnDrift", func(t *testing.T) {
+		// Given a terraform component that has drifted
+		mocks := setupPlanTest(t)
+		mocks.TerraformStack.DriftSummaryFunc = drifted
+		proj := newDriftProject(mocks, fluxinfra.NewMockStack())
+
+		// When running drift with --detailed-exitcode and --json
+		cmd := createTestDriftCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--detailed-exitcode", "--json"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the JSON report is written and the error maps to exit code 2
+		if ExitCode(err) != 2 {
+			t.Errorf("Expected exit code 2, got %d (%v)", ExitCode(err), err)
+		}
+		if !strings.Contains(stdout.String(), `"drifted": true`) {
+			t.Errorf("Expected JSON drift report, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("DetailedExitCodeReturnsOneOnComponentError", func(t *testing.T) {
+		// Given a kustomization whose drift check failed alongside terraform drift
+		mocks := setupPlanTest(t)
+		mocks.TerraformStack.DriftSummaryFunc = drifted
+		fluxStack := fluxinfra.NewMockStack()
+		fluxStack.DriftSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) []fluxinfra.KustomizePlan {
+			return []fluxinfra.KustomizePlan{{Name: "monitoring", Err: fmt.Errorf("connection refused")}}
+		}
+		proj := newDriftProject(mocks, fluxStack)
+
+		// When running drift with --detailed-exitcode
+		cmd := createTestDriftCmd()
+		cmd.SetArgs([]string{"--detailed-exitcode"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the failure takes precedence and maps to exit code 1
+		if ExitCode(err) != 1 {
+			t.Errorf("Expected exit code 1, got %d (%v)", ExitCode(err), err)
+		}
+	})
+
+	t.Run("DetailedExitCodeReturnsNilWithoutDrift", func(t *testing.T) {
+		// Given components that match their recorded state
+		mocks := setupPlanTest(t)
+		mocks.TerraformStack.DriftSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) []terraforminfra.TerraformComponentPlan {
+			return []terraforminfra.TerraformComponentPlan{{ComponentID: "cluster", NoChanges: true}}
+		}
+		proj := newDriftProject(mocks, fluxinfra.NewMockStack())
+
+		// When running drift with --detailed-exitcode
+		cmd := createTestDriftCmd()
+		cmd.SetArgs([]string{"--detailed-exitcode"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the command succeeds
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
@@ -57,8 +57,8 @@ func applyWorkstationFlagOverrides(overrides map[string]any, vmDriver, platform
 //     keys can't be provisioned via API, so in-cluster state avoids a manual
 //     key step)
 //
-// gcp is intentionally not defaulted today: GCS backend support requires a
-// GCSBackend schema struct and provider.go branches that don't yet exist.
+// gcp is intentionally not defaulted: the gcs backend needs a bucket the
+// operator names, so it is selected explicitly with --backend gcs.
 func defaultTerraformBackendType(overrides map[string]any) {
 	if _, set := overrides["terraform.backend.type"]; set {
 		return
@@ -251,16 +251,29 @@ func TestDefaultTerraformBackendType(t *testing.T) {
 		}
 	})
 
-	t.Run("UnmappedPlatformDoesNotDefaultBackendType", func(t *testing.T) {
-		// Given platform=gcp (not yet wired up — GCSBackend schema is missing)
-		// the default switch must not invent a value. Operators on gcp are
-		// expected to configure terraform.backend.type explicitly until the
-		// schema lands.
+	t.Run("GcpPlatformDoesNotDefaultBackendType", func(t *testing.T) {
+		// Given platform=gcp, whose gcs backend needs a bucket the operator
+		// names, so the backend is selected explicitly
 		overrides := map[string]any{"platform": "gcp"}
 
 		// When the default is applied
 		defaultTerraformBackendType(overrides)
 
+		// Then no backend default is injected
+		if _, set := overrides["terraform.backend.type"]; set {
+			t.Errorf("Expected no backend default for gcp, got %v", overrides["terraform.backend.type"])
+		}
+	})
+
+	t.Run("UnmappedPlatformDoesNotDefaultBackendType", func(t *testing.T) {
+		// Given platform=omni, which has no canonical state store, the
+		// default switch must not invent a value. Operators are expected to
+		// configure terraform.backend.type explicitly.
+		overrides := map[string]any{"platform": "omni"}
+
+		// When the default is applied
+		defaultTerraformBackendType(overrides)
+
 		// Then no backend default is injected
 		if _, set := overrides["terraform.backend.type"]; set {
 			t.Errorf("Expected no backend default for unmapped platform, got %v", overrides["terraform.backend.type"])
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -9,15 +10,19 @@ import (
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
 	"github.com/windsorcli/cli/pkg/runtime"
 	"github.com/windsorcli/cli/pkg/runtime/config"
+	"github.com/windsorcli/cli/pkg/runtime/tools"
 )
 
+var getManagedOrigin string
+
 // getCmd represents the get command group
 var getCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Display Windsor resources.",
-	Long:  `Display Windsor resources. Currently supports listing contexts and printing the current context.`,
+	Long:  `Display Windsor resources. Currently supports listing contexts, printing the current context, and listing the cluster objects the current context manages.`,
 	Annotations: map[string]string{
 		"docs.seealso": "[`set`](set.md)",
 		"docs.source": "cmd/get.go",
@@ -158,8 +163,81 @@ var getContextCmd = &cobra.Command{
 	},
 }
 
+// getManagedCmd lists the cluster objects the current context applied
+var getManagedCmd = &cobra.Command{
+	Use:   "managed",
+	Short: "List the cluster objects the current context manages.",
+	Long: `List the cluster objects the current context applied, read from the provenance windsor stamps on them. Kustomizations, flux sources, ConfigMaps and Secrets carry the windsorcli.dev/context-id and windsorcli.dev/origin labels and the windsorcli.dev/blueprint-version and windsorcli.dev/applied-by annotations; each Kustomization passes them on to the objects it applies through commonMetadata.
+
+Output is a tab-aligned table with columns ORIGIN, KIND, NAMESPACE, NAME, VERSION, APPLIED-BY, sorted by origin. ORIGIN is the blueprint source the object came from, or '<none>' for objects not applied from a single source, such as blueprint-level ConfigMaps. Pass --origin to list only the objects from one source.`,
+	Example: `windsor get managed --origin core
+
+# Sample output:
+#   ORIGIN  KIND           NAMESPACE      NAME  VERSION  APPLIED-BY
+#   core    Kustomization  system-gitops  dns   v0.5.0   windsor/v0.9.0
+#   core    OCIRepository  system-gitops  core  v0.5.0   windsor/v0.9.0`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`status`](status.md)",
+		"docs.source":  "cmd/get.go",
+	},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// `get managed` only lists objects through the cluster API.
+		proj, err := prepareProject(cmd, tools.Requirements{Kubelogin: true})
+		if err != nil {
+			return err
+		}
+
+		objs, err := proj.Provisioner.ManagedObjects(getManagedOrigin)
+		if err != nil {
+			return err
+		}
+		if len(objs) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No managed objects found")
+			return nil
+		}
+		return writeManagedObjects(cmd.OutOrStdout(), objs)
+	},
+}
+
+// writeManagedObjects writes objs as a table sorted by origin, kind, namespace and name. Missing
+// provenance fields are shown as '<none>'.
+func writeManagedObjects(out io.Writer, objs []kubernetes.ManagedObject) error {
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, b := objs[i], objs[j]
+		if a.Origin != b.Origin {
+			return a.Origin < b.Origin
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	orNone := func(s string) string {
+		if s == "" {
+			return "<none>"
+		}
+		return s
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ORIGIN\tKIND\tNAMESPACE\tNAME\tVERSION\tAPPLIED-BY")
+	for _, obj := range objs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", orNone(obj.Origin), obj.Kind, orNone(obj.Namespace), obj.Name, orNone(obj.BlueprintVersion), orNone(obj.AppliedBy))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output: %w", err)
+	}
+	return nil
+}
+
 func init() {
+	getManagedCmd.Flags().StringVar(&getManagedOrigin, "origin", "", "List only objects applied from this blueprint source.")
 	getCmd.AddCommand(getContextsCmd)
 	getCmd.AddCommand(getContextCmd)
+	getCmd.AddCommand(getManagedCmd)
 	rootCmd.AddCommand(getCmd)
 }
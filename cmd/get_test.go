@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
 	"github.com/windsorcli/cli/pkg/runtime"
 	"github.com/windsorcli/cli/pkg/runtime/config"
 	"github.com/windsorcli/cli/pkg/runtime/shell"
@@ -526,3 +530,115 @@ func TestGetContextsCmd_ErrorScenarios(t *testing.T) {
 		}
 	})
 }
+
+func TestGetManagedCmd(t *testing.T) {
+	createTestGetManagedCmd := func() *cobra.Command {
+		getManagedOrigin = ""
+		cmd := &cobra.Command{
+			Use:  "managed",
+			RunE: getManagedCmd.RunE,
+		}
+		getManagedCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		return cmd
+	}
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	t.Run("ListsObjectsSortedByOrigin", func(t *testing.T) {
+		// Given a cluster holding objects from two origins, one without a version
+		km := kubernetes.NewMockKubernetesManager()
+		var gotOrigin string
+		km.ListManagedObjectsFunc = func(origin string) ([]kubernetes.ManagedObject, error) {
+			gotOrigin = origin
+			return []kubernetes.ManagedObject{
+				{Kind: "Kustomization", Namespace: "system-gitops", Name: "dns", Origin: "core", BlueprintVersion: "v0.5.0", AppliedBy: "windsor/v0.9.0"},
+				{Kind: "ConfigMap", Namespace: "system-gitops", Name: "values-common", AppliedBy: "windsor/v0.9.0"},
+				{Kind: "GitRepository", Namespace: "system-gitops", Name: "addons", Origin: "addons", AppliedBy: "windsor/v0.9.0"},
+			}, nil
+		}
+		proj := newStatusProject(t, km)
+
+		// When managed objects are listed
+		cmd := createTestGetManagedCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then every origin is listed, objects without provenance fields show <none>, sorted by origin
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotOrigin != "" {
+			t.Errorf("Expected no origin filter, got %q", gotOrigin)
+		}
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		if len(lines) != 4 || !strings.HasPrefix(lines[0], "ORIGIN") {
+			t.Fatalf("Expected a header and 3 rows, got:\n%s", stdout.String())
+		}
+		for i, want := range []string{"<none>  ConfigMap", "addons  GitRepository", "core    Kustomization"} {
+			if !strings.HasPrefix(lines[i+1], want) {
+				t.Errorf("Expected row %d to start with %q, got %q", i+1, want, lines[i+1])
+			}
+		}
+		if !strings.Contains(lines[2], "<none>") || !strings.Contains(lines[3], "v0.5.0") {
+			t.Errorf("Expected versions rendered, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("FiltersByOrigin", func(t *testing.T) {
+		// Given a cluster with no objects from the requested origin
+		km := kubernetes.NewMockKubernetesManager()
+		var gotOrigin string
+		km.ListManagedObjectsFunc = func(origin string) ([]kubernetes.ManagedObject, error) {
+			gotOrigin = origin
+			return nil, nil
+		}
+		proj := newStatusProject(t, km)
+
+		// When objects from core are listed
+		cmd := createTestGetManagedCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--origin", "core"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the origin is passed through and the empty result reported
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotOrigin != "core" {
+			t.Errorf("Expected origin core, got %q", gotOrigin)
+		}
+		if !strings.Contains(stdout.String(), "No managed objects found") {
+			t.Errorf("Expected empty message, got %q", stdout.String())
+		}
+	})
+
+	t.Run("ReturnsListError", func(t *testing.T) {
+		// Given a cluster that cannot be listed
+		km := kubernetes.NewMockKubernetesManager()
+		km.ListManagedObjectsFunc = func(origin string) ([]kubernetes.ManagedObject, error) {
+			return nil, fmt.Errorf("forbidden")
+		}
+		proj := newStatusProject(t, km)
+
+		// When managed objects are listed
+		cmd := createTestGetManagedCmd()
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the error is returned
+		if err == nil || !strings.Contains(err.Error(), "forbidden") {
+			t.Errorf("Expected list error, got %v", err)
+		}
+	})
+}
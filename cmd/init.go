@@ -208,7 +208,7 @@ windsor init staging --platform=aws --aws-profile=staging`,
 
 func init() {
 	initCmd.Flags().BoolVar(&initReset, "reset", false, "Overwrite existing files and clean .terraform.")
-	initCmd.Flags().StringVar(&initBackend, "backend", "", "Terraform backend type.")
+	initCmd.Flags().StringVar(&initBackend, "backend", "", "Terraform backend type: local, s3, kubernetes, azurerm, gcs, none, or one of consul, cos, http, oss, pg, remote configured via terraform.backend.config.")
 	initCmd.Flags().StringVar(&initAwsProfile, "aws-profile", "", "AWS profile name.")
 	initCmd.Flags().StringVar(&initAwsEndpointURL, "aws-endpoint-url", "", "AWS endpoint URL.")
 	initCmd.Flags().StringVar(&initVmDriver, "vm-driver", "", "VM driver: colima, colima-incus, docker-desktop, docker.")
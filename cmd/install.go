@@ -8,6 +8,7 @@ import (
 )
 
 var installWaitFlag bool
+var installForce bool
 
 var installCmd = &cobra.Command{
 	Use:   "install",
@@ -16,7 +17,9 @@ var installCmd = &cobra.Command{
 
 For most workflows, prefer 'windsor apply', which runs Terraform and Flux in the right order.
 
-Pass --wait to block until kustomizations report ready.`,
+Pass --wait to block until kustomizations report ready.
+
+Install is refused when any blueprint kustomization or a HelmRelease it owns is suspended, for example by 'windsor suspend', and the suspensions are listed with who made them and why. Pass --force to install anyway; suspended resources stay suspended until they are resumed.`,
 	Example: `# Install kustomizations and wait for them to settle
 windsor install --wait`,
 	Annotations: map[string]string{
@@ -37,6 +40,10 @@ windsor install --wait`,
 			return fmt.Errorf("error resolving blueprint substitutions: %w", err)
 		}
 
+		if err := checkSuspensions(cmd, proj, blueprint, nil, installForce); err != nil {
+			return err
+		}
+
 		if err := proj.Provisioner.Install(cmd.Context(), blueprint, false); err != nil {
 			return fmt.Errorf("error installing blueprint: %w", err)
 		}
@@ -53,5 +60,6 @@ windsor install --wait`,
 
 func init() {
 	installCmd.Flags().BoolVar(&installWaitFlag, "wait", false, "Wait for kustomization resources to be ready.")
+	installCmd.Flags().BoolVar(&installForce, "force", false, "Install even though blueprint resources are suspended.")
 	rootCmd.AddCommand(installCmd)
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -11,6 +12,7 @@ import (
 	"github.com/windsorcli/cli/pkg/project"
 	"github.com/windsorcli/cli/pkg/provisioner"
 	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	"github.com/windsorcli/cli/pkg/provisioner/policy"
 	"github.com/windsorcli/cli/pkg/provisioner/stacklock"
 	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
 	"github.com/windsorcli/cli/pkg/runtime/tools"
@@ -21,13 +23,19 @@ import (
 var planNoColor bool
 var planSummary bool
 var planJSON bool
+var planMaxConcurrency int
+var planOut string
 
 var planCmd = &cobra.Command{
 	Use:   "plan [component]",
 	Short: "Preview terraform and Flux changes.",
 	Long: `Preview pending changes across Terraform components and Flux kustomizations without applying them.
 
-With no argument, prints a compact summary across all components. Components that have never been applied show as '(new)' so you can distinguish first-time creates from updates.
+With no argument, prints a compact summary across all components. Components that have never been applied show as '(new)' so you can distinguish first-time creates from updates. Each kustomization in the --summary and --json output is classified as new, changed, unchanged, migrated (taking over objects from a kustomization the blueprint no longer declares, as when one is renamed) or reclaimed (no longer declared, listed with the objects pruning it would delete), along with its source's applied and target versions when they differ.
+
+Summaries are checked against the policies in the blueprint's and the context's policies/ directories. Violations are listed after the components and under "violations" in --json output; 'windsor apply' and 'windsor up' refuse to proceed while any policy denies the plan.
+
+Pass --out with a directory to also save every Terraform component's binary plan there, with a manifest.json recording the context, the windsor version, a digest of the composed blueprint, and digests of each component's inputs and module source. 'windsor apply --plan <dir>' later applies exactly those plans. Saving is refused when a component reads terraform_output() from a component with pending changes, since its plan would be applied against outputs that have since changed; apply the producer first. Kustomizations have no saved form; they are summarized here and planned again on apply. --out cannot be combined with a component name.
 
 With a component name, runs a full streaming plan for every layer (Terraform and/or Kustomize) that contains that component. Use a subcommand to restrict to a single layer.
 
@@ -41,6 +49,9 @@ windsor plan cluster
 # JSON-formatted summary, suitable for CI parsing
 windsor plan --summary --json
 
+# Save terraform plans for a later 'windsor apply --plan ./plan'
+windsor plan --out ./plan
+
 # Just terraform, just one component
 windsor plan terraform cluster`,
 	Annotations: map[string]string{
@@ -50,10 +61,14 @@ windsor plan terraform cluster`,
 	Args:         cobra.MaximumNArgs(1),
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if planOut != "" && len(args) > 0 {
+			return fmt.Errorf("--out saves plans for every component and cannot be combined with a component name")
+		}
+
 		// `plan` (no args, or with a component name) can dispatch to terraform plan and/or
 		// flux diff depending on what the blueprint contains. Both are read-only but exercise
 		// terraform + cluster API + secrets, so request the full read-side surface.
-		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true, Kubelogin: true})
+		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true, Kubelogin: true, StackLock: true})
 		if err != nil {
 			return err
 		}
@@ -64,21 +79,34 @@ windsor plan terraform cluster`,
 			if err := requireCloudAuth(cmd, proj); err != nil {
 				return err
 			}
-			return stacklock.With(cmd.Context(), proj.Runtime, "plan", lockTimeout, func() error {
+			outDir := ""
+			if planOut != "" {
+				if outDir, err = filepath.Abs(planOut); err != nil {
+					return fmt.Errorf("error resolving plan directory: %w", err)
+				}
+			}
+			return stacklock.WithMode(cmd.Context(), proj.Runtime, "plan", stacklock.Shared, lockTimeout, func() error {
 				var summary *provisioner.PlanSummary
 				if err := tui.WithProgress("Generating plan...", func() error {
 					var planErr error
-					summary, planErr = proj.Provisioner.PlanAll(blueprint)
+					if outDir != "" {
+						summary, planErr = proj.Provisioner.SavePlanAll(blueprint, outDir)
+					} else {
+						summary, planErr = proj.Provisioner.PlanAll(blueprint)
+					}
 					return planErr
 				}); err != nil {
 					return fmt.Errorf("error running plan: %w", err)
 				}
+				if outDir != "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Saved %d terraform plan(s) to %s. Apply them with 'windsor apply --plan %s'.\n", len(summary.Terraform), outDir, planOut)
+				}
 				if planJSON {
-					return tuiplan.SummaryJSON(os.Stdout, summary.Terraform, summary.Kustomize)
+					return tuiplan.SummaryJSON(os.Stdout, summary.Terraform, summary.Kustomize, summary.Violations)
 				}
 				describePlanMode(cmd, proj, blueprint)
 				describePendingPrunes(cmd, proj, blueprint)
-				tuiplan.Summary(os.Stdout, summary.Terraform, summary.Kustomize, summary.Hints, planNoColor || os.Getenv("NO_COLOR") != "")
+				tuiplan.Summary(os.Stdout, summary.Terraform, summary.Kustomize, summary.Violations, summary.Hints, planNoColor || os.Getenv("NO_COLOR") != "")
 				return nil
 			})
 		}
@@ -101,7 +129,7 @@ windsor plan terraform cluster`,
 		// against the cluster and must not block infra-mutating windsor operations.
 		runPlan := func(fn func() error) error {
 			if inTerraform {
-				return stacklock.With(cmd.Context(), proj.Runtime, "plan", lockTimeout, fn)
+				return stacklock.WithMode(cmd.Context(), proj.Runtime, "plan", stacklock.Shared, lockTimeout, fn)
 			}
 			return fn()
 		}
@@ -124,10 +152,14 @@ windsor plan terraform cluster`,
 					}
 					k8sResults = []fluxinfra.KustomizePlan{result}
 				}
+				violations, err := proj.Provisioner.EvaluatePolicies(policy.OperationPlan, tfResults, k8sResults)
+				if err != nil {
+					return fmt.Errorf("error evaluating policies: %w", err)
+				}
 				if planJSON {
-					return tuiplan.SummaryJSON(os.Stdout, tfResults, k8sResults)
+					return tuiplan.SummaryJSON(os.Stdout, tfResults, k8sResults, violations)
 				}
-				tuiplan.Summary(os.Stdout, tfResults, k8sResults, nil, planNoColor || os.Getenv("NO_COLOR") != "")
+				tuiplan.Summary(os.Stdout, tfResults, k8sResults, violations, nil, planNoColor || os.Getenv("NO_COLOR") != "")
 				return nil
 			})
 		}
@@ -153,10 +185,15 @@ var planTerraformCmd = &cobra.Command{
 	Use:     "terraform [component]",
 	Aliases: []string{"tf"},
 	Short:   "Plan Terraform changes.",
-	Long: `Stream 'terraform init' and 'terraform plan' for a specific component, or all components when no argument is given. Inherits --summary, --json, and --no-color from the parent 'plan' command.`,
+	Long: `Stream 'terraform init' and 'terraform plan' for a specific component, or all components when no argument is given. Inherits --summary, --json, and --no-color from the parent 'plan' command.
+
+Pass --max-concurrency to plan independent components in parallel when planning all components. A component that depends on another, or reads its outputs with terraform_output(), waits for that component's plan to finish. Each component's output is printed as one block in blueprint order, so plans never interleave: --json lines stay whole and --summary --json still emits one well-formed document. In the summary, a component whose terraform_output() inputs come from a component with pending changes is marked '(inputs known after apply)'.`,
 	Example: `# Stream the plan for one component
 windsor plan terraform cluster
 
+# Plan up to four independent components at a time
+windsor plan terraform --summary --max-concurrency 4
+
 # Compact summary across all components
 windsor plan terraform --summary
 
@@ -170,7 +207,7 @@ windsor plan terraform --json`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// `plan terraform` only invokes terraform; cluster/k8s tools are not used.
-		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true})
+		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true, StackLock: true})
 		if err != nil {
 			return err
 		}
@@ -180,8 +217,9 @@ windsor plan terraform --json`,
 		}
 
 		blueprint := proj.Composer.BlueprintHandler.Generate()
+		proj.Provisioner.SetTerraformConcurrency(planMaxConcurrency)
 
-		return stacklock.With(cmd.Context(), proj.Runtime, "plan", lockTimeout, func() error {
+		return stacklock.WithMode(cmd.Context(), proj.Runtime, "plan", stacklock.Shared, lockTimeout, func() error {
 			if len(args) == 0 {
 				if planJSON && !planSummary {
 					return proj.Provisioner.PlanTerraformAllJSON(blueprint)
@@ -192,9 +230,9 @@ windsor plan terraform --json`,
 						return fmt.Errorf("error running plan: %w", err)
 					}
 					if planJSON {
-						return tuiplan.SummaryJSON(os.Stdout, summary.Terraform, nil)
+						return tuiplan.SummaryJSON(os.Stdout, summary.Terraform, nil, summary.Violations)
 					}
-					tuiplan.Summary(os.Stdout, summary.Terraform, nil, nil, planNoColor || os.Getenv("NO_COLOR") != "")
+					tuiplan.Summary(os.Stdout, summary.Terraform, nil, summary.Violations, nil, planNoColor || os.Getenv("NO_COLOR") != "")
 					return nil
 				}
 				return proj.Provisioner.PlanTerraformAll(blueprint)
@@ -213,10 +251,15 @@ windsor plan terraform --json`,
 				if err != nil {
 					return fmt.Errorf("error running plan: %w", err)
 				}
+				tfResults := []terraforminfra.TerraformComponentPlan{result}
+				violations, err := proj.Provisioner.EvaluatePolicies(policy.OperationPlan, tfResults, nil)
+				if err != nil {
+					return fmt.Errorf("error evaluating policies: %w", err)
+				}
 				if planJSON {
-					return tuiplan.SummaryJSON(os.Stdout, []terraforminfra.TerraformComponentPlan{result}, nil)
+					return tuiplan.SummaryJSON(os.Stdout, tfResults, nil, violations)
 				}
-				tuiplan.Summary(os.Stdout, []terraforminfra.TerraformComponentPlan{result}, nil, nil, planNoColor || os.Getenv("NO_COLOR") != "")
+				tuiplan.Summary(os.Stdout, tfResults, nil, violations, nil, planNoColor || os.Getenv("NO_COLOR") != "")
 				return nil
 			}
 
@@ -264,9 +307,9 @@ windsor plan kustomize --summary`,
 					return fmt.Errorf("error running plan: %w", err)
 				}
 				if planJSON {
-					return tuiplan.SummaryJSON(os.Stdout, nil, summary.Kustomize)
+					return tuiplan.SummaryJSON(os.Stdout, nil, summary.Kustomize, summary.Violations)
 				}
-				tuiplan.Summary(os.Stdout, nil, summary.Kustomize, summary.Hints, planNoColor || os.Getenv("NO_COLOR") != "")
+				tuiplan.Summary(os.Stdout, nil, summary.Kustomize, summary.Violations, summary.Hints, planNoColor || os.Getenv("NO_COLOR") != "")
 				return nil
 			}
 			return proj.Provisioner.PlanKustomizeAll(blueprint)
@@ -282,10 +325,15 @@ windsor plan kustomize --summary`,
 			if err != nil {
 				return fmt.Errorf("error running plan: %w", err)
 			}
+			k8sResults := []fluxinfra.KustomizePlan{result}
+			violations, err := proj.Provisioner.EvaluatePolicies(policy.OperationPlan, nil, k8sResults)
+			if err != nil {
+				return fmt.Errorf("error evaluating policies: %w", err)
+			}
 			if planJSON {
-				return tuiplan.SummaryJSON(os.Stdout, nil, []fluxinfra.KustomizePlan{result})
+				return tuiplan.SummaryJSON(os.Stdout, nil, k8sResults, violations)
 			}
-			tuiplan.Summary(os.Stdout, nil, []fluxinfra.KustomizePlan{result}, nil, planNoColor || os.Getenv("NO_COLOR") != "")
+			tuiplan.Summary(os.Stdout, nil, k8sResults, violations, nil, planNoColor || os.Getenv("NO_COLOR") != "")
 			return nil
 		}
 
@@ -301,6 +349,8 @@ func init() {
 	planCmd.PersistentFlags().BoolVar(&planNoColor, "no-color", false, "Disable color output.")
 	planCmd.PersistentFlags().BoolVar(&planSummary, "summary", false, "Show a compact summary table instead of streaming output.")
 	planCmd.PersistentFlags().BoolVar(&planJSON, "json", false, "Output as JSON. Streams full plan JSON on subcommands; emits the summary as JSON on root 'plan'.")
+	planCmd.Flags().StringVar(&planOut, "out", "", "Save each terraform component's plan and a manifest to this directory for 'windsor apply --plan'.")
+	planTerraformCmd.Flags().IntVar(&planMaxConcurrency, "max-concurrency", 1, "Maximum number of independent terraform components to plan at once.")
 	planCmd.AddCommand(planTerraformCmd)
 	planCmd.AddCommand(planKustomizeCmd)
 	rootCmd.AddCommand(planCmd)
@@ -326,13 +376,18 @@ func describePlanMode(cmd *cobra.Command, proj *project.Project, blueprint *blue
 
 // describePendingPrunes prints to stderr the kustomizations a `windsor upgrade` would prune — those
 // this context still has live but the blueprint no longer declares — so a pending removal is visible
-// before it happens. It is best-effort: a legacy/unreachable cluster or no orphans prints nothing.
+// before it happens, followed by what pruning them would do to each object they own. It is
+// best-effort: a legacy/unreachable cluster or no orphans prints nothing, and an object analysis
+// that fails leaves just the names.
 func describePendingPrunes(cmd *cobra.Command, proj *project.Project, blueprint *blueprintv1alpha1.Blueprint) {
 	prunable, err := proj.Provisioner.PrunableKustomizations(blueprint)
 	if err != nil || len(prunable) == 0 {
 		return
 	}
-	fmt.Fprintf(cmd.ErrOrStderr(), "These kustomizations are no longer declared and would be pruned by `windsor apply --prune` or `windsor upgrade --yes`:\n  %s\n", strings.Join(prunable, "\n  "))
+	fmt.Fprintf(cmd.ErrOrStderr(), "These kustomizations are no longer declared and would be pruned by `windsor apply --prune` or `windsor upgrade --yes`:\n  %s\n", strings.Join(kustomizationNames(prunable), "\n  "))
+	if objs, err := proj.Provisioner.AnalyzePrune(prunable); err == nil {
+		writePruneAnalysis(cmd.ErrOrStderr(), objs)
+	}
 }
 
 // blueprintHasTerraformComponent reports whether the blueprint contains an enabled Terraform component with the given ID.
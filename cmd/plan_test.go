@@ -152,6 +152,9 @@ func TestPlanCmd(t *testing.T) {
 		planCmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
 			cmd.Flags().AddFlag(flag)
 		})
+		planCmd.LocalNonPersistentFlags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
 		cmd.Args = planCmd.Args
 		cmd.SilenceUsage = true
 		cmd.SilenceErrors = true
@@ -217,6 +220,68 @@ func TestPlanCmd(t *testing.T) {
 			t.Errorf("expected not-found error, got: %v", err)
 		}
 	})
+
+	t.Run("OutSavesTerraformPlans", func(t *testing.T) {
+		t.Cleanup(func() { planOut = ""; planJSON = false })
+		// Given a terraform stack that saves plans and an empty kustomize layer
+		mocks := setupPlanTest(t)
+		var savedDir string
+		mocks.TerraformStack.SavePlansFunc = func(bp *blueprintv1alpha1.Blueprint, dir string) ([]terraforminfra.TerraformComponentPlan, []terraforminfra.SavedPlan, error) {
+			savedDir = dir
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				return nil, nil, err
+			}
+			return []terraforminfra.TerraformComponentPlan{{ComponentID: "cluster"}}, []terraforminfra.SavedPlan{{ComponentID: "cluster", PlanFile: "cluster.tfplan"}}, nil
+		}
+		comp := composer.NewComposer(mocks.Runtime)
+		comp.BlueprintHandler = mocks.BlueprintHandler
+		proj := project.NewProject("", &project.Project{
+			Runtime:  mocks.Runtime,
+			Composer: comp,
+			Provisioner: provisioner.NewProvisioner(mocks.Runtime, comp.BlueprintHandler, &provisioner.Provisioner{
+				TerraformStack: mocks.TerraformStack,
+				FluxStack:      fluxinfra.NewMockStack(),
+			}),
+		})
+		outDir := filepath.Join(t.TempDir(), "plan")
+
+		// When planning with --out
+		cmd := createTestPlanCmd()
+		ctx := context.WithValue(context.Background(), projectOverridesKey, proj)
+		cmd.SetArgs([]string{"--json", "--out", outDir})
+		cmd.SetContext(ctx)
+		err := cmd.Execute()
+
+		// Then the plans and manifest are saved to the directory
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if savedDir != outDir {
+			t.Errorf("expected plans saved to %s, got %s", outDir, savedDir)
+		}
+		if _, err := os.Stat(filepath.Join(outDir, provisioner.PlanManifestFile)); err != nil {
+			t.Errorf("expected manifest to be written: %v", err)
+		}
+	})
+
+	t.Run("OutRejectsComponentName", func(t *testing.T) {
+		t.Cleanup(func() { planOut = "" })
+		// Given a plan command
+		mocks := setupPlanTest(t)
+		proj := newPlanProject(mocks)
+
+		// When planning one component with --out
+		cmd := createTestPlanCmd()
+		ctx := context.WithValue(context.Background(), projectOverridesKey, proj)
+		cmd.SetArgs([]string{"--out", t.TempDir(), "cluster"})
+		cmd.SetContext(ctx)
+		err := cmd.Execute()
+
+		// Then the combination is rejected
+		if err == nil || !strings.Contains(err.Error(), "cannot be combined with a component name") {
+			t.Errorf("expected --out with component error, got %v", err)
+		}
+	})
 }
 
 func TestDescribePlanMode(t *testing.T) {
@@ -308,7 +373,7 @@ func TestDescribePlanMode(t *testing.T) {
 }
 
 func TestDescribePendingPrunes(t *testing.T) {
-	projectWithPrunable := func(t *testing.T, listFn func(*blueprintv1alpha1.Blueprint, string) ([]string, error)) *project.Project {
+	projectWithPrunable := func(t *testing.T, listFn func(*blueprintv1alpha1.Blueprint, string) ([]blueprintv1alpha1.Kustomization, error)) *project.Project {
 		t.Helper()
 		mocks := setupPlanTest(t)
 		km := kubernetes.NewMockKubernetesManager()
@@ -333,8 +398,8 @@ func TestDescribePendingPrunes(t *testing.T) {
 	}
 
 	t.Run("ListsPendingPrunes", func(t *testing.T) {
-		proj := projectWithPrunable(t, func(*blueprintv1alpha1.Blueprint, string) ([]string, error) {
-			return []string{"old-thing", "stale-app"}, nil
+		proj := projectWithPrunable(t, func(*blueprintv1alpha1.Blueprint, string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}, {Name: "stale-app"}}, nil
 		})
 		out := run(proj)
 		if !strings.Contains(out, "would be pruned") || !strings.Contains(out, "old-thing") || !strings.Contains(out, "stale-app") {
@@ -343,7 +408,7 @@ func TestDescribePendingPrunes(t *testing.T) {
 	})
 
 	t.Run("SilentWhenNothingPrunable", func(t *testing.T) {
-		proj := projectWithPrunable(t, func(*blueprintv1alpha1.Blueprint, string) ([]string, error) {
+		proj := projectWithPrunable(t, func(*blueprintv1alpha1.Blueprint, string) ([]blueprintv1alpha1.Kustomization, error) {
 			return nil, nil
 		})
 		if out := run(proj); out != "" {
@@ -352,7 +417,7 @@ func TestDescribePendingPrunes(t *testing.T) {
 	})
 
 	t.Run("SilentWhenListingFails", func(t *testing.T) {
-		proj := projectWithPrunable(t, func(*blueprintv1alpha1.Blueprint, string) ([]string, error) {
+		proj := projectWithPrunable(t, func(*blueprintv1alpha1.Blueprint, string) ([]blueprintv1alpha1.Kustomization, error) {
 			return nil, fmt.Errorf("cluster unreachable")
 		})
 		if out := run(proj); out != "" {
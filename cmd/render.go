@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/windsorcli/cli/pkg/runtime/tools"
+)
+
+var renderOut string
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Compile the blueprint to a plain GitOps directory.",
+	Long: `Write everything windsor would apply for the current context to a directory, without touching a cluster or running terraform. The tree is deterministic, so a separate GitOps repository can commit it and review each change as a diff:
+
+  kubernetes/<namespace>/<kind>-<name>.yaml   every Flux Kustomization, GitRepository, OCIRepository, HelmRepository, Bucket and values ConfigMap, plus the gitops namespace under kubernetes/_cluster/
+  kubernetes/kustomization.yaml               a kustomize overlay listing them
+  secrets/<namespace>/<name>.yaml             the Secrets the blueprint declares, with every value replaced by '<sensitive>'; secrets whose namespace is resolved at placement go under secrets/_auto/<kustomization>/
+  terraform/<component>/terraform.tfvars      each terraform component's generated tfvars
+  render.json                                 the context, windsor version, a digest of the composed blueprint, and the rendered files
+
+When the context sets gitops.engine to argocd, kubernetes/ instead holds an Argo CD Application per kustomization, in gitops.argocd.namespace (default argocd). Each builds the kustomization's source and path, syncs in the wave its dependsOn depth gives it, and inlines its substitutions as the env of the windsor-envsubst config management plugin. The plugin's definition is rendered as the windsor-envsubst-plugin ConfigMap, under plugin.yaml, for a sidecar on the Argo CD repo server to mount at /home/argocd/cmp-server/config/plugin.yaml; the sidecar image must provide sh, kustomize and flux.
+
+Objects carry the same provenance labels and annotations 'windsor apply' stamps. Secret values are never resolved, so no secret material is written. The directory must be empty or hold an earlier render, whose kubernetes/, secrets/ and terraform/ trees are replaced so objects dropped from the blueprint disappear from the tree.`,
+	Example: `# Render the current context into ./gitops
+windsor render --out ./gitops
+
+# Render into a checkout of the GitOps repository, replacing the previous render
+windsor render --out ../gitops/clusters/local`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`show`](show.md), [`plan`](plan.md), [`apply`](apply.md)",
+		"docs.source":  "cmd/render.go",
+	},
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Render composes the blueprint and generates tfvars but runs neither terraform nor
+		// kubectl, and never resolves secrets, so no tools are required.
+		proj, err := prepareProject(cmd, tools.Requirements{})
+		if err != nil {
+			return err
+		}
+
+		outDir, err := filepath.Abs(renderOut)
+		if err != nil {
+			return fmt.Errorf("error resolving render directory: %w", err)
+		}
+
+		blueprint := proj.Composer.BlueprintHandler.Generate()
+		manifest, err := proj.Provisioner.Render(blueprint, outDir)
+		if err != nil {
+			return fmt.Errorf("error rendering blueprint: %w", err)
+		}
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "Rendered %d file(s) to %s.\n", len(manifest.Files), outDir)
+		return nil
+	},
+}
+
+func init() {
+	renderCmd.Flags().StringVar(&renderOut, "out", "", "Directory to write the rendered blueprint to.")
+	_ = renderCmd.MarkFlagRequired("out")
+	rootCmd.AddCommand(renderCmd)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/composer"
+	"github.com/windsorcli/cli/pkg/project"
+	"github.com/windsorcli/cli/pkg/provisioner"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// newRenderProject wires the plan mocks and a KubernetesManager mock into a project for render tests.
+func newRenderProject(mocks *PlanMocks, km *kubernetes.MockKubernetesManager) *project.Project {
+	comp := composer.NewComposer(mocks.Runtime)
+	comp.BlueprintHandler = mocks.BlueprintHandler
+	mockProvisioner := provisioner.NewProvisioner(mocks.Runtime, comp.BlueprintHandler, &provisioner.Provisioner{
+		TerraformStack:    mocks.TerraformStack,
+		KubernetesManager: km,
+	})
+	return project.NewProject("", &project.Project{
+		Runtime:     mocks.Runtime,
+		Composer:    comp,
+		Provisioner: mockProvisioner,
+	})
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestRenderCmd(t *testing.T) {
+	createTestRenderCmd := func() *cobra.Command {
+		renderOut = ""
+		cmd := &cobra.Command{
+			Use:  "render",
+			RunE: renderCmd.RunE,
+			Args: renderCmd.Args,
+		}
+		renderCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		return cmd
+	}
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	t.Run("WritesRenderedTree", func(t *testing.T) {
+		// Given a blueprint whose kubernetes manager renders a single kustomization
+		mocks := setupPlanTest(t)
+		km := kubernetes.NewMockKubernetesManager()
+		km.RenderBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]*unstructured.Unstructured, error) {
+			obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "kustomize.toolkit.fluxcd.io/v1", "kind": "Kustomization"}}
+			obj.SetNamespace(namespace)
+			obj.SetName("dns")
+			return []*unstructured.Unstructured{obj}, nil
+		}
+		proj := newRenderProject(mocks, km)
+		out := filepath.Join(t.TempDir(), "gitops")
+
+		// When rendering to a new directory
+		cmd := createTestRenderCmd()
+		cmd.SetArgs([]string{"--out", out})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the kustomization and the manifest are written
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, path := range []string{"kubernetes/system-gitops/kustomization-dns.yaml", provisioner.RenderManifestFile} {
+			if _, err := os.Stat(filepath.Join(out, path)); err != nil {
+				t.Errorf("Expected %s to be written: %v", path, err)
+			}
+		}
+	})
+
+	t.Run("ErrorWhenOutHoldsForeignFiles", func(t *testing.T) {
+		// Given an output directory holding files render did not write
+		mocks := setupPlanTest(t)
+		proj := newRenderProject(mocks, kubernetes.NewMockKubernetesManager())
+		out := t.TempDir()
+		if err := os.WriteFile(filepath.Join(out, "README.md"), []byte("keep"), 0600); err != nil {
+			t.Fatalf("failed to seed output directory: %v", err)
+		}
+
+		// When rendering into it
+		cmd := createTestRenderCmd()
+		cmd.SetArgs([]string{"--out", out})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then it refuses and leaves the files alone
+		if err == nil || !strings.Contains(err.Error(), "error rendering blueprint") {
+			t.Errorf("Expected render error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(out, "README.md")); err != nil {
+			t.Errorf("Expected existing file untouched, got %v", err)
+		}
+	})
+}
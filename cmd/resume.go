@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/windsorcli/cli/pkg/provisioner/stacklock"
+	"github.com/windsorcli/cli/pkg/runtime/tools"
+)
+
+// =============================================================================
+// Resume Commands
+// =============================================================================
+
+var resumeAll bool
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume Flux reconciliation of suspended blueprint resources.",
+	Long:  `Lift a suspension made with 'windsor suspend' and have Flux reconcile the resumed resources right away. Use the 'kustomize' subcommand to choose what to resume.`,
+	Example: `# Resume the dns kustomization and its HelmReleases
+windsor resume kustomize dns`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`suspend`](suspend.md), [`status`](status.md)",
+		"docs.source":  "cmd/resume.go",
+	},
+}
+
+var resumeKustomizeCmd = &cobra.Command{
+	Use:     "kustomize [name]",
+	Aliases: []string{"k8s"},
+	Short:   "Resume a Flux kustomization and the HelmReleases it owns.",
+	Long: `Clear spec.suspend and the suspension record on a blueprint kustomization's Flux Kustomization and on every suspended HelmRelease it applies, then request an immediate reconcile of the kustomization. Pass --all instead of a name to resume every kustomization in the blueprint.
+
+Resources suspended outside windsor, for example with 'flux suspend', are resumed as well.`,
+	Example: `# Resume one kustomization
+windsor resume kustomize dns
+
+# Resume every kustomization in the blueprint
+windsor resume kustomize --all`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`resume`](resume.md), [`suspend kustomize`](suspend-kustomize.md)",
+		"docs.source":  "cmd/resume.go",
+	},
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := suspendTargetArgs(args, resumeAll)
+		if err != nil {
+			return err
+		}
+
+		// `resume kustomize` only patches objects through the cluster API and notifies flux.
+		proj, err := prepareProject(cmd, tools.Requirements{Kubelogin: true, StackLock: true})
+		if err != nil {
+			return err
+		}
+
+		blueprint := proj.Composer.BlueprintHandler.Generate()
+		if blueprint == nil {
+			return fmt.Errorf("blueprint is not available")
+		}
+
+		return stacklock.With(cmd.Context(), proj.Runtime, "resume", lockTimeout, func() error {
+			resumed, err := proj.Provisioner.Resume(cmd.Context(), blueprint, names)
+			writeSuspendedObjects(cmd.OutOrStdout(), "Resumed", resumed)
+			if err != nil {
+				return fmt.Errorf("error resuming kustomizations: %w", err)
+			}
+			if len(resumed) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Nothing was suspended")
+			}
+			return nil
+		})
+	},
+}
+
+func init() {
+	resumeKustomizeCmd.Flags().BoolVar(&resumeAll, "all", false, "Resume every kustomization in the blueprint.")
+	resumeCmd.AddCommand(resumeKustomizeCmd)
+	rootCmd.AddCommand(resumeCmd)
+}
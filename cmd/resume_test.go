@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/windsorcli/cli/pkg/provisioner/flux"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestResumeKustomizeCmd(t *testing.T) {
+	createTestResumeCmd := func() *cobra.Command {
+		resumeAll = false
+		cmd := &cobra.Command{
+			Use:  "kustomize",
+			Args: resumeKustomizeCmd.Args,
+			RunE: resumeKustomizeCmd.RunE,
+		}
+		resumeKustomizeCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		return cmd
+	}
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	t.Run("ResumesAndReconciles", func(t *testing.T) {
+		// Given a suspended dns kustomization
+		km := kubernetes.NewMockKubernetesManager()
+		km.ResumeKustomizationFunc = func(name, namespace string) ([]kubernetes.SuspendedObject, error) {
+			if name != "dns" {
+				return nil, nil
+			}
+			return []kubernetes.SuspendedObject{{Kind: "Kustomization", Namespace: namespace, Name: name, Kustomization: name}}, nil
+		}
+		proj := newSuspendProject(t, km)
+		notifier := flux.NewMockNotifier()
+		var reconciled []string
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []flux.KustomizationRef) error {
+			for _, ref := range refs {
+				reconciled = append(reconciled, ref.Name)
+			}
+			return nil
+		}
+		proj.Provisioner.Notifier = notifier
+
+		// When resuming every kustomization
+		cmd := createTestResumeCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--all"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then dns is resumed and only dns is reconciled
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Resumed Kustomization system-gitops/dns") {
+			t.Errorf("Expected dns listed as resumed, got:\n%s", stdout.String())
+		}
+		if strings.Join(reconciled, ",") != "dns" {
+			t.Errorf("Expected only dns reconciled, got %v", reconciled)
+		}
+	})
+
+	t.Run("ReportsNothingSuspended", func(t *testing.T) {
+		// Given no suspended kustomizations
+		proj := newSuspendProject(t, kubernetes.NewMockKubernetesManager())
+
+		// When resuming dns
+		cmd := createTestResumeCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"dns"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then it says so
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Nothing was suspended") {
+			t.Errorf("Expected nothing-suspended note, got:\n%s", stdout.String())
+		}
+	})
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -20,6 +21,10 @@ var verbose bool
 // disk cache and re-downloads (and atomically overwrites) the cached entry.
 var noCache bool
 
+// noColor is a flag for disabling color in terminal output. Commands with their own --no-color
+// flag shadow it; setupGlobalContext reads whichever the command resolves.
+var noColor bool
+
 // lockTimeout is a flag for how long a command waits to acquire the stack lock before
 // failing. Defaults to 0 (fail immediately on contention, matching terraform's own
 // -lock-timeout default) rather than silently blocking; pass a duration to wait instead.
@@ -48,6 +53,36 @@ func Execute() error {
 	return rootCmd.ExecuteContext(ctx)
 }
 
+// ExitCode returns the process exit code for an error returned by Execute. Commands that
+// promise specific exit codes (such as `drift --detailed-exitcode`) return an
+// exitCodeError; every other error exits 1.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
+}
+
+// exitCodeError pairs an error with the exit code the process should terminate with.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+// Error returns the wrapped error's message.
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
 // RootCmd exposes the assembled cobra command tree for tooling that needs to
 // introspect commands without executing them — currently the reference-doc
 // generator under internal/gendocs. Importing the cmd package triggers the
@@ -72,6 +107,9 @@ func init() {
 	// bootstrap, bundle, ...) inherits it; the effect is plumbed via the NO_CACHE env
 	// var that ArtifactBuilder.Pull already honors.
 	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Bypass the OCI artifact cache and force re-download of remote sources")
+	// Define the --no-color flag. Persistent so the progress output of every command, such as
+	// the warnings printed while apply waits on kustomizations, can be left uncolored.
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output.")
 	// Define the --lock-timeout flag. Persistent so every command that acquires the stack
 	// lock (apply, up, destroy, plan, bootstrap, upgrade) inherits it.
 	rootCmd.PersistentFlags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the stack lock before failing (e.g. 30s, 5m). Defaults to 0 (fail immediately).")
@@ -190,7 +228,7 @@ func silenceErrorsOnAncestors(cmd *cobra.Command) {
 // setting the env var is the smallest-blast-radius path that works for every command
 // without threading a flag through the project/runtime/composer construction chain.
 // An explicit --no-cache always wins; a pre-existing NO_CACHE in the environment is
-// preserved when the flag is not set.
+// preserved when the flag is not set. --no-color or NO_COLOR turns off the color tui adds.
 func setupGlobalContext(cmd *cobra.Command) error {
 	ctx := cmd.Root().Context()
 	if ctx == nil {
@@ -206,5 +244,7 @@ func setupGlobalContext(cmd *cobra.Command) error {
 	}
 	cmd.SetContext(ctx)
 	tui.Init(verbose)
+	disableColor, _ := cmd.Flags().GetBool("no-color")
+	tui.SetNoColor(disableColor || os.Getenv("NO_COLOR") != "")
 	return nil
 }
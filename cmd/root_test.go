@@ -24,6 +24,7 @@ import (
 	"github.com/windsorcli/cli/pkg/runtime/secrets"
 	"github.com/windsorcli/cli/pkg/runtime/shell"
 	"github.com/windsorcli/cli/pkg/runtime/tools"
+	"github.com/windsorcli/cli/pkg/tui"
 )
 
 // =============================================================================
@@ -498,6 +499,24 @@ func TestExecute(t *testing.T) {
 	})
 }
 
+func TestExitCode(t *testing.T) {
+	t.Run("MapsErrorsToExitCodes", func(t *testing.T) {
+		// Given nil, a plain error, and a wrapped exit-code error
+		coded := fmt.Errorf("wrapped: %w", &exitCodeError{code: 2, err: fmt.Errorf("drift detected")})
+
+		// Then each maps to its exit code
+		if got := ExitCode(nil); got != 0 {
+			t.Errorf("Expected 0 for nil, got %d", got)
+		}
+		if got := ExitCode(fmt.Errorf("boom")); got != 1 {
+			t.Errorf("Expected 1 for a plain error, got %d", got)
+		}
+		if got := ExitCode(coded); got != 2 {
+			t.Errorf("Expected 2 for an exit-code error, got %d", got)
+		}
+	})
+}
+
 func TestCommandPreflight(t *testing.T) {
 	// Cleanup: reset rootCmd context and globals after all subtests complete.
 	// noCache is reset alongside verbose because both are package-level flag
@@ -601,6 +620,25 @@ func TestCommandPreflight(t *testing.T) {
 		}
 	})
 
+	t.Run("DisablesTuiColorWhenNoColorEnvSet", func(t *testing.T) {
+		// Given NO_COLOR is set in the environment
+		t.Setenv("NO_COLOR", "1")
+		t.Cleanup(func() { tui.SetNoColor(false) })
+		cmd := &cobra.Command{Use: "test"}
+		rootCmd.AddCommand(cmd)
+		t.Cleanup(func() { rootCmd.RemoveCommand(cmd) })
+
+		// When running preflight
+		if err := commandPreflight(cmd, []string{}); err != nil {
+			t.Fatalf("Expected no error for preflight, got: %v", err)
+		}
+
+		// Then tui renders warnings without color
+		if got := tui.Warning("x"); strings.Contains(got, "\033[") {
+			t.Errorf("Expected an uncolored warning, got %q", got)
+		}
+	})
+
 	t.Run("SetsNoCacheEnvWhenFlagTrue", func(t *testing.T) {
 		// Given the --no-cache flag is set, and NO_CACHE is unset in the environment,
 		// preflight must propagate the flag to NO_CACHE=true so ArtifactBuilder.Pull
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/windsorcli/cli/pkg/provisioner/stacklock"
+	"github.com/windsorcli/cli/pkg/runtime/tools"
+)
+
+// =============================================================================
+// State Commands
+// =============================================================================
+
+var (
+	stateConfirm         string
+	stateRestoreSnapshot string
+)
+
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Inspect and edit Terraform state for a component.",
+	Long: `Run terraform state operations against a single Terraform component. Each subcommand takes the component name as its first argument and runs with the same TF_DATA_DIR, backend configuration, and environment windsor uses for plan and apply, so there is no need to reconstruct them by hand through 'windsor exec'.
+
+Every subcommand holds the stack lock for the current context. The destructive subcommands (mv, rm, push, restore) first write a snapshot of the component's current state under .windsor/contexts/<context>/state-snapshots/<component>/ and then require confirmation: type the component name at the prompt, or pass --confirm=<component> to satisfy the gate non-interactively. The --confirm value must match the component name exactly; mismatches abort the operation.
+
+Apply, destroy, and backend migration also snapshot each component's state before changing it. Apply and destroy skip the snapshot when the state is unchanged since the newest one, and keep the newest terraform.snapshots.keep of theirs per component (default 10). Snapshots taken by the state subcommands, by restore, and before a backend migration carry a -edit, -restore or -migrate suffix and are never rotated out. Set terraform.snapshots.enabled to false to turn the automatic snapshots off. 'windsor state restore' pushes a snapshot back.`,
+	Example: `# List resources in the cluster component's state
+windsor state list cluster
+
+# Move a resource to a new address
+windsor state mv cluster module.main.aws_eks_cluster.this module.main.aws_eks_cluster.main --confirm=cluster
+
+# Back up the raw state document
+windsor state pull cluster > cluster.tfstate
+
+# Roll back to a snapshot taken before the last apply
+windsor state restore cluster --snapshot 20260101T120000.000000000Z --confirm=cluster`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`apply terraform`](apply-terraform.md), [`destroy terraform`](destroy-terraform.md), [`unlock`](unlock.md)",
+		"docs.source":  "cmd/state.go",
+	},
+}
+
+var stateListCmd = &cobra.Command{
+	Use:   "list <component> [address...]",
+	Short: "List resources in a component's state.",
+	Long:  `List the resources recorded in a Terraform component's state. Optional addresses filter the listing, as with 'terraform state list'.`,
+	Example: `# List every resource
+windsor state list cluster
+
+# List resources under one module
+windsor state list cluster module.main`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`state`](state.md), [`state show`](state-show.md)",
+		"docs.source":  "cmd/state.go",
+	},
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStateCommand(cmd, args[0], false, append([]string{"list"}, args[1:]...)...)
+	},
+}
+
+var stateShowCmd = &cobra.Command{
+	Use:     "show <component> <address>",
+	Short:   "Show a single resource in a component's state.",
+	Long:    `Show the attributes of a single resource in a Terraform component's state. Values windsor knows to be secrets are masked in the output.`,
+	Example: `windsor state show cluster module.main.aws_eks_cluster.this`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`state`](state.md), [`state list`](state-list.md)",
+		"docs.source":  "cmd/state.go",
+	},
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStateCommand(cmd, args[0], false, "show", args[1])
+	},
+}
+
+var stateMvCmd = &cobra.Command{
+	Use:     "mv <component> <source> <destination>",
+	Short:   "Move a resource to a new address in a component's state.",
+	Long:    `Move a resource to a new address within a Terraform component's state. Snapshots the current state first and requires confirmation; inherits --confirm from the parent 'state' command.`,
+	Example: `windsor state mv cluster module.main.aws_eks_cluster.this module.main.aws_eks_cluster.main --confirm=cluster`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`state`](state.md), [`state rm`](state-rm.md)",
+		"docs.source":  "cmd/state.go",
+	},
+	Args:         cobra.ExactArgs(3),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStateCommand(cmd, args[0], true, "mv", args[1], args[2])
+	},
+}
+
+var stateRmCmd = &cobra.Command{
+	Use:     "rm <component> <address>...",
+	Short:   "Remove resources from a component's state.",
+	Long:    `Remove one or more resources from a Terraform component's state without destroying them. Snapshots the current state first and requires confirmation; inherits --confirm from the parent 'state' command.`,
+	Example: `windsor state rm cluster module.main.kubernetes_namespace.legacy --confirm=cluster`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`state`](state.md), [`state mv`](state-mv.md)",
+		"docs.source":  "cmd/state.go",
+	},
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStateCommand(cmd, args[0], true, append([]string{"rm"}, args[1:]...)...)
+	},
+}
+
+var statePullCmd = &cobra.Command{
+	Use:     "pull <component>",
+	Short:   "Print a component's raw state.",
+	Long:    `Print a Terraform component's current state document to stdout. The document is written unmodified, without secret masking, so it can be saved and later restored with 'windsor state push'.`,
+	Example: `windsor state pull cluster > cluster.tfstate`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`state`](state.md), [`state push`](state-push.md)",
+		"docs.source":  "cmd/state.go",
+	},
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStateCommand(cmd, args[0], false, "pull")
+	},
+}
+
+var statePushCmd = &cobra.Command{
+	Use:     "push <component> <file>",
+	Short:   "Replace a component's state with a local file.",
+	Long:    `Upload a local state file as a Terraform component's state. Terraform refuses files whose lineage differs or whose serial is older than the current state. Snapshots the current state first and requires confirmation; inherits --confirm from the parent 'state' command.`,
+	Example: `windsor state push cluster cluster.tfstate --confirm=cluster`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`state`](state.md), [`state pull`](state-pull.md)",
+		"docs.source":  "cmd/state.go",
+	},
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statePath, err := filepath.Abs(args[1])
+		if err != nil {
+			return fmt.Errorf("error resolving state file path: %w", err)
+		}
+		return runStateCommand(cmd, args[0], true, "push", statePath)
+	},
+}
+
+var stateRestoreCmd = &cobra.Command{
+	Use:   "restore <component>",
+	Short: "Restore a component's state from a snapshot.",
+	Long: `Push a snapshot from the component's snapshot store back as its current state. The snapshot must share the current state's lineage; snapshots from an unrelated state are refused. The snapshot's serial is raised above the current serial so terraform accepts the older document. The state being replaced is itself snapshotted first, so a restore can be undone the same way. Requires confirmation; inherits --confirm from the parent 'state' command.
+
+Snapshot IDs are the file names under .windsor/contexts/<context>/state-snapshots/<component>/, with or without the .tfstate extension. An unknown ID is reported together with the IDs that exist.`,
+	Example: `windsor state restore cluster --snapshot 20260101T120000.000000000Z --confirm=cluster`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`state`](state.md), [`state push`](state-push.md)",
+		"docs.source":  "cmd/state.go",
+	},
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		componentID := args[0]
+
+		proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true, StackLock: true})
+		if err != nil {
+			return err
+		}
+
+		if err := requireCloudAuth(cmd, proj); err != nil {
+			return err
+		}
+
+		blueprint := proj.Composer.BlueprintHandler.Generate()
+
+		desc := fmt.Sprintf("This will replace the Terraform state of component %q with snapshot %s.", componentID, stateRestoreSnapshot)
+		if err := resolveStateConfirmation(cmd.InOrStdin(), cmd.ErrOrStderr(), desc, componentID); err != nil {
+			return err
+		}
+
+		return stacklock.With(cmd.Context(), proj.Runtime, "state", lockTimeout, func() error {
+			backupPath, err := proj.Provisioner.RestoreTerraformState(blueprint, componentID, stateRestoreSnapshot)
+			if backupPath != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "State snapshot saved to %s\n", backupPath)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Restored %s from snapshot %s\n", componentID, stateRestoreSnapshot)
+			return nil
+		})
+	},
+}
+
+// runStateCommand runs `terraform state <tfArgs...>` for componentID under the stack lock and
+// writes the output to stdout. Destructive operations take the lock exclusively, are confirmed
+// against the component name before the lock is taken, and snapshot the component's state
+// before running; read-only ones take it shared, so they run alongside plans and each other.
+func runStateCommand(cmd *cobra.Command, componentID string, destructive bool, tfArgs ...string) error {
+	// `state` only invokes terraform. Secrets backends are required because the component
+	// environment can dereference 1Password / SOPS-encrypted values.
+	proj, err := prepareProject(cmd, tools.Requirements{Terraform: true, Secrets: true, StackLock: true})
+	if err != nil {
+		return err
+	}
+
+	if err := requireCloudAuth(cmd, proj); err != nil {
+		return err
+	}
+
+	blueprint := proj.Composer.BlueprintHandler.Generate()
+
+	if destructive {
+		desc := fmt.Sprintf("This will modify the Terraform state of component %q.", componentID)
+		if err := resolveStateConfirmation(cmd.InOrStdin(), cmd.ErrOrStderr(), desc, componentID); err != nil {
+			return err
+		}
+	}
+
+	mode := stacklock.Shared
+	if destructive {
+		mode = stacklock.Exclusive
+	}
+	return stacklock.WithMode(cmd.Context(), proj.Runtime, "state", mode, lockTimeout, func() error {
+		if destructive {
+			snapshotPath, err := proj.Provisioner.SnapshotTerraformState(blueprint, componentID)
+			if err != nil {
+				return fmt.Errorf("error snapshotting state for %s: %w", componentID, err)
+			}
+			if snapshotPath != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "State snapshot saved to %s\n", snapshotPath)
+			}
+		}
+
+		output, err := proj.Provisioner.TerraformState(blueprint, componentID, tfArgs...)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), output)
+		return nil
+	})
+}
+
+// resolveStateConfirmation gates a destructive state operation. When --confirm was supplied
+// it must match expected exactly; otherwise the operator is prompted as for destroy.
+func resolveStateConfirmation(r io.Reader, w io.Writer, description, expected string) error {
+	if stateConfirm != "" {
+		if stateConfirm != expected {
+			return fmt.Errorf("confirmation failed: --confirm did not match %q", expected)
+		}
+		return nil
+	}
+	return confirmDestroy(r, w, description, expected)
+}
+
+func init() {
+	stateCmd.PersistentFlags().StringVar(&stateConfirm, "confirm", "", "Component name to confirm a destructive state operation. Must match the prompt token exactly; mismatches abort.")
+	stateCmd.AddCommand(stateListCmd)
+	stateCmd.AddCommand(stateShowCmd)
+	stateCmd.AddCommand(stateMvCmd)
+	stateCmd.AddCommand(stateRmCmd)
+	stateCmd.AddCommand(statePullCmd)
+	stateCmd.AddCommand(statePushCmd)
+	stateRestoreCmd.Flags().StringVar(&stateRestoreSnapshot, "snapshot", "", "ID of the snapshot to restore, as listed under the component's state-snapshots directory. Required.")
+	_ = stateRestoreCmd.MarkFlagRequired("snapshot")
+	stateCmd.AddCommand(stateRestoreCmd)
+	rootCmd.AddCommand(stateCmd)
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestStateCmd(t *testing.T) {
+	createTestStateCmd := func(source *cobra.Command) *cobra.Command {
+		stateConfirm = ""
+		stateRestoreSnapshot = ""
+		cmd := &cobra.Command{
+			Use:  source.Use,
+			RunE: source.RunE,
+		}
+		stateCmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+			cmd.PersistentFlags().AddFlag(flag)
+		})
+		source.Flags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
+		cmd.Args = source.Args
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		return cmd
+	}
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	t.Run("ListWritesStateOutput", func(t *testing.T) {
+		// Given a stack that returns a state listing
+		mocks := setupDestroyTest(t)
+		var gotArgs []string
+		mocks.TerraformStack.StateFunc = func(_ *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+			gotArgs = args
+			return "module.main.null_resource.a\n", nil
+		}
+		proj := newDestroyProject(mocks)
+
+		// When running state list for the cluster component
+		cmd := createTestStateCmd(stateListCmd)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"cluster", "module.main"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the listing is printed and the address filter is forwarded
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if stdout.String() != "module.main.null_resource.a\n" {
+			t.Errorf("Expected state listing on stdout, got %q", stdout.String())
+		}
+		if strings.Join(gotArgs, " ") != "list module.main" {
+			t.Errorf("Expected args [list module.main], got %v", gotArgs)
+		}
+	})
+
+	t.Run("RmSnapshotsBeforeRunning", func(t *testing.T) {
+		// Given a stack that records the order of snapshot and state calls
+		mocks := setupDestroyTest(t)
+		var calls []string
+		mocks.TerraformStack.SnapshotStateFunc = func(_ *blueprintv1alpha1.Blueprint, componentID string) (string, error) {
+			calls = append(calls, "snapshot")
+			return "/snapshots/cluster/1.tfstate", nil
+		}
+		mocks.TerraformStack.StateFunc = func(_ *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+			calls = append(calls, args[0])
+			return "", nil
+		}
+		proj := newDestroyProject(mocks)
+
+		// When removing a resource with a matching --confirm
+		cmd := createTestStateCmd(stateRmCmd)
+		var stderr bytes.Buffer
+		cmd.SetErr(&stderr)
+		cmd.SetArgs([]string{"--confirm=cluster", "cluster", "null_resource.a"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the state is snapshotted first and the snapshot path is reported
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if strings.Join(calls, ",") != "snapshot,rm" {
+			t.Errorf("Expected snapshot before rm, got %v", calls)
+		}
+		if !strings.Contains(stderr.String(), "State snapshot saved to /snapshots/cluster/1.tfstate") {
+			t.Errorf("Expected snapshot path on stderr, got %q", stderr.String())
+		}
+	})
+
+	t.Run("MvRefusesMismatchedConfirm", func(t *testing.T) {
+		// Given a stack that records whether state was touched
+		mocks := setupDestroyTest(t)
+		touched := false
+		mocks.TerraformStack.StateFunc = func(_ *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+			touched = true
+			return "", nil
+		}
+		proj := newDestroyProject(mocks)
+
+		// When moving a resource with a --confirm that names another component
+		cmd := createTestStateCmd(stateMvCmd)
+		cmd.SetArgs([]string{"--confirm=dns", "cluster", "a.b", "a.c"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the operation aborts without touching state
+		if err == nil || !strings.Contains(err.Error(), "confirmation failed") {
+			t.Errorf("Expected confirmation failure, got %v", err)
+		}
+		if touched {
+			t.Error("Expected state to be left untouched on confirmation failure")
+		}
+	})
+
+	t.Run("PushResolvesAbsolutePath", func(t *testing.T) {
+		// Given a stack that records the pushed file path
+		mocks := setupDestroyTest(t)
+		var gotArgs []string
+		mocks.TerraformStack.StateFunc = func(_ *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+			gotArgs = args
+			return "", nil
+		}
+		proj := newDestroyProject(mocks)
+
+		// When pushing a relative state file path after interactive confirmation
+		cmd := createTestStateCmd(statePushCmd)
+		cmd.SetIn(strings.NewReader("cluster\n"))
+		cmd.SetArgs([]string{"cluster", "cluster.tfstate"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then terraform receives an absolute path, since it runs with -chdir
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(gotArgs) != 2 || gotArgs[0] != "push" || !filepath.IsAbs(gotArgs[1]) {
+			t.Errorf("Expected [push <absolute path>], got %v", gotArgs)
+		}
+	})
+
+	t.Run("RestoreReportsBackupAndSnapshot", func(t *testing.T) {
+		// Given a stack that restores a snapshot and backs up the replaced state
+		mocks := setupDestroyTest(t)
+		var gotSnapshot string
+		mocks.TerraformStack.RestoreStateFunc = func(_ *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error) {
+			gotSnapshot = snapshotID
+			return "/snapshots/cluster/2.tfstate", nil
+		}
+		proj := newDestroyProject(mocks)
+
+		// When restoring a snapshot with a matching --confirm
+		cmd := createTestStateCmd(stateRestoreCmd)
+		var stderr bytes.Buffer
+		cmd.SetErr(&stderr)
+		cmd.SetArgs([]string{"--confirm=cluster", "--snapshot=1", "cluster"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the snapshot ID is forwarded and both the backup and the restore are reported
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotSnapshot != "1" {
+			t.Errorf("Expected snapshot ID 1 to be forwarded, got %q", gotSnapshot)
+		}
+		if !strings.Contains(stderr.String(), "State snapshot saved to /snapshots/cluster/2.tfstate") {
+			t.Errorf("Expected backup path on stderr, got %q", stderr.String())
+		}
+		if !strings.Contains(stderr.String(), "Restored cluster from snapshot 1") {
+			t.Errorf("Expected restore confirmation on stderr, got %q", stderr.String())
+		}
+	})
+
+	t.Run("RestoreRefusesMismatchedConfirm", func(t *testing.T) {
+		// Given a stack that records whether a restore ran
+		mocks := setupDestroyTest(t)
+		restored := false
+		mocks.TerraformStack.RestoreStateFunc = func(_ *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error) {
+			restored = true
+			return "", nil
+		}
+		proj := newDestroyProject(mocks)
+
+		// When restoring with a --confirm that names another component
+		cmd := createTestStateCmd(stateRestoreCmd)
+		cmd.SetArgs([]string{"--confirm=dns", "--snapshot=1", "cluster"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the restore aborts without touching state
+		if err == nil || !strings.Contains(err.Error(), "confirmation failed") {
+			t.Errorf("Expected confirmation failure, got %v", err)
+		}
+		if restored {
+			t.Error("Expected state to be left untouched on confirmation failure")
+		}
+	})
+}
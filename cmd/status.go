@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/windsorcli/cli/pkg/runtime/tools"
+	tuistatus "github.com/windsorcli/cli/pkg/tui/status"
+)
+
+var statusNoColor bool
+var statusJSON bool
+var statusWatch bool
+var statusInterval time.Duration
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the reconciliation state of the deployed blueprint.",
+	Long: `Show how far the cluster has reconciled the blueprint.
+
+Every kustomization the blueprint deploys — including the crds layer and the compiled flux system tiers — is listed with its state (Ready, Reconciling, Failed, Suspended or NotFound) and the revision flux last applied. A kustomization waiting on a dependsOn entry shows what it is blocked on, and a failing one shows flux's message. Beneath each kustomization are its source and the HelmReleases in its inventory, each with its own state. The header shows the phase of the applied-version marker and the sources it records.
+
+Nothing is changed; the cluster is only read.
+
+With --watch the dashboard is redrawn every --interval until interrupted; read errors are shown and the next refresh is attempted. With --json and --watch, one JSON document is written per refresh.`,
+	Example: `# Show reconciliation state
+windsor status
+
+# Redraw every 5 seconds while a bootstrap or upgrade runs
+windsor status --watch
+
+# Machine-readable status
+windsor status --json`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`apply`](apply.md), [`drift`](drift.md), [`upgrade`](upgrade.md)",
+		"docs.source":  "cmd/status.go",
+	},
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Status only reads flux objects through the kubernetes API, so it needs the
+		// kubeconfig surface and nothing from terraform.
+		proj, err := prepareProject(cmd, tools.Requirements{Secrets: true, Kubelogin: true})
+		if err != nil {
+			return err
+		}
+		if statusInterval <= 0 {
+			return fmt.Errorf("--interval must be positive, got %s", statusInterval)
+		}
+
+		blueprint := proj.Composer.BlueprintHandler.Generate()
+		noColor := statusNoColor || os.Getenv("NO_COLOR") != ""
+		out := cmd.OutOrStdout()
+
+		render := func() error {
+			summary, err := proj.Provisioner.Status(blueprint)
+			if err != nil {
+				return fmt.Errorf("error reading status: %w", err)
+			}
+			if statusJSON {
+				return tuistatus.SummaryJSON(out, summary)
+			}
+			tuistatus.Summary(out, summary, noColor)
+			return nil
+		}
+
+		if !statusWatch {
+			return render()
+		}
+
+		ctx := cmd.Context()
+		for {
+			if !statusJSON {
+				fmt.Fprint(out, "\033[H\033[2J")
+			}
+			if err := render(); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(statusInterval):
+			}
+		}
+	},
+}
+
+func init() {
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 5*time.Second, "Refresh interval for --watch.")
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output the status as JSON.")
+	statusCmd.Flags().BoolVar(&statusNoColor, "no-color", false, "Disable color output.")
+	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Redraw the status until interrupted.")
+	rootCmd.AddCommand(statusCmd)
+}
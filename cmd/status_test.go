@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/windsorcli/cli/pkg/composer"
+	"github.com/windsorcli/cli/pkg/project"
+	"github.com/windsorcli/cli/pkg/provisioner"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// newStatusProject wires the plan mocks, a seeded kubeconfig and the given kubernetes
+// manager into a project for status tests.
+func newStatusProject(t *testing.T, km *kubernetes.MockKubernetesManager) *project.Project {
+	t.Helper()
+	mocks := setupPlanTest(t)
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".kube"), 0755); err != nil {
+		t.Fatalf("seed kubeconfig dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".kube", "config"), []byte("apiVersion: v1\nkind: Config\n"), 0644); err != nil {
+		t.Fatalf("seed kubeconfig: %v", err)
+	}
+	mocks.Runtime.ConfigRoot = root
+	comp := composer.NewComposer(mocks.Runtime)
+	comp.BlueprintHandler = mocks.BlueprintHandler
+	prov := provisioner.NewProvisioner(mocks.Runtime, comp.BlueprintHandler, &provisioner.Provisioner{
+		TerraformStack:    mocks.TerraformStack,
+		KubernetesManager: km,
+	})
+	return project.NewProject("", &project.Project{Runtime: mocks.Runtime, Composer: comp, Provisioner: prov})
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestStatusCmd(t *testing.T) {
+	createTestStatusCmd := func() *cobra.Command {
+		statusJSON = false
+		statusNoColor = false
+		statusWatch = false
+		statusInterval = 5 * time.Second
+		cmd := &cobra.Command{
+			Use:  "status",
+			RunE: statusCmd.RunE,
+		}
+		statusCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		return cmd
+	}
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	statuses := func(names []string, namespaces map[string]string) ([]kubernetes.KustomizationStatus, error) {
+		return []kubernetes.KustomizationStatus{
+			{FluxStatus: kubernetes.FluxStatus{Name: "ingress", State: kubernetes.FluxStateReady, Revision: "main@sha1:abc"}},
+			{FluxStatus: kubernetes.FluxStatus{Name: "dns", State: kubernetes.FluxStateFailed, Message: "kustomize build failed"}},
+		}, nil
+	}
+
+	t.Run("RendersDashboard", func(t *testing.T) {
+		// Given a cluster with a ready and a failed kustomization
+		km := kubernetes.NewMockKubernetesManager()
+		km.GetKustomizationStatusesFunc = statuses
+		proj := newStatusProject(t, km)
+
+		// When running status
+		cmd := createTestStatusCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--no-color"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then both rows and the failure message are shown
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, want := range []string{"ingress", "main@sha1:abc", "dns", "kustomize build failed", "1 Ready, 1 Failed"} {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+			}
+		}
+	})
+
+	t.Run("RendersJSON", func(t *testing.T) {
+		// Given a cluster with a ready and a failed kustomization
+		km := kubernetes.NewMockKubernetesManager()
+		km.GetKustomizationStatusesFunc = statuses
+		proj := newStatusProject(t, km)
+
+		// When running status with --json
+		cmd := createTestStatusCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then a JSON document reports the cluster as not ready
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(stdout.String(), `"ready": false`) || !strings.Contains(stdout.String(), `"name": "dns"`) {
+			t.Errorf("Expected JSON status, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("ReturnsReadError", func(t *testing.T) {
+		// Given a cluster whose kustomizations cannot be listed
+		km := kubernetes.NewMockKubernetesManager()
+		km.GetKustomizationStatusesFunc = func(names []string, namespaces map[string]string) ([]kubernetes.KustomizationStatus, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+		proj := newStatusProject(t, km)
+
+		// When running status
+		cmd := createTestStatusCmd()
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the read error is returned
+		if err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Errorf("Expected read error, got %v", err)
+		}
+	})
+
+	t.Run("WatchRefreshesUntilCancelled", func(t *testing.T) {
+		// Given a cluster whose status is counted on every read
+		km := kubernetes.NewMockKubernetesManager()
+		reads := 0
+		ctx, cancel := context.WithCancel(context.Background())
+		km.GetKustomizationStatusesFunc = func(names []string, namespaces map[string]string) ([]kubernetes.KustomizationStatus, error) {
+			reads++
+			if reads == 2 {
+				cancel()
+			}
+			return statuses(names, namespaces)
+		}
+		proj := newStatusProject(t, km)
+		ctx = context.WithValue(ctx, projectOverridesKey, proj)
+
+		// When running status --watch with a short interval
+		cmd := createTestStatusCmd()
+		cmd.SetArgs([]string{"--watch", "--interval", "10ms", "--no-color"})
+		cmd.SetContext(ctx)
+		err := cmd.Execute()
+
+		// Then it refreshes until the context is cancelled and exits cleanly
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if reads != 2 {
+			t.Errorf("Expected 2 refreshes, got %d", reads)
+		}
+	})
+}
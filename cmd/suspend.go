@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/project"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+	"github.com/windsorcli/cli/pkg/provisioner/stacklock"
+	"github.com/windsorcli/cli/pkg/runtime/tools"
+)
+
+// =============================================================================
+// Suspend Commands
+// =============================================================================
+
+var (
+	suspendAll    bool
+	suspendReason string
+)
+
+var suspendCmd = &cobra.Command{
+	Use:   "suspend",
+	Short: "Stop Flux from reconciling blueprint resources.",
+	Long: `Suspend Flux reconciliation of blueprint resources, for example to hold a manual fix in place during an incident without Flux reverting it. Use the 'kustomize' subcommand to choose what to suspend.
+
+Each suspension records who made it, why, and when in the windsorcli.dev/suspension annotation. 'windsor apply' and 'windsor install' refuse to run while blueprint resources are suspended, listing the suspensions, unless --force is passed, so a suspension is never silently undone. Lift a suspension with 'windsor resume'.`,
+	Example: `# Suspend the dns kustomization and its HelmReleases
+windsor suspend kustomize dns --reason "hotfixing coredns config"`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`resume`](resume.md), [`status`](status.md), [`apply`](apply.md)",
+		"docs.source":  "cmd/suspend.go",
+	},
+}
+
+var suspendKustomizeCmd = &cobra.Command{
+	Use:     "kustomize [name]",
+	Aliases: []string{"k8s"},
+	Short:   "Suspend a Flux kustomization and the HelmReleases it owns.",
+	Long: `Set spec.suspend on a blueprint kustomization's Flux Kustomization and on every HelmRelease it applies, so Flux stops reconciling them. Pass --all instead of a name to suspend every kustomization in the blueprint.
+
+Pass --reason to record why the kustomization was suspended; the reason is shown to anyone who later runs 'windsor apply' or 'windsor install' against the suspended resources.`,
+	Example: `# Suspend one kustomization
+windsor suspend kustomize dns --reason "hotfixing coredns config"
+
+# Suspend every kustomization in the blueprint
+windsor suspend kustomize --all --reason "cluster maintenance"`,
+	Annotations: map[string]string{
+		"docs.seealso": "[`suspend`](suspend.md), [`resume kustomize`](resume-kustomize.md)",
+		"docs.source":  "cmd/suspend.go",
+	},
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := suspendTargetArgs(args, suspendAll)
+		if err != nil {
+			return err
+		}
+
+		// `suspend kustomize` only patches objects through the cluster API.
+		proj, err := prepareProject(cmd, tools.Requirements{Kubelogin: true, StackLock: true})
+		if err != nil {
+			return err
+		}
+
+		blueprint := proj.Composer.BlueprintHandler.Generate()
+		if blueprint == nil {
+			return fmt.Errorf("blueprint is not available")
+		}
+
+		suspension := kubernetes.Suspension{By: stacklock.HolderIdentity(), Reason: suspendReason, At: time.Now().UTC()}
+		return stacklock.With(cmd.Context(), proj.Runtime, "suspend", lockTimeout, func() error {
+			suspended, err := proj.Provisioner.Suspend(blueprint, names, suspension)
+			writeSuspendedObjects(cmd.OutOrStdout(), "Suspended", suspended)
+			if err != nil {
+				return fmt.Errorf("error suspending kustomizations: %w", err)
+			}
+			return nil
+		})
+	},
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// suspendTargetArgs resolves the kustomization names a suspend or resume command targets from
+// its arguments: the single name given, or nil for every kustomization when --all is set.
+// Exactly one of the two must be supplied.
+func suspendTargetArgs(args []string, all bool) ([]string, error) {
+	switch {
+	case all && len(args) > 0:
+		return nil, fmt.Errorf("--all cannot be combined with a kustomization name")
+	case all:
+		return nil, nil
+	case len(args) == 0:
+		return nil, fmt.Errorf("a kustomization name or --all is required")
+	}
+	return args, nil
+}
+
+// writeSuspendedObjects writes one line per object, prefixed with verb.
+func writeSuspendedObjects(w io.Writer, verb string, objs []kubernetes.SuspendedObject) {
+	for _, obj := range objs {
+		fmt.Fprintf(w, "%s %s %s/%s\n", verb, obj.Kind, obj.Namespace, obj.Name)
+	}
+}
+
+// describeSuspension renders who suspended an object, why and when, for the apply and install
+// guard. Objects suspended outside windsor carry no record.
+func describeSuspension(obj kubernetes.SuspendedObject) string {
+	line := fmt.Sprintf("%s %s/%s", obj.Kind, obj.Namespace, obj.Name)
+	s := obj.Suspension
+	if s == nil {
+		return line + " (suspended outside windsor)"
+	}
+	line += fmt.Sprintf(" (suspended by %s at %s", s.By, s.At.Format(time.RFC3339))
+	if s.Reason != "" {
+		line += ": " + s.Reason
+	}
+	return line + ")"
+}
+
+// checkSuspensions guards apply and install against silently undoing a suspension. It looks up
+// suspended objects belonging to the blueprint kustomizations in scope (every kustomization when
+// scope is nil) and, when any exist, refuses with a list of the suspensions unless force is set,
+// in which case it warns that the objects stay suspended and proceeds. The lookup is best-effort:
+// a cluster that cannot be read does not block the command.
+func checkSuspensions(cmd *cobra.Command, proj *project.Project, blueprint *blueprintv1alpha1.Blueprint, scope map[string]bool, force bool) error {
+	objs, err := proj.Provisioner.SuspendedObjects(blueprint)
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	names := make(map[string]bool)
+	for _, obj := range objs {
+		if scope != nil && !scope[obj.Kustomization] {
+			continue
+		}
+		lines = append(lines, describeSuspension(obj))
+		names[obj.Kustomization] = true
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	list := strings.Join(lines, "\n  ")
+	if !force {
+		resume := "windsor resume kustomize <name>"
+		if len(names) == 1 {
+			for name := range names {
+				resume = "windsor resume kustomize " + name
+			}
+		}
+		return fmt.Errorf("blueprint resources are suspended:\n  %s\nrun '%s' to lift the suspension, or pass --force to proceed and leave them suspended", list, resume)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Warning: blueprint resources are suspended and Flux will not reconcile them until they are resumed:\n  %s\n", list)
+	return nil
+}
+
+func init() {
+	suspendKustomizeCmd.Flags().BoolVar(&suspendAll, "all", false, "Suspend every kustomization in the blueprint.")
+	suspendKustomizeCmd.Flags().StringVar(&suspendReason, "reason", "", "Why the kustomization is being suspended, recorded with the suspension.")
+	suspendCmd.AddCommand(suspendKustomizeCmd)
+	rootCmd.AddCommand(suspendCmd)
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/composer/blueprint"
+	"github.com/windsorcli/cli/pkg/project"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// newSuspendProject returns a status test project whose blueprint declares the dns and
+// ingress kustomizations.
+func newSuspendProject(t *testing.T, km *kubernetes.MockKubernetesManager) *project.Project {
+	t.Helper()
+	proj := newStatusProject(t, km)
+	bp := &blueprintv1alpha1.Blueprint{
+		Metadata:       blueprintv1alpha1.Metadata{Name: "test"},
+		Kustomizations: []blueprintv1alpha1.Kustomization{{Name: "dns"}, {Name: "ingress"}},
+	}
+	proj.Composer.BlueprintHandler.(*blueprint.MockBlueprintHandler).GenerateFunc = func() *blueprintv1alpha1.Blueprint { return bp }
+	return proj
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestSuspendKustomizeCmd(t *testing.T) {
+	createTestSuspendCmd := func() *cobra.Command {
+		suspendAll = false
+		suspendReason = ""
+		cmd := &cobra.Command{
+			Use:  "kustomize",
+			Args: suspendKustomizeCmd.Args,
+			RunE: suspendKustomizeCmd.RunE,
+		}
+		suspendKustomizeCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			cmd.Flags().AddFlag(flag)
+		})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		return cmd
+	}
+
+	suppressProcessStdout(t)
+	suppressProcessStderr(t)
+
+	t.Run("SuspendsWithReason", func(t *testing.T) {
+		// Given a cluster holding the dns kustomization and one HelmRelease it owns
+		km := kubernetes.NewMockKubernetesManager()
+		var got kubernetes.Suspension
+		var gotName string
+		km.SuspendKustomizationFunc = func(name, namespace string, s kubernetes.Suspension) ([]kubernetes.SuspendedObject, error) {
+			gotName, got = name, s
+			return []kubernetes.SuspendedObject{
+				{Kind: "Kustomization", Namespace: namespace, Name: name, Kustomization: name, Suspension: &s},
+				{Kind: "HelmRelease", Namespace: "dns", Name: "coredns", Kustomization: name, Suspension: &s},
+			}, nil
+		}
+		proj := newSuspendProject(t, km)
+
+		// When suspending dns with a reason
+		cmd := createTestSuspendCmd()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"dns", "--reason", "hotfixing coredns"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then dns is suspended with who, why and when recorded, and each object is listed
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotName != "dns" || got.Reason != "hotfixing coredns" || got.By == "" || got.At.IsZero() {
+			t.Errorf("Expected dns suspended with a full record, got %q %+v", gotName, got)
+		}
+		for _, want := range []string{"Suspended Kustomization system-gitops/dns", "Suspended HelmRelease dns/coredns"} {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+			}
+		}
+	})
+
+	t.Run("AllSuspendsEveryKustomization", func(t *testing.T) {
+		// Given a blueprint with two kustomizations
+		km := kubernetes.NewMockKubernetesManager()
+		var names []string
+		km.SuspendKustomizationFunc = func(name, namespace string, s kubernetes.Suspension) ([]kubernetes.SuspendedObject, error) {
+			names = append(names, name)
+			return []kubernetes.SuspendedObject{{Kind: "Kustomization", Namespace: namespace, Name: name, Kustomization: name}}, nil
+		}
+		proj := newSuspendProject(t, km)
+
+		// When suspending with --all
+		cmd := createTestSuspendCmd()
+		cmd.SetArgs([]string{"--all"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then both are suspended
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if strings.Join(names, ",") != "dns,ingress" {
+			t.Errorf("Expected dns and ingress suspended, got %v", names)
+		}
+	})
+
+	t.Run("RejectsUndeclaredKustomization", func(t *testing.T) {
+		// Given a blueprint without a monitoring kustomization
+		proj := newSuspendProject(t, kubernetes.NewMockKubernetesManager())
+
+		// When suspending monitoring
+		cmd := createTestSuspendCmd()
+		cmd.SetArgs([]string{"monitoring"})
+		cmd.SetContext(context.WithValue(context.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the name is rejected
+		if err == nil || !strings.Contains(err.Error(), `"monitoring" not found in blueprint`) {
+			t.Errorf("Expected undeclared kustomization error, got %v", err)
+		}
+	})
+
+	t.Run("RequiresNameOrAll", func(t *testing.T) {
+		for _, args := range [][]string{{}, {"dns", "--all"}} {
+			// Given neither or both of a name and --all
+			cmd := createTestSuspendCmd()
+			cmd.SetArgs(args)
+			cmd.SetContext(context.Background())
+
+			// When suspending
+			err := cmd.Execute()
+
+			// Then the arguments are rejected before the project is loaded
+			if err == nil {
+				t.Errorf("Expected an argument error for %v, got nil", args)
+			}
+		}
+	})
+}
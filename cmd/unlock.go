@@ -20,7 +20,11 @@ var unlockCmd = &cobra.Command{
 	Short: "Release a stuck stack lock.",
 	Long: `Force-release a stuck stack lock for the current context.
 
-A holder killed before it could release (CI cancellation, OOM, crash) leaves the lock behind, so later commands block until timeout and then fail. This clears it. It does not check whether the holder is still alive, so only run it when no other windsor process is using this context.`,
+A holder killed before it could release (CI cancellation, OOM, crash) leaves the lock behind, so later commands block until timeout and then fail. This clears it. It does not check whether the holder is still alive, so only run it when no other windsor process is using this context.
+
+When the context's terraform backend is kubernetes, the stack lock is a Lease named windsor-stacklock-<context> in the gitops namespace of the cluster, so it is shared by every operator whose kubeconfig reaches that cluster, and unlock clears it remotely. A Lease whose holder stops renewing it is taken over automatically after a minute.
+
+When the backend is s3, the stack lock is an object at .windsor-stacklock/<context>.json under the backend prefix in the state bucket, written with conditional puts through the aws CLI so any S3-compatible store that honours If-None-Match (including MinIO via AWS_ENDPOINT_URL_S3) works. Its holder refreshes a heartbeat while it runs; a lock whose heartbeat is more than a minute old is taken over automatically, and unlock deletes the object.`,
 	Example: `# Clear a stuck lock interactively
 windsor unlock
 # → prompts: Type "local" to confirm:
@@ -35,10 +39,12 @@ windsor unlock --force`,
 	Args:         cobra.NoArgs,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// unlock only touches the local lock files; no terraform/k8s/docker tools are
+		// unlock only touches the stack lock (local files, the context's Lease when the
+		// terraform backend is kubernetes, or its object in an s3 backend's bucket, which
+		// uses the aws CLI already on the path); no terraform/k8s/docker tools are
 		// needed. Skip-validation so a deployed-but-misordered blueprint can't block
 		// the recovery path that exists precisely to unstick such a context.
-		proj, err := prepareProjectSkipValidation(cmd, tools.Requirements{})
+		proj, err := prepareProjectSkipValidation(cmd, tools.Requirements{StackLock: true})
 		if err != nil {
 			return err
 		}
@@ -21,6 +21,8 @@ var (
 	upPlatform  string
 	upBlueprint string
 	upSetFlags  []string
+
+	upMaxConcurrency int
 )
 
 var upCmd = &cobra.Command{
@@ -28,9 +30,11 @@ var upCmd = &cobra.Command{
 	Short: "Bring up the local workstation environment.",
 	Long: `Start the workstation VM, run Terraform components, then install the Flux blueprint. Workstation contexts only — for non-workstation contexts, use 'windsor apply'. If the current context has no workstation, up exits with a hint and does no work.
 
-Returns once the install request has been issued. Pass --wait to block until kustomizations report ready.
+Returns once the install request has been issued. Pass --wait to block until kustomizations report ready. While waiting, Warning events on those kustomizations, their HelmReleases and the workloads they deploy are printed as they appear, and a timeout names the deepest failing kustomization in each stuck dependency chain.
+
+If any host-side network or DNS configuration was deferred (because it requires sudo / elevation), up prints a follow-up command at the end so the operator knows what to run next.
 
-If any host-side network or DNS configuration was deferred (because it requires sudo / elevation), up prints a follow-up command at the end so the operator knows what to run next.`,
+As with 'windsor apply', terraform and kustomization plans that violate a policy under policies/ are refused before they are applied.`,
 	Example: `# Bring up the workstation and wait for everything to be ready
 windsor up --wait
 
@@ -108,6 +112,8 @@ windsor up --blueprint=ghcr.io/myorg/blueprint:v1.0.0`,
 			return err
 		}
 
+		proj.Provisioner.SetTerraformConcurrency(upMaxConcurrency)
+
 		var halted bool
 		var postRunMessages []blueprintv1alpha1.Message
 		if err := stacklock.With(cmd.Context(), proj.Runtime, "up", lockTimeout, func() error {
@@ -223,5 +229,6 @@ func init() {
 	upCmd.Flags().StringVar(&upPlatform, "platform", "", "Target platform: none, docker, incus, metal, hetzner, aws, azure, gcp, hyperv, vsphere.")
 	upCmd.Flags().StringVar(&upBlueprint, "blueprint", "", "Blueprint OCI reference or local path.")
 	upCmd.Flags().StringSliceVar(&upSetFlags, "set", []string{}, "Override config values, e.g. --set dns.enabled=false. May be repeated.")
+	upCmd.Flags().IntVar(&upMaxConcurrency, "max-concurrency", 1, "Maximum number of independent terraform components to apply at once.")
 	rootCmd.AddCommand(upCmd)
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -13,9 +14,12 @@ import (
 	"github.com/windsorcli/cli/pkg/constants"
 	"github.com/windsorcli/cli/pkg/project"
 	"github.com/windsorcli/cli/pkg/provisioner"
+	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
 	"github.com/windsorcli/cli/pkg/provisioner/stacklock"
 	"github.com/windsorcli/cli/pkg/runtime"
 	"github.com/windsorcli/cli/pkg/runtime/tools"
+	tuiplan "github.com/windsorcli/cli/pkg/tui/plan"
 )
 
 var (
@@ -24,6 +28,7 @@ var (
 	upgradeSources        []string
 	upgradeYes            bool
 	upgradeAllowDowngrade bool
+	upgradeAllowDataLoss  bool
 	upgradeRebootMode     string
 
 	upgradeNodeAddr           string
@@ -38,6 +43,8 @@ var upgradeCmd = &cobra.Command{
 	Short: "Move sources to their latest version and reconcile the blueprint.",
 	Long: `With no arguments, move every declared OCI source to its latest stable version, then reconcile: apply terraform and the Flux blueprint, wait, and prune kustomizations this context no longer declares. Use --source name=url to move named sources to specific versions instead. The whole reconcile — including the prune — is gated by --yes.
 
+Before applying, upgrade lists how each kustomization moves: new, changed (with its source's applied and target versions), migrated (taking over the objects of a kustomization the blueprint no longer declares, as when one is renamed) or reclaimed (no longer declared, so pruned). Before pruning, every object the pruned kustomizations own is listed as deleted, retained (annotated kustomize.toolkit.fluxcd.io/prune: disabled or helm.sh/resource-policy: keep) or orphaned (PersistentVolumes with a Retain reclaim policy and StatefulSet claims that outlive their owner); objects a migrated kustomization has taken over are retained. The prune is refused when it would delete PersistentVolumeClaims or PersistentVolumes along with their data, unless --allow-data-loss is passed; that check also runs before anything is applied, so a refused upgrade changes nothing. With gitops.review, the change is pushed to a review branch and neither the prune nor the applied-version record happens until an upgrade after the merge.
+
 Use the 'cluster' or 'node' subcommand to upgrade Talos nodes instead.`,
 	Example: `# Move all sources to their latest stable version and reconcile
 windsor upgrade --yes
@@ -106,6 +113,15 @@ windsor upgrade cluster --nodes=10.0.0.5 --image=ghcr.io/siderolabs/installer:v1
 		}
 
 		return stacklock.With(cmd.Context(), proj.Runtime, "upgrade", lockTimeout, func() error {
+			summary := describeUpgradeTransitions(cmd, proj, blueprint)
+
+			// Refuse a prune that would destroy data before anything is applied, so the refusal
+			// does not leave terraform and the blueprint upgraded with the old kustomizations still
+			// in place. pruneOrphaned checks again once migrated objects have been adopted.
+			if err := checkPruneDataLoss(proj, blueprint, summary, upgradeAllowDataLoss); err != nil {
+				return err
+			}
+
 			if _, err := proj.Provisioner.Up(blueprint); err != nil {
 				return fmt.Errorf("error applying terraform: %w", err)
 			}
@@ -133,11 +149,18 @@ windsor upgrade cluster --nodes=10.0.0.5 --image=ghcr.io/siderolabs/installer:v1
 				return fmt.Errorf("error waiting for kustomizations: %w", err)
 			}
 
+			// A change awaiting review has not reached the cluster: pruning now would delete what
+			// the running kustomizations still own, and the marker would record a version not yet
+			// rolled out. Both settle on the upgrade run after the review branch is merged.
+			if proj.Provisioner.AwaitingReview() {
+				return nil
+			}
+
 			prunable, err := proj.Provisioner.PrunableKustomizations(blueprint)
 			if err != nil {
 				return fmt.Errorf("error listing kustomizations to prune: %w", err)
 			}
-			if err := pruneOrphaned(cmd, proj, blueprint, prunable); err != nil {
+			if err := pruneOrphaned(cmd, proj, blueprint, prunable, upgradeAllowDataLoss); err != nil {
 				return err
 			}
 
@@ -295,21 +318,143 @@ func parseRebootMode(mode string) (bool, error) {
 	}
 }
 
-// pruneOrphaned deletes the kustomizations the blueprint no longer declares, after printing them.
-// prunable is the already-computed prune set (empty → no-op). The caller must have waited for the
-// desired set to be Ready first, so any migrated resources are adopted before a deletion. Shared by
-// apply (behind --prune) and upgrade (unconditional, since upgrade already required --yes to start).
-func pruneOrphaned(cmd *cobra.Command, proj *project.Project, blueprint *blueprintv1alpha1.Blueprint, prunable []string) error {
+// pruneOrphaned deletes the kustomizations the blueprint no longer declares, after printing them
+// and what deleting them does to each object they own. prunable is the already-computed prune set
+// (empty → no-op). The caller must have waited for the desired set to be Ready first, so any
+// migrated resources are adopted before a deletion. When the prune would delete data-bearing
+// objects it is refused unless allowDataLoss is set. Shared by apply (behind --prune) and upgrade
+// (unconditional, since upgrade already required --yes to start).
+func pruneOrphaned(cmd *cobra.Command, proj *project.Project, blueprint *blueprintv1alpha1.Blueprint, prunable []blueprintv1alpha1.Kustomization, allowDataLoss bool) error {
 	if len(prunable) == 0 {
 		return nil
 	}
-	fmt.Fprintf(cmd.OutOrStdout(), "Pruning kustomizations no longer declared:\n  %s\n", strings.Join(prunable, "\n  "))
+	objs, err := proj.Provisioner.AnalyzePrune(prunable)
+	if err != nil {
+		return fmt.Errorf("error analyzing kustomizations to prune: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Pruning kustomizations no longer declared:\n  %s\n", strings.Join(kustomizationNames(prunable), "\n  "))
+	writePruneAnalysis(cmd.OutOrStdout(), objs)
+
+	if lost := dataLossObjects(objs, nil); len(lost) > 0 && !allowDataLoss {
+		return pruneDataLossError(lost)
+	}
+
 	if err := proj.Provisioner.Prune(blueprint); err != nil {
 		return fmt.Errorf("error pruning orphaned kustomizations: %w", err)
 	}
 	return nil
 }
 
+// checkPruneDataLoss refuses, before an upgrade applies anything, the prune it ends with when that
+// would delete data-bearing objects and allowDataLoss is not set. Objects a declared kustomization's
+// plan in summary already names are left out, since a migrated kustomization adopts them before
+// the prune runs and flux then leaves them alone. summary may be nil when no plan could be made.
+func checkPruneDataLoss(proj *project.Project, blueprint *blueprintv1alpha1.Blueprint, summary *provisioner.PlanSummary, allowDataLoss bool) error {
+	if allowDataLoss {
+		return nil
+	}
+	prunable, err := proj.Provisioner.PrunableKustomizations(blueprint)
+	if err != nil {
+		return fmt.Errorf("error listing kustomizations to prune: %w", err)
+	}
+	if len(prunable) == 0 {
+		return nil
+	}
+	objs, err := proj.Provisioner.AnalyzePrune(prunable)
+	if err != nil {
+		return fmt.Errorf("error analyzing kustomizations to prune: %w", err)
+	}
+	adopted := map[string]bool{}
+	if summary != nil {
+		for _, plan := range summary.Kustomize {
+			if plan.Transition == fluxinfra.TransitionReclaimed {
+				continue
+			}
+			for _, r := range plan.Resources {
+				adopted[r.Address] = true
+			}
+		}
+	}
+	if lost := dataLossObjects(objs, adopted); len(lost) > 0 {
+		return pruneDataLossError(lost)
+	}
+	return nil
+}
+
+// dataLossObjects describes each object in objs the prune deletes along with its data, skipping
+// those whose flux address ("<Kind>/<namespace>/<name>", or "<Kind>/<name>" when cluster-scoped)
+// is in skip.
+func dataLossObjects(objs []kubernetes.PruneObject, skip map[string]bool) []string {
+	var lost []string
+	for _, obj := range objs {
+		if !obj.DataBearing || obj.Disposition != kubernetes.PruneDelete {
+			continue
+		}
+		address := obj.Kind + "/" + obj.Name
+		if obj.Namespace != "" {
+			address = obj.Kind + "/" + obj.Namespace + "/" + obj.Name
+		}
+		if skip[address] {
+			continue
+		}
+		lost = append(lost, describePruneEntry(obj.InventoryEntry))
+	}
+	return lost
+}
+
+// pruneDataLossError is the refusal returned when a prune would delete the lost objects' data.
+func pruneDataLossError(lost []string) error {
+	return fmt.Errorf("refusing to prune: deleting these objects destroys their data:\n  %s\nre-run with --allow-data-loss to delete them, or annotate them kustomize.toolkit.fluxcd.io/prune=disabled to keep them", strings.Join(lost, "\n  "))
+}
+
+// writePruneAnalysis writes one line per object a prune affects: what happens to it, which
+// kustomization owns it, and why when it is retained, orphaned or takes data with it.
+func writePruneAnalysis(w io.Writer, objs []kubernetes.PruneObject) {
+	if len(objs) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Objects they own:")
+	for _, obj := range objs {
+		line := fmt.Sprintf("  %-6s %s (%s)", obj.Disposition, describePruneEntry(obj.InventoryEntry), obj.Kustomization)
+		if obj.Reason != "" {
+			line += ": " + obj.Reason
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// kustomizationNames returns the names of kustomizations, in order.
+func kustomizationNames(kustomizations []blueprintv1alpha1.Kustomization) []string {
+	names := make([]string, 0, len(kustomizations))
+	for _, k := range kustomizations {
+		names = append(names, k.Name)
+	}
+	return names
+}
+
+// describePruneEntry renders an object as its kind and namespace/name, or name alone when it is
+// cluster-scoped.
+func describePruneEntry(e kubernetes.InventoryEntry) string {
+	if e.Namespace == "" {
+		return e.Kind + " " + e.Name
+	}
+	return fmt.Sprintf("%s %s/%s", e.Kind, e.Namespace, e.Name)
+}
+
+// describeUpgradeTransitions prints how the upgrade moves each kustomization — new, changed,
+// migrated from a kustomization it replaces, or reclaimed — before anything is applied, so a rename
+// reads as a migration rather than an unrelated delete and add, and returns the plan. It is
+// best-effort: a plan that cannot be produced prints nothing, returns nil and does not block the
+// upgrade.
+func describeUpgradeTransitions(cmd *cobra.Command, proj *project.Project, blueprint *blueprintv1alpha1.Blueprint) *provisioner.PlanSummary {
+	summary, err := proj.Provisioner.PlanKustomizeSummary(blueprint)
+	if err != nil {
+		return nil
+	}
+	tuiplan.Transitions(cmd.OutOrStdout(), summary.Kustomize)
+	return summary
+}
+
 // upgradeToLatest moves every remote OCI source pinned to a semver to its latest stable tag,
 // persists the bumps to blueprint.yaml, and prints what changed. Sources that are not OCI, not
 // semver-pinned, or already current are left untouched; it reports when nothing moved.
@@ -433,6 +578,7 @@ func init() {
 
 	upgradeCmd.Flags().StringArrayVar(&upgradeSources, "source", nil, "Retarget a declared source to a new tagged URL (name=url); repeatable. Persisted to blueprint.yaml.")
 	upgradeCmd.Flags().BoolVar(&upgradeYes, "yes", false, "Proceed without confirmation when the upgrade would prune kustomizations.")
+	upgradeCmd.Flags().BoolVar(&upgradeAllowDataLoss, "allow-data-loss", false, "Prune kustomizations even when that deletes PersistentVolumeClaims or PersistentVolumes and their data.")
 	upgradeCmd.Flags().BoolVar(&upgradeAllowDowngrade, "allow-downgrade", false, "Permit moving a source to an older version. Reverts infrastructure declaratively; does NOT reverse application data.")
 
 	upgradeClusterCmd.Flags().StringSliceVar(&upgradeNodes, "nodes", []string{}, "Node addresses to upgrade. Required.")
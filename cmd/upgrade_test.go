@@ -12,6 +12,8 @@ import (
 	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
 	"github.com/windsorcli/cli/pkg/composer/blueprint"
 	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/provisioner"
+	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
 	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
 	"github.com/windsorcli/cli/pkg/runtime/config"
 )
@@ -29,8 +31,8 @@ func TestUpgradeCmd(t *testing.T) {
 	t.Run("PrunesAfterSuccessfulWait", func(t *testing.T) {
 		// Given an upgrade with an orphaned kustomization
 		mocks := setupApplyTest(t)
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
-			return []string{"old-thing"}, nil
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}}, nil
 		}
 		pruned := false
 		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
@@ -55,6 +57,131 @@ func TestUpgradeCmd(t *testing.T) {
 		}
 	})
 
+	t.Run("ListsKustomizationTransitionsBeforeApplying", func(t *testing.T) {
+		// Given an upgrade that renames old-ingress to ingress
+		mocks := setupApplyTest(t)
+		fluxStack := fluxinfra.NewMockStack()
+		fluxStack.PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) ([]fluxinfra.KustomizePlan, []string) {
+			return []fluxinfra.KustomizePlan{
+				{Name: "dns", Transition: fluxinfra.TransitionUnchanged},
+				{Name: "ingress", Transition: fluxinfra.TransitionMigrated, MigratedFrom: []string{"old-ingress"}},
+				{Name: "old-ingress", Transition: fluxinfra.TransitionReclaimed, MigratedTo: []string{"ingress"}},
+			}, nil
+		}
+		proj := newApplyProjectWith(mocks, &provisioner.Provisioner{TerraformStack: mocks.TerraformStack, KubernetesManager: mocks.KubernetesManager, FluxStack: fluxStack})
+
+		// When executing the bare upgrade command with --yes
+		cmd := createTestUpgradeCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--yes"})
+		ctx := stdcontext.WithValue(stdcontext.Background(), projectOverridesKey, proj)
+		cmd.SetContext(ctx)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the rename is listed as a migration and a reclaim, and unchanged kustomizations are omitted
+		for _, want := range []string{"Kustomizations:", "migrated   ingress (migrated from old-ingress)", "reclaimed  old-ingress (objects migrated to ingress)"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+			}
+		}
+		if strings.Contains(out.String(), "dns") {
+			t.Errorf("Expected unchanged kustomization omitted, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("RefusesPruneThatDeletesData", func(t *testing.T) {
+		// Given an upgrade whose orphaned kustomization owns a PersistentVolume that would be deleted
+		mocks := setupApplyTest(t)
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-db"}}, nil
+		}
+		mocks.KubernetesManager.AnalyzePruneFunc = func(names []string, namespaces map[string]string) ([]kubernetes.PruneObject, error) {
+			return []kubernetes.PruneObject{
+				{InventoryEntry: kubernetes.InventoryEntry{Kind: "PersistentVolume", Name: "pv-1"}, Kustomization: "old-db", Disposition: kubernetes.PruneDelete, DataBearing: true},
+			}, nil
+		}
+		pruned := false
+		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+			pruned = true
+			return nil
+		}
+		applied := false
+		mocks.KubernetesManager.ApplyBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+			applied = true
+			return nil
+		}
+		proj := newApplyAllProject(mocks)
+
+		// When executing the upgrade without --allow-data-loss
+		cmd := createTestUpgradeCmd()
+		cmd.SetArgs([]string{"--yes"})
+		cmd.SetContext(stdcontext.WithValue(stdcontext.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the prune is refused before the blueprint is applied
+		if err == nil || !strings.Contains(err.Error(), "PersistentVolume pv-1") {
+			t.Errorf("Expected a data-loss refusal, got %v", err)
+		}
+		if pruned {
+			t.Error("Expected prune to be refused")
+		}
+		if applied {
+			t.Error("Expected the refusal before the blueprint is applied")
+		}
+	})
+
+	t.Run("AllowsPruneOfObjectsAMigrationAdopts", func(t *testing.T) {
+		// Given an upgrade renaming old-db to db, whose plan takes over the data-bearing claim
+		mocks := setupApplyTest(t)
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-db"}}, nil
+		}
+		adopted := false
+		mocks.KubernetesManager.ApplyBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+			adopted = true
+			return nil
+		}
+		mocks.KubernetesManager.AnalyzePruneFunc = func(names []string, namespaces map[string]string) ([]kubernetes.PruneObject, error) {
+			claim := kubernetes.PruneObject{InventoryEntry: kubernetes.InventoryEntry{Kind: "PersistentVolumeClaim", Namespace: "db", Name: "data"}, Kustomization: "old-db", Disposition: kubernetes.PruneDelete, DataBearing: true}
+			if adopted {
+				claim.Disposition, claim.DataBearing = kubernetes.PruneRetain, false
+			}
+			return []kubernetes.PruneObject{claim}, nil
+		}
+		fluxStack := fluxinfra.NewMockStack()
+		fluxStack.PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) ([]fluxinfra.KustomizePlan, []string) {
+			return []fluxinfra.KustomizePlan{
+				{Name: "db", Transition: fluxinfra.TransitionMigrated, MigratedFrom: []string{"old-db"}, Resources: []fluxinfra.ResourceChange{
+					{Address: "PersistentVolumeClaim/db/data", Action: fluxinfra.ActionUpdate},
+				}},
+				{Name: "old-db", Transition: fluxinfra.TransitionReclaimed, MigratedTo: []string{"db"}},
+			}, nil
+		}
+		pruned := false
+		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+			pruned = true
+			return nil
+		}
+		proj := newApplyProjectWith(mocks, &provisioner.Provisioner{TerraformStack: mocks.TerraformStack, KubernetesManager: mocks.KubernetesManager, FluxStack: fluxStack})
+
+		// When executing the upgrade without --allow-data-loss
+		cmd := createTestUpgradeCmd()
+		cmd.SetArgs([]string{"--yes"})
+		cmd.SetContext(stdcontext.WithValue(stdcontext.Background(), projectOverridesKey, proj))
+		err := cmd.Execute()
+
+		// Then the upgrade applies and prunes, the claim having been adopted
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !pruned {
+			t.Error("Expected the prune to run")
+		}
+	})
+
 	t.Run("PlacesSecretsAfterInstall", func(t *testing.T) {
 		// Given an upgrade whose resolved blueprint declares a secret
 		mocks := setupApplyTest(t)
@@ -177,8 +304,8 @@ func TestUpgradeCmd(t *testing.T) {
 	t.Run("LeavesInFlightMarkerWhenPruneFails", func(t *testing.T) {
 		// Given an orphan to prune and a prune that fails after install and wait succeed
 		mocks := setupApplyTest(t)
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
-			return []string{"old-thing"}, nil
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}}, nil
 		}
 		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
 			return fmt.Errorf("prune failed")
@@ -240,8 +367,8 @@ func TestUpgradeCmd(t *testing.T) {
 	t.Run("ErrorPruneFails", func(t *testing.T) {
 		// Given an orphan to prune and a prune step that fails after a successful wait
 		mocks := setupApplyTest(t)
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
-			return []string{"old-thing"}, nil
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}}, nil
 		}
 		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
 			return fmt.Errorf("delete kustomization failed")
@@ -741,8 +868,8 @@ func TestUpgradeCmd_Confirmation(t *testing.T) {
 		t.Cleanup(func() { upgradeYes = false })
 		// Given a pending prune
 		mocks := setupApplyTest(t)
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
-			return []string{"old-thing"}, nil
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}}, nil
 		}
 		pruned := false
 		mocks.KubernetesManager.PruneBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
@@ -8,8 +8,8 @@ import (
 
 func main() {
 	// Execute the root command and handle the error,
-	// exiting with a non-zero exit code if there's an error
+	// exiting with the code the failing command asked for
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/fluxcd/pkg/apis/kustomize"
 	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
 	"github.com/windsorcli/cli/pkg/runtime"
 )
 
@@ -149,7 +150,7 @@ func (c *BaseBlueprintComposer) Compose(loaders []BlueprintLoader, initLoaderNam
 					Install: &blueprintv1alpha1.BoolExpression{Value: &trueVal, IsExpr: false},
 				}
 				for _, s := range bp.Sources {
-					if strings.HasPrefix(s.Url, "oci://") {
+					if blueprintv1alpha1.SourceKind(s) == blueprintv1alpha1.SourceKindOCI {
 						sourceToAdd.Url = s.Url
 						sourceToAdd.Ref = s.Ref
 						break
@@ -871,7 +872,7 @@ func (c *BaseBlueprintComposer) applyPerKustomizationSubstitutions(blueprint *bl
 // install must be explicitly true. sourceShouldBeMerged delegates here so the two never diverge.
 func sourceInstalls(source blueprintv1alpha1.Source) bool {
 	if source.Install == nil {
-		return strings.HasPrefix(source.Url, "oci://")
+		return blueprintv1alpha1.SourceKind(source) == blueprintv1alpha1.SourceKindOCI
 	}
 	return source.Install.IsInstalled()
 }
@@ -1102,23 +1103,49 @@ func (c *BaseBlueprintComposer) resolveTierDependencies(bp *blueprintv1alpha1.Bl
 	}
 }
 
-// validateSources checks that install is only used on OCI sources. Git and other non-OCI sources
-// cannot be installed (merged); install is supported only for oci:// URLs.
+// validateSources checks each source's kind and how the blueprint uses it. Install is only
+// supported on OCI sources: git and other non-OCI sources cannot be installed (merged). An OCI
+// source's URL must use the oci:// scheme, and a bucket source must name its bucket. Helm sources hold charts for HelmReleases, so no kustomization or
+// terraform component may read from one, and terraform components cannot read from a bucket.
 func (c *BaseBlueprintComposer) validateSources(bp *blueprintv1alpha1.Blueprint) error {
+	kinds := make(map[string]string, len(bp.Sources))
 	for _, s := range bp.Sources {
 		if s.Name == "" {
 			continue
 		}
+		kind := blueprintv1alpha1.SourceKind(s)
+		switch kind {
+		case blueprintv1alpha1.SourceKindGit, blueprintv1alpha1.SourceKindOCI, blueprintv1alpha1.SourceKindHelm, blueprintv1alpha1.SourceKindBucket:
+		default:
+			return fmt.Errorf("source %q has unsupported kind %q; supported kinds are git, oci, helm and bucket", s.Name, s.Kind)
+		}
+		kinds[s.Name] = kind
+		if kind == blueprintv1alpha1.SourceKindOCI && s.Url != "" && !strings.HasPrefix(s.Url, "oci://") {
+			return fmt.Errorf("source %q is an oci source but URL %q does not start with oci://", s.Name, s.Url)
+		}
+		if kind == blueprintv1alpha1.SourceKindBucket && s.BucketName == "" {
+			return fmt.Errorf("source %q is a bucket source but sets no bucketName", s.Name)
+		}
 		if !s.Install.IsInstalled() {
 			continue
 		}
 		if s.Url == "" {
 			continue
 		}
-		if !strings.HasPrefix(s.Url, "oci://") {
+		if kind != blueprintv1alpha1.SourceKindOCI {
 			return fmt.Errorf("source %q has install: true but URL %q is not an OCI source (oci://); install is only supported for OCI sources", s.Name, s.Url)
 		}
 	}
+	for _, k := range bp.AllKustomizations() {
+		if kinds[k.Source] == blueprintv1alpha1.SourceKindHelm {
+			return fmt.Errorf("kustomization %q uses helm source %q; helm sources can only be referenced by HelmReleases", k.Name, k.Source)
+		}
+	}
+	for _, tc := range bp.TerraformComponents {
+		if kind := kinds[tc.Source]; kind == blueprintv1alpha1.SourceKindHelm || kind == blueprintv1alpha1.SourceKindBucket {
+			return fmt.Errorf("terraform component %q uses %s source %q; terraform modules can only come from git or oci sources", tc.GetID(), kind, tc.Source)
+		}
+	}
 	return nil
 }
 
@@ -1138,6 +1165,8 @@ func (c *BaseBlueprintComposer) validateReservedNames(bp *blueprintv1alpha1.Blue
 
 // validateDependencies checks that all component dependencies reference components that exist in the final blueprint.
 // This validation happens after all composition is complete to ensure dependencies are valid in the final state.
+// A kustomization dependency must also name the namespace its target lives in: a bare name only reaches
+// kustomizations in the gitops namespace, and a "namespace/name" entry must match the target's namespace.
 func (c *BaseBlueprintComposer) validateDependencies(bp *blueprintv1alpha1.Blueprint) error {
 	tfIDs := make(map[string]struct{})
 	for _, tf := range bp.TerraformComponents {
@@ -1145,12 +1174,13 @@ func (c *BaseBlueprintComposer) validateDependencies(bp *blueprintv1alpha1.Bluep
 	}
 
 	allK := bp.AllKustomizations()
-	kNames := make(map[string]struct{}, len(allK)+len(bp.Sources)+1)
+	gitopsNamespace := c.gitopsNamespace()
+	kNamespaces := make(map[string]string, len(allK)+len(bp.Sources)+1)
 	for _, k := range allK {
-		kNames[k.Name] = struct{}{}
+		kNamespaces[k.Name] = k.ObjectNamespace(gitopsNamespace)
 	}
 	for _, layer := range CrdLayers(bp) {
-		kNames[blueprintv1alpha1.CrdKustomizationName(layer.Source)] = struct{}{}
+		kNamespaces[blueprintv1alpha1.CrdKustomizationName(layer.Source)] = gitopsNamespace
 	}
 
 	for _, tf := range bp.TerraformComponents {
@@ -1163,8 +1193,19 @@ func (c *BaseBlueprintComposer) validateDependencies(bp *blueprintv1alpha1.Bluep
 
 	for _, k := range allK {
 		for _, dep := range k.DependsOn {
-			if _, exists := kNames[dep]; !exists {
-				return fmt.Errorf("kustomization %q depends on %s", k.Name, c.describeMissingDependency(dep, "kustomization"))
+			depNamespace, depName, err := blueprintv1alpha1.ParseKustomizationDependency(dep)
+			if err != nil {
+				return fmt.Errorf("kustomization %q: %w", k.Name, err)
+			}
+			actual, exists := kNamespaces[depName]
+			if !exists {
+				return fmt.Errorf("kustomization %q depends on %s", k.Name, c.describeMissingDependency(depName, "kustomization"))
+			}
+			if depNamespace == "" {
+				depNamespace = gitopsNamespace
+			}
+			if actual != depNamespace {
+				return fmt.Errorf("kustomization %q depends on %q, but kustomization %q lives in namespace %q; use %q", k.Name, dep, depName, actual, actual+"/"+depName)
 			}
 		}
 	}
@@ -1172,6 +1213,15 @@ func (c *BaseBlueprintComposer) validateDependencies(bp *blueprintv1alpha1.Bluep
 	return nil
 }
 
+// gitopsNamespace returns the configured gitops namespace, defaulting to DefaultGitopsNamespace.
+// Bare kustomization dependsOn names and kustomizations without a namespace resolve here.
+func (c *BaseBlueprintComposer) gitopsNamespace() string {
+	if c.runtime == nil || c.runtime.ConfigHandler == nil {
+		return constants.DefaultGitopsNamespace
+	}
+	return c.runtime.ConfigHandler.GetString("gitops.namespace", constants.DefaultGitopsNamespace)
+}
+
 // describeMissingDependency renders the tail of a dangling-dependency error. When an excluded facet
 // would have provided the missing name, it names that facet and the when: condition that excluded it,
 // turning a bare "non-existent X" into an actionable diagnostic. Otherwise it falls back to the plain
@@ -3620,6 +3620,96 @@ func TestComposer_validateSources(t *testing.T) {
 			t.Errorf("Expected error to mention OCI, got: %v", err)
 		}
 	})
+
+	t.Run("ReturnsErrorWhenHelmSourceWithOCIURLHasInstallTrue", func(t *testing.T) {
+		// Given a helm source served from an OCI registry marked install: true
+		mocks := setupComposerMocks(t)
+		composer := NewBlueprintComposer(mocks.Runtime)
+		trueVal := true
+		bp := &blueprintv1alpha1.Blueprint{
+			Sources: []blueprintv1alpha1.Source{
+				{Name: "charts", Kind: blueprintv1alpha1.SourceKindHelm, Url: "oci://ghcr.io/example/charts", Install: &blueprintv1alpha1.BoolExpression{Value: &trueVal, IsExpr: false}},
+			},
+		}
+
+		// When validating
+		err := composer.validateSources(bp)
+
+		// Then install is rejected, since only OCI artifact sources carry blueprints
+		if err == nil || !strings.Contains(err.Error(), "install: true") {
+			t.Errorf("Expected install error for helm source, got %v", err)
+		}
+	})
+
+	t.Run("ReturnsNilForHelmAndBucketSources", func(t *testing.T) {
+		// Given a helm source and a bucket source a kustomization reads from
+		mocks := setupComposerMocks(t)
+		composer := NewBlueprintComposer(mocks.Runtime)
+		bp := &blueprintv1alpha1.Blueprint{
+			Sources: []blueprintv1alpha1.Source{
+				{Name: "bitnami", Kind: blueprintv1alpha1.SourceKindHelm, Url: "https://charts.bitnami.com/bitnami"},
+				{Name: "manifests", Kind: blueprintv1alpha1.SourceKindBucket, Url: "https://s3.amazonaws.com", BucketName: "platform"},
+			},
+			Kustomizations: []blueprintv1alpha1.Kustomization{{Name: "apps", Source: "manifests"}},
+		}
+
+		// When validating
+		err := composer.validateSources(bp)
+
+		// Then no error is returned
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("ReturnsErrorForInvalidSourceUsage", func(t *testing.T) {
+		cases := map[string]struct {
+			bp   *blueprintv1alpha1.Blueprint
+			want string
+		}{
+			"UnsupportedKind": {
+				bp:   &blueprintv1alpha1.Blueprint{Sources: []blueprintv1alpha1.Source{{Name: "s", Kind: "svn", Url: "svn://example"}}},
+				want: `unsupported kind "svn"`,
+			},
+			"OCIKindWithoutOCIScheme": {
+				bp:   &blueprintv1alpha1.Blueprint{Sources: []blueprintv1alpha1.Source{{Name: "s", Kind: blueprintv1alpha1.SourceKindOCI, Url: "ghcr.io/org/repo:v1"}}},
+				want: "does not start with oci://",
+			},
+			"BucketWithoutBucketName": {
+				bp:   &blueprintv1alpha1.Blueprint{Sources: []blueprintv1alpha1.Source{{Name: "s", Kind: blueprintv1alpha1.SourceKindBucket, Url: "https://s3.amazonaws.com"}}},
+				want: "sets no bucketName",
+			},
+			"KustomizationFromHelmSource": {
+				bp: &blueprintv1alpha1.Blueprint{
+					Sources:        []blueprintv1alpha1.Source{{Name: "charts", Kind: blueprintv1alpha1.SourceKindHelm, Url: "https://charts.example.com"}},
+					Kustomizations: []blueprintv1alpha1.Kustomization{{Name: "apps", Source: "charts"}},
+				},
+				want: `kustomization "apps" uses helm source "charts"`,
+			},
+			"TerraformFromBucketSource": {
+				bp: &blueprintv1alpha1.Blueprint{
+					Sources:             []blueprintv1alpha1.Source{{Name: "manifests", Kind: blueprintv1alpha1.SourceKindBucket, Url: "https://s3.amazonaws.com", BucketName: "platform"}},
+					TerraformComponents: []blueprintv1alpha1.TerraformComponent{{Path: "network", Source: "manifests"}},
+				},
+				want: `terraform component "network" uses bucket source "manifests"`,
+			},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Given a blueprint misusing a source
+				mocks := setupComposerMocks(t)
+				composer := NewBlueprintComposer(mocks.Runtime)
+
+				// When validating
+				err := composer.validateSources(tc.bp)
+
+				// Then the misuse is reported
+				if err == nil || !strings.Contains(err.Error(), tc.want) {
+					t.Errorf("Expected error containing %q, got %v", tc.want, err)
+				}
+			})
+		}
+	})
 }
 
 // =============================================================================
@@ -3904,4 +3994,57 @@ func TestComposer_validateDependencies(t *testing.T) {
 			t.Errorf("Expected no error when dependency matches by name, got %v", err)
 		}
 	})
+	t.Run("AcceptsQualifiedDependencyInTargetNamespace", func(t *testing.T) {
+		// Given a kustomization depending on one in another namespace by namespace/name
+		mocks := setupComposerMocks(t)
+		composer := NewBlueprintComposer(mocks.Runtime)
+		bp := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "platform", Path: "platform", Namespace: "tenants"},
+				{Name: "base", Path: "base"},
+				{Name: "app", Path: "app", DependsOn: []string{"tenants/platform", "base"}},
+			},
+		}
+
+		// When validating, both the qualified and the bare dependency resolve
+		if err := composer.validateDependencies(bp); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("RejectsDependencyNamingWrongNamespace", func(t *testing.T) {
+		// Given dependencies that name the wrong namespace for their target
+		for _, dep := range []string{"platform", "other/platform"} {
+			mocks := setupComposerMocks(t)
+			composer := NewBlueprintComposer(mocks.Runtime)
+			bp := &blueprintv1alpha1.Blueprint{
+				Kustomizations: []blueprintv1alpha1.Kustomization{
+					{Name: "platform", Path: "platform", Namespace: "tenants"},
+					{Name: "app", Path: "app", DependsOn: []string{dep}},
+				},
+			}
+
+			// When validating, the error points at the qualified reference to use
+			err := composer.validateDependencies(bp)
+			if err == nil || !strings.Contains(err.Error(), `lives in namespace "tenants"`) || !strings.Contains(err.Error(), `use "tenants/platform"`) {
+				t.Errorf("Expected namespace mismatch error for %q, got %v", dep, err)
+			}
+		}
+	})
+
+	t.Run("RejectsMalformedKustomizationDependency", func(t *testing.T) {
+		mocks := setupComposerMocks(t)
+		composer := NewBlueprintComposer(mocks.Runtime)
+		bp := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "app", Path: "app", DependsOn: []string{"a/b/c"}},
+			},
+		}
+
+		err := composer.validateDependencies(bp)
+
+		if err == nil || !strings.Contains(err.Error(), "invalid dependsOn entry") {
+			t.Errorf("Expected malformed dependsOn error, got %v", err)
+		}
+	})
 }
@@ -26,6 +26,7 @@ type BlueprintHandler interface {
 	GetDeclaredSources() ([]blueprintv1alpha1.Source, error)
 	GetTerraformComponents() []blueprintv1alpha1.TerraformComponent
 	GetLocalTemplateData() (map[string][]byte, error)
+	GetSourceTemplateData() map[string]map[string][]byte
 	Generate() *blueprintv1alpha1.Blueprint
 	GenerateResolved() (*blueprintv1alpha1.Blueprint, error)
 	Explain(path string) (*ExplainTrace, error)
@@ -239,7 +240,7 @@ func (h *BaseBlueprintHandler) UpgradeSourcesToLatest() ([]SourceUpgrade, error)
 	var planned []plannedUpgrade
 	for i := range h.composedBlueprint.Sources {
 		src := h.composedBlueprint.Sources[i]
-		if !strings.HasPrefix(src.Url, "oci://") {
+		if blueprintv1alpha1.SourceKind(src) != blueprintv1alpha1.SourceKindOCI {
 			continue
 		}
 		info, err := artifact.ParseOCIReference(src.Url)
@@ -377,6 +378,23 @@ func (h *BaseBlueprintHandler) GetLocalTemplateData() (map[string][]byte, error)
 	return templateLoader.GetTemplateData(), nil
 }
 
+// GetSourceTemplateData returns the files collected from each loaded source blueprint, keyed by
+// source name and then by path relative to the source's template root. Unlike the merged data the
+// evaluator resolves file() against, files sharing a path in different sources stay apart, so the
+// policy loader reads every source's policies/ directory. Sources without files are omitted.
+func (h *BaseBlueprintHandler) GetSourceTemplateData() map[string]map[string][]byte {
+	data := make(map[string]map[string][]byte)
+	for name, loader := range h.sourceBlueprintLoaders {
+		if loader == nil {
+			continue
+		}
+		if files := loader.GetTemplateData(); len(files) > 0 {
+			data[name] = files
+		}
+	}
+	return data
+}
+
 // Generate returns the fully composed blueprint after all sources and user blueprint
 // have been merged. This is a simple accessor method that returns the composedBlueprint field.
 // The blueprint is already fully processed and composed by LoadBlueprint(). Input expressions
@@ -812,7 +830,7 @@ func (h *BaseBlueprintHandler) loadSources() error {
 			var sourceURL string
 			if src.Name == "template" {
 				sourceURL = ""
-			} else if strings.HasPrefix(src.Url, "oci://") {
+			} else if blueprintv1alpha1.SourceKind(src) == blueprintv1alpha1.SourceKindOCI {
 				sourceURL = src.Url
 			} else {
 				return
@@ -871,7 +889,7 @@ func (h *BaseBlueprintHandler) loadNestedSources() error {
 				if _, exists := h.sourceBlueprintLoaders[source.Name]; exists {
 					continue
 				}
-				if !strings.HasPrefix(source.Url, "oci://") {
+				if blueprintv1alpha1.SourceKind(source) != blueprintv1alpha1.SourceKindOCI {
 					continue
 				}
 				newSources = append(newSources, source)
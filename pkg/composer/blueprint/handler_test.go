@@ -1335,6 +1335,38 @@ func TestHandler_GetLocalTemplateData(t *testing.T) {
 	})
 }
 
+func TestHandler_GetSourceTemplateData(t *testing.T) {
+	t.Run("ReturnsEachSourceApart", func(t *testing.T) {
+		// Given two sources sharing a path and one without files
+		mocks := setupHandlerMocks(t)
+		handler := NewBlueprintHandler(mocks.Runtime, mocks.ArtifactBuilder)
+		loaderWith := func(content string) *mockLoaderImpl {
+			return &mockLoaderImpl{
+				getTemplateDataFunc: func() map[string][]byte {
+					if content == "" {
+						return nil
+					}
+					return map[string][]byte{"policies/p.yaml": []byte(content)}
+				},
+			}
+		}
+		handler.sourceBlueprintLoaders["core"] = loaderWith("core")
+		handler.sourceBlueprintLoaders["addons"] = loaderWith("addons")
+		handler.sourceBlueprintLoaders["empty"] = loaderWith("")
+
+		// When getting source template data
+		data := handler.GetSourceTemplateData()
+
+		// Then each source keeps its own copy and the empty source is omitted
+		if len(data) != 2 {
+			t.Fatalf("Expected 2 sources, got %d", len(data))
+		}
+		if string(data["core"]["policies/p.yaml"]) != "core" || string(data["addons"]["policies/p.yaml"]) != "addons" {
+			t.Errorf("Expected per-source files, got %v", data)
+		}
+	})
+}
+
 func TestHandler_Generate(t *testing.T) {
 	t.Run("ReturnsNilWhenNoBlueprint", func(t *testing.T) {
 		// Given a handler with no composed blueprint
@@ -302,17 +302,21 @@ func (l *BaseBlueprintLoader) injectOCISource() {
 
 // normalizeOCISourceRefs zeros Ref on sources whose OCI URL already includes the tag, so ref is
 // not duplicated. Only the path (after the first slash) is checked for a tag; colons in the
-// authority (e.g. localhost:5000) are not treated as tags.
+// authority (e.g. localhost:5000) are not treated as tags. A kind: oci source without an oci://
+// URL is left alone for validateSources to reject.
 func (l *BaseBlueprintLoader) normalizeOCISourceRefs(bp *blueprintv1alpha1.Blueprint) {
 	if bp == nil {
 		return
 	}
 	for i := range bp.Sources {
 		s := &bp.Sources[i]
-		if s.Url == "" || !strings.HasPrefix(s.Url, "oci://") {
+		if blueprintv1alpha1.SourceKind(*s) != blueprintv1alpha1.SourceKindOCI {
+			continue
+		}
+		afterScheme, ok := strings.CutPrefix(s.Url, "oci://")
+		if !ok {
 			continue
 		}
-		afterScheme := s.Url[7:]
 		firstSlash := strings.Index(afterScheme, "/")
 		if firstSlash == -1 {
 			continue
@@ -646,6 +646,35 @@ sources:
 		}
 	})
 
+	t.Run("LoadsOCIKindSourceWithoutOCIScheme", func(t *testing.T) {
+		// Given a kind: oci source whose URL is shorter than the oci:// prefix
+		mocks := setupLoaderMocks(t)
+		blueprintYaml := `kind: Blueprint
+apiVersion: blueprints.windsorcli.dev/v1alpha1
+metadata:
+  name: test
+sources:
+  - name: registry
+    kind: oci
+    url: ghcr
+    ref:
+      tag: v1.0.0
+`
+		os.WriteFile(filepath.Join(mocks.TmpDir, "blueprint.yaml"), []byte(blueprintYaml), 0644)
+		loader := NewBlueprintLoader(mocks.Runtime, mocks.ArtifactBuilder)
+
+		// When loading
+		err := loader.Load("user", "")
+
+		// Then the source loads untouched, leaving the scheme for source validation to reject
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if bp := loader.GetBlueprint(); bp == nil || bp.Sources[0].Ref.Tag != "v1.0.0" {
+			t.Errorf("Expected the source loaded with its ref, got %v", bp)
+		}
+	})
+
 	t.Run("CollectsTemplateData", func(t *testing.T) {
 		// Given a loader with template files
 		mocks := setupLoaderMocks(t)
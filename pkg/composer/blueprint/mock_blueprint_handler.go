@@ -14,6 +14,7 @@ type MockBlueprintHandler struct {
 	GetDeclaredSourcesFunc     func() ([]blueprintv1alpha1.Source, error)
 	GetTerraformComponentsFunc func() []blueprintv1alpha1.TerraformComponent
 	GetLocalTemplateDataFunc   func() (map[string][]byte, error)
+	GetSourceTemplateDataFunc  func() map[string]map[string][]byte
 	GenerateFunc               func() *blueprintv1alpha1.Blueprint
 	GenerateResolvedFunc       func() (*blueprintv1alpha1.Blueprint, error)
 	ExplainFunc                func(string) (*ExplainTrace, error)
@@ -106,6 +107,14 @@ func (m *MockBlueprintHandler) GetLocalTemplateData() (map[string][]byte, error)
 	return map[string][]byte{}, nil
 }
 
+// GetSourceTemplateData calls the mock GetSourceTemplateDataFunc if set, otherwise returns nil.
+func (m *MockBlueprintHandler) GetSourceTemplateData() map[string]map[string][]byte {
+	if m.GetSourceTemplateDataFunc != nil {
+		return m.GetSourceTemplateDataFunc()
+	}
+	return nil
+}
+
 // Generate calls the mock GenerateFunc if set, otherwise returns nil.
 func (m *MockBlueprintHandler) Generate() *blueprintv1alpha1.Blueprint {
 	if m.GenerateFunc != nil {
@@ -3,6 +3,8 @@ package blueprint
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
 )
@@ -23,10 +25,26 @@ var ErrBlueprintInvalid = errors.New("invalid blueprint")
 // =============================================================================
 
 // ValidateComposedBlueprint rejects composed blueprints whose Backend field names a
-// component that does not exist. Nil and empty-Backend blueprints are accepted.
-// Failures wrap ErrBlueprintInvalid.
+// component that does not exist, or whose kustomization dependsOn entries are malformed
+// or form a cycle. Nil blueprints are accepted. Failures wrap ErrBlueprintInvalid.
 func ValidateComposedBlueprint(blueprint *blueprintv1alpha1.Blueprint) error {
-	if blueprint == nil || blueprint.Backend == "" {
+	if blueprint == nil {
+		return nil
+	}
+	if err := validateBackend(blueprint); err != nil {
+		return err
+	}
+	return validateKustomizationDependencies(blueprint)
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// validateBackend rejects a Backend field that names no declared terraform component. An
+// empty Backend opts out of the in-blueprint backend tier and is accepted.
+func validateBackend(blueprint *blueprintv1alpha1.Blueprint) error {
+	if blueprint.Backend == "" {
 		return nil
 	}
 
@@ -41,3 +59,55 @@ func ValidateComposedBlueprint(blueprint *blueprintv1alpha1.Blueprint) error {
 		ErrBlueprintInvalid, blueprint.Backend,
 	)
 }
+
+// validateKustomizationDependencies rejects malformed kustomization dependsOn entries and
+// dependency cycles across every kustomization, including the tiers compiled from flux
+// systems. Entries are followed by their name part, so a cycle through "namespace/name"
+// edges is caught the same as one through bare names. Entries naming no kustomization in
+// the blueprint have no edges of their own; the composer reports those with facet attribution.
+func validateKustomizationDependencies(blueprint *blueprintv1alpha1.Blueprint) error {
+	all := blueprint.AllKustomizations()
+	deps := make(map[string][]string, len(all))
+	for _, k := range all {
+		for _, dep := range k.DependsOn {
+			if _, _, err := blueprintv1alpha1.ParseKustomizationDependency(dep); err != nil {
+				return fmt.Errorf("%w\n\nBlueprint configuration: kustomization %q: %v.", ErrBlueprintInvalid, k.Name, err)
+			}
+			deps[k.Name] = append(deps[k.Name], blueprintv1alpha1.KustomizationDependencyName(dep))
+		}
+	}
+
+	visited := make(map[string]bool, len(all))
+	visiting := make(map[string]bool, len(all))
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		if visiting[name] {
+			i := slices.Index(path, name)
+			return append(slices.Clone(path[i:]), name)
+		}
+		if visited[name] {
+			return nil
+		}
+		visiting[name] = true
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		visiting[name] = false
+		visited[name] = true
+		return nil
+	}
+	for _, k := range all {
+		if cycle := visit(k.Name); cycle != nil {
+			return fmt.Errorf(
+				"%w\n\nBlueprint configuration: kustomizations form a dependency cycle: %s. Remove one of these dependsOn entries to break the cycle.",
+				ErrBlueprintInvalid, strings.Join(cycle, " -> "),
+			)
+		}
+	}
+	return nil
+}
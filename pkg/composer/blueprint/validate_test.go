@@ -120,4 +120,38 @@ func TestValidateComposedBlueprint(t *testing.T) {
 			t.Errorf("Expected nil error (naming convention retired), got %v", err)
 		}
 	})
+
+	t.Run("KustomizationDependencyCycleIsRejected", func(t *testing.T) {
+		// Given kustomizations whose dependencies loop through a namespace-qualified entry
+		bp := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "platform", Namespace: "tenants", DependsOn: []string{"app"}},
+				{Name: "app", DependsOn: []string{"tenants/platform"}},
+			},
+		}
+
+		// When validation runs, the cycle is reported with its path
+		err := ValidateComposedBlueprint(bp)
+		if !errors.Is(err, ErrBlueprintInvalid) {
+			t.Fatalf("Expected ErrBlueprintInvalid, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "platform -> app -> platform") {
+			t.Errorf("Expected the cycle path in the error, got %v", err)
+		}
+	})
+
+	t.Run("MalformedKustomizationDependencyIsRejected", func(t *testing.T) {
+		// Given a dependsOn entry with too many separators
+		bp := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "app", DependsOn: []string{"a/b/c"}},
+			},
+		}
+
+		// When validation runs, the entry is rejected
+		err := ValidateComposedBlueprint(bp)
+		if !errors.Is(err, ErrBlueprintInvalid) || !strings.Contains(err.Error(), `"a/b/c"`) {
+			t.Errorf("Expected ErrBlueprintInvalid naming the entry, got %v", err)
+		}
+	})
 }
@@ -64,6 +64,10 @@ const DefaultWorkerMemory = 8
 
 const DefaultGitopsNamespace = "system-gitops"
 
+// DefaultArgoCDNamespace is the namespace Argo CD Applications are rendered into when the gitops
+// engine is Argo CD and gitops.argocd.namespace is unset.
+const DefaultArgoCDNamespace = "argocd"
+
 // DefaultFluxKustomizationInterval is the reconciliation interval for Kustomizations. Flux
 // content here is pinned and explicitly triggered (the notifier re-fetches sources on every
 // apply/up/bootstrap) rather than continuously tracked, so this is an eventual-consistency
@@ -119,10 +123,12 @@ const DefaultFluxPrimaryRepositoryInterval = 1 * time.Minute
 // depends on it, directly or transitively.
 const DefaultFluxPrimaryKustomizationInterval = 5 * time.Minute
 
-// GitopsMode is accepted by ToFluxKustomization and friends for call-site stability but no
-// longer changes reconciliation cadence: the notifier re-fetches sources on every
-// apply/up/bootstrap regardless of mode, so pull and push need the same backstop cadence.
-// Unknown and empty values resolve to "pull" via ParseGitopsMode.
+// GitopsMode selects how the kustomization layer reaches the cluster. In pull mode the CLI applies
+// every Flux source, ConfigMap and Kustomization itself. In push mode it commits the rendered
+// objects to the blueprint repository's branch and applies only a root Kustomization that
+// reconciles them from there. The mode does not change reconciliation cadence: the notifier
+// re-fetches sources on every apply/up/bootstrap regardless of mode, so pull and push need the same
+// backstop cadence. Unknown and empty values resolve to "pull" via ParseGitopsMode.
 type GitopsMode string
 
 const (
@@ -140,6 +146,25 @@ func ParseGitopsMode(s string) GitopsMode {
 	return GitopsModePull
 }
 
+// GitopsEngine selects the controller blueprint kustomizations are compiled for: Flux
+// Kustomizations, or Argo CD Applications. Unknown and empty values resolve to "flux" via
+// ParseGitopsEngine.
+type GitopsEngine string
+
+const (
+	GitopsEngineFlux   GitopsEngine = "flux"
+	GitopsEngineArgoCD GitopsEngine = "argocd"
+)
+
+// ParseGitopsEngine resolves a config string to a GitopsEngine, defaulting to "flux" for empty or
+// unrecognised values, matching ParseGitopsMode.
+func ParseGitopsEngine(s string) GitopsEngine {
+	if GitopsEngine(s) == GitopsEngineArgoCD {
+		return GitopsEngineArgoCD
+	}
+	return GitopsEngineFlux
+}
+
 // FluxKustomizationInterval returns the default reconciliation interval for a Kustomization.
 // isPrimarySource is true when the Kustomization resolves, explicitly or by falling back to
 // the blueprint's default source, to the blueprint's own repository rather than a named vendor
@@ -219,6 +244,11 @@ const MinimumVersionSOPS = "3.10.0"
 
 const MinimumVersionAWS = "2.0.0"
 
+// MinimumVersionAWSStackLock is the minimum aws CLI version when the stack lock is an object in
+// an s3 bucket. The lock relies on `s3api put-object --if-none-match` and `--if-match`; older
+// CLIs reject the flags, and this release carries both.
+const MinimumVersionAWSStackLock = "2.23.0"
+
 const MinimumVersionAzure = "2.50.0"
 
 // DefaultAKSOIDCServerID is the standard Azure AKS OIDC server ID (application ID of the
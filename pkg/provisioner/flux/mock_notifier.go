@@ -19,7 +19,7 @@ import (
 // nil to keep callers' "best-effort" semantics unchanged when no override is set.
 type MockNotifier struct {
 	NotifyFunc                  func(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint) error
-	ReconcileKustomizationsFunc func(ctx context.Context, names []string) error
+	ReconcileKustomizationsFunc func(ctx context.Context, refs []KustomizationRef) error
 	ReconcileHelmReleasesFunc   func(ctx context.Context, refs []HelmReleaseRef, force bool) error
 }
 
@@ -47,9 +47,9 @@ func (m *MockNotifier) Notify(ctx context.Context, blueprint *blueprintv1alpha1.
 
 // ReconcileKustomizations implements the Notifier interface. Delegates to ReconcileKustomizationsFunc when
 // set and otherwise returns nil to match the Notifier's best-effort contract.
-func (m *MockNotifier) ReconcileKustomizations(ctx context.Context, names []string) error {
+func (m *MockNotifier) ReconcileKustomizations(ctx context.Context, refs []KustomizationRef) error {
 	if m.ReconcileKustomizationsFunc != nil {
-		return m.ReconcileKustomizationsFunc(ctx, names)
+		return m.ReconcileKustomizationsFunc(ctx, refs)
 	}
 	return nil
 }
@@ -23,6 +23,7 @@ type MockStack struct {
 	PlanComponentSummaryFunc        func(blueprint *blueprintv1alpha1.Blueprint, name string) KustomizePlan
 	PlanDestroySummaryFunc          func(blueprint *blueprintv1alpha1.Blueprint) ([]KustomizePlan, error)
 	PlanDestroyComponentSummaryFunc func(blueprint *blueprintv1alpha1.Blueprint, name string) KustomizePlan
+	DriftSummaryFunc                func(blueprint *blueprintv1alpha1.Blueprint) []KustomizePlan
 }
 
 // =============================================================================
@@ -102,6 +103,14 @@ func (m *MockStack) PlanDestroyComponentSummary(blueprint *blueprintv1alpha1.Blu
 	return KustomizePlan{Name: name}
 }
 
+// DriftSummary is a mock implementation of the DriftSummary method.
+func (m *MockStack) DriftSummary(blueprint *blueprintv1alpha1.Blueprint) []KustomizePlan {
+	if m.DriftSummaryFunc != nil {
+		return m.DriftSummaryFunc(blueprint)
+	}
+	return nil
+}
+
 // =============================================================================
 // Interface Compliance
 // =============================================================================
@@ -1,9 +1,9 @@
 // Package flux provides Flux kustomization stack management functionality.
 // The Notifier piece implements best-effort flux reconcile requests. After a
 // successful apply/bootstrap it annotates each of the blueprint's flux sources
-// (GitRepository / OCIRepository) with reconcile.fluxcd.io/requestedAt so
-// source-controller re-fetches them immediately instead of waiting for the
-// next scheduled interval. When the artifact revision changes, kustomize-
+// (GitRepository / OCIRepository / HelmRepository / Bucket) with
+// reconcile.fluxcd.io/requestedAt so source-controller re-fetches them
+// immediately instead of waiting for the next scheduled interval. When the artifact revision changes, kustomize-
 // controller reconciles the dependent Kustomizations automatically via its
 // watch on source status, so a revision change needs only sources annotated.
 // ReconcileKustomizations covers the other case: advancing already-applied
@@ -71,10 +71,16 @@ const (
 // never for cluster state.
 type Notifier interface {
 	Notify(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint) error
-	ReconcileKustomizations(ctx context.Context, names []string) error
+	ReconcileKustomizations(ctx context.Context, refs []KustomizationRef) error
 	ReconcileHelmReleases(ctx context.Context, refs []HelmReleaseRef, force bool) error
 }
 
+// KustomizationRef identifies a Kustomization to reconcile by its namespace and name.
+type KustomizationRef struct {
+	Namespace string
+	Name      string
+}
+
 // HelmReleaseRef identifies a HelmRelease to reconcile by its namespace and name.
 type HelmReleaseRef struct {
 	Namespace string
@@ -157,8 +163,8 @@ func NewNotifier(rt *runtime.Runtime, kubeClient client.KubernetesClient, opts .
 // re-fetch them immediately instead of waiting for the next scheduled interval.
 // The blueprint.Repository entry is annotated under blueprint.Metadata.Name;
 // each blueprint.Sources entry under its own Name. Local template sources (no
-// URL) are skipped. OCI URLs (oci://) route to OCIRepository; every other
-// protocol to GitRepository. Per-source PATCH errors are logged and swallowed
+// URL) are skipped. Each source routes to the Flux source object of its kind:
+// GitRepository, OCIRepository, HelmRepository or Bucket. Per-source PATCH errors are logged and swallowed
 // so one unreachable source does not abort the rest. Returns nil for every
 // cluster-state condition; returns an error only for nil blueprint.
 func (n *BaseNotifier) Notify(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint) error {
@@ -197,42 +203,41 @@ func (n *BaseNotifier) Notify(ctx context.Context, blueprint *blueprintv1alpha1.
 	return nil
 }
 
-// ReconcileKustomizations annotates each named Kustomization in the gitops namespace with the current
+// ReconcileKustomizations annotates each referenced Kustomization, in its own namespace, with the current
 // timestamp under reconcile.fluxcd.io/requestedAt, causing kustomize-controller to re-reconcile it
 // immediately — re-evaluating its dependency readiness and re-applying — instead of waiting for its
 // scheduled interval. Unlike Notify, which pokes sources to re-fetch git (and only progresses dependents
 // when the artifact revision changes), this advances already-applied Kustomizations, so a dependency chain
 // unblocked mid-flight (e.g. by a just-placed secret) progresses in seconds rather than one interval per
-// hop. Best-effort like Notify: per-name PATCH errors are logged and swallowed, a nil is returned for
-// every cluster-state condition, and an empty names list is a no-op.
-func (n *BaseNotifier) ReconcileKustomizations(ctx context.Context, names []string) error {
-	if len(names) == 0 {
+// hop. Best-effort like Notify: per-ref PATCH errors are logged and swallowed, a nil is returned for
+// every cluster-state condition, and an empty refs list is a no-op.
+func (n *BaseNotifier) ReconcileKustomizations(ctx context.Context, refs []KustomizationRef) error {
+	if len(refs) == 0 {
 		return nil
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
 	defer cancel()
 
-	namespace := n.runtime.ConfigHandler.GetString("gitops.namespace", constants.DefaultGitopsNamespace)
 	ts := n.shims.Now().UTC().Format(time.RFC3339Nano)
 	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, reconcileAnnotation, ts))
 	opts := metav1.PatchOptions{FieldManager: fieldManager}
 	gvr := schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
 
 	var reconciled []string
-	for _, name := range names {
+	for _, ref := range refs {
 		if err := ctx.Err(); err != nil {
 			n.logf("flux kustomization reconcile aborted: %v", err)
 			return nil
 		}
-		if _, err := n.kubeClient.PatchResource(ctx, gvr, namespace, name, types.MergePatchType, patch, opts); err != nil {
-			n.logf("flux reconcile request for Kustomization/%s skipped: %v", name, err)
+		if _, err := n.kubeClient.PatchResource(ctx, gvr, ref.Namespace, ref.Name, types.MergePatchType, patch, opts); err != nil {
+			n.logf("flux reconcile request for Kustomization/%s/%s skipped: %v", ref.Namespace, ref.Name, err)
 			continue
 		}
-		reconciled = append(reconciled, name)
+		reconciled = append(reconciled, ref.Namespace+"/"+ref.Name)
 	}
 	if len(reconciled) > 0 {
-		n.logf("flux reconcile requested in namespace %s for kustomizations: %s", namespace, strings.Join(reconciled, ", "))
+		n.logf("flux reconcile requested for kustomizations: %s", strings.Join(reconciled, ", "))
 	}
 	return nil
 }
@@ -296,15 +301,15 @@ func (n *BaseNotifier) logf(format string, args ...any) {
 // Helpers
 // =============================================================================
 
-// collectSourceTargets enumerates the GitRepository/OCIRepository resources
-// ApplyBlueprint creates: the primary blueprint.Repository (named after
+// collectSourceTargets enumerates the flux source resources ApplyBlueprint
+// creates: the primary blueprint.Repository (named after
 // blueprint.Metadata.Name) plus each blueprint.Sources entry except the local
 // template source. Returns an empty slice when the blueprint declares no
 // remote sources (common for bootstraps that only apply local kustomizations).
 func collectSourceTargets(blueprint *blueprintv1alpha1.Blueprint) []sourceTarget {
 	var targets []sourceTarget
 	if blueprint.Repository.Url != "" {
-		targets = append(targets, sourceTargetFromURL(blueprint.Metadata.Name, blueprint.Repository.Url))
+		targets = append(targets, sourceTargetFor(blueprintv1alpha1.Source{Name: blueprint.Metadata.Name, Url: blueprint.Repository.Url}))
 	}
 	for _, s := range blueprint.Sources {
 		if blueprintv1alpha1.IsLocalTemplateSource(s) {
@@ -313,35 +318,31 @@ func collectSourceTargets(blueprint *blueprintv1alpha1.Blueprint) []sourceTarget
 		if s.Url == "" {
 			continue
 		}
-		targets = append(targets, sourceTargetFromURL(s.Name, s.Url))
+		targets = append(targets, sourceTargetFor(s))
 	}
 	return targets
 }
 
-// sourceTargetFromURL maps a blueprint source URL to its flux source GVR. An
-// oci:// prefix routes to OCIRepository; everything else (https, ssh, git, or
-// bare HTTP) routes to GitRepository, matching applyBlueprintSource's routing
-// in kubernetes_manager.go so the annotation target always corresponds to the
+// sourceTargetFor maps a blueprint source to its flux source GVR by kind (see
+// blueprintv1alpha1.FluxSourceKind): GitRepository, OCIRepository,
+// HelmRepository or Bucket, matching applyBlueprintSource's routing in
+// kubernetes_manager.go so the annotation target always corresponds to the
 // resource actually created on the cluster.
-func sourceTargetFromURL(name, url string) sourceTarget {
-	if strings.HasPrefix(url, "oci://") {
-		return sourceTarget{
-			name: name,
-			kind: "OCIRepository",
-			gvr: schema.GroupVersionResource{
-				Group:    "source.toolkit.fluxcd.io",
-				Version:  "v1",
-				Resource: "ocirepositories",
-			},
-		}
+func sourceTargetFor(source blueprintv1alpha1.Source) sourceTarget {
+	kind := blueprintv1alpha1.FluxSourceKind(source)
+	resources := map[string]string{
+		"GitRepository":  "gitrepositories",
+		"OCIRepository":  "ocirepositories",
+		"HelmRepository": "helmrepositories",
+		"Bucket":         "buckets",
 	}
 	return sourceTarget{
-		name: name,
-		kind: "GitRepository",
+		name: source.Name,
+		kind: kind,
 		gvr: schema.GroupVersionResource{
 			Group:    "source.toolkit.fluxcd.io",
 			Version:  "v1",
-			Resource: "gitrepositories",
+			Resource: resources[kind],
 		},
 	}
 }
@@ -141,6 +141,35 @@ func TestNotifier_Notify(t *testing.T) {
 		}
 	})
 
+	t.Run("RoutesHelmAndBucketSourcesByKind", func(t *testing.T) {
+		// Given a blueprint with a helm source on an OCI registry and a bucket source
+		m := setupNotifierMocks(t)
+		bp := &blueprintv1alpha1.Blueprint{
+			Metadata: blueprintv1alpha1.Metadata{Name: "core"},
+			Sources: []blueprintv1alpha1.Source{
+				{Name: "charts", Kind: blueprintv1alpha1.SourceKindHelm, Url: "oci://ghcr.io/example/charts"},
+				{Name: "manifests", Kind: blueprintv1alpha1.SourceKindBucket, Url: "https://s3.amazonaws.com", BucketName: "platform"},
+			},
+		}
+		n := newTestNotifier(m)
+
+		// When Notify is called
+		if err := n.Notify(ctx, bp); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+
+		// Then each is patched as its own kind, not by URL scheme
+		if len(*m.patches) != 2 {
+			t.Fatalf("expected 2 patches, got %d", len(*m.patches))
+		}
+		if r := (*m.patches)[0].gvr.Resource; r != "helmrepositories" {
+			t.Errorf("expected helm source to target helmrepositories, got %s", r)
+		}
+		if r := (*m.patches)[1].gvr.Resource; r != "buckets" {
+			t.Errorf("expected bucket source to target buckets, got %s", r)
+		}
+	})
+
 	t.Run("UsesGitopsNamespaceFromConfig", func(t *testing.T) {
 		// Given a config handler that returns a non-default gitops namespace
 		m := setupNotifierMocks(t)
@@ -335,13 +364,17 @@ func TestNotifier_Notify(t *testing.T) {
 func TestNotifier_ReconcileKustomizations(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("AnnotatesEachNamedKustomization", func(t *testing.T) {
-		// Given a set of kustomization names
+	t.Run("AnnotatesEachReferencedKustomization", func(t *testing.T) {
+		// Given references to kustomizations in different namespaces
 		m := setupNotifierMocks(t)
 		n := newTestNotifier(m)
+		refs := []KustomizationRef{
+			{Namespace: "system-gitops", Name: "lb-install"},
+			{Namespace: "team-a", Name: "gateway-install"},
+		}
 
 		// When ReconcileKustomizations is called
-		if err := n.ReconcileKustomizations(ctx, []string{"lb-install", "gateway-install"}); err != nil {
+		if err := n.ReconcileKustomizations(ctx, refs); err != nil {
 			t.Fatalf("expected nil, got %v", err)
 		}
 
@@ -357,13 +390,16 @@ func TestNotifier_ReconcileKustomizations(t *testing.T) {
 				t.Errorf("expected reconcile annotation with injected timestamp, got %s", p.data)
 			}
 		}
-		if (*m.patches)[0].name != "lb-install" || (*m.patches)[1].name != "gateway-install" {
-			t.Errorf("expected the named kustomizations patched, got %s, %s", (*m.patches)[0].name, (*m.patches)[1].name)
+		// And each kustomization is patched in its own namespace
+		for i, ref := range refs {
+			if p := (*m.patches)[i]; p.namespace != ref.Namespace || p.name != ref.Name {
+				t.Errorf("expected %s/%s patched, got %s/%s", ref.Namespace, ref.Name, p.namespace, p.name)
+			}
 		}
 	})
 
-	t.Run("EmptyNamesIsNoOp", func(t *testing.T) {
-		// Given no names
+	t.Run("EmptyRefsIsNoOp", func(t *testing.T) {
+		// Given no refs
 		m := setupNotifierMocks(t)
 		n := newTestNotifier(m)
 
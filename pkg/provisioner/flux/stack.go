@@ -46,6 +46,7 @@ type Stack interface {
 	PlanComponentSummary(blueprint *blueprintv1alpha1.Blueprint, name string) KustomizePlan
 	PlanDestroySummary(blueprint *blueprintv1alpha1.Blueprint) ([]KustomizePlan, error)
 	PlanDestroyComponentSummary(blueprint *blueprintv1alpha1.Blueprint, name string) KustomizePlan
+	DriftSummary(blueprint *blueprintv1alpha1.Blueprint) []KustomizePlan
 }
 
 // KustomizePlan holds the plan result for a single Flux kustomization.
@@ -55,16 +56,30 @@ type Stack interface {
 // is true. Resources is the per-resource change list extracted from flux diff
 // (existing kustomizations) or kustomize build (new kustomizations); it is empty
 // when Degraded is true or when the diff produced no parseable resource banners.
-// Degraded is true when the required CLI tool was absent and no counts could be
-// produced. Err is non-nil when the component could not be planned.
+// Degraded is true when no counts could be produced: the required CLI tool was
+// absent, or the kustomization reads from a bucket source with no local copy.
+// Err is non-nil when the component could not be planned.
+//
+// Transition, MigratedFrom, MigratedTo, AppliedRef and TargetRef are set by
+// PlanSummary only. Transition classifies the kustomization (see Transition) and
+// is empty when it could not be determined. MigratedFrom names the reclaimed
+// kustomizations a migrated one takes objects over from, and MigratedTo the
+// kustomizations a reclaimed one hands objects to. AppliedRef and TargetRef are
+// the version marker's and the blueprint's refs of the kustomization's source,
+// set only when they differ.
 type KustomizePlan struct {
-	Name      string
-	Added     int
-	Removed   int
-	IsNew     bool
-	Degraded  bool
-	Resources []ResourceChange
-	Err       error
+	Name         string
+	Added        int
+	Removed      int
+	IsNew        bool
+	Degraded     bool
+	Resources    []ResourceChange
+	Err          error
+	Transition   Transition
+	MigratedFrom []string
+	MigratedTo   []string
+	AppliedRef   string
+	TargetRef    string
 }
 
 // Action enumerates the kinds of changes a kustomization plan can produce for a
@@ -210,7 +225,9 @@ func (s *FluxStack) Plan(blueprint *blueprintv1alpha1.Blueprint, componentID str
 // row is marked Degraded=true rather than returning an error entry. Cluster
 // connectivity failures are also handled gracefully: when KustomizationExists
 // returns an error the kustomization is treated as new and planned via kustomize
-// build instead.
+// build instead. Each result carries its Transition, and kustomizations this
+// context runs that the blueprint no longer declares are appended as reclaimed
+// rows listing the objects pruning them would delete.
 func (s *FluxStack) PlanSummary(blueprint *blueprintv1alpha1.Blueprint) ([]KustomizePlan, []string) {
 	if blueprint == nil {
 		return nil, nil
@@ -243,7 +260,7 @@ func (s *FluxStack) PlanSummary(blueprint *blueprintv1alpha1.Blueprint) ([]Kusto
 		results = append(results, s.planOneKustomizeSummary(blueprint, k, namespace, fluxMissing, kustomizeMissing))
 	}
 
-	return results, hints
+	return s.classifyTransitions(blueprint, namespace, results), hints
 }
 
 // PlanComponentSummary plans a single kustomization by name and returns its structured
@@ -353,6 +370,64 @@ func (s *FluxStack) PlanDestroyComponentSummary(blueprint *blueprintv1alpha1.Blu
 	return resolved
 }
 
+// DriftSummary reports, for every non-destroyOnly kustomization, the objects in flux's live
+// inventory that were deleted or edited by hand. It never renders the blueprint: objects are
+// read from the inventory flux recorded at its last reconcile, so unapplied blueprint edits are
+// not mistaken for out-of-band changes. Objects deleted out of band are reported as ActionDelete
+// and manual edits, detected from interactive field managers, as ActionUpdate (see
+// KubernetesManager.GetInventoryManualEdits); edits by other clients are not detected. A kustomization absent from the
+// cluster, or a missing kubeconfig, yields IsNew; other cluster errors are recorded on the
+// component's Err so the remaining kustomizations are still checked.
+func (s *FluxStack) DriftSummary(blueprint *blueprintv1alpha1.Blueprint) []KustomizePlan {
+	if blueprint == nil {
+		return nil
+	}
+
+	namespace := s.gitopsNamespace()
+
+	var results []KustomizePlan
+	for _, k := range blueprint.Kustomizations {
+		if k.DestroyOnly != nil && *k.DestroyOnly {
+			continue
+		}
+		results = append(results, s.driftOneKustomization(k, namespace))
+	}
+	return results
+}
+
+// driftOneKustomization computes the drift report for one kustomization by reading its
+// inventory and checking each entry's live object for deletion or manual edits.
+func (s *FluxStack) driftOneKustomization(k blueprintv1alpha1.Kustomization, namespace string) KustomizePlan {
+	result := KustomizePlan{Name: k.Name}
+
+	entries, err := s.kubernetesManager.GetKustomizationInventory(k.Name, namespace)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			result.IsNew = true
+			return result
+		}
+		result.Err = fmt.Errorf("error querying kustomization %q inventory: %w", k.Name, err)
+		return result
+	}
+	if entries == nil {
+		result.IsNew = true
+		return result
+	}
+
+	edits, err := s.kubernetesManager.GetInventoryManualEdits(entries)
+	if err != nil {
+		result.Err = fmt.Errorf("error checking kustomization %q for edits: %w", k.Name, err)
+		return result
+	}
+	for _, e := range edits.Missing {
+		result.Resources = append(result.Resources, ResourceChange{Address: inventoryAddress(e), Action: ActionDelete})
+	}
+	for _, e := range edits.ManuallyEdited {
+		result.Resources = append(result.Resources, ResourceChange{Address: inventoryAddress(e), Action: ActionUpdate})
+	}
+	return result
+}
+
 // planOneKustomizeDestroySummary computes the destroy preview for one
 // kustomization. Pulls flux's live inventory and tags every entry as Delete.
 // IsNew marks the not-deployed case so the renderer shows "(not deployed)" —
@@ -485,6 +560,12 @@ func (s *FluxStack) planOneKustomizeSummary(blueprint *blueprintv1alpha1.Bluepri
 		exists = false
 	}
 
+	if bucketSourceName(blueprint, k) != "" {
+		result.IsNew = !exists
+		result.Degraded = true
+		return result
+	}
+
 	sourceRoot := s.resolveSourceRoot(blueprint, k)
 	fluxK := k.ToFluxKustomization(namespace, blueprint.Metadata.Name, blueprint.Sources, s.gitopsMode())
 	localPath := filepath.Join(sourceRoot, fluxK.Spec.Path)
@@ -522,8 +603,15 @@ func (s *FluxStack) planOneKustomizeSummary(blueprint *blueprintv1alpha1.Bluepri
 // planOne runs flux diff for a single kustomization. It checks whether the kustomization
 // already exists in the cluster and dispatches to the appropriate diff strategy.
 // If the cluster is not reachable, the kustomization is treated as new and planned
-// via kustomize build instead of flux diff.
+// via kustomize build instead of flux diff. A kustomization reading from a bucket
+// source is skipped with a note, as there is no local copy of its manifests.
 func (s *FluxStack) planOne(blueprint *blueprintv1alpha1.Blueprint, k blueprintv1alpha1.Kustomization, namespace string) error {
+	if bucket := bucketSourceName(blueprint, k); bucket != "" {
+		fmt.Fprintf(os.Stderr, "\n%s\n", tui.SectionHeader("Kustomize: "+k.Name))
+		fmt.Fprintf(os.Stderr, "Skipped: bucket source %q is fetched in-cluster and has no local copy to diff.\n", bucket)
+		return nil
+	}
+
 	exists, err := s.kubernetesManager.KustomizationExists(k.Name, namespace)
 	if err != nil {
 		// Cluster not reachable — treat as new.
@@ -542,31 +630,17 @@ func (s *FluxStack) planOne(blueprint *blueprintv1alpha1.Blueprint, k blueprintv
 }
 
 // resolveSourceRoot returns the local filesystem root directory for a kustomization's source.
-// For OCI sources (url starts with oci://), the root is the extracted OCI cache directory at
+// For OCI sources, the root is the extracted OCI cache directory at
 // <projectRoot>/.windsor/cache/oci/<key>. For Git/local sources it is the project root.
 // The OCI cache key mirrors the GetCacheDir logic in pkg/composer/artifact.
 func (s *FluxStack) resolveSourceRoot(blueprint *blueprintv1alpha1.Blueprint, k blueprintv1alpha1.Kustomization) string {
-	sourceName := k.Source
-	if sourceName == "" {
-		sourceName = blueprint.Metadata.Name
-	}
-	if sourceName == "template" && !blueprintv1alpha1.HasRemoteTemplateSource(blueprint.Sources) {
-		sourceName = blueprint.Metadata.Name
-	}
-
-	for _, source := range blueprint.Sources {
-		if source.Name != sourceName {
-			continue
-		}
-		if !strings.HasPrefix(source.Url, "oci://") {
-			break
-		}
-		ref := strings.TrimPrefix(source.Url, "oci://")
-		extractionKey := strings.ReplaceAll(strings.ReplaceAll(ref, "/", "_"), ":", "_")
-		return filepath.Join(s.runtime.ProjectRoot, ".windsor", "cache", "oci", extractionKey)
+	source, found := findSource(blueprint, k)
+	if !found || blueprintv1alpha1.SourceKind(source) != blueprintv1alpha1.SourceKindOCI {
+		return s.runtime.ProjectRoot
 	}
-
-	return s.runtime.ProjectRoot
+	ref := strings.TrimPrefix(source.Url, "oci://")
+	extractionKey := strings.ReplaceAll(strings.ReplaceAll(ref, "/", "_"), ":", "_")
+	return filepath.Join(s.runtime.ProjectRoot, ".windsor", "cache", "oci", extractionKey)
 }
 
 // runFromScratch renders the raw kustomize manifests for a kustomization that has not yet
@@ -867,7 +941,8 @@ func mapFluxAction(verb string) Action {
 
 // encodeKustomizationsJSON builds each kustomization in targets via kustomize build,
 // converts the YAML output to JSON, and writes a JSON array of
-// {"kustomization": name, "resources": [...]} objects to w.
+// {"kustomization": name, "resources": [...]} objects to w. Kustomizations reading from a
+// bucket source are omitted, since their manifests have no local copy to build.
 func (s *FluxStack) encodeKustomizationsJSON(w io.Writer, blueprint *blueprintv1alpha1.Blueprint, namespace string, targets []blueprintv1alpha1.Kustomization) error {
 	type entry struct {
 		Kustomization string            `json:"kustomization"`
@@ -876,6 +951,9 @@ func (s *FluxStack) encodeKustomizationsJSON(w io.Writer, blueprint *blueprintv1
 
 	var results []entry
 	for _, k := range targets {
+		if bucketSourceName(blueprint, k) != "" {
+			continue
+		}
 		fluxK := k.ToFluxKustomization(namespace, blueprint.Metadata.Name, blueprint.Sources, s.gitopsMode())
 		sourceRoot := s.resolveSourceRoot(blueprint, k)
 		localPath := filepath.Join(sourceRoot, fluxK.Spec.Path)
@@ -922,6 +1000,37 @@ func findKustomization(blueprint *blueprintv1alpha1.Blueprint, name string) (blu
 	return blueprintv1alpha1.Kustomization{}, false
 }
 
+// findSource returns the blueprint source a kustomization reads from, resolving an empty source to
+// the blueprint's own repository and a local template source to the blueprint's own repository as
+// ToFluxKustomization does. It reports false when the kustomization reads from the blueprint's own
+// repository, which has no Sources entry.
+func findSource(blueprint *blueprintv1alpha1.Blueprint, k blueprintv1alpha1.Kustomization) (blueprintv1alpha1.Source, bool) {
+	sourceName := k.Source
+	if sourceName == "" {
+		sourceName = blueprint.Metadata.Name
+	}
+	if sourceName == "template" && !blueprintv1alpha1.HasRemoteTemplateSource(blueprint.Sources) {
+		sourceName = blueprint.Metadata.Name
+	}
+	for _, source := range blueprint.Sources {
+		if source.Name == sourceName {
+			return source, true
+		}
+	}
+	return blueprintv1alpha1.Source{}, false
+}
+
+// bucketSourceName returns the name of k's source when it is a bucket source, and "" otherwise.
+// A bucket's contents are fetched only by source-controller in the cluster, so no local copy
+// exists for plan to diff or build.
+func bucketSourceName(blueprint *blueprintv1alpha1.Blueprint, k blueprintv1alpha1.Kustomization) string {
+	source, found := findSource(blueprint, k)
+	if !found || blueprintv1alpha1.SourceKind(source) != blueprintv1alpha1.SourceKindBucket {
+		return ""
+	}
+	return source.Name
+}
+
 // =============================================================================
 // Helpers
 // =============================================================================
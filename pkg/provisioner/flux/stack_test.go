@@ -642,6 +642,35 @@ func TestFluxStack_PlanSummary(t *testing.T) {
 		}
 	})
 
+	t.Run("DegradesKustomizationFromBucketSource", func(t *testing.T) {
+		// Given an existing kustomization that reads from a bucket source
+		m := setupFluxMocks(t)
+		var commands []string
+		m.shell.ExecCaptureWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			commands = append(commands, command)
+			return "", nil
+		}
+		s := newTestFluxStack(m)
+		bp := &blueprintv1alpha1.Blueprint{
+			Metadata: blueprintv1alpha1.Metadata{Name: "test-blueprint"},
+			Sources: []blueprintv1alpha1.Source{
+				{Name: "manifests", Kind: blueprintv1alpha1.SourceKindBucket, Url: "https://s3.amazonaws.com", BucketName: "platform"},
+			},
+			Kustomizations: []blueprintv1alpha1.Kustomization{{Name: "apps", Source: "manifests"}},
+		}
+
+		// When PlanSummary is called
+		results, _ := s.PlanSummary(bp)
+
+		// Then the row is degraded without running flux or kustomize against a local path
+		if len(results) != 1 || !results[0].Degraded || results[0].IsNew || results[0].Err != nil {
+			t.Errorf("expected one degraded existing row, got %+v", results)
+		}
+		if len(commands) != 0 {
+			t.Errorf("expected no commands run, got %v", commands)
+		}
+	})
+
 	t.Run("TreatsKustomizationAsNewWhenClusterUnreachable", func(t *testing.T) {
 		// Given a kubernetes manager that returns an error on KustomizationExists
 		m := setupFluxMocks(t)
@@ -1012,6 +1041,100 @@ func TestFluxStack_PlanDestroyComponentSummary(t *testing.T) {
 	})
 }
 
+func TestFluxStack_DriftSummary(t *testing.T) {
+	t.Run("ReturnsNilForNilBlueprint", func(t *testing.T) {
+		m := setupFluxMocks(t)
+		s := newTestFluxStack(m)
+		if got := s.DriftSummary(nil); got != nil {
+			t.Errorf("expected nil, got %#v", got)
+		}
+	})
+
+	t.Run("ReportsMissingAndManuallyEditedInventoryObjects", func(t *testing.T) {
+		// Given a deployed kustomization with one object deleted and one edited out of band
+		m := setupFluxMocks(t)
+		m.kubernetesManager.GetKustomizationInventoryFunc = func(name, namespace string) ([]kubernetes.InventoryEntry, error) {
+			return []kubernetes.InventoryEntry{
+				{Namespace: "monitoring", Name: "grafana", Group: "apps", Kind: "Deployment"},
+				{Namespace: "monitoring", Name: "grafana-config", Kind: "ConfigMap"},
+			}, nil
+		}
+		m.kubernetesManager.GetInventoryManualEditsFunc = func(entries []kubernetes.InventoryEntry) (kubernetes.InventoryManualEdits, error) {
+			return kubernetes.InventoryManualEdits{
+				Missing:        []kubernetes.InventoryEntry{entries[1]},
+				ManuallyEdited: []kubernetes.InventoryEntry{entries[0]},
+			}, nil
+		}
+		s := newTestFluxStack(m)
+
+		// When DriftSummary runs
+		results := s.DriftSummary(testBlueprint())
+
+		// Then destroyOnly kustomizations are skipped and drifted objects are reported
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %#v", results)
+		}
+		want := []ResourceChange{
+			{Address: "ConfigMap/monitoring/grafana-config", Action: ActionDelete},
+			{Address: "Deployment/monitoring/grafana", Action: ActionUpdate},
+		}
+		got := results[0].Resources
+		if len(got) != len(want) {
+			t.Fatalf("expected %d resources, got %#v", len(want), got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("[%d] expected %#v, got %#v", i, want[i], got[i])
+			}
+		}
+	})
+
+	t.Run("MarksUndeployedKustomizationsNew", func(t *testing.T) {
+		// Given a cluster with no kubeconfig
+		m := setupFluxMocks(t)
+		m.kubernetesManager.GetKustomizationInventoryFunc = func(name, namespace string) ([]kubernetes.InventoryEntry, error) {
+			return nil, os.ErrNotExist
+		}
+		s := newTestFluxStack(m)
+
+		// When DriftSummary runs
+		results := s.DriftSummary(testBlueprint())
+
+		// Then every kustomization is reported as not deployed
+		for _, r := range results {
+			if !r.IsNew || r.Err != nil {
+				t.Errorf("expected %s to be IsNew without error, got %#v", r.Name, r)
+			}
+		}
+	})
+
+	t.Run("RecordsClusterErrorsPerKustomization", func(t *testing.T) {
+		// Given a cluster whose inventory read fails for one kustomization
+		m := setupFluxMocks(t)
+		m.kubernetesManager.GetKustomizationInventoryFunc = func(name, namespace string) ([]kubernetes.InventoryEntry, error) {
+			if name == "my-app" {
+				return nil, fmt.Errorf("forbidden")
+			}
+			return []kubernetes.InventoryEntry{}, nil
+		}
+		s := newTestFluxStack(m)
+
+		// When DriftSummary runs
+		results := s.DriftSummary(testBlueprint())
+
+		// Then the failure is recorded on that kustomization and the other is still checked
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %#v", results)
+		}
+		if results[0].Err == nil || !strings.Contains(results[0].Err.Error(), "forbidden") {
+			t.Errorf("expected inventory error on my-app, got %v", results[0].Err)
+		}
+		if results[1].Err != nil || results[1].IsNew || len(results[1].Resources) != 0 {
+			t.Errorf("expected infra-base to report no drift, got %#v", results[1])
+		}
+	})
+}
+
 func TestCountDiffLines(t *testing.T) {
 	t.Run("CountsAddedAndRemovedLines", func(t *testing.T) {
 		// Given a unified diff with additions and removals
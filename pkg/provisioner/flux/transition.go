@@ -0,0 +1,152 @@
+// Package flux provides Flux kustomization stack management functionality.
+// This file classifies how each kustomization moves between what the cluster runs and what
+// the blueprint declares: new, changed, unchanged, migrated (taking over objects another
+// kustomization owned, as when an upgrade renames one) or reclaimed (no longer declared, so
+// its objects are garbage-collected). Without it a rename reads as an unrelated delete and add.
+
+package flux
+
+import (
+	"slices"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// Transition is how a kustomization changes when the blueprint is applied.
+type Transition string
+
+const (
+	// TransitionNew means the kustomization is not yet in the cluster.
+	TransitionNew Transition = "new"
+	// TransitionChanged means the kustomization exists and applying changes what it manages.
+	TransitionChanged Transition = "changed"
+	// TransitionUnchanged means the kustomization exists and applying changes nothing.
+	TransitionUnchanged Transition = "unchanged"
+	// TransitionMigrated means the kustomization takes over objects owned by a kustomization the
+	// blueprint no longer declares, so they are adopted rather than deleted and recreated.
+	TransitionMigrated Transition = "migrated"
+	// TransitionReclaimed means the blueprint no longer declares the kustomization; pruning it
+	// garbage-collects the objects no other kustomization takes over.
+	TransitionReclaimed Transition = "reclaimed"
+	// TransitionUnknown means the kustomization exists but could not be diffed and its source did
+	// not move, so whether applying changes it is not known.
+	TransitionUnknown Transition = "unknown"
+)
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// classifyTransitions sets the Transition of every planned kustomization in results and appends a
+// reclaimed row for each kustomization this context runs that the blueprint no longer declares.
+// A reclaimed row lists as deleted only the inventory objects no declared kustomization plans to
+// create or update; the rest are credited to the kustomizations taking them over, which are marked
+// migrated. Source versions come from the version marker's applied sources, read from the gitops
+// namespace where apply records it: a kustomization whose source moves records both refs, and an
+// existing kustomization that could not be diffed is classified as changed when its source moves
+// and as unknown otherwise. Reclaimed kustomizations are found in whichever namespace their objects
+// live, and each inventory is read there. Cluster reads are best-effort: without a marker or a
+// readable cluster, only what the diffs show is classified.
+func (s *FluxStack) classifyTransitions(blueprint *blueprintv1alpha1.Blueprint, namespace string, results []KustomizePlan) []KustomizePlan {
+	applied := map[string]kubernetes.SourceRef{}
+	if marker, found, err := s.kubernetesManager.GetVersionMarker(namespace); err == nil && found {
+		applied = marker.AppliedSources
+	}
+	target, err := kubernetes.BuildVersionMarker(blueprint)
+	if err != nil {
+		target = kubernetes.VersionMarker{}
+	}
+
+	for i := range results {
+		r := &results[i]
+		if k, ok := findKustomization(blueprint, r.Name); ok {
+			source := kustomizationSourceName(blueprint, k)
+			if from, ok := applied[source]; ok && from.Ref != target.AppliedSources[source].Ref {
+				r.AppliedRef, r.TargetRef = from.Ref, target.AppliedSources[source].Ref
+			}
+		}
+		switch {
+		case r.Err != nil:
+			// A kustomization that could not be planned is left unclassified.
+		case r.IsNew:
+			r.Transition = TransitionNew
+		case r.Degraded && r.AppliedRef != r.TargetRef:
+			r.Transition = TransitionChanged
+		case r.Degraded:
+			r.Transition = TransitionUnknown
+		case len(r.Resources) > 0 || r.Added+r.Removed > 0:
+			r.Transition = TransitionChanged
+		default:
+			r.Transition = TransitionUnchanged
+		}
+	}
+
+	prunable, err := s.kubernetesManager.ListPrunableKustomizations(blueprint, namespace)
+	if err != nil || len(prunable) == 0 {
+		return results
+	}
+	reclaimed := make([]KustomizePlan, 0, len(prunable))
+	owners := map[string]int{}
+	inventories := make([][]kubernetes.InventoryEntry, len(prunable))
+	for i, orphan := range prunable {
+		reclaimed = append(reclaimed, KustomizePlan{Name: orphan.Name, Transition: TransitionReclaimed})
+		entries, err := s.kubernetesManager.GetKustomizationInventory(orphan.Name, orphan.ObjectNamespace(namespace))
+		if err != nil {
+			reclaimed[i].Err = err
+			continue
+		}
+		inventories[i] = entries
+		for _, e := range entries {
+			owners[inventoryAddress(e)] = i
+		}
+	}
+
+	adopted := map[string]bool{}
+	for i := range results {
+		r := &results[i]
+		for _, change := range r.Resources {
+			owner, ok := owners[change.Address]
+			if !ok || (change.Action != ActionCreate && change.Action != ActionUpdate) {
+				continue
+			}
+			adopted[change.Address] = true
+			r.Transition = TransitionMigrated
+			if !slices.Contains(r.MigratedFrom, prunable[owner].Name) {
+				r.MigratedFrom = append(r.MigratedFrom, prunable[owner].Name)
+			}
+			if !slices.Contains(reclaimed[owner].MigratedTo, r.Name) {
+				reclaimed[owner].MigratedTo = append(reclaimed[owner].MigratedTo, r.Name)
+			}
+		}
+	}
+
+	for i, entries := range inventories {
+		for _, e := range entries {
+			address := inventoryAddress(e)
+			if adopted[address] {
+				continue
+			}
+			reclaimed[i].Resources = append(reclaimed[i].Resources, ResourceChange{Address: address, Action: ActionDelete})
+		}
+		reclaimed[i].Removed = len(reclaimed[i].Resources)
+	}
+	return append(results, reclaimed...)
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// kustomizationSourceName returns the name of the blueprint source a kustomization reads from, as
+// the version marker keys it: the blueprint's name for its own repository.
+func kustomizationSourceName(blueprint *blueprintv1alpha1.Blueprint, k blueprintv1alpha1.Kustomization) string {
+	if source, found := findSource(blueprint, k); found {
+		return source.Name
+	}
+	return blueprint.Metadata.Name
+}
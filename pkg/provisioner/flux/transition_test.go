@@ -0,0 +1,202 @@
+package flux
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// transitionBlueprint declares dns and ingress reading from the core source at v0.6.0.
+func transitionBlueprint() *blueprintv1alpha1.Blueprint {
+	return &blueprintv1alpha1.Blueprint{
+		Metadata: blueprintv1alpha1.Metadata{Name: "local"},
+		Sources: []blueprintv1alpha1.Source{
+			{Name: "core", Url: "oci://ghcr.io/windsorcli/core:v0.6.0", Ref: blueprintv1alpha1.Reference{Tag: "v0.6.0"}},
+		},
+		Kustomizations: []blueprintv1alpha1.Kustomization{
+			{Name: "dns", Source: "core"},
+			{Name: "ingress", Source: "core"},
+		},
+	}
+}
+
+// =============================================================================
+// Test Private Methods
+// =============================================================================
+
+func TestFluxStack_classifyTransitions(t *testing.T) {
+	t.Run("ClassifiesFromPlanResults", func(t *testing.T) {
+		// Given one new, one changed, one unchanged and one failed kustomization and no cluster orphans
+		m := setupFluxMocks(t)
+		s := newTestFluxStack(m)
+		results := []KustomizePlan{
+			{Name: "a", IsNew: true},
+			{Name: "b", Resources: []ResourceChange{{Address: "ConfigMap/app/b", Action: ActionUpdate}}},
+			{Name: "c"},
+			{Name: "d", Err: fmt.Errorf("diff failed")},
+		}
+
+		// When transitions are classified
+		got := s.classifyTransitions(transitionBlueprint(), "system-gitops", results)
+
+		// Then each is classified from its plan and the failed one is left unclassified
+		want := []Transition{TransitionNew, TransitionChanged, TransitionUnchanged, ""}
+		if len(got) != len(want) {
+			t.Fatalf("Expected %d results, got %+v", len(want), got)
+		}
+		for i, w := range want {
+			if got[i].Transition != w {
+				t.Errorf("Expected %s to be %q, got %q", got[i].Name, w, got[i].Transition)
+			}
+		}
+	})
+
+	t.Run("DetectsMigrationFromReclaimedKustomization", func(t *testing.T) {
+		// Given ingress, renamed from old-ingress, taking over one of old-ingress's two objects
+		m := setupFluxMocks(t)
+		m.kubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old-ingress"}}, nil
+		}
+		m.kubernetesManager.GetKustomizationInventoryFunc = func(name, namespace string) ([]kubernetes.InventoryEntry, error) {
+			if name != "old-ingress" {
+				return nil, fmt.Errorf("unexpected inventory read for %s", name)
+			}
+			return []kubernetes.InventoryEntry{
+				{Namespace: "ingress", Name: "nginx", Group: "apps", Kind: "Deployment"},
+				{Namespace: "ingress", Name: "legacy", Kind: "ConfigMap"},
+			}, nil
+		}
+		s := newTestFluxStack(m)
+		results := []KustomizePlan{
+			{Name: "dns"},
+			{Name: "ingress", IsNew: true, Resources: []ResourceChange{
+				{Address: "Deployment/ingress/nginx", Action: ActionCreate},
+				{Address: "Service/ingress/nginx", Action: ActionCreate},
+			}},
+		}
+
+		// When transitions are classified
+		got := s.classifyTransitions(transitionBlueprint(), "system-gitops", results)
+
+		// Then ingress is migrated from old-ingress, which is reclaimed deleting only what ingress does not adopt
+		if len(got) != 3 {
+			t.Fatalf("Expected a reclaimed row appended, got %+v", got)
+		}
+		if got[1].Transition != TransitionMigrated || !slices.Equal(got[1].MigratedFrom, []string{"old-ingress"}) {
+			t.Errorf("Expected ingress migrated from old-ingress, got %+v", got[1])
+		}
+		reclaimed := got[2]
+		if reclaimed.Name != "old-ingress" || reclaimed.Transition != TransitionReclaimed || !slices.Equal(reclaimed.MigratedTo, []string{"ingress"}) {
+			t.Errorf("Expected old-ingress reclaimed into ingress, got %+v", reclaimed)
+		}
+		if len(reclaimed.Resources) != 1 || reclaimed.Resources[0] != (ResourceChange{Address: "ConfigMap/ingress/legacy", Action: ActionDelete}) || reclaimed.Removed != 1 {
+			t.Errorf("Expected only the legacy ConfigMap deleted, got %+v", reclaimed.Resources)
+		}
+	})
+
+	t.Run("RecordsSourceRefMoveFromVersionMarker", func(t *testing.T) {
+		// Given a marker recording core at v0.5.0 and a dns kustomization that could not be diffed
+		m := setupFluxMocks(t)
+		m.kubernetesManager.GetVersionMarkerFunc = func(namespace string) (kubernetes.VersionMarker, bool, error) {
+			return kubernetes.VersionMarker{AppliedSources: map[string]kubernetes.SourceRef{
+				"core": {URL: "oci://ghcr.io/windsorcli/core:v0.5.0", Ref: "v0.5.0"},
+			}}, true, nil
+		}
+		s := newTestFluxStack(m)
+		results := []KustomizePlan{{Name: "dns", Degraded: true}}
+
+		// When transitions are classified against a blueprint at v0.6.0
+		got := s.classifyTransitions(transitionBlueprint(), "system-gitops", results)
+
+		// Then the move is recorded and classifies the degraded kustomization as changed
+		if got[0].AppliedRef != "v0.5.0" || got[0].TargetRef != "v0.6.0" {
+			t.Errorf("Expected v0.5.0 → v0.6.0, got %q → %q", got[0].AppliedRef, got[0].TargetRef)
+		}
+		if got[0].Transition != TransitionChanged {
+			t.Errorf("Expected changed, got %q", got[0].Transition)
+		}
+	})
+
+	t.Run("ReportsDegradedUnknownWithoutSourceMove", func(t *testing.T) {
+		// Given no version marker and a kustomization that could not be diffed
+		m := setupFluxMocks(t)
+		s := newTestFluxStack(m)
+
+		// When transitions are classified
+		got := s.classifyTransitions(transitionBlueprint(), "system-gitops", []KustomizePlan{{Name: "dns", Degraded: true}})
+
+		// Then it is reported as unknown, with no source move claimed
+		if got[0].Transition != TransitionUnknown || got[0].AppliedRef != "" || got[0].TargetRef != "" {
+			t.Errorf("Expected an unknown transition, got %+v", got[0])
+		}
+	})
+
+	t.Run("OmitsReclaimedRowsWhenClusterUnreadable", func(t *testing.T) {
+		// Given a cluster whose kustomizations cannot be listed
+		m := setupFluxMocks(t)
+		m.kubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+		s := newTestFluxStack(m)
+
+		// When transitions are classified
+		got := s.classifyTransitions(transitionBlueprint(), "system-gitops", []KustomizePlan{{Name: "dns"}})
+
+		// Then the declared kustomizations are still classified and no reclaimed rows are added
+		if len(got) != 1 || got[0].Transition != TransitionUnchanged {
+			t.Errorf("Expected dns alone, unchanged, got %+v", got)
+		}
+	})
+
+	t.Run("ReadsReclaimedInventoryInItsOwnNamespace", func(t *testing.T) {
+		// Given an orphaned kustomization living outside the gitops namespace
+		m := setupFluxMocks(t)
+		m.kubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old", Namespace: "team-a"}}, nil
+		}
+		var inventoryNamespace string
+		m.kubernetesManager.GetKustomizationInventoryFunc = func(name, namespace string) ([]kubernetes.InventoryEntry, error) {
+			inventoryNamespace = namespace
+			return []kubernetes.InventoryEntry{{Namespace: "team-a", Name: "legacy", Kind: "ConfigMap"}}, nil
+		}
+		s := newTestFluxStack(m)
+
+		// When transitions are classified
+		got := s.classifyTransitions(transitionBlueprint(), "system-gitops", nil)
+
+		// Then its inventory is read from its own namespace
+		if inventoryNamespace != "team-a" {
+			t.Errorf("Expected inventory read in team-a, got %q", inventoryNamespace)
+		}
+		if len(got) != 1 || got[0].Transition != TransitionReclaimed || got[0].Removed != 1 {
+			t.Errorf("Expected one reclaimed row removing the ConfigMap, got %+v", got)
+		}
+	})
+
+	t.Run("RecordsInventoryErrorOnReclaimedRow", func(t *testing.T) {
+		// Given an orphaned kustomization whose inventory cannot be read
+		m := setupFluxMocks(t)
+		m.kubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
+			return []blueprintv1alpha1.Kustomization{{Name: "old"}}, nil
+		}
+		m.kubernetesManager.GetKustomizationInventoryFunc = func(name, namespace string) ([]kubernetes.InventoryEntry, error) {
+			return nil, fmt.Errorf("forbidden")
+		}
+		s := newTestFluxStack(m)
+
+		// When transitions are classified
+		got := s.classifyTransitions(transitionBlueprint(), "system-gitops", nil)
+
+		// Then the reclaimed row carries the error
+		if len(got) != 1 || got[0].Transition != TransitionReclaimed || got[0].Err == nil {
+			t.Errorf("Expected reclaimed row with error, got %+v", got)
+		}
+	})
+}
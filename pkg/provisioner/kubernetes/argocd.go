@@ -0,0 +1,168 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file holds what the Argo CD engine needs beyond the Applications themselves: the root
+// Application push mode applies to sync the rendered tree, the Application health check that
+// makes its sync waves wait on earlier Applications, and the definition of the substitution
+// plugin the Applications select.
+
+package kubernetes
+
+import (
+	"fmt"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// GitopsSyncApplicationName is the name of the root Argo CD Application push mode applies when
+// the gitops engine is Argo CD. It syncs the rendered tree of Applications from the blueprint's
+// repository, the counterpart of GitopsSyncKustomizationName.
+const GitopsSyncApplicationName = "windsor-gitops-sync"
+
+// ArgoCDPluginConfigMapName is the ConfigMap the rendered tree carries the substitution plugin's
+// definition in, under plugin.yaml, for a repo server sidecar to mount as its plugin config.
+const ArgoCDPluginConfigMapName = "windsor-envsubst-plugin"
+
+// argoCDConfigMapName is the ConfigMap Argo CD reads its resource customizations from.
+const argoCDConfigMapName = "argocd-cm"
+
+// argoCDApplicationHealthKey is the argocd-cm key holding the health check for Applications.
+// Argo CD 1.8 dropped its built-in Application health assessment, so without it a parent
+// Application treats every child as healthy and its sync waves do not wait on one another.
+const argoCDApplicationHealthKey = "resource.customizations.health.argoproj.io_Application"
+
+// argoCDApplicationHealthCheck reports an Application as healthy only once Argo CD has assessed
+// its own resources healthy, Progressing until then.
+const argoCDApplicationHealthCheck = `hs = {}
+hs.status = "Progressing"
+hs.message = ""
+if obj.status ~= nil then
+  if obj.status.health ~= nil then
+    hs.status = obj.status.health.status
+    if obj.status.health.message ~= nil then
+      hs.message = obj.status.health.message
+    end
+  end
+end
+return hs
+`
+
+// argoCDSubstitutionPluginDefinition is the ConfigManagementPlugin implementing
+// blueprintv1alpha1.ArgoCDSubstitutionPlugin. It builds the Application's path through an overlay
+// adding the components, patches and namespace parameters, then substitutes the Application's
+// ARGOCD_ENV_ variables, prefix stripped, with flux envsubst so ${var:=default} and the other
+// postBuild forms resolve as Flux would. The sidecar image must provide sh, kustomize and flux.
+var argoCDSubstitutionPluginDefinition = `apiVersion: argoproj.io/v1alpha1
+kind: ConfigManagementPlugin
+metadata:
+  name: ` + blueprintv1alpha1.ArgoCDSubstitutionPlugin + `
+spec:
+  generate:
+    command: [sh, -c]
+    args:
+      - |
+        set -eu
+        for name in $(env | sed -n 's/^ARGOCD_ENV_\([A-Za-z_][A-Za-z0-9_]*\)=.*/\1/p'); do
+          export "$name=$(printenv "ARGOCD_ENV_$name")"
+        done
+        overlay=$(mktemp -d .windsor-envsubst.XXXXXX)
+        {
+          printf 'apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n- ..\n'
+          i=0
+          while component=$(printenv "PARAM_COMPONENTS_$i"); do
+            if [ "$i" -eq 0 ]; then printf 'components:\n'; fi
+            printf -- '- ../%s\n' "$component"
+            i=$((i + 1))
+          done
+          if [ -n "${PARAM_PATCHES:-}" ]; then printf 'patches:\n%s\n' "$PARAM_PATCHES"; fi
+          if [ -n "${PARAM_NAMESPACE:-}" ]; then printf 'namespace: %s\n' "$PARAM_NAMESPACE"; fi
+        } > "$overlay/kustomization.yaml"
+        kustomize build "$overlay" | flux envsubst
+  parameters:
+    static:
+      - name: components
+        collectionType: array
+      - name: patches
+        collectionType: string
+      - name: namespace
+        collectionType: string
+`
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// ApplyArgoCDSync applies what push mode needs for an Argo CD instance to sync from git: the
+// Application health check in argocd-cm, so the root Application's sync waves wait on earlier
+// Applications, and the root Application syncing treePath — the directory in the blueprint's
+// repository holding the rendered Applications — both in gitops.argocd.namespace. The health
+// check is applied server-side as the only field the CLI owns in argocd-cm, leaving the rest of
+// the instance's configuration in place. It fails when the blueprint declares no repository,
+// treePath is not a relative path inside the repository, or either object cannot be applied.
+func (k *BaseKubernetesManager) ApplyArgoCDSync(blueprint *blueprintv1alpha1.Blueprint, treePath string) error {
+	cleaned, err := repositoryTreePath(treePath)
+	if err != nil {
+		return err
+	}
+	namespace := k.argoCDNamespace()
+	content, err := blueprint.ToArgoSyncApplication(GitopsSyncApplicationName, namespace, cleaned)
+	if err != nil {
+		return err
+	}
+
+	health := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      argoCDConfigMapName,
+			"namespace": namespace,
+			"labels":    map[string]any{"app.kubernetes.io/part-of": "argocd"},
+		},
+		"data": map[string]any{argoCDApplicationHealthKey: argoCDApplicationHealthCheck},
+	}}
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	if _, err := k.client.ApplyResource(configMaps, health, metav1.ApplyOptions{FieldManager: "windsor-cli", Force: true}); err != nil {
+		return fmt.Errorf("failed to apply argo cd application health check: %w", err)
+	}
+
+	app := &unstructured.Unstructured{Object: content}
+	labels, annotations := k.provenance(blueprint.Metadata.Name, sourceVersion(blueprint, blueprint.Metadata.Name))
+	app.SetLabels(labels)
+	app.SetAnnotations(annotations)
+	applications := schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+	if err := k.applyWithRetry(applications, app, metav1.ApplyOptions{FieldManager: "windsor-cli"}); err != nil {
+		return fmt.Errorf("failed to apply gitops sync application: %w", err)
+	}
+	return nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// argoCDNamespace returns the namespace Argo CD Applications live in: gitops.argocd.namespace,
+// defaulting to DefaultArgoCDNamespace.
+func (k *BaseKubernetesManager) argoCDNamespace() string {
+	return k.configHandler.GetString("gitops.argocd.namespace", constants.DefaultArgoCDNamespace)
+}
+
+// argoPluginConfigMap returns the ConfigMap carrying the substitution plugin's definition in
+// namespace, stamped with the provenance of the blueprint's own repository.
+func (k *BaseKubernetesManager) argoPluginConfigMap(blueprint *blueprintv1alpha1.Blueprint, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": ArgoCDPluginConfigMapName, "namespace": namespace},
+		"data":       map[string]any{"plugin.yaml": argoCDSubstitutionPluginDefinition},
+	}}
+	labels, annotations := k.provenance(blueprint.Metadata.Name, sourceVersion(blueprint, blueprint.Metadata.Name))
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return obj
+}
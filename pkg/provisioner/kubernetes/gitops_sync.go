@@ -0,0 +1,97 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file applies the GitOps sync for push mode: the blueprint's repository source and a single
+// root Kustomization reconciling the rendered tree the CLI committed to that repository. In push
+// mode these are the only Flux objects the CLI applies itself; every other source, ConfigMap and
+// Kustomization reaches the cluster through git.
+
+package kubernetes
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// GitopsSyncKustomizationName is the name of the root Kustomization push mode applies. It
+// reconciles the rendered tree from the blueprint's repository and is never pruned as an orphan.
+const GitopsSyncKustomizationName = "windsor-gitops-sync"
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// ApplyGitopsSync applies what push mode needs for the cluster to reconcile from git: the
+// namespace, the blueprint's repository source, and the root Kustomization reconciling
+// treePath — the directory in that repository holding the rendered kustomization.yaml. The
+// Kustomization prunes, so objects dropped from the committed tree are removed from the cluster
+// once the commit is reconciled. It fails when the blueprint declares no repository, treePath is
+// not a relative path inside the repository, or any object cannot be applied.
+func (k *BaseKubernetesManager) ApplyGitopsSync(blueprint *blueprintv1alpha1.Blueprint, namespace, treePath string) error {
+	source, ok := repositorySource(blueprint)
+	if !ok {
+		return fmt.Errorf("blueprint declares no repository to sync from")
+	}
+	cleaned, err := repositoryTreePath(treePath)
+	if err != nil {
+		return err
+	}
+
+	if err := k.CreateNamespace(namespace); err != nil {
+		return fmt.Errorf("failed to create namespace: %w", err)
+	}
+	if err := k.applyBlueprintSource(source, namespace, true); err != nil {
+		return fmt.Errorf("failed to apply blueprint repository: %w", err)
+	}
+
+	labels, annotations := k.provenance(source.Name, sourceVersion(blueprint, source.Name))
+	sync := kustomizev1.Kustomization{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Kustomization",
+			APIVersion: "kustomize.toolkit.fluxcd.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GitopsSyncKustomizationName,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{
+				Kind: "GitRepository",
+				Name: source.Name,
+			},
+			Path:          "./" + cleaned,
+			Interval:      metav1.Duration{Duration: constants.FluxKustomizationInterval(true)},
+			RetryInterval: &metav1.Duration{Duration: constants.DefaultFluxKustomizationRetryInterval},
+			Timeout:       &metav1.Duration{Duration: constants.DefaultFluxKustomizationTimeout},
+			Prune:         true,
+		},
+	}
+	if err := k.ApplyKustomization(sync); err != nil {
+		return fmt.Errorf("failed to apply gitops sync kustomization: %w", err)
+	}
+	return nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// repositoryTreePath returns treePath cleaned to a slash-separated path, or an error when it is
+// absolute or leaves the repository root.
+func repositoryTreePath(treePath string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(treePath, "\\", "/"))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("gitops path %q must be relative to the repository root", treePath)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,185 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestBaseKubernetesManager_ApplyGitopsSync(t *testing.T) {
+	syncBlueprint := func() *blueprintv1alpha1.Blueprint {
+		return &blueprintv1alpha1.Blueprint{
+			Metadata:   blueprintv1alpha1.Metadata{Name: "local"},
+			Repository: blueprintv1alpha1.Repository{Url: "https://github.com/example/blueprint.git", Ref: blueprintv1alpha1.Reference{Branch: "main"}},
+			Sources:    []blueprintv1alpha1.Source{{Name: "core", Url: "oci://ghcr.io/windsorcli/core:v0.6.0"}},
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "dns", Source: "core", Path: "dns"},
+			},
+		}
+	}
+	setup := func(t *testing.T) (*BaseKubernetesManager, map[string]*unstructured.Unstructured) {
+		t.Helper()
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		applied := make(map[string]*unstructured.Unstructured)
+		manager.client.(*client.MockKubernetesClient).ApplyResourceFunc = func(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+			applied[gvr.Resource+"/"+obj.GetName()] = obj
+			return obj, nil
+		}
+		return manager, applied
+	}
+
+	t.Run("AppliesRepositoryAndRootKustomizationOnly", func(t *testing.T) {
+		// Given a blueprint with a repository, a vendor source and a kustomization
+		manager, applied := setup(t)
+
+		// When the gitops sync is applied for a rendered tree
+		if err := manager.ApplyGitopsSync(syncBlueprint(), "system-gitops", "clusters/local/kubernetes"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then only the namespace, the repository and the root kustomization are applied
+		for _, key := range []string{"namespaces/system-gitops", "gitrepositories/local", "kustomizations/" + GitopsSyncKustomizationName} {
+			if applied[key] == nil {
+				t.Errorf("Expected %s to be applied, got %v", key, applied)
+			}
+		}
+		if len(applied) != 3 {
+			t.Errorf("Expected 3 objects applied, got %d", len(applied))
+		}
+
+		// And the root kustomization reconciles the tree from the repository with pruning on
+		sync := applied["kustomizations/"+GitopsSyncKustomizationName]
+		if got, _, _ := unstructured.NestedString(sync.Object, "spec", "path"); got != "./clusters/local/kubernetes" {
+			t.Errorf("Expected path ./clusters/local/kubernetes, got %q", got)
+		}
+		if got, _, _ := unstructured.NestedString(sync.Object, "spec", "sourceRef", "name"); got != "local" {
+			t.Errorf("Expected sourceRef local, got %q", got)
+		}
+		if prune, _, _ := unstructured.NestedBool(sync.Object, "spec", "prune"); !prune {
+			t.Error("Expected the root kustomization to prune")
+		}
+		if sync.GetLabels()[ContextIDLabel] != "test-context-id" || sync.GetLabels()[OriginLabel] != "local" {
+			t.Errorf("Expected provenance labels, got %v", sync.GetLabels())
+		}
+	})
+
+	t.Run("ErrorWhenBlueprintHasNoRepository", func(t *testing.T) {
+		// Given a blueprint without a repository
+		manager, _ := setup(t)
+		blueprint := syncBlueprint()
+		blueprint.Repository = blueprintv1alpha1.Repository{}
+
+		// When the gitops sync is applied
+		err := manager.ApplyGitopsSync(blueprint, "system-gitops", "clusters/local/kubernetes")
+
+		// Then it fails
+		if err == nil || !strings.Contains(err.Error(), "no repository") {
+			t.Errorf("Expected missing repository error, got %v", err)
+		}
+	})
+
+	t.Run("ErrorWhenPathLeavesRepository", func(t *testing.T) {
+		// Given a tree path escaping the repository root
+		manager, applied := setup(t)
+
+		// When the gitops sync is applied
+		err := manager.ApplyGitopsSync(syncBlueprint(), "system-gitops", "../elsewhere")
+
+		// Then it fails before applying anything
+		if err == nil || !strings.Contains(err.Error(), "relative to the repository root") {
+			t.Errorf("Expected path error, got %v", err)
+		}
+		if len(applied) != 0 {
+			t.Errorf("Expected nothing applied, got %v", applied)
+		}
+	})
+}
+
+func TestBaseKubernetesManager_ApplyArgoCDSync(t *testing.T) {
+	syncBlueprint := func() *blueprintv1alpha1.Blueprint {
+		return &blueprintv1alpha1.Blueprint{
+			Metadata:   blueprintv1alpha1.Metadata{Name: "local"},
+			Repository: blueprintv1alpha1.Repository{Url: "https://github.com/example/blueprint.git", Ref: blueprintv1alpha1.Reference{Branch: "main"}},
+		}
+	}
+	setup := func(t *testing.T) (*BaseKubernetesManager, map[string]*unstructured.Unstructured, map[string]metav1.ApplyOptions) {
+		t.Helper()
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		applied := make(map[string]*unstructured.Unstructured)
+		options := make(map[string]metav1.ApplyOptions)
+		manager.client.(*client.MockKubernetesClient).ApplyResourceFunc = func(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+			key := gvr.Resource + "/" + obj.GetNamespace() + "/" + obj.GetName()
+			applied[key] = obj
+			options[key] = opts
+			return obj, nil
+		}
+		return manager, applied, options
+	}
+
+	t.Run("AppliesHealthCheckAndRootApplication", func(t *testing.T) {
+		// Given a blueprint with a repository
+		manager, applied, options := setup(t)
+
+		// When the Argo CD sync is applied for a rendered tree
+		if err := manager.ApplyArgoCDSync(syncBlueprint(), "clusters/local/kubernetes"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then only argocd-cm and the root Application are applied
+		if len(applied) != 2 {
+			t.Errorf("Expected 2 objects applied, got %v", applied)
+		}
+
+		// And argocd-cm carries only the Application health check, applied server-side
+		cm := applied["configmaps/argocd/argocd-cm"]
+		if cm == nil {
+			t.Fatalf("Expected argocd-cm applied, got %v", applied)
+		}
+		data, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+		if len(data) != 1 || !strings.Contains(data["resource.customizations.health.argoproj.io_Application"], "obj.status.health") {
+			t.Errorf("Expected only the application health check, got %v", data)
+		}
+		if opts := options["configmaps/argocd/argocd-cm"]; opts.FieldManager != "windsor-cli" {
+			t.Errorf("Expected the windsor-cli field manager, got %+v", opts)
+		}
+
+		// And the root Application syncs the tree from the repository
+		app := applied["applications/argocd/"+GitopsSyncApplicationName]
+		if app == nil {
+			t.Fatalf("Expected the root application applied, got %v", applied)
+		}
+		if got, _, _ := unstructured.NestedString(app.Object, "spec", "source", "path"); got != "clusters/local/kubernetes" {
+			t.Errorf("Expected path clusters/local/kubernetes, got %q", got)
+		}
+		if app.GetLabels()[ContextIDLabel] != "test-context-id" || app.GetLabels()[OriginLabel] != "local" {
+			t.Errorf("Expected provenance labels, got %v", app.GetLabels())
+		}
+	})
+
+	t.Run("ErrorWhenPathLeavesRepository", func(t *testing.T) {
+		// Given a tree path escaping the repository root
+		manager, applied, _ := setup(t)
+
+		// When the Argo CD sync is applied
+		err := manager.ApplyArgoCDSync(syncBlueprint(), "../elsewhere")
+
+		// Then it fails before applying anything
+		if err == nil || !strings.Contains(err.Error(), "relative to the repository root") {
+			t.Errorf("Expected path error, got %v", err)
+		}
+		if len(applied) != 0 {
+			t.Errorf("Expected nothing applied, got %v", applied)
+		}
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"maps"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -52,20 +53,33 @@ type KubernetesManager interface {
 	PruneSecrets(desired map[string]map[string]bool) error
 	RollWorkloadsForSecret(ctx context.Context, namespace, secretName, digest string) error
 	GetHelmReleasesForKustomization(name, namespace string) ([]helmv2.HelmRelease, error)
+	SuspendKustomization(name, namespace string, suspension Suspension) ([]SuspendedObject, error)
+	ResumeKustomization(name, namespace string) ([]SuspendedObject, error)
+	GetSuspendedObjects(names []string, namespaces map[string]string) ([]SuspendedObject, error)
 	ApplyGitRepository(repo *sourcev1.GitRepository) error
 	ApplyOCIRepository(repo *sourcev1.OCIRepository) error
+	ApplyHelmRepository(repo *sourcev1.HelmRepository) error
+	ApplyBucket(bucket *sourcev1.Bucket) error
 	CheckGitRepositoryStatus() error
 	GetKustomizationStatus(names []string) (map[string]bool, error)
-	GetKustomizationReadiness(names []string) (map[string]bool, error)
+	GetKustomizationReadiness(names []string, namespaces map[string]string) (map[string]bool, error)
+	GetKustomizationStatuses(names []string, namespaces map[string]string) ([]KustomizationStatus, error)
+	GetSourceStatus(kind, name, namespace string) (FluxStatus, error)
 	KustomizationExists(name, namespace string) (bool, error)
 	NamespaceExists(name string) (bool, error)
 	GetKustomizationInventory(name, namespace string) ([]InventoryEntry, error)
+	GetInventoryManualEdits(entries []InventoryEntry) (InventoryManualEdits, error)
 	WaitForKubernetesHealthy(ctx context.Context, endpoint string, outputFunc func(string), nodeNames ...string) error
 	GetNodeReadyStatus(ctx context.Context, nodeNames []string) (map[string]bool, error)
 	ApplyBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) error
 	DeleteBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) error
 	PruneBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) error
-	ListPrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]string, error)
+	ListPrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error)
+	AnalyzePrune(names []string, namespaces map[string]string) ([]PruneObject, error)
+	ListManagedObjects(origin string) ([]ManagedObject, error)
+	RenderBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]*unstructured.Unstructured, error)
+	ApplyGitopsSync(blueprint *blueprintv1alpha1.Blueprint, namespace, treePath string) error
+	ApplyArgoCDSync(blueprint *blueprintv1alpha1.Blueprint, treePath string) error
 	ApplyVersionMarker(namespace string, marker VersionMarker) error
 	GetVersionMarker(namespace string) (VersionMarker, bool, error)
 }
@@ -83,6 +97,16 @@ type InventoryEntry struct {
 	Name      string
 }
 
+// InventoryManualEdits lists the objects in a kustomization's inventory that were deleted or
+// edited by hand. Missing objects were deleted out of band. ManuallyEdited objects carry fields
+// owned by an interactive field manager (kubectl, k9s, or a browser-based console), which is how
+// a manual edit shows up in the object's managedFields. It is not a comparison with what flux
+// applied: edits made by scripts, operators or other API clients are not detected.
+type InventoryManualEdits struct {
+	Missing        []InventoryEntry
+	ManuallyEdited []InventoryEntry
+}
+
 // =============================================================================
 // Constructor
 // =============================================================================
@@ -100,6 +124,9 @@ type BaseKubernetesManager struct {
 
 	notReadyDescribeBudget time.Duration
 
+	kustomizationEventPollInterval time.Duration
+	waitDiagnosticsBudget          time.Duration
+
 	healthCheckPollInterval time.Duration
 	nodeReadyPollInterval   time.Duration
 }
@@ -123,6 +150,8 @@ func NewKubernetesManager(kubernetesClient client.KubernetesClient, configHandle
 		kustomizationReconcileTimeout:         5 * time.Minute,
 		kustomizationReconcileSleep:           2 * time.Second,
 		notReadyDescribeBudget:                10 * time.Second,
+		kustomizationEventPollInterval:        10 * time.Second,
+		waitDiagnosticsBudget:                 5 * time.Second,
 		healthCheckPollInterval:               10 * time.Second,
 		nodeReadyPollInterval:                 5 * time.Second,
 	}
@@ -346,7 +375,17 @@ func kustomizationReady(obj *unstructured.Unstructured) bool {
 // kustomizationWaitMaxConsecutiveErrors: a single transient blip (a brief apiserver restart, a
 // load-balancer failover) is tolerated and retried on the next tick, but a persistent failure
 // like broken cluster auth ends the wait immediately with the underlying error surfaced, rather
-// than silently retrying it for the full timeout budget.
+// than silently retrying it for the full timeout budget. Each kustomization is read from the
+// namespace its object lives in: its namespace field when set, otherwise the gitops namespace.
+//
+// Every kustomizationEventPollInterval the wait also scans Warning events on the kustomizations
+// not yet Ready, their HelmReleases and the objects in their inventory, printing each new one
+// (an image pull back-off, a failed scheduling, a webhook denial, a failed helm install) to
+// stderr as it appears. Each scan runs inside the wait loop, so its probing is capped at
+// waitDiagnosticsBudget to keep a slow API from delaying the readiness poll, the timeout and
+// cancellation. On timeout the error closes with a root-cause digest built from the
+// last scan, naming the deepest not-Ready kustomization in each stuck dependsOn chain with its
+// failing HelmReleases and recent warnings.
 func (k *BaseKubernetesManager) WaitForKustomizations(ctx context.Context, message string, blueprint *blueprintv1alpha1.Blueprint) error {
 	if blueprint == nil {
 		return fmt.Errorf("blueprint not provided")
@@ -354,11 +393,13 @@ func (k *BaseKubernetesManager) WaitForKustomizations(ctx context.Context, messa
 
 	timeout := k.calculateTotalWaitTime(blueprint)
 	kustomizationNames := make([]string, 0, len(blueprint.Kustomizations))
+	kustomizationNamespaces := make(map[string]string, len(blueprint.Kustomizations))
 	for _, kustomization := range blueprint.Kustomizations {
 		if kustomization.DestroyOnly != nil && *kustomization.DestroyOnly {
 			continue
 		}
 		kustomizationNames = append(kustomizationNames, kustomization.Name)
+		kustomizationNamespaces[kustomization.Name] = kustomization.ObjectNamespace(k.gitopsNamespace())
 	}
 
 	tui.Start(message)
@@ -366,6 +407,9 @@ func (k *BaseKubernetesManager) WaitForKustomizations(ctx context.Context, messa
 	timeoutChan := time.After(timeout)
 	ticker := time.NewTicker(k.kustomizationWaitPollInterval)
 	defer ticker.Stop()
+	eventTicker := time.NewTicker(k.kustomizationEventPollInterval)
+	defer eventTicker.Stop()
+	diagnostics := newWaitDiagnostics(time.Now())
 
 	consecutiveErrors := 0
 
@@ -376,7 +420,15 @@ func (k *BaseKubernetesManager) WaitForKustomizations(ctx context.Context, messa
 			return ctx.Err()
 		case <-timeoutChan:
 			tui.Fail()
-			return fmt.Errorf("timeout waiting for kustomizations%s", k.describeNotReadyKustomizations(kustomizationNames, k.gitopsNamespace()))
+			return fmt.Errorf("timeout waiting for kustomizations%s%s", k.describeNotReadyKustomizations(kustomizationNames, k.gitopsNamespace()), diagnostics.digest(blueprint.Kustomizations))
+		case <-eventTicker.C:
+			if fresh := k.scanWaitDiagnostics(kustomizationNames, kustomizationNamespaces, diagnostics); len(fresh) > 0 {
+				tui.Pause()
+				for _, ev := range fresh {
+					fmt.Fprintln(os.Stderr, tui.Warning(ev.kustomization+": "+formatWarningEvent(ev)))
+				}
+				tui.Resume()
+			}
 		case <-ticker.C:
 			allReady := true
 			var tickErr error
@@ -386,7 +438,7 @@ func (k *BaseKubernetesManager) WaitForKustomizations(ctx context.Context, messa
 					Version:  "v1",
 					Resource: "kustomizations",
 				}
-				obj, err := k.client.GetResource(gvr, k.gitopsNamespace(), name)
+				obj, err := k.client.GetResource(gvr, kustomizationNamespaces[name], name)
 				if err != nil && isNotFoundError(err) {
 					allReady = false
 					break
@@ -446,18 +498,7 @@ func (k *BaseKubernetesManager) WaitForKustomizations(ctx context.Context, messa
 
 // CreateNamespace creates a new namespace
 func (k *BaseKubernetesManager) CreateNamespace(name string) error {
-	obj := &unstructured.Unstructured{
-		Object: map[string]any{
-			"apiVersion": "v1",
-			"kind":       "Namespace",
-			"metadata": map[string]any{
-				"name": name,
-				"labels": map[string]any{
-					"app.kubernetes.io/managed-by": "windsor-cli",
-				},
-			},
-		},
-	}
+	obj := namespaceObject(name)
 
 	gvr := schema.GroupVersionResource{
 		Group:    "",
@@ -487,19 +528,17 @@ func (k *BaseKubernetesManager) DeleteNamespace(name string) error {
 	return k.client.DeleteResource(gvr, "", name, metav1.DeleteOptions{})
 }
 
-// ApplyConfigMap creates or updates a ConfigMap using SSA
+// ApplyConfigMap creates or updates a ConfigMap using SSA, stamped with the context labels and
+// the CLI version that applied it.
 func (k *BaseKubernetesManager) ApplyConfigMap(name, namespace string, data map[string]string) error {
-	obj := &unstructured.Unstructured{
-		Object: map[string]any{
-			"apiVersion": "v1",
-			"kind":       "ConfigMap",
-			"metadata": map[string]any{
-				"name":      name,
-				"namespace": namespace,
-			},
-			"data": data,
-		},
-	}
+	labels, annotations := k.provenance("", "")
+	return k.applyConfigMap(name, namespace, data, labels, annotations)
+}
+
+// applyConfigMap creates or updates a ConfigMap using SSA with the given labels and annotations.
+// An existing immutable ConfigMap is deleted first, since SSA cannot update it.
+func (k *BaseKubernetesManager) applyConfigMap(name, namespace string, data, labels, annotations map[string]string) error {
+	obj := configMapObject(name, namespace, data, labels, annotations)
 
 	if err := validateFields(obj); err != nil {
 		return fmt.Errorf("invalid configmap fields: %w", err)
@@ -532,33 +571,18 @@ func (k *BaseKubernetesManager) ApplyConfigMap(name, namespace string, data map[
 // ApplyConfigMap's server-side-apply handling, including its immutable-field guard: Kubernetes
 // rejects an update that changes Secret.type, so if an existing Secret's type differs from the
 // newly resolved one, ApplySecret deletes it first rather than SSA-merging a rejected change. It
-// stamps the context ownership labels plus a secret-owner label naming the kustomization the
+// stamps the context provenance plus a secret-owner label naming the kustomization the
 // secret belongs to; that label is set only by CLI placement (never by Flux), so PruneSecrets can
 // find and reclaim CLI-placed secrets without ever touching a Flux-managed one. The Secret's type
 // and stringData are resolved by secretTypeAndData: stringData already carrying
 // ".dockerconfigjson", or carrying docker-username/docker-password (docker-server optional),
 // produces a kubernetes.io/dockerconfigjson Secret for imagePullSecrets; anything else stays Opaque.
 func (k *BaseKubernetesManager) ApplySecret(name, namespace string, stringData map[string]string, owner string) error {
-	secretType, resolvedData, err := secretTypeAndData(stringData)
+	obj, err := k.secretObject(name, namespace, stringData, owner)
 	if err != nil {
-		return fmt.Errorf("failed to resolve secret type for %q: %w", name, err)
-	}
-
-	labels := k.ownershipLabels()
-	labels[secretOwnerLabel] = owner
-	obj := &unstructured.Unstructured{
-		Object: map[string]any{
-			"apiVersion": "v1",
-			"kind":       "Secret",
-			"type":       secretType,
-			"metadata": map[string]any{
-				"name":      name,
-				"namespace": namespace,
-			},
-			"stringData": resolvedData,
-		},
+		return err
 	}
-	obj.SetLabels(labels)
+	secretType, _, _ := unstructured.NestedString(obj.Object, "type")
 
 	if err := validateFields(obj); err != nil {
 		return fmt.Errorf("invalid secret fields: %w", err)
@@ -611,7 +635,7 @@ func (k *BaseKubernetesManager) PruneSecrets(desired map[string]map[string]bool)
 		Resource: "secrets",
 	}
 
-	selector := fmt.Sprintf("windsorcli.dev/context-id=%s,%s", contextID, secretOwnerLabel)
+	selector := fmt.Sprintf("%s=%s,%s", ContextIDLabel, contextID, SecretOwnerLabel)
 	list, err := k.client.ListResourcesByLabel(gvr, "", selector)
 	if err != nil {
 		return fmt.Errorf("failed to list CLI-placed secrets: %w", err)
@@ -885,52 +909,101 @@ func (k *BaseKubernetesManager) ApplyOCIRepository(repo *sourcev1.OCIRepository)
 	return k.applyWithRetry(gvr, obj, opts)
 }
 
-// CheckGitRepositoryStatus checks the status of all GitRepository and OCIRepository resources
-func (k *BaseKubernetesManager) CheckGitRepositoryStatus() error {
-	gitGvr := schema.GroupVersionResource{
+// ApplyHelmRepository creates or updates a HelmRepository resource using SSA
+func (k *BaseKubernetesManager) ApplyHelmRepository(repo *sourcev1.HelmRepository) error {
+	obj := &unstructured.Unstructured{}
+	unstructuredMap, err := k.shims.ToUnstructured(repo)
+	if err != nil {
+		return fmt.Errorf("failed to convert helmrepository to unstructured: %w", err)
+	}
+	obj.Object = unstructuredMap
+
+	if err := validateFields(obj); err != nil {
+		return fmt.Errorf("invalid helmrepository fields: %w", err)
+	}
+
+	gvr := schema.GroupVersionResource{
 		Group:    "source.toolkit.fluxcd.io",
 		Version:  "v1",
-		Resource: "gitrepositories",
+		Resource: "helmrepositories",
 	}
 
-	gitObjList, err := k.client.ListResources(gitGvr, k.gitopsNamespace())
-	if err != nil {
-		return fmt.Errorf("failed to list git repositories: %w", err)
+	opts := metav1.ApplyOptions{
+		FieldManager: "windsor-cli",
+		Force:        false,
 	}
 
-	for _, obj := range gitObjList.Items {
-		var gitRepo sourcev1.GitRepository
-		if err := k.shims.FromUnstructured(obj.UnstructuredContent(), &gitRepo); err != nil {
-			return fmt.Errorf("failed to convert git repository %s: %w", gitRepo.Name, err)
-		}
+	return k.applyWithRetry(gvr, obj, opts)
+}
 
-		for _, condition := range gitRepo.Status.Conditions {
-			if condition.Type == "Ready" && condition.Status == "False" {
-				return fmt.Errorf("%s: %s", gitRepo.Name, condition.Message)
-			}
-		}
+// ApplyBucket creates or updates a Bucket resource using SSA
+func (k *BaseKubernetesManager) ApplyBucket(bucket *sourcev1.Bucket) error {
+	obj := &unstructured.Unstructured{}
+	unstructuredMap, err := k.shims.ToUnstructured(bucket)
+	if err != nil {
+		return fmt.Errorf("failed to convert bucket to unstructured: %w", err)
 	}
+	obj.Object = unstructuredMap
 
-	ociGvr := schema.GroupVersionResource{
+	if err := validateFields(obj); err != nil {
+		return fmt.Errorf("invalid bucket fields: %w", err)
+	}
+
+	gvr := schema.GroupVersionResource{
 		Group:    "source.toolkit.fluxcd.io",
 		Version:  "v1",
-		Resource: "ocirepositories",
+		Resource: "buckets",
 	}
 
-	ociObjList, err := k.client.ListResources(ociGvr, k.gitopsNamespace())
-	if err != nil {
-		return fmt.Errorf("failed to list oci repositories: %w", err)
+	opts := metav1.ApplyOptions{
+		FieldManager: "windsor-cli",
+		Force:        false,
 	}
 
-	for _, obj := range ociObjList.Items {
-		var ociRepo sourcev1.OCIRepository
-		if err := k.shims.FromUnstructured(obj.UnstructuredContent(), &ociRepo); err != nil {
-			return fmt.Errorf("failed to convert oci repository %s: %w", ociRepo.Name, err)
+	return k.applyWithRetry(gvr, obj, opts)
+}
+
+// CheckGitRepositoryStatus checks the status of every Flux source in the gitops namespace —
+// GitRepository, OCIRepository, HelmRepository and Bucket resources — returning the message of the
+// first one whose Ready condition is False.
+func (k *BaseKubernetesManager) CheckGitRepositoryStatus() error {
+	sources := []struct {
+		resource string
+		singular string
+		plural   string
+	}{
+		{"gitrepositories", "git repository", "git repositories"},
+		{"ocirepositories", "oci repository", "oci repositories"},
+		{"helmrepositories", "helm repository", "helm repositories"},
+		{"buckets", "bucket", "buckets"},
+	}
+
+	for _, source := range sources {
+		gvr := schema.GroupVersionResource{
+			Group:    "source.toolkit.fluxcd.io",
+			Version:  "v1",
+			Resource: source.resource,
 		}
 
-		for _, condition := range ociRepo.Status.Conditions {
-			if condition.Type == "Ready" && condition.Status == "False" {
-				return fmt.Errorf("%s: %s", ociRepo.Name, condition.Message)
+		objList, err := k.client.ListResources(gvr, k.gitopsNamespace())
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", source.plural, err)
+		}
+
+		for _, obj := range objList.Items {
+			var status struct {
+				Status struct {
+					Conditions []metav1.Condition `json:"conditions,omitempty"`
+				} `json:"status"`
+			}
+			if err := k.shims.FromUnstructured(obj.UnstructuredContent(), &status); err != nil {
+				return fmt.Errorf("failed to convert %s %s: %w", source.singular, obj.GetName(), err)
+			}
+
+			for _, condition := range status.Status.Conditions {
+				if condition.Type == "Ready" && condition.Status == "False" {
+					return fmt.Errorf("%s: %s", obj.GetName(), condition.Message)
+				}
 			}
 		}
 	}
@@ -988,33 +1061,107 @@ func (k *BaseKubernetesManager) GetKustomizationStatus(names []string) (map[stri
 	return status, nil
 }
 
-// GetKustomizationReadiness returns whether each named Kustomization currently reports Ready=True, in the
-// gitops namespace. Unlike GetKustomizationStatus it never fails on a Kustomization in a failed state — a
-// failed one is simply reported not-ready — so a convergence driver can keep nudging it toward Ready rather
-// than aborting. Names absent from the cluster report false; only an API list error propagates.
-func (k *BaseKubernetesManager) GetKustomizationReadiness(names []string) (map[string]bool, error) {
-	gvr := schema.GroupVersionResource{
-		Group:    "kustomize.toolkit.fluxcd.io",
-		Version:  "v1",
-		Resource: "kustomizations",
+// GetKustomizationReadiness returns whether each named Kustomization currently reports Ready=True. Each
+// name is resolved in its namespace from namespaces, falling back to the gitops namespace. Unlike
+// GetKustomizationStatus it never fails on a Kustomization in a failed state — a failed one is simply
+// reported not-ready — so a convergence driver can keep nudging it toward Ready rather than aborting.
+// Names absent from the cluster report false; only an API list error propagates.
+func (k *BaseKubernetesManager) GetKustomizationReadiness(names []string, namespaces map[string]string) (map[string]bool, error) {
+	objs, err := k.listNamedKustomizations(names, namespaces)
+	if err != nil {
+		return nil, err
 	}
 
-	objList, err := k.client.ListResources(gvr, k.gitopsNamespace())
+	ready := make(map[string]bool, len(names))
+	for _, name := range names {
+		obj, ok := objs[name]
+		ready[name] = ok && kustomizationReady(obj)
+	}
+	return ready, nil
+}
+
+// GetKustomizationStatuses returns the reconciliation status of each named Kustomization, in the order
+// given, with one list call per namespace involved. Each name is resolved in its namespace from
+// namespaces, falling back to the gitops namespace. A name absent from the cluster reports
+// FluxStateNotFound; like GetKustomizationReadiness, a failed Kustomization is reported rather than
+// returned as an error, so only an API list or decode error propagates.
+func (k *BaseKubernetesManager) GetKustomizationStatuses(names []string, namespaces map[string]string) ([]KustomizationStatus, error) {
+	objs, err := k.listNamedKustomizations(names, namespaces)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list kustomizations: %w", err)
+		return nil, err
 	}
 
-	ready := make(map[string]bool, len(names))
+	statuses := make([]KustomizationStatus, 0, len(names))
 	for _, name := range names {
-		ready[name] = false
+		obj, ok := objs[name]
+		if !ok {
+			statuses = append(statuses, KustomizationStatus{FluxStatus: FluxStatus{
+				Kind:      kustomizev1.KustomizationKind,
+				Name:      name,
+				Namespace: k.kustomizationNamespace(name, namespaces),
+				State:     FluxStateNotFound,
+			}})
+			continue
+		}
+		var kustomizeObj kustomizev1.Kustomization
+		if err := k.shims.FromUnstructured(obj.UnstructuredContent(), &kustomizeObj); err != nil {
+			return nil, fmt.Errorf("failed to convert kustomization %s: %w", name, err)
+		}
+		statuses = append(statuses, kustomizationStatus(&kustomizeObj))
+	}
+	return statuses, nil
+}
+
+// GetSourceStatus returns the reconciliation status of the flux source of the given kind
+// (GitRepository, OCIRepository, HelmRepository, or Bucket), with Revision set to the revision
+// of its current artifact. A source absent from the cluster reports FluxStateNotFound; an
+// unsupported kind or a read failure returns an error.
+func (k *BaseKubernetesManager) GetSourceStatus(kind, name, namespace string) (FluxStatus, error) {
+	resources := map[string]string{
+		sourcev1.GitRepositoryKind:  "gitrepositories",
+		sourcev1.OCIRepositoryKind:  "ocirepositories",
+		sourcev1.HelmRepositoryKind: "helmrepositories",
+		sourcev1.BucketKind:         "buckets",
+	}
+	resource, ok := resources[kind]
+	if !ok {
+		return FluxStatus{}, fmt.Errorf("unsupported source kind %q", kind)
 	}
-	for i := range objList.Items {
-		obj := &objList.Items[i]
-		if _, wanted := ready[obj.GetName()]; wanted {
-			ready[obj.GetName()] = kustomizationReady(obj)
+	gvr := schema.GroupVersionResource{
+		Group:    "source.toolkit.fluxcd.io",
+		Version:  "v1",
+		Resource: resource,
+	}
+
+	status := FluxStatus{Kind: kind, Name: name, Namespace: namespace}
+	obj, err := k.client.GetResource(gvr, namespace, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			status.State = FluxStateNotFound
+			return status, nil
 		}
+		return FluxStatus{}, fmt.Errorf("failed to get %s %s: %w", kind, name, err)
 	}
-	return ready, nil
+
+	var source struct {
+		Spec struct {
+			Suspend bool `json:"suspend,omitempty"`
+		} `json:"spec"`
+		Status struct {
+			Conditions []metav1.Condition `json:"conditions,omitempty"`
+			Artifact   *struct {
+				Revision string `json:"revision"`
+			} `json:"artifact,omitempty"`
+		} `json:"status"`
+	}
+	if err := k.shims.FromUnstructured(obj.UnstructuredContent(), &source); err != nil {
+		return FluxStatus{}, fmt.Errorf("failed to convert %s %s: %w", kind, name, err)
+	}
+	status.State, status.Reason, status.Message = fluxState(source.Status.Conditions, source.Spec.Suspend)
+	if source.Status.Artifact != nil {
+		status.Revision = source.Status.Artifact.Revision
+	}
+	return status, nil
 }
 
 // KustomizationExists returns true if a Kustomization resource with the given name exists in the given namespace.
@@ -1119,6 +1266,63 @@ func decodeInventoryID(id string) (InventoryEntry, bool) {
 	}, true
 }
 
+// interactiveFieldManagers are the field-manager name prefixes GetInventoryManualEdits treats as
+// manual edits. kubectl records one manager per verb (kubectl-edit, kubectl-patch,
+// kubectl-client-side-apply, ...) and "kubectl" for server-side apply; browser-based consoles
+// record the user agent, which begins with "Mozilla". Controllers that legitimately write to
+// flux-managed objects (the deployment controller's revision annotation, for example) never
+// match, so they are not reported.
+var interactiveFieldManagers = []string{"kubectl", "k9s", "Mozilla"}
+
+// GetInventoryManualEdits reads the live object behind each inventory entry. An object that no
+// longer exists, or whose kind is no longer served, is reported as Missing. An object whose
+// managedFields include a main-resource entry from an interactive field manager is reported as
+// ManuallyEdited; status subresource writes are ignored. Field values are never compared, so only
+// manual edits are detected, not every divergence from what flux applied. Resolving a kind or
+// reading an object fails the whole call, since a partial report would read as "no edits" for
+// the rest.
+func (k *BaseKubernetesManager) GetInventoryManualEdits(entries []InventoryEntry) (InventoryManualEdits, error) {
+	var edits InventoryManualEdits
+	for _, e := range entries {
+		gvr, err := k.client.ResourceFor(schema.GroupVersionKind{Group: e.Group, Kind: e.Kind})
+		if err != nil {
+			if apimeta.IsNoMatchError(err) {
+				edits.Missing = append(edits.Missing, e)
+				continue
+			}
+			return InventoryManualEdits{}, fmt.Errorf("error resolving %s %q: %w", e.Kind, e.Name, err)
+		}
+		obj, err := k.client.GetResource(gvr, e.Namespace, e.Name)
+		if err != nil {
+			if isNotFoundError(err) {
+				edits.Missing = append(edits.Missing, e)
+				continue
+			}
+			return InventoryManualEdits{}, fmt.Errorf("error reading %s %q: %w", e.Kind, e.Name, err)
+		}
+		if hasInteractiveFieldManager(obj) {
+			edits.ManuallyEdited = append(edits.ManuallyEdited, e)
+		}
+	}
+	return edits, nil
+}
+
+// hasInteractiveFieldManager reports whether any main-resource managedFields entry on obj was
+// written by one of interactiveFieldManagers.
+func hasInteractiveFieldManager(obj *unstructured.Unstructured) bool {
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Subresource != "" {
+			continue
+		}
+		for _, prefix := range interactiveFieldManagers {
+			if strings.HasPrefix(mf.Manager, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // WaitForKubernetesHealthy waits for the Kubernetes API to become healthy within the context deadline.
 // If nodeNames are provided, verifies all specified nodes reach Ready state before returning.
 // Returns an error if the API is unreachable or any specified nodes are not Ready within the deadline.
@@ -1194,12 +1398,12 @@ func (k *BaseKubernetesManager) GetNodeReadyStatus(ctx context.Context, nodeName
 }
 
 // ApplyBlueprint applies the entire blueprint to the cluster in the proper sequence.
-// It creates the target namespace, applies all blueprint source repositories (Git and OCI),
+// It creates the target namespace, applies all blueprint source repositories (Git, OCI, Helm and Bucket),
 // applies all individual sources, applies any standalone ConfigMaps, and finally applies
 // all kustomizations and their associated ConfigMaps. This orchestrates a complete
-// blueprint installation following the intended order. Context ownership labels are stamped on
-// each Kustomization's ObjectMeta (so the objects are selectable by context) and propagated to
-// managed resources via CommonMetadata, using context info from the config handler.
+// blueprint installation following the intended order. Provenance — the context labels, the origin
+// source and its version, and the CLI version — is stamped on each source, ConfigMap and
+// Kustomization, and propagated from each Kustomization to its managed resources via CommonMetadata.
 // Returns an error if any step fails.
 func (k *BaseKubernetesManager) ApplyBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) error {
 	if err := k.CreateNamespace(namespace); err != nil {
@@ -1208,17 +1412,7 @@ func (k *BaseKubernetesManager) ApplyBlueprint(blueprint *blueprintv1alpha1.Blue
 
 	mode := k.gitopsMode()
 
-	if blueprint.Repository.Url != "" {
-		var secretName string
-		if blueprint.Repository.SecretName != nil {
-			secretName = *blueprint.Repository.SecretName
-		}
-		source := blueprintv1alpha1.Source{
-			Name:       blueprint.Metadata.Name,
-			Url:        blueprint.Repository.Url,
-			Ref:        blueprint.Repository.Ref,
-			SecretName: secretName,
-		}
+	if source, ok := repositorySource(blueprint); ok {
 		if err := k.applyBlueprintSource(source, namespace, true); err != nil {
 			return fmt.Errorf("failed to apply blueprint repository: %w", err)
 		}
@@ -1247,18 +1441,15 @@ func (k *BaseKubernetesManager) ApplyBlueprint(blueprint *blueprintv1alpha1.Blue
 		if kustomization.DestroyOnly != nil && *kustomization.DestroyOnly {
 			continue
 		}
+		fluxKustomization := kustomization.ToFluxKustomization(namespace, defaultSourceName, blueprint.Sources, mode, blueprint.ConfigMaps)
+		labels, annotations := k.stampProvenance(&fluxKustomization, blueprint)
+
 		if len(kustomization.Substitutions) > 0 {
 			configMapName := fmt.Sprintf("values-%s", kustomization.Name)
-			if err := k.ApplyConfigMap(configMapName, namespace, kustomization.Substitutions); err != nil {
+			if err := k.applyConfigMap(configMapName, namespace, kustomization.Substitutions, labels, annotations); err != nil {
 				return fmt.Errorf("failed to create ConfigMap for kustomization %s: %w", kustomization.Name, err)
 			}
 		}
-		fluxKustomization := kustomization.ToFluxKustomization(namespace, defaultSourceName, blueprint.Sources, mode, blueprint.ConfigMaps)
-
-		fluxKustomization.Labels = k.ownershipLabels()
-		fluxKustomization.Spec.CommonMetadata = &kustomizev1.CommonMetadata{
-			Labels: k.ownershipLabels(),
-		}
 
 		if err := k.ApplyKustomization(fluxKustomization); err != nil {
 			return fmt.Errorf("failed to apply kustomization %s: %w", kustomization.Name, err)
@@ -1276,7 +1467,9 @@ func (k *BaseKubernetesManager) ApplyBlueprint(blueprint *blueprintv1alpha1.Blue
 //     from this phase are joined and returned immediately — the destroy walk does
 //     not start until the destroy hooks succeed.
 //
-//  2. Regular kustomizations in reverse-topological order. Each Kustomization carries
+//  2. Regular kustomizations in reverse-topological order, following "namespace/name"
+//     dependsOn entries by name, each suspended and deleted in the namespace its object
+//     lives in. Each Kustomization carries
 //     spec.deletionPolicy=WaitForTermination (set at apply time by ToFluxKustomization),
 //     so DELETE blocks until every managed resource is fully gone from etcd. The chain
 //     for cloud resources is:
@@ -1337,7 +1530,7 @@ func (k *BaseKubernetesManager) DeleteBlueprint(blueprint *blueprintv1alpha1.Blu
 		eligible = append(eligible, kustomization)
 	}
 	for _, kustomization := range eligible {
-		if err := k.setKustomizationSuspend(kustomization.Name, namespace, true); err != nil {
+		if err := k.setKustomizationSuspend(kustomization.Name, kustomization.ObjectNamespace(namespace), true); err != nil {
 			return k.abortDestroy(eligible, namespace, fmt.Errorf("destroy aborted: failed to suspend kustomization %q: %w", kustomization.Name, err))
 		}
 	}
@@ -1348,11 +1541,11 @@ func (k *BaseKubernetesManager) DeleteBlueprint(blueprint *blueprintv1alpha1.Blu
 
 	for _, kustomization := range orderForDestroy(eligible, "destroy") {
 		tui.Start(fmt.Sprintf("Destroying kustomization %s", kustomization.Name))
-		if err := k.setKustomizationSuspend(kustomization.Name, namespace, false); err != nil {
+		if err := k.setKustomizationSuspend(kustomization.Name, kustomization.ObjectNamespace(namespace), false); err != nil {
 			tui.Fail()
 			return k.abortDestroy(eligible, namespace, fmt.Errorf("destroy aborted: failed to resume kustomization %q before delete: %w", kustomization.Name, err))
 		}
-		if err := k.DeleteKustomization(kustomization.Name, namespace); err != nil {
+		if err := k.DeleteKustomization(kustomization.Name, kustomization.ObjectNamespace(namespace)); err != nil {
 			tui.Fail()
 			return k.abortDestroy(eligible, namespace, fmt.Errorf("destroy aborted: failed to delete kustomization %q: %w (further deletions skipped to avoid cascading orphans)", kustomization.Name, err))
 		}
@@ -1372,7 +1565,7 @@ func (k *BaseKubernetesManager) DeleteBlueprint(blueprint *blueprintv1alpha1.Blu
 func (k *BaseKubernetesManager) abortDestroy(eligible []blueprintv1alpha1.Kustomization, namespace string, cause error) error {
 	errs := []error{cause}
 	for _, kustomization := range eligible {
-		if err := k.setKustomizationSuspend(kustomization.Name, namespace, false); err != nil {
+		if err := k.setKustomizationSuspend(kustomization.Name, kustomization.ObjectNamespace(namespace), false); err != nil {
 			errs = append(errs, fmt.Errorf("failed to un-suspend kustomization %q during abort cleanup: %w", kustomization.Name, err))
 		}
 	}
@@ -1383,9 +1576,9 @@ func (k *BaseKubernetesManager) abortDestroy(eligible []blueprintv1alpha1.Kustom
 // the blueprint. It scopes strictly to this context — only objects carrying the
 // windsorcli.dev/context-id label for this context are considered, so kustomizations owned by other
 // contexts (or by no Windsor context) are never touched. Every non-DestroyOnly kustomization in the
-// blueprint is treated as desired; the live remainder is deleted in reverse-dependency order (read
-// from each object's live spec.dependsOn) so dependents tear down before their dependencies, each
-// honoring its own deletionPolicy. The caller passes the same prepared blueprint Install applied
+// blueprint is treated as desired in its object namespace; the live remainder, in any namespace, is
+// deleted where it lives in reverse-dependency order (read from each object's live spec.dependsOn)
+// so dependents tear down before their dependencies, each honoring its own deletionPolicy. The caller passes the same prepared blueprint Install applied
 // (CRD layers included) so the synthesized crds/crds-<source> layers are recognized as desired and
 // not pruned. Deletion errors are collected and joined rather than aborting on the first.
 func (k *BaseKubernetesManager) PruneBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) error {
@@ -1404,7 +1597,7 @@ func (k *BaseKubernetesManager) PruneBlueprint(blueprint *blueprintv1alpha1.Blue
 
 	var errs []error
 	for _, orphan := range orderForDestroy(orphans, "prune") {
-		if err := k.DeleteKustomization(orphan.Name, namespace); err != nil {
+		if err := k.DeleteKustomization(orphan.Name, orphan.ObjectNamespace(namespace)); err != nil {
 			errs = append(errs, fmt.Errorf("failed to prune kustomization %q: %w", orphan.Name, err))
 		}
 	}
@@ -1415,7 +1608,10 @@ func (k *BaseKubernetesManager) PruneBlueprint(blueprint *blueprintv1alpha1.Blue
 // windsorcli.dev/context-id label) that the blueprint no longer declares — the set Prune deletes and
 // ListPrunableKustomizations reports. It scopes strictly to this context, so kustomizations owned by
 // other contexts (or by no Windsor context) are never returned. DestroyOnly entries are not desired.
-// Each orphan carries its live spec.dependsOn for reverse-dependency ordering.
+// Kustomizations are listed across all namespaces, since a blueprint kustomization may place its
+// object outside the gitops namespace; a declared kustomization is desired only in the namespace it
+// resolves to. Each orphan carries the namespace it lives in and its live spec.dependsOn for
+// reverse-dependency ordering.
 func (k *BaseKubernetesManager) contextOrphanKustomizations(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
 	contextID := k.configHandler.GetString("id")
 	if contextID == "" {
@@ -1427,7 +1623,10 @@ func (k *BaseKubernetesManager) contextOrphanKustomizations(blueprint *blueprint
 		if kustomization.DestroyOnly != nil && *kustomization.DestroyOnly {
 			continue
 		}
-		desired[kustomization.Name] = true
+		desired[kustomization.ObjectNamespace(namespace)+"/"+kustomization.Name] = true
+	}
+	if k.gitopsMode() == constants.GitopsModePush {
+		desired[namespace+"/"+GitopsSyncKustomizationName] = true
 	}
 
 	gvr := schema.GroupVersionResource{
@@ -1435,7 +1634,7 @@ func (k *BaseKubernetesManager) contextOrphanKustomizations(blueprint *blueprint
 		Version:  "v1",
 		Resource: "kustomizations",
 	}
-	list, err := k.client.ListResources(gvr, namespace)
+	list, err := k.client.ListResources(gvr, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list kustomizations: %w", err)
 	}
@@ -1443,25 +1642,27 @@ func (k *BaseKubernetesManager) contextOrphanKustomizations(blueprint *blueprint
 	orphans := make([]blueprintv1alpha1.Kustomization, 0)
 	for i := range list.Items {
 		item := list.Items[i]
-		if item.GetLabels()["windsorcli.dev/context-id"] != contextID {
+		if item.GetLabels()[ContextIDLabel] != contextID {
 			continue
 		}
 		name := item.GetName()
-		if desired[name] {
+		if desired[item.GetNamespace()+"/"+name] {
 			continue
 		}
 		orphans = append(orphans, blueprintv1alpha1.Kustomization{
 			Name:      name,
+			Namespace: item.GetNamespace(),
 			DependsOn: dependsOnFromObject(item),
 		})
 	}
 	return orphans, nil
 }
 
-// ListPrunableKustomizations returns the names of this context's Kustomizations that the blueprint no
-// longer declares — exactly what PruneBlueprint would delete, in reverse-dependency order. It is the
-// read-only input to plan's prune preview and upgrade's confirmation gate; it deletes nothing.
-func (k *BaseKubernetesManager) ListPrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
+// ListPrunableKustomizations returns this context's Kustomizations that the blueprint no longer
+// declares — exactly what PruneBlueprint would delete, in reverse-dependency order — each with its
+// Name and the Namespace its object lives in. It is the read-only input to plan's prune preview and
+// upgrade's confirmation gate; it deletes nothing.
+func (k *BaseKubernetesManager) ListPrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
 	if blueprint == nil {
 		return nil, fmt.Errorf("blueprint not provided")
 	}
@@ -1471,11 +1672,11 @@ func (k *BaseKubernetesManager) ListPrunableKustomizations(blueprint *blueprintv
 		return nil, err
 	}
 
-	names := make([]string, 0, len(orphans))
+	prunable := make([]blueprintv1alpha1.Kustomization, 0, len(orphans))
 	for _, orphan := range orderForDestroy(orphans, "prune") {
-		names = append(names, orphan.Name)
+		prunable = append(prunable, blueprintv1alpha1.Kustomization{Name: orphan.Name, Namespace: orphan.Namespace})
 	}
-	return names, nil
+	return prunable, nil
 }
 
 // processDestroyOnlyKustomizations applies all destroy-only kustomizations, waits for all to become ready, then deletes all.
@@ -1493,9 +1694,12 @@ func (k *BaseKubernetesManager) processDestroyOnlyKustomizations(kustomizations
 	appliedKustomizations := []blueprintv1alpha1.Kustomization{}
 
 	for _, kustomization := range kustomizations {
+		fluxKustomization := kustomization.ToFluxKustomization(namespace, defaultSourceName, blueprint.Sources, mode, blueprint.ConfigMaps)
+		labels, annotations := k.stampProvenance(&fluxKustomization, blueprint)
+
 		if len(kustomization.Substitutions) > 0 {
 			configMapName := fmt.Sprintf("values-%s", kustomization.Name)
-			if err := k.ApplyConfigMap(configMapName, namespace, kustomization.Substitutions); err != nil {
+			if err := k.applyConfigMap(configMapName, namespace, kustomization.Substitutions, labels, annotations); err != nil {
 				errors = append(errors, fmt.Errorf("failed to create ConfigMap for destroy-only kustomization %s: %w", kustomization.Name, err))
 				for i := len(appliedKustomizations) - 1; i >= 0; i-- {
 					appliedKust := appliedKustomizations[i]
@@ -1507,13 +1711,6 @@ func (k *BaseKubernetesManager) processDestroyOnlyKustomizations(kustomizations
 			}
 		}
 
-		fluxKustomization := kustomization.ToFluxKustomization(namespace, defaultSourceName, blueprint.Sources, mode, blueprint.ConfigMaps)
-
-		fluxKustomization.Labels = k.ownershipLabels()
-		fluxKustomization.Spec.CommonMetadata = &kustomizev1.CommonMetadata{
-			Labels: k.ownershipLabels(),
-		}
-
 		filteredDependsOn := make([]kustomizev1.DependencyReference, 0)
 		for _, dep := range fluxKustomization.Spec.DependsOn {
 			if destroyOnlyNames[dep.Name] {
@@ -1788,21 +1985,57 @@ func (k *BaseKubernetesManager) gitopsNamespace() string {
 	return k.configHandler.GetString("gitops.namespace", constants.DefaultGitopsNamespace)
 }
 
-// secretOwnerLabel names the kustomization a CLI-placed Secret belongs to. It is set only by
-// ApplySecret, never by Flux, so it is the reliable marker for finding secrets the CLI itself placed —
-// PruneSecrets selects on it (scoped to the context) to reclaim orphans without touching Flux-managed
-// secrets that happen to carry the context labels via CommonMetadata.
-const secretOwnerLabel = "windsorcli.dev/secret-owner" // #nosec G101 -- label key, not a credential
+// kustomizationNamespace returns the namespace the named Kustomization lives in: its entry in
+// namespaces, or the gitops namespace when it has none.
+func (k *BaseKubernetesManager) kustomizationNamespace(name string, namespaces map[string]string) string {
+	if ns := namespaces[name]; ns != "" {
+		return ns
+	}
+	return k.gitopsNamespace()
+}
 
-// ownershipLabels returns the Windsor context labels stamped on each Kustomization object (so the
-// objects are selectable by context) and propagated to its managed resources via CommonMetadata.
-func (k *BaseKubernetesManager) ownershipLabels() map[string]string {
-	return map[string]string{
-		"windsorcli.dev/context":    k.configHandler.GetContext(),
-		"windsorcli.dev/context-id": k.configHandler.GetString("id"),
+// listNamedKustomizations returns the named Kustomizations present on the cluster, keyed by name, each
+// looked up in its namespace from namespaces (falling back to the gitops namespace). Every namespace
+// involved is listed once; a same-named Kustomization in a different namespace is not matched.
+func (k *BaseKubernetesManager) listNamedKustomizations(names []string, namespaces map[string]string) (map[string]*unstructured.Unstructured, error) {
+	gvr := schema.GroupVersionResource{
+		Group:    "kustomize.toolkit.fluxcd.io",
+		Version:  "v1",
+		Resource: "kustomizations",
 	}
+
+	byNamespace := make(map[string][]string)
+	var order []string
+	for _, name := range names {
+		ns := k.kustomizationNamespace(name, namespaces)
+		if _, ok := byNamespace[ns]; !ok {
+			order = append(order, ns)
+		}
+		byNamespace[ns] = append(byNamespace[ns], name)
+	}
+
+	objs := make(map[string]*unstructured.Unstructured, len(names))
+	for _, ns := range order {
+		objList, err := k.client.ListResources(gvr, ns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list kustomizations: %w", err)
+		}
+		for i := range objList.Items {
+			obj := &objList.Items[i]
+			if slices.Contains(byNamespace[ns], obj.GetName()) {
+				objs[obj.GetName()] = obj
+			}
+		}
+	}
+	return objs, nil
 }
 
+// SecretOwnerLabel names the kustomization a CLI-placed Secret belongs to. It is set only by
+// ApplySecret, never by Flux, so it is the reliable marker for finding secrets the CLI itself placed —
+// PruneSecrets selects on it (scoped to the context) to reclaim orphans without touching Flux-managed
+// secrets that happen to carry the context labels via CommonMetadata.
+const SecretOwnerLabel = "windsorcli.dev/secret-owner" // #nosec G101 -- label key, not a credential
+
 // applyWithRetry applies a resource using SSA with minimal logic
 func (k *BaseKubernetesManager) applyWithRetry(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts metav1.ApplyOptions) error {
 	existing, err := k.client.GetResource(gvr, obj.GetNamespace(), obj.GetName())
@@ -1848,16 +2081,45 @@ func (k *BaseKubernetesManager) getHelmRelease(name, namespace string) (*helmv2.
 	return &helmRelease, nil
 }
 
-// applyBlueprintSource applies a blueprint Source as a GitRepository or OCIRepository resource.
-// It routes to the appropriate repository type based on the source URL and applies it to the
-// cluster. isPrimary is true for the blueprint's own repository (the top-level "repository:"
-// field) and selects the short, continuously-polled default interval rather than the long
-// pinned-vendor-source default; see constants.FluxSourceInterval.
+// applyBlueprintSource applies a blueprint Source as a GitRepository, OCIRepository,
+// HelmRepository or Bucket resource. It routes to the appropriate source type based on the
+// source's kind (see blueprintv1alpha1.SourceKind) and applies it to the cluster. isPrimary is true
+// for the blueprint's own repository (the top-level "repository:" field) and selects the short,
+// continuously-polled default interval rather than the long pinned-vendor-source default; see
+// constants.FluxSourceInterval. Each source is stamped with provenance naming itself as the origin.
 func (k *BaseKubernetesManager) applyBlueprintSource(source blueprintv1alpha1.Source, namespace string, isPrimary bool) error {
-	if strings.HasPrefix(source.Url, "oci://") {
-		return k.applyBlueprintOCIRepository(source, namespace, isPrimary)
+	obj, err := k.blueprintSourceObject(source, namespace, isPrimary)
+	if err != nil {
+		return err
+	}
+	switch o := obj.(type) {
+	case *sourcev1.GitRepository:
+		return k.ApplyGitRepository(o)
+	case *sourcev1.OCIRepository:
+		return k.ApplyOCIRepository(o)
+	case *sourcev1.HelmRepository:
+		return k.ApplyHelmRepository(o)
+	default:
+		return k.ApplyBucket(o.(*sourcev1.Bucket))
+	}
+}
+
+// blueprintSourceObject builds the flux source object for a blueprint Source: a GitRepository,
+// OCIRepository, HelmRepository or Bucket according to its kind (see blueprintv1alpha1.SourceKind).
+// It is what applyBlueprintSource applies and what RenderBlueprint writes out.
+func (k *BaseKubernetesManager) blueprintSourceObject(source blueprintv1alpha1.Source, namespace string, isPrimary bool) (runtime.Object, error) {
+	switch kind := blueprintv1alpha1.SourceKind(source); kind {
+	case blueprintv1alpha1.SourceKindGit:
+		return k.blueprintGitRepository(source, namespace, isPrimary), nil
+	case blueprintv1alpha1.SourceKindOCI:
+		return k.blueprintOCIRepository(source, namespace, isPrimary), nil
+	case blueprintv1alpha1.SourceKindHelm:
+		return k.blueprintHelmRepository(source, namespace, isPrimary), nil
+	case blueprintv1alpha1.SourceKindBucket:
+		return k.blueprintBucket(source, namespace, isPrimary)
+	default:
+		return nil, fmt.Errorf("unsupported source kind %q", kind)
 	}
-	return k.applyBlueprintGitRepository(source, namespace, isPrimary)
 }
 
 // setKustomizationSuspend patches spec.suspend on a Kustomization. DeleteBlueprint
@@ -1901,6 +2163,11 @@ func (k *BaseKubernetesManager) gitopsMode() constants.GitopsMode {
 	return constants.ParseGitopsMode(k.configHandler.GetString("gitops.mode", ""))
 }
 
+// gitopsEngine returns the configured gitops engine, defaulting to Flux.
+func (k *BaseKubernetesManager) gitopsEngine() constants.GitopsEngine {
+	return constants.ParseGitopsEngine(k.configHandler.GetString("gitops.engine", ""))
+}
+
 // waitForNodesReady blocks until all specified nodes exist and are in Ready state or the context deadline is reached.
 // It periodically queries node status, invokes outputFunc on status changes, and returns an error if any nodes are missing or not Ready within the deadline.
 // If the context is cancelled, returns an error immediately.
@@ -2000,10 +2267,11 @@ func (k *BaseKubernetesManager) waitForNodesReady(ctx context.Context, nodeNames
 	return fmt.Errorf("timeout waiting for nodes to be ready")
 }
 
-// applyBlueprintGitRepository converts and applies a blueprint Source as a GitRepository.
+// blueprintGitRepository converts a blueprint Source to a GitRepository.
 // isPrimary selects the short, continuously-polled interval default for the blueprint's own
 // repository rather than the long pinned-vendor-source default; see constants.FluxSourceInterval.
-func (k *BaseKubernetesManager) applyBlueprintGitRepository(source blueprintv1alpha1.Source, namespace string, isPrimary bool) error {
+func (k *BaseKubernetesManager) blueprintGitRepository(source blueprintv1alpha1.Source, namespace string, isPrimary bool) *sourcev1.GitRepository {
+	labels, annotations := k.provenance(source.Name, referenceVersion(source))
 	sourceUrl := runtimegit.NormalizeRemoteURL(source.Url)
 
 	gitRepo := &sourcev1.GitRepository{
@@ -2012,14 +2280,15 @@ func (k *BaseKubernetesManager) applyBlueprintGitRepository(source blueprintv1al
 			APIVersion: "source.toolkit.fluxcd.io/v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      source.Name,
-			Namespace: namespace,
+			Name:        source.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: sourcev1.GitRepositorySpec{
-			URL: sourceUrl,
-			Interval: metav1.Duration{
-				Duration: constants.FluxSourceInterval(isPrimary),
-			},
+			URL:      sourceUrl,
+			Interval: sourceInterval(source, isPrimary),
+			Provider: source.Provider,
 			Timeout: &metav1.Duration{
 				Duration: constants.DefaultFluxSourceTimeout,
 			},
@@ -2032,19 +2301,20 @@ func (k *BaseKubernetesManager) applyBlueprintGitRepository(source blueprintv1al
 		},
 	}
 
-	if source.SecretName != "" {
+	if secretName := sourceSecretName(source); secretName != "" {
 		gitRepo.Spec.SecretRef = &meta.LocalObjectReference{
-			Name: source.SecretName,
+			Name: secretName,
 		}
 	}
 
-	return k.ApplyGitRepository(gitRepo)
+	return gitRepo
 }
 
-// applyBlueprintOCIRepository converts and applies a blueprint Source as an OCIRepository.
+// blueprintOCIRepository converts a blueprint Source to an OCIRepository.
 // isPrimary selects the short, continuously-polled interval default for the blueprint's own
 // repository rather than the long pinned-vendor-source default; see constants.FluxSourceInterval.
-func (k *BaseKubernetesManager) applyBlueprintOCIRepository(source blueprintv1alpha1.Source, namespace string, isPrimary bool) error {
+func (k *BaseKubernetesManager) blueprintOCIRepository(source blueprintv1alpha1.Source, namespace string, isPrimary bool) *sourcev1.OCIRepository {
+	labels, annotations := k.provenance(source.Name, referenceVersion(source))
 	ociURL := source.Url
 	var ref *sourcev1.OCIRepositoryRef
 
@@ -2077,14 +2347,15 @@ func (k *BaseKubernetesManager) applyBlueprintOCIRepository(source blueprintv1al
 			APIVersion: "source.toolkit.fluxcd.io/v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      source.Name,
-			Namespace: namespace,
+			Name:        source.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: sourcev1.OCIRepositorySpec{
-			URL: ociURL,
-			Interval: metav1.Duration{
-				Duration: constants.FluxSourceInterval(isPrimary),
-			},
+			URL:      ociURL,
+			Interval: sourceInterval(source, isPrimary),
+			Provider: source.Provider,
 			Timeout: &metav1.Duration{
 				Duration: constants.DefaultFluxSourceTimeout,
 			},
@@ -2092,19 +2363,202 @@ func (k *BaseKubernetesManager) applyBlueprintOCIRepository(source blueprintv1al
 		},
 	}
 
-	if source.SecretName != "" {
+	if secretName := sourceSecretName(source); secretName != "" {
 		ociRepo.Spec.SecretRef = &meta.LocalObjectReference{
-			Name: source.SecretName,
+			Name: secretName,
+		}
+	}
+
+	return ociRepo
+}
+
+// blueprintHelmRepository converts a blueprint Source to a HelmRepository. An oci:// URL yields
+// an OCI-type HelmRepository; any other URL is a classic HTTP chart repository. isPrimary selects
+// the interval default as for the other source kinds.
+func (k *BaseKubernetesManager) blueprintHelmRepository(source blueprintv1alpha1.Source, namespace string, isPrimary bool) *sourcev1.HelmRepository {
+	labels, annotations := k.provenance(source.Name, referenceVersion(source))
+	helmRepo := &sourcev1.HelmRepository{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HelmRepository",
+			APIVersion: "source.toolkit.fluxcd.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: sourcev1.HelmRepositorySpec{
+			URL:      source.Url,
+			Interval: sourceInterval(source, isPrimary),
+			Timeout: &metav1.Duration{
+				Duration: constants.DefaultFluxSourceTimeout,
+			},
+			Provider: source.Provider,
+		},
+	}
+
+	if strings.HasPrefix(source.Url, "oci://") {
+		helmRepo.Spec.Type = sourcev1.HelmRepositoryTypeOCI
+	}
+
+	if secretName := sourceSecretName(source); secretName != "" {
+		helmRepo.Spec.SecretRef = &meta.LocalObjectReference{
+			Name: secretName,
 		}
 	}
 
-	return k.ApplyOCIRepository(ociRepo)
+	return helmRepo
+}
+
+// blueprintBucket converts a blueprint Source to a Bucket. The source URL is the bucket endpoint:
+// its scheme is stripped, and an http:// endpoint sets spec.insecure. isPrimary selects the
+// interval default as for the other source kinds. It fails when the source names no bucket.
+func (k *BaseKubernetesManager) blueprintBucket(source blueprintv1alpha1.Source, namespace string, isPrimary bool) (*sourcev1.Bucket, error) {
+	labels, annotations := k.provenance(source.Name, referenceVersion(source))
+	if source.BucketName == "" {
+		return nil, fmt.Errorf("bucket source %q has no bucketName", source.Name)
+	}
+
+	endpoint := strings.TrimPrefix(source.Url, "https://")
+	insecure := strings.HasPrefix(endpoint, "http://")
+	endpoint = strings.TrimSuffix(strings.TrimPrefix(endpoint, "http://"), "/")
+
+	bucket := &sourcev1.Bucket{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Bucket",
+			APIVersion: "source.toolkit.fluxcd.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: sourcev1.BucketSpec{
+			Provider:   source.Provider,
+			BucketName: source.BucketName,
+			Endpoint:   endpoint,
+			Insecure:   insecure,
+			Region:     source.Region,
+			Interval:   sourceInterval(source, isPrimary),
+			Timeout: &metav1.Duration{
+				Duration: constants.DefaultFluxSourceTimeout,
+			},
+		},
+	}
+
+	if secretName := sourceSecretName(source); secretName != "" {
+		bucket.Spec.SecretRef = &meta.LocalObjectReference{
+			Name: secretName,
+		}
+	}
+
+	return bucket, nil
+}
+
+// secretObject builds the Secret ApplySecret places: its type and stringData resolved by
+// secretTypeAndData, stamped with the context provenance and the secret-owner label naming the
+// kustomization it belongs to.
+func (k *BaseKubernetesManager) secretObject(name, namespace string, stringData map[string]string, owner string) (*unstructured.Unstructured, error) {
+	secretType, resolvedData, err := secretTypeAndData(stringData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve secret type for %q: %w", name, err)
+	}
+
+	labels, annotations := k.provenance("", "")
+	labels[SecretOwnerLabel] = owner
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"type":       secretType,
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"stringData": resolvedData,
+		},
+	}
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return obj, nil
 }
 
 // =============================================================================
 // Helpers
 // =============================================================================
 
+// repositorySource returns the blueprint's own repository as a Source named after the blueprint,
+// and false when the blueprint declares no repository.
+func repositorySource(blueprint *blueprintv1alpha1.Blueprint) (blueprintv1alpha1.Source, bool) {
+	if blueprint.Repository.Url == "" {
+		return blueprintv1alpha1.Source{}, false
+	}
+	var secretName string
+	if blueprint.Repository.SecretName != nil {
+		secretName = *blueprint.Repository.SecretName
+	}
+	return blueprintv1alpha1.Source{
+		Name:       blueprint.Metadata.Name,
+		Url:        blueprint.Repository.Url,
+		Ref:        blueprint.Repository.Ref,
+		SecretName: secretName,
+	}, true
+}
+
+// namespaceObject builds a Namespace labeled as managed by windsor.
+func namespaceObject(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]any{
+				"name": name,
+				"labels": map[string]any{
+					"app.kubernetes.io/managed-by": "windsor-cli",
+				},
+			},
+		},
+	}
+}
+
+// configMapObject builds a ConfigMap with the given data, labels and annotations.
+func configMapObject(name, namespace string, data, labels, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"data": data,
+		},
+	}
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+// sourceInterval returns the poll interval for a blueprint source: its own Interval when set,
+// otherwise the primary or vendor default from constants.FluxSourceInterval.
+func sourceInterval(source blueprintv1alpha1.Source, isPrimary bool) metav1.Duration {
+	if source.Interval != nil && source.Interval.Duration != 0 {
+		return metav1.Duration{Duration: source.Interval.Duration}
+	}
+	return metav1.Duration{Duration: constants.FluxSourceInterval(isPrimary)}
+}
+
+// sourceSecretName returns the name of the Secret holding a blueprint source's credentials:
+// SecretRef when set, otherwise SecretName.
+func sourceSecretName(source blueprintv1alpha1.Source) string {
+	if source.SecretRef != nil && source.SecretRef.Name != "" {
+		return source.SecretRef.Name
+	}
+	return source.SecretName
+}
+
 // inventoryKey builds the group/kind/namespace/name key used to match live objects against a
 // kustomization's Flux inventory. Group is empty for core API objects; namespace is empty for
 // cluster-scoped resources.
@@ -2294,8 +2748,8 @@ func (k *BaseKubernetesManager) calculateTotalWaitTime(blueprint *blueprintv1alp
 		}
 
 		maxDependencyTimeout := time.Duration(0)
-		for _, depName := range kustomization.DependsOn {
-			if depIndex, exists := nameToIndex[depName]; exists {
+		for _, dep := range kustomization.DependsOn {
+			if depIndex, exists := nameToIndex[blueprintv1alpha1.KustomizationDependencyName(dep)]; exists {
 				depTimeout := calculateChainTimeout(depIndex, visited)
 				if depTimeout > maxDependencyTimeout {
 					maxDependencyTimeout = depTimeout
@@ -2375,12 +2829,12 @@ func orderForDestroy(ks []blueprintv1alpha1.Kustomization, label string) []bluep
 
 // reverseTopologicalKustomizations returns ks in destroy order — each kustomization
 // before its DependsOn entries. Independent nodes tie-break by reverse input order,
-// so a topo-sorted input produces the same walk as a naive slice-reverse. Missing
-// dependencies (a DependsOn name not in ks) are treated as no-edge, matching the
-// apply-side walk. Returns an error on cycles across two or more nodes; a single-
-// node input short-circuits before cycle detection, so a self-loop on a lone
-// kustomization is not flagged — do not rely on this function to validate a
-// single-entry slice.
+// so a topo-sorted input produces the same walk as a naive slice-reverse. Qualified
+// "namespace/name" entries resolve by their name part. Missing dependencies (a
+// DependsOn name not in ks) are treated as no-edge, matching the apply-side walk.
+// Returns an error on cycles across two or more nodes; a single-node input
+// short-circuits before cycle detection, so a self-loop on a lone kustomization is
+// not flagged — do not rely on this function to validate a single-entry slice.
 func reverseTopologicalKustomizations(ks []blueprintv1alpha1.Kustomization) ([]blueprintv1alpha1.Kustomization, error) {
 	if len(ks) == 0 {
 		return []blueprintv1alpha1.Kustomization{}, nil
@@ -2410,7 +2864,7 @@ func reverseTopologicalKustomizations(ks []blueprintv1alpha1.Kustomization) ([]b
 		}
 		visiting[idx] = true
 		for _, dep := range ks[idx].DependsOn {
-			depIdx, ok := nameToIndex[dep]
+			depIdx, ok := nameToIndex[blueprintv1alpha1.KustomizationDependencyName(dep)]
 			if !ok {
 				continue
 			}
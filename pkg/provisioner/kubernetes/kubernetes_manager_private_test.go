@@ -1214,6 +1214,27 @@ func TestReverseTopologicalKustomizations(t *testing.T) {
 		}
 	})
 
+	t.Run("FollowsNamespaceQualifiedDependencies", func(t *testing.T) {
+		// Given a dependent a listed first that depends on b in another namespace
+		input := []blueprintv1alpha1.Kustomization{
+			{Name: "a", DependsOn: []string{"tenants/b"}},
+			{Name: "b", Namespace: "tenants"},
+		}
+
+		// When computing reverse-topological order
+		out, err := reverseTopologicalKustomizations(input)
+
+		// Then the qualified edge is honored and a destroys before b
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := kustomizationNames(out)
+		want := []string{"a", "b"}
+		if !equalStringSlice(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
 	t.Run("PutsDependentBeforeDependencyWhenInputOutOfOrder", func(t *testing.T) {
 		// Given input in non-topological order: dependent a first, dependency b second
 		input := []blueprintv1alpha1.Kustomization{
@@ -516,6 +516,43 @@ func TestBaseKubernetesManager_WaitForKustomizations(t *testing.T) {
 		}
 	})
 
+	t.Run("PollsKustomizationInItsOwnNamespace", func(t *testing.T) {
+		// Given a kustomization that overrides its namespace
+		manager := setup(t)
+		kubernetesClient := client.NewMockKubernetesClient()
+		var polled []string
+		kubernetesClient.GetResourceFunc = func(gvr schema.GroupVersionResource, ns, name string) (*unstructured.Unstructured, error) {
+			polled = append(polled, ns+"/"+name)
+			return &unstructured.Unstructured{
+				Object: map[string]any{
+					"status": map[string]any{
+						"conditions": []any{
+							map[string]any{"type": "Ready", "status": "True"},
+						},
+					},
+				},
+			}, nil
+		}
+		manager.client = kubernetesClient
+
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "platform", Namespace: "tenants"},
+			},
+		}
+
+		// When waiting for kustomizations
+		err := manager.WaitForKustomizations(context.Background(), "Waiting for kustomizations", blueprint)
+
+		// Then it is polled in its own namespace rather than the gitops namespace
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(polled) == 0 || polled[0] != "tenants/platform" {
+			t.Errorf("Expected tenants/platform polled, got %v", polled)
+		}
+	})
+
 	t.Run("NotFoundKeepsPollingUntilReady", func(t *testing.T) {
 		// Given a kustomization that isn't created yet, then becomes Ready
 		manager := setup(t)
@@ -2220,6 +2257,51 @@ func TestBaseKubernetesManager_CheckGitRepositoryStatus(t *testing.T) {
 		}
 	})
 
+	t.Run("BucketNotReady", func(t *testing.T) {
+		// Given every source kind listed, with only a Bucket not ready
+		manager := func(t *testing.T) *BaseKubernetesManager {
+			mocks := setupKubernetesMocks(t)
+			manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+			return manager
+		}(t)
+		kubernetesClient := client.NewMockKubernetesClient()
+		var listed []string
+		kubernetesClient.ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+			listed = append(listed, gvr.Resource)
+			if gvr.Resource != "buckets" {
+				return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{}}, nil
+			}
+			return &unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{
+					{
+						Object: map[string]any{
+							"apiVersion": "source.toolkit.fluxcd.io/v1",
+							"kind":       "Bucket",
+							"metadata":   map[string]any{"name": "manifests"},
+							"status": map[string]any{
+								"conditions": []any{
+									map[string]any{"type": "Ready", "status": "False", "message": "access denied"},
+								},
+							},
+						},
+					},
+				},
+			}, nil
+		}
+		manager.client = kubernetesClient
+
+		// When source status is checked
+		err := manager.CheckGitRepositoryStatus()
+
+		// Then every source kind is listed and the bucket's failure is reported
+		if err == nil || !strings.Contains(err.Error(), "manifests: access denied") {
+			t.Errorf("Expected bucket not-ready error, got %v", err)
+		}
+		if strings.Join(listed, ",") != "gitrepositories,ocirepositories,helmrepositories,buckets" {
+			t.Errorf("Expected every source kind listed, got %v", listed)
+		}
+	})
+
 	t.Run("OCIRepositoryListError", func(t *testing.T) {
 		manager := func(t *testing.T) *BaseKubernetesManager {
 			mocks := setupKubernetesMocks(t)
@@ -5073,6 +5155,138 @@ func TestBaseKubernetesManager_ApplyBlueprint(t *testing.T) {
 		}
 	})
 
+	t.Run("SuccessWithHelmAndBucketSources", func(t *testing.T) {
+		// Given a blueprint with a helm chart repository and an S3-compatible bucket a kustomization reads from
+		manager := setup(t)
+		applied := make(map[string]*unstructured.Unstructured)
+		kubernetesClient := client.NewMockKubernetesClient()
+		kubernetesClient.ApplyResourceFunc = func(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+			applied[obj.GetKind()+"/"+obj.GetName()] = obj
+			return obj, nil
+		}
+		kubernetesClient.GetResourceFunc = func(gvr schema.GroupVersionResource, ns, name string) (*unstructured.Unstructured, error) {
+			return nil, fmt.Errorf("not found")
+		}
+		manager.client = kubernetesClient
+
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Metadata: blueprintv1alpha1.Metadata{Name: "test-blueprint"},
+			Sources: []blueprintv1alpha1.Source{
+				{
+					Name:      "bitnami",
+					Kind:      blueprintv1alpha1.SourceKindHelm,
+					Url:       "https://charts.bitnami.com/bitnami",
+					SecretRef: &blueprintv1alpha1.SourceSecretRef{Name: "bitnami-auth"},
+					Interval:  &blueprintv1alpha1.DurationString{Duration: 30 * time.Minute},
+				},
+				{
+					Name:       "manifests",
+					Kind:       blueprintv1alpha1.SourceKindBucket,
+					Url:        "http://minio.local:9000",
+					BucketName: "platform",
+					Provider:   "generic",
+					Region:     "us-east-1",
+					SecretName: "minio-auth",
+				},
+			},
+			Kustomizations: []blueprintv1alpha1.Kustomization{{Name: "apps", Source: "manifests"}},
+		}
+
+		// When the blueprint is applied
+		err := manager.ApplyBlueprint(blueprint, "test-namespace")
+
+		// Then the HelmRepository carries its URL, secret and interval override
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var helmRepo sourcev1.HelmRepository
+		if obj := applied["HelmRepository/bitnami"]; obj == nil {
+			t.Fatalf("Expected HelmRepository applied, got %v", applied)
+		} else if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &helmRepo); err != nil {
+			t.Fatalf("Failed to convert HelmRepository: %v", err)
+		}
+		if helmRepo.Spec.URL != "https://charts.bitnami.com/bitnami" || helmRepo.Spec.Type != "" || helmRepo.Spec.SecretRef == nil || helmRepo.Spec.SecretRef.Name != "bitnami-auth" || helmRepo.Spec.Interval.Duration != 30*time.Minute {
+			t.Errorf("Unexpected HelmRepository spec: %+v", helmRepo.Spec)
+		}
+
+		// And the Bucket splits the endpoint from its scheme and marks plain http insecure
+		var bucket sourcev1.Bucket
+		if obj := applied["Bucket/manifests"]; obj == nil {
+			t.Fatalf("Expected Bucket applied, got %v", applied)
+		} else if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &bucket); err != nil {
+			t.Fatalf("Failed to convert Bucket: %v", err)
+		}
+		if bucket.Spec.Endpoint != "minio.local:9000" || !bucket.Spec.Insecure || bucket.Spec.BucketName != "platform" || bucket.Spec.Region != "us-east-1" || bucket.Spec.Provider != "generic" || bucket.Spec.SecretRef == nil || bucket.Spec.SecretRef.Name != "minio-auth" {
+			t.Errorf("Unexpected Bucket spec: %+v", bucket.Spec)
+		}
+
+		// And the kustomization references the Bucket
+		var kustomization kustomizev1.Kustomization
+		if obj := applied["Kustomization/apps"]; obj == nil {
+			t.Fatalf("Expected Kustomization applied, got %v", applied)
+		} else if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &kustomization); err != nil {
+			t.Fatalf("Failed to convert Kustomization: %v", err)
+		}
+		if kustomization.Spec.SourceRef.Kind != "Bucket" || kustomization.Spec.SourceRef.Name != "manifests" {
+			t.Errorf("Expected sourceRef Bucket/manifests, got %+v", kustomization.Spec.SourceRef)
+		}
+	})
+
+	t.Run("HelmSourceWithOCIURLIsOCIType", func(t *testing.T) {
+		// Given a helm source served from an OCI registry
+		manager := setup(t)
+		var helmRepo sourcev1.HelmRepository
+		kubernetesClient := client.NewMockKubernetesClient()
+		kubernetesClient.ApplyResourceFunc = func(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+			if obj.GetKind() == "HelmRepository" {
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &helmRepo); err != nil {
+					t.Fatalf("Failed to convert HelmRepository: %v", err)
+				}
+			}
+			return obj, nil
+		}
+		kubernetesClient.GetResourceFunc = func(gvr schema.GroupVersionResource, ns, name string) (*unstructured.Unstructured, error) {
+			return nil, fmt.Errorf("not found")
+		}
+		manager.client = kubernetesClient
+
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Metadata: blueprintv1alpha1.Metadata{Name: "test-blueprint"},
+			Sources: []blueprintv1alpha1.Source{
+				{Name: "charts", Kind: blueprintv1alpha1.SourceKindHelm, Url: "oci://ghcr.io/example/charts", Provider: "aws"},
+			},
+		}
+
+		// When the blueprint is applied
+		if err := manager.ApplyBlueprint(blueprint, "test-namespace"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then a HelmRepository of type oci is applied rather than an OCIRepository
+		if helmRepo.Spec.Type != sourcev1.HelmRepositoryTypeOCI || helmRepo.Spec.Provider != "aws" {
+			t.Errorf("Expected OCI HelmRepository with aws provider, got %+v", helmRepo.Spec)
+		}
+	})
+
+	t.Run("ErrorWhenBucketSourceHasNoBucketName", func(t *testing.T) {
+		// Given a bucket source without a bucket name
+		manager := setup(t)
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Metadata: blueprintv1alpha1.Metadata{Name: "test-blueprint"},
+			Sources: []blueprintv1alpha1.Source{
+				{Name: "manifests", Kind: blueprintv1alpha1.SourceKindBucket, Url: "https://s3.amazonaws.com"},
+			},
+		}
+
+		// When the blueprint is applied
+		err := manager.ApplyBlueprint(blueprint, "test-namespace")
+
+		// Then the missing bucket name is reported
+		if err == nil || !strings.Contains(err.Error(), "has no bucketName") {
+			t.Errorf("Expected missing bucketName error, got %v", err)
+		}
+	})
+
 	t.Run("SuccessWithBlueprintConfigMaps", func(t *testing.T) {
 		manager := setup(t)
 		configMapApplied := false
@@ -5799,6 +6013,75 @@ func TestBaseKubernetesManager_GetKustomizationInventory(t *testing.T) {
 	})
 }
 
+func TestBaseKubernetesManager_GetInventoryManualEdits(t *testing.T) {
+	t.Run("ReportsMissingAndManuallyEditedObjects", func(t *testing.T) {
+		// Given an inventory of three objects: one deleted out of band, one edited with
+		// kubectl, and one touched only by flux and the deployment controller
+		m := setupKubernetesMocks(t)
+		mockClient := m.KubernetesClient.(*client.MockKubernetesClient)
+		mockClient.ResourceForFunc = func(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+			return schema.GroupVersionResource{Group: gvk.Group, Version: "v1", Resource: strings.ToLower(gvk.Kind) + "s"}, nil
+		}
+		mockClient.GetResourceFunc = func(gvr schema.GroupVersionResource, ns, name string) (*unstructured.Unstructured, error) {
+			obj := &unstructured.Unstructured{Object: map[string]any{}}
+			obj.SetName(name)
+			switch name {
+			case "deleted":
+				return nil, fmt.Errorf("%q not found", name)
+			case "edited":
+				obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+					{Manager: "kustomize-controller", Operation: metav1.ManagedFieldsOperationApply},
+					{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate},
+				})
+			default:
+				obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+					{Manager: "kustomize-controller", Operation: metav1.ManagedFieldsOperationApply},
+					{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate},
+					{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status"},
+				})
+			}
+			return obj, nil
+		}
+		manager := NewKubernetesManager(m.KubernetesClient, m.ConfigHandler)
+		entries := []InventoryEntry{
+			{Namespace: "app", Name: "deleted", Kind: "ConfigMap"},
+			{Namespace: "app", Name: "edited", Group: "apps", Kind: "Deployment"},
+			{Namespace: "app", Name: "clean", Group: "apps", Kind: "Deployment"},
+		}
+
+		// When GetInventoryManualEdits runs
+		edits, err := manager.GetInventoryManualEdits(entries)
+
+		// Then the deleted object is missing and only the kubectl-edited object is manually edited
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(edits.Missing) != 1 || edits.Missing[0].Name != "deleted" {
+			t.Errorf("expected only 'deleted' to be missing, got %#v", edits.Missing)
+		}
+		if len(edits.ManuallyEdited) != 1 || edits.ManuallyEdited[0].Name != "edited" {
+			t.Errorf("expected only 'edited' to be manually edited, got %#v", edits.ManuallyEdited)
+		}
+	})
+
+	t.Run("ReturnsErrorWhenObjectCannotBeRead", func(t *testing.T) {
+		// Given an API server that fails to return an inventory object
+		m := setupKubernetesMocks(t)
+		m.KubernetesClient.(*client.MockKubernetesClient).GetResourceFunc = func(gvr schema.GroupVersionResource, ns, name string) (*unstructured.Unstructured, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+		manager := NewKubernetesManager(m.KubernetesClient, m.ConfigHandler)
+
+		// When GetInventoryManualEdits runs
+		_, err := manager.GetInventoryManualEdits([]InventoryEntry{{Namespace: "app", Name: "web", Kind: "Service"}})
+
+		// Then the error is returned rather than reported as no drift
+		if err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Errorf("expected read error, got %v", err)
+		}
+	})
+}
+
 func TestBaseKubernetesManager_ApplySecret(t *testing.T) {
 	setup := func(t *testing.T) *BaseKubernetesManager {
 		t.Helper()
@@ -5852,7 +6135,7 @@ func TestBaseKubernetesManager_ApplySecret(t *testing.T) {
 		}
 
 		// And it carries the secret-owner marker so PruneSecrets can reclaim it later
-		if applied.GetLabels()[secretOwnerLabel] != "dns-install" {
+		if applied.GetLabels()[SecretOwnerLabel] != "dns-install" {
 			t.Errorf("Expected secret-owner label dns-install, got %v", applied.GetLabels())
 		}
 	})
@@ -6213,7 +6496,7 @@ func TestBaseKubernetesManager_PruneSecrets(t *testing.T) {
 				"namespace": namespace,
 				"labels": map[string]any{
 					"windsorcli.dev/context-id": "test-context-id",
-					secretOwnerLabel:            "pki-install",
+					SecretOwnerLabel:            "pki-install",
 				},
 			},
 		}}
@@ -6255,7 +6538,7 @@ func TestBaseKubernetesManager_PruneSecrets(t *testing.T) {
 			t.Errorf("Expected only system-dns/hetzner-dns deleted, got %v", *deleted)
 		}
 		// And the list is scoped to this context and the CLI secret-owner marker
-		if !strings.Contains(*selector, "windsorcli.dev/context-id=test-context-id") || !strings.Contains(*selector, secretOwnerLabel) {
+		if !strings.Contains(*selector, "windsorcli.dev/context-id=test-context-id") || !strings.Contains(*selector, SecretOwnerLabel) {
 			t.Errorf("Expected context+owner-scoped selector, got %q", *selector)
 		}
 	})
@@ -204,18 +204,22 @@ func TestBaseKubernetesManager_ListPrunableKustomizations(t *testing.T) {
 		return manager
 	}
 
-	ctxItem := func(name string) unstructured.Unstructured {
+	ctxItemIn := func(name, namespace string) unstructured.Unstructured {
 		return unstructured.Unstructured{Object: map[string]any{
 			"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
 			"kind":       "Kustomization",
 			"metadata": map[string]any{
 				"name":      name,
-				"namespace": "system-gitops",
+				"namespace": namespace,
 				"labels":    map[string]any{"windsorcli.dev/context-id": "test-context-id"},
 			},
 		}}
 	}
 
+	ctxItem := func(name string) unstructured.Unstructured {
+		return ctxItemIn(name, "system-gitops")
+	}
+
 	wire := func(manager *BaseKubernetesManager, items ...unstructured.Unstructured) {
 		c := client.NewMockKubernetesClient()
 		c.ListResourcesFunc = func(gvr schema.GroupVersionResource, ns string) (*unstructured.UnstructuredList, error) {
@@ -237,11 +241,35 @@ func TestBaseKubernetesManager_ListPrunableKustomizations(t *testing.T) {
 		}
 
 		// Then only the orphan is reported
-		if len(got) != 1 || got[0] != "old-thing" {
+		if len(got) != 1 || got[0].Name != "old-thing" || got[0].Namespace != "system-gitops" {
 			t.Errorf("Expected [old-thing], got %v", got)
 		}
 	})
 
+	t.Run("MatchesKustomizationsInTheirOwnNamespaces", func(t *testing.T) {
+		// Given app declared in team-a, live there and in the gitops namespace, plus an orphan in team-b
+		manager := setup(t)
+		wire(manager, ctxItemIn("app", "team-a"), ctxItem("app"), ctxItemIn("old-thing", "team-b"))
+		blueprint := &blueprintv1alpha1.Blueprint{Kustomizations: []blueprintv1alpha1.Kustomization{{Name: "app", Namespace: "team-a"}}}
+
+		// When listing prunable kustomizations
+		got, err := manager.ListPrunableKustomizations(blueprint, "system-gitops")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the declared app is kept and the others are reported in their own namespaces
+		want := map[string]string{"system-gitops": "app", "team-b": "old-thing"}
+		if len(got) != len(want) {
+			t.Fatalf("Expected %d prunable kustomizations, got %v", len(want), got)
+		}
+		for _, k := range got {
+			if want[k.Namespace] != k.Name {
+				t.Errorf("Unexpected prunable kustomization %s/%s", k.Namespace, k.Name)
+			}
+		}
+	})
+
 	t.Run("EmptyWhenNothingOrphaned", func(t *testing.T) {
 		manager := setup(t)
 		wire(manager, ctxItem("app"))
@@ -256,6 +284,33 @@ func TestBaseKubernetesManager_ListPrunableKustomizations(t *testing.T) {
 		}
 	})
 
+	t.Run("KeepsGitopsSyncInPushMode", func(t *testing.T) {
+		// Given push mode and the root sync kustomization owned by this context
+		manager := setup(t)
+		manager.configHandler.(*config.MockConfigHandler).GetStringFunc = func(key string, defaultValue ...string) string {
+			switch key {
+			case "id":
+				return "test-context-id"
+			case "gitops.mode":
+				return "push"
+			}
+			return ""
+		}
+		wire(manager, ctxItem("app"), ctxItem(GitopsSyncKustomizationName))
+		blueprint := &blueprintv1alpha1.Blueprint{Kustomizations: []blueprintv1alpha1.Kustomization{{Name: "app"}}}
+
+		// When listing prunable kustomizations
+		got, err := manager.ListPrunableKustomizations(blueprint, "system-gitops")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the sync kustomization is not an orphan
+		if len(got) != 0 {
+			t.Errorf("Expected no prunable kustomizations, got %v", got)
+		}
+	})
+
 	t.Run("NilBlueprintReturnsError", func(t *testing.T) {
 		manager := setup(t)
 		wire(manager)
@@ -11,6 +11,7 @@ import (
 	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // =============================================================================
@@ -23,7 +24,9 @@ type MockKubernetesManager struct {
 	DeleteKustomizationFunc             func(name, namespace string) error
 	WaitForKustomizationsFunc           func(ctx context.Context, message string, blueprint *blueprintv1alpha1.Blueprint) error
 	GetKustomizationStatusFunc          func(names []string) (map[string]bool, error)
-	GetKustomizationReadinessFunc       func(names []string) (map[string]bool, error)
+	GetKustomizationReadinessFunc       func(names []string, namespaces map[string]string) (map[string]bool, error)
+	GetKustomizationStatusesFunc        func(names []string, namespaces map[string]string) ([]KustomizationStatus, error)
+	GetSourceStatusFunc                 func(kind, name, namespace string) (FluxStatus, error)
 	CreateNamespaceFunc                 func(name string) error
 	DeleteNamespaceFunc                 func(name string) error
 	ApplyConfigMapFunc                  func(name, namespace string, data map[string]string) error
@@ -33,18 +36,29 @@ type MockKubernetesManager struct {
 	ApplyVersionMarkerFunc              func(namespace string, marker VersionMarker) error
 	GetVersionMarkerFunc                func(namespace string) (VersionMarker, bool, error)
 	GetHelmReleasesForKustomizationFunc func(name, namespace string) ([]helmv2.HelmRelease, error)
+	SuspendKustomizationFunc            func(name, namespace string, suspension Suspension) ([]SuspendedObject, error)
+	ResumeKustomizationFunc             func(name, namespace string) ([]SuspendedObject, error)
+	GetSuspendedObjectsFunc             func(names []string, namespaces map[string]string) ([]SuspendedObject, error)
 	ApplyGitRepositoryFunc              func(repo *sourcev1.GitRepository) error
 	ApplyOCIRepositoryFunc              func(repo *sourcev1.OCIRepository) error
+	ApplyHelmRepositoryFunc             func(repo *sourcev1.HelmRepository) error
+	ApplyBucketFunc                     func(bucket *sourcev1.Bucket) error
 	CheckGitRepositoryStatusFunc        func() error
 	KustomizationExistsFunc             func(name, namespace string) (bool, error)
 	NamespaceExistsFunc                 func(name string) (bool, error)
 	GetKustomizationInventoryFunc       func(name, namespace string) ([]InventoryEntry, error)
+	GetInventoryManualEditsFunc         func(entries []InventoryEntry) (InventoryManualEdits, error)
 	WaitForKubernetesHealthyFunc        func(ctx context.Context, endpoint string, outputFunc func(string), nodeNames ...string) error
 	GetNodeReadyStatusFunc              func(ctx context.Context, nodeNames []string) (map[string]bool, error)
 	ApplyBlueprintFunc                  func(blueprint *blueprintv1alpha1.Blueprint, namespace string) error
 	DeleteBlueprintFunc                 func(blueprint *blueprintv1alpha1.Blueprint, namespace string) error
 	PruneBlueprintFunc                  func(blueprint *blueprintv1alpha1.Blueprint, namespace string) error
-	ListPrunableKustomizationsFunc      func(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]string, error)
+	ListPrunableKustomizationsFunc      func(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error)
+	AnalyzePruneFunc                    func(names []string, namespaces map[string]string) ([]PruneObject, error)
+	ListManagedObjectsFunc              func(origin string) ([]ManagedObject, error)
+	RenderBlueprintFunc                 func(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]*unstructured.Unstructured, error)
+	ApplyGitopsSyncFunc                 func(blueprint *blueprintv1alpha1.Blueprint, namespace, treePath string) error
+	ApplyArgoCDSyncFunc                 func(blueprint *blueprintv1alpha1.Blueprint, treePath string) error
 }
 
 // =============================================================================
@@ -95,9 +109,9 @@ func (m *MockKubernetesManager) GetKustomizationStatus(names []string) (map[stri
 // GetKustomizationReadiness implements KubernetesManager interface. The default reports every requested
 // kustomization Ready so a convergence pass returns immediately in tests that do not exercise it; tests
 // that drive convergence set GetKustomizationReadinessFunc.
-func (m *MockKubernetesManager) GetKustomizationReadiness(names []string) (map[string]bool, error) {
+func (m *MockKubernetesManager) GetKustomizationReadiness(names []string, namespaces map[string]string) (map[string]bool, error) {
 	if m.GetKustomizationReadinessFunc != nil {
-		return m.GetKustomizationReadinessFunc(names)
+		return m.GetKustomizationReadinessFunc(names, namespaces)
 	}
 	ready := make(map[string]bool, len(names))
 	for _, n := range names {
@@ -106,6 +120,28 @@ func (m *MockKubernetesManager) GetKustomizationReadiness(names []string) (map[s
 	return ready, nil
 }
 
+// GetKustomizationStatuses implements KubernetesManager interface. Without a func set it
+// reports every named kustomization Ready.
+func (m *MockKubernetesManager) GetKustomizationStatuses(names []string, namespaces map[string]string) ([]KustomizationStatus, error) {
+	if m.GetKustomizationStatusesFunc != nil {
+		return m.GetKustomizationStatusesFunc(names, namespaces)
+	}
+	statuses := make([]KustomizationStatus, 0, len(names))
+	for _, n := range names {
+		statuses = append(statuses, KustomizationStatus{FluxStatus: FluxStatus{Kind: "Kustomization", Name: n, Namespace: namespaces[n], State: FluxStateReady}})
+	}
+	return statuses, nil
+}
+
+// GetSourceStatus implements KubernetesManager interface. Without a func set it reports the
+// source Ready.
+func (m *MockKubernetesManager) GetSourceStatus(kind, name, namespace string) (FluxStatus, error) {
+	if m.GetSourceStatusFunc != nil {
+		return m.GetSourceStatusFunc(kind, name, namespace)
+	}
+	return FluxStatus{Kind: kind, Name: name, Namespace: namespace, State: FluxStateReady}, nil
+}
+
 // CreateNamespace implements KubernetesManager interface
 func (m *MockKubernetesManager) CreateNamespace(name string) error {
 	if m.CreateNamespaceFunc != nil {
@@ -178,6 +214,30 @@ func (m *MockKubernetesManager) GetHelmReleasesForKustomization(name, namespace
 	return nil, nil
 }
 
+// SuspendKustomization implements KubernetesManager interface
+func (m *MockKubernetesManager) SuspendKustomization(name, namespace string, suspension Suspension) ([]SuspendedObject, error) {
+	if m.SuspendKustomizationFunc != nil {
+		return m.SuspendKustomizationFunc(name, namespace, suspension)
+	}
+	return nil, nil
+}
+
+// ResumeKustomization implements KubernetesManager interface
+func (m *MockKubernetesManager) ResumeKustomization(name, namespace string) ([]SuspendedObject, error) {
+	if m.ResumeKustomizationFunc != nil {
+		return m.ResumeKustomizationFunc(name, namespace)
+	}
+	return nil, nil
+}
+
+// GetSuspendedObjects implements KubernetesManager interface
+func (m *MockKubernetesManager) GetSuspendedObjects(names []string, namespaces map[string]string) ([]SuspendedObject, error) {
+	if m.GetSuspendedObjectsFunc != nil {
+		return m.GetSuspendedObjectsFunc(names, namespaces)
+	}
+	return nil, nil
+}
+
 // ApplyGitRepository implements KubernetesManager interface
 func (m *MockKubernetesManager) ApplyGitRepository(repo *sourcev1.GitRepository) error {
 	if m.ApplyGitRepositoryFunc != nil {
@@ -194,6 +254,22 @@ func (m *MockKubernetesManager) ApplyOCIRepository(repo *sourcev1.OCIRepository)
 	return nil
 }
 
+// ApplyHelmRepository implements KubernetesManager interface
+func (m *MockKubernetesManager) ApplyHelmRepository(repo *sourcev1.HelmRepository) error {
+	if m.ApplyHelmRepositoryFunc != nil {
+		return m.ApplyHelmRepositoryFunc(repo)
+	}
+	return nil
+}
+
+// ApplyBucket implements KubernetesManager interface
+func (m *MockKubernetesManager) ApplyBucket(bucket *sourcev1.Bucket) error {
+	if m.ApplyBucketFunc != nil {
+		return m.ApplyBucketFunc(bucket)
+	}
+	return nil
+}
+
 // WaitForKustomizationDeletionProcessed waits for the specified kustomization deletion to be processed.
 
 // CheckGitRepositoryStatus checks the status of all GitRepository resources
@@ -228,6 +304,14 @@ func (m *MockKubernetesManager) GetKustomizationInventory(name, namespace string
 	return nil, nil
 }
 
+// GetInventoryManualEdits implements KubernetesManager interface
+func (m *MockKubernetesManager) GetInventoryManualEdits(entries []InventoryEntry) (InventoryManualEdits, error) {
+	if m.GetInventoryManualEditsFunc != nil {
+		return m.GetInventoryManualEditsFunc(entries)
+	}
+	return InventoryManualEdits{}, nil
+}
+
 // WaitForKubernetesHealthy waits for the Kubernetes API endpoint to be healthy with polling and timeout
 func (m *MockKubernetesManager) WaitForKubernetesHealthy(ctx context.Context, endpoint string, outputFunc func(string), nodeNames ...string) error {
 	if m.WaitForKubernetesHealthyFunc != nil {
@@ -269,13 +353,53 @@ func (m *MockKubernetesManager) PruneBlueprint(blueprint *blueprintv1alpha1.Blue
 }
 
 // ListPrunableKustomizations implements KubernetesManager interface
-func (m *MockKubernetesManager) ListPrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
+func (m *MockKubernetesManager) ListPrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
 	if m.ListPrunableKustomizationsFunc != nil {
 		return m.ListPrunableKustomizationsFunc(blueprint, namespace)
 	}
 	return nil, nil
 }
 
+// AnalyzePrune implements KubernetesManager interface
+func (m *MockKubernetesManager) AnalyzePrune(names []string, namespaces map[string]string) ([]PruneObject, error) {
+	if m.AnalyzePruneFunc != nil {
+		return m.AnalyzePruneFunc(names, namespaces)
+	}
+	return nil, nil
+}
+
+// ListManagedObjects implements KubernetesManager interface
+func (m *MockKubernetesManager) ListManagedObjects(origin string) ([]ManagedObject, error) {
+	if m.ListManagedObjectsFunc != nil {
+		return m.ListManagedObjectsFunc(origin)
+	}
+	return nil, nil
+}
+
+// RenderBlueprint implements KubernetesManager interface
+func (m *MockKubernetesManager) RenderBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]*unstructured.Unstructured, error) {
+	if m.RenderBlueprintFunc != nil {
+		return m.RenderBlueprintFunc(blueprint, namespace)
+	}
+	return nil, nil
+}
+
+// ApplyGitopsSync implements KubernetesManager interface
+func (m *MockKubernetesManager) ApplyGitopsSync(blueprint *blueprintv1alpha1.Blueprint, namespace, treePath string) error {
+	if m.ApplyGitopsSyncFunc != nil {
+		return m.ApplyGitopsSyncFunc(blueprint, namespace, treePath)
+	}
+	return nil
+}
+
+// ApplyArgoCDSync implements KubernetesManager interface
+func (m *MockKubernetesManager) ApplyArgoCDSync(blueprint *blueprintv1alpha1.Blueprint, treePath string) error {
+	if m.ApplyArgoCDSyncFunc != nil {
+		return m.ApplyArgoCDSyncFunc(blueprint, treePath)
+	}
+	return nil
+}
+
 // =============================================================================
 // Interface Compliance
 // =============================================================================
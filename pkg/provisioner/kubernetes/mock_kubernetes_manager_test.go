@@ -309,6 +309,56 @@ func TestMockKubernetesManager_ApplyOCIRepository(t *testing.T) {
 	})
 }
 
+func TestMockKubernetesManager_ApplyHelmRepository(t *testing.T) {
+	setup := func(t *testing.T) *MockKubernetesManager {
+		t.Helper()
+		return NewMockKubernetesManager()
+	}
+	repo := &sourcev1.HelmRepository{}
+
+	t.Run("FuncSet", func(t *testing.T) {
+		manager := setup(t)
+		manager.ApplyHelmRepositoryFunc = func(r *sourcev1.HelmRepository) error { return fmt.Errorf("err") }
+		err := manager.ApplyHelmRepository(repo)
+		if err == nil || err.Error() != "err" {
+			t.Errorf("Expected error 'err', got %v", err)
+		}
+	})
+
+	t.Run("FuncNotSet", func(t *testing.T) {
+		manager := setup(t)
+		err := manager.ApplyHelmRepository(repo)
+		if err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+}
+
+func TestMockKubernetesManager_ApplyBucket(t *testing.T) {
+	setup := func(t *testing.T) *MockKubernetesManager {
+		t.Helper()
+		return NewMockKubernetesManager()
+	}
+	bucket := &sourcev1.Bucket{}
+
+	t.Run("FuncSet", func(t *testing.T) {
+		manager := setup(t)
+		manager.ApplyBucketFunc = func(b *sourcev1.Bucket) error { return fmt.Errorf("err") }
+		err := manager.ApplyBucket(bucket)
+		if err == nil || err.Error() != "err" {
+			t.Errorf("Expected error 'err', got %v", err)
+		}
+	})
+
+	t.Run("FuncNotSet", func(t *testing.T) {
+		manager := setup(t)
+		err := manager.ApplyBucket(bucket)
+		if err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+}
+
 func TestMockKubernetesManager_WaitForKubernetesHealthy(t *testing.T) {
 	setup := func(t *testing.T) *MockKubernetesManager {
 		t.Helper()
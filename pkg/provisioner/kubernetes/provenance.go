@@ -0,0 +1,202 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file defines the provenance windsor stamps on every cluster object it applies: labels
+// naming the context and the blueprint source the object came from, and annotations recording
+// that source's version and the CLI version that applied it. Flux Kustomizations carry the same
+// set in their commonMetadata so the workloads they reconcile inherit it, which lets an operator
+// trace any object back to where it came from and list everything a context manages by origin.
+
+package kubernetes
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+const (
+	// ContextLabel names the windsor context that applied an object.
+	ContextLabel = "windsorcli.dev/context"
+
+	// ContextIDLabel holds the id of the windsor context that applied an object. Pruning and
+	// managed-object listings select on it.
+	ContextIDLabel = "windsorcli.dev/context-id"
+
+	// OriginLabel names the blueprint source an object came from: the source a Kustomization
+	// reconciles from, or the source a flux source object was created for.
+	OriginLabel = "windsorcli.dev/origin"
+
+	// BlueprintVersionAnnotation records the reference (tag, semver, branch or commit) of the
+	// origin source when the object was applied.
+	BlueprintVersionAnnotation = "windsorcli.dev/blueprint-version"
+
+	// AppliedByAnnotation records the windsor CLI version that applied an object.
+	AppliedByAnnotation = "windsorcli.dev/applied-by"
+)
+
+// managedResources are the kinds ListManagedObjects searches: the objects windsor applies
+// directly, and the common kinds that inherit provenance through a Kustomization's commonMetadata.
+var managedResources = []struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}{
+	{"Kustomization", schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}},
+	{"GitRepository", schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"}},
+	{"OCIRepository", schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "ocirepositories"}},
+	{"HelmRepository", schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "helmrepositories"}},
+	{"Bucket", schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "buckets"}},
+	{"HelmRelease", schema.GroupVersionResource{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"}},
+	{"Namespace", schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}},
+	{"ConfigMap", schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}},
+	{"Secret", schema.GroupVersionResource{Version: "v1", Resource: "secrets"}},
+	{"Deployment", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+	{"StatefulSet", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}},
+	{"DaemonSet", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}},
+}
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// ManagedObject is a cluster object carrying this context's provenance. Origin, BlueprintVersion
+// and AppliedBy are empty when the object predates provenance stamping or, for a CLI-placed Secret,
+// was not applied from a single source.
+type ManagedObject struct {
+	Kind             string
+	Namespace        string
+	Name             string
+	Origin           string
+	BlueprintVersion string
+	AppliedBy        string
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// ListManagedObjects returns the objects of the managed kinds labeled with this context's id, limited
+// to those whose origin label matches origin when it is non-empty. Objects are returned grouped by kind
+// in managedResources order, as the API lists them within a kind. It fails when the context id is unset
+// or is not a valid label value, since the listing could not be scoped to this context.
+func (k *BaseKubernetesManager) ListManagedObjects(origin string) ([]ManagedObject, error) {
+	contextID := k.configHandler.GetString("id")
+	if contextID == "" {
+		return nil, fmt.Errorf("context id not set; cannot list objects managed by this context")
+	}
+	if errs := validation.IsValidLabelValue(contextID); len(errs) > 0 {
+		return nil, fmt.Errorf("context id %q is not a valid label value: %s", contextID, strings.Join(errs, "; "))
+	}
+	selector := ContextIDLabel + "=" + contextID
+	if origin != "" {
+		if errs := validation.IsValidLabelValue(origin); len(errs) > 0 {
+			return nil, fmt.Errorf("origin %q is not a valid label value: %s", origin, strings.Join(errs, "; "))
+		}
+		selector += "," + OriginLabel + "=" + origin
+	}
+
+	var objs []ManagedObject
+	for _, r := range managedResources {
+		list, err := k.client.ListResourcesByLabel(r.gvr, "", selector)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", r.gvr.Resource, err)
+		}
+		for _, item := range list.Items {
+			annotations := item.GetAnnotations()
+			objs = append(objs, ManagedObject{
+				Kind:             r.kind,
+				Namespace:        item.GetNamespace(),
+				Name:             item.GetName(),
+				Origin:           item.GetLabels()[OriginLabel],
+				BlueprintVersion: annotations[BlueprintVersionAnnotation],
+				AppliedBy:        annotations[AppliedByAnnotation],
+			})
+		}
+	}
+	return objs, nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// ownershipLabels returns the Windsor context labels stamped on each Kustomization object (so the
+// objects are selectable by context) and propagated to its managed resources via CommonMetadata.
+func (k *BaseKubernetesManager) ownershipLabels() map[string]string {
+	return map[string]string{
+		ContextLabel:   k.configHandler.GetContext(),
+		ContextIDLabel: k.configHandler.GetString("id"),
+	}
+}
+
+// provenance returns the labels and annotations stamped on an object applied from the named
+// blueprint source: the context labels plus the origin, and the CLI version plus the source's
+// version. An empty origin or version is left off, as for the blueprint-level ConfigMaps.
+func (k *BaseKubernetesManager) provenance(origin, version string) (map[string]string, map[string]string) {
+	labels := k.ownershipLabels()
+	if origin != "" {
+		labels[OriginLabel] = origin
+	}
+	annotations := map[string]string{AppliedByAnnotation: "windsor/" + constants.Version}
+	if version != "" {
+		annotations[BlueprintVersionAnnotation] = version
+	}
+	return labels, annotations
+}
+
+// stampProvenance sets the provenance of the Kustomization's origin — the source it reconciles
+// from — on its metadata and its commonMetadata, so the objects it applies inherit it. It returns
+// the labels and annotations for objects applied alongside it, such as its values ConfigMap.
+func (k *BaseKubernetesManager) stampProvenance(kustomization *kustomizev1.Kustomization, blueprint *blueprintv1alpha1.Blueprint) (map[string]string, map[string]string) {
+	origin := kustomization.Spec.SourceRef.Name
+	labels, annotations := k.provenance(origin, sourceVersion(blueprint, origin))
+	kustomization.Labels = labels
+	kustomization.Annotations = annotations
+	kustomization.Spec.CommonMetadata = &kustomizev1.CommonMetadata{
+		Labels:      maps.Clone(labels),
+		Annotations: maps.Clone(annotations),
+	}
+	return labels, annotations
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// sourceVersion returns the version of the named blueprint source: that of the blueprint's own
+// repository when name is the blueprint's name, or of the declared source by that name. It returns
+// an empty string for a source that is not declared, such as a local template source.
+func sourceVersion(blueprint *blueprintv1alpha1.Blueprint, name string) string {
+	if name == blueprint.Metadata.Name && blueprint.Repository.Url != "" {
+		return referenceVersion(blueprintv1alpha1.Source{Url: blueprint.Repository.Url, Ref: blueprint.Repository.Ref})
+	}
+	for _, source := range blueprint.Sources {
+		if source.Name == name {
+			return referenceVersion(source)
+		}
+	}
+	return ""
+}
+
+// referenceVersion returns the reference a source is pinned to: for an OCI source, the tag in its
+// URL, which takes precedence as it does in the applied OCIRepository; otherwise its commit, semver,
+// tag or branch. It returns an empty string when the source carries no reference.
+func referenceVersion(source blueprintv1alpha1.Source) string {
+	if url, ok := strings.CutPrefix(source.Url, "oci://"); ok {
+		if i := strings.LastIndex(url, ":"); i > 0 && url[i+1:] != "" && !strings.Contains(url[i+1:], "/") {
+			return url[i+1:]
+		}
+	}
+	return effectiveRef(source.Ref)
+}
@@ -0,0 +1,293 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	"github.com/windsorcli/cli/pkg/runtime/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestBaseKubernetesManager_ListManagedObjects(t *testing.T) {
+	t.Run("SelectsByContextAndOrigin", func(t *testing.T) {
+		// Given a cluster holding one stamped Kustomization and one stamped Deployment
+		mocks := setupKubernetesMocks(t)
+		var selectors []string
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		manager.client.(*client.MockKubernetesClient).ListResourcesByLabelFunc = func(gvr schema.GroupVersionResource, namespace, selector string) (*unstructured.UnstructuredList, error) {
+			selectors = append(selectors, selector)
+			list := &unstructured.UnstructuredList{}
+			switch gvr.Resource {
+			case "kustomizations":
+				list.Items = append(list.Items, managedItem("system-gitops", "dns", "core", "v0.5.0"))
+			case "deployments":
+				list.Items = append(list.Items, managedItem("dns", "coredns", "core", "v0.5.0"))
+			}
+			return list, nil
+		}
+
+		// When objects from the core origin are listed
+		objs, err := manager.ListManagedObjects("core")
+
+		// Then every managed kind is selected by context id and origin, and provenance is read back
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(selectors) != len(managedResources) {
+			t.Errorf("Expected %d kinds listed, got %d", len(managedResources), len(selectors))
+		}
+		want := "windsorcli.dev/context-id=test-context-id,windsorcli.dev/origin=core"
+		for _, selector := range selectors {
+			if selector != want {
+				t.Errorf("Expected selector %q, got %q", want, selector)
+			}
+		}
+		if len(objs) != 2 {
+			t.Fatalf("Expected 2 objects, got %+v", objs)
+		}
+		if got := objs[0]; got.Kind != "Kustomization" || got.Namespace != "system-gitops" || got.Name != "dns" ||
+			got.Origin != "core" || got.BlueprintVersion != "v0.5.0" || got.AppliedBy != "windsor/test" {
+			t.Errorf("Expected dns Kustomization with its provenance, got %+v", got)
+		}
+		if got := objs[1]; got.Kind != "Deployment" || got.Name != "coredns" {
+			t.Errorf("Expected coredns Deployment, got %+v", got)
+		}
+	})
+
+	t.Run("SelectsByContextOnlyWithoutOrigin", func(t *testing.T) {
+		// Given a cluster with no managed objects
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		var selector string
+		manager.client.(*client.MockKubernetesClient).ListResourcesByLabelFunc = func(gvr schema.GroupVersionResource, namespace, s string) (*unstructured.UnstructuredList, error) {
+			selector = s
+			return &unstructured.UnstructuredList{}, nil
+		}
+
+		// When objects are listed without an origin
+		if _, err := manager.ListManagedObjects(""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then only the context id is selected on
+		if selector != "windsorcli.dev/context-id=test-context-id" {
+			t.Errorf("Expected context-only selector, got %q", selector)
+		}
+	})
+
+	t.Run("SkipsKindsNotInstalled", func(t *testing.T) {
+		// Given a cluster without the helm-controller CRDs
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		manager.client.(*client.MockKubernetesClient).ListResourcesByLabelFunc = func(gvr schema.GroupVersionResource, namespace, selector string) (*unstructured.UnstructuredList, error) {
+			if gvr.Resource == "helmreleases" {
+				return nil, fmt.Errorf("the server could not find the requested resource")
+			}
+			return &unstructured.UnstructuredList{}, nil
+		}
+
+		// When objects are listed
+		_, err := manager.ListManagedObjects("")
+
+		// Then the missing kind is skipped
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("ReturnsErrorWhenListFails", func(t *testing.T) {
+		// Given a cluster that refuses to list secrets
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		manager.client.(*client.MockKubernetesClient).ListResourcesByLabelFunc = func(gvr schema.GroupVersionResource, namespace, selector string) (*unstructured.UnstructuredList, error) {
+			if gvr.Resource == "secrets" {
+				return nil, fmt.Errorf("forbidden")
+			}
+			return &unstructured.UnstructuredList{}, nil
+		}
+
+		// When objects are listed
+		_, err := manager.ListManagedObjects("")
+
+		// Then the error is returned
+		if err == nil || !strings.Contains(err.Error(), "forbidden") {
+			t.Errorf("Expected list error, got %v", err)
+		}
+	})
+
+	t.Run("RejectsInvalidOrigin", func(t *testing.T) {
+		// Given a manager
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When an origin that cannot be a label value is given
+		_, err := manager.ListManagedObjects("core,other")
+
+		// Then it is rejected rather than widening the selector
+		if err == nil || !strings.Contains(err.Error(), "not a valid label value") {
+			t.Errorf("Expected invalid origin error, got %v", err)
+		}
+	})
+
+	t.Run("ReturnsErrorWithoutContextID", func(t *testing.T) {
+		// Given a context with no id
+		mocks := setupKubernetesMocks(t)
+		mocks.ConfigHandler.(*config.MockConfigHandler).GetStringFunc = func(key string, defaultValue ...string) string { return "" }
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When objects are listed
+		_, err := manager.ListManagedObjects("")
+
+		// Then it fails rather than listing every context's objects
+		if err == nil || !strings.Contains(err.Error(), "context id not set") {
+			t.Errorf("Expected context id error, got %v", err)
+		}
+	})
+}
+
+func TestBaseKubernetesManager_ApplyConfigMapProvenance(t *testing.T) {
+	t.Run("StampsContextAndAppliedBy", func(t *testing.T) {
+		// Given a manager recording what it applies
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		var applied *unstructured.Unstructured
+		manager.client.(*client.MockKubernetesClient).ApplyResourceFunc = func(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+			applied = obj
+			return obj, nil
+		}
+
+		// When a blueprint-level ConfigMap is applied
+		if err := manager.ApplyConfigMap("values-common", "system-gitops", map[string]string{"a": "b"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then it carries the context labels and the CLI version but no origin
+		labels := applied.GetLabels()
+		if labels[ContextIDLabel] != "test-context-id" || labels[ContextLabel] != "test-context" {
+			t.Errorf("Expected context labels, got %v", labels)
+		}
+		if _, ok := labels[OriginLabel]; ok {
+			t.Errorf("Expected no origin label, got %v", labels)
+		}
+		if got := applied.GetAnnotations()[AppliedByAnnotation]; got != "windsor/"+constants.Version {
+			t.Errorf("Expected applied-by windsor/%s, got %q", constants.Version, got)
+		}
+	})
+}
+
+func TestBaseKubernetesManager_ApplyBlueprintProvenance(t *testing.T) {
+	t.Run("StampsKustomizationsSourcesAndValues", func(t *testing.T) {
+		// Given a blueprint whose kustomization reconciles from a pinned OCI source
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		manager.shims.ToUnstructured = runtime.DefaultUnstructuredConverter.ToUnstructured
+		applied := make(map[string]*unstructured.Unstructured)
+		mockClient := manager.client.(*client.MockKubernetesClient)
+		mockClient.ApplyResourceFunc = func(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+			applied[gvr.Resource+"/"+obj.GetName()] = obj
+			return obj, nil
+		}
+		mockClient.GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+			return nil, fmt.Errorf("%s %q not found", gvr.Resource, name)
+		}
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Metadata: blueprintv1alpha1.Metadata{Name: "local"},
+			Sources:  []blueprintv1alpha1.Source{{Name: "core", Url: "oci://ghcr.io/windsorcli/core:v0.5.0"}},
+			Kustomizations: []blueprintv1alpha1.Kustomization{{
+				Name: "dns", Path: "dns", Source: "core",
+				Substitutions: map[string]string{"domain": "test"},
+			}},
+		}
+
+		// When the blueprint is applied
+		if err := manager.ApplyBlueprint(blueprint, "system-gitops"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the source, the kustomization and its values carry the core origin and version
+		for _, key := range []string{"ocirepositories/core", "kustomizations/dns", "configmaps/values-dns"} {
+			obj, ok := applied[key]
+			if !ok {
+				t.Errorf("Expected %s applied, got %v", key, applied)
+				continue
+			}
+			if got := obj.GetLabels()[OriginLabel]; got != "core" {
+				t.Errorf("Expected %s origin core, got %q", key, got)
+			}
+			if got := obj.GetAnnotations()[BlueprintVersionAnnotation]; got != "v0.5.0" {
+				t.Errorf("Expected %s version v0.5.0, got %q", key, got)
+			}
+		}
+
+		// And the kustomization passes its provenance on to what it applies
+		var kustomization kustomizev1.Kustomization
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(applied["kustomizations/dns"].Object, &kustomization); err != nil {
+			t.Fatalf("Failed to convert kustomization: %v", err)
+		}
+		common := kustomization.Spec.CommonMetadata
+		if common == nil || common.Labels[OriginLabel] != "core" || common.Labels[ContextIDLabel] != "test-context-id" ||
+			common.Annotations[BlueprintVersionAnnotation] != "v0.5.0" || common.Annotations[AppliedByAnnotation] == "" {
+			t.Errorf("Expected commonMetadata to carry the provenance, got %+v", common)
+		}
+	})
+}
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func TestSourceVersion(t *testing.T) {
+	blueprint := &blueprintv1alpha1.Blueprint{
+		Metadata:   blueprintv1alpha1.Metadata{Name: "local"},
+		Repository: blueprintv1alpha1.Repository{Url: "https://github.com/acme/blueprint", Ref: blueprintv1alpha1.Reference{Branch: "main"}},
+		Sources: []blueprintv1alpha1.Source{
+			{Name: "core", Url: "oci://ghcr.io/windsorcli/core:v0.5.0"},
+			{Name: "registry", Url: "oci://localhost:5000/core", Ref: blueprintv1alpha1.Reference{SemVer: ">=1.0.0"}},
+			{Name: "pinned", Url: "https://github.com/acme/addons", Ref: blueprintv1alpha1.Reference{Tag: "v1.2.0", Commit: "abc123"}},
+			{Name: "unpinned", Url: "https://github.com/acme/other"},
+		},
+	}
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"BlueprintRepositoryUsesItsRef", "local", "main"},
+		{"OCISourceUsesURLTag", "core", "v0.5.0"},
+		{"RegistryPortIsNotATag", "registry", ">=1.0.0"},
+		{"CommitWinsOverTag", "pinned", "abc123"},
+		{"UnpinnedSourceHasNoVersion", "unpinned", ""},
+		{"UndeclaredSourceHasNoVersion", "template", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sourceVersion(blueprint, tt.source); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// managedItem builds an object stamped with this test context's provenance.
+func managedItem(namespace, name, origin, version string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":        name,
+			"namespace":   namespace,
+			"labels":      map[string]any{ContextIDLabel: "test-context-id", OriginLabel: origin},
+			"annotations": map[string]any{BlueprintVersionAnnotation: version, AppliedByAnnotation: "windsor/test"},
+		},
+	}}
+}
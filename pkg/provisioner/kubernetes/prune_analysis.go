@@ -0,0 +1,329 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file analyzes what pruning a set of flux Kustomizations would do to the objects in
+// their inventories before anything is deleted. Each object is classified as deleted,
+// retained or orphaned, following the annotations and policies flux, helm and kubernetes
+// honor during garbage collection, and objects whose deletion destroys persistent data are
+// flagged so apply and upgrade can refuse to prune them without explicit consent.
+
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+const (
+	// fluxPruneAnnotation set to fluxPruneDisabled tells kustomize-controller to leave an object in
+	// place when it is garbage-collected from a Kustomization's inventory.
+	fluxPruneAnnotation = "kustomize.toolkit.fluxcd.io/prune"
+	fluxPruneDisabled   = "disabled"
+
+	// helmResourcePolicyAnnotation set to helmResourcePolicyKeep tells helm to leave an object in
+	// place when its release is uninstalled.
+	helmResourcePolicyAnnotation = "helm.sh/resource-policy"
+	helmResourcePolicyKeep       = "keep"
+
+	// helmReleaseNameAnnotation and helmReleaseNamespaceAnnotation are set by helm on every object
+	// it installs, naming the release that owns it.
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// PruneDisposition is what pruning a Kustomization does to one object it owns.
+type PruneDisposition string
+
+const (
+	// PruneDelete means the object is deleted.
+	PruneDelete PruneDisposition = "delete"
+	// PruneRetain means the object is left in place because an annotation asks for it.
+	PruneRetain PruneDisposition = "retain"
+	// PruneOrphan means the object, or the storage behind it, outlives its owner and is left
+	// unmanaged.
+	PruneOrphan PruneDisposition = "orphan"
+)
+
+// PruneObject is one object a prune would affect. Kustomization names the pruned Kustomization
+// the object belongs to, directly through its inventory or through a HelmRelease or StatefulSet
+// in it. Reason explains a retain or orphan disposition, or what a delete takes with it.
+// DataBearing is set when the object holds persistent data the prune destroys.
+type PruneObject struct {
+	InventoryEntry
+	Kustomization string
+	Disposition   PruneDisposition
+	Reason        string
+	DataBearing   bool
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// AnalyzePrune classifies every object that pruning the named Kustomizations would affect, read
+// from each Kustomization's inventory. Each name is resolved in its namespace from namespaces,
+// falling back to the gitops namespace. HelmReleases are expanded into the PersistentVolumeClaims
+// and StatefulSets their release installed, StatefulSets into the claims created from their
+// volumeClaimTemplates, and Namespaces into the claims they contain, since uninstalling or deleting those is where persistent data is lost.
+// Objects already gone from the cluster are skipped. An object whose kustomize.toolkit.fluxcd.io
+// owner labels name a different Kustomization has been adopted by it, as when a renamed
+// kustomization takes over its predecessor's objects; flux's garbage collection leaves such
+// objects alone, so they are retained and not expanded. Objects are returned per Kustomization in
+// the order of names, each followed by what it expands to.
+func (k *BaseKubernetesManager) AnalyzePrune(names []string, namespaces map[string]string) ([]PruneObject, error) {
+	var objs []PruneObject
+	for _, name := range names {
+		namespace := k.kustomizationNamespace(name, namespaces)
+		entries, err := k.GetKustomizationInventory(name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error reading inventory for kustomization %q: %w", name, err)
+		}
+		for _, e := range entries {
+			obj, err := k.getInventoryObject(e)
+			if err != nil {
+				return nil, err
+			}
+			if obj == nil {
+				continue
+			}
+			if adopter := adoptingKustomization(obj, name, namespace); adopter != "" {
+				objs = append(objs, PruneObject{
+					InventoryEntry: inventoryEntryFor(obj),
+					Kustomization:  name,
+					Disposition:    PruneRetain,
+					Reason:         "now managed by Kustomization " + adopter,
+				})
+				continue
+			}
+			classified, err := k.classifyPruneObject(name, obj)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, classified...)
+		}
+	}
+	return objs, nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// getInventoryObject reads the live object an inventory entry names, returning nil when it or
+// its kind is no longer on the cluster.
+func (k *BaseKubernetesManager) getInventoryObject(e InventoryEntry) (*unstructured.Unstructured, error) {
+	gvr, err := k.client.ResourceFor(schema.GroupVersionKind{Group: e.Group, Kind: e.Kind})
+	if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error resolving %s %q: %w", e.Kind, e.Name, err)
+	}
+	obj, err := k.client.GetResource(gvr, e.Namespace, e.Name)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading %s %q: %w", e.Kind, e.Name, err)
+	}
+	return obj, nil
+}
+
+// classifyPruneObject classifies obj, owned by the named Kustomization, and whatever its deletion
+// cascades to. A retain annotation wins over everything else, since the object and everything it
+// owns stay in place.
+func (k *BaseKubernetesManager) classifyPruneObject(owner string, obj *unstructured.Unstructured) ([]PruneObject, error) {
+	self := PruneObject{InventoryEntry: inventoryEntryFor(obj), Kustomization: owner, Disposition: PruneDelete}
+	annotations := obj.GetAnnotations()
+	switch {
+	case annotations[fluxPruneAnnotation] == fluxPruneDisabled:
+		self.Disposition, self.Reason = PruneRetain, fluxPruneAnnotation+": "+fluxPruneDisabled
+		return []PruneObject{self}, nil
+	case annotations[helmResourcePolicyAnnotation] == helmResourcePolicyKeep:
+		self.Disposition, self.Reason = PruneRetain, helmResourcePolicyAnnotation+": "+helmResourcePolicyKeep
+		return []PruneObject{self}, nil
+	}
+
+	switch obj.GetKind() {
+	case "PersistentVolumeClaim":
+		return k.classifyClaim(self, obj)
+	case "PersistentVolume":
+		if reclaim, _, _ := unstructured.NestedString(obj.Object, "spec", "persistentVolumeReclaimPolicy"); reclaim == "Retain" {
+			self.Disposition, self.Reason = PruneOrphan, "reclaim policy Retain keeps the backing storage"
+		} else {
+			self.Reason, self.DataBearing = "deletes the backing storage", true
+		}
+		return []PruneObject{self}, nil
+	case "Namespace":
+		self.Reason = "deletes everything in the namespace"
+		claims, err := k.classifyNamespaceClaims(owner, obj)
+		return append([]PruneObject{self}, claims...), err
+	case "StatefulSet":
+		claims, err := k.classifyStatefulSetClaims(owner, obj)
+		return append([]PruneObject{self}, claims...), err
+	case helmv2.HelmReleaseKind:
+		self.Reason = "uninstalls the Helm release"
+		released, err := k.classifyHelmReleaseObjects(owner, obj)
+		return append([]PruneObject{self}, released...), err
+	}
+	return []PruneObject{self}, nil
+}
+
+// classifyClaim completes the classification of a PersistentVolumeClaim being deleted. Its data
+// survives when the bound PersistentVolume has a Retain reclaim policy, leaving the volume orphaned;
+// otherwise the volume and its data are deleted with the claim.
+func (k *BaseKubernetesManager) classifyClaim(self PruneObject, claim *unstructured.Unstructured) ([]PruneObject, error) {
+	volume, _, _ := unstructured.NestedString(claim.Object, "spec", "volumeName")
+	if volume != "" {
+		pv, err := k.client.GetResource(schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumes"}, "", volume)
+		if err != nil && !isNotFoundError(err) {
+			return nil, fmt.Errorf("error reading PersistentVolume %q: %w", volume, err)
+		}
+		if pv != nil {
+			if reclaim, _, _ := unstructured.NestedString(pv.Object, "spec", "persistentVolumeReclaimPolicy"); reclaim == "Retain" {
+				self.Disposition = PruneOrphan
+				self.Reason = fmt.Sprintf("bound PersistentVolume %s has reclaim policy Retain", volume)
+				return []PruneObject{self}, nil
+			}
+		}
+	}
+	self.Disposition, self.DataBearing = PruneDelete, true
+	self.Reason = "deletes the claim's volume and data"
+	return []PruneObject{self}, nil
+}
+
+// classifyNamespaceClaims classifies the PersistentVolumeClaims in a Namespace being deleted.
+// Deleting a namespace deletes every claim in it regardless of annotations or the owning
+// StatefulSet's retention policy, so each is classified as a deleted claim.
+func (k *BaseKubernetesManager) classifyNamespaceClaims(owner string, ns *unstructured.Unstructured) ([]PruneObject, error) {
+	list, err := k.client.ListResources(schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, ns.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("error listing PersistentVolumeClaims of Namespace %q: %w", ns.GetName(), err)
+	}
+
+	var objs []PruneObject
+	for i := range list.Items {
+		claim := &list.Items[i]
+		self := PruneObject{InventoryEntry: inventoryEntryFor(claim), Kustomization: owner, Disposition: PruneDelete}
+		classified, err := k.classifyClaim(self, claim)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, classified...)
+	}
+	return objs, nil
+}
+
+// classifyStatefulSetClaims classifies the PersistentVolumeClaims created from a StatefulSet's
+// volumeClaimTemplates, found by the <template>-<statefulset>-<ordinal> name kubernetes gives them.
+// They are orphaned when the StatefulSet is deleted unless its persistentVolumeClaimRetentionPolicy
+// deletes them.
+func (k *BaseKubernetesManager) classifyStatefulSetClaims(owner string, sts *unstructured.Unstructured) ([]PruneObject, error) {
+	templates, _, _ := unstructured.NestedSlice(sts.Object, "spec", "volumeClaimTemplates")
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	whenDeleted, _, _ := unstructured.NestedString(sts.Object, "spec", "persistentVolumeClaimRetentionPolicy", "whenDeleted")
+	list, err := k.client.ListResources(schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, sts.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("error listing PersistentVolumeClaims of StatefulSet %q: %w", sts.GetName(), err)
+	}
+
+	var objs []PruneObject
+	for _, raw := range templates {
+		template, _ := raw.(map[string]any)
+		name, _, _ := unstructured.NestedString(template, "metadata", "name")
+		prefix := name + "-" + sts.GetName() + "-"
+		for i := range list.Items {
+			claim := &list.Items[i]
+			if name == "" || !strings.HasPrefix(claim.GetName(), prefix) {
+				continue
+			}
+			self := PruneObject{InventoryEntry: inventoryEntryFor(claim), Kustomization: owner, Disposition: PruneOrphan}
+			if whenDeleted != "Delete" {
+				self.Reason = fmt.Sprintf("claims of StatefulSet %s are kept when it is deleted", sts.GetName())
+				objs = append(objs, self)
+				continue
+			}
+			classified, err := k.classifyClaim(self, claim)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, classified...)
+		}
+	}
+	return objs, nil
+}
+
+// classifyHelmReleaseObjects classifies the PersistentVolumeClaims and StatefulSets a HelmRelease's
+// release installed into its target namespace, found by the release annotations helm sets. Helm
+// deletes them on uninstall unless they carry helm.sh/resource-policy: keep.
+func (k *BaseKubernetesManager) classifyHelmReleaseObjects(owner string, hr *unstructured.Unstructured) ([]PruneObject, error) {
+	targetNamespace, _, _ := unstructured.NestedString(hr.Object, "spec", "targetNamespace")
+	releaseName, _, _ := unstructured.NestedString(hr.Object, "spec", "releaseName")
+	if releaseName == "" {
+		releaseName = hr.GetName()
+		if targetNamespace != "" {
+			releaseName = targetNamespace + "-" + releaseName
+		}
+	}
+	if targetNamespace == "" {
+		targetNamespace = hr.GetNamespace()
+	}
+
+	var objs []PruneObject
+	for _, gvr := range []schema.GroupVersionResource{
+		{Version: "v1", Resource: "persistentvolumeclaims"},
+		{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	} {
+		list, err := k.client.ListResources(gvr, targetNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s of HelmRelease %q: %w", gvr.Resource, hr.GetName(), err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			annotations := obj.GetAnnotations()
+			if annotations[helmReleaseNameAnnotation] != releaseName || annotations[helmReleaseNamespaceAnnotation] != targetNamespace {
+				continue
+			}
+			classified, err := k.classifyPruneObject(owner, obj)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, classified...)
+		}
+	}
+	return objs, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// adoptingKustomization returns the namespace/name of the Kustomization obj's flux owner labels
+// name when it is not the named one, or "" when obj is unlabelled or still owned by it.
+func adoptingKustomization(obj *unstructured.Unstructured, name, namespace string) string {
+	labels := obj.GetLabels()
+	ownerName, ownerNamespace := labels[fluxOwnerNameLabel], labels[fluxOwnerNamespaceLabel]
+	if ownerName == "" || (ownerName == name && ownerNamespace == namespace) {
+		return ""
+	}
+	return ownerNamespace + "/" + ownerName
+}
+
+// inventoryEntryFor identifies obj the way a flux inventory entry does.
+func inventoryEntryFor(obj *unstructured.Unstructured) InventoryEntry {
+	gvk := obj.GroupVersionKind()
+	return InventoryEntry{Group: gvk.Group, Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
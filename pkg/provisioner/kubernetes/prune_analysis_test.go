@@ -0,0 +1,254 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// pruneCluster returns a mock client serving kustomization "old" in system-gitops, whose
+// inventory names every object in objs plus a Secret that is no longer on the cluster. Objects
+// are served by GetResource and ListResources according to their kind, namespace and name.
+func pruneCluster(t *testing.T, objs ...map[string]any) *client.MockKubernetesClient {
+	t.Helper()
+	resources := map[string]string{
+		"ConfigMap": "configmaps", "Deployment": "deployments", "Secret": "secrets", "StatefulSet": "statefulsets",
+		"PersistentVolumeClaim": "persistentvolumeclaims", "PersistentVolume": "persistentvolumes", "HelmRelease": "helmreleases",
+		"Namespace": "namespaces",
+	}
+	var items []unstructured.Unstructured
+	entries := []any{map[string]any{"id": "app_gone__Secret", "v": "v1"}}
+	for _, obj := range objs {
+		u := unstructured.Unstructured{Object: obj}
+		items = append(items, u)
+		if u.GetLabels()["inventory"] == "true" {
+			id := fmt.Sprintf("%s_%s_%s_%s", u.GetNamespace(), u.GetName(), u.GroupVersionKind().Group, u.GetKind())
+			entries = append(entries, map[string]any{"id": id, "v": u.GroupVersionKind().Version})
+		}
+	}
+	kustomization := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "old", "namespace": "system-gitops"},
+		"status":   map[string]any{"inventory": map[string]any{"entries": entries}},
+	}}
+
+	mockClient := client.NewMockKubernetesClient()
+	mockClient.ResourceForFunc = func(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+		return schema.GroupVersionResource{Group: gvk.Group, Resource: resources[gvk.Kind]}, nil
+	}
+	mockClient.GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+		if gvr.Resource == "kustomizations" && name == "old" {
+			return kustomization, nil
+		}
+		for i := range items {
+			if resources[items[i].GetKind()] == gvr.Resource && items[i].GetNamespace() == namespace && items[i].GetName() == name {
+				return items[i].DeepCopy(), nil
+			}
+		}
+		return nil, fmt.Errorf("%s %q not found", gvr.Resource, name)
+	}
+	mockClient.ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+		list := &unstructured.UnstructuredList{}
+		for _, item := range items {
+			if resources[item.GetKind()] == gvr.Resource && item.GetNamespace() == namespace {
+				list.Items = append(list.Items, item)
+			}
+		}
+		return list, nil
+	}
+	return mockClient
+}
+
+// pruneObj builds an object of the given kind; inventory marks it as listed in the "old"
+// kustomization's inventory.
+func pruneObj(apiVersion, kind, namespace, name string, inventory bool, annotations map[string]any, spec map[string]any) map[string]any {
+	meta := map[string]any{"name": name, "annotations": annotations, "labels": map[string]any{"inventory": fmt.Sprint(inventory)}}
+	if namespace != "" {
+		meta["namespace"] = namespace
+	}
+	return map[string]any{"apiVersion": apiVersion, "kind": kind, "metadata": meta, "spec": spec}
+}
+
+// describePruneObjects renders objs as "disposition kind namespace/name" lines, with a trailing
+// "!" marking data-bearing objects.
+func describePruneObjects(objs []PruneObject) string {
+	lines := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		line := fmt.Sprintf("%s %s %s/%s", obj.Disposition, obj.Kind, obj.Namespace, obj.Name)
+		if obj.DataBearing {
+			line += "!"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestBaseKubernetesManager_AnalyzePrune(t *testing.T) {
+	t.Run("ClassifiesInventoryAndWhatItCascadesTo", func(t *testing.T) {
+		// Given a kustomization owning annotated, data-bearing, stateful and helm-installed objects
+		mocks := setupKubernetesMocks(t)
+		mockClient := pruneCluster(t,
+			pruneObj("v1", "ConfigMap", "app", "settings", true, map[string]any{fluxPruneAnnotation: "disabled"}, nil),
+			pruneObj("apps/v1", "Deployment", "app", "web", true, nil, nil),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "data", true, nil, map[string]any{"volumeName": "pv-delete"}),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "logs", true, nil, map[string]any{"volumeName": "pv-keep"}),
+			pruneObj("v1", "PersistentVolume", "", "pv-delete", false, nil, map[string]any{"persistentVolumeReclaimPolicy": "Delete"}),
+			pruneObj("v1", "PersistentVolume", "", "pv-keep", false, nil, map[string]any{"persistentVolumeReclaimPolicy": "Retain"}),
+			pruneObj("apps/v1", "StatefulSet", "app", "pg", true, nil, map[string]any{
+				"volumeClaimTemplates": []any{map[string]any{"metadata": map[string]any{"name": "data"}}},
+			}),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "data-pg-0", false, nil, nil),
+			pruneObj("helm.toolkit.fluxcd.io/v2", "HelmRelease", "app", "redis", true, nil, nil),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "redis-data", false, map[string]any{helmReleaseNameAnnotation: "redis", helmReleaseNamespaceAnnotation: "app"}, nil),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "redis-keep", false, map[string]any{
+				helmReleaseNameAnnotation: "redis", helmReleaseNamespaceAnnotation: "app", helmResourcePolicyAnnotation: "keep",
+			}, nil),
+		)
+		manager := NewKubernetesManager(mockClient, mocks.ConfigHandler)
+
+		// When the prune of "old" is analyzed
+		objs, err := manager.AnalyzePrune([]string{"old"}, nil)
+
+		// Then each object is classified, expansions follow their owner, and the missing Secret is skipped
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := strings.Join([]string{
+			"retain ConfigMap app/settings",
+			"delete Deployment app/web",
+			"delete PersistentVolumeClaim app/data!",
+			"orphan PersistentVolumeClaim app/logs",
+			"delete StatefulSet app/pg",
+			"orphan PersistentVolumeClaim app/data-pg-0",
+			"delete HelmRelease app/redis",
+			"delete PersistentVolumeClaim app/redis-data!",
+			"retain PersistentVolumeClaim app/redis-keep",
+		}, "\n")
+		if got := describePruneObjects(objs); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+		for _, obj := range objs {
+			if obj.Kustomization != "old" {
+				t.Errorf("Expected %s attributed to old, got %q", obj.Name, obj.Kustomization)
+			}
+		}
+	})
+
+	t.Run("RetainsObjectsAdoptedByAnotherKustomization", func(t *testing.T) {
+		// Given a data-bearing claim in the inventory now labelled as owned by another kustomization
+		mocks := setupKubernetesMocks(t)
+		claim := pruneObj("v1", "PersistentVolumeClaim", "app", "data", true, nil, map[string]any{"volumeName": "pv-delete"})
+		claim["metadata"].(map[string]any)["labels"].(map[string]any)[fluxOwnerNameLabel] = "new"
+		claim["metadata"].(map[string]any)["labels"].(map[string]any)[fluxOwnerNamespaceLabel] = "system-gitops"
+		owned := pruneObj("v1", "ConfigMap", "app", "settings", true, nil, nil)
+		owned["metadata"].(map[string]any)["labels"].(map[string]any)[fluxOwnerNameLabel] = "old"
+		owned["metadata"].(map[string]any)["labels"].(map[string]any)[fluxOwnerNamespaceLabel] = "system-gitops"
+		mockClient := pruneCluster(t, claim, owned,
+			pruneObj("v1", "PersistentVolume", "", "pv-delete", false, nil, map[string]any{"persistentVolumeReclaimPolicy": "Delete"}),
+		)
+		manager := NewKubernetesManager(mockClient, mocks.ConfigHandler)
+
+		// When the prune of "old" is analyzed
+		objs, err := manager.AnalyzePrune([]string{"old"}, nil)
+
+		// Then the adopted claim is retained without data loss and the owned object is deleted
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := "retain PersistentVolumeClaim app/data\ndelete ConfigMap app/settings"
+		if got := describePruneObjects(objs); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+		if objs[0].Reason != "now managed by Kustomization system-gitops/new" {
+			t.Errorf("Expected the adopting kustomization to be named, got %q", objs[0].Reason)
+		}
+	})
+
+	t.Run("DeletesStatefulSetClaimsWhenRetentionPolicyDeletes", func(t *testing.T) {
+		// Given a StatefulSet whose claims are deleted with it
+		mocks := setupKubernetesMocks(t)
+		mockClient := pruneCluster(t,
+			pruneObj("apps/v1", "StatefulSet", "app", "pg", true, nil, map[string]any{
+				"volumeClaimTemplates":                 []any{map[string]any{"metadata": map[string]any{"name": "data"}}},
+				"persistentVolumeClaimRetentionPolicy": map[string]any{"whenDeleted": "Delete"},
+			}),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "data-pg-0", false, nil, nil),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "data-other-0", false, nil, nil),
+		)
+		manager := NewKubernetesManager(mockClient, mocks.ConfigHandler)
+
+		// When the prune is analyzed
+		objs, err := manager.AnalyzePrune([]string{"old"}, nil)
+
+		// Then the StatefulSet's own claim is deleted with its data and other claims are untouched
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := "delete StatefulSet app/pg\ndelete PersistentVolumeClaim app/data-pg-0!"
+		if got := describePruneObjects(objs); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+	})
+
+	t.Run("DeletesClaimsInPrunedNamespace", func(t *testing.T) {
+		// Given a namespace in the inventory holding a claim on a deleted volume and one on a retained volume
+		mocks := setupKubernetesMocks(t)
+		mockClient := pruneCluster(t,
+			pruneObj("v1", "Namespace", "", "app", true, nil, nil),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "data", false, map[string]any{helmResourcePolicyAnnotation: "keep"}, map[string]any{"volumeName": "pv-delete"}),
+			pruneObj("v1", "PersistentVolumeClaim", "app", "logs", false, nil, map[string]any{"volumeName": "pv-keep"}),
+			pruneObj("v1", "PersistentVolume", "", "pv-delete", false, nil, map[string]any{"persistentVolumeReclaimPolicy": "Delete"}),
+			pruneObj("v1", "PersistentVolume", "", "pv-keep", false, nil, map[string]any{"persistentVolumeReclaimPolicy": "Retain"}),
+		)
+		manager := NewKubernetesManager(mockClient, mocks.ConfigHandler)
+
+		// When the prune is analyzed
+		objs, err := manager.AnalyzePrune([]string{"old"}, nil)
+
+		// Then the namespace's claims are deleted with it, ignoring annotations, unless their volume is retained
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := strings.Join([]string{
+			"delete Namespace /app",
+			"delete PersistentVolumeClaim app/data!",
+			"orphan PersistentVolumeClaim app/logs",
+		}, "\n")
+		if got := describePruneObjects(objs); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+	})
+
+	t.Run("ReturnsErrorWhenObjectCannotBeRead", func(t *testing.T) {
+		// Given an inventory object whose read fails for a reason other than absence
+		mocks := setupKubernetesMocks(t)
+		mockClient := pruneCluster(t, pruneObj("apps/v1", "Deployment", "app", "web", true, nil, nil))
+		getResource := mockClient.GetResourceFunc
+		mockClient.GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+			if gvr.Resource == "deployments" {
+				return nil, fmt.Errorf("forbidden")
+			}
+			return getResource(gvr, namespace, name)
+		}
+		manager := NewKubernetesManager(mockClient, mocks.ConfigHandler)
+
+		// When the prune is analyzed
+		_, err := manager.AnalyzePrune([]string{"old"}, nil)
+
+		// Then the error is returned rather than under-reporting what the prune deletes
+		if err == nil || !strings.Contains(err.Error(), "forbidden") {
+			t.Errorf("Expected read error, got %v", err)
+		}
+	})
+}
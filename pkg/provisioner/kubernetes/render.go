@@ -0,0 +1,175 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file renders a blueprint to the objects ApplyBlueprint and secret placement would apply,
+// without a cluster. It shares the builders the apply path uses, so the rendered objects are
+// exactly what the CLI would send to the API server, and a separate GitOps repository can commit
+// them as a reviewable, diffable artifact.
+
+package kubernetes
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/runtime/config"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// RenderBlueprint returns the objects ApplyBlueprint applies for the blueprint, in the order it
+// applies them: the namespace, the blueprint's repository and its other remote sources, the
+// blueprint-level ConfigMaps, then each kustomization's values ConfigMap and Kustomization. Each
+// carries the same provenance the apply path stamps. Destroy-only kustomizations are skipped, as
+// on apply. The Secrets the kustomizations declare follow, with every value replaced by
+// config.SensitiveRedactionMarker so no secret material is rendered: one per namespace the entry
+// names, or a single Secret with no namespace when placement resolves it from the owning
+// kustomization at apply time. When the gitops engine is Argo CD, each kustomization is rendered
+// as an Application in gitops.argocd.namespace instead, in its sync wave, with its values inlined,
+// so no namespace, sources or values ConfigMaps are rendered ahead of it; only the substitution
+// plugin's definition is, when an Application selects it. The caller passes the prepared
+// blueprint Install applies (CRD layers included). It fails when a source cannot be built or an
+// object cannot be converted.
+func (k *BaseKubernetesManager) RenderBlueprint(blueprint *blueprintv1alpha1.Blueprint, namespace string) ([]*unstructured.Unstructured, error) {
+	if k.gitopsEngine() == constants.GitopsEngineArgoCD {
+		objs, err := k.renderArgoApplications(blueprint)
+		if err != nil {
+			return nil, err
+		}
+		return k.appendSecretPlaceholders(objs, blueprint)
+	}
+
+	objs := []*unstructured.Unstructured{namespaceObject(namespace)}
+
+	sources := []blueprintv1alpha1.Source{}
+	repository, hasRepository := repositorySource(blueprint)
+	if hasRepository {
+		sources = append(sources, repository)
+	}
+	for _, source := range blueprint.Sources {
+		if !blueprintv1alpha1.IsLocalTemplateSource(source) {
+			sources = append(sources, source)
+		}
+	}
+	for i, source := range sources {
+		typed, err := k.blueprintSourceObject(source, namespace, hasRepository && i == 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render source %s: %w", source.Name, err)
+		}
+		obj, err := k.renderedObject(typed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render source %s: %w", source.Name, err)
+		}
+		objs = append(objs, obj)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(blueprint.ConfigMaps)) {
+		labels, annotations := k.provenance("", "")
+		objs = append(objs, configMapObject(name, namespace, blueprint.ConfigMaps[name], labels, annotations))
+	}
+
+	mode := k.gitopsMode()
+	for _, kustomization := range blueprint.Kustomizations {
+		if kustomization.DestroyOnly != nil && *kustomization.DestroyOnly {
+			continue
+		}
+		fluxKustomization := kustomization.ToFluxKustomization(namespace, blueprint.Metadata.Name, blueprint.Sources, mode, blueprint.ConfigMaps)
+		labels, annotations := k.stampProvenance(&fluxKustomization, blueprint)
+		if len(kustomization.Substitutions) > 0 {
+			objs = append(objs, configMapObject(fmt.Sprintf("values-%s", kustomization.Name), namespace, kustomization.Substitutions, labels, annotations))
+		}
+		obj, err := k.renderedObject(&fluxKustomization)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render kustomization %s: %w", kustomization.Name, err)
+		}
+		objs = append(objs, obj)
+	}
+
+	return k.appendSecretPlaceholders(objs, blueprint)
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// renderArgoApplications compiles each non-destroy-only kustomization to an Argo CD Application in
+// gitops.argocd.namespace, in the sync wave Blueprint.SyncWaves assigns it, stamped with the same
+// provenance its Flux Kustomization would carry. When any Application selects the substitution
+// plugin, the ConfigMap carrying the plugin's definition is rendered ahead of them.
+func (k *BaseKubernetesManager) renderArgoApplications(blueprint *blueprintv1alpha1.Blueprint) ([]*unstructured.Unstructured, error) {
+	namespace := k.argoCDNamespace()
+	waves := blueprint.SyncWaves()
+	var objs []*unstructured.Unstructured
+	usesPlugin := false
+	for _, kustomization := range blueprint.Kustomizations {
+		if kustomization.DestroyOnly != nil && *kustomization.DestroyOnly {
+			continue
+		}
+		content, err := kustomization.ToArgoApplication(namespace, blueprint.Metadata.Name, blueprint.Repository, blueprint.Sources, waves[kustomization.Name], blueprint.ConfigMaps)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render application %s: %w", kustomization.Name, err)
+		}
+		obj := &unstructured.Unstructured{Object: content}
+		if _, found, _ := unstructured.NestedMap(obj.Object, "spec", "source", "plugin"); found {
+			usesPlugin = true
+		}
+		origin := kustomization.SourceName(blueprint.Metadata.Name, blueprint.Sources)
+		labels, annotations := k.provenance(origin, sourceVersion(blueprint, origin))
+		maps.Copy(labels, obj.GetLabels())
+		maps.Copy(annotations, obj.GetAnnotations())
+		obj.SetLabels(labels)
+		obj.SetAnnotations(annotations)
+		objs = append(objs, obj)
+	}
+	if usesPlugin {
+		objs = append([]*unstructured.Unstructured{k.argoPluginConfigMap(blueprint, namespace)}, objs...)
+	}
+	return objs, nil
+}
+
+// appendSecretPlaceholders appends the redacted Secrets the blueprint's kustomizations declare to
+// objs: one per namespace an entry names, or one with no namespace when placement resolves it.
+func (k *BaseKubernetesManager) appendSecretPlaceholders(objs []*unstructured.Unstructured, blueprint *blueprintv1alpha1.Blueprint) ([]*unstructured.Unstructured, error) {
+	for _, kustomization := range blueprint.Kustomizations {
+		for _, name := range slices.Sorted(maps.Keys(kustomization.Secrets)) {
+			entry := kustomization.Secrets[name]
+			placeholders := make(map[string]string, len(entry.Data))
+			for key := range entry.Data {
+				placeholders[key] = config.SensitiveRedactionMarker
+			}
+			namespaces := entry.Namespaces
+			if len(namespaces) == 0 {
+				namespaces = []string{""}
+			}
+			for _, ns := range namespaces {
+				obj, err := k.secretObject(name, ns, placeholders, kustomization.Name)
+				if err != nil {
+					return nil, err
+				}
+				if ns == "" {
+					unstructured.RemoveNestedField(obj.Object, "metadata", "namespace")
+				}
+				objs = append(objs, obj)
+			}
+		}
+	}
+	return objs, nil
+}
+
+// renderedObject converts a typed object to unstructured form and drops the empty status and
+// creation timestamp the conversion leaves, which the API server fills in and a rendered manifest
+// should not carry.
+func (k *BaseKubernetesManager) renderedObject(typed any) (*unstructured.Unstructured, error) {
+	content, err := k.shims.ToUnstructured(typed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to unstructured: %w", err)
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	unstructured.RemoveNestedField(obj.Object, "status")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	return obj, nil
+}
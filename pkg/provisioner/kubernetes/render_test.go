@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/runtime/config"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestBaseKubernetesManager_RenderBlueprint(t *testing.T) {
+	renderBlueprint := func() *blueprintv1alpha1.Blueprint {
+		destroyOnly := true
+		return &blueprintv1alpha1.Blueprint{
+			Metadata:   blueprintv1alpha1.Metadata{Name: "local"},
+			Repository: blueprintv1alpha1.Repository{Url: "https://github.com/example/blueprint.git", Ref: blueprintv1alpha1.Reference{Branch: "main"}},
+			Sources: []blueprintv1alpha1.Source{
+				{Name: "core", Url: "oci://ghcr.io/windsorcli/core:v0.6.0"},
+			},
+			ConfigMaps: map[string]map[string]string{
+				"values-common": {"DOMAIN": "test"},
+			},
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "dns", Source: "core", Path: "dns", Substitutions: map[string]string{"zone": "test"}, Secrets: map[string]blueprintv1alpha1.SecretEntry{
+					"dns-token": {Namespaces: []string{"system-dns", "system-dns-ext"}, Data: map[string]string{"token": "${secret('op://dns/token')}"}},
+					"dns-auto":  {Data: map[string]string{"key": "${env('KEY')}"}},
+				}},
+				{Name: "cleanup", Source: "core", Path: "cleanup", DestroyOnly: &destroyOnly},
+			},
+		}
+	}
+
+	t.Run("RendersObjectsInApplyOrderWithProvenance", func(t *testing.T) {
+		// Given a blueprint with a repository, an OCI source, a shared ConfigMap and a kustomization
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When it is rendered
+		objs, err := manager.RenderBlueprint(renderBlueprint(), "system-gitops")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the objects follow apply order, skipping the destroy-only kustomization
+		want := []string{
+			"Namespace//system-gitops",
+			"GitRepository/system-gitops/local",
+			"OCIRepository/system-gitops/core",
+			"ConfigMap/system-gitops/values-common",
+			"ConfigMap/system-gitops/values-dns",
+			"Kustomization/system-gitops/dns",
+		}
+		got := make([]string, len(objs))
+		for i, obj := range objs {
+			got[i] = obj.GetKind() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+		}
+		if len(got) < len(want) {
+			t.Fatalf("Expected at least %d objects, got %v", len(want), got)
+		}
+		for i, w := range want {
+			if got[i] != w {
+				t.Errorf("Expected object %d to be %s, got %s", i, w, got[i])
+			}
+		}
+
+		// And each carries the apply path's provenance without server-populated fields
+		kustomization := objs[5]
+		if kustomization.GetLabels()[OriginLabel] != "core" || kustomization.GetAnnotations()[BlueprintVersionAnnotation] != "v0.6.0" {
+			t.Errorf("Expected kustomization stamped with core v0.6.0, got %v %v", kustomization.GetLabels(), kustomization.GetAnnotations())
+		}
+		if _, found, _ := unstructured.NestedFieldNoCopy(kustomization.Object, "status"); found {
+			t.Error("Expected status to be dropped from the rendered kustomization")
+		}
+		if _, found, _ := unstructured.NestedFieldNoCopy(kustomization.Object, "metadata", "creationTimestamp"); found {
+			t.Error("Expected creationTimestamp to be dropped from the rendered kustomization")
+		}
+	})
+
+	t.Run("RendersSecretPlaceholdersPerNamespace", func(t *testing.T) {
+		// Given a kustomization declaring one secret for two namespaces and one auto-placed secret
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When it is rendered
+		objs, err := manager.RenderBlueprint(renderBlueprint(), "system-gitops")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then each secret is rendered with redacted values, auto-placed ones without a namespace
+		var secrets []*unstructured.Unstructured
+		for _, obj := range objs {
+			if obj.GetKind() == "Secret" {
+				secrets = append(secrets, obj)
+			}
+		}
+		if len(secrets) != 3 {
+			t.Fatalf("Expected 3 secrets, got %d", len(secrets))
+		}
+		if secrets[0].GetName() != "dns-auto" || secrets[0].GetNamespace() != "" {
+			t.Errorf("Expected dns-auto without a namespace, got %s/%s", secrets[0].GetNamespace(), secrets[0].GetName())
+		}
+		if secrets[1].GetNamespace() != "system-dns" || secrets[2].GetNamespace() != "system-dns-ext" {
+			t.Errorf("Expected dns-token fanned out to both namespaces, got %s and %s", secrets[1].GetNamespace(), secrets[2].GetNamespace())
+		}
+		for _, secret := range secrets {
+			data, _, _ := unstructured.NestedMap(secret.Object, "stringData")
+			for key, value := range data {
+				if value != config.SensitiveRedactionMarker {
+					t.Errorf("Expected %s key %s redacted, got %v", secret.GetName(), key, value)
+				}
+			}
+			if secret.GetLabels()[SecretOwnerLabel] != "dns" {
+				t.Errorf("Expected %s owned by dns, got %v", secret.GetName(), secret.GetLabels())
+			}
+		}
+	})
+
+	t.Run("RendersArgoApplicationsForArgoEngine", func(t *testing.T) {
+		// Given the argocd gitops engine
+		mocks := setupKubernetesMocks(t)
+		mocks.ConfigHandler.(*config.MockConfigHandler).GetStringFunc = func(key string, defaultValue ...string) string {
+			switch key {
+			case "id":
+				return "test-context-id"
+			case "gitops.engine":
+				return "argocd"
+			}
+			if len(defaultValue) > 0 {
+				return defaultValue[0]
+			}
+			return ""
+		}
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When the blueprint is rendered
+		objs, err := manager.RenderBlueprint(renderBlueprint(), "system-gitops")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the substituting kustomization is an Application in the argocd namespace, preceded by the
+		// substitution plugin's definition and followed by the secrets
+		got := make([]string, len(objs))
+		for i, obj := range objs {
+			got[i] = obj.GetKind() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+		}
+		want := []string{"ConfigMap/argocd/" + ArgoCDPluginConfigMapName, "Application/argocd/dns", "Secret//dns-auto", "Secret/system-dns/dns-token", "Secret/system-dns-ext/dns-token"}
+		if len(got) != len(want) {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+		for i, w := range want {
+			if got[i] != w {
+				t.Errorf("Expected object %d to be %s, got %s", i, w, got[i])
+			}
+		}
+
+		// And the plugin definition names the plugin the Application selects
+		definition, _, _ := unstructured.NestedString(objs[0].Object, "data", "plugin.yaml")
+		if !strings.Contains(definition, "kind: ConfigManagementPlugin") || !strings.Contains(definition, "name: "+blueprintv1alpha1.ArgoCDSubstitutionPlugin) {
+			t.Errorf("Expected the substitution plugin definition, got %q", definition)
+		}
+		if plugin, _, _ := unstructured.NestedString(objs[1].Object, "spec", "source", "plugin", "name"); plugin != blueprintv1alpha1.ArgoCDSubstitutionPlugin {
+			t.Errorf("Expected the application to select the plugin, got %q", plugin)
+		}
+
+		// And the Application carries its sync wave beside the apply path's provenance
+		application := objs[1]
+		if application.GetLabels()[OriginLabel] != "core" || application.GetAnnotations()[BlueprintVersionAnnotation] != "v0.6.0" {
+			t.Errorf("Expected application stamped with core v0.6.0, got %v %v", application.GetLabels(), application.GetAnnotations())
+		}
+		if application.GetAnnotations()[blueprintv1alpha1.ArgoCDSyncWaveAnnotation] != "0" {
+			t.Errorf("Expected sync wave 0, got %v", application.GetAnnotations())
+		}
+	})
+
+	t.Run("ErrorWhenSourceCannotBeBuilt", func(t *testing.T) {
+		// Given a bucket source without a bucket name
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+		blueprint := renderBlueprint()
+		blueprint.Sources = append(blueprint.Sources, blueprintv1alpha1.Source{Name: "archive", Url: "https://s3.example.com", Kind: blueprintv1alpha1.SourceKindBucket})
+
+		// When it is rendered
+		_, err := manager.RenderBlueprint(blueprint, "system-gitops")
+
+		// Then it fails naming the source
+		if err == nil {
+			t.Fatal("Expected an error for a bucket source without bucketName")
+		}
+	})
+}
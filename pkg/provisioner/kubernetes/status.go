@@ -0,0 +1,151 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file defines the reconciliation status windsor reports for flux objects: a
+// Kustomization, the source it pulls from, and the HelmReleases it owns, each reduced
+// to one state (Ready, Reconciling, Failed, Suspended, NotFound) with the revision it
+// last applied and the condition that explains it.
+
+package kubernetes
+
+import (
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+const (
+	// FluxStateReady is an object whose Ready condition is True.
+	FluxStateReady = "Ready"
+
+	// FluxStateReconciling is an object flux is still working on: no Ready condition yet, a
+	// Ready condition of Unknown, a Reconciling condition, or one waiting on a dependency.
+	FluxStateReconciling = "Reconciling"
+
+	// FluxStateFailed is an object whose Ready condition is False for a reason other than
+	// progress or a dependency, or which is Stalled.
+	FluxStateFailed = "Failed"
+
+	// FluxStateSuspended is an object whose reconciliation is suspended.
+	FluxStateSuspended = "Suspended"
+
+	// FluxStateNotFound is an object absent from the cluster.
+	FluxStateNotFound = "NotFound"
+
+	// dependencyNotReadyReason is the Ready reason flux sets while a dependsOn entry is not Ready.
+	dependencyNotReadyReason = "DependencyNotReady"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// FluxStatus is the reconciliation status of one flux object. Revision is the revision it
+// last applied (a Kustomization's lastAppliedRevision, a HelmRelease's last attempted chart
+// revision, a source's artifact revision). Reason and Message come from the condition that
+// decided State, so they explain a Failed or Reconciling object.
+type FluxStatus struct {
+	Kind      string
+	Name      string
+	Namespace string
+	State     string
+	Revision  string
+	Reason    string
+	Message   string
+}
+
+// KustomizationStatus is a Kustomization's FluxStatus with the source it reconciles from
+// and, when it is waiting on a dependsOn entry, the dependency message flux reported.
+type KustomizationStatus struct {
+	FluxStatus
+	SourceKind      string
+	SourceName      string
+	SourceNamespace string
+	BlockedBy       string
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// fluxState reduces an object's conditions and suspend flag to one state, returning the
+// reason and message of the condition that decided it. Suspension wins over everything;
+// a Stalled=True condition is a failure; otherwise the Ready condition decides, with a
+// missing or Unknown Ready, a True Reconciling condition, or a dependency wait read as
+// still reconciling.
+func fluxState(conditions []metav1.Condition, suspended bool) (state, reason, message string) {
+	if suspended {
+		return FluxStateSuspended, "", ""
+	}
+	var ready, stalled, reconciling *metav1.Condition
+	for i := range conditions {
+		c := &conditions[i]
+		switch c.Type {
+		case "Ready":
+			ready = c
+		case "Stalled":
+			stalled = c
+		case "Reconciling":
+			reconciling = c
+		}
+	}
+	if stalled != nil && stalled.Status == metav1.ConditionTrue {
+		return FluxStateFailed, stalled.Reason, stalled.Message
+	}
+	if ready == nil {
+		return FluxStateReconciling, "", ""
+	}
+	switch {
+	case ready.Status == metav1.ConditionTrue:
+		return FluxStateReady, ready.Reason, ready.Message
+	case ready.Status == metav1.ConditionUnknown,
+		ready.Reason == dependencyNotReadyReason,
+		reconciling != nil && reconciling.Status == metav1.ConditionTrue:
+		return FluxStateReconciling, ready.Reason, ready.Message
+	}
+	return FluxStateFailed, ready.Reason, ready.Message
+}
+
+// kustomizationStatus builds the KustomizationStatus of a Kustomization read from the
+// cluster. The source namespace defaults to the Kustomization's own, as flux resolves it.
+func kustomizationStatus(k *kustomizev1.Kustomization) KustomizationStatus {
+	state, reason, message := fluxState(k.Status.Conditions, k.Spec.Suspend)
+	status := KustomizationStatus{
+		FluxStatus: FluxStatus{
+			Kind:      kustomizev1.KustomizationKind,
+			Name:      k.Name,
+			Namespace: k.Namespace,
+			State:     state,
+			Revision:  k.Status.LastAppliedRevision,
+			Reason:    reason,
+			Message:   message,
+		},
+		SourceKind:      k.Spec.SourceRef.Kind,
+		SourceName:      k.Spec.SourceRef.Name,
+		SourceNamespace: k.Spec.SourceRef.Namespace,
+	}
+	if status.SourceNamespace == "" {
+		status.SourceNamespace = k.Namespace
+	}
+	if reason == dependencyNotReadyReason {
+		status.BlockedBy = message
+	}
+	return status
+}
+
+// HelmReleaseStatus builds the FluxStatus of a HelmRelease, as returned by
+// GetHelmReleasesForKustomization.
+func HelmReleaseStatus(hr helmv2.HelmRelease) FluxStatus {
+	state, reason, message := fluxState(hr.Status.Conditions, hr.Spec.Suspend)
+	return FluxStatus{
+		Kind:      helmv2.HelmReleaseKind,
+		Name:      hr.Name,
+		Namespace: hr.Namespace,
+		State:     state,
+		Revision:  hr.Status.LastAttemptedRevision,
+		Reason:    reason,
+		Message:   message,
+	}
+}
@@ -0,0 +1,253 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestFluxState(t *testing.T) {
+	cond := func(typ string, status metav1.ConditionStatus, reason string) metav1.Condition {
+		return metav1.Condition{Type: typ, Status: status, Reason: reason, Message: reason + " message"}
+	}
+
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		suspended  bool
+		want       string
+	}{
+		{"SuspendedWinsOverReady", []metav1.Condition{cond("Ready", metav1.ConditionTrue, "Succeeded")}, true, FluxStateSuspended},
+		{"MissingReadyIsReconciling", nil, false, FluxStateReconciling},
+		{"ReadyTrueIsReady", []metav1.Condition{cond("Ready", metav1.ConditionTrue, "Succeeded")}, false, FluxStateReady},
+		{"ReadyUnknownIsReconciling", []metav1.Condition{cond("Ready", metav1.ConditionUnknown, "Progressing")}, false, FluxStateReconciling},
+		{"DependencyWaitIsReconciling", []metav1.Condition{cond("Ready", metav1.ConditionFalse, dependencyNotReadyReason)}, false, FluxStateReconciling},
+		{"ReconcilingTrueIsReconciling", []metav1.Condition{
+			cond("Ready", metav1.ConditionFalse, "ProgressingWithRetry"),
+			cond("Reconciling", metav1.ConditionTrue, "Progressing"),
+		}, false, FluxStateReconciling},
+		{"ReadyFalseIsFailed", []metav1.Condition{cond("Ready", metav1.ConditionFalse, "BuildFailed")}, false, FluxStateFailed},
+		{"StalledIsFailed", []metav1.Condition{
+			cond("Ready", metav1.ConditionUnknown, "Progressing"),
+			cond("Stalled", metav1.ConditionTrue, "InvalidPath"),
+		}, false, FluxStateFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given conditions and a suspend flag
+			// When the state is derived
+			state, _, _ := fluxState(tt.conditions, tt.suspended)
+
+			// Then it matches the expected state
+			if state != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, state)
+			}
+		})
+	}
+}
+
+func TestBaseKubernetesManager_GetKustomizationStatuses(t *testing.T) {
+	t.Run("ReportsStatesInRequestedOrderWithMissingAsNotFound", func(t *testing.T) {
+		// Given a cluster with one ready and one dependency-blocked kustomization
+		mocks := setupKubernetesMocks(t)
+		mocks.KubernetesClient.(*client.MockKubernetesClient).ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+			return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				{Object: map[string]any{
+					"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+					"kind":       "Kustomization",
+					"metadata":   map[string]any{"name": "crds", "namespace": "system-gitops"},
+					"spec":       map[string]any{"sourceRef": map[string]any{"kind": "OCIRepository", "name": "core"}},
+					"status": map[string]any{
+						"lastAppliedRevision": "v1.0.0@sha256:abc",
+						"conditions": []any{map[string]any{
+							"type": "Ready", "status": "True", "reason": "ReconciliationSucceeded",
+							"lastTransitionTime": "2026-01-01T00:00:00Z",
+						}},
+					},
+				}},
+				{Object: map[string]any{
+					"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+					"kind":       "Kustomization",
+					"metadata":   map[string]any{"name": "ingress", "namespace": "system-gitops"},
+					"spec":       map[string]any{"sourceRef": map[string]any{"kind": "GitRepository", "name": "local", "namespace": "flux"}},
+					"status": map[string]any{
+						"conditions": []any{map[string]any{
+							"type": "Ready", "status": "False", "reason": "DependencyNotReady",
+							"message": "dependency 'system-gitops/crds' is not ready", "lastTransitionTime": "2026-01-01T00:00:00Z",
+						}},
+					},
+				}},
+			}}, nil
+		}
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When statuses are requested for three names
+		statuses, err := manager.GetKustomizationStatuses([]string{"ingress", "crds", "dns"}, nil)
+
+		// Then each is reported in order, with its source and blocked reason, and the absent one as NotFound
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(statuses) != 3 {
+			t.Fatalf("Expected 3 statuses, got %d", len(statuses))
+		}
+		if statuses[0].State != FluxStateReconciling || statuses[0].BlockedBy != "dependency 'system-gitops/crds' is not ready" {
+			t.Errorf("Expected ingress blocked on crds, got %+v", statuses[0])
+		}
+		if statuses[0].SourceNamespace != "flux" {
+			t.Errorf("Expected explicit source namespace, got %q", statuses[0].SourceNamespace)
+		}
+		if statuses[1].State != FluxStateReady || statuses[1].Revision != "v1.0.0@sha256:abc" {
+			t.Errorf("Expected crds Ready at its revision, got %+v", statuses[1])
+		}
+		if statuses[1].SourceKind != "OCIRepository" || statuses[1].SourceNamespace != "system-gitops" {
+			t.Errorf("Expected crds source defaulted to its own namespace, got %+v", statuses[1])
+		}
+		if statuses[2].State != FluxStateNotFound {
+			t.Errorf("Expected dns NotFound, got %s", statuses[2].State)
+		}
+	})
+
+	t.Run("ResolvesEachNameInItsNamespace", func(t *testing.T) {
+		// Given a kustomization in a tenant namespace and a same-named one in the gitops namespace
+		mocks := setupKubernetesMocks(t)
+		var listed []string
+		mocks.KubernetesClient.(*client.MockKubernetesClient).ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+			listed = append(listed, namespace)
+			state := "False"
+			if namespace == "team-a" {
+				state = "True"
+			}
+			return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				{Object: map[string]any{
+					"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+					"kind":       "Kustomization",
+					"metadata":   map[string]any{"name": "apps", "namespace": namespace},
+					"status": map[string]any{
+						"conditions": []any{map[string]any{
+							"type": "Ready", "status": state, "reason": "ReconciliationSucceeded",
+							"lastTransitionTime": "2026-01-01T00:00:00Z",
+						}},
+					},
+				}},
+			}}, nil
+		}
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When the status and readiness of apps are requested with its namespace
+		namespaces := map[string]string{"apps": "team-a"}
+		statuses, err := manager.GetKustomizationStatuses([]string{"apps", "dns"}, namespaces)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		readiness, err := manager.GetKustomizationReadiness([]string{"apps"}, namespaces)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then apps is read from its own namespace and dns from the gitops namespace
+		if statuses[0].Namespace != "team-a" || statuses[0].State != FluxStateReady {
+			t.Errorf("Expected apps Ready in team-a, got %+v", statuses[0])
+		}
+		if statuses[1].State != FluxStateNotFound || statuses[1].Namespace != "system-gitops" {
+			t.Errorf("Expected dns NotFound in the gitops namespace, got %+v", statuses[1])
+		}
+		if !readiness["apps"] {
+			t.Errorf("Expected apps ready in team-a, got %v", readiness)
+		}
+		if !slices.Contains(listed, "team-a") || !slices.Contains(listed, "system-gitops") {
+			t.Errorf("Expected both namespaces listed, got %v", listed)
+		}
+	})
+
+	t.Run("ListError", func(t *testing.T) {
+		// Given a client whose list call fails
+		mocks := setupKubernetesMocks(t)
+		mocks.KubernetesClient.(*client.MockKubernetesClient).ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When statuses are requested
+		_, err := manager.GetKustomizationStatuses([]string{"crds"}, nil)
+
+		// Then the error is returned
+		if err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Errorf("Expected list error, got %v", err)
+		}
+	})
+}
+
+func TestBaseKubernetesManager_GetSourceStatus(t *testing.T) {
+	t.Run("ReportsArtifactRevision", func(t *testing.T) {
+		// Given a ready OCIRepository with an artifact
+		mocks := setupKubernetesMocks(t)
+		var gotGVR schema.GroupVersionResource
+		mocks.KubernetesClient.(*client.MockKubernetesClient).GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+			gotGVR = gvr
+			return &unstructured.Unstructured{Object: map[string]any{
+				"status": map[string]any{
+					"artifact": map[string]any{"revision": "v0.6.0@sha256:def"},
+					"conditions": []any{map[string]any{
+						"type": "Ready", "status": "True", "reason": "Succeeded", "lastTransitionTime": "2026-01-01T00:00:00Z",
+					}},
+				},
+			}}, nil
+		}
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When its status is read
+		status, err := manager.GetSourceStatus("OCIRepository", "core", "system-gitops")
+
+		// Then it is Ready at the artifact revision, read from the ocirepositories resource
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotGVR.Resource != "ocirepositories" {
+			t.Errorf("Expected ocirepositories, got %s", gotGVR.Resource)
+		}
+		if status.State != FluxStateReady || status.Revision != "v0.6.0@sha256:def" {
+			t.Errorf("Expected Ready at artifact revision, got %+v", status)
+		}
+	})
+
+	t.Run("MissingSourceIsNotFound", func(t *testing.T) {
+		// Given a source absent from the cluster
+		mocks := setupKubernetesMocks(t)
+		mocks.KubernetesClient.(*client.MockKubernetesClient).GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+			return nil, fmt.Errorf(`gitrepositories.source.toolkit.fluxcd.io "local" not found`)
+		}
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When its status is read
+		status, err := manager.GetSourceStatus("GitRepository", "local", "system-gitops")
+
+		// Then it is reported NotFound without error
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if status.State != FluxStateNotFound {
+			t.Errorf("Expected NotFound, got %s", status.State)
+		}
+	})
+
+	t.Run("UnsupportedKind", func(t *testing.T) {
+		// Given a manager
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(mocks.KubernetesClient, mocks.ConfigHandler)
+
+		// When the status of an unknown source kind is read
+		_, err := manager.GetSourceStatus("ExternalArtifact", "x", "system-gitops")
+
+		// Then an error names the kind
+		if err == nil || !strings.Contains(err.Error(), "ExternalArtifact") {
+			t.Errorf("Expected unsupported kind error, got %v", err)
+		}
+	})
+}
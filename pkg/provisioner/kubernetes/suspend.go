@@ -0,0 +1,210 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file suspends and resumes a blueprint's flux Kustomizations together with the
+// HelmReleases they own, so an operator can stop flux from reverting a manual fix during
+// an incident. Each suspension windsor makes is recorded on the object in an annotation
+// naming who suspended it, why and when, which apply and install read back to refuse
+// reconciling over a suspension nobody has lifted.
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+const (
+	// SuspensionAnnotation holds the JSON-encoded Suspension windsor records on a flux object
+	// it suspends. It is removed again on resume.
+	SuspensionAnnotation = "windsorcli.dev/suspension"
+
+	// fluxOwnerNameLabel and fluxOwnerNamespaceLabel are set by kustomize-controller on every
+	// object it applies, naming the Kustomization that owns it.
+	fluxOwnerNameLabel      = "kustomize.toolkit.fluxcd.io/name"
+	fluxOwnerNamespaceLabel = "kustomize.toolkit.fluxcd.io/namespace"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// Suspension records who suspended a flux object, why, and when.
+type Suspension struct {
+	By     string    `json:"by"`
+	Reason string    `json:"reason,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// SuspendedObject is a suspended flux Kustomization or HelmRelease. Kustomization names the
+// blueprint kustomization it belongs to: itself for a Kustomization, its owner for a
+// HelmRelease. Suspension is nil when the object was suspended outside windsor, for example
+// with `flux suspend`.
+type SuspendedObject struct {
+	Kind          string
+	Namespace     string
+	Name          string
+	Kustomization string
+	Suspension    *Suspension
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// SuspendKustomization sets spec.suspend on the named Kustomization and on every HelmRelease in
+// its inventory, recording suspension in SuspensionAnnotation on each. The Kustomization is
+// suspended first so it cannot re-apply its HelmReleases mid-way. Returns the objects suspended,
+// which is none when the Kustomization is not on the cluster, or an error when a patch fails.
+func (k *BaseKubernetesManager) SuspendKustomization(name, namespace string, suspension Suspension) ([]SuspendedObject, error) {
+	kustomizationsGVR := schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
+	helmReleasesGVR := schema.GroupVersionResource{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"}
+	record, err := json.Marshal(suspension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode suspension: %w", err)
+	}
+	patch := fmt.Appendf(nil, `{"metadata":{"annotations":{%q:%q}},"spec":{"suspend":true}}`, SuspensionAnnotation, record)
+
+	if err := k.patchFluxObject(kustomizationsGVR, namespace, name, patch); err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to suspend kustomization %s: %w", name, err)
+	}
+	suspended := []SuspendedObject{{Kind: kustomizev1.KustomizationKind, Namespace: namespace, Name: name, Kustomization: name, Suspension: &suspension}}
+
+	hrs, err := k.GetHelmReleasesForKustomization(name, namespace)
+	if err != nil {
+		return suspended, err
+	}
+	for _, hr := range hrs {
+		if err := k.patchFluxObject(helmReleasesGVR, hr.Namespace, hr.Name, patch); err != nil {
+			return suspended, fmt.Errorf("failed to suspend helmrelease %s/%s: %w", hr.Namespace, hr.Name, err)
+		}
+		suspended = append(suspended, SuspendedObject{Kind: helmv2.HelmReleaseKind, Namespace: hr.Namespace, Name: hr.Name, Kustomization: name, Suspension: &suspension})
+	}
+	return suspended, nil
+}
+
+// ResumeKustomization clears spec.suspend and SuspensionAnnotation on the named Kustomization
+// and on every suspended HelmRelease in its inventory. HelmReleases are resumed first so that
+// when the Kustomization reconciles again the releases it re-applies are already live. Returns
+// the objects that were suspended and are now resumed; a Kustomization absent from the cluster
+// resumes nothing.
+func (k *BaseKubernetesManager) ResumeKustomization(name, namespace string) ([]SuspendedObject, error) {
+	kustomizationsGVR := schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
+	helmReleasesGVR := schema.GroupVersionResource{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"}
+	patch := fmt.Appendf(nil, `{"metadata":{"annotations":{%q:null}},"spec":{"suspend":false}}`, SuspensionAnnotation)
+
+	hrs, err := k.GetHelmReleasesForKustomization(name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var resumed []SuspendedObject
+	for _, hr := range hrs {
+		if !hr.Spec.Suspend {
+			continue
+		}
+		if err := k.patchFluxObject(helmReleasesGVR, hr.Namespace, hr.Name, patch); err != nil {
+			return resumed, fmt.Errorf("failed to resume helmrelease %s/%s: %w", hr.Namespace, hr.Name, err)
+		}
+		resumed = append(resumed, SuspendedObject{Kind: helmv2.HelmReleaseKind, Namespace: hr.Namespace, Name: hr.Name, Kustomization: name, Suspension: suspensionFrom(hr.Annotations)})
+	}
+
+	obj, err := k.client.GetResource(kustomizationsGVR, namespace, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			return resumed, nil
+		}
+		return resumed, fmt.Errorf("failed to get kustomization %s: %w", name, err)
+	}
+	if suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); !suspended {
+		return resumed, nil
+	}
+	if err := k.patchFluxObject(kustomizationsGVR, namespace, name, patch); err != nil {
+		return resumed, fmt.Errorf("failed to resume kustomization %s: %w", name, err)
+	}
+	resumed = append([]SuspendedObject{{Kind: kustomizev1.KustomizationKind, Namespace: namespace, Name: name, Kustomization: name, Suspension: suspensionFrom(obj.GetAnnotations())}}, resumed...)
+	return resumed, nil
+}
+
+// GetSuspendedObjects returns the suspended Kustomizations among names and the suspended
+// HelmReleases those Kustomizations own, found through the owner labels kustomize-controller
+// stamps on what it applies. Each name is resolved in its namespace from namespaces, falling
+// back to the gitops namespace. Kustomizations come first, in the order of names, followed by
+// HelmReleases.
+func (k *BaseKubernetesManager) GetSuspendedObjects(names []string, namespaces map[string]string) ([]SuspendedObject, error) {
+	helmReleasesGVR := schema.GroupVersionResource{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"}
+	byName, err := k.listNamedKustomizations(names, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	var suspended []SuspendedObject
+	for _, name := range names {
+		obj, ok := byName[name]
+		if !ok {
+			continue
+		}
+		if s, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); s {
+			suspended = append(suspended, SuspendedObject{Kind: kustomizev1.KustomizationKind, Namespace: obj.GetNamespace(), Name: name, Kustomization: name, Suspension: suspensionFrom(obj.GetAnnotations())})
+		}
+	}
+
+	releases, err := k.client.ListResources(helmReleasesGVR, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list helmreleases: %w", err)
+	}
+	for _, obj := range releases.Items {
+		labels := obj.GetLabels()
+		owner := labels[fluxOwnerNameLabel]
+		if !slices.Contains(names, owner) || labels[fluxOwnerNamespaceLabel] != k.kustomizationNamespace(owner, namespaces) {
+			continue
+		}
+		if s, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); s {
+			suspended = append(suspended, SuspendedObject{Kind: helmv2.HelmReleaseKind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Kustomization: owner, Suspension: suspensionFrom(obj.GetAnnotations())})
+		}
+	}
+	return suspended, nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// patchFluxObject merge-patches a flux object as windsor-cli.
+func (k *BaseKubernetesManager) patchFluxObject(gvr schema.GroupVersionResource, namespace, name string, patch []byte) error {
+	opts := metav1.PatchOptions{FieldManager: "windsor-cli"}
+	_, err := k.client.PatchResource(context.Background(), gvr, namespace, name, types.MergePatchType, patch, opts)
+	return err
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// suspensionFrom decodes the Suspension recorded in annotations, or returns nil when there is
+// none or it does not decode.
+func suspensionFrom(annotations map[string]string) *Suspension {
+	raw, ok := annotations[SuspensionAnnotation]
+	if !ok {
+		return nil
+	}
+	var s Suspension
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		return nil
+	}
+	return &s
+}
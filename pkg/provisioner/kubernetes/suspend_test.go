@@ -0,0 +1,265 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// suspendClient returns a mock client serving kustomization "dns" in system-gitops, whose
+// inventory holds HelmRelease dns/coredns, and records every patch it receives by resource
+// and name. suspended sets spec.suspend and the windsor record on both objects.
+func suspendClient(t *testing.T, suspended bool, patches map[string]string) *client.MockKubernetesClient {
+	t.Helper()
+	return suspendClientIn(t, "system-gitops", suspended, patches)
+}
+
+// suspendClientIn is suspendClient with kustomization "dns" in namespace. Kustomizations are
+// only served from their own namespace, so a lookup in the wrong namespace finds nothing.
+func suspendClientIn(t *testing.T, namespace string, suspended bool, patches map[string]string) *client.MockKubernetesClient {
+	t.Helper()
+	record, _ := json.Marshal(Suspension{By: "alice@laptop", Reason: "hotfix", At: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)})
+	metadata := func(name, namespace string, labels map[string]any) map[string]any {
+		meta := map[string]any{"name": name, "namespace": namespace, "labels": labels}
+		if suspended {
+			meta["annotations"] = map[string]any{SuspensionAnnotation: string(record)}
+		}
+		return meta
+	}
+	kustomization := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   metadata("dns", namespace, nil),
+		"spec":       map[string]any{"suspend": suspended},
+		"status": map[string]any{"inventory": map[string]any{"entries": []any{
+			map[string]any{"id": "dns_coredns_helm.toolkit.fluxcd.io_HelmRelease", "v": "v2"},
+		}}},
+	}}
+	release := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2",
+		"kind":       "HelmRelease",
+		"metadata": metadata("coredns", "dns", map[string]any{
+			fluxOwnerNameLabel:      "dns",
+			fluxOwnerNamespaceLabel: namespace,
+		}),
+		"spec": map[string]any{"suspend": suspended},
+	}}
+	other := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2",
+		"kind":       "HelmRelease",
+		"metadata":   metadata("grafana", "monitoring", map[string]any{fluxOwnerNameLabel: "monitoring", fluxOwnerNamespaceLabel: "system-gitops"}),
+		"spec":       map[string]any{"suspend": true},
+	}}
+
+	mockClient := client.NewMockKubernetesClient()
+	mockClient.GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+		switch {
+		case gvr.Resource == "kustomizations" && name == "dns" && namespace == kustomization.GetNamespace():
+			obj := kustomization.DeepCopy()
+			return obj, nil
+		case gvr.Resource == "helmreleases" && name == "coredns":
+			obj := release.DeepCopy()
+			return obj, nil
+		}
+		return nil, fmt.Errorf("%s %q not found", gvr.Resource, name)
+	}
+	mockClient.ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+		switch gvr.Resource {
+		case "kustomizations":
+			if namespace != "" && namespace != kustomization.GetNamespace() {
+				return &unstructured.UnstructuredList{}, nil
+			}
+			return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{kustomization}}, nil
+		case "helmreleases":
+			return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{release, other}}, nil
+		}
+		return &unstructured.UnstructuredList{}, nil
+	}
+	mockClient.PatchResourceFunc = func(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (*unstructured.Unstructured, error) {
+		if gvr.Resource == "kustomizations" && (name != "dns" || namespace != kustomization.GetNamespace()) {
+			return nil, fmt.Errorf("kustomizations %q not found", name)
+		}
+		patches[gvr.Resource+"/"+name] = string(data)
+		return nil, nil
+	}
+	return mockClient
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestBaseKubernetesManager_SuspendKustomization(t *testing.T) {
+	t.Run("SuspendsKustomizationAndHelmReleases", func(t *testing.T) {
+		// Given a kustomization owning one HelmRelease
+		mocks := setupKubernetesMocks(t)
+		patches := make(map[string]string)
+		manager := NewKubernetesManager(suspendClient(t, false, patches), mocks.ConfigHandler)
+		suspension := Suspension{By: "bob@desk", Reason: "incident 42", At: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+		// When the kustomization is suspended
+		objs, err := manager.SuspendKustomization("dns", "system-gitops", suspension)
+
+		// Then both objects are patched suspended with the suspension recorded
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(objs) != 2 || objs[0].Kind != "Kustomization" || objs[1].Name != "coredns" {
+			t.Fatalf("Expected the kustomization then its HelmRelease, got %+v", objs)
+		}
+		for _, key := range []string{"kustomizations/dns", "helmreleases/coredns"} {
+			var patch struct {
+				Metadata struct {
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+				Spec struct {
+					Suspend bool `json:"suspend"`
+				} `json:"spec"`
+			}
+			if err := json.Unmarshal([]byte(patches[key]), &patch); err != nil {
+				t.Fatalf("Expected a JSON patch for %s, got %q", key, patches[key])
+			}
+			got := suspensionFrom(patch.Metadata.Annotations)
+			if !patch.Spec.Suspend || got == nil || *got != suspension {
+				t.Errorf("Expected %s suspended with %+v, got %s", key, suspension, patches[key])
+			}
+		}
+	})
+
+	t.Run("SuspendsKustomizationOutsideGitopsNamespace", func(t *testing.T) {
+		// Given a kustomization that lives in its own namespace rather than the gitops namespace
+		mocks := setupKubernetesMocks(t)
+		patches := make(map[string]string)
+		manager := NewKubernetesManager(suspendClientIn(t, "team-dns", false, patches), mocks.ConfigHandler)
+
+		// When it is suspended in that namespace
+		objs, err := manager.SuspendKustomization("dns", "team-dns", Suspension{By: "bob@desk"})
+
+		// Then the kustomization and its HelmRelease are patched
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(objs) != 2 || objs[0].Namespace != "team-dns" {
+			t.Errorf("Expected dns in team-dns and its HelmRelease, got %+v", objs)
+		}
+		if patches["kustomizations/dns"] == "" || patches["helmreleases/coredns"] == "" {
+			t.Errorf("Expected both objects patched, got %v", patches)
+		}
+	})
+
+	t.Run("SuspendsNothingWhenAbsent", func(t *testing.T) {
+		// Given a kustomization that is not on the cluster
+		mocks := setupKubernetesMocks(t)
+		patches := make(map[string]string)
+		manager := NewKubernetesManager(suspendClient(t, false, patches), mocks.ConfigHandler)
+
+		// When it is suspended
+		objs, err := manager.SuspendKustomization("ingress", "system-gitops", Suspension{By: "bob@desk"})
+
+		// Then nothing is suspended and no error is returned
+		if err != nil || len(objs) != 0 {
+			t.Errorf("Expected nothing suspended, got %+v, %v", objs, err)
+		}
+	})
+}
+
+func TestBaseKubernetesManager_ResumeKustomization(t *testing.T) {
+	t.Run("ResumesSuspendedObjects", func(t *testing.T) {
+		// Given a suspended kustomization and HelmRelease
+		mocks := setupKubernetesMocks(t)
+		patches := make(map[string]string)
+		manager := NewKubernetesManager(suspendClient(t, true, patches), mocks.ConfigHandler)
+
+		// When the kustomization is resumed
+		objs, err := manager.ResumeKustomization("dns", "system-gitops")
+
+		// Then both are unsuspended with the record cleared, and the previous record is returned
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(objs) != 2 || objs[0].Kind != "Kustomization" || objs[0].Suspension == nil || objs[0].Suspension.By != "alice@laptop" {
+			t.Fatalf("Expected the kustomization then its HelmRelease with their records, got %+v", objs)
+		}
+		want := `{"metadata":{"annotations":{"windsorcli.dev/suspension":null}},"spec":{"suspend":false}}`
+		for _, key := range []string{"kustomizations/dns", "helmreleases/coredns"} {
+			if patches[key] != want {
+				t.Errorf("Expected %s patched with %s, got %q", key, want, patches[key])
+			}
+		}
+	})
+
+	t.Run("LeavesUnsuspendedObjectsAlone", func(t *testing.T) {
+		// Given a kustomization that is not suspended
+		mocks := setupKubernetesMocks(t)
+		patches := make(map[string]string)
+		manager := NewKubernetesManager(suspendClient(t, false, patches), mocks.ConfigHandler)
+
+		// When it is resumed
+		objs, err := manager.ResumeKustomization("dns", "system-gitops")
+
+		// Then nothing is patched
+		if err != nil || len(objs) != 0 || len(patches) != 0 {
+			t.Errorf("Expected nothing resumed, got %+v, %v, patches %v", objs, err, patches)
+		}
+	})
+}
+
+func TestBaseKubernetesManager_GetSuspendedObjects(t *testing.T) {
+	t.Run("ReportsSuspendedObjectsOfNamedKustomizations", func(t *testing.T) {
+		// Given a suspended dns kustomization and HelmRelease, and a suspended release owned elsewhere
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(suspendClient(t, true, map[string]string{}), mocks.ConfigHandler)
+
+		// When suspended objects are listed for dns
+		objs, err := manager.GetSuspendedObjects([]string{"dns"}, nil)
+
+		// Then only dns's objects are reported, with their suspension records
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(objs) != 2 {
+			t.Fatalf("Expected 2 suspended objects, got %+v", objs)
+		}
+		if objs[1].Kind != "HelmRelease" || objs[1].Kustomization != "dns" || objs[1].Suspension == nil || objs[1].Suspension.Reason != "hotfix" {
+			t.Errorf("Expected coredns attributed to dns with its record, got %+v", objs[1])
+		}
+	})
+
+	t.Run("ReportsKustomizationOutsideGitopsNamespace", func(t *testing.T) {
+		// Given a suspended dns kustomization that lives in team-dns rather than the gitops namespace
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(suspendClientIn(t, "team-dns", true, map[string]string{}), mocks.ConfigHandler)
+
+		// When suspended objects are listed with dns mapped to team-dns
+		objs, err := manager.GetSuspendedObjects([]string{"dns"}, map[string]string{"dns": "team-dns"})
+
+		// Then the kustomization and the HelmRelease it owns are both found there
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(objs) != 2 || objs[0].Namespace != "team-dns" || objs[1].Name != "coredns" {
+			t.Errorf("Expected dns in team-dns and coredns, got %+v", objs)
+		}
+	})
+
+	t.Run("ReportsNothingWhenNoneSuspended", func(t *testing.T) {
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(suspendClient(t, false, map[string]string{}), mocks.ConfigHandler)
+		objs, err := manager.GetSuspendedObjects([]string{"dns"}, nil)
+		if err != nil || len(objs) != 0 {
+			t.Errorf("Expected no suspended objects, got %+v, %v", objs, err)
+		}
+	})
+}
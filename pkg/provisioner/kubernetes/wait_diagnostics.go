@@ -0,0 +1,394 @@
+// Package kubernetes provides Kubernetes resource management functionality.
+// This file collects the diagnostics WaitForKustomizations surfaces while it waits:
+// Warning events on the kustomizations it is waiting on, their HelmReleases and the
+// objects in their inventory (including the pods those workloads own), and, when the
+// wait times out, a digest naming the deepest failing kustomization in each stuck
+// dependency chain.
+
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// digestEventLimit caps the Warning events listed under each root cause in the timeout digest.
+const digestEventLimit = 5
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// warningEvent is a Kubernetes Warning event on an object a wait is watching, attributed to
+// the kustomization that owns the object.
+type warningEvent struct {
+	uid           string
+	kustomization string
+	kind          string
+	namespace     string
+	name          string
+	reason        string
+	message       string
+	count         int32
+	lastSeen      time.Time
+}
+
+// waitDiagnostics is the state a single WaitForKustomizations call accumulates between scans.
+// seen holds the UIDs of events already surfaced so each is reported once; the remaining fields
+// are the snapshot from the most recent scan, which the timeout digest reads instead of going
+// back to a cluster that may already be slow to answer.
+type waitDiagnostics struct {
+	start        time.Time
+	seen         map[string]bool
+	scanned      bool
+	statuses     map[string]KustomizationStatus
+	helmReleases map[string][]FluxStatus
+	events       map[string][]warningEvent
+}
+
+// eventScope maps the objects owned by the kustomizations still being waited on back to the
+// kustomization that owns each: exact objects by "Kind/namespace/name", workloads by namespace
+// so the pods and replica sets they spawn can be matched by name prefix, and the target
+// namespaces of their HelmReleases, whose contents flux does not inventory.
+type eventScope struct {
+	objects        map[string]string
+	workloads      map[string][]scopedWorkload
+	helmNamespaces map[string]string
+	namespaces     []string
+}
+
+// scopedWorkload is an inventoried workload and the kustomization that owns it.
+type scopedWorkload struct {
+	name  string
+	owner string
+}
+
+// =============================================================================
+// Constructor
+// =============================================================================
+
+// newWaitDiagnostics returns the diagnostics state for a wait that began at start. Events last
+// seen before start are history and are not surfaced live.
+func newWaitDiagnostics(start time.Time) *waitDiagnostics {
+	return &waitDiagnostics{
+		start: start,
+		seen:  make(map[string]bool),
+	}
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// scanWaitDiagnostics refreshes d from the cluster and returns the Warning events first seen in
+// this scan, oldest first. Kustomizations already Ready drop out of the scope; for the rest it
+// reads their HelmReleases and inventory, then lists events in the gitops namespace and every
+// namespace those objects occupy. Each name is resolved in its namespace from namespaces.
+// Diagnostics are best effort: a failed read leaves that part of the snapshot empty rather than
+// failing the wait, and a failure to read the kustomizations themselves keeps the previous
+// snapshot. Like describeNotReadyKustomizations, total probing is capped at
+// waitDiagnosticsBudget; once it is spent the remaining HelmRelease, inventory and event reads
+// are skipped and the next scan tries again.
+func (k *BaseKubernetesManager) scanWaitDiagnostics(names []string, namespaces map[string]string, d *waitDiagnostics) []warningEvent {
+	start := time.Now()
+	statuses, err := k.GetKustomizationStatuses(names, namespaces)
+	if err != nil {
+		return nil
+	}
+
+	d.scanned = true
+	d.statuses = make(map[string]KustomizationStatus, len(statuses))
+	d.helmReleases = make(map[string][]FluxStatus)
+	d.events = make(map[string][]warningEvent)
+
+	scope := &eventScope{
+		objects:        make(map[string]string),
+		workloads:      make(map[string][]scopedWorkload),
+		helmNamespaces: make(map[string]string),
+	}
+	scope.addNamespace(k.gitopsNamespace())
+	for _, status := range statuses {
+		d.statuses[status.Name] = status
+		if status.State == FluxStateReady || status.State == FluxStateNotFound {
+			continue
+		}
+		scope.objects[eventObjectKey(kustomizev1.KustomizationKind, status.Namespace, status.Name)] = status.Name
+		scope.addNamespace(status.Namespace)
+		if time.Since(start) >= k.waitDiagnosticsBudget {
+			continue
+		}
+
+		if hrs, err := k.GetHelmReleasesForKustomization(status.Name, status.Namespace); err == nil {
+			for _, hr := range hrs {
+				d.helmReleases[status.Name] = append(d.helmReleases[status.Name], HelmReleaseStatus(hr))
+				scope.objects[eventObjectKey(helmv2.HelmReleaseKind, hr.Namespace, hr.Name)] = status.Name
+				scope.addNamespace(hr.Namespace)
+				target := hr.Spec.TargetNamespace
+				if target == "" {
+					target = hr.Namespace
+				}
+				if _, ok := scope.helmNamespaces[target]; !ok {
+					scope.helmNamespaces[target] = status.Name
+				}
+				scope.addNamespace(target)
+			}
+		}
+
+		if entries, err := k.GetKustomizationInventory(status.Name, status.Namespace); err == nil {
+			for _, entry := range entries {
+				if entry.Namespace == "" {
+					continue
+				}
+				scope.objects[eventObjectKey(entry.Kind, entry.Namespace, entry.Name)] = status.Name
+				if isWorkloadKind(entry.Kind) {
+					scope.workloads[entry.Namespace] = append(scope.workloads[entry.Namespace], scopedWorkload{name: entry.Name, owner: status.Name})
+				}
+				scope.addNamespace(entry.Namespace)
+			}
+		}
+	}
+
+	var fresh []warningEvent
+	for _, ns := range scope.namespaces {
+		if time.Since(start) >= k.waitDiagnosticsBudget {
+			break
+		}
+		for _, ev := range k.listWarningEvents(ns) {
+			owner := scope.owner(ev)
+			if owner == "" {
+				continue
+			}
+			ev.kustomization = owner
+			d.events[owner] = append(d.events[owner], ev)
+			if d.seen[ev.uid] || ev.lastSeen.Before(d.start) {
+				continue
+			}
+			d.seen[ev.uid] = true
+			fresh = append(fresh, ev)
+		}
+	}
+	for owner := range d.events {
+		slices.SortStableFunc(d.events[owner], func(a, b warningEvent) int { return b.lastSeen.Compare(a.lastSeen) })
+	}
+	slices.SortStableFunc(fresh, func(a, b warningEvent) int { return a.lastSeen.Compare(b.lastSeen) })
+	return fresh
+}
+
+// listWarningEvents returns the Warning events in namespace. A failed list or an event that
+// does not decode yields nothing for that event; diagnostics never fail the wait.
+func (k *BaseKubernetesManager) listWarningEvents(namespace string) []warningEvent {
+	gvr := schema.GroupVersionResource{
+		Group:    "",
+		Version:  "v1",
+		Resource: "events",
+	}
+	list, err := k.client.ListResources(gvr, namespace)
+	if err != nil || list == nil {
+		return nil
+	}
+	var events []warningEvent
+	for _, obj := range list.Items {
+		var ev corev1.Event
+		if err := k.shims.FromUnstructured(obj.UnstructuredContent(), &ev); err != nil {
+			continue
+		}
+		if ev.Type != corev1.EventTypeWarning {
+			continue
+		}
+		events = append(events, newWarningEvent(&ev))
+	}
+	return events
+}
+
+// owner returns the kustomization an event's object belongs to, or "" when the object is outside
+// the scope. An exact inventory, HelmRelease or Kustomization match wins; a Pod or ReplicaSet
+// is matched to the inventoried workload whose name prefixes its own; anything else in a
+// HelmRelease's target namespace belongs to that HelmRelease's kustomization.
+func (s *eventScope) owner(ev warningEvent) string {
+	if owner, ok := s.objects[eventObjectKey(ev.kind, ev.namespace, ev.name)]; ok {
+		return owner
+	}
+	if ev.kind == "Pod" || ev.kind == "ReplicaSet" {
+		owner, longest := "", 0
+		for _, w := range s.workloads[ev.namespace] {
+			if len(w.name) > longest && strings.HasPrefix(ev.name, w.name+"-") {
+				owner, longest = w.owner, len(w.name)
+			}
+		}
+		if owner != "" {
+			return owner
+		}
+	}
+	return s.helmNamespaces[ev.namespace]
+}
+
+// addNamespace records namespace as one whose events are listed, once.
+func (s *eventScope) addNamespace(namespace string) {
+	if namespace == "" || slices.Contains(s.namespaces, namespace) {
+		return
+	}
+	s.namespaces = append(s.namespaces, namespace)
+}
+
+// digest returns the root-cause digest appended to a wait timeout, or "" when no scan has
+// completed or every kustomization was Ready at the last scan. Each kustomization still not
+// Ready is followed down its dependsOn chain to the deepest dependency that is itself not
+// Ready; each such root is listed once with the condition that explains it, its HelmReleases
+// that are not Ready, and its most recent Warning events.
+func (d *waitDiagnostics) digest(kustomizations []blueprintv1alpha1.Kustomization) string {
+	if !d.scanned {
+		return ""
+	}
+	dependsOn := make(map[string][]string, len(kustomizations))
+	for _, k := range kustomizations {
+		for _, dep := range k.DependsOn {
+			dependsOn[k.Name] = append(dependsOn[k.Name], blueprintv1alpha1.KustomizationDependencyName(dep))
+		}
+	}
+	notReady := func(name string) bool {
+		status, ok := d.statuses[name]
+		return ok && status.State != FluxStateReady
+	}
+
+	var roots []string
+	for _, k := range kustomizations {
+		if !notReady(k.Name) {
+			continue
+		}
+		root := k.Name
+		visited := map[string]bool{root: true}
+		for {
+			next := ""
+			for _, dep := range dependsOn[root] {
+				if notReady(dep) && !visited[dep] {
+					next = dep
+					break
+				}
+			}
+			if next == "" {
+				break
+			}
+			visited[next] = true
+			root = next
+		}
+		if !slices.Contains(roots, root) {
+			roots = append(roots, root)
+		}
+	}
+	if len(roots) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, root := range roots {
+		status := d.statuses[root]
+		fmt.Fprintf(&b, "\nroot cause: kustomization %s %s%s", root, status.State, describeFluxReason(status.FluxStatus))
+		for _, hr := range d.helmReleases[root] {
+			if hr.State == FluxStateReady {
+				continue
+			}
+			fmt.Fprintf(&b, "\n  HelmRelease %s/%s %s%s", hr.Namespace, hr.Name, hr.State, describeFluxReason(hr))
+		}
+		events := d.events[root]
+		if len(events) > digestEventLimit {
+			events = events[:digestEventLimit]
+		}
+		for _, ev := range events {
+			fmt.Fprintf(&b, "\n  %s", formatWarningEvent(ev))
+		}
+	}
+	return b.String()
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// newWarningEvent converts a core Event into a warningEvent, taking its count and last-seen
+// time from the event series when the event uses the events.k8s.io series form.
+func newWarningEvent(ev *corev1.Event) warningEvent {
+	out := warningEvent{
+		uid:       string(ev.UID),
+		kind:      ev.InvolvedObject.Kind,
+		namespace: ev.InvolvedObject.Namespace,
+		name:      ev.InvolvedObject.Name,
+		reason:    ev.Reason,
+		message:   ev.Message,
+		count:     ev.Count,
+		lastSeen:  ev.LastTimestamp.Time,
+	}
+	if out.namespace == "" {
+		out.namespace = ev.Namespace
+	}
+	if out.lastSeen.IsZero() {
+		out.lastSeen = ev.EventTime.Time
+	}
+	if ev.Series != nil {
+		out.count = ev.Series.Count
+		if !ev.Series.LastObservedTime.IsZero() {
+			out.lastSeen = ev.Series.LastObservedTime.Time
+		}
+	}
+	if out.lastSeen.IsZero() {
+		out.lastSeen = ev.CreationTimestamp.Time
+	}
+	return out
+}
+
+// formatWarningEvent renders an event as "Kind ns/name Reason: message (xN)", keeping only the
+// first line of the message.
+func formatWarningEvent(ev warningEvent) string {
+	message := strings.TrimSpace(ev.message)
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		message = message[:i]
+	}
+	line := fmt.Sprintf("%s %s/%s %s: %s", ev.kind, ev.namespace, ev.name, ev.reason, message)
+	if ev.count > 1 {
+		line += fmt.Sprintf(" (x%d)", ev.count)
+	}
+	return line
+}
+
+// describeFluxReason returns " (reason: message)" for a status carrying a reason or message,
+// or "" when it carries neither.
+func describeFluxReason(s FluxStatus) string {
+	message := strings.TrimSpace(s.Message)
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		message = message[:i]
+	}
+	switch {
+	case s.Reason != "" && message != "":
+		return fmt.Sprintf(" (%s: %s)", s.Reason, message)
+	case message != "":
+		return fmt.Sprintf(" (%s)", message)
+	case s.Reason != "":
+		return fmt.Sprintf(" (%s)", s.Reason)
+	}
+	return ""
+}
+
+// eventObjectKey returns the "Kind/namespace/name" key eventScope indexes objects by.
+func eventObjectKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// isWorkloadKind reports whether kind spawns pods whose names are prefixed by its own.
+func isWorkloadKind(kind string) bool {
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job", "CronJob":
+		return true
+	}
+	return false
+}
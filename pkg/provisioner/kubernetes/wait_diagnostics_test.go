@@ -0,0 +1,290 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// waitDiagnosticsClient returns a mock client serving a cluster where kustomization "crds" is
+// Ready and "apps" has failed. apps inventories Deployment web/web and HelmRelease apps/podinfo,
+// which installs into namespace podinfo. Warning events exist on a web pod, on an unrelated pod
+// in the same namespace, on the apps Kustomization, and in the podinfo namespace.
+func waitDiagnosticsClient(t *testing.T, eventTime time.Time) *client.MockKubernetesClient {
+	t.Helper()
+	ts := eventTime.UTC().Format(time.RFC3339)
+	kustomization := func(name, status, reason, message string, inventory []any) unstructured.Unstructured {
+		obj := map[string]any{
+			"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+			"kind":       "Kustomization",
+			"metadata":   map[string]any{"name": name, "namespace": "system-gitops"},
+			"status": map[string]any{
+				"conditions": []any{map[string]any{
+					"type": "Ready", "status": status, "reason": reason, "message": message, "lastTransitionTime": ts,
+				}},
+			},
+		}
+		if inventory != nil {
+			obj["status"].(map[string]any)["inventory"] = map[string]any{"entries": inventory}
+		}
+		return unstructured.Unstructured{Object: obj}
+	}
+	appsInventory := []any{
+		map[string]any{"id": "web_web_apps_Deployment", "v": "v1"},
+		map[string]any{"id": "apps_podinfo_helm.toolkit.fluxcd.io_HelmRelease", "v": "v2"},
+	}
+	apps := kustomization("apps", "False", "HealthCheckFailed", "timeout waiting for web", appsInventory)
+	event := func(uid, kind, namespace, name, eventType, reason, message string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{
+			"apiVersion":     "v1",
+			"kind":           "Event",
+			"metadata":       map[string]any{"name": uid, "namespace": namespace, "uid": uid},
+			"involvedObject": map[string]any{"kind": kind, "namespace": namespace, "name": name},
+			"type":           eventType,
+			"reason":         reason,
+			"message":        message,
+			"count":          int64(3),
+			"lastTimestamp":  ts,
+		}}
+	}
+	events := map[string][]unstructured.Unstructured{
+		"system-gitops": {
+			event("e1", "Kustomization", "system-gitops", "apps", "Warning", "HealthCheckFailed", "timeout waiting for web"),
+			event("e2", "Kustomization", "system-gitops", "crds", "Normal", "ReconciliationSucceeded", "applied"),
+		},
+		"web": {
+			event("e3", "Pod", "web", "web-7d9f8-abcde", "Warning", "Failed", `Failed to pull image "web:v9": not found`),
+			event("e4", "Pod", "web", "batch-1-xyz", "Warning", "BackOff", "unrelated"),
+		},
+		"podinfo": {
+			event("e5", "Pod", "podinfo", "podinfo-0", "Warning", "FailedScheduling", "0/1 nodes are available"),
+		},
+	}
+
+	mockClient := client.NewMockKubernetesClient()
+	mockClient.ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+		switch gvr.Resource {
+		case "kustomizations":
+			return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				kustomization("crds", "True", "ReconciliationSucceeded", "applied", nil),
+				apps,
+			}}, nil
+		case "events":
+			return &unstructured.UnstructuredList{Items: events[namespace]}, nil
+		}
+		return &unstructured.UnstructuredList{}, nil
+	}
+	mockClient.GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+		switch gvr.Resource {
+		case "kustomizations":
+			obj := apps
+			return &obj, nil
+		case "helmreleases":
+			return &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "helm.toolkit.fluxcd.io/v2",
+				"kind":       "HelmRelease",
+				"metadata":   map[string]any{"name": "podinfo", "namespace": "apps"},
+				"spec":       map[string]any{"targetNamespace": "podinfo"},
+				"status": map[string]any{
+					"conditions": []any{map[string]any{
+						"type": "Ready", "status": "False", "reason": "InstallFailed", "message": "install retries exhausted", "lastTransitionTime": ts,
+					}},
+				},
+			}}, nil
+		}
+		return nil, nil
+	}
+	return mockClient
+}
+
+// =============================================================================
+// Test Private Methods
+// =============================================================================
+
+func TestBaseKubernetesManager_scanWaitDiagnostics(t *testing.T) {
+	t.Run("SurfacesWarningsOnWatchedObjectsOnce", func(t *testing.T) {
+		// Given a failed kustomization with warnings on its objects, its pods and its helm namespace
+		mocks := setupKubernetesMocks(t)
+		start := time.Now().Add(-time.Minute)
+		manager := NewKubernetesManager(waitDiagnosticsClient(t, time.Now()), mocks.ConfigHandler)
+		d := newWaitDiagnostics(start)
+
+		// When the wait diagnostics are scanned twice
+		fresh := manager.scanWaitDiagnostics([]string{"crds", "apps"}, nil, d)
+		again := manager.scanWaitDiagnostics([]string{"crds", "apps"}, nil, d)
+
+		// Then the kustomization, web pod and podinfo warnings are surfaced once, attributed to apps
+		got := make(map[string]string)
+		for _, ev := range fresh {
+			got[ev.kind+"/"+ev.name] = ev.kustomization
+		}
+		for _, want := range []string{"Kustomization/apps", "Pod/web-7d9f8-abcde", "Pod/podinfo-0"} {
+			if got[want] != "apps" {
+				t.Errorf("Expected %s attributed to apps, got %v", want, got)
+			}
+		}
+		if _, ok := got["Pod/batch-1-xyz"]; ok {
+			t.Errorf("Expected the unrelated pod to be ignored, got %v", got)
+		}
+		if len(fresh) != 3 {
+			t.Errorf("Expected 3 warnings, got %d", len(fresh))
+		}
+		if len(again) != 0 {
+			t.Errorf("Expected no warnings on the second scan, got %d", len(again))
+		}
+	})
+
+	t.Run("StopsProbingOnceTheBudgetIsSpent", func(t *testing.T) {
+		// Given a failed kustomization and no budget left for probing
+		mocks := setupKubernetesMocks(t)
+		mockClient := waitDiagnosticsClient(t, time.Now())
+		var probes []string
+		listResources := mockClient.ListResourcesFunc
+		mockClient.ListResourcesFunc = func(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+			if gvr.Resource != "kustomizations" {
+				probes = append(probes, gvr.Resource+"/"+namespace)
+			}
+			return listResources(gvr, namespace)
+		}
+		getResource := mockClient.GetResourceFunc
+		mockClient.GetResourceFunc = func(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+			probes = append(probes, gvr.Resource+"/"+name)
+			return getResource(gvr, namespace, name)
+		}
+		manager := NewKubernetesManager(mockClient, mocks.ConfigHandler)
+		manager.waitDiagnosticsBudget = 0
+		d := newWaitDiagnostics(time.Now().Add(-time.Minute))
+
+		// When the wait diagnostics are scanned
+		fresh := manager.scanWaitDiagnostics([]string{"crds", "apps"}, nil, d)
+
+		// Then the statuses are recorded but nothing further is read
+		if len(probes) != 0 {
+			t.Errorf("Expected no probes past the kustomization list, got %v", probes)
+		}
+		if len(fresh) != 0 {
+			t.Errorf("Expected no warnings, got %d", len(fresh))
+		}
+		if d.statuses["apps"].State == "" {
+			t.Errorf("Expected the apps status recorded, got %+v", d.statuses)
+		}
+	})
+
+	t.Run("SkipsEventsFromBeforeTheWait", func(t *testing.T) {
+		// Given warnings last seen an hour before the wait began
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(waitDiagnosticsClient(t, time.Now().Add(-time.Hour)), mocks.ConfigHandler)
+		d := newWaitDiagnostics(time.Now())
+
+		// When the wait diagnostics are scanned
+		fresh := manager.scanWaitDiagnostics([]string{"crds", "apps"}, nil, d)
+
+		// Then nothing is surfaced live, but the snapshot still holds them for the digest
+		if len(fresh) != 0 {
+			t.Errorf("Expected no live warnings, got %d", len(fresh))
+		}
+		if len(d.events["apps"]) != 3 {
+			t.Errorf("Expected 3 warnings in the snapshot, got %d", len(d.events["apps"]))
+		}
+	})
+}
+
+func TestWaitDiagnostics_digest(t *testing.T) {
+	t.Run("NamesTheDeepestFailingDependency", func(t *testing.T) {
+		// Given ingress → dns → crds with crds failed, and an independent failed monitoring
+		status := func(name, state, reason, message string) KustomizationStatus {
+			return KustomizationStatus{FluxStatus: FluxStatus{Name: name, State: state, Reason: reason, Message: message}}
+		}
+		d := newWaitDiagnostics(time.Now())
+		d.scanned = true
+		d.statuses = map[string]KustomizationStatus{
+			"base":       status("base", FluxStateReady, "", ""),
+			"crds":       status("crds", FluxStateFailed, "BuildFailed", "kustomize build failed"),
+			"dns":        status("dns", FluxStateReconciling, "DependencyNotReady", "dependency 'crds' is not ready"),
+			"ingress":    status("ingress", FluxStateReconciling, "DependencyNotReady", "dependency 'dns' is not ready"),
+			"monitoring": status("monitoring", FluxStateFailed, "HealthCheckFailed", "timeout"),
+		}
+		d.helmReleases = map[string][]FluxStatus{
+			"monitoring": {
+				{Name: "grafana", Namespace: "monitoring", State: FluxStateFailed, Reason: "InstallFailed", Message: "install retries exhausted"},
+				{Name: "loki", Namespace: "monitoring", State: FluxStateReady},
+			},
+		}
+		d.events = map[string][]warningEvent{
+			"monitoring": {{kind: "Pod", namespace: "monitoring", name: "grafana-0", reason: "Failed", message: "ImagePullBackOff", count: 4}},
+		}
+		kustomizations := []blueprintv1alpha1.Kustomization{
+			{Name: "base"},
+			{Name: "crds", DependsOn: []string{"base"}},
+			{Name: "dns", DependsOn: []string{"base", "crds"}},
+			{Name: "ingress", DependsOn: []string{"dns"}},
+			{Name: "monitoring"},
+		}
+
+		// When the digest is built
+		digest := d.digest(kustomizations)
+
+		// Then each stuck chain names its root with the failing helm release and events beneath
+		for _, want := range []string{
+			"root cause: kustomization crds Failed (BuildFailed: kustomize build failed)",
+			"root cause: kustomization monitoring Failed (HealthCheckFailed: timeout)",
+			"HelmRelease monitoring/grafana Failed (InstallFailed: install retries exhausted)",
+			"Pod monitoring/grafana-0 Failed: ImagePullBackOff (x4)",
+		} {
+			if !strings.Contains(digest, want) {
+				t.Errorf("Expected digest to contain %q, got:\n%s", want, digest)
+			}
+		}
+		for _, unwanted := range []string{"kustomization dns", "kustomization ingress", "loki"} {
+			if strings.Contains(digest, unwanted) {
+				t.Errorf("Expected digest not to contain %q, got:\n%s", unwanted, digest)
+			}
+		}
+	})
+
+	t.Run("EmptyBeforeFirstScan", func(t *testing.T) {
+		d := newWaitDiagnostics(time.Now())
+		if digest := d.digest([]blueprintv1alpha1.Kustomization{{Name: "a"}}); digest != "" {
+			t.Errorf("Expected empty digest, got %q", digest)
+		}
+	})
+}
+
+func TestBaseKubernetesManager_WaitForKustomizations_Diagnostics(t *testing.T) {
+	t.Run("TimeoutIncludesRootCauseDigest", func(t *testing.T) {
+		// Given a kustomization that never becomes Ready and scans that run during the wait
+		mocks := setupKubernetesMocks(t)
+		manager := NewKubernetesManager(waitDiagnosticsClient(t, time.Now()), mocks.ConfigHandler)
+		manager.kustomizationWaitPollInterval = 20 * time.Millisecond
+		manager.kustomizationEventPollInterval = 20 * time.Millisecond
+		manager.notReadyDescribeBudget = 0
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "apps", Timeout: &blueprintv1alpha1.DurationString{Duration: 200 * time.Millisecond}},
+			},
+		}
+
+		// When the wait times out
+		err := manager.WaitForKustomizations(context.Background(), "Waiting for kustomizations", blueprint)
+
+		// Then the error closes with the digest naming apps and its failing helm release
+		if err == nil {
+			t.Fatal("Expected timeout error, got nil")
+		}
+		for _, want := range []string{"timeout waiting for kustomizations", "root cause: kustomization apps Failed", "HelmRelease apps/podinfo Failed"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,452 @@
+// The Policy package evaluates policy-as-code deny rules against Terraform and Flux plans.
+// Policies are loaded from the policies/ directories of the blueprint's sources, its local
+// template and the context, and are
+// evaluated with the runtime expression evaluator, once per planned change. A policy whose
+// deny expression is true produces a Violation; callers report violations on plan and refuse
+// to apply while any are present.
+
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
+	"github.com/windsorcli/cli/pkg/runtime/evaluator"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// PoliciesDir is the directory name, under the template root and the context config root,
+// that holds policy files.
+const PoliciesDir = "policies"
+
+// Layer names exposed to policy expressions as `layer`.
+const (
+	LayerTerraform = "terraform"
+	LayerKustomize = "kustomize"
+)
+
+// Operation names exposed to policy expressions as `operation`: plan when windsor plan reports
+// violations, apply when apply or up refuses them.
+const (
+	OperationPlan  = "plan"
+	OperationApply = "apply"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// Violation records one policy denying one planned change. Component is the terraform
+// component ID or kustomization name; Address is the resource address the change targets.
+type Violation struct {
+	Policy    string
+	Message   string
+	Layer     string
+	Component string
+	Address   string
+}
+
+// DeniedError is returned when an apply is refused because the plan violates one or more
+// policies. The error text lists every violation.
+type DeniedError struct {
+	Violations []Violation
+}
+
+// Checker evaluates a fixed set of policies against plan results.
+type Checker struct {
+	evaluator evaluator.ExpressionEvaluator
+	policies  []blueprintv1alpha1.Policy
+}
+
+// =============================================================================
+// Constructor
+// =============================================================================
+
+// NewChecker creates a Checker that evaluates policies with the given expression evaluator.
+func NewChecker(eval evaluator.ExpressionEvaluator, policies []blueprintv1alpha1.Policy) *Checker {
+	return &Checker{evaluator: eval, policies: policies}
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Error lists the violations that blocked the apply, one per line.
+func (e *DeniedError) Error() string {
+	lines := make([]string, 0, len(e.Violations)+1)
+	lines = append(lines, fmt.Sprintf("plan denied by %d policy violation(s):", len(e.Violations)))
+	for _, v := range e.Violations {
+		lines = append(lines, "  "+FormatViolation(v))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Load reads every *.yaml and *.yml policy file of the composed blueprint's sources, then under
+// <templateRoot>/policies and <configRoot>/policies, and returns the policies they define, ordered
+// by name. sources maps each source name to its template files keyed by path relative to the
+// source's template root, as the blueprint handler collects them, so policies shipped in an OCI or
+// git blueprint apply as well as local ones. Sources are read in name order, and a policy read
+// later replaces an earlier one with the same name, so a local template policy overrides a
+// source's and a context policy overrides both. Missing directories are not an error. Returns an
+// error when a file cannot be read or parsed, or when a policy lacks a name or deny expression.
+func Load(sources map[string]map[string][]byte, templateRoot, configRoot string) ([]blueprintv1alpha1.Policy, error) {
+	byName := make(map[string]blueprintv1alpha1.Policy)
+	sourceNames := make([]string, 0, len(sources))
+	for name := range sources {
+		sourceNames = append(sourceNames, name)
+	}
+	sort.Strings(sourceNames)
+	for _, name := range sourceNames {
+		policies, err := loadSource(name, sources[name])
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range policies {
+			byName[p.Name] = p
+		}
+	}
+	for _, root := range []string{templateRoot, configRoot} {
+		if root == "" {
+			continue
+		}
+		policies, err := loadDir(filepath.Join(root, PoliciesDir))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range policies {
+			byName[p.Name] = p
+		}
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make([]blueprintv1alpha1.Policy, 0, len(names))
+	for _, name := range names {
+		out = append(out, byName[name])
+	}
+	return out, nil
+}
+
+// FormatViolation renders a violation as a single line for errors and logs.
+func FormatViolation(v Violation) string {
+	return fmt.Sprintf("policy %s denied %s %s: %s", v.Policy, v.Component, v.Address, v.Message)
+}
+
+// Len returns the number of policies the checker evaluates.
+func (c *Checker) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.policies)
+}
+
+// CheckTerraform evaluates every policy against each planned resource change of one terraform
+// component, exposing operation to the expressions. Resources carry before/after values only
+// when the stack read them from the saved plan; otherwise `before` and `after` are null in the
+// expression scope.
+func (c *Checker) CheckTerraform(operation, componentID string, changes []terraforminfra.ResourceChange) ([]Violation, error) {
+	if c.Len() == 0 {
+		return nil, nil
+	}
+	var violations []Violation
+	for _, change := range changes {
+		action := terraformAction(change.Action)
+		if action == "" {
+			continue
+		}
+		scope := map[string]any{
+			"layer":     LayerTerraform,
+			"operation": operation,
+			"component": componentID,
+			"address":   change.Address,
+			"type":      change.Type,
+			"action":    action,
+			"before":    nil,
+			"after":     nil,
+		}
+		if change.Values != nil {
+			scope["before"] = change.Values.Before
+			scope["after"] = change.Values.After
+		}
+		v, err := c.evaluate(scope)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	return violations, nil
+}
+
+// CheckKustomize evaluates every policy against one kustomization plan: once for the
+// Kustomization itself (type "Kustomization") and once for each Kubernetes resource it changes
+// (type is the resource Kind), exposing operation to the expressions. Plans that failed are
+// skipped; their error is reported elsewhere.
+func (c *Checker) CheckKustomize(operation string, plan fluxinfra.KustomizePlan) ([]Violation, error) {
+	if c.Len() == 0 || plan.Err != nil {
+		return nil, nil
+	}
+	action := "noop"
+	switch {
+	case plan.IsNew:
+		action = "create"
+	case plan.Added > 0 || plan.Removed > 0 || len(plan.Resources) > 0:
+		action = "update"
+	}
+	subjects := []map[string]any{{
+		"layer":         LayerKustomize,
+		"operation":     operation,
+		"component":     plan.Name,
+		"address":       "Kustomization/" + plan.Name,
+		"type":          "Kustomization",
+		"action":        action,
+		"lines_added":   plan.Added,
+		"lines_removed": plan.Removed,
+		"before":        nil,
+		"after":         nil,
+	}}
+	for _, change := range plan.Resources {
+		action := kustomizeAction(change.Action)
+		if action == "" {
+			continue
+		}
+		kind, _, _ := strings.Cut(change.Address, "/")
+		subjects = append(subjects, map[string]any{
+			"layer":     LayerKustomize,
+			"operation": operation,
+			"component": plan.Name,
+			"address":   change.Address,
+			"type":      kind,
+			"action":    action,
+			"before":    nil,
+			"after":     nil,
+		})
+	}
+	var violations []Violation
+	for _, scope := range subjects {
+		v, err := c.evaluate(scope)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	return violations, nil
+}
+
+// Check evaluates every policy against a full plan: each terraform component's resource changes
+// and each kustomization plan, for the named operation. Components whose plan failed are skipped.
+func (c *Checker) Check(operation string, tf []terraforminfra.TerraformComponentPlan, k8s []fluxinfra.KustomizePlan) ([]Violation, error) {
+	if c.Len() == 0 {
+		return nil, nil
+	}
+	var violations []Violation
+	for _, component := range tf {
+		if component.Err != nil {
+			continue
+		}
+		v, err := c.CheckTerraform(operation, component.ComponentID, component.Resources)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	for _, plan := range k8s {
+		v, err := c.CheckKustomize(operation, plan)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	return violations, nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// evaluate runs every policy against one change scope and returns the violations it produced.
+// The when expression gates the policy; deny must evaluate to a boolean. Returns an error naming
+// the policy when an expression fails to evaluate or deny is not a boolean.
+func (c *Checker) evaluate(scope map[string]any) ([]Violation, error) {
+	var violations []Violation
+	for _, p := range c.policies {
+		if strings.TrimSpace(p.When) != "" {
+			applies, err := c.evaluateBool(p.When, scope)
+			if err != nil {
+				return nil, fmt.Errorf("policy %q: error evaluating when: %w", p.Name, err)
+			}
+			if !applies {
+				continue
+			}
+		}
+		denied, err := c.evaluateBool(p.Deny, scope)
+		if err != nil {
+			return nil, fmt.Errorf("policy %q: error evaluating deny: %w", p.Name, err)
+		}
+		if !denied {
+			continue
+		}
+		message, err := c.message(p, scope)
+		if err != nil {
+			return nil, fmt.Errorf("policy %q: error evaluating message: %w", p.Name, err)
+		}
+		violations = append(violations, Violation{
+			Policy:    p.Name,
+			Message:   message,
+			Layer:     fmt.Sprint(scope["layer"]),
+			Component: fmt.Sprint(scope["component"]),
+			Address:   fmt.Sprint(scope["address"]),
+		})
+	}
+	return violations, nil
+}
+
+// evaluateBool evaluates a policy expression, with or without ${} bookends, and requires a
+// boolean result.
+func (c *Checker) evaluateBool(expression string, scope map[string]any) (bool, error) {
+	body, _ := evaluator.ExpressionBody(expression)
+	value, err := c.evaluator.EvaluateShadowingBuiltins("${"+body+"}", scope)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q evaluated to %T, expected bool", body, value)
+	}
+	return b, nil
+}
+
+// message interpolates the policy message against the change scope. An empty message falls
+// back to a generic description naming the policy.
+func (c *Checker) message(p blueprintv1alpha1.Policy, scope map[string]any) (string, error) {
+	if strings.TrimSpace(p.Message) == "" {
+		return fmt.Sprintf("denied by policy %s", p.Name), nil
+	}
+	value, err := c.evaluator.EvaluateShadowingBuiltins(p.Message, scope)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(fmt.Sprint(value)), nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// loadDir parses every policy file directly under dir. Returns nil when dir does not exist.
+func loadDir(dir string) ([]blueprintv1alpha1.Policy, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading policies directory %s: %w", dir, err)
+	}
+	var policies []blueprintv1alpha1.Policy
+	for _, entry := range entries {
+		if entry.IsDir() || !isPolicyFile(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		// #nosec G304 - Policy file paths are derived from listing the project's policies directory
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading policy file %s: %w", path, err)
+		}
+		file, err := parseFile(path, data)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, file...)
+	}
+	return policies, nil
+}
+
+// loadSource parses the policy files directly under policies/ in one source's template files,
+// in path order. Errors name the file as <source>:<path>.
+func loadSource(name string, files map[string][]byte) ([]blueprintv1alpha1.Policy, error) {
+	var paths []string
+	for path := range files {
+		dir, file := filepath.Split(filepath.ToSlash(path))
+		if dir == PoliciesDir+"/" && isPolicyFile(file) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	var policies []blueprintv1alpha1.Policy
+	for _, path := range paths {
+		file, err := parseFile(name+":"+path, files[path])
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, file...)
+	}
+	return policies, nil
+}
+
+// parseFile decodes one policy file and checks that every policy has a name and a deny
+// expression. path only names the file in errors.
+func parseFile(path string, data []byte) ([]blueprintv1alpha1.Policy, error) {
+	var file blueprintv1alpha1.PolicyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing policy file %s: %w", path, err)
+	}
+	for i, p := range file.Policies {
+		if strings.TrimSpace(p.Name) == "" {
+			return nil, fmt.Errorf("policy %d in %s has no name", i+1, path)
+		}
+		if strings.TrimSpace(p.Deny) == "" {
+			return nil, fmt.Errorf("policy %q in %s has no deny expression", p.Name, path)
+		}
+	}
+	return file.Policies, nil
+}
+
+// isPolicyFile reports whether name has a YAML extension.
+func isPolicyFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// terraformAction maps a terraform resource action to the string exposed as `action`.
+// Returns an empty string for ActionUnknown, which policies never see.
+func terraformAction(a terraforminfra.Action) string {
+	switch a {
+	case terraforminfra.ActionCreate:
+		return "create"
+	case terraforminfra.ActionUpdate:
+		return "update"
+	case terraforminfra.ActionDelete:
+		return "delete"
+	case terraforminfra.ActionReplace:
+		return "replace"
+	default:
+		return ""
+	}
+}
+
+// kustomizeAction maps a flux resource action to the string exposed as `action`.
+// Returns an empty string for ActionUnknown, which policies never see.
+func kustomizeAction(a fluxinfra.Action) string {
+	switch a {
+	case fluxinfra.ActionCreate:
+		return "create"
+	case fluxinfra.ActionUpdate:
+		return "update"
+	case fluxinfra.ActionDelete:
+		return "delete"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,428 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
+	"github.com/windsorcli/cli/pkg/runtime/config"
+	"github.com/windsorcli/cli/pkg/runtime/evaluator"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+func newTestChecker(t *testing.T, policies ...blueprintv1alpha1.Policy) *Checker {
+	t.Helper()
+	configHandler := config.NewMockConfigHandler()
+	configHandler.GetContextValuesFunc = func() (map[string]any, error) {
+		return map[string]any{}, nil
+	}
+	configHandler.GetContextFunc = func() string {
+		return "test"
+	}
+	return NewChecker(evaluator.NewExpressionEvaluator(configHandler, t.TempDir(), t.TempDir()), policies)
+}
+
+func writePolicyFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, PoliciesDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("Failed to create policies dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write policy file: %v", err)
+	}
+}
+
+var noDatabaseDestroy = blueprintv1alpha1.Policy{
+	Name:    "no-db-destroy",
+	When:    `layer == "terraform" && type == "aws_db_instance"`,
+	Deny:    `action == "delete" || action == "replace"`,
+	Message: "${address} may not be destroyed outside of windsor destroy",
+}
+
+var noPublicBuckets = blueprintv1alpha1.Policy{
+	Name:    "no-public-buckets",
+	When:    `type == "aws_s3_bucket"`,
+	Deny:    `after.acl in ["public-read", "public-read-write"]`,
+	Message: "bucket ${address} is public",
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestLoad(t *testing.T) {
+	t.Run("ReturnsNilWhenNoPoliciesDirExists", func(t *testing.T) {
+		// Given roots without policies directories
+		policies, err := Load(nil, t.TempDir(), t.TempDir())
+
+		// Then no policies and no error are returned
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(policies) != 0 {
+			t.Errorf("Expected no policies, got %v", policies)
+		}
+	})
+
+	t.Run("MergesTemplateAndContextPoliciesByName", func(t *testing.T) {
+		// Given a blueprint policy overridden by the context and one policy on each side
+		templateRoot, configRoot := t.TempDir(), t.TempDir()
+		writePolicyFile(t, templateRoot, "base.yaml", `policies:
+  - name: no-db-destroy
+    deny: action == "delete"
+  - name: blueprint-only
+    deny: "false"
+`)
+		writePolicyFile(t, configRoot, "override.yml", `policies:
+  - name: no-db-destroy
+    deny: action == "replace"
+    message: overridden
+`)
+		writePolicyFile(t, configRoot, "README.md", "not a policy")
+
+		// When loading
+		policies, err := Load(nil, templateRoot, configRoot)
+
+		// Then both names are present, sorted, and the context version wins
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(policies) != 2 {
+			t.Fatalf("Expected 2 policies, got %v", policies)
+		}
+		if policies[0].Name != "blueprint-only" || policies[1].Name != "no-db-destroy" {
+			t.Errorf("Expected policies sorted by name, got %v", policies)
+		}
+		if policies[1].Deny != `action == "replace"` || policies[1].Message != "overridden" {
+			t.Errorf("Expected context policy to replace blueprint policy, got %+v", policies[1])
+		}
+	})
+
+	t.Run("LoadsSourcePoliciesUnderLocalOnes", func(t *testing.T) {
+		// Given policies shipped by two sources and a local template policy sharing a name
+		sources := map[string]map[string][]byte{
+			"core": {
+				"policies/core.yaml":        []byte("policies:\n  - name: shared\n    deny: \"true\"\n  - name: core-only\n    deny: \"false\"\n"),
+				"policies/nested/skip.yaml": []byte("policies: ["),
+				"facets/platform.yaml":      []byte("kind: Facet"),
+			},
+			"addons": {
+				"policies/addons.yml": []byte("policies:\n  - name: addons-only\n    deny: \"false\"\n"),
+			},
+		}
+		templateRoot := t.TempDir()
+		writePolicyFile(t, templateRoot, "local.yaml", `policies:
+  - name: shared
+    deny: "false"
+    message: local
+`)
+
+		// When loading
+		policies, err := Load(sources, templateRoot, "")
+
+		// Then every source's policies load, nested and non-policy files are ignored, and the local one wins
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(policies) != 3 {
+			t.Fatalf("Expected 3 policies, got %v", policies)
+		}
+		if policies[2].Name != "shared" || policies[2].Message != "local" {
+			t.Errorf("Expected the local policy to replace the source policy, got %+v", policies[2])
+		}
+	})
+
+	t.Run("NamesSourceInParseErrors", func(t *testing.T) {
+		// Given a malformed policy file in a source
+		sources := map[string]map[string][]byte{"core": {"policies/bad.yaml": []byte("policies: [")}}
+
+		// When loading
+		_, err := Load(sources, "", "")
+
+		// Then the error names the source and file
+		if err == nil || !strings.Contains(err.Error(), "core:policies/bad.yaml") {
+			t.Errorf("Expected source parse error, got %v", err)
+		}
+	})
+
+	t.Run("RejectsPolicyWithoutDeny", func(t *testing.T) {
+		// Given a policy with no deny expression
+		root := t.TempDir()
+		writePolicyFile(t, root, "bad.yaml", "policies:\n  - name: empty\n")
+
+		// When loading
+		_, err := Load(nil, root, "")
+
+		// Then an error names the policy
+		if err == nil || !strings.Contains(err.Error(), `"empty"`) {
+			t.Errorf("Expected missing deny error, got %v", err)
+		}
+	})
+
+	t.Run("RejectsPolicyWithoutName", func(t *testing.T) {
+		// Given a policy with no name
+		root := t.TempDir()
+		writePolicyFile(t, root, "bad.yaml", "policies:\n  - deny: \"true\"\n")
+
+		// When loading
+		_, err := Load(nil, root, "")
+
+		// Then an error is returned
+		if err == nil || !strings.Contains(err.Error(), "has no name") {
+			t.Errorf("Expected missing name error, got %v", err)
+		}
+	})
+
+	t.Run("ReturnsErrorForInvalidYAML", func(t *testing.T) {
+		// Given a malformed policy file
+		root := t.TempDir()
+		writePolicyFile(t, root, "bad.yaml", "policies: [")
+
+		// When loading
+		_, err := Load(nil, root, "")
+
+		// Then a parse error is returned
+		if err == nil || !strings.Contains(err.Error(), "error parsing policy file") {
+			t.Errorf("Expected parse error, got %v", err)
+		}
+	})
+}
+
+func TestChecker_CheckTerraform(t *testing.T) {
+	t.Run("DeniesDatabaseDelete", func(t *testing.T) {
+		// Given a policy forbidding database destroys
+		checker := newTestChecker(t, noDatabaseDestroy)
+
+		// When checking a plan that deletes a database and creates a bucket
+		violations, err := checker.CheckTerraform(OperationApply, "database", []terraforminfra.ResourceChange{
+			{Address: "aws_db_instance.main", Action: terraforminfra.ActionDelete, Type: "aws_db_instance"},
+			{Address: "aws_s3_bucket.logs", Action: terraforminfra.ActionCreate, Type: "aws_s3_bucket"},
+		})
+
+		// Then only the database delete is a violation with an interpolated message
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(violations) != 1 {
+			t.Fatalf("Expected 1 violation, got %v", violations)
+		}
+		want := Violation{
+			Policy:    "no-db-destroy",
+			Message:   "aws_db_instance.main may not be destroyed outside of windsor destroy",
+			Layer:     LayerTerraform,
+			Component: "database",
+			Address:   "aws_db_instance.main",
+		}
+		if violations[0] != want {
+			t.Errorf("Expected %+v, got %+v", want, violations[0])
+		}
+	})
+
+	t.Run("EvaluatesAfterValues", func(t *testing.T) {
+		// Given a policy forbidding public buckets
+		checker := newTestChecker(t, noPublicBuckets)
+
+		// When checking a public and a private bucket, and one without values
+		violations, err := checker.CheckTerraform(OperationApply, "storage", []terraforminfra.ResourceChange{
+			{Address: "aws_s3_bucket.public", Action: terraforminfra.ActionCreate, Type: "aws_s3_bucket",
+				Values: &terraforminfra.ResourceValues{After: map[string]any{"acl": "public-read"}}},
+			{Address: "aws_s3_bucket.private", Action: terraforminfra.ActionCreate, Type: "aws_s3_bucket",
+				Values: &terraforminfra.ResourceValues{After: map[string]any{"acl": "private"}}},
+			{Address: "aws_s3_bucket.unknown", Action: terraforminfra.ActionUpdate, Type: "aws_s3_bucket"},
+		})
+
+		// Then only the public bucket is denied
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(violations) != 1 || violations[0].Address != "aws_s3_bucket.public" {
+			t.Errorf("Expected only the public bucket to be denied, got %v", violations)
+		}
+	})
+
+	t.Run("AcceptsWrappedExpressionsAndDefaultMessage", func(t *testing.T) {
+		// Given a policy written with ${} bookends and no message
+		checker := newTestChecker(t, blueprintv1alpha1.Policy{Name: "no-deletes", Deny: `${action == "delete"}`})
+
+		// When checking a delete
+		violations, err := checker.CheckTerraform(OperationApply, "network", []terraforminfra.ResourceChange{
+			{Address: "aws_vpc.main", Action: terraforminfra.ActionDelete, Type: "aws_vpc"},
+		})
+
+		// Then the violation carries the default message
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(violations) != 1 || violations[0].Message != "denied by policy no-deletes" {
+			t.Errorf("Expected default message, got %v", violations)
+		}
+	})
+
+	t.Run("ReturnsErrorWhenDenyIsNotBoolean", func(t *testing.T) {
+		// Given a policy whose deny expression yields a string
+		checker := newTestChecker(t, blueprintv1alpha1.Policy{Name: "bad", Deny: "address"})
+
+		// When checking any change
+		_, err := checker.CheckTerraform(OperationApply, "network", []terraforminfra.ResourceChange{
+			{Address: "aws_vpc.main", Action: terraforminfra.ActionUpdate, Type: "aws_vpc"},
+		})
+
+		// Then an error names the policy
+		if err == nil || !strings.Contains(err.Error(), `policy "bad"`) {
+			t.Errorf("Expected non-boolean error, got %v", err)
+		}
+	})
+
+	t.Run("NilCheckerReturnsNoViolations", func(t *testing.T) {
+		// Given no checker
+		var checker *Checker
+
+		// When checking a change
+		violations, err := checker.CheckTerraform(OperationApply, "network", []terraforminfra.ResourceChange{
+			{Address: "aws_vpc.main", Action: terraforminfra.ActionDelete},
+		})
+
+		// Then nothing is reported
+		if err != nil || violations != nil {
+			t.Errorf("Expected no violations, got %v, %v", violations, err)
+		}
+	})
+}
+
+func TestChecker_CheckKustomize(t *testing.T) {
+	t.Run("EvaluatesKustomizationAndResources", func(t *testing.T) {
+		// Given a policy forbidding namespace deletes and one forbidding new kustomizations
+		checker := newTestChecker(t,
+			blueprintv1alpha1.Policy{Name: "keep-namespaces", When: `layer == "kustomize" && type == "Namespace"`, Deny: `action == "delete"`, Message: "${address} would be deleted"},
+			blueprintv1alpha1.Policy{Name: "no-new", When: `type == "Kustomization"`, Deny: `action == "create"`},
+		)
+
+		// When checking an existing kustomization that deletes a namespace
+		violations, err := checker.CheckKustomize(OperationApply, fluxinfra.KustomizePlan{
+			Name:    "apps",
+			Removed: 3,
+			Resources: []fluxinfra.ResourceChange{
+				{Address: "Namespace/apps", Action: fluxinfra.ActionDelete},
+				{Address: "Deployment/apps/web", Action: fluxinfra.ActionUpdate},
+			},
+		})
+
+		// Then only the namespace delete is denied
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(violations) != 1 || violations[0].Address != "Namespace/apps" || violations[0].Message != "Namespace/apps would be deleted" {
+			t.Errorf("Expected namespace violation, got %v", violations)
+		}
+	})
+
+	t.Run("TreatsNewKustomizationAsCreate", func(t *testing.T) {
+		// Given a policy forbidding new kustomizations
+		checker := newTestChecker(t, blueprintv1alpha1.Policy{Name: "no-new", When: `type == "Kustomization"`, Deny: `action == "create"`})
+
+		// When checking a kustomization that is not yet deployed
+		violations, err := checker.CheckKustomize(OperationApply, fluxinfra.KustomizePlan{Name: "apps", IsNew: true})
+
+		// Then the kustomization itself is denied
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(violations) != 1 || violations[0].Address != "Kustomization/apps" || violations[0].Layer != LayerKustomize {
+			t.Errorf("Expected kustomization violation, got %v", violations)
+		}
+	})
+
+	t.Run("SkipsFailedPlans", func(t *testing.T) {
+		// Given a policy that denies everything
+		checker := newTestChecker(t, blueprintv1alpha1.Policy{Name: "all", Deny: "true"})
+
+		// When checking a plan that failed
+		violations, err := checker.CheckKustomize(OperationApply, fluxinfra.KustomizePlan{Name: "apps", Err: errors.New("boom")})
+
+		// Then nothing is evaluated
+		if err != nil || len(violations) != 0 {
+			t.Errorf("Expected no violations, got %v, %v", violations, err)
+		}
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	t.Run("CombinesLayersAndSkipsFailedComponents", func(t *testing.T) {
+		// Given a policy denying every delete
+		checker := newTestChecker(t, blueprintv1alpha1.Policy{Name: "no-deletes", Deny: `action == "delete"`})
+
+		// When checking a plan with a terraform delete, a failed component, and a kustomize delete
+		violations, err := checker.Check(OperationApply,
+			[]terraforminfra.TerraformComponentPlan{
+				{ComponentID: "network", Resources: []terraforminfra.ResourceChange{{Address: "aws_vpc.main", Action: terraforminfra.ActionDelete}}},
+				{ComponentID: "broken", Err: errors.New("plan failed"), Resources: []terraforminfra.ResourceChange{{Address: "x.y", Action: terraforminfra.ActionDelete}}},
+			},
+			[]fluxinfra.KustomizePlan{
+				{Name: "apps", Resources: []fluxinfra.ResourceChange{{Address: "Namespace/apps", Action: fluxinfra.ActionDelete}}},
+			},
+		)
+
+		// Then one violation per layer is reported in order
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(violations) != 2 || violations[0].Component != "network" || violations[1].Component != "apps" {
+			t.Errorf("Expected terraform then kustomize violation, got %v", violations)
+		}
+	})
+	t.Run("ExposesTheCallersOperation", func(t *testing.T) {
+		// Given a policy that only denies deletes at apply time
+		checker := newTestChecker(t, blueprintv1alpha1.Policy{Name: "no-apply-deletes", Deny: `operation == "apply" && action == "delete"`})
+		tf := []terraforminfra.TerraformComponentPlan{
+			{ComponentID: "network", Resources: []terraforminfra.ResourceChange{{Address: "aws_vpc.main", Action: terraforminfra.ActionDelete}}},
+		}
+		k8s := []fluxinfra.KustomizePlan{
+			{Name: "apps", Resources: []fluxinfra.ResourceChange{{Address: "Namespace/apps", Action: fluxinfra.ActionDelete}}},
+		}
+
+		// When the same plan is checked for plan and for apply
+		planned, err := checker.Check(OperationPlan, tf, k8s)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		applied, err := checker.Check(OperationApply, tf, k8s)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then only the apply check sees operation "apply" on both layers
+		if len(planned) != 0 {
+			t.Errorf("Expected no violations for plan, got %v", planned)
+		}
+		if len(applied) != 2 {
+			t.Errorf("Expected both deletes denied for apply, got %v", applied)
+		}
+	})
+}
+
+func TestDeniedError(t *testing.T) {
+	t.Run("ListsEveryViolation", func(t *testing.T) {
+		// Given two violations
+		err := &DeniedError{Violations: []Violation{
+			{Policy: "a", Component: "network", Address: "aws_vpc.main", Message: "first"},
+			{Policy: "b", Component: "apps", Address: "Namespace/apps", Message: "second"},
+		}}
+
+		// Then the error text names the count and each violation
+		text := err.Error()
+		for _, want := range []string{"2 policy violation(s)", "policy a denied network aws_vpc.main: first", "policy b denied apps Namespace/apps: second"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("Expected %q in %q", want, text)
+			}
+		}
+	})
+}
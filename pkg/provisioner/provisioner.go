@@ -21,6 +21,7 @@ import (
 	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
 	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
 	k8sclient "github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	"github.com/windsorcli/cli/pkg/provisioner/policy"
 	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
 	"github.com/windsorcli/cli/pkg/runtime"
 	"github.com/windsorcli/cli/pkg/runtime/config"
@@ -51,6 +52,10 @@ type Provisioner struct {
 	configRoot    string
 	runtime       *runtime.Runtime
 
+	// awaitingReview is set when a push-mode Install committed to a review branch, so Wait has no
+	// change on the cluster to wait for until the branch is merged.
+	awaitingReview bool
+
 	// secretPollInterval overrides how often PlaceSecrets re-checks for a pending secret's namespace;
 	// zero uses constants.DefaultKustomizationWaitPollInterval. Set small in tests to avoid real waits.
 	secretPollInterval time.Duration
@@ -60,6 +65,9 @@ type Provisioner struct {
 	Notifier             fluxinfra.Notifier
 	onTerraformApply     []func(id string) (bool, error)
 	onTerraformPostApply []func(id string) error
+	terraformConcurrency int
+	policyChecker        *policy.Checker
+	policiesLoaded       bool
 	KubernetesManager    kubernetes.KubernetesManager
 	KubernetesClient     k8sclient.KubernetesClient
 	ClusterClient        cluster.ClusterClient
@@ -71,10 +79,13 @@ type Provisioner struct {
 // entry per non-destroyOnly kustomization. Either slice may be nil when the
 // corresponding layer is absent from the blueprint or its tooling is unavailable.
 // Hints contains upgrade suggestions collected when required CLI tools are absent.
+// Violations lists the policy denials found in the plan; it is nil when no policies
+// are defined or none deny a change.
 type PlanSummary struct {
-	Terraform []terraforminfra.TerraformComponentPlan
-	Kustomize []fluxinfra.KustomizePlan
-	Hints     []string
+	Terraform  []terraforminfra.TerraformComponentPlan
+	Kustomize  []fluxinfra.KustomizePlan
+	Hints      []string
+	Violations []policy.Violation
 }
 
 // DestroyPlanSummary holds aggregated destroy-plan results across all
@@ -90,6 +101,35 @@ type DestroyPlanSummary struct {
 	Kustomize []fluxinfra.KustomizePlan
 }
 
+// DriftSummary holds drift-detection results across all infrastructure layers.
+// Terraform contains one refresh-only result per enabled component; Kustomize
+// contains one inventory comparison per non-destroyOnly kustomization. IsNew on
+// these entries means there is nothing deployed to compare against, which
+// renderers show as "(no state)" / "(not deployed)" as on the destroy path.
+type DriftSummary struct {
+	Terraform []terraforminfra.TerraformComponentPlan
+	Kustomize []fluxinfra.KustomizePlan
+}
+
+// StatusSummary is the cluster's view of the blueprint. Kustomizations holds one entry per
+// non-destroyOnly kustomization — the synthesized CRD layer first, then the blueprint's own and
+// the compiled flux system tiers — in blueprint order. Marker is the applied-version marker,
+// nil when the cluster has none.
+type StatusSummary struct {
+	Marker         *kubernetes.VersionMarker
+	Kustomizations []KustomizationStatusEntry
+}
+
+// KustomizationStatusEntry is one kustomization's reconciliation status together with the
+// status of the source it pulls from and of each HelmRelease in its inventory. Err records a
+// failure to read the source or HelmReleases, so one unreadable object does not hide the rest.
+type KustomizationStatusEntry struct {
+	kubernetes.KustomizationStatus
+	Source       *kubernetes.FluxStatus
+	HelmReleases []kubernetes.FluxStatus
+	Err          error
+}
+
 // VersionGate describes how the blueprint a command is about to apply relates to the version marker
 // recorded in the cluster. It is the input to apply's version-equality seam: apply may reconcile in
 // place only when a settled marker matches the blueprint it would apply. Any other state — a version
@@ -205,10 +245,19 @@ func (i *Provisioner) OnTerraformPostApply(fn func(id string) error) {
 	}
 }
 
+// SetTerraformConcurrency sets how many terraform components Up may apply, and the
+// all-component plan paths may plan, at once. Values of 1 or less keep the sequential loops.
+// The setting is forwarded to the terraform stack on every Up and plan call, so it applies
+// to every pass Bootstrap makes as well.
+func (i *Provisioner) SetTerraformConcurrency(n int) {
+	i.terraformConcurrency = n
+}
+
 // Up orchestrates the high-level infrastructure deployment process. It runs Terraform apply
 // when terraform.enabled and the stack exists, invoking the given onApply hooks after each
-// component apply (after any hooks registered via OnTerraformApply). The blueprint parameter
-// is required.
+// component apply (after any hooks registered via OnTerraformApply). When policies are
+// defined, each component's saved plan is checked against them before it is applied and a
+// denial aborts the run. The blueprint parameter is required.
 //
 // Returns (halted bool, err error). halted=true means a hook signaled a clean stop after a
 // component apply — the apply succeeded, but subsequent components were intentionally
@@ -231,6 +280,10 @@ func (i *Provisioner) Up(blueprint *blueprintv1alpha1.Blueprint, onApply ...func
 	if len(i.onTerraformPostApply) > 0 {
 		i.TerraformStack.PostApply(i.onTerraformPostApply...)
 	}
+	i.forwardTerraformConcurrency()
+	if err := i.forwardPolicyCheck(policy.OperationApply); err != nil {
+		return false, err
+	}
 	halted, err := i.TerraformStack.Up(blueprint, hooks...)
 	if err != nil {
 		return false, fmt.Errorf("failed to run terraform up: %w", err)
@@ -431,9 +484,18 @@ func (i *Provisioner) destroyAllTerraform(blueprint *blueprintv1alpha1.Blueprint
 }
 
 // Apply runs terraform init, plan, and apply for a single component identified by componentID.
-// Returns an error if terraform is disabled, the stack cannot be initialized, the component is
-// not found, or any terraform operation fails.
+// When policies are defined, the plan is checked against them before apply. Returns an error if
+// terraform is disabled, the stack cannot be initialized, the component is not found, a policy
+// denies the plan, or any terraform operation fails.
 func (i *Provisioner) Apply(blueprint *blueprintv1alpha1.Blueprint, componentID string) error {
+	return i.ApplyWithOptions(blueprint, componentID, terraforminfra.ApplyOptions{})
+}
+
+// ApplyWithOptions applies a single component as Apply does, with the plan narrowed by opts to
+// target or replace specific resources or to refresh state only. A targeted apply that would
+// leave other changes in the component pending is refused with a *terraforminfra.PartialApplyError
+// unless opts.Confirmed is set.
+func (i *Provisioner) ApplyWithOptions(blueprint *blueprintv1alpha1.Blueprint, componentID string, opts terraforminfra.ApplyOptions) error {
 	if blueprint == nil {
 		return fmt.Errorf("blueprint not provided")
 	}
@@ -443,7 +505,10 @@ func (i *Provisioner) Apply(blueprint *blueprintv1alpha1.Blueprint, componentID
 	if i.TerraformStack == nil {
 		return fmt.Errorf("terraform is disabled")
 	}
-	if err := i.TerraformStack.Apply(blueprint, componentID); err != nil {
+	if err := i.forwardPolicyCheck(policy.OperationApply); err != nil {
+		return err
+	}
+	if err := i.TerraformStack.ApplyWithOptions(blueprint, componentID, opts); err != nil {
 		return fmt.Errorf("failed to run terraform apply for %s: %w", componentID, err)
 	}
 	return nil
@@ -475,6 +540,70 @@ func (i *Provisioner) Destroy(blueprint *blueprintv1alpha1.Blueprint, componentI
 	return skipped, nil
 }
 
+// TerraformState runs `terraform state <args...>` for a single component identified by
+// componentID and returns the command output. Returns an error if the blueprint is nil,
+// terraform is disabled, the stack cannot be initialized, the component is not found, or
+// the state command fails.
+func (i *Provisioner) TerraformState(blueprint *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+	if blueprint == nil {
+		return "", fmt.Errorf("blueprint not provided")
+	}
+	if err := i.ensureTerraformStack(); err != nil {
+		return "", err
+	}
+	if i.TerraformStack == nil {
+		return "", fmt.Errorf("terraform is disabled")
+	}
+	output, err := i.TerraformStack.State(blueprint, componentID, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to run terraform state for %s: %w", componentID, err)
+	}
+	return output, nil
+}
+
+// SnapshotTerraformState writes a snapshot of a single component's current state to the
+// context's snapshot store and returns its path, or an empty path when the component has no
+// state. Returns an error if the blueprint is nil, terraform is disabled, the stack cannot be
+// initialized, the component is not found, or the state cannot be pulled or written.
+func (i *Provisioner) SnapshotTerraformState(blueprint *blueprintv1alpha1.Blueprint, componentID string) (string, error) {
+	if blueprint == nil {
+		return "", fmt.Errorf("blueprint not provided")
+	}
+	if err := i.ensureTerraformStack(); err != nil {
+		return "", err
+	}
+	if i.TerraformStack == nil {
+		return "", fmt.Errorf("terraform is disabled")
+	}
+	path, err := i.TerraformStack.SnapshotState(blueprint, componentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot terraform state for %s: %w", componentID, err)
+	}
+	return path, nil
+}
+
+// RestoreTerraformState pushes a recorded state snapshot back as a single component's current
+// state and returns the path of the snapshot taken of the state it replaced, or an empty path
+// when the component had no state. Returns an error if the blueprint is nil, terraform is
+// disabled, the stack cannot be initialized, the snapshot is not found or belongs to a
+// different state lineage, or the push fails.
+func (i *Provisioner) RestoreTerraformState(blueprint *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error) {
+	if blueprint == nil {
+		return "", fmt.Errorf("blueprint not provided")
+	}
+	if err := i.ensureTerraformStack(); err != nil {
+		return "", err
+	}
+	if i.TerraformStack == nil {
+		return "", fmt.Errorf("terraform is disabled")
+	}
+	backupPath, err := i.TerraformStack.RestoreState(blueprint, componentID, snapshotID)
+	if err != nil {
+		return backupPath, fmt.Errorf("failed to restore terraform state for %s: %w", componentID, err)
+	}
+	return backupPath, nil
+}
+
 // DestroyKustomize deletes a single kustomization by name from the cluster.
 // Returns an error if the blueprint is nil, the kubernetes manager is not configured,
 // the kustomization is not found in the blueprint, or the delete operation fails.
@@ -593,6 +722,7 @@ func (i *Provisioner) PlanTerraformAll(blueprint *blueprintv1alpha1.Blueprint) e
 	if i.TerraformStack == nil {
 		return fmt.Errorf("terraform is disabled")
 	}
+	i.forwardTerraformConcurrency()
 	return i.TerraformStack.PlanAll(blueprint)
 }
 
@@ -609,6 +739,7 @@ func (i *Provisioner) PlanTerraformAllJSON(blueprint *blueprintv1alpha1.Blueprin
 	if i.TerraformStack == nil {
 		return fmt.Errorf("terraform is disabled")
 	}
+	i.forwardTerraformConcurrency()
 	return i.TerraformStack.PlanAllJSON(blueprint)
 }
 
@@ -642,7 +773,9 @@ func (i *Provisioner) PlanKustomizeJSON(blueprint *blueprintv1alpha1.Blueprint,
 }
 
 // PlanTerraformComponentSummary plans a single Terraform component and returns its
-// structured result. Returns an error only when blueprint is nil or stack initialisation fails.
+// structured result. When policies are defined the result's resources carry their planned
+// values so EvaluatePolicies can inspect them. Returns an error only when blueprint is nil,
+// stack initialisation fails, or the policies cannot be loaded.
 func (i *Provisioner) PlanTerraformComponentSummary(blueprint *blueprintv1alpha1.Blueprint, componentID string) (terraforminfra.TerraformComponentPlan, error) {
 	if blueprint == nil {
 		return terraforminfra.TerraformComponentPlan{}, fmt.Errorf("blueprint not provided")
@@ -653,6 +786,9 @@ func (i *Provisioner) PlanTerraformComponentSummary(blueprint *blueprintv1alpha1
 	if i.TerraformStack == nil {
 		return terraforminfra.TerraformComponentPlan{}, fmt.Errorf("terraform is disabled")
 	}
+	if err := i.forwardPolicyCheck(policy.OperationPlan); err != nil {
+		return terraforminfra.TerraformComponentPlan{}, err
+	}
 	return i.TerraformStack.PlanComponentSummary(blueprint, componentID), nil
 }
 
@@ -699,8 +835,9 @@ func (i *Provisioner) PlanDestroyKustomizeComponentSummary(blueprint *blueprintv
 }
 
 // PlanTerraformSummary runs a best-effort summary plan across every Terraform
-// component in the blueprint without touching the Flux/Kustomize layer.
-// Returns an error only when blueprint is nil or stack initialisation fails.
+// component in the blueprint without touching the Flux/Kustomize layer, and
+// evaluates any policies against the planned changes. Returns an error only when
+// blueprint is nil, stack initialisation fails, or a policy cannot be evaluated.
 func (i *Provisioner) PlanTerraformSummary(blueprint *blueprintv1alpha1.Blueprint) (*PlanSummary, error) {
 	if blueprint == nil {
 		return nil, fmt.Errorf("blueprint not provided")
@@ -712,15 +849,28 @@ func (i *Provisioner) PlanTerraformSummary(blueprint *blueprintv1alpha1.Blueprin
 		return nil, err
 	}
 	if i.TerraformStack != nil {
+		i.forwardTerraformConcurrency()
+		if err := i.forwardPolicyCheck(policy.OperationPlan); err != nil {
+			return nil, err
+		}
 		summary.Terraform = i.TerraformStack.PlanSummary(blueprint)
 	}
 
+	violations, err := i.EvaluatePolicies(policy.OperationPlan, summary.Terraform, nil)
+	if err != nil {
+		return nil, err
+	}
+	summary.Violations = violations
+
 	return summary, nil
 }
 
 // PlanKustomizeSummary runs a best-effort summary plan across every Flux
-// kustomization in the blueprint without touching the Terraform layer.
-// Returns an error only when blueprint is nil or stack initialisation fails.
+// kustomization in the blueprint without touching the Terraform layer, and
+// evaluates any policies against the planned changes. Reclaimed kustomizations
+// are reported but not evaluated, since apply only prunes them when asked to
+// and prune carries its own guard. Returns an error only when
+// blueprint is nil, stack initialisation fails, or a policy cannot be evaluated.
 func (i *Provisioner) PlanKustomizeSummary(blueprint *blueprintv1alpha1.Blueprint) (*PlanSummary, error) {
 	if blueprint == nil {
 		return nil, fmt.Errorf("blueprint not provided")
@@ -733,6 +883,12 @@ func (i *Provisioner) PlanKustomizeSummary(blueprint *blueprintv1alpha1.Blueprin
 	}
 	summary.Kustomize, summary.Hints = i.FluxStack.PlanSummary(withCrdLayer(blueprint))
 
+	violations, err := i.EvaluatePolicies(policy.OperationPlan, nil, declaredKustomizePlans(summary.Kustomize))
+	if err != nil {
+		return nil, err
+	}
+	summary.Violations = violations
+
 	return summary, nil
 }
 
@@ -757,12 +913,26 @@ func (i *Provisioner) PlanAll(blueprint *blueprintv1alpha1.Blueprint) (*PlanSumm
 	}
 
 	return &PlanSummary{
-		Terraform: tfSummary.Terraform,
-		Kustomize: k8sSummary.Kustomize,
-		Hints:     k8sSummary.Hints,
+		Terraform:  tfSummary.Terraform,
+		Kustomize:  k8sSummary.Kustomize,
+		Hints:      k8sSummary.Hints,
+		Violations: append(tfSummary.Violations, k8sSummary.Violations...),
 	}, nil
 }
 
+// EvaluatePolicies checks plan results against the policies defined under the blueprint's and
+// the context's policies/ directories, exposing operation (policy.OperationPlan or
+// policy.OperationApply) to their expressions. Used by callers that assemble plans themselves,
+// such as single-component plans. Returns nil when no policies are defined, or an error when the
+// policies cannot be loaded or an expression fails to evaluate.
+func (i *Provisioner) EvaluatePolicies(operation string, tf []terraforminfra.TerraformComponentPlan, k8s []fluxinfra.KustomizePlan) ([]policy.Violation, error) {
+	checker, err := i.ensurePolicyChecker()
+	if err != nil {
+		return nil, err
+	}
+	return checker.Check(operation, tf, k8s)
+}
+
 // PlanDestroyTerraformSummary previews the destroy plan for every Terraform
 // component the blueprint would actually tear down (filtering destroy=false
 // pins). Mirrors PlanTerraformSummary but uses `terraform plan -destroy -json`
@@ -838,6 +1008,102 @@ func (i *Provisioner) PlanDestroyAll(blueprint *blueprintv1alpha1.Blueprint) (*D
 	}, nil
 }
 
+// Drift checks every Terraform component and Flux kustomization in the blueprint
+// for out-of-band changes. Terraform components run a refresh-only plan;
+// kustomizations compare flux's recorded inventory against live cluster objects.
+// Failures are recorded per component so one unreachable layer does not hide
+// drift in another. Returns an error only when blueprint is nil or stack
+// initialisation fails.
+func (i *Provisioner) Drift(blueprint *blueprintv1alpha1.Blueprint) (*DriftSummary, error) {
+	if blueprint == nil {
+		return nil, fmt.Errorf("blueprint not provided")
+	}
+
+	summary := &DriftSummary{}
+
+	if err := i.ensureTerraformStack(); err != nil {
+		return nil, err
+	}
+	if i.TerraformStack != nil {
+		summary.Terraform = i.TerraformStack.DriftSummary(blueprint)
+	}
+
+	if err := i.ensureFluxStack(); err != nil {
+		return nil, err
+	}
+	summary.Kustomize = i.FluxStack.DriftSummary(withCrdLayer(blueprint))
+
+	return summary, nil
+}
+
+// Status reads the cluster's reconciliation state for every non-destroyOnly kustomization the
+// blueprint deploys, including the CRD layer and compiled flux system tiers. Each deployed
+// kustomization is reported with its source, read once per distinct source, and with the
+// HelmReleases in its inventory; kustomizations not present in the cluster are reported
+// NotFound without further reads. Per-kustomization read failures are recorded on the entry.
+// Returns an error when blueprint is nil, no kubeconfig exists for the context, or the
+// kustomizations or version marker cannot be read.
+func (i *Provisioner) Status(blueprint *blueprintv1alpha1.Blueprint) (*StatusSummary, error) {
+	if blueprint == nil {
+		return nil, fmt.Errorf("blueprint not provided")
+	}
+	if i.KubernetesManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not configured")
+	}
+	if !i.kubeconfigPresent() {
+		return nil, fmt.Errorf("no kubeconfig found for this context; bootstrap the cluster first")
+	}
+
+	statuses, err := i.KubernetesManager.GetKustomizationStatuses(appliedKustomizationNames(blueprint), i.kustomizationNamespaces(blueprint))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &StatusSummary{}
+	marker, found, err := i.KubernetesManager.GetVersionMarker(i.fluxNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		summary.Marker = &marker
+	}
+
+	sources := make(map[string]kubernetes.FluxStatus)
+	for _, status := range statuses {
+		entry := KustomizationStatusEntry{KustomizationStatus: status}
+		if status.State == kubernetes.FluxStateNotFound {
+			summary.Kustomizations = append(summary.Kustomizations, entry)
+			continue
+		}
+		if status.SourceKind != "" {
+			key := status.SourceKind + "/" + status.SourceNamespace + "/" + status.SourceName
+			source, ok := sources[key]
+			if !ok {
+				var sourceErr error
+				source, sourceErr = i.KubernetesManager.GetSourceStatus(status.SourceKind, status.SourceName, status.SourceNamespace)
+				if sourceErr != nil {
+					entry.Err = sourceErr
+				} else {
+					sources[key] = source
+					ok = true
+				}
+			}
+			if ok {
+				entry.Source = &source
+			}
+		}
+		hrs, err := i.KubernetesManager.GetHelmReleasesForKustomization(status.Name, status.Namespace)
+		if err != nil {
+			entry.Err = errors.Join(entry.Err, err)
+		}
+		for _, hr := range hrs {
+			entry.HelmReleases = append(entry.HelmReleases, kubernetes.HelmReleaseStatus(hr))
+		}
+		summary.Kustomizations = append(summary.Kustomizations, entry)
+	}
+	return summary, nil
+}
+
 // PlanKustomizeAll runs flux diff for every non-destroyOnly kustomization in the blueprint.
 // Returns an error if the flux CLI is not found or any diff fails.
 func (i *Provisioner) PlanKustomizeAll(blueprint *blueprintv1alpha1.Blueprint) error {
@@ -886,9 +1152,11 @@ func (i *Provisioner) PlanKustomization(blueprint *blueprintv1alpha1.Blueprint,
 // kustomization's secrets, applies it via the kubernetes manager, then places the resolved secrets into
 // the namespace it creates — scoped to the one kustomization so placement never blocks on a namespace
 // another kustomization would create. Secret pruning is off, since a single-kustomization apply is
-// additive. Returns an error if the blueprint is nil, the kubernetes manager is not configured, the
-// kustomization is not found, the kustomization is marked destroyOnly, or resolution, apply, or placement
-// fails.
+// additive. When policies are defined the kustomization is planned and checked first. Returns an error
+// if the blueprint is nil, the kubernetes manager is not configured, the kustomization is not found, the
+// kustomization is marked destroyOnly, push mode is configured (the cluster reconciles the whole rendered
+// tree from git, so a single kustomization cannot be applied around it), the gitops engine is Argo CD, a
+// policy denies its plan, or resolution, apply, or placement fails.
 func (i *Provisioner) ApplyKustomize(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint, componentID string) error {
 	if blueprint == nil {
 		return fmt.Errorf("blueprint not provided")
@@ -913,10 +1181,20 @@ func (i *Provisioner) ApplyKustomize(ctx context.Context, blueprint *blueprintv1
 	if found.DestroyOnly != nil && *found.DestroyOnly {
 		return fmt.Errorf("kustomization %q is destroy-only and cannot be applied", componentID)
 	}
+	if i.gitopsMode() == constants.GitopsModePush {
+		return fmt.Errorf("kustomization %q cannot be applied on its own in push mode; apply the blueprint to push the rendered tree", componentID)
+	}
+	if i.gitopsEngine() == constants.GitopsEngineArgoCD {
+		return fmt.Errorf("kustomization %q cannot be applied directly when gitops.engine is argocd; set gitops.mode to push", componentID)
+	}
 
 	filtered := *blueprint
 	filtered.Kustomizations = []blueprintv1alpha1.Kustomization{*found}
 
+	if err := i.checkKustomizePolicies(blueprint, componentID); err != nil {
+		return err
+	}
+
 	resolvedSecrets, err := i.ResolveSecrets(&filtered)
 	if err != nil {
 		return fmt.Errorf("error resolving secrets: %w", err)
@@ -958,8 +1236,12 @@ func (i *Provisioner) ApplyKustomizeAll(ctx context.Context, blueprint *blueprin
 // consumer whose readiness depends on its secret cannot deadlock placement. prune reclaims CLI-placed
 // secrets this context no longer declares, mirroring kustomization prune (on for upgrade and apply
 // --prune, off otherwise). ctx is threaded into Notify and placement so a cancelled parent context
-// (e.g. Ctrl+C) tears down promptly. The blueprint must be provided. Returns an error if resolution,
-// apply, or placement fails.
+// (e.g. Ctrl+C) tears down promptly. When policies are defined, the kustomizations are planned and
+// checked before anything is resolved or applied, and a denial refuses the install. The blueprint must
+// be provided. In push mode (gitops.mode: push) the kustomization layer reaches the cluster through git
+// instead; see Push and installPush. The Argo CD engine (gitops.engine: argocd) is only reached that way.
+// Returns an error if the Argo CD engine is configured without push mode, a policy denies the plan, or
+// resolution, apply, push, or placement fails.
 func (i *Provisioner) Install(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint, prune bool) error {
 	if blueprint == nil {
 		return fmt.Errorf("blueprint not provided")
@@ -969,11 +1251,24 @@ func (i *Provisioner) Install(ctx context.Context, blueprint *blueprintv1alpha1.
 		return fmt.Errorf("kubernetes manager not configured")
 	}
 
+	if i.gitopsEngine() == constants.GitopsEngineArgoCD && i.gitopsMode() != constants.GitopsModePush {
+		return fmt.Errorf("gitops.engine argocd requires gitops.mode push; the CLI does not apply Argo CD Applications directly")
+	}
+	i.awaitingReview = false
+
+	if err := i.checkKustomizePolicies(blueprint, ""); err != nil {
+		return err
+	}
+
 	resolvedSecrets, err := i.ResolveSecrets(blueprint)
 	if err != nil {
 		return fmt.Errorf("error resolving secrets: %w", err)
 	}
 
+	if i.gitopsMode() == constants.GitopsModePush {
+		return i.installPush(ctx, blueprint, resolvedSecrets, prune)
+	}
+
 	applied := withCrdLayer(blueprint)
 
 	if err := tui.WithProgress("Installing blueprint resources", func() error {
@@ -998,15 +1293,28 @@ func (i *Provisioner) Install(ctx context.Context, blueprint *blueprintv1alpha1.
 	return nil
 }
 
+// AwaitingReview reports whether the last Install pushed its change to a review branch that has
+// not yet been merged. Nothing new has reached the cluster then, so callers skip pruning and leave
+// the version marker to the install that follows the merge.
+func (i *Provisioner) AwaitingReview() bool {
+	return i.awaitingReview
+}
+
 // Wait waits for kustomizations from the blueprint to be ready. It initializes the kubernetes manager
 // if needed and polls the status of all kustomizations until they are ready or a timeout occurs.
 // The timeout is calculated from the longest dependency chain in the blueprint. The wait honors ctx,
-// so a cancelled context (caller SIGTERM/Ctrl+C or command deadline) ends it promptly. Returns an
-// error if the kubernetes manager is not configured, initialization fails, or waiting times out.
+// so a cancelled context (caller SIGTERM/Ctrl+C or command deadline) ends it promptly. It returns at
+// once after a push-mode Install left its change awaiting review, since the cluster has nothing new to
+// reconcile until the review branch is merged, or when the gitops engine is Argo CD, whose
+// Applications carry no Flux readiness to poll. Returns an error if the kubernetes manager is not
+// configured, initialization fails, or waiting times out.
 func (i *Provisioner) Wait(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint) error {
 	if blueprint == nil {
 		return fmt.Errorf("blueprint not provided")
 	}
+	if i.awaitingReview || i.gitopsEngine() == constants.GitopsEngineArgoCD {
+		return nil
+	}
 
 	if i.KubernetesManager == nil {
 		return fmt.Errorf("kubernetes manager not configured")
@@ -1183,8 +1491,9 @@ func (i *Provisioner) PlaceSecrets(ctx context.Context, resolved ResolvedSecrets
 			// stalled for lack of the secret, rather than waiting on its scheduled interval.
 			if len(placedOwners) > 0 {
 				owners := sortedStringKeys(placedOwners)
-				i.reconcileKustomizations(ctx, owners)
-				i.forceStalledHelmReleases(ctx, owners)
+				namespaces := i.kustomizationNamespaces(blueprint)
+				i.reconcileKustomizations(ctx, owners, namespaces)
+				i.forceStalledHelmReleases(ctx, owners, namespaces)
 			}
 			if len(pending) == 0 {
 				break
@@ -1332,17 +1641,22 @@ func (i *Provisioner) missingClusterNamespaces(want []string) ([]string, error)
 	return missing, nil
 }
 
-// reconcileKustomizations requests an immediate flux reconcile of the named Kustomizations, best-effort: it
-// initializes the notifier if needed and swallows any error, so a placement round is never failed by a
-// reconcile nudge. A nil or empty names slice is a no-op.
-func (i *Provisioner) reconcileKustomizations(ctx context.Context, names []string) {
+// reconcileKustomizations requests an immediate flux reconcile of the named Kustomizations, each in its
+// namespace from namespaces (falling back to the gitops namespace), best-effort: it initializes the
+// notifier if needed and swallows any error, so a placement round is never failed by a reconcile nudge. A
+// nil or empty names slice is a no-op.
+func (i *Provisioner) reconcileKustomizations(ctx context.Context, names []string, namespaces map[string]string) {
 	if len(names) == 0 {
 		return
 	}
 	if err := i.ensureNotifier(); err != nil {
 		return
 	}
-	_ = i.Notifier.ReconcileKustomizations(ctx, names)
+	refs := make([]fluxinfra.KustomizationRef, 0, len(names))
+	for _, name := range names {
+		refs = append(refs, fluxinfra.KustomizationRef{Namespace: i.kustomizationNamespace(name, namespaces), Name: name})
+	}
+	_ = i.Notifier.ReconcileKustomizations(ctx, refs)
 }
 
 // helmReleaseReady reports whether a HelmRelease currently carries a Ready=True condition.
@@ -1359,13 +1673,13 @@ func helmReleaseReady(hr helmv2.HelmRelease) bool {
 // Ready. A release that failed to install or upgrade — e.g. because a secret it needs was not yet present —
 // stalls and will not retry on a plain reconcile of its owning Kustomization, since the release spec is
 // unchanged; forcing it (requestedAt + forceAt) makes helm-controller retry now that its inputs may be in
-// place. Best-effort: read failures and the reconcile request are swallowed, and healthy releases are left
-// untouched so no needless upgrade fires.
-func (i *Provisioner) forceStalledHelmReleases(ctx context.Context, kustomizations []string) {
+// place. Each kustomization is looked up in its namespace from namespaces. Best-effort: read failures and
+// the reconcile request are swallowed, and healthy releases are left untouched so no needless upgrade fires.
+func (i *Provisioner) forceStalledHelmReleases(ctx context.Context, kustomizations []string, namespaces map[string]string) {
 	var refs []fluxinfra.HelmReleaseRef
 	seen := make(map[string]struct{})
 	for _, name := range kustomizations {
-		hrs, err := i.KubernetesManager.GetHelmReleasesForKustomization(name, i.fluxNamespace())
+		hrs, err := i.KubernetesManager.GetHelmReleasesForKustomization(name, i.kustomizationNamespace(name, namespaces))
 		if err != nil {
 			continue
 		}
@@ -1463,13 +1777,16 @@ func (i *Provisioner) nudgeFrontier(ctx context.Context, blueprint *blueprintv1a
 	if len(names) == 0 {
 		return nil
 	}
-	readiness, err := i.KubernetesManager.GetKustomizationReadiness(names)
+	namespaces := i.kustomizationNamespaces(blueprint)
+	readiness, err := i.KubernetesManager.GetKustomizationReadiness(names, namespaces)
 	if err != nil {
 		return names // unknown readiness: report all not-Ready so a caller keeps waiting, but nudge nothing
 	}
 	deps := make(map[string][]string, len(blueprint.Kustomizations))
 	for _, k := range blueprint.Kustomizations {
-		deps[k.Name] = k.DependsOn
+		for _, dep := range k.DependsOn {
+			deps[k.Name] = append(deps[k.Name], blueprintv1alpha1.KustomizationDependencyName(dep))
+		}
 	}
 
 	var notReady, frontier []string
@@ -1498,8 +1815,8 @@ func (i *Provisioner) nudgeFrontier(ctx context.Context, blueprint *blueprintv1a
 		for _, n := range frontier {
 			nudged[n] = struct{}{}
 		}
-		i.reconcileKustomizations(ctx, frontier)
-		i.forceStalledHelmReleases(ctx, frontier)
+		i.reconcileKustomizations(ctx, frontier, namespaces)
+		i.forceStalledHelmReleases(ctx, frontier, namespaces)
 	}
 	slices.Sort(notReady)
 	return notReady
@@ -1592,11 +1909,12 @@ func (i *Provisioner) Prune(blueprint *blueprintv1alpha1.Blueprint) error {
 	return nil
 }
 
-// PrunableKustomizations returns the names of this context's Kustomizations that the blueprint no
-// longer declares — exactly what Prune would delete. It is the read-only input to plan's prune
-// preview and upgrade's confirmation gate; it deletes nothing. It prepares the blueprint with the
-// synthesized CRD layers so the desired set matches what Prune deletes against.
-func (i *Provisioner) PrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint) ([]string, error) {
+// PrunableKustomizations returns this context's Kustomizations that the blueprint no longer
+// declares — exactly what Prune would delete — each with its Name and the Namespace its object
+// lives in. It is the read-only input to plan's prune preview and upgrade's confirmation gate; it
+// deletes nothing. It prepares the blueprint with the synthesized CRD layers so the desired set
+// matches what Prune deletes against.
+func (i *Provisioner) PrunableKustomizations(blueprint *blueprintv1alpha1.Blueprint) ([]blueprintv1alpha1.Kustomization, error) {
 	if blueprint == nil {
 		return nil, fmt.Errorf("blueprint not provided")
 	}
@@ -1606,11 +1924,53 @@ func (i *Provisioner) PrunableKustomizations(blueprint *blueprintv1alpha1.Bluepr
 
 	blueprint = withCrdLayer(blueprint)
 
-	names, err := i.KubernetesManager.ListPrunableKustomizations(blueprint, i.fluxNamespace())
+	prunable, err := i.KubernetesManager.ListPrunableKustomizations(blueprint, i.fluxNamespace())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list prunable kustomizations: %w", err)
 	}
-	return names, nil
+	return prunable, nil
+}
+
+// AnalyzePrune classifies every object that pruning the given kustomizations would delete, retain
+// or orphan, flagging those whose deletion destroys persistent data. prunable is the set
+// PrunableKustomizations returned, each read in its own namespace; the analysis reads the cluster
+// and deletes nothing.
+func (i *Provisioner) AnalyzePrune(prunable []blueprintv1alpha1.Kustomization) ([]kubernetes.PruneObject, error) {
+	if i.KubernetesManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not configured")
+	}
+	if len(prunable) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(prunable))
+	namespaces := make(map[string]string, len(prunable))
+	for _, k := range prunable {
+		names = append(names, k.Name)
+		namespaces[k.Name] = k.ObjectNamespace(i.fluxNamespace())
+	}
+	objs, err := i.KubernetesManager.AnalyzePrune(names, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze prune: %w", err)
+	}
+	return objs, nil
+}
+
+// ManagedObjects lists the cluster objects this context applied, read from the provenance labels
+// windsor stamps on them, limited to those from the named origin source when origin is non-empty.
+func (i *Provisioner) ManagedObjects(origin string) ([]kubernetes.ManagedObject, error) {
+	if i.KubernetesManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not configured")
+	}
+	if !i.kubeconfigPresent() {
+		return nil, fmt.Errorf("no kubeconfig found for this context; bootstrap the cluster first")
+	}
+
+	objs, err := i.KubernetesManager.ListManagedObjects(origin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed objects: %w", err)
+	}
+	return objs, nil
 }
 
 // GetVersionMarker reads the applied-version marker for this context's gitops namespace, reporting
@@ -1976,6 +2336,18 @@ func (i *Provisioner) ensureClusterClient() error {
 	return nil
 }
 
+// declaredKustomizePlans returns the plans of kustomizations the blueprint declares, dropping the
+// reclaimed rows PlanSummary appends for kustomizations it no longer declares.
+func declaredKustomizePlans(plans []fluxinfra.KustomizePlan) []fluxinfra.KustomizePlan {
+	declared := make([]fluxinfra.KustomizePlan, 0, len(plans))
+	for _, p := range plans {
+		if p.Transition != fluxinfra.TransitionReclaimed {
+			declared = append(declared, p)
+		}
+	}
+	return declared
+}
+
 // withCrdLayer returns a copy of the blueprint with synthesized CRD kustomizations prepended ahead of
 // the stack. Pruning is disabled (pruning a CRD deletes every custom resource of that kind cluster-wide)
 // and wait is enabled so dependents block until the CRDs are Established. Returns the blueprint unchanged
@@ -2038,6 +2410,97 @@ func (i *Provisioner) ensureTerraformStack() error {
 	return nil
 }
 
+// forwardTerraformConcurrency passes the concurrency set via SetTerraformConcurrency to the
+// terraform stack. A zero value leaves the stack's own setting untouched. The stack must exist.
+func (i *Provisioner) forwardTerraformConcurrency() {
+	if i.terraformConcurrency > 0 {
+		i.TerraformStack.SetMaxConcurrency(i.terraformConcurrency)
+	}
+}
+
+// ensurePolicyChecker loads the policies of the composed blueprint's sources, its local template
+// and the context on first use and caches the resulting checker. The checker is empty when no
+// policies are defined.
+func (i *Provisioner) ensurePolicyChecker() (*policy.Checker, error) {
+	if i.policiesLoaded {
+		return i.policyChecker, nil
+	}
+	policies, err := policy.Load(i.blueprintHandler.GetSourceTemplateData(), i.runtime.TemplateRoot, i.configRoot)
+	if err != nil {
+		return nil, fmt.Errorf("error loading policies: %w", err)
+	}
+	i.policyChecker = policy.NewChecker(i.evaluator, policies)
+	i.policiesLoaded = true
+	return i.policyChecker, nil
+}
+
+// forwardPolicyCheck registers a plan check on the terraform stack that refuses any component
+// whose saved plan violates a policy, evaluated for the named operation. Nothing is registered
+// when no policies are defined, so the stack skips the extra `terraform show` per component. The
+// stack must exist.
+func (i *Provisioner) forwardPolicyCheck(operation string) error {
+	checker, err := i.ensurePolicyChecker()
+	if err != nil {
+		return err
+	}
+	if checker.Len() == 0 {
+		return nil
+	}
+	i.TerraformStack.SetPlanCheck(func(componentID string, changes []terraforminfra.ResourceChange) error {
+		violations, err := checker.CheckTerraform(operation, componentID, changes)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			return &policy.DeniedError{Violations: violations}
+		}
+		return nil
+	})
+	return nil
+}
+
+// checkKustomizePolicies plans the blueprint's kustomizations and refuses with a policy.DeniedError
+// when any planned change violates a policy. name limits the check to one kustomization; empty checks
+// every declared one. The gate fails closed: a kustomization that could not be planned, or whose plan
+// is degraded to no resources because the flux or kustomize CLI is missing or its source is a bucket
+// with no local copy, is refused too, since its changes cannot be checked. The plan's hints are only
+// rendered by windsor plan and are dropped here. A no-op when no policies are defined.
+func (i *Provisioner) checkKustomizePolicies(blueprint *blueprintv1alpha1.Blueprint, name string) error {
+	checker, err := i.ensurePolicyChecker()
+	if err != nil {
+		return err
+	}
+	if checker.Len() == 0 {
+		return nil
+	}
+	if err := i.ensureFluxStack(); err != nil {
+		return err
+	}
+	var plans []fluxinfra.KustomizePlan
+	if name != "" {
+		plans = []fluxinfra.KustomizePlan{i.FluxStack.PlanComponentSummary(withCrdLayer(blueprint), name)}
+	} else {
+		plans, _ = i.FluxStack.PlanSummary(withCrdLayer(blueprint))
+	}
+	plans = declaredKustomizePlans(plans)
+	for _, plan := range plans {
+		if plan.Err != nil {
+			return fmt.Errorf("cannot check policies: kustomization %s could not be planned: %w", plan.Name, plan.Err)
+		}
+		if plan.Degraded {
+			return fmt.Errorf("cannot check policies: kustomization %s could not be planned; install the flux and kustomize CLIs and make its source available locally", plan.Name)
+		}
+	}
+	violations, err := checker.Check(policy.OperationApply, nil, plans)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &policy.DeniedError{Violations: violations}
+	}
+	return nil
+}
+
 // ensureFluxStack initializes the FluxStack if it is not already initialized.
 func (i *Provisioner) ensureFluxStack() error {
 	if i.FluxStack != nil {
@@ -2073,6 +2536,29 @@ func (i *Provisioner) fluxNamespace() string {
 	return i.configHandler.GetString("gitops.namespace", constants.DefaultGitopsNamespace)
 }
 
+// kustomizationNamespaces maps each kustomization the blueprint applies, including the CRD layer, to
+// the namespace its Flux Kustomization object lives in. A nil blueprint yields an empty map.
+func (i *Provisioner) kustomizationNamespaces(blueprint *blueprintv1alpha1.Blueprint) map[string]string {
+	namespaces := make(map[string]string)
+	if blueprint == nil {
+		return namespaces
+	}
+	namespace := i.fluxNamespace()
+	for _, k := range withCrdLayer(blueprint).AllKustomizations() {
+		namespaces[k.Name] = k.ObjectNamespace(namespace)
+	}
+	return namespaces
+}
+
+// kustomizationNamespace returns the namespace of the named kustomization from namespaces, or the
+// gitops namespace when it has none.
+func (i *Provisioner) kustomizationNamespace(name string, namespaces map[string]string) string {
+	if ns := namespaces[name]; ns != "" {
+		return ns
+	}
+	return i.fluxNamespace()
+}
+
 // kubeconfigPresent reports whether the context-scoped kubeconfig file exists on
 // disk. Used by destroy paths to decide whether to attempt kustomization deletion:
 // the cluster is gone (or was never bootstrapped past terraform) when the file is
@@ -2,6 +2,7 @@ package provisioner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
 	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
 	k8sclient "github.com/windsorcli/cli/pkg/provisioner/kubernetes/client"
+	"github.com/windsorcli/cli/pkg/provisioner/policy"
 	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
 	"github.com/windsorcli/cli/pkg/runtime"
 	"github.com/windsorcli/cli/pkg/runtime/config"
@@ -166,6 +168,15 @@ func setupProvisionerMocks(t *testing.T, opts ...func(*ProvisionerTestMocks)) *P
 	return mocks
 }
 
+// kustomizationRefNames returns the names of the given kustomization references, in order.
+func kustomizationRefNames(refs []fluxinfra.KustomizationRef) []string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
 // =============================================================================
 // Test Constructor
 // =============================================================================
@@ -437,6 +448,24 @@ func TestProvisioner_Up(t *testing.T) {
 		}
 	})
 
+	t.Run("ForwardsTerraformConcurrencyToStack", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		var captured int
+		mockStack := terraforminfra.NewMockStack()
+		mockStack.SetMaxConcurrencyFunc = func(n int) {
+			captured = n
+		}
+		opts := &Provisioner{TerraformStack: mockStack}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, opts)
+		provisioner.SetTerraformConcurrency(4)
+
+		_, _ = provisioner.Up(createTestBlueprint())
+
+		if captured != 4 {
+			t.Errorf("Expected stack max concurrency 4, got %d", captured)
+		}
+	})
+
 }
 
 func TestProvisioner_MigrateState(t *testing.T) {
@@ -1015,6 +1044,196 @@ func TestProvisioner_Apply(t *testing.T) {
 			t.Errorf("Expected specific error message, got: %v", err)
 		}
 	})
+
+	t.Run("ApplyWithOptionsForwardsOptions", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		var got terraforminfra.ApplyOptions
+		mockStack.ApplyWithOptionsFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string, opts terraforminfra.ApplyOptions) error {
+			got = opts
+			return nil
+		}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: mockStack})
+
+		err := provisioner.ApplyWithOptions(createTestBlueprint(), "remote/path", terraforminfra.ApplyOptions{Targets: []string{"a.b"}, Confirmed: true})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if len(got.Targets) != 1 || got.Targets[0] != "a.b" || !got.Confirmed {
+			t.Errorf("Expected options to reach the stack, got %+v", got)
+		}
+	})
+
+	t.Run("PartialApplyErrorIsWrapped", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		mockStack.ApplyWithOptionsFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string, opts terraforminfra.ApplyOptions) error {
+			return &terraforminfra.PartialApplyError{ComponentID: componentID}
+		}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: mockStack})
+
+		err := provisioner.ApplyWithOptions(createTestBlueprint(), "remote/path", terraforminfra.ApplyOptions{Targets: []string{"a.b"}})
+
+		var partial *terraforminfra.PartialApplyError
+		if !errors.As(err, &partial) {
+			t.Errorf("Expected wrapped PartialApplyError, got: %v", err)
+		}
+	})
+}
+
+func TestProvisioner_TerraformState(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		var gotArgs []string
+		mockStack.StateFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+			gotArgs = args
+			return "module.main.null_resource.a\n", nil
+		}
+		opts := &Provisioner{TerraformStack: mockStack}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, opts)
+
+		output, err := provisioner.TerraformState(createTestBlueprint(), "remote/path", "list")
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if output != "module.main.null_resource.a\n" {
+			t.Errorf("Expected state output to be returned, got %q", output)
+		}
+		if len(gotArgs) != 1 || gotArgs[0] != "list" {
+			t.Errorf("Expected args [list], got %v", gotArgs)
+		}
+	})
+
+	t.Run("ErrorNilBlueprint", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler)
+
+		_, err := provisioner.TerraformState(nil, "remote/path", "list")
+
+		if err == nil || !strings.Contains(err.Error(), "blueprint not provided") {
+			t.Errorf("Expected blueprint not provided error, got: %v", err)
+		}
+	})
+
+	t.Run("ErrorTerraformStackState", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		mockStack.StateFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+			return "", fmt.Errorf("state failed")
+		}
+		opts := &Provisioner{TerraformStack: mockStack}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, opts)
+
+		_, err := provisioner.TerraformState(createTestBlueprint(), "remote/path", "rm", "a.b")
+
+		if err == nil || !strings.Contains(err.Error(), "failed to run terraform state for remote/path") {
+			t.Errorf("Expected wrapped state error, got: %v", err)
+		}
+	})
+}
+
+func TestProvisioner_SnapshotTerraformState(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		mockStack.SnapshotStateFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string) (string, error) {
+			return "/snapshots/" + componentID + "/1.tfstate", nil
+		}
+		opts := &Provisioner{TerraformStack: mockStack}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, opts)
+
+		path, err := provisioner.SnapshotTerraformState(createTestBlueprint(), "remote/path")
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if path != "/snapshots/remote/path/1.tfstate" {
+			t.Errorf("Expected snapshot path to be returned, got %q", path)
+		}
+	})
+
+	t.Run("ErrorNilBlueprint", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler)
+
+		_, err := provisioner.SnapshotTerraformState(nil, "remote/path")
+
+		if err == nil || !strings.Contains(err.Error(), "blueprint not provided") {
+			t.Errorf("Expected blueprint not provided error, got: %v", err)
+		}
+	})
+
+	t.Run("ErrorTerraformStackSnapshot", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		mockStack.SnapshotStateFunc = func(bp *blueprintv1alpha1.Blueprint, componentID string) (string, error) {
+			return "", fmt.Errorf("pull failed")
+		}
+		opts := &Provisioner{TerraformStack: mockStack}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, opts)
+
+		_, err := provisioner.SnapshotTerraformState(createTestBlueprint(), "remote/path")
+
+		if err == nil || !strings.Contains(err.Error(), "failed to snapshot terraform state for remote/path") {
+			t.Errorf("Expected wrapped snapshot error, got: %v", err)
+		}
+	})
+}
+
+func TestProvisioner_RestoreTerraformState(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		var gotSnapshot string
+		mockStack.RestoreStateFunc = func(bp *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error) {
+			gotSnapshot = snapshotID
+			return "/snapshots/" + componentID + "/2.tfstate", nil
+		}
+		opts := &Provisioner{TerraformStack: mockStack}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, opts)
+
+		backupPath, err := provisioner.RestoreTerraformState(createTestBlueprint(), "remote/path", "1")
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if gotSnapshot != "1" {
+			t.Errorf("Expected snapshot ID to be forwarded, got %q", gotSnapshot)
+		}
+		if backupPath != "/snapshots/remote/path/2.tfstate" {
+			t.Errorf("Expected backup path to be returned, got %q", backupPath)
+		}
+	})
+
+	t.Run("ErrorNilBlueprint", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler)
+
+		_, err := provisioner.RestoreTerraformState(nil, "remote/path", "1")
+
+		if err == nil || !strings.Contains(err.Error(), "blueprint not provided") {
+			t.Errorf("Expected blueprint not provided error, got: %v", err)
+		}
+	})
+
+	t.Run("ErrorTerraformStackRestore", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mockStack := terraforminfra.NewMockStack()
+		mockStack.RestoreStateFunc = func(bp *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error) {
+			return "", fmt.Errorf("lineage mismatch")
+		}
+		opts := &Provisioner{TerraformStack: mockStack}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, opts)
+
+		_, err := provisioner.RestoreTerraformState(createTestBlueprint(), "remote/path", "1")
+
+		if err == nil || !strings.Contains(err.Error(), "failed to restore terraform state for remote/path") {
+			t.Errorf("Expected wrapped restore error, got: %v", err)
+		}
+	})
 }
 
 func TestProvisioner_ApplyKustomize(t *testing.T) {
@@ -1404,9 +1623,9 @@ func TestProvisioner_PrunableKustomizations(t *testing.T) {
 		// Given a manager that reports an orphan kustomization
 		mocks := setupProvisionerMocks(t)
 		var capturedNamespace string
-		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]string, error) {
+		mocks.KubernetesManager.ListPrunableKustomizationsFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]blueprintv1alpha1.Kustomization, error) {
 			capturedNamespace = namespace
-			return []string{"old-thing"}, nil
+			return []blueprintv1alpha1.Kustomization{{Name: "old-thing"}}, nil
 		}
 		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
 
@@ -1420,7 +1639,7 @@ func TestProvisioner_PrunableKustomizations(t *testing.T) {
 		if capturedNamespace != provisioner.fluxNamespace() {
 			t.Errorf("Expected namespace %q, got %q", provisioner.fluxNamespace(), capturedNamespace)
 		}
-		if len(got) != 1 || got[0] != "old-thing" {
+		if len(got) != 1 || got[0].Name != "old-thing" {
 			t.Errorf("Expected [old-thing], got %v", got)
 		}
 	})
@@ -1443,6 +1662,99 @@ func TestProvisioner_PrunableKustomizations(t *testing.T) {
 	})
 }
 
+func TestProvisioner_AnalyzePrune(t *testing.T) {
+	t.Run("DelegatesEachKustomizationInItsNamespace", func(t *testing.T) {
+		// Given a manager that classifies one object per orphan kustomization
+		mocks := setupProvisionerMocks(t)
+		var captured map[string]string
+		mocks.KubernetesManager.AnalyzePruneFunc = func(names []string, namespaces map[string]string) ([]kubernetes.PruneObject, error) {
+			captured = namespaces
+			return []kubernetes.PruneObject{{Kustomization: names[0], Disposition: kubernetes.PruneDelete}}, nil
+		}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When the prune of one orphan in the gitops namespace and one in team-x is analyzed
+		got, err := provisioner.AnalyzePrune([]blueprintv1alpha1.Kustomization{{Name: "old-thing"}, {Name: "old-app", Namespace: "team-x"}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then each kustomization is analyzed in its own namespace
+		if captured["old-thing"] != provisioner.fluxNamespace() || captured["old-app"] != "team-x" {
+			t.Errorf("Expected old-thing in %q and old-app in team-x, got %v", provisioner.fluxNamespace(), captured)
+		}
+		if len(got) != 1 || got[0].Kustomization != "old-thing" {
+			t.Errorf("Expected old-thing classified, got %+v", got)
+		}
+	})
+
+	t.Run("SkipsClusterWhenNothingIsPrunable", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mocks.KubernetesManager.AnalyzePruneFunc = func(names []string, namespaces map[string]string) ([]kubernetes.PruneObject, error) {
+			t.Error("Expected no analysis without prunable kustomizations")
+			return nil, nil
+		}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+		if got, err := provisioner.AnalyzePrune(nil); err != nil || got != nil {
+			t.Errorf("Expected nothing, got %+v, %v", got, err)
+		}
+	})
+}
+
+func TestProvisioner_ManagedObjects(t *testing.T) {
+	t.Run("DelegatesOriginToManager", func(t *testing.T) {
+		// Given a manager that lists one object from the core origin
+		mocks := setupProvisionerMocks(t)
+		var gotOrigin string
+		mocks.KubernetesManager.ListManagedObjectsFunc = func(origin string) ([]kubernetes.ManagedObject, error) {
+			gotOrigin = origin
+			return []kubernetes.ManagedObject{{Kind: "Kustomization", Name: "dns", Origin: origin}}, nil
+		}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When the core origin's objects are listed
+		got, err := provisioner.ManagedObjects("core")
+
+		// Then the origin is passed through and the objects returned
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotOrigin != "core" || len(got) != 1 || got[0].Name != "dns" {
+			t.Errorf("Expected dns from core, got origin %q and %+v", gotOrigin, got)
+		}
+	})
+
+	t.Run("ReturnsErrorWithoutKubeconfig", func(t *testing.T) {
+		// Given a context with no cluster
+		mocks := setupProvisionerMocks(t)
+		mocks.Runtime.ConfigRoot = t.TempDir()
+		mocks.KubernetesManager.ListManagedObjectsFunc = func(origin string) ([]kubernetes.ManagedObject, error) {
+			t.Error("Expected the cluster not to be consulted without a kubeconfig")
+			return nil, nil
+		}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When managed objects are listed
+		_, err := provisioner.ManagedObjects("")
+
+		// Then it asks for the cluster to be bootstrapped
+		if err == nil || !strings.Contains(err.Error(), "no kubeconfig found") {
+			t.Errorf("Expected no kubeconfig error, got %v", err)
+		}
+	})
+
+	t.Run("WrapsManagerError", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mocks.KubernetesManager.ListManagedObjectsFunc = func(origin string) ([]kubernetes.ManagedObject, error) {
+			return nil, fmt.Errorf("forbidden")
+		}
+		provisioner := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+		if _, err := provisioner.ManagedObjects(""); err == nil || !strings.Contains(err.Error(), "failed to list managed objects: forbidden") {
+			t.Errorf("Expected wrapped error, got %v", err)
+		}
+	})
+}
+
 func TestProvisioner_GetVersionMarker(t *testing.T) {
 	t.Run("DelegatesToManagerWhenKubeconfigPresent", func(t *testing.T) {
 		// Given a manager that returns a marker and a context with a kubeconfig
@@ -4293,6 +4605,361 @@ func TestProvisioner_PlanDestroyAll(t *testing.T) {
 	})
 }
 
+func TestProvisioner_Drift(t *testing.T) {
+	t.Run("ReturnsErrorForNilBlueprint", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{
+			TerraformStack: mocks.TerraformStack,
+			FluxStack:      mocks.FluxStack,
+		})
+
+		summary, err := p.Drift(nil)
+		if err == nil {
+			t.Fatal("expected error for nil blueprint, got nil")
+		}
+		if summary != nil {
+			t.Errorf("expected nil summary, got %v", summary)
+		}
+	})
+
+	t.Run("AggregatesBothLayers", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mocks.TerraformStack.(*terraforminfra.MockStack).DriftSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) []terraforminfra.TerraformComponentPlan {
+			return []terraforminfra.TerraformComponentPlan{{ComponentID: "cluster", Change: 1}}
+		}
+		mocks.FluxStack.DriftSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) []fluxinfra.KustomizePlan {
+			return []fluxinfra.KustomizePlan{{Name: "monitoring", Err: fmt.Errorf("connection refused")}}
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{
+			TerraformStack: mocks.TerraformStack,
+			FluxStack:      mocks.FluxStack,
+		})
+
+		summary, err := p.Drift(createTestBlueprint())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(summary.Terraform) != 1 || summary.Terraform[0].ComponentID != "cluster" {
+			t.Errorf("expected terraform drift result for cluster, got %v", summary.Terraform)
+		}
+		if len(summary.Kustomize) != 1 || summary.Kustomize[0].Err == nil {
+			t.Errorf("expected per-kustomization error to be carried through, got %v", summary.Kustomize)
+		}
+	})
+}
+
+func TestProvisioner_Status(t *testing.T) {
+	t.Run("NilBlueprintReturnsError", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+		if _, err := p.Status(nil); err == nil {
+			t.Error("Expected error for nil blueprint")
+		}
+	})
+
+	t.Run("ErrorsWithoutKubeconfig", func(t *testing.T) {
+		// Given a context whose config root has no kubeconfig
+		mocks := setupProvisionerMocks(t)
+		mocks.Runtime.ConfigRoot = t.TempDir()
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When status is read
+		_, err := p.Status(&blueprintv1alpha1.Blueprint{})
+
+		// Then it points at bootstrapping the cluster
+		if err == nil || !strings.Contains(err.Error(), "bootstrap") {
+			t.Errorf("Expected no-kubeconfig error, got %v", err)
+		}
+	})
+
+	t.Run("CollectsSourcesAndHelmReleasesForDeployedKustomizations", func(t *testing.T) {
+		// Given two deployed kustomizations sharing a source, one missing, and a destroyOnly one
+		mocks := setupProvisionerMocks(t)
+		destroyOnly := true
+		bp := &blueprintv1alpha1.Blueprint{Kustomizations: []blueprintv1alpha1.Kustomization{
+			{Name: "ingress"},
+			{Name: "dns"},
+			{Name: "gone"},
+			{Name: "cleanup", DestroyOnly: &destroyOnly},
+		}}
+		var requested []string
+		mocks.KubernetesManager.GetKustomizationStatusesFunc = func(names []string, namespaces map[string]string) ([]kubernetes.KustomizationStatus, error) {
+			requested = names
+			deployed := func(name string) kubernetes.KustomizationStatus {
+				return kubernetes.KustomizationStatus{
+					FluxStatus: kubernetes.FluxStatus{Name: name, State: kubernetes.FluxStateReady},
+					SourceKind: "GitRepository", SourceName: "local", SourceNamespace: "system-gitops",
+				}
+			}
+			return []kubernetes.KustomizationStatus{
+				deployed("ingress"),
+				deployed("dns"),
+				{FluxStatus: kubernetes.FluxStatus{Name: "gone", State: kubernetes.FluxStateNotFound}},
+			}, nil
+		}
+		sourceReads := 0
+		mocks.KubernetesManager.GetSourceStatusFunc = func(kind, name, namespace string) (kubernetes.FluxStatus, error) {
+			sourceReads++
+			return kubernetes.FluxStatus{Kind: kind, Name: name, Namespace: namespace, State: kubernetes.FluxStateReady}, nil
+		}
+		mocks.KubernetesManager.GetHelmReleasesForKustomizationFunc = func(name, namespace string) ([]helmv2.HelmRelease, error) {
+			if name == "dns" {
+				return nil, fmt.Errorf("inventory unreadable")
+			}
+			return []helmv2.HelmRelease{{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "ingress"}}}, nil
+		}
+		mocks.KubernetesManager.GetVersionMarkerFunc = func(namespace string) (kubernetes.VersionMarker, bool, error) {
+			return kubernetes.VersionMarker{Phase: kubernetes.VersionMarkerPhaseIdle}, true, nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When status is read
+		summary, err := p.Status(bp)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then destroyOnly is skipped, the shared source is read once, and per-entry failures are recorded
+		if slices.Contains(requested, "cleanup") {
+			t.Errorf("Expected destroyOnly kustomization to be skipped, got %v", requested)
+		}
+		if sourceReads != 1 {
+			t.Errorf("Expected the shared source to be read once, got %d", sourceReads)
+		}
+		if summary.Marker == nil || summary.Marker.Phase != kubernetes.VersionMarkerPhaseIdle {
+			t.Errorf("Expected the version marker, got %+v", summary.Marker)
+		}
+		if len(summary.Kustomizations) != 3 {
+			t.Fatalf("Expected 3 entries, got %d", len(summary.Kustomizations))
+		}
+		ingress, dns, gone := summary.Kustomizations[0], summary.Kustomizations[1], summary.Kustomizations[2]
+		if ingress.Source == nil || len(ingress.HelmReleases) != 1 || ingress.HelmReleases[0].Name != "nginx" {
+			t.Errorf("Expected ingress source and helm release, got %+v", ingress)
+		}
+		if dns.Source == nil || dns.Err == nil {
+			t.Errorf("Expected dns source and helm release error, got %+v", dns)
+		}
+		if gone.Source != nil || gone.Err != nil {
+			t.Errorf("Expected missing kustomization to have no further reads, got %+v", gone)
+		}
+	})
+}
+
+func TestProvisioner_Policies(t *testing.T) {
+	writePolicies := func(t *testing.T, mocks *ProvisionerTestMocks, content string) {
+		t.Helper()
+		dir := filepath.Join(mocks.Runtime.ConfigRoot, "policies")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create policies dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "policies.yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write policies: %v", err)
+		}
+	}
+	noDeletes := `policies:
+  - name: no-deletes
+    deny: action == "delete"
+    message: ${address} would be deleted
+`
+
+	t.Run("PlanAllReportsViolationsFromBothLayers", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		writePolicies(t, mocks, noDeletes)
+		mocks.TerraformStack.(*terraforminfra.MockStack).PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) []terraforminfra.TerraformComponentPlan {
+			return []terraforminfra.TerraformComponentPlan{{ComponentID: "database", Destroy: 1, Resources: []terraforminfra.ResourceChange{
+				{Address: "aws_db_instance.main", Action: terraforminfra.ActionDelete, Type: "aws_db_instance"},
+			}}}
+		}
+		mocks.FluxStack.PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) ([]fluxinfra.KustomizePlan, []string) {
+			return []fluxinfra.KustomizePlan{{Name: "apps", Resources: []fluxinfra.ResourceChange{
+				{Address: "Namespace/apps", Action: fluxinfra.ActionDelete},
+			}}}, nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{
+			TerraformStack: mocks.TerraformStack,
+			FluxStack:      mocks.FluxStack,
+		})
+
+		summary, err := p.PlanAll(createTestBlueprint())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(summary.Violations) != 2 {
+			t.Fatalf("expected 2 violations, got %v", summary.Violations)
+		}
+		if summary.Violations[0].Message != "aws_db_instance.main would be deleted" || summary.Violations[1].Component != "apps" {
+			t.Errorf("unexpected violations %v", summary.Violations)
+		}
+	})
+
+	t.Run("ApplyRegistersDenyingPlanCheck", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		writePolicies(t, mocks, noDeletes)
+		stack := mocks.TerraformStack.(*terraforminfra.MockStack)
+		var check func(string, []terraforminfra.ResourceChange) error
+		stack.SetPlanCheckFunc = func(fn func(string, []terraforminfra.ResourceChange) error) {
+			check = fn
+		}
+		stack.ApplyFunc = func(bp *blueprintv1alpha1.Blueprint, id string) error {
+			return check(id, []terraforminfra.ResourceChange{{Address: "aws_db_instance.main", Action: terraforminfra.ActionDelete}})
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: stack})
+
+		err := p.Apply(createTestBlueprint(), "database")
+
+		var denied *policy.DeniedError
+		if !errors.As(err, &denied) {
+			t.Fatalf("expected policy.DeniedError, got %v", err)
+		}
+		if len(denied.Violations) != 1 || denied.Violations[0].Component != "database" {
+			t.Errorf("unexpected violations %v", denied.Violations)
+		}
+	})
+
+	t.Run("SkipsPlanCheckWithoutPolicies", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		stack := mocks.TerraformStack.(*terraforminfra.MockStack)
+		registered := false
+		stack.SetPlanCheckFunc = func(fn func(string, []terraforminfra.ResourceChange) error) {
+			registered = true
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: stack})
+
+		if _, err := p.Up(createTestBlueprint()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if registered {
+			t.Error("expected no plan check to be registered when no policies exist")
+		}
+	})
+
+	t.Run("InstallRefusesDeniedKustomizePlan", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		writePolicies(t, mocks, noDeletes)
+		mocks.FluxStack.PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) ([]fluxinfra.KustomizePlan, []string) {
+			return []fluxinfra.KustomizePlan{{Name: "apps", Resources: []fluxinfra.ResourceChange{
+				{Address: "Namespace/apps", Action: fluxinfra.ActionDelete},
+			}}}, nil
+		}
+		applied := false
+		mocks.KubernetesManager.ApplyBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+			applied = true
+			return nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{
+			FluxStack:         mocks.FluxStack,
+			KubernetesManager: mocks.KubernetesManager,
+		})
+
+		err := p.Install(context.Background(), createTestBlueprint(), false)
+
+		var denied *policy.DeniedError
+		if !errors.As(err, &denied) {
+			t.Fatalf("expected policy.DeniedError, got %v", err)
+		}
+		if applied {
+			t.Error("expected blueprint not to be applied after a policy denial")
+		}
+	})
+
+	t.Run("InstallRefusesUncheckableKustomizePlan", func(t *testing.T) {
+		for name, plan := range map[string]fluxinfra.KustomizePlan{
+			"Failed":   {Name: "apps", Err: fmt.Errorf("flux diff failed")},
+			"Degraded": {Name: "apps", Degraded: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				mocks := setupProvisionerMocks(t)
+				writePolicies(t, mocks, noDeletes)
+				mocks.FluxStack.PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) ([]fluxinfra.KustomizePlan, []string) {
+					return []fluxinfra.KustomizePlan{plan}, nil
+				}
+				applied := false
+				mocks.KubernetesManager.ApplyBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) error {
+					applied = true
+					return nil
+				}
+				p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{
+					FluxStack:         mocks.FluxStack,
+					KubernetesManager: mocks.KubernetesManager,
+				})
+
+				err := p.Install(context.Background(), createTestBlueprint(), false)
+
+				if err == nil || !strings.Contains(err.Error(), "cannot check policies: kustomization apps") {
+					t.Fatalf("expected the unplannable kustomization to be refused, got %v", err)
+				}
+				if applied {
+					t.Error("expected blueprint not to be applied when policies cannot be checked")
+				}
+			})
+		}
+	})
+
+	t.Run("PlanReportsViolationsOfSourcePolicies", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		mocks.BlueprintHandler.(*blueprint.MockBlueprintHandler).GetSourceTemplateDataFunc = func() map[string]map[string][]byte {
+			return map[string]map[string][]byte{"core": {"policies/core.yaml": []byte(noDeletes)}}
+		}
+		mocks.TerraformStack.(*terraforminfra.MockStack).PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) []terraforminfra.TerraformComponentPlan {
+			return []terraforminfra.TerraformComponentPlan{{ComponentID: "database", Destroy: 1, Resources: []terraforminfra.ResourceChange{
+				{Address: "aws_db_instance.main", Action: terraforminfra.ActionDelete, Type: "aws_db_instance"},
+			}}}
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: mocks.TerraformStack})
+
+		summary, err := p.PlanTerraformSummary(createTestBlueprint())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(summary.Violations) != 1 || summary.Violations[0].Policy != "no-deletes" {
+			t.Errorf("expected the source policy to deny the delete, got %v", summary.Violations)
+		}
+	})
+
+	t.Run("EvaluatesPlanAndApplyAsTheirOwnOperation", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		writePolicies(t, mocks, `policies:
+  - name: plan-only
+    deny: operation == "plan" && action == "delete"
+`)
+		deletes := []terraforminfra.ResourceChange{{Address: "aws_db_instance.main", Action: terraforminfra.ActionDelete}}
+		stack := mocks.TerraformStack.(*terraforminfra.MockStack)
+		stack.PlanSummaryFunc = func(bp *blueprintv1alpha1.Blueprint) []terraforminfra.TerraformComponentPlan {
+			return []terraforminfra.TerraformComponentPlan{{ComponentID: "database", Destroy: 1, Resources: deletes}}
+		}
+		var check func(string, []terraforminfra.ResourceChange) error
+		stack.SetPlanCheckFunc = func(fn func(string, []terraforminfra.ResourceChange) error) {
+			check = fn
+		}
+		stack.ApplyFunc = func(bp *blueprintv1alpha1.Blueprint, id string) error {
+			return check(id, deletes)
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: stack})
+
+		summary, err := p.PlanTerraformSummary(createTestBlueprint())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(summary.Violations) != 1 {
+			t.Errorf("expected plan to see operation plan, got %v", summary.Violations)
+		}
+		if err := p.Apply(createTestBlueprint(), "database"); err != nil {
+			t.Errorf("expected apply to see operation apply and pass, got %v", err)
+		}
+	})
+
+	t.Run("ReturnsErrorForInvalidPolicyFile", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		writePolicies(t, mocks, "policies:\n  - name: missing-deny\n")
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: mocks.TerraformStack})
+
+		_, err := p.PlanTerraformSummary(createTestBlueprint())
+		if err == nil || !strings.Contains(err.Error(), "error loading policies") {
+			t.Errorf("expected policy load error, got %v", err)
+		}
+	})
+}
+
 func TestProvisioner_PlanDestroyTerraformComponentSummary(t *testing.T) {
 	t.Run("ReturnsErrorForNilBlueprint", func(t *testing.T) {
 		mocks := setupProvisionerMocks(t)
@@ -4775,13 +5442,13 @@ func TestProvisioner_nudgeFrontier(t *testing.T) {
 
 	t.Run("NudgesOnlyNotReadyFrontierAndNeverReadyOrBlocked", func(t *testing.T) {
 		mocks := setupProvisionerMocks(t)
-		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string) (map[string]bool, error) {
+		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string, namespaces map[string]string) (map[string]bool, error) {
 			return map[string]bool{"crds": true, "lb-install": false, "gateway-install": false}, nil
 		}
 		notifier := fluxinfra.NewMockNotifier()
 		var nudgedNames [][]string
-		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, names []string) error {
-			nudgedNames = append(nudgedNames, names)
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
+			nudgedNames = append(nudgedNames, kustomizationRefNames(refs))
 			return nil
 		}
 
@@ -4795,12 +5462,12 @@ func TestProvisioner_nudgeFrontier(t *testing.T) {
 
 	t.Run("NudgesEachFrontierMemberOnlyOnce", func(t *testing.T) {
 		mocks := setupProvisionerMocks(t)
-		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string) (map[string]bool, error) {
+		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string, namespaces map[string]string) (map[string]bool, error) {
 			return map[string]bool{"crds": true, "lb-install": false, "gateway-install": false}, nil
 		}
 		notifier := fluxinfra.NewMockNotifier()
 		calls := 0
-		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, names []string) error {
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
 			calls++
 			return nil
 		}
@@ -4814,6 +5481,45 @@ func TestProvisioner_nudgeFrontier(t *testing.T) {
 			t.Errorf("expected the frontier member nudged once, got %d nudges", calls)
 		}
 	})
+
+	t.Run("ResolvesKustomizationsInTheirObjectNamespace", func(t *testing.T) {
+		// Given a kustomization whose Flux object lives in a tenant namespace
+		mocks := setupProvisionerMocks(t)
+		tenant := &blueprintv1alpha1.Blueprint{Kustomizations: []blueprintv1alpha1.Kustomization{
+			{Name: "crds"},
+			{Name: "apps", Namespace: "team-a", DependsOn: []string{"crds"}},
+		}}
+		var readNamespaces map[string]string
+		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string, namespaces map[string]string) (map[string]bool, error) {
+			readNamespaces = namespaces
+			return map[string]bool{"crds": true, "apps": false}, nil
+		}
+		var hrNamespace string
+		mocks.KubernetesManager.GetHelmReleasesForKustomizationFunc = func(name, namespace string) ([]helmv2.HelmRelease, error) {
+			hrNamespace = namespace
+			return nil, nil
+		}
+		notifier := fluxinfra.NewMockNotifier()
+		var nudged []fluxinfra.KustomizationRef
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
+			nudged = append(nudged, refs...)
+			return nil
+		}
+
+		// When nudging the frontier
+		newProv(mocks, notifier).nudgeFrontier(context.Background(), tenant, map[string]struct{}{})
+
+		// Then readiness, the reconcile patch and the HelmRelease lookup all use the tenant namespace
+		if readNamespaces["apps"] != "team-a" {
+			t.Errorf("expected apps read in team-a, got %v", readNamespaces)
+		}
+		if len(nudged) != 1 || nudged[0] != (fluxinfra.KustomizationRef{Namespace: "team-a", Name: "apps"}) {
+			t.Errorf("expected team-a/apps nudged, got %v", nudged)
+		}
+		if hrNamespace != "team-a" {
+			t.Errorf("expected HelmReleases looked up in team-a, got %q", hrNamespace)
+		}
+	})
 }
 
 func TestProvisioner_Converge(t *testing.T) {
@@ -4827,7 +5533,7 @@ func TestProvisioner_Converge(t *testing.T) {
 		// Given a kustomization not Ready on the first read then Ready, owning a stalled HelmRelease
 		mocks := setupProvisionerMocks(t)
 		var reads int
-		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string) (map[string]bool, error) {
+		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string, namespaces map[string]string) (map[string]bool, error) {
 			reads++
 			out := make(map[string]bool)
 			for _, n := range names {
@@ -4845,8 +5551,8 @@ func TestProvisioner_Converge(t *testing.T) {
 		var reconciledK [][]string
 		var forcedRefs [][]fluxinfra.HelmReleaseRef
 		var forcedFlags []bool
-		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, names []string) error {
-			reconciledK = append(reconciledK, names)
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
+			reconciledK = append(reconciledK, kustomizationRefNames(refs))
 			return nil
 		}
 		notifier.ReconcileHelmReleasesFunc = func(ctx context.Context, refs []fluxinfra.HelmReleaseRef, force bool) error {
@@ -4872,7 +5578,7 @@ func TestProvisioner_Converge(t *testing.T) {
 	t.Run("ReturnsImmediatelyWhenAllReady", func(t *testing.T) {
 		// Given everything already Ready
 		mocks := setupProvisionerMocks(t)
-		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string) (map[string]bool, error) {
+		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string, namespaces map[string]string) (map[string]bool, error) {
 			out := make(map[string]bool)
 			for _, n := range names {
 				out[n] = true
@@ -4881,7 +5587,7 @@ func TestProvisioner_Converge(t *testing.T) {
 		}
 		notifier := fluxinfra.NewMockNotifier()
 		nudged := false
-		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, names []string) error {
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
 			nudged = true
 			return nil
 		}
@@ -4900,7 +5606,7 @@ func TestProvisioner_Converge(t *testing.T) {
 		// Given a not-ready kustomization whose HelmRelease is nonetheless Ready
 		mocks := setupProvisionerMocks(t)
 		var reads int
-		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string) (map[string]bool, error) {
+		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string, namespaces map[string]string) (map[string]bool, error) {
 			reads++
 			out := make(map[string]bool)
 			for _, n := range names {
@@ -5187,8 +5893,8 @@ func TestProvisioner_PlaceSecrets(t *testing.T) {
 		mocks.KubernetesManager.ApplySecretFunc = func(name, namespace string, stringData map[string]string, owner string) error { return nil }
 		notifier := fluxinfra.NewMockNotifier()
 		var reconciled [][]string
-		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, names []string) error {
-			reconciled = append(reconciled, names)
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
+			reconciled = append(reconciled, kustomizationRefNames(refs))
 			return nil
 		}
 		prov := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager, Notifier: notifier})
@@ -5209,13 +5915,13 @@ func TestProvisioner_PlaceSecrets(t *testing.T) {
 		// not-Ready — only the base (lb-install, no unmet deps) is on the frontier
 		mocks := setupProvisionerMocks(t)
 		mocks.KubernetesManager.NamespaceExistsFunc = func(name string) (bool, error) { return false, nil }
-		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string) (map[string]bool, error) {
+		mocks.KubernetesManager.GetKustomizationReadinessFunc = func(names []string, namespaces map[string]string) (map[string]bool, error) {
 			return map[string]bool{"dns-install": false, "gateway-install": false, "lb-install": false}, nil
 		}
 		notifier := fluxinfra.NewMockNotifier()
 		var reconciled [][]string
-		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, names []string) error {
-			reconciled = append(reconciled, names)
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
+			reconciled = append(reconciled, kustomizationRefNames(refs))
 			return nil
 		}
 		prov := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager, Notifier: notifier})
@@ -0,0 +1,316 @@
+package provisioner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+	"github.com/windsorcli/cli/pkg/tui"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// pushCommitIdentity is the author and committer push mode falls back to when the operator has no
+// git identity configured, so a commit from a CI runner never fails on a missing user.email.
+var pushCommitIdentity = map[string]string{
+	"GIT_AUTHOR_NAME":     "Windsor CLI",
+	"GIT_AUTHOR_EMAIL":    "windsor@localhost",
+	"GIT_COMMITTER_NAME":  "Windsor CLI",
+	"GIT_COMMITTER_EMAIL": "windsor@localhost",
+}
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// PushResult describes a push-mode push: the repository and the branch the rendered tree was
+// committed to, the branch the cluster reconciles from, and the tree's directory in the
+// repository. Commit is empty when the rendered tree already matched the branch and nothing was
+// committed. Changes lists the rendered files the commit added, modified or removed.
+type PushResult struct {
+	URL     string
+	Branch  string
+	Base    string
+	Path    string
+	Commit  string
+	Changes []string
+}
+
+// AwaitingReview reports whether the push committed to a review branch that has not yet reached the
+// branch the cluster reconciles from.
+func (r *PushResult) AwaitingReview() bool {
+	return r.Commit != "" && r.Branch != r.Base
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Push renders the blueprint's kubernetes tree — every Flux source, ConfigMap and Kustomization
+// Install would apply, without secrets or tfvars — and commits it to the blueprint's repository
+// under gitops.path (default clusters/<context>) on the branch its ref names, which is the branch
+// the cluster's GitRepository reconciles from. With gitops.review set the commit goes to a
+// windsor/<context>-<digest> branch instead, for a reviewer to merge. The commit message records
+// the context, each blueprint source and its version, the files that changed and the blueprint
+// digest. Nothing is committed when the rendered tree already matches the branch. git runs with the
+// operator's own credentials and identity, falling back to a Windsor CLI identity when none is set.
+// Returns an error if the blueprint has no repository URL or branch ref, gitops.path is not
+// relative to the repository root, or rendering, cloning, committing or pushing fails.
+func (i *Provisioner) Push(blueprint *blueprintv1alpha1.Blueprint) (*PushResult, error) {
+	if blueprint == nil {
+		return nil, fmt.Errorf("blueprint not provided")
+	}
+	if blueprint.Repository.Url == "" {
+		return nil, fmt.Errorf("push mode requires the blueprint repository url to commit the rendered tree to")
+	}
+	base := blueprint.Repository.Ref.Branch
+	if base == "" {
+		return nil, fmt.Errorf("push mode requires the blueprint repository ref to name a branch to commit the rendered tree to")
+	}
+	treePath, err := i.gitopsPath()
+	if err != nil {
+		return nil, err
+	}
+	digest, err := blueprintDigest(blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PushResult{URL: blueprint.Repository.Url, Branch: base, Base: base, Path: treePath}
+	if i.configHandler.GetBool("gitops.review", false) {
+		result.Branch = fmt.Sprintf("windsor/%s-%s", i.contextName, digest[:12])
+	}
+
+	work, err := os.MkdirTemp("", "windsor-push-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating push working copy: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(work) }()
+
+	if _, err := i.shell.ExecSilent("git", "clone", "--quiet", "--single-branch", "--branch", base, result.URL, work); err != nil {
+		return nil, fmt.Errorf("error cloning %s at %s: %w", result.URL, base, err)
+	}
+	if result.Branch != base {
+		if _, err := i.git(work, "checkout", "--quiet", "-B", result.Branch); err != nil {
+			return nil, fmt.Errorf("error creating review branch %s: %w", result.Branch, err)
+		}
+	}
+
+	if _, err := i.render(blueprint, filepath.Join(work, filepath.FromSlash(treePath)), false); err != nil {
+		return nil, err
+	}
+	if _, err := i.git(work, "add", "--all", "--", treePath); err != nil {
+		return nil, fmt.Errorf("error staging rendered tree: %w", err)
+	}
+	status, err := i.git(work, "status", "--porcelain", "--no-renames", "--", treePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rendered tree status: %w", err)
+	}
+	result.Changes = pushChanges(status, treePath)
+	if len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	marker, err := kubernetes.BuildVersionMarker(blueprint)
+	if err != nil {
+		return nil, err
+	}
+	message := pushCommitMessage(i.contextName, marker, result.Changes, digest)
+	var identity map[string]string
+	if email, err := i.git(work, "config", "user.email"); err != nil || strings.TrimSpace(email) == "" {
+		identity = pushCommitIdentity
+	}
+	if _, err := i.shell.ExecSilentWithEnv("git", identity, "-C", work, "commit", "--quiet", "--message", message); err != nil {
+		return nil, fmt.Errorf("error committing rendered tree: %w", err)
+	}
+	push := []string{"push", "--quiet", "origin", "HEAD:refs/heads/" + result.Branch}
+	if result.Branch != base {
+		// The review branch is named for the blueprint digest and owned by the CLI; pushing the same
+		// blueprint again replaces it rather than failing on the earlier, unmerged commit.
+		push = append(push, "--force")
+	}
+	if _, err := i.git(work, push...); err != nil {
+		return nil, fmt.Errorf("error pushing %s to %s: %w", result.Branch, result.URL, err)
+	}
+	sha, err := i.git(work, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("error reading pushed commit: %w", err)
+	}
+	result.Commit = strings.TrimSpace(sha)
+	return result, nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// installPush is Install in push mode. It pushes the rendered tree, then applies the sync — the
+// blueprint's repository and the root Kustomization reconciling the tree — in place of every
+// Flux object, fires the flux webhook so the new commit is fetched at once, places the resolved
+// secrets, which never go to git, and drives the kustomizations toward Ready. When the push is
+// awaiting review it stops after pushing: the cluster picks the change up once the review branch is
+// merged, and the next install, finding the tree already committed, applies the sync and secrets.
+// When the engine is Argo CD the tree holds Applications, so the sync applied is the root
+// Application syncing them and the Application health check its waves wait on (see
+// ApplyArgoCDSync); the secrets are placed, each naming its namespaces, and no kustomizations are
+// converged.
+func (i *Provisioner) installPush(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint, resolvedSecrets ResolvedSecrets, prune bool) error {
+	if err := i.argoSecretsPlaceable(resolvedSecrets); err != nil {
+		return err
+	}
+
+	var result *PushResult
+	if err := tui.WithProgress("Pushing rendered blueprint", func() error {
+		var err error
+		result, err = i.Push(blueprint)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to push blueprint: %w", err)
+	}
+	if result.AwaitingReview() {
+		i.awaitingReview = true
+		fmt.Fprintf(os.Stderr, "Pushed %d change(s) to %s on branch %s for review; the cluster applies them once merged into %s.\n", len(result.Changes), result.URL, result.Branch, result.Base)
+		return nil
+	}
+
+	applied := withCrdLayer(blueprint)
+
+	if i.gitopsEngine() == constants.GitopsEngineArgoCD {
+		if err := tui.WithProgress("Installing gitops sync", func() error {
+			return i.KubernetesManager.ApplyArgoCDSync(applied, path.Join(result.Path, "kubernetes"))
+		}); err != nil {
+			return fmt.Errorf("failed to apply gitops sync: %w", err)
+		}
+		if err := i.PlaceSecrets(ctx, resolvedSecrets, applied, prune); err != nil {
+			return fmt.Errorf("error placing secrets: %w", err)
+		}
+		return nil
+	}
+
+	if err := tui.WithProgress("Installing gitops sync", func() error {
+		if err := i.KubernetesManager.ApplyGitopsSync(applied, i.fluxNamespace(), path.Join(result.Path, "kubernetes")); err != nil {
+			return err
+		}
+		_ = i.Notify(ctx, applied)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to apply gitops sync: %w", err)
+	}
+
+	if err := i.PlaceSecrets(ctx, resolvedSecrets, applied, prune); err != nil {
+		return fmt.Errorf("error placing secrets: %w", err)
+	}
+
+	_ = i.Converge(ctx, applied, constants.DefaultConvergeTimeout)
+
+	return nil
+}
+
+// gitopsPath returns the directory push mode commits the rendered tree to, relative to the
+// repository root: gitops.path, defaulting to clusters/<context>. It errors when the configured
+// path is absolute or leaves the repository.
+func (i *Provisioner) gitopsPath() (string, error) {
+	configured := i.configHandler.GetString("gitops.path", path.Join("clusters", i.contextName))
+	cleaned := path.Clean(strings.ReplaceAll(configured, "\\", "/"))
+	if cleaned == "." || !filepath.IsLocal(filepath.FromSlash(cleaned)) {
+		return "", fmt.Errorf("gitops.path %q must be a directory inside the repository", configured)
+	}
+	return cleaned, nil
+}
+
+// gitopsMode returns the configured gitops mode.
+func (i *Provisioner) gitopsMode() constants.GitopsMode {
+	return constants.ParseGitopsMode(i.configHandler.GetString("gitops.mode", ""))
+}
+
+// gitopsEngine returns the configured gitops engine.
+func (i *Provisioner) gitopsEngine() constants.GitopsEngine {
+	return constants.ParseGitopsEngine(i.configHandler.GetString("gitops.engine", ""))
+}
+
+// argoSecretsPlaceable errors when the engine is Argo CD and a resolved secret names no
+// namespace. Placement resolves such a secret from the Flux Kustomization's inventory, which an
+// Argo CD Application does not have, so the secret must name its namespaces instead.
+func (i *Provisioner) argoSecretsPlaceable(resolvedSecrets ResolvedSecrets) error {
+	if i.gitopsEngine() != constants.GitopsEngineArgoCD {
+		return nil
+	}
+	for _, owner := range slices.Sorted(maps.Keys(resolvedSecrets)) {
+		for _, name := range slices.Sorted(maps.Keys(resolvedSecrets[owner])) {
+			if len(resolvedSecrets[owner][name].Namespaces) == 0 {
+				return fmt.Errorf("secret %s of kustomization %s must name its namespaces when gitops.engine is argocd", name, owner)
+			}
+		}
+	}
+	return nil
+}
+
+// git runs a git subcommand in the working copy at dir.
+func (i *Provisioner) git(dir string, args ...string) (string, error) {
+	return i.shell.ExecSilent("git", append([]string{"-C", dir}, args...)...)
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// pushChanges turns the porcelain status of the staged tree, read without rename detection, into
+// "added", "modified" and "removed" lines sorted by path, with paths relative to the tree.
+func pushChanges(status, treePath string) []string {
+	var changes []string
+	for _, line := range strings.Split(status, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		file := strings.TrimPrefix(strings.Trim(line[3:], `"`), treePath+"/")
+		switch line[0] {
+		case 'A':
+			changes = append(changes, "added "+file)
+		case 'D':
+			changes = append(changes, "removed "+file)
+		default:
+			changes = append(changes, "modified "+file)
+		}
+	}
+	slices.SortFunc(changes, func(a, b string) int {
+		return strings.Compare(a[strings.Index(a, " ")+1:], b[strings.Index(b, " ")+1:])
+	})
+	return changes
+}
+
+// pushCommitMessage builds the push-mode commit message: a subject naming the context and counting
+// the changes, then the blueprint's sources and their versions, the changed files, and trailers
+// recording the blueprint digest and the CLI version that rendered it.
+func pushCommitMessage(contextName string, marker kubernetes.VersionMarker, changes []string, digest string) string {
+	counts := map[string]int{}
+	for _, change := range changes {
+		verb, _, _ := strings.Cut(change, " ")
+		counts[verb]++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "windsor: render %s (%d added, %d modified, %d removed)\n", contextName, counts["added"], counts["modified"], counts["removed"])
+	if len(marker.AppliedSources) > 0 {
+		b.WriteString("\nSources:\n")
+		for _, name := range slices.Sorted(maps.Keys(marker.AppliedSources)) {
+			source := marker.AppliedSources[name]
+			fmt.Fprintf(&b, "  %s %s %s\n", name, source.URL, source.Ref)
+		}
+	}
+	b.WriteString("\nChanges:\n")
+	for _, change := range changes {
+		fmt.Fprintf(&b, "  %s\n", change)
+	}
+	fmt.Fprintf(&b, "\nBlueprint-Digest: %s\nRendered-By: windsor/%s\n", digest, constants.Version)
+	return b.String()
+}
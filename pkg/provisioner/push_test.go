@@ -0,0 +1,387 @@
+package provisioner
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+	"github.com/windsorcli/cli/pkg/runtime/config"
+	"github.com/windsorcli/cli/pkg/runtime/shell"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// runGit runs git in dir with a fixed identity and fails the test on error, returning its output.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+// setupPushRepository creates a bare repository with a main branch holding one commit and returns
+// its path.
+func setupPushRepository(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	seed := filepath.Join(root, "seed")
+	runGit(t, root, "init", "--quiet", "--bare", "--initial-branch=main", origin)
+	runGit(t, root, "clone", "--quiet", origin, seed)
+	if err := os.WriteFile(filepath.Join(seed, "README.md"), []byte("blueprint\n"), 0600); err != nil {
+		t.Fatalf("failed to seed repository: %v", err)
+	}
+	runGit(t, seed, "add", "README.md")
+	runGit(t, seed, "commit", "--quiet", "-m", "initial")
+	runGit(t, seed, "push", "--quiet", "origin", "HEAD:refs/heads/main")
+	return origin
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestProvisioner_Push(t *testing.T) {
+	setup := func(t *testing.T, values map[string]any) (*Provisioner, *blueprintv1alpha1.Blueprint, string) {
+		t.Helper()
+		origin := setupPushRepository(t)
+		mocks := setupProvisionerMocks(t)
+		mocks.Runtime.Shell = shell.NewDefaultShell()
+		configHandler := mocks.ConfigHandler.(*config.MockConfigHandler)
+		configHandler.GetStringFunc = func(key string, defaultValue ...string) string {
+			if v, ok := values[key].(string); ok {
+				return v
+			}
+			if len(defaultValue) > 0 {
+				return defaultValue[0]
+			}
+			return ""
+		}
+		configHandler.GetBoolFunc = func(key string, defaultValue ...bool) bool {
+			if v, ok := values[key].(bool); ok {
+				return v
+			}
+			if len(defaultValue) > 0 {
+				return defaultValue[0]
+			}
+			return false
+		}
+		mocks.KubernetesManager.RenderBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]*unstructured.Unstructured, error) {
+			objs := []*unstructured.Unstructured{}
+			for _, k := range bp.Kustomizations {
+				obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "kustomize.toolkit.fluxcd.io/v1", "kind": "Kustomization"}}
+				obj.SetNamespace(namespace)
+				obj.SetName(k.Name)
+				objs = append(objs, obj)
+			}
+			secret := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": "Secret"}}
+			secret.SetNamespace("system-dns")
+			secret.SetName("dns-token")
+			return append(objs, secret), nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+		blueprint := createTestBlueprint()
+		blueprint.Repository = blueprintv1alpha1.Repository{Url: origin, Ref: blueprintv1alpha1.Reference{Branch: "main"}}
+		return p, blueprint, origin
+	}
+
+	t.Run("CommitsRenderedTreeToBranch", func(t *testing.T) {
+		// Given a blueprint whose repository tracks main
+		p, blueprint, origin := setup(t, nil)
+
+		// When it is pushed
+		result, err := p.Push(blueprint)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the rendered kubernetes tree is committed to main under clusters/<context>
+		if result.Commit == "" || result.Branch != "main" || result.AwaitingReview() {
+			t.Errorf("Expected a commit on main, got %+v", result)
+		}
+		files := runGit(t, origin, "ls-tree", "-r", "--name-only", "main")
+		if !strings.Contains(files, "clusters/test-context/kubernetes/kustomization.yaml") ||
+			!strings.Contains(files, "clusters/test-context/kubernetes/system-gitops/kustomization-test-kustomization.yaml") {
+			t.Errorf("Expected the rendered tree on main, got:\n%s", files)
+		}
+
+		// And no secret placeholders or tfvars are committed
+		if strings.Contains(files, "secrets/") || strings.Contains(files, "terraform/") {
+			t.Errorf("Expected neither secrets nor tfvars committed, got:\n%s", files)
+		}
+
+		// And the message records the context, the sources and the changes
+		message := runGit(t, origin, "log", "-1", "--format=%B", "main")
+		for _, want := range []string{"windsor: render test-context (", "test-blueprint " + origin + " main", "source1 https://github.com/example/example.git main", "added kubernetes/kustomization.yaml", "Blueprint-Digest: "} {
+			if !strings.Contains(message, want) {
+				t.Errorf("Expected commit message to contain %q, got:\n%s", want, message)
+			}
+		}
+	})
+
+	t.Run("CommitsNothingWhenTreeUnchanged", func(t *testing.T) {
+		// Given a blueprint already pushed
+		p, blueprint, _ := setup(t, nil)
+		if _, err := p.Push(blueprint); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// When it is pushed again
+		result, err := p.Push(blueprint)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then nothing is committed
+		if result.Commit != "" || len(result.Changes) != 0 {
+			t.Errorf("Expected no commit, got %+v", result)
+		}
+	})
+
+	t.Run("RecordsRemovedObjects", func(t *testing.T) {
+		// Given a pushed blueprint whose kustomization is then dropped
+		p, blueprint, origin := setup(t, map[string]any{"gitops.path": "gitops/local"})
+		if _, err := p.Push(blueprint); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		blueprint.Kustomizations = []blueprintv1alpha1.Kustomization{{Name: "replacement"}}
+
+		// When it is pushed again
+		result, err := p.Push(blueprint)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the dropped object's file is removed under the configured path
+		want := "removed kubernetes/system-gitops/kustomization-test-kustomization.yaml"
+		if !strings.Contains(strings.Join(result.Changes, "\n"), want) {
+			t.Errorf("Expected %q in changes, got %v", want, result.Changes)
+		}
+		files := runGit(t, origin, "ls-tree", "-r", "--name-only", "main")
+		if strings.Contains(files, "kustomization-test-kustomization.yaml") || !strings.Contains(files, "gitops/local/kubernetes/system-gitops/kustomization-replacement.yaml") {
+			t.Errorf("Expected only the replacement on main, got:\n%s", files)
+		}
+	})
+
+	t.Run("PushesReviewBranch", func(t *testing.T) {
+		// Given review is enabled
+		p, blueprint, origin := setup(t, map[string]any{"gitops.review": true})
+
+		// When it is pushed
+		result, err := p.Push(blueprint)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the commit lands on a review branch and main is untouched
+		if !strings.HasPrefix(result.Branch, "windsor/test-context-") || !result.AwaitingReview() {
+			t.Errorf("Expected a review branch awaiting review, got %+v", result)
+		}
+		if files := runGit(t, origin, "ls-tree", "-r", "--name-only", "main"); strings.Contains(files, "clusters/") {
+			t.Errorf("Expected main untouched, got:\n%s", files)
+		}
+		if files := runGit(t, origin, "ls-tree", "-r", "--name-only", result.Branch); !strings.Contains(files, "clusters/test-context/kubernetes/kustomization.yaml") {
+			t.Errorf("Expected the rendered tree on %s, got:\n%s", result.Branch, files)
+		}
+	})
+
+	t.Run("ReplacesUnmergedReviewBranch", func(t *testing.T) {
+		// Given a review branch already pushed for the blueprint
+		p, blueprint, _ := setup(t, map[string]any{"gitops.review": true})
+		first, err := p.Push(blueprint)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// When the same blueprint is pushed again before the branch is merged
+		second, err := p.Push(blueprint)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the review branch is replaced
+		if second.Branch != first.Branch || second.Commit == "" {
+			t.Errorf("Expected %s replaced, got %+v", first.Branch, second)
+		}
+	})
+
+	t.Run("ErrorWhenRefHasNoBranch", func(t *testing.T) {
+		// Given a repository pinned to a tag
+		p, blueprint, _ := setup(t, nil)
+		blueprint.Repository.Ref = blueprintv1alpha1.Reference{Tag: "v1.0.0"}
+
+		// When it is pushed
+		_, err := p.Push(blueprint)
+
+		// Then it fails asking for a branch
+		if err == nil || !strings.Contains(err.Error(), "branch") {
+			t.Errorf("Expected branch error, got %v", err)
+		}
+	})
+
+	t.Run("ErrorWhenPathLeavesRepository", func(t *testing.T) {
+		// Given a gitops path outside the repository
+		p, blueprint, _ := setup(t, map[string]any{"gitops.path": "../elsewhere"})
+
+		// When it is pushed
+		_, err := p.Push(blueprint)
+
+		// Then it fails naming the setting
+		if err == nil || !strings.Contains(err.Error(), "gitops.path") {
+			t.Errorf("Expected gitops.path error, got %v", err)
+		}
+	})
+
+	t.Run("InstallAppliesSyncInsteadOfBlueprint", func(t *testing.T) {
+		// Given push mode
+		p, blueprint, _ := setup(t, map[string]any{"gitops.mode": "push"})
+		var syncPath string
+		mock := p.KubernetesManager.(*kubernetes.MockKubernetesManager)
+		mock.ApplyBlueprintFunc = func(*blueprintv1alpha1.Blueprint, string) error {
+			t.Error("Expected the blueprint not to be applied directly")
+			return nil
+		}
+		mock.ApplyGitopsSyncFunc = func(_ *blueprintv1alpha1.Blueprint, _ string, treePath string) error {
+			syncPath = treePath
+			return nil
+		}
+
+		// When the blueprint is installed
+		if err := p.Install(context.Background(), blueprint, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the sync reconciles the pushed tree
+		if syncPath != "clusters/test-context/kubernetes" {
+			t.Errorf("Expected sync path clusters/test-context/kubernetes, got %q", syncPath)
+		}
+	})
+
+	t.Run("InstallStopsAfterReviewPush", func(t *testing.T) {
+		// Given push mode with review
+		p, blueprint, _ := setup(t, map[string]any{"gitops.mode": "push", "gitops.review": true})
+		mock := p.KubernetesManager.(*kubernetes.MockKubernetesManager)
+		mock.ApplyGitopsSyncFunc = func(*blueprintv1alpha1.Blueprint, string, string) error {
+			t.Error("Expected no sync while the change awaits review")
+			return nil
+		}
+		mock.WaitForKustomizationsFunc = func(context.Context, string, *blueprintv1alpha1.Blueprint) error {
+			t.Error("Expected no wait while the change awaits review")
+			return nil
+		}
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("Pipe failed: %v", err)
+		}
+		origStderr := os.Stderr
+		os.Stderr = w
+		defer func() { os.Stderr = origStderr }()
+
+		// When the blueprint is installed and waited on
+		installErr := p.Install(context.Background(), blueprint, false)
+		waitErr := p.Wait(context.Background(), blueprint)
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+
+		// Then both succeed and the operator is told where the change awaits review
+		if installErr != nil || waitErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", installErr, waitErr)
+		}
+		if !p.AwaitingReview() {
+			t.Error("Expected the provisioner to report the change awaiting review")
+		}
+		if !strings.Contains(string(stderr), "on branch windsor/test-context-") || !strings.Contains(string(stderr), "merged into main") {
+			t.Errorf("Expected review notice, got %q", stderr)
+		}
+	})
+
+	t.Run("InstallPushesArgoTreeWithRootApplication", func(t *testing.T) {
+		// Given push mode with the argocd engine
+		p, blueprint, origin := setup(t, map[string]any{"gitops.mode": "push", "gitops.engine": "argocd"})
+		mock := p.KubernetesManager.(*kubernetes.MockKubernetesManager)
+		mock.ApplyGitopsSyncFunc = func(*blueprintv1alpha1.Blueprint, string, string) error {
+			t.Error("Expected no flux sync for the argocd engine")
+			return nil
+		}
+		var argoTreePath string
+		mock.ApplyArgoCDSyncFunc = func(_ *blueprintv1alpha1.Blueprint, treePath string) error {
+			argoTreePath = treePath
+			return nil
+		}
+		mock.WaitForKustomizationsFunc = func(context.Context, string, *blueprintv1alpha1.Blueprint) error {
+			t.Error("Expected no kustomization wait for the argocd engine")
+			return nil
+		}
+
+		// When the blueprint is installed and waited on
+		installErr := p.Install(context.Background(), blueprint, false)
+		waitErr := p.Wait(context.Background(), blueprint)
+
+		// Then both succeed and the tree is committed for Argo CD to sync
+		if installErr != nil || waitErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", installErr, waitErr)
+		}
+		if files := runGit(t, origin, "ls-tree", "-r", "--name-only", "main"); !strings.Contains(files, "clusters/test-context/kubernetes/kustomization.yaml") {
+			t.Errorf("Expected the rendered tree on main, got:\n%s", files)
+		}
+		// And the root Application is applied to sync it
+		if argoTreePath != "clusters/test-context/kubernetes" {
+			t.Errorf("Expected the root application to sync clusters/test-context/kubernetes, got %q", argoTreePath)
+		}
+	})
+
+	t.Run("InstallErrorsForArgoWithoutPush", func(t *testing.T) {
+		// Given the argocd engine in pull mode
+		p, blueprint, _ := setup(t, map[string]any{"gitops.engine": "argocd"})
+		mock := p.KubernetesManager.(*kubernetes.MockKubernetesManager)
+		mock.ApplyBlueprintFunc = func(*blueprintv1alpha1.Blueprint, string) error {
+			t.Error("Expected the blueprint not to be applied")
+			return nil
+		}
+
+		// When the blueprint is installed
+		err := p.Install(context.Background(), blueprint, false)
+
+		// Then it refuses, pointing at push mode
+		if err == nil || !strings.Contains(err.Error(), "gitops.mode push") {
+			t.Errorf("Expected push mode to be required, got %v", err)
+		}
+	})
+
+	t.Run("ErrorWhenArgoSecretNamesNoNamespace", func(t *testing.T) {
+		// Given the argocd engine and a secret left to auto-placement
+		p, blueprint, origin := setup(t, map[string]any{"gitops.mode": "push", "gitops.engine": "argocd"})
+		resolved := ResolvedSecrets{"dns": {"dns-token": {Data: map[string]string{"token": "value"}}}}
+
+		// When it is installed
+		err := p.installPush(context.Background(), blueprint, resolved, false)
+
+		// Then it fails naming the secret before anything is pushed
+		if err == nil || !strings.Contains(err.Error(), "secret dns-token of kustomization dns") {
+			t.Errorf("Expected the secret to be named, got %v", err)
+		}
+		if log := runGit(t, origin, "log", "--format=%s", "main"); strings.Contains(log, "windsor: render") {
+			t.Errorf("Expected nothing pushed, got:\n%s", log)
+		}
+	})
+}
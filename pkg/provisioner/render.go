@@ -0,0 +1,227 @@
+package provisioner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// RenderManifestFile is the name of the manifest Render writes at the root of the output
+// directory, listing every file it rendered.
+const RenderManifestFile = "render.json"
+
+// renderManifestVersion is the render manifest format version.
+const renderManifestVersion = 1
+
+// renderedDirs are the directories Render owns under its output directory. Each is cleared before
+// rendering so objects dropped from the blueprint do not linger in the tree.
+var renderedDirs = []string{"kubernetes", "secrets", "terraform"}
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// RenderManifest describes a directory written by Render: the context and CLI version it was
+// rendered with, a digest of the composed blueprint, and the rendered files relative to the
+// directory, sorted.
+type RenderManifest struct {
+	Version         int      `json:"version"`
+	Context         string   `json:"context"`
+	CLIVersion      string   `json:"cliVersion"`
+	BlueprintDigest string   `json:"blueprintDigest"`
+	Files           []string `json:"files"`
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Render compiles the blueprint to a plain GitOps directory at dir without touching a cluster:
+// every object Install would apply under kubernetes/, one YAML file per object with a
+// kustomization.yaml listing them; the Secrets the blueprint declares under secrets/, with their
+// values redacted; and each terraform component's generated tfvars under terraform/<id>/. The
+// layout is deterministic, so a GitOps repository committing it sees only real changes in a diff.
+// A manifest recording the context, CLI version and blueprint digest is written alongside. dir must
+// be empty or hold an earlier render, whose kubernetes/, secrets/ and terraform/ trees are replaced.
+// Returns an error if the blueprint is nil, the kubernetes manager is not configured, a component's
+// tfvars have not been generated, or any file cannot be written.
+func (i *Provisioner) Render(blueprint *blueprintv1alpha1.Blueprint, dir string) (*RenderManifest, error) {
+	return i.render(blueprint, dir, true)
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// render writes the rendered tree Render describes. When full is false only the kubernetes/ tree
+// is written: the secret placeholders and tfvars are left out, as push mode commits the tree to a
+// repository the cluster reconciles from and neither belongs there.
+func (i *Provisioner) render(blueprint *blueprintv1alpha1.Blueprint, dir string, full bool) (*RenderManifest, error) {
+	if blueprint == nil {
+		return nil, fmt.Errorf("blueprint not provided")
+	}
+	if i.KubernetesManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not configured")
+	}
+	if err := prepareRenderDir(dir); err != nil {
+		return nil, err
+	}
+
+	digest, err := blueprintDigest(blueprint)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &RenderManifest{
+		Version:         renderManifestVersion,
+		Context:         i.contextName,
+		CLIVersion:      constants.Version,
+		BlueprintDigest: digest,
+	}
+
+	objs, err := i.KubernetesManager.RenderBlueprint(withCrdLayer(blueprint), i.fluxNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("failed to render blueprint resources: %w", err)
+	}
+	var resources []string
+	for _, obj := range objs {
+		if !full && obj.GetKind() == "Secret" {
+			continue
+		}
+		path := renderedObjectPath(obj)
+		if err := writeRenderedObject(dir, path, obj.Object); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, path)
+		if rel, ok := strings.CutPrefix(path, "kubernetes/"); ok {
+			resources = append(resources, rel)
+		}
+	}
+	if len(resources) > 0 {
+		kustomization := map[string]any{
+			"apiVersion": "kustomize.config.k8s.io/v1beta1",
+			"kind":       "Kustomization",
+			"resources":  resources,
+		}
+		if err := writeRenderedObject(dir, "kubernetes/kustomization.yaml", kustomization); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, "kubernetes/kustomization.yaml")
+	}
+
+	if full && i.configHandler.GetBool("terraform.enabled", true) {
+		for _, component := range blueprint.TerraformComponents {
+			path, err := i.copyRenderedTfvars(dir, component.GetID())
+			if err != nil {
+				return nil, err
+			}
+			manifest.Files = append(manifest.Files, path)
+		}
+	}
+
+	slices.Sort(manifest.Files)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding render manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, RenderManifestFile), append(data, '\n'), 0o600); err != nil {
+		return nil, fmt.Errorf("error writing render manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// copyRenderedTfvars copies the tfvars composition generated for the component into dir as
+// terraform/<id>/terraform.tfvars and returns that path relative to dir.
+func (i *Provisioner) copyRenderedTfvars(dir, componentID string) (string, error) {
+	source := filepath.Join(i.projectRoot, ".windsor", "contexts", i.contextName, "terraform", componentID, "terraform.tfvars")
+	// #nosec G304 - The path is built from the project root and a blueprint component id
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return "", fmt.Errorf("error reading tfvars for component %s: %w", componentID, err)
+	}
+	path := filepath.ToSlash(filepath.Join("terraform", componentID, "terraform.tfvars"))
+	if err := writeRenderedFile(dir, path, data); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// prepareRenderDir creates dir when it does not exist and clears the trees Render owns in it. It
+// refuses a non-empty directory holding no render manifest, so pointing --out at a project or
+// repository root never deletes files Render did not write.
+func prepareRenderDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading render directory: %w", err)
+	}
+	if len(entries) > 0 {
+		if _, err := os.Stat(filepath.Join(dir, RenderManifestFile)); err != nil {
+			return fmt.Errorf("%s is not empty and holds no %s from an earlier render; choose an empty directory", dir, RenderManifestFile)
+		}
+	}
+	for _, name := range renderedDirs {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("error clearing rendered %s: %w", name, err)
+		}
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("error creating render directory: %w", err)
+	}
+	return nil
+}
+
+// renderedObjectPath returns the path an object is rendered to, relative to the output directory.
+// Secrets go to secrets/<namespace>/<name>.yaml, or secrets/_auto/<owner>/<name>.yaml when placement
+// resolves the namespace from the owning kustomization. Every other object goes to
+// kubernetes/<namespace>/<kind>-<name>.yaml, with cluster-scoped objects under kubernetes/_cluster.
+func renderedObjectPath(obj *unstructured.Unstructured) string {
+	kind := strings.ToLower(obj.GetKind())
+	namespace := obj.GetNamespace()
+	if kind == "secret" {
+		if namespace == "" {
+			return fmt.Sprintf("secrets/_auto/%s/%s.yaml", obj.GetLabels()[kubernetes.SecretOwnerLabel], obj.GetName())
+		}
+		return fmt.Sprintf("secrets/%s/%s.yaml", namespace, obj.GetName())
+	}
+	if namespace == "" {
+		namespace = "_cluster"
+	}
+	return fmt.Sprintf("kubernetes/%s/%s-%s.yaml", namespace, kind, obj.GetName())
+}
+
+// writeRenderedObject encodes content as YAML, with keys sorted, and writes it to path under dir.
+func writeRenderedObject(dir, path string, content any) error {
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", path, err)
+	}
+	return writeRenderedFile(dir, path, data)
+}
+
+// writeRenderedFile writes data to path under dir, creating its parent directories.
+func writeRenderedFile(dir, path string, data []byte) error {
+	target := filepath.Join(dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("error creating directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(target, data, 0o600); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package provisioner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestProvisioner_Render(t *testing.T) {
+	object := func(kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": kind}}
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetLabels(labels)
+		return obj
+	}
+	setup := func(t *testing.T) *Provisioner {
+		t.Helper()
+		mocks := setupProvisionerMocks(t)
+		mocks.Runtime.ProjectRoot = t.TempDir()
+		tfvars := filepath.Join(mocks.Runtime.ProjectRoot, ".windsor", "contexts", "test-context", "terraform", "remote", "path", "terraform.tfvars")
+		if err := os.MkdirAll(filepath.Dir(tfvars), 0755); err != nil {
+			t.Fatalf("failed to seed tfvars dir: %v", err)
+		}
+		if err := os.WriteFile(tfvars, []byte("remote_variable1 = \"default_value\"\n"), 0644); err != nil {
+			t.Fatalf("failed to seed tfvars: %v", err)
+		}
+		mocks.KubernetesManager.RenderBlueprintFunc = func(bp *blueprintv1alpha1.Blueprint, namespace string) ([]*unstructured.Unstructured, error) {
+			return []*unstructured.Unstructured{
+				object("Namespace", "", namespace, nil),
+				object("Kustomization", namespace, "test-kustomization", nil),
+				object("Secret", "system-dns", "dns-token", map[string]string{kubernetes.SecretOwnerLabel: "dns"}),
+				object("Secret", "", "dns-auto", map[string]string{kubernetes.SecretOwnerLabel: "dns"}),
+			}, nil
+		}
+		return NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+	}
+
+	t.Run("WritesDeterministicTree", func(t *testing.T) {
+		// Given a blueprint rendering a namespace, a kustomization, two secrets and one terraform component
+		p := setup(t)
+		dir := filepath.Join(t.TempDir(), "out")
+
+		// When it is rendered
+		manifest, err := p.Render(createTestBlueprint(), dir)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// Then every file lands at its path and the manifest lists them sorted
+		want := []string{
+			"kubernetes/_cluster/namespace-system-gitops.yaml",
+			"kubernetes/kustomization.yaml",
+			"kubernetes/system-gitops/kustomization-test-kustomization.yaml",
+			"secrets/_auto/dns/dns-auto.yaml",
+			"secrets/system-dns/dns-token.yaml",
+			"terraform/remote/path/terraform.tfvars",
+		}
+		if !slices.Equal(manifest.Files, want) {
+			t.Errorf("expected files %v, got %v", want, manifest.Files)
+		}
+		for _, path := range want {
+			if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+				t.Errorf("expected %s to be written: %v", path, err)
+			}
+		}
+		data, err := os.ReadFile(filepath.Join(dir, RenderManifestFile))
+		if err != nil {
+			t.Fatalf("expected manifest to be written: %v", err)
+		}
+		var onDisk RenderManifest
+		if err := json.Unmarshal(data, &onDisk); err != nil || onDisk.Context != "test-context" || onDisk.BlueprintDigest == "" {
+			t.Errorf("unexpected manifest %s (%v)", data, err)
+		}
+	})
+
+	t.Run("KustomizationListsOnlyKubernetesObjects", func(t *testing.T) {
+		// Given a rendered tree
+		p := setup(t)
+		dir := t.TempDir()
+		if _, err := p.Render(createTestBlueprint(), dir); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// When its kustomization.yaml is read
+		data, err := os.ReadFile(filepath.Join(dir, "kubernetes", "kustomization.yaml"))
+		if err != nil {
+			t.Fatalf("expected kustomization.yaml: %v", err)
+		}
+
+		// Then it lists the kubernetes objects and leaves the secret placeholders out
+		out := string(data)
+		if !strings.Contains(out, "system-gitops/kustomization-test-kustomization.yaml") || strings.Contains(out, "secret") {
+			t.Errorf("unexpected kustomization.yaml:\n%s", out)
+		}
+	})
+
+	t.Run("ReplacesEarlierRender", func(t *testing.T) {
+		// Given a directory holding an earlier render with an object since dropped
+		p := setup(t)
+		dir := t.TempDir()
+		if _, err := p.Render(createTestBlueprint(), dir); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		stale := filepath.Join(dir, "kubernetes", "system-gitops", "kustomization-old.yaml")
+		if err := os.WriteFile(stale, []byte("stale"), 0600); err != nil {
+			t.Fatalf("failed to seed stale file: %v", err)
+		}
+
+		// When it is rendered again
+		if _, err := p.Render(createTestBlueprint(), dir); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// Then the dropped object's file is gone
+		if _, err := os.Stat(stale); !os.IsNotExist(err) {
+			t.Errorf("expected stale file removed, got %v", err)
+		}
+	})
+
+	t.Run("RefusesNonEmptyDirectoryWithoutManifest", func(t *testing.T) {
+		// Given a non-empty directory that was not written by render
+		p := setup(t)
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, "terraform"), 0755); err != nil {
+			t.Fatalf("failed to seed dir: %v", err)
+		}
+
+		// When rendering into it
+		_, err := p.Render(createTestBlueprint(), dir)
+
+		// Then it refuses and leaves the directory alone
+		if err == nil || !strings.Contains(err.Error(), "not empty") {
+			t.Errorf("expected refusal, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "terraform")); err != nil {
+			t.Errorf("expected existing files untouched, got %v", err)
+		}
+	})
+
+	t.Run("ErrorWhenTfvarsMissing", func(t *testing.T) {
+		// Given a component whose tfvars were never generated
+		p := setup(t)
+		blueprint := createTestBlueprint()
+		blueprint.TerraformComponents = append(blueprint.TerraformComponents, blueprintv1alpha1.TerraformComponent{Path: "missing"})
+
+		// When it is rendered
+		_, err := p.Render(blueprint, t.TempDir())
+
+		// Then it fails naming the component
+		if err == nil || !strings.Contains(err.Error(), "missing") {
+			t.Errorf("expected error naming the component, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package provisioner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/provisioner/policy"
+	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// PlanManifestFile is the name of the manifest SaveTerraformPlan writes alongside the
+// per-component plan files.
+const PlanManifestFile = "manifest.json"
+
+// planManifestVersion is the manifest format version. ApplySavedTerraformPlan refuses
+// manifests written in any other format.
+const planManifestVersion = 1
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// PlanManifest describes a directory of saved terraform plans. BlueprintDigest covers the
+// composed blueprint the plans were computed from; Components records each plan file with
+// digests of the component's inputs and module source, in the order they will be applied.
+type PlanManifest struct {
+	Version         int                        `json:"version"`
+	Context         string                     `json:"context"`
+	CLIVersion      string                     `json:"cliVersion"`
+	BlueprintDigest string                     `json:"blueprintDigest"`
+	Components      []terraforminfra.SavedPlan `json:"components"`
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// SaveTerraformPlan plans every terraform component and writes the binary plans to dir with a
+// manifest recording the context, CLI version, and composed blueprint digest, so the plans can
+// later be applied exactly with ApplySavedTerraformPlan. The returned summary carries the
+// terraform results and any policy violations. Returns an error if the blueprint is nil,
+// terraform is disabled, any component fails to plan, or the manifest cannot be written.
+func (i *Provisioner) SaveTerraformPlan(blueprint *blueprintv1alpha1.Blueprint, dir string) (*PlanSummary, error) {
+	if blueprint == nil {
+		return nil, fmt.Errorf("blueprint not provided")
+	}
+	if err := i.ensureTerraformStack(); err != nil {
+		return nil, err
+	}
+	if i.TerraformStack == nil {
+		return nil, fmt.Errorf("terraform is disabled")
+	}
+	if err := i.forwardPolicyCheck(policy.OperationPlan); err != nil {
+		return nil, err
+	}
+
+	digest, err := blueprintDigest(blueprint)
+	if err != nil {
+		return nil, err
+	}
+	results, saved, err := i.TerraformStack.SavePlans(blueprint, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save terraform plans: %w", err)
+	}
+
+	manifest := PlanManifest{
+		Version:         planManifestVersion,
+		Context:         i.contextName,
+		CLIVersion:      constants.Version,
+		BlueprintDigest: digest,
+		Components:      saved,
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding plan manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, PlanManifestFile), data, 0o600); err != nil {
+		return nil, fmt.Errorf("error writing plan manifest: %w", err)
+	}
+
+	violations, err := i.EvaluatePolicies(policy.OperationPlan, results, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PlanSummary{Terraform: results, Violations: violations}, nil
+}
+
+// SavePlanAll saves the terraform plans to dir as SaveTerraformPlan does and adds a summary of
+// the Flux kustomization plans, which have no saved form and are re-planned on apply.
+func (i *Provisioner) SavePlanAll(blueprint *blueprintv1alpha1.Blueprint, dir string) (*PlanSummary, error) {
+	tfSummary, err := i.SaveTerraformPlan(blueprint, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	k8sSummary, err := i.PlanKustomizeSummary(blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlanSummary{
+		Terraform:  tfSummary.Terraform,
+		Kustomize:  k8sSummary.Kustomize,
+		Hints:      k8sSummary.Hints,
+		Violations: append(tfSummary.Violations, k8sSummary.Violations...),
+	}, nil
+}
+
+// ApplySavedTerraformPlan applies the terraform plans saved in dir by SaveTerraformPlan. It
+// refuses before applying anything when the manifest was written for another context or by
+// another CLI version, when the composed blueprint no longer matches the one the plans were
+// computed from, or when any component's inputs or module source changed. Policies are checked
+// against each saved plan before it is applied.
+func (i *Provisioner) ApplySavedTerraformPlan(blueprint *blueprintv1alpha1.Blueprint, dir string) error {
+	if blueprint == nil {
+		return fmt.Errorf("blueprint not provided")
+	}
+	manifest, err := ReadPlanManifest(dir)
+	if err != nil {
+		return err
+	}
+	if err := i.ensureTerraformStack(); err != nil {
+		return err
+	}
+	if i.TerraformStack == nil {
+		return fmt.Errorf("terraform is disabled")
+	}
+
+	if manifest.Context != i.contextName {
+		return fmt.Errorf("plan in %s was saved for context %q, not %q", dir, manifest.Context, i.contextName)
+	}
+	if manifest.CLIVersion != constants.Version {
+		return fmt.Errorf("plan in %s was saved by windsor %s, not %s; run 'windsor plan --out' again", dir, manifest.CLIVersion, constants.Version)
+	}
+	digest, err := blueprintDigest(blueprint)
+	if err != nil {
+		return err
+	}
+	if digest != manifest.BlueprintDigest {
+		return fmt.Errorf("composed blueprint changed since the plan in %s was saved; run 'windsor plan --out' again", dir)
+	}
+
+	if err := i.forwardPolicyCheck(policy.OperationApply); err != nil {
+		return err
+	}
+	if err := i.TerraformStack.ApplySavedPlans(blueprint, dir, manifest.Components); err != nil {
+		return fmt.Errorf("failed to apply saved terraform plan: %w", err)
+	}
+	return nil
+}
+
+// ReadPlanManifest reads and decodes the manifest in a saved plan directory. Returns an error
+// when the manifest is missing, malformed, or written in an unsupported format version.
+func ReadPlanManifest(dir string) (*PlanManifest, error) {
+	path := filepath.Join(dir, PlanManifestFile)
+	// #nosec G304 - The plan directory is supplied by the operator on the command line
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading plan manifest: %w", err)
+	}
+	var manifest PlanManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("error parsing plan manifest %s: %w", path, err)
+	}
+	if manifest.Version != planManifestVersion {
+		return nil, fmt.Errorf("unsupported plan manifest version %d in %s", manifest.Version, path)
+	}
+	return &manifest, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// blueprintDigest returns a SHA-256 digest of the composed blueprint's YAML encoding, the same
+// encoding the blueprint is rendered in. Encoding sorts map keys, so the same blueprint always
+// produces the same digest.
+func blueprintDigest(blueprint *blueprintv1alpha1.Blueprint) (string, error) {
+	data, err := yaml.Marshal(blueprint)
+	if err != nil {
+		return "", fmt.Errorf("error encoding blueprint: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
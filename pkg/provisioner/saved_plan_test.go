@@ -0,0 +1,172 @@
+package provisioner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/constants"
+	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestProvisioner_SavedTerraformPlan(t *testing.T) {
+	setup := func(t *testing.T) (*Provisioner, *terraforminfra.MockStack, *[]terraforminfra.SavedPlan) {
+		t.Helper()
+		mocks := setupProvisionerMocks(t)
+		stack := mocks.TerraformStack.(*terraforminfra.MockStack)
+		stack.SavePlansFunc = func(bp *blueprintv1alpha1.Blueprint, dir string) ([]terraforminfra.TerraformComponentPlan, []terraforminfra.SavedPlan, error) {
+			return []terraforminfra.TerraformComponentPlan{{ComponentID: "cluster", Add: 1}},
+				[]terraforminfra.SavedPlan{{ComponentID: "cluster", PlanFile: "cluster.tfplan", InputsDigest: "in", SourceDigest: "src"}}, nil
+		}
+		var applied []terraforminfra.SavedPlan
+		stack.ApplySavedPlansFunc = func(bp *blueprintv1alpha1.Blueprint, dir string, plans []terraforminfra.SavedPlan) error {
+			applied = plans
+			return nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{TerraformStack: stack})
+		return p, stack, &applied
+	}
+
+	t.Run("SaveWritesManifest", func(t *testing.T) {
+		// Given a provisioner whose stack saves one component plan
+		p, _, _ := setup(t)
+		dir := t.TempDir()
+
+		// When saving the terraform plan
+		summary, err := p.SaveTerraformPlan(createTestBlueprint(), dir)
+
+		// Then the summary is returned and the manifest records the context, version, and plans
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(summary.Terraform) != 1 || summary.Terraform[0].Add != 1 {
+			t.Errorf("expected the saved component summary, got %+v", summary.Terraform)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, PlanManifestFile))
+		if err != nil {
+			t.Fatalf("expected manifest to be written: %v", err)
+		}
+		var manifest PlanManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			t.Fatalf("expected valid manifest JSON: %v", err)
+		}
+		if manifest.Version != 1 || manifest.Context != p.contextName || manifest.CLIVersion != constants.Version || manifest.BlueprintDigest == "" {
+			t.Errorf("unexpected manifest header %+v", manifest)
+		}
+		if len(manifest.Components) != 1 || manifest.Components[0].PlanFile != "cluster.tfplan" {
+			t.Errorf("unexpected manifest components %+v", manifest.Components)
+		}
+	})
+
+	t.Run("ApplyPassesManifestPlansToStack", func(t *testing.T) {
+		// Given a saved plan for a blueprint
+		p, _, applied := setup(t)
+		dir := t.TempDir()
+		if _, err := p.SaveTerraformPlan(createTestBlueprint(), dir); err != nil {
+			t.Fatalf("failed to save plan: %v", err)
+		}
+
+		// When applying it against the same blueprint
+		err := p.ApplySavedTerraformPlan(createTestBlueprint(), dir)
+
+		// Then the stack applies the recorded plans
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(*applied) != 1 || (*applied)[0].ComponentID != "cluster" {
+			t.Errorf("expected the recorded plan to be applied, got %+v", *applied)
+		}
+	})
+
+	t.Run("ApplyRefusesChangedBlueprint", func(t *testing.T) {
+		// Given a saved plan and a blueprint that changed since
+		p, _, applied := setup(t)
+		dir := t.TempDir()
+		if _, err := p.SaveTerraformPlan(createTestBlueprint(), dir); err != nil {
+			t.Fatalf("failed to save plan: %v", err)
+		}
+		bp := createTestBlueprint()
+		bp.Metadata.Name = "changed"
+
+		// When applying the saved plan
+		err := p.ApplySavedTerraformPlan(bp, dir)
+
+		// Then the apply is refused before the stack runs
+		if err == nil || !strings.Contains(err.Error(), "composed blueprint changed") {
+			t.Errorf("expected changed blueprint error, got %v", err)
+		}
+		if *applied != nil {
+			t.Errorf("expected no plans applied, got %+v", *applied)
+		}
+	})
+
+	t.Run("ApplyRefusesOtherContextOrVersion", func(t *testing.T) {
+		// Given manifests written for another context and by another CLI version
+		p, _, applied := setup(t)
+		digest, err := blueprintDigest(createTestBlueprint())
+		if err != nil {
+			t.Fatalf("failed to digest blueprint: %v", err)
+		}
+		for _, tc := range []struct {
+			manifest PlanManifest
+			want     string
+		}{
+			{PlanManifest{Version: 1, Context: "other", CLIVersion: constants.Version, BlueprintDigest: digest}, `saved for context "other"`},
+			{PlanManifest{Version: 1, Context: p.contextName, CLIVersion: "v0.0.0-other", BlueprintDigest: digest}, "saved by windsor v0.0.0-other"},
+			{PlanManifest{Version: 2, Context: p.contextName, CLIVersion: constants.Version, BlueprintDigest: digest}, "unsupported plan manifest version 2"},
+		} {
+			dir := t.TempDir()
+			data, _ := json.Marshal(tc.manifest)
+			if err := os.WriteFile(filepath.Join(dir, PlanManifestFile), data, 0o600); err != nil {
+				t.Fatalf("failed to write manifest: %v", err)
+			}
+
+			// When applying the saved plan
+			err := p.ApplySavedTerraformPlan(createTestBlueprint(), dir)
+
+			// Then the apply is refused
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+		}
+		if *applied != nil {
+			t.Errorf("expected no plans applied, got %+v", *applied)
+		}
+	})
+
+	t.Run("ApplyMissingManifest", func(t *testing.T) {
+		// Given an empty plan directory
+		p, _, _ := setup(t)
+
+		// When applying it
+		err := p.ApplySavedTerraformPlan(createTestBlueprint(), t.TempDir())
+
+		// Then the missing manifest is reported
+		if err == nil || !strings.Contains(err.Error(), "error reading plan manifest") {
+			t.Errorf("expected manifest read error, got %v", err)
+		}
+	})
+
+	t.Run("SaveErrorIsWrapped", func(t *testing.T) {
+		// Given a stack whose plan fails
+		p, stack, _ := setup(t)
+		stack.SavePlansFunc = func(bp *blueprintv1alpha1.Blueprint, dir string) ([]terraforminfra.TerraformComponentPlan, []terraforminfra.SavedPlan, error) {
+			return nil, nil, os.ErrPermission
+		}
+
+		// When saving the terraform plan
+		_, err := p.SaveTerraformPlan(createTestBlueprint(), t.TempDir())
+
+		// Then the error is wrapped
+		if err == nil || !strings.Contains(err.Error(), "failed to save terraform plans") {
+			t.Errorf("expected wrapped save error, got %v", err)
+		}
+	})
+}
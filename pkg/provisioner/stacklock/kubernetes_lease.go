@@ -0,0 +1,531 @@
+package stacklock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// leaseInfoAnnotation is the Lease annotation carrying the holder's JSON-encoded LockInfo.
+const leaseInfoAnnotation = "windsorcli.dev/lock-info"
+
+// leaseNamePrefix prefixes the per-context Lease name.
+const leaseNamePrefix = "windsor-stacklock-"
+
+// leaseReaderPrefix follows leaseNamePrefix in the name of each shared holder's reader Lease.
+const leaseReaderPrefix = "reader-"
+
+// leaseLockLabel labels each reader Lease with the name of the Lease it shares, so the
+// readers of one context's lock are listed together.
+const leaseLockLabel = "windsorcli.dev/stacklock"
+
+// leaseDuration is how long a Lease stays held after its last renewal. A holder that dies
+// without releasing is treated as gone once this elapses, so the next Acquire can take over.
+const leaseDuration = 60 * time.Second
+
+// leaseRenewInterval is how often a held Lease is renewed; a third of leaseDuration leaves
+// room for two missed renewals before the Lease expires under a live holder.
+const leaseRenewInterval = leaseDuration / 3
+
+// leaseRetryInterval is the wait between acquisition attempts under contention. Longer than
+// acquireRetryInterval because every attempt is an API server round trip.
+const leaseRetryInterval = 2 * time.Second
+
+// leaseRequestTimeout bounds each API call made on behalf of a Release or renewal, which
+// run independently of the caller's context, and every request the lock's client makes, so
+// an unreachable API server is detected instead of hanging on the connection.
+const leaseRequestTimeout = 10 * time.Second
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// kubernetesLeaseLock is the cluster-wide implementation of StackLock, backed by a
+// coordination.k8s.io/v1 Lease. The Lease's holderIdentity is the holder's LockInfo.ID and
+// the full LockInfo is stored in an annotation, so contenders on other machines can name
+// the holder. A Shared holder takes a reader Lease of its own, labelled with leaseLockLabel,
+// so any number of readers hold the lock together while a writer holds the Lease alone. now,
+// renewInterval, retryInterval and unreachableWindow are fields so tests can drive expiry,
+// renewal and retries.
+type kubernetesLeaseLock struct {
+	client            kubernetes.Interface
+	namespace         string
+	name              string
+	renewInterval     time.Duration
+	retryInterval     time.Duration
+	unreachableWindow time.Duration
+	now               func() time.Time
+}
+
+// =============================================================================
+// Constructor
+// =============================================================================
+
+// NewKubernetesLeaseLock returns a StackLock backed by the Lease name in namespace. The Lease
+// is created on first Acquire, renewed while held, and deleted on Release. Unlike the local
+// flock, it coordinates every operator whose kubeconfig reaches the same cluster.
+func NewKubernetesLeaseLock(client kubernetes.Interface, namespace, name string) StackLock {
+	return &kubernetesLeaseLock{
+		client:            client,
+		namespace:         namespace,
+		name:              name,
+		renewInterval:     leaseRenewInterval,
+		retryInterval:     leaseRetryInterval,
+		unreachableWindow: unreachableRetryWindow,
+		now:               time.Now,
+	}
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Acquire takes the Lease when it is absent, released, or expired, and otherwise fails
+// immediately (timeout <= 0) or retries every leaseRetryInterval until it is held, the
+// timeout elapses, or ctx is cancelled — the same contract as the local flock. Creation
+// and takeover are guarded by the API server: a lost create race surfaces as AlreadyExists
+// and a lost takeover as a resourceVersion conflict, both treated as contention. An API
+// server that does not answer is retried for at least unreachableRetryWindow and then
+// reported as an error. A Shared info takes a reader Lease of its own instead, so readers
+// hold the lock together and only a writer waits. Once held, the Lease is renewed in the
+// background until the returned Release runs.
+func (s *kubernetesLeaseLock) Acquire(ctx context.Context, info LockInfo, timeout time.Duration) (Release, error) {
+	if info.PID == 0 {
+		info.PID = os.Getpid()
+	}
+
+	err := retryAcquire(ctx, s.describe(), timeout, s.retryInterval, s.unreachableWindow, func() (bool, *LockInfo, error) {
+		return s.tryAcquire(ctx, info)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := s.name
+	if info.Mode == Shared {
+		name = readerLeaseName(info.ID)
+	}
+	return s.startRenewal(name, info.ID), nil
+}
+
+// Inspect returns the holder recorded on the Lease, or on the first live reader Lease when
+// the lock is shared. A missing Lease, or one with no holderIdentity, and no live reader
+// returns (nil, nil). A held Lease whose info annotation is missing or corrupt returns an
+// error, matching the local lock's treatment of a corrupt sidecar so windsor unlock still
+// clears it.
+func (s *kubernetesLeaseLock) Inspect(ctx context.Context) (*LockInfo, error) {
+	lease, err := s.client.CoordinationV1().Leases(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("stacklock: reading %s: %w", s.describe(), leaseRequestError(err))
+	}
+	if err != nil || leaseHolderID(lease) == "" {
+		readers, err := s.readerLeases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range readers {
+			if s.isHeld(&readers[i]) {
+				return leaseHolderInfo(&readers[i]), nil
+			}
+		}
+		return nil, nil
+	}
+	data, ok := lease.Annotations[leaseInfoAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("stacklock: %s has no holder info", s.describe())
+	}
+	var info LockInfo
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		return nil, fmt.Errorf("stacklock: holder info on %s is corrupt: %w", s.describe(), err)
+	}
+	return &info, nil
+}
+
+// ForceRelease deletes the Lease and every reader Lease so the next Acquire starts from a
+// clean slate, whether or not the holders are alive. As with the local lock, a non-empty
+// lockID refuses the release when none of the current holders has that ID, as a different
+// holder has taken the lock since the caller inspected it. Each delete is conditioned on the
+// resourceVersion that was checked, so a takeover racing the delete is refused by the API
+// server rather than yanked. A missing Lease is not an error.
+func (s *kubernetesLeaseLock) ForceRelease(ctx context.Context, lockID string, reason string) error {
+	leases := s.client.CoordinationV1().Leases(s.namespace)
+	var targets []coordinationv1.Lease
+	lease, err := leases.Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("stacklock: force-release (%s): %w", reason, leaseRequestError(err))
+	}
+	if err == nil {
+		targets = append(targets, *lease)
+	}
+	readers, err := s.readerLeases(ctx)
+	if err != nil {
+		return fmt.Errorf("stacklock: force-release (%s): %w", reason, err)
+	}
+	targets = append(targets, readers...)
+
+	if lockID != "" {
+		var holders []string
+		for i := range targets {
+			if holder := leaseHolderID(&targets[i]); holder != "" {
+				holders = append(holders, holder)
+			}
+		}
+		if len(holders) > 0 && !slices.Contains(holders, lockID) {
+			return fmt.Errorf("stacklock: refusing to force-release: lock is now held by a different holder (%q, not %q) — a new windsor process acquired it", holders[0], lockID)
+		}
+	}
+	for i := range targets {
+		err := leases.Delete(ctx, targets[i].Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &targets[i].ResourceVersion},
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("stacklock: force-release (%s): %w", reason, err)
+		}
+	}
+	return nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// tryAcquire makes one attempt to take the lock for info. It returns (true, nil, nil) when
+// the lock is now held by info.ID, (false, holder, nil) on contention with holder best-effort,
+// and an error only when the API server cannot be reached, which wraps errBackendUnreachable,
+// or refuses for another reason. A writer claims the Lease and then yields it again if a live
+// reader Lease exists; a reader creates its own Lease and then yields it if the Lease is held.
+// Each registers before it checks for the other, so of a writer and a reader racing, at least
+// one sees the other and neither proceeds alongside it.
+func (s *kubernetesLeaseLock) tryAcquire(ctx context.Context, info LockInfo) (bool, *LockInfo, error) {
+	if info.Mode == Shared {
+		return s.tryAcquireShared(ctx, info)
+	}
+	held, holder, err := s.claimLease(ctx, s.name, info)
+	if err != nil || !held {
+		return held, holder, err
+	}
+	readers, err := s.readerLeases(ctx)
+	if err != nil {
+		_ = s.release(s.name, info.ID)
+		return false, nil, err
+	}
+	for i := range readers {
+		if s.isHeld(&readers[i]) {
+			_ = s.release(s.name, info.ID)
+			return false, leaseHolderInfo(&readers[i]), nil
+		}
+		_ = s.release(readers[i].Name, leaseHolderID(&readers[i]))
+	}
+	return true, nil, nil
+}
+
+// tryAcquireShared makes one attempt to take a reader Lease for info, with the same results
+// as tryAcquire.
+func (s *kubernetesLeaseLock) tryAcquireShared(ctx context.Context, info LockInfo) (bool, *LockInfo, error) {
+	if holder, held, err := s.writer(ctx); err != nil || held {
+		return false, holder, err
+	}
+	name := readerLeaseName(info.ID)
+	held, holder, err := s.claimLease(ctx, name, info)
+	if err != nil || !held {
+		return held, holder, err
+	}
+	if holder, held, err := s.writer(ctx); err != nil || held {
+		_ = s.release(name, info.ID)
+		return false, holder, err
+	}
+	return true, nil, nil
+}
+
+// claimLease makes one attempt to take the Lease name for info, creating it when absent and
+// taking it over when released or expired. A namespace that does not exist yet, as before
+// flux is installed, is created so the Lease can be. Reader Leases are labelled with the
+// writer Lease's name so readerLeases finds them.
+func (s *kubernetesLeaseLock) claimLease(ctx context.Context, name string, info LockInfo) (bool, *LockInfo, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return false, nil, fmt.Errorf("stacklock: encoding holder info: %w", err)
+	}
+	leases := s.client.CoordinationV1().Leases(s.namespace)
+
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.namespace}}
+		if name != s.name {
+			lease.Labels = map[string]string{leaseLockLabel: s.name}
+		}
+		s.claim(lease, info.ID, string(data))
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		if apierrors.IsNotFound(err) {
+			if err := s.ensureNamespace(ctx); err != nil {
+				return false, nil, err
+			}
+			_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		}
+		if apierrors.IsAlreadyExists(err) {
+			return false, nil, nil
+		}
+		if err != nil {
+			return false, nil, fmt.Errorf("stacklock: creating %s: %w", s.describe(), leaseRequestError(err))
+		}
+		return true, nil, nil
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("stacklock: reading %s: %w", s.describe(), leaseRequestError(err))
+	}
+
+	if s.isHeld(lease) {
+		return false, leaseHolderInfo(lease), nil
+	}
+	s.claim(lease, info.ID, string(data))
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("stacklock: taking over %s: %w", s.describe(), leaseRequestError(err))
+	}
+	return true, nil, nil
+}
+
+// writer reports whether the writer Lease is held, with its holder best-effort.
+func (s *kubernetesLeaseLock) writer(ctx context.Context) (*LockInfo, bool, error) {
+	lease, err := s.client.CoordinationV1().Leases(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("stacklock: reading %s: %w", s.describe(), leaseRequestError(err))
+	}
+	if !s.isHeld(lease) {
+		return nil, false, nil
+	}
+	return leaseHolderInfo(lease), true, nil
+}
+
+// readerLeases returns the reader Leases sharing the lock, live or expired, sorted by name.
+func (s *kubernetesLeaseLock) readerLeases(ctx context.Context) ([]coordinationv1.Lease, error) {
+	list, err := s.client.CoordinationV1().Leases(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: leaseLockLabel + "=" + s.name})
+	if err != nil {
+		return nil, fmt.Errorf("stacklock: listing readers of %s: %w", s.describe(), leaseRequestError(err))
+	}
+	readers := list.Items
+	slices.SortFunc(readers, func(a, b coordinationv1.Lease) int { return strings.Compare(a.Name, b.Name) })
+	return readers, nil
+}
+
+// ensureNamespace creates the Lease's namespace. One created concurrently is not an error.
+func (s *kubernetesLeaseLock) ensureNamespace(ctx context.Context) error {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}
+	_, err := s.client.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("stacklock: creating namespace %s for %s: %w", s.namespace, s.describe(), leaseRequestError(err))
+	}
+	return nil
+}
+
+// claim sets lease's holder to id with fresh acquire and renew times and records the
+// holder's encoded LockInfo in the info annotation.
+func (s *kubernetesLeaseLock) claim(lease *coordinationv1.Lease, id, info string) {
+	now := metav1.NewMicroTime(s.now())
+	seconds := int32(leaseDuration / time.Second)
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[leaseInfoAnnotation] = info
+	lease.Spec.HolderIdentity = &id
+	lease.Spec.LeaseDurationSeconds = &seconds
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+}
+
+// isHeld reports whether lease has a holder whose last renewal is within its duration.
+func (s *kubernetesLeaseLock) isHeld(lease *coordinationv1.Lease) bool {
+	if leaseHolderID(lease) == "" || lease.Spec.RenewTime == nil {
+		return false
+	}
+	duration := leaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return s.now().Before(lease.Spec.RenewTime.Add(duration))
+}
+
+// startRenewal renews the Lease name held by id every renewInterval in the background and
+// returns the Release that stops renewal and deletes the Lease. A failed renewal is reported
+// on stderr and retried on the next tick; it does not abort the operation holding the lock,
+// which terraform's own state lock still protects. Release is idempotent.
+func (s *kubernetesLeaseLock) startRenewal(name, id string) Release {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.renewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := s.renew(name, id); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: failed to renew stack lock %s: %v\n", s.describe(), err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() error {
+		var err error
+		once.Do(func() {
+			close(stop)
+			<-done
+			err = s.release(name, id)
+		})
+		return err
+	}
+}
+
+// renew advances the Lease's renew time, provided it is still held by id.
+func (s *kubernetesLeaseLock) renew(name, id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), leaseRequestTimeout)
+	defer cancel()
+	leases := s.client.CoordinationV1().Leases(s.namespace)
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if holder := leaseHolderID(lease); holder != id {
+		return fmt.Errorf("lease is now held by %q", holder)
+	}
+	now := metav1.NewMicroTime(s.now())
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// release deletes the Lease name if it is still held by id. A Lease that is gone or has been
+// taken over after a force-release is left alone.
+func (s *kubernetesLeaseLock) release(name, id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), leaseRequestTimeout)
+	defer cancel()
+	leases := s.client.CoordinationV1().Leases(s.namespace)
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stacklock: releasing %s: %w", s.describe(), err)
+	}
+	if leaseHolderID(lease) != id {
+		return nil
+	}
+	err = leases.Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("stacklock: releasing %s: %w", s.describe(), err)
+	}
+	return nil
+}
+
+// describe names the Lease in busy errors and waiting notices.
+func (s *kubernetesLeaseLock) describe() string {
+	return fmt.Sprintf("lease %s/%s", s.namespace, s.name)
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// newKubernetesClient builds a clientset from the kubeconfig at path. Building the client
+// makes no API calls. It is a variable so tests can substitute a fake clientset.
+var newKubernetesClient = func(kubeconfigPath string) (kubernetes.Interface, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	config.Timeout = leaseRequestTimeout
+	return kubernetes.NewForConfig(config)
+}
+
+// leaseRequestError wraps err with errBackendUnreachable when the request never got an answer
+// from the API server — a refused or timed-out connection, or a server reporting itself
+// unavailable — so Acquire retries it. Errors the API server returned for the request itself,
+// and cancellation of the caller's context, are returned as is.
+func leaseRequestError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && !apierrors.IsServiceUnavailable(err) && !apierrors.IsServerTimeout(err) && !apierrors.IsTimeout(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", errBackendUnreachable, err)
+}
+
+// leaseHolderID returns the Lease's holderIdentity, or "" when it has none.
+func leaseHolderID(lease *coordinationv1.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+// leaseHolderInfo decodes the LockInfo annotation on lease, or returns nil when it is
+// absent or unparseable. Like readHolderInfo, the result is diagnostic only.
+func leaseHolderInfo(lease *coordinationv1.Lease) *LockInfo {
+	data, ok := lease.Annotations[leaseInfoAnnotation]
+	if !ok {
+		return nil
+	}
+	var info LockInfo
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		return nil
+	}
+	return &info
+}
+
+// leaseNameInvalidChars matches runs of characters not allowed in a Lease name.
+var leaseNameInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// leaseNameForContext returns the Lease name for a context: the context name lowercased with
+// disallowed characters collapsed to hyphens, so every checkout of the same context on any
+// machine contends for the same Lease.
+func leaseNameForContext(contextName string) string {
+	name := strings.Trim(leaseNameInvalidChars.ReplaceAllString(strings.ToLower(contextName), "-"), "-")
+	if name == "" {
+		name = "default"
+	}
+	if len(leaseNamePrefix)+len(name) > 63 {
+		name = strings.TrimRight(name[:63-len(leaseNamePrefix)], "-")
+	}
+	return leaseNamePrefix + name
+}
+
+// readerLeaseName returns the name of the reader Lease held by the Shared holder id.
+func readerLeaseName(id string) string {
+	name := leaseNamePrefix + leaseReaderPrefix + strings.Trim(leaseNameInvalidChars.ReplaceAllString(strings.ToLower(id), "-"), "-")
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
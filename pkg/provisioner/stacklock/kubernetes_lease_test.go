@@ -0,0 +1,621 @@
+package stacklock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/windsorcli/cli/pkg/runtime/config"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// newTestLeaseLock returns a lease lock against a fake clientset seeded with objects,
+// with the clock pinned to now.
+func newTestLeaseLock(t *testing.T, now time.Time, objects ...*coordinationv1.Lease) (*kubernetesLeaseLock, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewClientset()
+	for _, lease := range objects {
+		if _, err := client.CoordinationV1().Leases(lease.Namespace).Create(context.Background(), lease, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("seed lease: %v", err)
+		}
+	}
+	lock := NewKubernetesLeaseLock(client, "system-gitops", "windsor-stacklock-test").(*kubernetesLeaseLock)
+	lock.now = func() time.Time { return now }
+	return lock, client
+}
+
+// newHeldLease returns a Lease held by info, last renewed at renewed.
+func newHeldLease(t *testing.T, info LockInfo, renewed time.Time) *coordinationv1.Lease {
+	t.Helper()
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("encode info: %v", err)
+	}
+	seconds := int32(leaseDuration / time.Second)
+	renewTime := metav1.NewMicroTime(renewed)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "windsor-stacklock-test",
+			Namespace:   "system-gitops",
+			Annotations: map[string]string{leaseInfoAnnotation: string(data)},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &info.ID,
+			LeaseDurationSeconds: &seconds,
+			RenewTime:            &renewTime,
+		},
+	}
+}
+
+// getTestLease returns the lease the test lock manages, or nil when it does not exist.
+func getTestLease(t *testing.T, client *fake.Clientset) *coordinationv1.Lease {
+	t.Helper()
+	lease, err := client.CoordinationV1().Leases("system-gitops").Get(context.Background(), "windsor-stacklock-test", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("get lease: %v", err)
+	}
+	return lease
+}
+
+// =============================================================================
+// Test Acquire
+// =============================================================================
+
+func TestKubernetesLeaseLock_Acquire(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("creates the lease with the holder info", func(t *testing.T) {
+		// Given no lease in the cluster
+		lock, client := newTestLeaseLock(t, now)
+
+		// When acquiring
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// Then the lease names the holder and carries its info
+		lease := getTestLease(t, client)
+		if lease == nil || leaseHolderID(lease) != "test-id-1" {
+			t.Fatalf("expected lease held by test-id-1, got %+v", lease)
+		}
+		if info := leaseHolderInfo(lease); info == nil || info.Operation != "up" {
+			t.Errorf("expected holder info annotation, got %+v", info)
+		}
+	})
+
+	t.Run("fails fast when a live holder has the lease", func(t *testing.T) {
+		// Given a lease renewed moments ago by another holder
+		other := newTestLockInfo()
+		other.ID = "other"
+		other.Who = "alice@laptop"
+		lock, _ := newTestLeaseLock(t, now, newHeldLease(t, other, now.Add(-5*time.Second)))
+
+		// When acquiring without waiting
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then a busy error names the holder
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected LockBusyError, got %v", err)
+		}
+		if busy.Holder == nil || busy.Holder.Who != "alice@laptop" {
+			t.Errorf("expected holder alice@laptop, got %+v", busy.Holder)
+		}
+		if !strings.Contains(busy.Error(), "lease system-gitops/windsor-stacklock-test") {
+			t.Errorf("expected the lease to be named, got %q", busy.Error())
+		}
+	})
+
+	t.Run("takes over an expired lease", func(t *testing.T) {
+		// Given a lease whose holder stopped renewing longer ago than the lease duration
+		other := newTestLockInfo()
+		other.ID = "dead"
+		lock, client := newTestLeaseLock(t, now, newHeldLease(t, other, now.Add(-2*leaseDuration)))
+
+		// When acquiring
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// Then the lease now names the new holder
+		if lease := getTestLease(t, client); leaseHolderID(lease) != "test-id-1" {
+			t.Errorf("expected takeover by test-id-1, got %q", leaseHolderID(lease))
+		}
+	})
+
+	t.Run("release deletes the lease and is idempotent", func(t *testing.T) {
+		// Given a held lease
+		lock, client := newTestLeaseLock(t, now)
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		// When releasing twice
+		if err := release(); err != nil {
+			t.Fatalf("expected nil release error, got %v", err)
+		}
+		if err := release(); err != nil {
+			t.Fatalf("expected idempotent release, got %v", err)
+		}
+
+		// Then the lease is gone
+		if lease := getTestLease(t, client); lease != nil {
+			t.Errorf("expected lease deleted, got %+v", lease)
+		}
+	})
+
+	t.Run("creates a missing namespace before the lease", func(t *testing.T) {
+		// Given a cluster without the gitops namespace, which refuses the first lease create
+		lock, client := newTestLeaseLock(t, now)
+		refused := false
+		client.PrependReactor("create", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if refused {
+				return false, nil, nil
+			}
+			refused = true
+			return true, nil, apierrors.NewNotFound(corev1.Resource("namespaces"), "system-gitops")
+		})
+
+		// When acquiring
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// Then the namespace and the lease both exist
+		if _, err := client.CoreV1().Namespaces().Get(context.Background(), "system-gitops", metav1.GetOptions{}); err != nil {
+			t.Errorf("expected the namespace to be created, got %v", err)
+		}
+		if lease := getTestLease(t, client); leaseHolderID(lease) != "test-id-1" {
+			t.Errorf("expected lease held by test-id-1, got %+v", lease)
+		}
+	})
+
+	t.Run("retries an unreachable api server and then fails", func(t *testing.T) {
+		// Given an API server that refuses connections
+		lock, client := newTestLeaseLock(t, now)
+		lock.retryInterval = time.Millisecond
+		lock.unreachableWindow = 20 * time.Millisecond
+		var attempts atomic.Int32
+		client.PrependReactor("get", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			attempts.Add(1)
+			return true, nil, errors.New("dial tcp 10.5.0.2:6443: connect: connection refused")
+		})
+
+		// When acquiring without waiting
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the request is retried and the lock fails as unreachable rather than unavailable
+		if !errors.Is(err, errBackendUnreachable) || errors.Is(err, errBackendUnavailable) {
+			t.Fatalf("expected errBackendUnreachable, got %v", err)
+		}
+		if attempts.Load() < 2 {
+			t.Errorf("expected the request to be retried, got %d attempts", attempts.Load())
+		}
+	})
+
+	t.Run("acquires once an unreachable api server answers", func(t *testing.T) {
+		// Given an API server that refuses the first connection only
+		lock, client := newTestLeaseLock(t, now)
+		lock.retryInterval = time.Millisecond
+		var attempts atomic.Int32
+		client.PrependReactor("get", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if attempts.Add(1) == 1 {
+				return true, nil, errors.New("dial tcp 10.5.0.2:6443: i/o timeout")
+			}
+			return false, nil, nil
+		})
+
+		// When acquiring without waiting
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the lease is taken on the retry
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+		if lease := getTestLease(t, client); leaseHolderID(lease) != "test-id-1" {
+			t.Errorf("expected lease held by test-id-1, got %+v", lease)
+		}
+	})
+
+	t.Run("does not treat an api refusal as unavailable", func(t *testing.T) {
+		// Given an API server that forbids reading the lease
+		lock, client := newTestLeaseLock(t, now)
+		client.PrependReactor("get", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(coordinationv1.Resource("leases"), "windsor-stacklock-test", errors.New("rbac"))
+		})
+
+		// When acquiring
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the refusal is returned as is
+		if err == nil || errors.Is(err, errBackendUnavailable) || errors.Is(err, errBackendUnreachable) {
+			t.Fatalf("expected a plain error, got %v", err)
+		}
+	})
+
+	t.Run("renews the lease while held", func(t *testing.T) {
+		// Given a lock that renews quickly and a clock that has moved on since acquisition
+		lock, client := newTestLeaseLock(t, now)
+		lock.renewInterval = 10 * time.Millisecond
+		var clock atomic.Int64
+		clock.Store(now.UnixNano())
+		lock.now = func() time.Time { return time.Unix(0, clock.Load()).UTC() }
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+		later := now.Add(30 * time.Second)
+		clock.Store(later.UnixNano())
+
+		// When renewal has had time to run
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if lease := getTestLease(t, client); lease.Spec.RenewTime.Time.Equal(later) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		// Then the renew time should have advanced
+		t.Fatal("expected the lease renew time to advance")
+	})
+}
+
+// =============================================================================
+// Test Shared
+// =============================================================================
+
+func TestKubernetesLeaseLock_Shared(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// newReaderInfo returns a Shared holder with the given ID.
+	newReaderInfo := func(id string) LockInfo {
+		info := newTestLockInfo()
+		info.ID = id
+		info.Mode = Shared
+		return info
+	}
+
+	t.Run("readers hold the lock together", func(t *testing.T) {
+		// Given a lock with no holder
+		lock, client := newTestLeaseLock(t, now)
+
+		// When two readers acquire without waiting
+		for _, id := range []string{"reader-1", "reader-2"} {
+			release, err := lock.Acquire(context.Background(), newReaderInfo(id), 0)
+			if err != nil {
+				t.Fatalf("expected reader %s to acquire, got %v", id, err)
+			}
+			defer release()
+		}
+
+		// Then each holds a reader lease and the writer lease is untouched
+		readers, err := lock.readerLeases(context.Background())
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if len(readers) != 2 {
+			t.Errorf("expected 2 reader leases, got %d", len(readers))
+		}
+		if lease := getTestLease(t, client); lease != nil {
+			t.Errorf("expected no writer lease, got %+v", lease)
+		}
+	})
+
+	t.Run("a writer waits for a live reader", func(t *testing.T) {
+		// Given a reader holding the lock
+		lock, client := newTestLeaseLock(t, now)
+		release, err := lock.Acquire(context.Background(), newReaderInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// When a writer acquires without waiting
+		_, err = lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then it is busy with the reader and has yielded the writer lease again
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected LockBusyError, got %v", err)
+		}
+		if busy.Holder == nil || busy.Holder.ID != "reader-1" {
+			t.Errorf("expected holder reader-1, got %+v", busy.Holder)
+		}
+		if lease := getTestLease(t, client); lease != nil {
+			t.Errorf("expected the writer lease to be released, got %+v", lease)
+		}
+	})
+
+	t.Run("a reader waits for a live writer", func(t *testing.T) {
+		// Given a writer holding the lock
+		lock, _ := newTestLeaseLock(t, now, newHeldLease(t, newTestLockInfo(), now.Add(-5*time.Second)))
+
+		// When a reader acquires without waiting
+		_, err := lock.Acquire(context.Background(), newReaderInfo("reader-1"), 0)
+
+		// Then it is busy with the writer and leaves no reader lease behind
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected LockBusyError, got %v", err)
+		}
+		if busy.Holder == nil || busy.Holder.ID != "test-id-1" {
+			t.Errorf("expected holder test-id-1, got %+v", busy.Holder)
+		}
+		readers, err := lock.readerLeases(context.Background())
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if len(readers) != 0 {
+			t.Errorf("expected no reader leases, got %d", len(readers))
+		}
+	})
+
+	t.Run("inspect and force-release cover the readers", func(t *testing.T) {
+		// Given a reader holding the lock
+		lock, _ := newTestLeaseLock(t, now)
+		release, err := lock.Acquire(context.Background(), newReaderInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// When inspecting and force-releasing the inspected holder
+		info, err := lock.Inspect(context.Background())
+		if err != nil || info == nil || info.ID != "reader-1" {
+			t.Fatalf("expected holder reader-1, got %+v (%v)", info, err)
+		}
+		if err := lock.ForceRelease(context.Background(), info.ID, "test"); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		// Then the reader lease is gone
+		readers, err := lock.readerLeases(context.Background())
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if len(readers) != 0 {
+			t.Errorf("expected no reader leases, got %d", len(readers))
+		}
+	})
+}
+
+// =============================================================================
+// Test Inspect and ForceRelease
+// =============================================================================
+
+func TestKubernetesLeaseLock_Inspect(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("returns nil when no lease exists", func(t *testing.T) {
+		// Given no lease
+		lock, _ := newTestLeaseLock(t, now)
+
+		// When inspecting
+		info, err := lock.Inspect(context.Background())
+
+		// Then there is no holder and no error
+		if err != nil || info != nil {
+			t.Fatalf("expected (nil, nil), got (%+v, %v)", info, err)
+		}
+	})
+
+	t.Run("returns the holder recorded on the lease", func(t *testing.T) {
+		// Given a held lease
+		holder := newTestLockInfo()
+		holder.PID = 4242
+		lock, _ := newTestLeaseLock(t, now, newHeldLease(t, holder, now))
+
+		// When inspecting
+		info, err := lock.Inspect(context.Background())
+
+		// Then the recorded holder is returned
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if info == nil || info.PID != 4242 {
+			t.Fatalf("expected populated holder, got %+v", info)
+		}
+	})
+
+	t.Run("errors when the holder info is corrupt", func(t *testing.T) {
+		// Given a held lease with a corrupt info annotation
+		lease := newHeldLease(t, newTestLockInfo(), now)
+		lease.Annotations[leaseInfoAnnotation] = `{"id":"orphan`
+		lock, _ := newTestLeaseLock(t, now, lease)
+
+		// When inspecting
+		_, err := lock.Inspect(context.Background())
+
+		// Then the corruption is reported
+		if err == nil || !strings.Contains(err.Error(), "corrupt") {
+			t.Fatalf("expected corrupt error, got %v", err)
+		}
+	})
+}
+
+func TestKubernetesLeaseLock_ForceRelease(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("deletes the lease of the inspected holder", func(t *testing.T) {
+		// Given a lease held by a live holder on another machine
+		lock, client := newTestLeaseLock(t, now, newHeldLease(t, newTestLockInfo(), now))
+
+		// When force-releasing it by the inspected ID
+		err := lock.ForceRelease(context.Background(), "test-id-1", "windsor unlock")
+
+		// Then the lease is gone
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if lease := getTestLease(t, client); lease != nil {
+			t.Errorf("expected lease deleted, got %+v", lease)
+		}
+	})
+
+	t.Run("refuses when a different holder took the lease", func(t *testing.T) {
+		// Given a lease now held by a different holder than was inspected
+		lock, client := newTestLeaseLock(t, now, newHeldLease(t, newTestLockInfo(), now))
+
+		// When force-releasing by a stale ID
+		err := lock.ForceRelease(context.Background(), "stale-id", "windsor unlock")
+
+		// Then the release is refused and the lease is kept
+		if err == nil || !strings.Contains(err.Error(), "different holder") {
+			t.Fatalf("expected refusal, got %v", err)
+		}
+		if lease := getTestLease(t, client); lease == nil {
+			t.Error("expected lease to be kept")
+		}
+	})
+
+	t.Run("is a no-op when no lease exists", func(t *testing.T) {
+		// Given no lease
+		lock, _ := newTestLeaseLock(t, now)
+
+		// When force-releasing
+		err := lock.ForceRelease(context.Background(), "", "windsor unlock")
+
+		// Then no error is returned
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	})
+}
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func TestForRuntime_KubernetesBackend(t *testing.T) {
+	t.Run("selects the lease lock in the gitops namespace", func(t *testing.T) {
+		// Given a runtime whose terraform backend is kubernetes
+		handler := config.NewMockConfigHandler()
+		handler.GetStringFunc = func(key string, defaultValue ...string) string {
+			switch key {
+			case "terraform.backend.type":
+				return "kubernetes"
+			case "gitops.namespace":
+				return "flux-system"
+			}
+			return ""
+		}
+		rt := newTestRuntime(t)
+		rt.ConfigHandler = handler
+		rt.ConfigRoot = t.TempDir()
+		if err := os.MkdirAll(filepath.Join(rt.ConfigRoot, ".kube"), 0o755); err != nil {
+			t.Fatalf("seed kubeconfig dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(rt.ConfigRoot, ".kube", "config"), []byte("apiVersion: v1\nkind: Config\n"), 0o600); err != nil {
+			t.Fatalf("seed kubeconfig: %v", err)
+		}
+		var kubeconfig string
+		orig := newKubernetesClient
+		newKubernetesClient = func(path string) (kubernetes.Interface, error) {
+			kubeconfig = path
+			return fake.NewClientset(), nil
+		}
+		t.Cleanup(func() { newKubernetesClient = orig })
+
+		// When deriving the lock
+		lock, err := ForRuntime(rt)
+
+		// Then it is the context's lease, reached through the context kubeconfig
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		lease, ok := lock.(*kubernetesLeaseLock)
+		if !ok {
+			t.Fatalf("expected kubernetes lease lock without a local fallback, got %T", lock)
+		}
+		if lease.namespace != "flux-system" || lease.name != "windsor-stacklock-test-ctx" {
+			t.Errorf("expected flux-system/windsor-stacklock-test-ctx, got %s/%s", lease.namespace, lease.name)
+		}
+		if kubeconfig != filepath.Join(rt.ConfigRoot, ".kube", "config") {
+			t.Errorf("expected the context kubeconfig, got %q", kubeconfig)
+		}
+	})
+
+	t.Run("falls back to the local flock before bootstrap", func(t *testing.T) {
+		// Given a kubernetes-backend runtime with no kubeconfig yet
+		handler := config.NewMockConfigHandler()
+		handler.GetStringFunc = func(key string, defaultValue ...string) string {
+			if key == "terraform.backend.type" {
+				return "kubernetes"
+			}
+			return ""
+		}
+		rt := newTestRuntime(t)
+		rt.ConfigHandler = handler
+		rt.ConfigRoot = t.TempDir()
+		orig := newKubernetesClient
+		newKubernetesClient = func(path string) (kubernetes.Interface, error) {
+			t.Fatalf("expected no kubernetes client to be built, got a request for %s", path)
+			return nil, nil
+		}
+		t.Cleanup(func() { newKubernetesClient = orig })
+
+		// When deriving the lock
+		lock, err := ForRuntime(rt)
+
+		// Then it is the local flock
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if _, ok := lock.(*localFlockLock); !ok {
+			t.Errorf("expected local flock lock, got %T", lock)
+		}
+	})
+}
+
+func TestLeaseNameForContext(t *testing.T) {
+	t.Run("sanitizes the context name", func(t *testing.T) {
+		// Given context names with characters a Lease name cannot hold
+		cases := map[string]string{
+			"local":                 "windsor-stacklock-local",
+			"Prod_EU.west":          "windsor-stacklock-prod-eu-west",
+			"--":                    "windsor-stacklock-default",
+			strings.Repeat("a", 80): "windsor-stacklock-" + strings.Repeat("a", 45),
+		}
+
+		for in, want := range cases {
+			// When deriving the lease name
+			got := leaseNameForContext(in)
+
+			// Then it is a valid, stable name
+			if got != want {
+				t.Errorf("leaseNameForContext(%q) = %q, want %q", in, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+// +build !windows
+
+package stacklock
+
+import (
+	"errors"
+	"syscall"
+)
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// processAlive reports whether a process with pid exists on this host. Signal 0 checks
+// existence without delivering anything; EPERM means the process exists but belongs to
+// another user.
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
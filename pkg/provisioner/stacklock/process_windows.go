@@ -0,0 +1,23 @@
+//go:build windows
+// +build windows
+
+package stacklock
+
+import (
+	"os"
+)
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// processAlive reports whether a process with pid exists on this host. On Windows,
+// FindProcess opens a handle to the process and fails when there is none.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
@@ -0,0 +1,650 @@
+package stacklock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/runtime/shell"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// s3LockKeyPrefix is the directory, under the backend prefix, that holds per-context lock
+// objects. It sits beside the state objects so the lock needs no access beyond the bucket
+// terraform already uses.
+const s3LockKeyPrefix = ".windsor-stacklock/"
+
+// s3ReaderSuffix replaces the lock object's .json extension to form the directory holding
+// one reader object per Shared holder.
+const s3ReaderSuffix = ".readers/"
+
+// s3LockTTL is how long a lock object stays held after its last heartbeat. A holder that
+// dies without releasing is treated as gone once this elapses.
+const s3LockTTL = 60 * time.Second
+
+// s3HeartbeatInterval is how often a held lock object's heartbeat is refreshed; a third of
+// s3LockTTL leaves room for two failed refreshes before the lock expires under a live holder.
+const s3HeartbeatInterval = s3LockTTL / 3
+
+// s3RequestTimeout bounds each aws CLI call the S3 lock makes.
+const s3RequestTimeout = 30 * time.Second
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// errObjectNotFound is returned by an objectStore when the requested key does not exist.
+var errObjectNotFound = errors.New("object not found")
+
+// errPreconditionFailed is returned by an objectStore when a conditional write is refused
+// because the object exists (If-None-Match) or has changed (If-Match).
+var errPreconditionFailed = errors.New("precondition failed")
+
+// s3LockRecord is the body of an S3 lock object: the holder's LockInfo, the time of its
+// last heartbeat, and the TTL after which a stale heartbeat releases the lock.
+type s3LockRecord struct {
+	Info       LockInfo  `json:"info"`
+	Heartbeat  time.Time `json:"heartbeat"`
+	TTLSeconds int       `json:"ttl_seconds"`
+}
+
+// s3Reader is one reader object: its key and its decoded record, nil when corrupt.
+type s3Reader struct {
+	key    string
+	record *s3LockRecord
+}
+
+// s3Lock is the object-storage implementation of StackLock. Mutual exclusion comes from
+// S3 conditional writes: the lock object is created with If-None-Match so only one writer
+// can create it, and every later write (heartbeat, takeover of an expired lock) is an
+// If-Match on the ETag the writer last saw. A Shared holder writes a reader object of its own
+// beside the lock object instead, so readers hold the lock together while a writer holds it
+// alone. now, heartbeatInterval, retryInterval and unreachableWindow are fields so tests can
+// drive expiry, heartbeats and retries.
+type s3Lock struct {
+	store             objectStore
+	bucket            string
+	key               string
+	heartbeatInterval time.Duration
+	retryInterval     time.Duration
+	unreachableWindow time.Duration
+	now               func() time.Time
+}
+
+// awsCLIObjectStore is the objectStore used against real buckets. It drives `aws s3api`
+// through the shell, so credentials, profiles, SSO, and an S3-compatible endpoint
+// (AWS_ENDPOINT_URL_S3) resolve exactly as they do for the rest of the context.
+type awsCLIObjectStore struct {
+	shell   shell.Shell
+	bucket  string
+	region  string
+	profile string
+}
+
+// =============================================================================
+// Interfaces
+// =============================================================================
+
+// objectStore is the slice of S3 the lock needs: read an object with its ETag, create it
+// only if absent, replace it only if unchanged, delete it, and list the keys under a prefix.
+type objectStore interface {
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	PutIfAbsent(ctx context.Context, key string, data []byte) (string, error)
+	PutIfMatch(ctx context.Context, key string, data []byte, etag string) (string, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// =============================================================================
+// Constructor
+// =============================================================================
+
+// NewS3Lock returns a StackLock backed by the object key in bucket, reached through the aws
+// CLI with the given region and profile (either may be empty to use the ambient settings).
+// The object is created on first Acquire, its heartbeat refreshed while held, and deleted on
+// Release. Requires a bucket that honours conditional writes, as S3 and MinIO do.
+func NewS3Lock(sh shell.Shell, bucket, key, region, profile string) StackLock {
+	return newS3Lock(&awsCLIObjectStore{shell: sh, bucket: bucket, region: region, profile: profile}, bucket, key)
+}
+
+// newS3Lock returns an s3Lock over store, which tests replace with an in-memory stand-in.
+func newS3Lock(store objectStore, bucket, key string) *s3Lock {
+	return &s3Lock{
+		store:             store,
+		bucket:            bucket,
+		key:               key,
+		heartbeatInterval: s3HeartbeatInterval,
+		retryInterval:     leaseRetryInterval,
+		unreachableWindow: unreachableRetryWindow,
+		now:               time.Now,
+	}
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Acquire creates the lock object, or takes it over when its heartbeat is older than its
+// TTL, and otherwise fails immediately (timeout <= 0) or retries every leaseRetryInterval
+// until it is held, the timeout elapses, or ctx is cancelled — the same contract as the
+// local flock. A lost create or takeover race surfaces as a failed precondition and is
+// treated as contention. An endpoint that does not answer is retried for at least
+// unreachableRetryWindow and then reported as an error. A Shared info writes a reader object
+// instead, so readers hold the lock together and only a writer waits. Once held, the
+// heartbeat is refreshed in the background until the returned Release runs.
+func (s *s3Lock) Acquire(ctx context.Context, info LockInfo, timeout time.Duration) (Release, error) {
+	if info.PID == 0 {
+		info.PID = os.Getpid()
+	}
+
+	var etag string
+	err := retryAcquire(ctx, s.describe(), timeout, s.retryInterval, s.unreachableWindow, func() (bool, *LockInfo, error) {
+		var holder *LockInfo
+		var err error
+		etag, holder, err = s.tryAcquire(ctx, info)
+		return etag != "", holder, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.key
+	if info.Mode == Shared {
+		key = s.readerKey(info.ID)
+	}
+	return s.startHeartbeat(key, info, etag), nil
+}
+
+// Inspect returns the holder recorded in the lock object, or in the first live reader object
+// when the lock is shared. A missing object and no live reader returns (nil, nil). An object
+// that cannot be decoded returns an error, matching the local lock's treatment of a corrupt
+// sidecar so windsor unlock still clears it.
+func (s *s3Lock) Inspect(ctx context.Context) (*LockInfo, error) {
+	data, _, err := s.store.Get(ctx, s.key)
+	if errors.Is(err, errObjectNotFound) {
+		readers, err := s.readers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, reader := range readers {
+			if reader.record != nil && s.isLive(reader.record) {
+				return &reader.record.Info, nil
+			}
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stacklock: reading %s: %w", s.describe(), err)
+	}
+	var record s3LockRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("stacklock: holder info in %s is corrupt: %w", s.describe(), err)
+	}
+	return &record.Info, nil
+}
+
+// ForceRelease deletes the lock object and every reader object so the next Acquire starts
+// from a clean slate, whether or not the holders are alive. As with the local lock, a
+// non-empty lockID refuses the release when none of the current holders has that ID, as a
+// different holder has taken the lock since the caller inspected it. A missing object is not
+// an error.
+func (s *s3Lock) ForceRelease(ctx context.Context, lockID string, reason string) error {
+	var keys, holders []string
+	data, _, err := s.store.Get(ctx, s.key)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return fmt.Errorf("stacklock: force-release (%s): %w", reason, err)
+	}
+	if err == nil {
+		keys = append(keys, s.key)
+		var record s3LockRecord
+		if json.Unmarshal(data, &record) == nil {
+			holders = append(holders, record.Info.ID)
+		}
+	}
+	readers, err := s.readers(ctx)
+	if err != nil {
+		return fmt.Errorf("stacklock: force-release (%s): %w", reason, err)
+	}
+	for _, reader := range readers {
+		keys = append(keys, reader.key)
+		if reader.record != nil {
+			holders = append(holders, reader.record.Info.ID)
+		}
+	}
+
+	if lockID != "" && len(holders) > 0 && !slices.Contains(holders, lockID) {
+		return fmt.Errorf("stacklock: refusing to force-release: lock is now held by a different holder (%q, not %q) — a new windsor process acquired it", holders[0], lockID)
+	}
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("stacklock: force-release (%s): %w", reason, err)
+		}
+	}
+	return nil
+}
+
+// Get downloads key to a temporary file and returns its body and ETag.
+func (a *awsCLIObjectStore) Get(ctx context.Context, key string) ([]byte, string, error) {
+	dir, err := os.MkdirTemp("", "windsor-stacklock-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(dir)
+	outfile := filepath.Join(dir, "lock.json")
+
+	out, err := a.run("get-object", "--bucket", a.bucket, "--key", key, outfile)
+	if err != nil {
+		return nil, "", err
+	}
+	// #nosec G304 - outfile is a path inside a temporary directory created above
+	data, err := os.ReadFile(outfile)
+	if err != nil {
+		return nil, "", err
+	}
+	etag, err := parseETag(out)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, etag, nil
+}
+
+// PutIfAbsent uploads data to key only if no object exists there, returning the new ETag.
+func (a *awsCLIObjectStore) PutIfAbsent(ctx context.Context, key string, data []byte) (string, error) {
+	return a.put(key, data, "--if-none-match", "*")
+}
+
+// PutIfMatch replaces key with data only if its current ETag is etag, returning the new ETag.
+func (a *awsCLIObjectStore) PutIfMatch(ctx context.Context, key string, data []byte, etag string) (string, error) {
+	return a.put(key, data, "--if-match", etag)
+}
+
+// Delete removes key. Deleting a missing key succeeds, as it does in S3.
+func (a *awsCLIObjectStore) Delete(ctx context.Context, key string) error {
+	_, err := a.run("delete-object", "--bucket", a.bucket, "--key", key)
+	return err
+}
+
+// List returns every key under prefix. The aws CLI follows continuation tokens itself, and
+// prints nothing when no key matches.
+func (a *awsCLIObjectStore) List(ctx context.Context, prefix string) ([]string, error) {
+	out, err := a.run("list-objects-v2", "--bucket", a.bucket, "--prefix", prefix)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(out) == "" {
+		return nil, nil
+	}
+	var resp struct {
+		Contents []struct {
+			Key string `json:"Key"`
+		} `json:"Contents"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		return nil, fmt.Errorf("error parsing aws s3api output: %w", err)
+	}
+	keys := make([]string, 0, len(resp.Contents))
+	for _, obj := range resp.Contents {
+		keys = append(keys, obj.Key)
+	}
+	return keys, nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// tryAcquire makes one attempt to take the lock for info. It returns the ETag of the written
+// object when the lock is now held, ("", holder, nil) on contention with holder best-effort,
+// and an error when the bucket does not exist, which wraps errBackendUnavailable, when the
+// endpoint does not answer, which wraps errBackendUnreachable, or when S3 refuses for another
+// reason. A writer takes the lock object and then yields it again if a
+// live reader object exists; a reader writes its own object and then yields it if the lock
+// object is held. Each registers before it checks for the other, so of a writer and a reader
+// racing, at least one sees the other and neither proceeds alongside it.
+func (s *s3Lock) tryAcquire(ctx context.Context, info LockInfo) (string, *LockInfo, error) {
+	if info.Mode == Shared {
+		return s.tryAcquireShared(ctx, info)
+	}
+	etag, holder, err := s.claim(ctx, s.key, info)
+	if err != nil || etag == "" {
+		return etag, holder, err
+	}
+	readers, err := s.readers(ctx)
+	if err != nil {
+		_ = s.release(s.key, info.ID)
+		return "", nil, err
+	}
+	for _, reader := range readers {
+		if reader.record == nil || s.isLive(reader.record) {
+			_ = s.release(s.key, info.ID)
+			if reader.record == nil {
+				return "", nil, nil
+			}
+			return "", &reader.record.Info, nil
+		}
+		_ = s.release(reader.key, reader.record.Info.ID)
+	}
+	return etag, nil, nil
+}
+
+// tryAcquireShared makes one attempt to write a reader object for info, with the same results
+// as tryAcquire.
+func (s *s3Lock) tryAcquireShared(ctx context.Context, info LockInfo) (string, *LockInfo, error) {
+	if holder, held, err := s.writer(ctx); err != nil || held {
+		return "", holder, err
+	}
+	key := s.readerKey(info.ID)
+	etag, holder, err := s.claim(ctx, key, info)
+	if err != nil || etag == "" {
+		return etag, holder, err
+	}
+	if holder, held, err := s.writer(ctx); err != nil || held {
+		_ = s.release(key, info.ID)
+		return "", holder, err
+	}
+	return etag, nil, nil
+}
+
+// claim makes one attempt to write the object key for info, creating it when absent and
+// taking it over when its heartbeat has expired. An object that cannot be decoded is treated
+// as held, so it is only ever cleared deliberately with windsor unlock. An expired object
+// deleted by its holder between the read and the takeover is contention too: the next attempt
+// creates it afresh.
+func (s *s3Lock) claim(ctx context.Context, key string, info LockInfo) (string, *LockInfo, error) {
+	data, err := s.encode(info)
+	if err != nil {
+		return "", nil, err
+	}
+
+	etag, err := s.store.PutIfAbsent(ctx, key, data)
+	if err == nil {
+		return etag, nil, nil
+	}
+	if !errors.Is(err, errPreconditionFailed) {
+		return "", nil, fmt.Errorf("stacklock: creating %s: %w", s.describe(), err)
+	}
+
+	current, currentETag, err := s.store.Get(ctx, key)
+	if errors.Is(err, errObjectNotFound) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("stacklock: reading %s: %w", s.describe(), err)
+	}
+	var record s3LockRecord
+	if err := json.Unmarshal(current, &record); err != nil {
+		return "", nil, nil
+	}
+	if s.isLive(&record) {
+		return "", &record.Info, nil
+	}
+
+	etag, err = s.store.PutIfMatch(ctx, key, data, currentETag)
+	if errors.Is(err, errPreconditionFailed) {
+		return "", &record.Info, nil
+	}
+	if errors.Is(err, errObjectNotFound) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("stacklock: taking over %s: %w", s.describe(), err)
+	}
+	return etag, nil, nil
+}
+
+// writer reports whether the lock object is held, with its holder best-effort. An object
+// that cannot be decoded counts as held, as it does in claim.
+func (s *s3Lock) writer(ctx context.Context) (*LockInfo, bool, error) {
+	data, _, err := s.store.Get(ctx, s.key)
+	if errors.Is(err, errObjectNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("stacklock: reading %s: %w", s.describe(), err)
+	}
+	var record s3LockRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, true, nil
+	}
+	if !s.isLive(&record) {
+		return nil, false, nil
+	}
+	return &record.Info, true, nil
+}
+
+// readers returns the reader objects sharing the lock, live or expired, sorted by key.
+// Objects deleted between the listing and the read are skipped.
+func (s *s3Lock) readers(ctx context.Context) ([]s3Reader, error) {
+	keys, err := s.store.List(ctx, s.readerPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("stacklock: listing readers of %s: %w", s.describe(), err)
+	}
+	slices.Sort(keys)
+	var readers []s3Reader
+	for _, key := range keys {
+		data, _, err := s.store.Get(ctx, key)
+		if errors.Is(err, errObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("stacklock: reading %s: %w", s.describe(), err)
+		}
+		reader := s3Reader{key: key}
+		var record s3LockRecord
+		if json.Unmarshal(data, &record) == nil {
+			reader.record = &record
+		}
+		readers = append(readers, reader)
+	}
+	return readers, nil
+}
+
+// isLive reports whether record's last heartbeat is within its TTL.
+func (s *s3Lock) isLive(record *s3LockRecord) bool {
+	return s.now().Before(record.Heartbeat.Add(time.Duration(record.TTLSeconds) * time.Second))
+}
+
+// readerPrefix returns the key prefix under which reader objects are written.
+func (s *s3Lock) readerPrefix() string {
+	return strings.TrimSuffix(s.key, ".json") + s3ReaderSuffix
+}
+
+// readerKey returns the key of the reader object held by the Shared holder id.
+func (s *s3Lock) readerKey(id string) string {
+	return s.readerPrefix() + id + ".json"
+}
+
+// encode returns the lock object body for info with a fresh heartbeat.
+func (s *s3Lock) encode(info LockInfo) ([]byte, error) {
+	data, err := json.Marshal(s3LockRecord{Info: info, Heartbeat: s.now().UTC(), TTLSeconds: int(s3LockTTL / time.Second)})
+	if err != nil {
+		return nil, fmt.Errorf("stacklock: encoding holder info: %w", err)
+	}
+	return data, nil
+}
+
+// startHeartbeat refreshes the heartbeat of the object key every heartbeatInterval in the
+// background, each write conditioned on the ETag of the previous one, and returns the Release
+// that stops the heartbeat and deletes the object. A refused heartbeat, or an object that is
+// gone, means the lock was force-released or taken over; it is reported on stderr once and
+// the heartbeat stops. Other failures are reported and retried on the next tick. Neither aborts the operation holding
+// the lock, which terraform's own state lock still protects. Release is idempotent.
+func (s *s3Lock) startHeartbeat(key string, info LockInfo, etag string) Release {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	var mu sync.Mutex
+	current := etag
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			data, err := s.encode(info)
+			if err != nil {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+			mu.Lock()
+			next, err := s.store.PutIfMatch(ctx, key, data, current)
+			if err == nil {
+				current = next
+			}
+			mu.Unlock()
+			cancel()
+			if errors.Is(err, errPreconditionFailed) || errors.Is(err, errObjectNotFound) {
+				fmt.Fprintf(os.Stderr, "warning: stack lock %s was released or taken over by another holder\n", s.describe())
+				return
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: failed to refresh stack lock %s: %v\n", s.describe(), err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() error {
+		var err error
+		once.Do(func() {
+			close(stop)
+			<-done
+			err = s.release(key, info.ID)
+		})
+		return err
+	}
+}
+
+// release deletes the object key if it is still held by id. An object that is gone or has
+// been taken over after a force-release is left alone.
+func (s *s3Lock) release(key, id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+	defer cancel()
+	data, _, err := s.store.Get(ctx, key)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stacklock: releasing %s: %w", s.describe(), err)
+	}
+	var record s3LockRecord
+	if json.Unmarshal(data, &record) != nil || record.Info.ID != id {
+		return nil
+	}
+	if err := s.store.Delete(ctx, key); err != nil {
+		return fmt.Errorf("stacklock: releasing %s: %w", s.describe(), err)
+	}
+	return nil
+}
+
+// describe names the lock object in busy errors and waiting notices.
+func (s *s3Lock) describe() string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.key)
+}
+
+// put writes data to key through a temporary file with the given conditional flags.
+func (a *awsCLIObjectStore) put(key string, data []byte, condition ...string) (string, error) {
+	dir, err := os.MkdirTemp("", "windsor-stacklock-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	body := filepath.Join(dir, "lock.json")
+	if err := os.WriteFile(body, data, lockInfoPerm); err != nil {
+		return "", err
+	}
+
+	args := []string{"put-object", "--bucket", a.bucket, "--key", key, "--body", body, "--content-type", "application/json"}
+	out, err := a.run(append(args, condition...)...)
+	if err != nil {
+		return "", err
+	}
+	return parseETag(out)
+}
+
+// run executes an `aws s3api` subcommand and maps the CLI's failures onto the objectStore and
+// backend sentinel errors; see classifyS3Error.
+func (a *awsCLIObjectStore) run(args ...string) (string, error) {
+	args = append([]string{"s3api"}, args...)
+	args = append(args, "--output", "json")
+	if a.profile != "" {
+		args = append(args, "--profile", a.profile)
+	}
+	env := map[string]string{}
+	if a.region != "" {
+		env["AWS_REGION"] = a.region
+	}
+	out, err := a.shell.ExecSilentWithEnvAndTimeout("aws", env, args, s3RequestTimeout)
+	if err != nil {
+		return "", classifyS3Error(err)
+	}
+	return out, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// classifyS3Error maps an aws CLI failure onto the objectStore sentinel errors by the S3
+// error code in its output, wrapping the original so the detail is kept. Only a bucket that
+// does not exist yet, as before bootstrap's backend component creates it, marks the backend
+// unavailable so the local flock stands in. An endpoint that cannot be reached, a request that
+// timed out, or a server error marks it unreachable, which Acquire retries and then reports.
+// A CLI too old to know the conditional-write flags is reported as such, since the lock cannot
+// work without them.
+func classifyS3Error(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "Unknown options") && (strings.Contains(msg, "--if-none-match") || strings.Contains(msg, "--if-match")):
+		return fmt.Errorf("the aws CLI does not support the conditional writes the s3 stack lock needs; upgrade it to %s or later: %w", constants.MinimumVersionAWSStackLock, err)
+	case strings.Contains(msg, "NoSuchBucket"):
+		return fmt.Errorf("%w: %v", errBackendUnavailable, err)
+	case strings.Contains(msg, "Could not connect to the endpoint URL"), strings.Contains(msg, "Connect timeout"),
+		strings.Contains(msg, "Read timeout"), strings.Contains(msg, "Connection was closed"), strings.Contains(msg, "command timed out"),
+		strings.Contains(msg, "ServiceUnavailable"), strings.Contains(msg, "SlowDown"), strings.Contains(msg, "InternalError"),
+		strings.Contains(msg, "(500)"), strings.Contains(msg, "(503)"):
+		return fmt.Errorf("%w: %v", errBackendUnreachable, err)
+	case strings.Contains(msg, "NoSuchKey"), strings.Contains(msg, "(404)"):
+		return fmt.Errorf("%w: %v", errObjectNotFound, err)
+	case strings.Contains(msg, "PreconditionFailed"), strings.Contains(msg, "ConditionalRequestConflict"), strings.Contains(msg, "(412)"):
+		return fmt.Errorf("%w: %v", errPreconditionFailed, err)
+	}
+	return err
+}
+
+// parseETag extracts the ETag from the JSON the aws CLI prints for get-object and put-object.
+func parseETag(output string) (string, error) {
+	var resp struct {
+		ETag string `json:"ETag"`
+	}
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		return "", fmt.Errorf("error parsing aws s3api output: %w", err)
+	}
+	if resp.ETag == "" {
+		return "", fmt.Errorf("aws s3api output has no ETag")
+	}
+	return resp.ETag, nil
+}
+
+// s3LockKey returns the lock object key for a context under the backend prefix, beside the
+// state objects terraform writes there.
+func s3LockKey(prefix, contextName string) string {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix + s3LockKeyPrefix + contextName + ".json"
+}
@@ -0,0 +1,835 @@
+package stacklock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/windsorcli/cli/pkg/constants"
+	"github.com/windsorcli/cli/pkg/runtime/config"
+	"github.com/windsorcli/cli/pkg/runtime/shell"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// memoryObjectStore is a MinIO-style stand-in for a bucket: objects carry an ETag that
+// changes on every write, and conditional puts are refused exactly as S3 refuses them.
+type memoryObjectStore struct {
+	mu      sync.Mutex
+	objects map[string]memoryObject
+	writes  int
+}
+
+// memoryObject is one stored body and its ETag.
+type memoryObject struct {
+	data []byte
+	etag string
+}
+
+func newMemoryObjectStore() *memoryObjectStore {
+	return &memoryObjectStore{objects: map[string]memoryObject{}}
+}
+
+func (m *memoryObjectStore) Get(ctx context.Context, key string) ([]byte, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	obj, ok := m.objects[key]
+	if !ok {
+		return nil, "", errObjectNotFound
+	}
+	return obj.data, obj.etag, nil
+}
+
+func (m *memoryObjectStore) PutIfAbsent(ctx context.Context, key string, data []byte) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.objects[key]; ok {
+		return "", errPreconditionFailed
+	}
+	return m.put(key, data), nil
+}
+
+func (m *memoryObjectStore) PutIfMatch(ctx context.Context, key string, data []byte, etag string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	obj, ok := m.objects[key]
+	if !ok {
+		return "", errObjectNotFound
+	}
+	if obj.etag != etag {
+		return "", errPreconditionFailed
+	}
+	return m.put(key, data), nil
+}
+
+func (m *memoryObjectStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memoryObjectStore) List(ctx context.Context, prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for key := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// put stores data under key with a fresh ETag. Callers hold m.mu.
+func (m *memoryObjectStore) put(key string, data []byte) string {
+	m.writes++
+	etag := fmt.Sprintf(`"etag-%d"`, m.writes)
+	m.objects[key] = memoryObject{data: data, etag: etag}
+	return etag
+}
+
+// record decodes the lock object at key, or returns nil when it does not exist.
+func (m *memoryObjectStore) record(t *testing.T, key string) *s3LockRecord {
+	t.Helper()
+	data, _, err := m.Get(context.Background(), key)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	var record s3LockRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("decode lock object: %v", err)
+	}
+	return &record
+}
+
+// seed stores a lock object held by info with its last heartbeat at heartbeat.
+func (m *memoryObjectStore) seed(t *testing.T, key string, info LockInfo, heartbeat time.Time) {
+	t.Helper()
+	data, err := json.Marshal(s3LockRecord{Info: info, Heartbeat: heartbeat, TTLSeconds: int(s3LockTTL / time.Second)})
+	if err != nil {
+		t.Fatalf("encode lock object: %v", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(key, data)
+}
+
+// vanishingObjectStore is a memoryObjectStore whose conditional replaces find the object
+// already deleted, as when its holder releases between a contender's read and takeover.
+type vanishingObjectStore struct {
+	*memoryObjectStore
+	replaces atomic.Int32
+}
+
+func (v *vanishingObjectStore) PutIfMatch(ctx context.Context, key string, data []byte, etag string) (string, error) {
+	v.replaces.Add(1)
+	_ = v.Delete(ctx, key)
+	return v.memoryObjectStore.PutIfMatch(ctx, key, data, etag)
+}
+
+// unreachableObjectStore is a memoryObjectStore whose conditional creates fail as unreachable
+// the first failures times, as when the endpoint drops connections for a while.
+type unreachableObjectStore struct {
+	*memoryObjectStore
+	failures int32
+	attempts atomic.Int32
+}
+
+func (u *unreachableObjectStore) PutIfAbsent(ctx context.Context, key string, data []byte) (string, error) {
+	if u.attempts.Add(1) <= u.failures {
+		return "", fmt.Errorf("%w: Could not connect to the endpoint URL", errBackendUnreachable)
+	}
+	return u.memoryObjectStore.PutIfAbsent(ctx, key, data)
+}
+
+const testS3LockKey = "state/.windsor-stacklock/test-ctx.json"
+
+// newTestS3Lock returns an S3 lock over an in-memory store with the clock pinned to now.
+func newTestS3Lock(t *testing.T, now time.Time) (*s3Lock, *memoryObjectStore) {
+	t.Helper()
+	store := newMemoryObjectStore()
+	lock := newS3Lock(store, "tf-state", testS3LockKey)
+	lock.now = func() time.Time { return now }
+	return lock, store
+}
+
+// =============================================================================
+// Test Acquire
+// =============================================================================
+
+func TestS3Lock_Acquire(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("retries an unreachable endpoint and then fails", func(t *testing.T) {
+		// Given an endpoint that never answers
+		store := &unreachableObjectStore{memoryObjectStore: newMemoryObjectStore(), failures: 1 << 30}
+		lock := newS3Lock(store, "tf-state", testS3LockKey)
+		lock.now = func() time.Time { return now }
+		lock.retryInterval = time.Millisecond
+		lock.unreachableWindow = 20 * time.Millisecond
+
+		// When acquiring without waiting
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the create is retried and the lock fails as unreachable rather than unavailable
+		if !errors.Is(err, errBackendUnreachable) || errors.Is(err, errBackendUnavailable) {
+			t.Fatalf("expected errBackendUnreachable, got %v", err)
+		}
+		if store.attempts.Load() < 2 {
+			t.Errorf("expected the create to be retried, got %d attempts", store.attempts.Load())
+		}
+	})
+
+	t.Run("acquires once an unreachable endpoint answers", func(t *testing.T) {
+		// Given an endpoint that drops the first connection only
+		store := &unreachableObjectStore{memoryObjectStore: newMemoryObjectStore(), failures: 1}
+		lock := newS3Lock(store, "tf-state", testS3LockKey)
+		lock.now = func() time.Time { return now }
+		lock.retryInterval = time.Millisecond
+
+		// When acquiring without waiting
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the lock object is written on the retry
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+		if record := store.record(t, testS3LockKey); record == nil || record.Info.ID != "test-id-1" {
+			t.Errorf("expected the lock held by test-id-1, got %+v", record)
+		}
+	})
+
+	t.Run("creates the lock object with the holder info", func(t *testing.T) {
+		// Given an empty bucket
+		lock, store := newTestS3Lock(t, now)
+
+		// When acquiring
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// Then the object names the holder and carries a heartbeat and TTL
+		record := store.record(t, testS3LockKey)
+		if record == nil || record.Info.ID != "test-id-1" {
+			t.Fatalf("expected object held by test-id-1, got %+v", record)
+		}
+		if !record.Heartbeat.Equal(now) || record.TTLSeconds != 60 {
+			t.Errorf("expected heartbeat %v and ttl 60, got %v and %d", now, record.Heartbeat, record.TTLSeconds)
+		}
+	})
+
+	t.Run("fails fast when a live holder has the lock", func(t *testing.T) {
+		// Given an object whose heartbeat is recent
+		lock, store := newTestS3Lock(t, now)
+		other := newTestLockInfo()
+		other.ID = "other"
+		other.Who = "alice@laptop"
+		store.seed(t, testS3LockKey, other, now.Add(-5*time.Second))
+
+		// When acquiring without waiting
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then a busy error names the holder and the object
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected LockBusyError, got %v", err)
+		}
+		if busy.Holder == nil || busy.Holder.Who != "alice@laptop" {
+			t.Errorf("expected holder alice@laptop, got %+v", busy.Holder)
+		}
+		if !strings.Contains(busy.Error(), "s3://tf-state/"+testS3LockKey) {
+			t.Errorf("expected the object to be named, got %q", busy.Error())
+		}
+	})
+
+	t.Run("takes over a lock whose heartbeat has expired", func(t *testing.T) {
+		// Given an object whose holder stopped heartbeating longer ago than the TTL
+		lock, store := newTestS3Lock(t, now)
+		other := newTestLockInfo()
+		other.ID = "dead"
+		store.seed(t, testS3LockKey, other, now.Add(-2*s3LockTTL))
+
+		// When acquiring
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// Then the object now names the new holder
+		if record := store.record(t, testS3LockKey); record.Info.ID != "test-id-1" {
+			t.Errorf("expected takeover by test-id-1, got %q", record.Info.ID)
+		}
+	})
+
+	t.Run("treats a takeover of a vanished object as contention", func(t *testing.T) {
+		// Given an expired object its holder deletes just before the takeover lands
+		lock, store := newTestS3Lock(t, now)
+		vanishing := &vanishingObjectStore{memoryObjectStore: store}
+		lock.store = vanishing
+		other := newTestLockInfo()
+		other.ID = "dead"
+		store.seed(t, testS3LockKey, other, now.Add(-2*s3LockTTL))
+
+		// When acquiring without waiting
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the lost takeover is reported as contention, not a failure
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected LockBusyError, got %v", err)
+		}
+	})
+
+	t.Run("treats a corrupt lock object as held", func(t *testing.T) {
+		// Given an object that is not a lock record
+		lock, store := newTestS3Lock(t, now)
+		if _, err := store.PutIfAbsent(context.Background(), testS3LockKey, []byte("{not json")); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+
+		// When acquiring without waiting
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the lock is busy with no known holder
+		var busy *LockBusyError
+		if !errors.As(err, &busy) || busy.Holder != nil {
+			t.Fatalf("expected LockBusyError with nil holder, got %v", err)
+		}
+	})
+
+	t.Run("release deletes the lock object and is idempotent", func(t *testing.T) {
+		// Given a held lock
+		lock, store := newTestS3Lock(t, now)
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		// When releasing twice
+		if err := release(); err != nil {
+			t.Fatalf("expected nil release error, got %v", err)
+		}
+		if err := release(); err != nil {
+			t.Fatalf("expected idempotent release, got %v", err)
+		}
+
+		// Then the object is gone
+		if record := store.record(t, testS3LockKey); record != nil {
+			t.Errorf("expected object deleted, got %+v", record)
+		}
+	})
+
+	t.Run("release leaves a lock taken over by another holder", func(t *testing.T) {
+		// Given a held lock that was force-released and re-acquired by someone else
+		lock, store := newTestS3Lock(t, now)
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		other := newTestLockInfo()
+		other.ID = "other"
+		_ = store.Delete(context.Background(), testS3LockKey)
+		store.seed(t, testS3LockKey, other, now)
+
+		// When the original holder releases
+		if err := release(); err != nil {
+			t.Fatalf("expected nil release error, got %v", err)
+		}
+
+		// Then the new holder's object is untouched
+		if record := store.record(t, testS3LockKey); record == nil || record.Info.ID != "other" {
+			t.Errorf("expected object still held by other, got %+v", record)
+		}
+	})
+
+	t.Run("refreshes the heartbeat while held", func(t *testing.T) {
+		// Given a lock that heartbeats quickly and a clock that has moved on since acquisition
+		lock, store := newTestS3Lock(t, now)
+		lock.heartbeatInterval = 10 * time.Millisecond
+		var clock atomic.Int64
+		clock.Store(now.UnixNano())
+		lock.now = func() time.Time { return time.Unix(0, clock.Load()).UTC() }
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+		later := now.Add(30 * time.Second)
+		clock.Store(later.UnixNano())
+
+		// When the heartbeat has had time to run
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if record := store.record(t, testS3LockKey); record.Heartbeat.Equal(later) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		// Then the heartbeat should have advanced
+		t.Fatal("expected the heartbeat to advance")
+	})
+
+	t.Run("stops the heartbeat once the lock object is gone", func(t *testing.T) {
+		// Given a held lock that heartbeats quickly, whose object is then deleted
+		lock, store := newTestS3Lock(t, now)
+		vanishing := &vanishingObjectStore{memoryObjectStore: store}
+		lock.store = vanishing
+		lock.heartbeatInterval = 5 * time.Millisecond
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// When the heartbeat has had time for many ticks
+		time.Sleep(100 * time.Millisecond)
+
+		// Then it gave up after the first refresh found the object gone
+		if got := vanishing.replaces.Load(); got != 1 {
+			t.Errorf("expected one refresh before the heartbeat stopped, got %d", got)
+		}
+	})
+}
+
+// =============================================================================
+// Test Shared
+// =============================================================================
+
+func TestS3Lock_Shared(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// newReaderInfo returns a Shared holder with the given ID.
+	newReaderInfo := func(id string) LockInfo {
+		info := newTestLockInfo()
+		info.ID = id
+		info.Mode = Shared
+		return info
+	}
+
+	t.Run("readers hold the lock together", func(t *testing.T) {
+		// Given an empty bucket
+		lock, store := newTestS3Lock(t, now)
+
+		// When two readers acquire without waiting
+		for _, id := range []string{"reader-1", "reader-2"} {
+			release, err := lock.Acquire(context.Background(), newReaderInfo(id), 0)
+			if err != nil {
+				t.Fatalf("expected reader %s to acquire, got %v", id, err)
+			}
+			defer release()
+		}
+
+		// Then each holds a reader object beside the lock object, which is untouched
+		keys, _ := store.List(context.Background(), lock.readerPrefix())
+		if len(keys) != 2 {
+			t.Errorf("expected 2 reader objects, got %v", keys)
+		}
+		if record := store.record(t, testS3LockKey); record != nil {
+			t.Errorf("expected no lock object, got %+v", record)
+		}
+	})
+
+	t.Run("a writer waits for a live reader", func(t *testing.T) {
+		// Given a reader holding the lock
+		lock, store := newTestS3Lock(t, now)
+		release, err := lock.Acquire(context.Background(), newReaderInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// When a writer acquires without waiting
+		_, err = lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then it is busy with the reader and has yielded the lock object again
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected LockBusyError, got %v", err)
+		}
+		if busy.Holder == nil || busy.Holder.ID != "reader-1" {
+			t.Errorf("expected holder reader-1, got %+v", busy.Holder)
+		}
+		if record := store.record(t, testS3LockKey); record != nil {
+			t.Errorf("expected the lock object to be released, got %+v", record)
+		}
+	})
+
+	t.Run("a writer takes over from an expired reader", func(t *testing.T) {
+		// Given a reader object whose holder stopped heartbeating longer ago than the TTL
+		lock, store := newTestS3Lock(t, now)
+		store.seed(t, lock.readerKey("dead"), newReaderInfo("dead"), now.Add(-2*s3LockTTL))
+
+		// When a writer acquires
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// Then the expired reader object is cleared
+		if record := store.record(t, lock.readerKey("dead")); record != nil {
+			t.Errorf("expected the expired reader object to be deleted, got %+v", record)
+		}
+	})
+
+	t.Run("a reader waits for a live writer", func(t *testing.T) {
+		// Given a writer holding the lock
+		lock, store := newTestS3Lock(t, now)
+		store.seed(t, testS3LockKey, newTestLockInfo(), now.Add(-5*time.Second))
+
+		// When a reader acquires without waiting
+		_, err := lock.Acquire(context.Background(), newReaderInfo("reader-1"), 0)
+
+		// Then it is busy with the writer and leaves no reader object behind
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected LockBusyError, got %v", err)
+		}
+		if busy.Holder == nil || busy.Holder.ID != "test-id-1" {
+			t.Errorf("expected holder test-id-1, got %+v", busy.Holder)
+		}
+		if record := store.record(t, lock.readerKey("reader-1")); record != nil {
+			t.Errorf("expected no reader object, got %+v", record)
+		}
+	})
+
+	t.Run("inspect and force-release cover the readers", func(t *testing.T) {
+		// Given a reader holding the lock
+		lock, store := newTestS3Lock(t, now)
+		release, err := lock.Acquire(context.Background(), newReaderInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+
+		// When inspecting and force-releasing the inspected holder
+		info, err := lock.Inspect(context.Background())
+		if err != nil || info == nil || info.ID != "reader-1" {
+			t.Fatalf("expected holder reader-1, got %+v (%v)", info, err)
+		}
+		if err := lock.ForceRelease(context.Background(), info.ID, "test"); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		// Then the reader object is gone
+		if record := store.record(t, lock.readerKey("reader-1")); record != nil {
+			t.Errorf("expected the reader object to be deleted, got %+v", record)
+		}
+	})
+}
+
+// =============================================================================
+// Test Inspect and ForceRelease
+// =============================================================================
+
+func TestS3Lock_Inspect(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("returns nil when no lock object exists", func(t *testing.T) {
+		// Given an empty bucket
+		lock, _ := newTestS3Lock(t, now)
+
+		// When inspecting
+		info, err := lock.Inspect(context.Background())
+
+		// Then there is no holder
+		if err != nil || info != nil {
+			t.Errorf("expected (nil, nil), got (%+v, %v)", info, err)
+		}
+	})
+
+	t.Run("returns the holder recorded in the object", func(t *testing.T) {
+		// Given a held lock object
+		lock, store := newTestS3Lock(t, now)
+		store.seed(t, testS3LockKey, newTestLockInfo(), now)
+
+		// When inspecting
+		info, err := lock.Inspect(context.Background())
+
+		// Then the holder info is returned
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if info == nil || info.ID != "test-id-1" {
+			t.Errorf("expected holder test-id-1, got %+v", info)
+		}
+	})
+
+	t.Run("errors when the lock object is corrupt", func(t *testing.T) {
+		// Given an object that is not a lock record
+		lock, store := newTestS3Lock(t, now)
+		_, _ = store.PutIfAbsent(context.Background(), testS3LockKey, []byte("{not json"))
+
+		// When inspecting
+		_, err := lock.Inspect(context.Background())
+
+		// Then the corruption is reported
+		if err == nil || !strings.Contains(err.Error(), "corrupt") {
+			t.Errorf("expected corrupt error, got %v", err)
+		}
+	})
+}
+
+func TestS3Lock_ForceRelease(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("deletes the object of the inspected holder", func(t *testing.T) {
+		// Given a held lock object
+		lock, store := newTestS3Lock(t, now)
+		store.seed(t, testS3LockKey, newTestLockInfo(), now)
+
+		// When force-releasing with the inspected lock ID
+		if err := lock.ForceRelease(context.Background(), "test-id-1", "operator"); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		// Then the object is gone
+		if record := store.record(t, testS3LockKey); record != nil {
+			t.Errorf("expected object deleted, got %+v", record)
+		}
+	})
+
+	t.Run("refuses when a different holder took the lock", func(t *testing.T) {
+		// Given an object held by another holder
+		lock, store := newTestS3Lock(t, now)
+		other := newTestLockInfo()
+		other.ID = "other"
+		store.seed(t, testS3LockKey, other, now)
+
+		// When force-releasing with a stale lock ID
+		err := lock.ForceRelease(context.Background(), "test-id-1", "operator")
+
+		// Then the release is refused and the object remains
+		if err == nil || !strings.Contains(err.Error(), "different holder") {
+			t.Fatalf("expected refusal, got %v", err)
+		}
+		if store.record(t, testS3LockKey) == nil {
+			t.Error("expected object to remain")
+		}
+	})
+
+	t.Run("is a no-op when no lock object exists", func(t *testing.T) {
+		// Given an empty bucket
+		lock, _ := newTestS3Lock(t, now)
+
+		// When force-releasing
+		err := lock.ForceRelease(context.Background(), "", "operator")
+
+		// Then nothing fails
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+	})
+}
+
+// =============================================================================
+// Test aws CLI Object Store
+// =============================================================================
+
+func TestAWSCLIObjectStore(t *testing.T) {
+	t.Run("puts conditionally with the configured region and profile", func(t *testing.T) {
+		// Given a store whose shell records the aws invocation
+		mockShell := shell.NewMockShell()
+		var gotArgs []string
+		var gotEnv map[string]string
+		var gotBody string
+		mockShell.ExecSilentWithEnvAndTimeoutFunc = func(command string, env map[string]string, args []string, timeout time.Duration) (string, error) {
+			gotArgs, gotEnv = args, env
+			if i := slices.Index(args, "--body"); i >= 0 {
+				data, _ := os.ReadFile(args[i+1])
+				gotBody = string(data)
+			}
+			return `{"ETag": "\"abc\""}`, nil
+		}
+		store := &awsCLIObjectStore{shell: mockShell, bucket: "tf-state", region: "eu-west-1", profile: "ops"}
+
+		// When creating an object only if absent
+		etag, err := store.PutIfAbsent(context.Background(), "k.json", []byte(`{"a":1}`))
+
+		// Then put-object runs with If-None-Match and returns the ETag
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if etag != `"abc"` {
+			t.Errorf("expected etag \"abc\", got %s", etag)
+		}
+		joined := strings.Join(gotArgs, " ")
+		for _, want := range []string{"s3api put-object", "--bucket tf-state", "--key k.json", "--if-none-match *", "--profile ops"} {
+			if !strings.Contains(joined, want) {
+				t.Errorf("expected %q in %q", want, joined)
+			}
+		}
+		if gotEnv["AWS_REGION"] != "eu-west-1" {
+			t.Errorf("expected AWS_REGION eu-west-1, got %v", gotEnv)
+		}
+		if gotBody != `{"a":1}` {
+			t.Errorf("expected body to be uploaded, got %q", gotBody)
+		}
+	})
+
+	t.Run("lists the keys under a prefix", func(t *testing.T) {
+		// Given a store whose shell returns two keys, then none
+		mockShell := shell.NewMockShell()
+		outputs := []string{`{"Contents": [{"Key": "p/a.json"}, {"Key": "p/b.json"}]}`, ""}
+		var gotArgs []string
+		mockShell.ExecSilentWithEnvAndTimeoutFunc = func(command string, env map[string]string, args []string, timeout time.Duration) (string, error) {
+			gotArgs = args
+			out := outputs[0]
+			outputs = outputs[1:]
+			return out, nil
+		}
+		store := &awsCLIObjectStore{shell: mockShell, bucket: "tf-state"}
+
+		// When listing twice
+		keys, err := store.List(context.Background(), "p/")
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		empty, err := store.List(context.Background(), "p/")
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		// Then list-objects-v2 runs with the prefix and an empty listing has no keys
+		if !slices.Equal(keys, []string{"p/a.json", "p/b.json"}) {
+			t.Errorf("expected both keys, got %v", keys)
+		}
+		if len(empty) != 0 {
+			t.Errorf("expected no keys, got %v", empty)
+		}
+		if joined := strings.Join(gotArgs, " "); !strings.Contains(joined, "s3api list-objects-v2 --bucket tf-state --prefix p/") {
+			t.Errorf("expected list-objects-v2 with the prefix, got %q", joined)
+		}
+	})
+
+	t.Run("reports a CLI without conditional writes", func(t *testing.T) {
+		// Given an aws CLI that does not know the conditional-write flags
+		mockShell := shell.NewMockShell()
+		mockShell.ExecSilentWithEnvAndTimeoutFunc = func(command string, env map[string]string, args []string, timeout time.Duration) (string, error) {
+			return "", fmt.Errorf("command execution failed: exit status 252\nUnknown options: --if-none-match, *")
+		}
+		store := &awsCLIObjectStore{shell: mockShell, bucket: "tf-state"}
+
+		// When creating an object conditionally
+		_, err := store.PutIfAbsent(context.Background(), "k.json", []byte("{}"))
+
+		// Then the error asks for a newer CLI and does not fall back or retry
+		if err == nil || !strings.Contains(err.Error(), "upgrade it to "+constants.MinimumVersionAWSStackLock) {
+			t.Errorf("expected an upgrade error, got %v", err)
+		}
+		if errors.Is(err, errBackendUnavailable) || errors.Is(err, errBackendUnreachable) {
+			t.Errorf("expected a plain error, got %v", err)
+		}
+	})
+
+	t.Run("maps S3 error codes onto the store sentinels", func(t *testing.T) {
+		// Given CLI failures carrying S3 error codes
+		cases := map[string]error{
+			"An error occurred (PreconditionFailed) when calling the PutObject operation":       errPreconditionFailed,
+			"An error occurred (NoSuchKey) when calling the GetObject operation":                errObjectNotFound,
+			"An error occurred (404) when calling the HeadObject operation":                     errObjectNotFound,
+			"An error occurred (NoSuchBucket) when calling the PutObject operation":             errBackendUnavailable,
+			`Could not connect to the endpoint URL: "https://tf-state.s3.amazonaws.com/k.json"`: errBackendUnreachable,
+			"An error occurred (ServiceUnavailable) when calling the PutObject operation":       errBackendUnreachable,
+		}
+		for stderr, want := range cases {
+			mockShell := shell.NewMockShell()
+			mockShell.ExecSilentWithEnvAndTimeoutFunc = func(command string, env map[string]string, args []string, timeout time.Duration) (string, error) {
+				return "", fmt.Errorf("command execution failed: exit status 254\n%s", stderr)
+			}
+			store := &awsCLIObjectStore{shell: mockShell, bucket: "tf-state"}
+
+			// When putting conditionally
+			_, err := store.PutIfMatch(context.Background(), "k.json", []byte("{}"), `"abc"`)
+
+			// Then the sentinel is matched
+			if !errors.Is(err, want) {
+				t.Errorf("expected %v for %q, got %v", want, stderr, err)
+			}
+		}
+	})
+}
+
+// =============================================================================
+// Test ForRuntime
+// =============================================================================
+
+func TestForRuntime_S3Backend(t *testing.T) {
+	t.Run("selects the object lock beside the state", func(t *testing.T) {
+		// Given a runtime whose terraform backend is s3
+		handler := config.NewMockConfigHandler()
+		handler.GetStringFunc = func(key string, defaultValue ...string) string {
+			switch key {
+			case "terraform.backend.type":
+				return "s3"
+			case "terraform.backend.s3.bucket":
+				return "tf-state"
+			case "terraform.backend.prefix":
+				return "state"
+			}
+			return ""
+		}
+		rt := newTestRuntime(t)
+		rt.ConfigHandler = handler
+		rt.Shell = shell.NewMockShell()
+
+		// When deriving the lock
+		lock, err := ForRuntime(rt)
+
+		// Then it is the context's object under the backend prefix
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		fallback, ok := lock.(*fallbackLock)
+		if !ok {
+			t.Fatalf("expected a lock falling back to the local flock, got %T", lock)
+		}
+		s3, ok := fallback.remote.(*s3Lock)
+		if !ok {
+			t.Fatalf("expected s3 lock, got %T", fallback.remote)
+		}
+		if s3.describe() != "s3://tf-state/"+testS3LockKey {
+			t.Errorf("expected s3://tf-state/%s, got %s", testS3LockKey, s3.describe())
+		}
+	})
+
+	t.Run("falls back to the local lock without a bucket", func(t *testing.T) {
+		// Given an s3 backend with no bucket configured
+		handler := config.NewMockConfigHandler()
+		handler.GetStringFunc = func(key string, defaultValue ...string) string {
+			if key == "terraform.backend.type" {
+				return "s3"
+			}
+			return ""
+		}
+		rt := newTestRuntime(t)
+		rt.ConfigHandler = handler
+
+		// When deriving the lock
+		lock, err := ForRuntime(rt)
+
+		// Then the local flock is used
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if _, ok := lock.(*localFlockLock); !ok {
+			t.Errorf("expected local flock lock, got %T", lock)
+		}
+	})
+}
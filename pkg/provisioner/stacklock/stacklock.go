@@ -1,12 +1,16 @@
-// The StackLock is a single-writer advisory lock scoped to one (projectRoot, contextName).
-// It provides a process-coordination point for windsor operations that mutate infrastructure,
-// preventing two concurrent invocations from interleaving before terraform's per-state lock
-// can engage. The current implementation is a local flock-backed adapter; s3, kubernetes,
-// and azurerm backends slot in behind the same interface.
+// The StackLock is a single-writer, multi-reader advisory lock scoped to one (projectRoot,
+// contextName). It provides a process-coordination point for windsor operations that mutate
+// infrastructure, preventing two concurrent invocations from interleaving before terraform's
+// per-state lock can engage, while letting read-only operations such as plan run side by
+// side. The default implementation is a local flock-backed adapter; contexts whose
+// terraform backend is kubernetes use a Lease in the cluster instead, and contexts with an
+// s3 backend use a conditionally-written object beside the state, so operators on different
+// machines contend for the same lock. An azurerm backend slots in behind the same interface.
 
 package stacklock
 
 import (
+	"bufio"
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
@@ -17,10 +21,12 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gofrs/flock"
+	"golang.org/x/term"
 
 	"github.com/windsorcli/cli/pkg/constants"
 	"github.com/windsorcli/cli/pkg/runtime"
@@ -56,13 +62,37 @@ const stackLockInfoSuffix = ".info"
 // lockInfoPerm is the mode used when writing the holder-info sidecar.
 const lockInfoPerm = 0o644
 
+// heartbeatInterval is how often a local holder rewrites its sidecar with a fresh heartbeat.
+const heartbeatInterval = 30 * time.Second
+
+// staleHeartbeatAfter is how old a holder's heartbeat may grow before a contender reports
+// the holder as stale. Ten missed heartbeats rules out a slow disk or a busy host.
+const staleHeartbeatAfter = 5 * time.Minute
+
+// unreachableRetryWindow is the least time a remote lock keeps retrying a backend that does not
+// answer before it fails, even for a caller that asked not to wait, so a brief API server or
+// network outage does not fail the command outright.
+const unreachableRetryWindow = 30 * time.Second
+
 // =============================================================================
 // Types
 // =============================================================================
 
-// Mode distinguishes writer (Exclusive) from reader (Shared) acquisition.
-// Only Exclusive is supported today; Shared is reserved for read-only plan operations
-// (§7.3 of the terraform-lifecycle-hardening spike).
+// errBackendUnavailable marks a remote lock whose backend provably does not exist yet, such as
+// a bucket bootstrap has not created, as opposed to one that is there but did not answer. A
+// lock wrapped by newFallbackLock falls back to the local flock on it.
+var errBackendUnavailable = errors.New("lock backend unavailable")
+
+// errBackendUnreachable marks a remote lock's request that got no answer from a backend that
+// may well exist — a refused or timed-out connection, a server reporting itself unavailable.
+// Acquire retries it and then fails; it never falls back to the local flock, since another
+// operator may hold the remote lock while this one cannot see it.
+var errBackendUnreachable = errors.New("lock backend unreachable")
+
+// Mode distinguishes writer (Exclusive) from reader (Shared) acquisition. Shared holders
+// coexist with each other but not with an Exclusive holder, so read-only operations such as
+// plan and drift do not block one another. The local flock takes a shared flock; the Lease
+// and S3 locks give each Shared holder a reader Lease or object of its own.
 type Mode int
 
 const (
@@ -83,6 +113,8 @@ type LockInfo struct {
 	Context   string    `json:"context"`
 	Created   time.Time `json:"created"`
 	PID       int       `json:"pid"`
+	Host      string    `json:"host,omitempty"`
+	Heartbeat time.Time `json:"heartbeat,omitempty"`
 }
 
 // Release frees a previously-acquired lock. Implementations must be idempotent:
@@ -91,19 +123,27 @@ type Release func() error
 
 // LockBusyError is returned by Acquire when the timeout elapses with the lock still held.
 // Holder is best-effort and may be nil when the lock-file body is absent or unparseable.
+// Stale is non-empty when the holder looks dead — its PID is gone from this host or its
+// heartbeat has stopped — and says why.
 type LockBusyError struct {
 	Path   string
 	Holder *LockInfo
+	Stale  string
 }
 
 // Error renders a human-readable description of the lock contention; the Holder fields
 // are included when known so operators can identify the blocker without opening the file.
+// A stale holder is called out with the recovery command.
 func (e *LockBusyError) Error() string {
 	if e.Holder == nil {
 		return fmt.Sprintf("stack lock at %s is held by another windsor process", e.Path)
 	}
-	return fmt.Sprintf("stack lock at %s is held by %s (PID=%d, operation=%s, started=%s)",
+	msg := fmt.Sprintf("stack lock at %s is held by %s (PID=%d, operation=%s, started=%s)",
 		e.Path, e.Holder.Who, e.Holder.PID, e.Holder.Operation, e.Holder.Created.Format(time.RFC3339))
+	if e.Stale != "" {
+		msg += fmt.Sprintf("; the holder appears stale (%s), run 'windsor unlock' to clear it", e.Stale)
+	}
+	return msg
 }
 
 // =============================================================================
@@ -142,11 +182,21 @@ func NewLocalFlockLock(lockPath string) StackLock {
 // contention before failing; 0 fails immediately (matching terraform's own
 // -lock-timeout=0 default) rather than blocking silently.
 func With(ctx context.Context, rt *runtime.Runtime, operation string, timeout time.Duration, fn func() error) error {
+	return WithMode(ctx, rt, operation, Exclusive, timeout, fn)
+}
+
+// WithMode is With for a caller-chosen Mode; read-only operations pass Shared so they
+// run alongside each other. When the lock is busy with a stale holder and the operator is
+// at a terminal, they are asked whether to take the lock over; accepting force-releases the
+// stale holder's lock, guarded by its lock ID, and makes one more attempt.
+func WithMode(ctx context.Context, rt *runtime.Runtime, operation string, mode Mode, timeout time.Duration, fn func() error) error {
 	lock, err := ForRuntime(rt)
 	if err != nil {
 		return err
 	}
-	release, err := lock.Acquire(ctx, NewInfo(rt, operation), timeout)
+	info := NewInfo(rt, operation)
+	info.Mode = mode
+	release, err := acquireOrTakeOver(ctx, lock, info, timeout)
 	if err != nil {
 		return err
 	}
@@ -157,8 +207,16 @@ func With(ctx context.Context, rt *runtime.Runtime, operation string, timeout ti
 // ForRuntime returns the StackLock for the runtime's context — the same lock that
 // With acquires. It is exposed so operator-facing recovery (windsor unlock) can
 // inspect and force-release a stuck lock without duplicating the path derivation.
-// Returns an error when the runtime is nil or has not been configured yet (empty
-// scratch path).
+// When the context's terraform backend is kubernetes and its kubeconfig exists the
+// lock is a Lease in the gitops namespace, reached through that kubeconfig; before
+// bootstrap, and after cleanup removes it, there is no kubeconfig, so the local flock guards
+// the run. When the backend is s3 with a bucket configured the lock is an object under the
+// backend prefix in that bucket; otherwise it is the local flock under the scratch path. A
+// remote lock falls back to the local flock only when its backend provably does not exist
+// yet — a bucket bootstrap has not created — so bootstrap still runs; a backend that exists
+// but does not answer fails the command instead. Returns an error when the runtime is nil or
+// has not been configured yet (empty scratch path), or when the kubernetes client cannot be
+// built.
 func ForRuntime(rt *runtime.Runtime) (StackLock, error) {
 	if rt == nil {
 		return nil, errors.New("stacklock: runtime is required")
@@ -166,7 +224,30 @@ func ForRuntime(rt *runtime.Runtime) (StackLock, error) {
 	if rt.WindsorScratchPath == "" {
 		return nil, errors.New("stacklock: scratch path is empty (Configure must run first)")
 	}
-	return NewLocalFlockLock(filepath.Join(rt.WindsorScratchPath, stackLockFilename)), nil
+	local := NewLocalFlockLock(filepath.Join(rt.WindsorScratchPath, stackLockFilename))
+	if rt.ConfigHandler != nil && rt.ConfigHandler.GetString("terraform.backend.type", "local") == "kubernetes" {
+		if rt.ConfigRoot == "" {
+			return nil, errors.New("stacklock: config root is empty; cannot locate the kubeconfig for the lease lock")
+		}
+		kubeconfig := filepath.Join(rt.ConfigRoot, ".kube", "config")
+		if _, err := os.Stat(kubeconfig); err == nil {
+			client, err := newKubernetesClient(kubeconfig)
+			if err != nil {
+				return nil, fmt.Errorf("stacklock: building kubernetes client: %w", err)
+			}
+			namespace := rt.ConfigHandler.GetString("gitops.namespace", constants.DefaultGitopsNamespace)
+			return NewKubernetesLeaseLock(client, namespace, leaseNameForContext(rt.ContextName)), nil
+		}
+	}
+	if rt.ConfigHandler != nil && rt.ConfigHandler.GetString("terraform.backend.type", "local") == "s3" {
+		if bucket := rt.ConfigHandler.GetString("terraform.backend.s3.bucket"); bucket != "" {
+			key := s3LockKey(rt.ConfigHandler.GetString("terraform.backend.prefix"), rt.ContextName)
+			region := rt.ConfigHandler.GetString("terraform.backend.s3.region")
+			profile := rt.ConfigHandler.GetString("terraform.backend.s3.profile")
+			return newFallbackLock(NewS3Lock(rt.Shell, bucket, key, region, profile), local), nil
+		}
+	}
+	return local, nil
 }
 
 // NewInfo constructs the LockInfo persisted into the holder-info sidecar for a
@@ -178,12 +259,13 @@ func NewInfo(rt *runtime.Runtime, operation string) LockInfo {
 		ID:        newLockID(),
 		Operation: operation,
 		Mode:      Exclusive,
-		Who:       holderIdentity(),
+		Who:       HolderIdentity(),
 		Version:   constants.Version,
 		ProjectID: hashProjectRoot(rt.ProjectRoot),
 		Context:   rt.ContextName,
 		Created:   time.Now().UTC(),
 		PID:       os.Getpid(),
+		Host:      hostname(),
 	}
 }
 
@@ -210,35 +292,42 @@ type localFlockLock struct {
 // timeout returns *LockBusyError, populating Holder from the sidecar info file
 // when one is present. Entering the retry loop prints a one-line notice to stderr
 // naming the holder when known, so an operator who opted into waiting sees why the
-// command appears to hang instead of staring at a silent terminal. After flock
-// succeeds, info is persisted to a sidecar (<path>.info) via atomic temp+rename so
-// a future contender's busy error can name the holder and PID. The sidecar is
-// removed by Release; it is diagnostic only and a missing or partial file is not
+// command appears to hang instead of staring at a silent terminal. A Shared info
+// takes a shared flock, so readers hold the lock together and only a writer waits.
+// After flock succeeds, info is persisted to a sidecar via atomic temp+rename so a
+// future contender's busy error can name the holder and PID — <path>.info for the
+// writer, <path>.<id>.info for each reader — and any sidecars left by holders that
+// died without releasing are cleared, since the mode just granted rules them out.
+// The sidecar's heartbeat is refreshed every heartbeatInterval until Release, which
+// removes it; it is diagnostic only and a missing or partial file is not
 // load-bearing for correctness.
 func (s *localFlockLock) Acquire(ctx context.Context, info LockInfo, timeout time.Duration) (Release, error) {
 	if err := os.MkdirAll(filepath.Dir(s.path), lockDirPerm); err != nil {
 		return nil, fmt.Errorf("create lock directory: %w", err)
 	}
 	flk := flock.New(s.path)
-	infoPath := s.path + stackLockInfoSuffix
+	tryLock := flk.TryLock
+	if info.Mode == Shared {
+		tryLock = flk.TryRLock
+	}
 
-	locked, err := flk.TryLock()
+	locked, err := tryLock()
 	if err != nil {
 		return nil, fmt.Errorf("flock acquire: %w", err)
 	}
 	if !locked {
 		if timeout <= 0 {
-			return nil, &LockBusyError{Path: s.path, Holder: readHolderInfo(infoPath)}
+			return nil, newBusyError(s.path, s.readHolder())
 		}
 		deadline := time.Now().Add(timeout)
-		announceWaiting(s.path, readHolderInfo(infoPath))
+		announceWaiting(s.path, s.readHolder())
 		for {
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
 			case <-time.After(acquireRetryInterval):
 			}
-			locked, err = flk.TryLock()
+			locked, err = tryLock()
 			if err != nil {
 				return nil, fmt.Errorf("flock acquire: %w", err)
 			}
@@ -246,7 +335,7 @@ func (s *localFlockLock) Acquire(ctx context.Context, info LockInfo, timeout tim
 				break
 			}
 			if time.Now().After(deadline) {
-				return nil, &LockBusyError{Path: s.path, Holder: readHolderInfo(infoPath)}
+				return nil, newBusyError(s.path, s.readHolder())
 			}
 		}
 	}
@@ -254,20 +343,36 @@ func (s *localFlockLock) Acquire(ctx context.Context, info LockInfo, timeout tim
 	if info.PID == 0 {
 		info.PID = os.Getpid()
 	}
+	infoPath := s.path + stackLockInfoSuffix
+	if info.Mode == Shared {
+		infoPath = readerInfoPath(s.path, info.ID)
+		_ = os.Remove(s.path + stackLockInfoSuffix)
+	} else {
+		for _, reader := range s.readerInfoPaths() {
+			_ = os.Remove(reader)
+		}
+	}
+	info.Heartbeat = time.Now().UTC()
 	writeHolderInfo(infoPath, info)
-	return makeRelease(flk, infoPath), nil
+	return makeRelease(flk, infoPath, startHeartbeat(infoPath, info)), nil
 }
 
-// Inspect returns the current holder recorded in the sidecar. A missing sidecar
-// returns (nil, nil) — the normal clean state, nothing to release. A sidecar that
-// is present but unreadable or corrupt (e.g. a partial write from a killed holder)
-// returns an error: that is debris windsor unlock should still clear, so the caller
-// must not mistake it for "no lock held". It backs unlock's "who holds this?" report.
+// Inspect returns the current holder recorded in the sidecar — the writer's, or the
+// first reader's when the lock is shared. A missing sidecar returns (nil, nil) — the
+// normal clean state, nothing to release. A writer sidecar that is present but
+// unreadable or corrupt (e.g. a partial write from a killed holder) returns an error:
+// that is debris windsor unlock should still clear, so the caller must not mistake it
+// for "no lock held". It backs unlock's "who holds this?" report.
 func (s *localFlockLock) Inspect(ctx context.Context) (*LockInfo, error) {
 	infoPath := s.path + stackLockInfoSuffix
 	// #nosec G304 - infoPath is the lock's sidecar location configured by the runtime, not user-supplied
 	data, err := os.ReadFile(infoPath)
 	if errors.Is(err, os.ErrNotExist) {
+		for _, reader := range s.readerInfoPaths() {
+			if info := readHolderInfo(reader); info != nil {
+				return info, nil
+			}
+		}
 		return nil, nil
 	}
 	if err != nil {
@@ -280,26 +385,28 @@ func (s *localFlockLock) Inspect(ctx context.Context) (*LockInfo, error) {
 	return &info, nil
 }
 
-// ForceRelease clears a stuck lock by removing the lock file and its holder-info
+// ForceRelease clears a stuck lock by removing the lock file and every holder-info
 // sidecar, so the next Acquire starts from a clean slate. It is the operator-facing
 // recovery path (windsor unlock) for a holder that died without releasing. When
-// lockID is non-empty it guards against a race: if a different holder has acquired
-// the lock since the caller inspected it, the release is refused rather than yanking
-// a lock that is now legitimately held. reason is included in any failure message
-// for diagnostics. Missing files are not an error — the lock is already clear.
+// lockID is non-empty it guards against a race: if the lock is now held and none of
+// its recorded holders has that ID, a different holder has acquired the lock since the
+// caller inspected it, and the release is refused rather than yanking a lock that is
+// now legitimately held. reason is included in any failure message for diagnostics.
+// Missing files are not an error — the lock is already clear.
 func (s *localFlockLock) ForceRelease(ctx context.Context, lockID string, reason string) error {
 	infoPath := s.path + stackLockInfoSuffix
+	readers := s.readerInfoPaths()
 	if lockID != "" {
-		if info := readHolderInfo(infoPath); info != nil && info.ID != lockID {
-			return fmt.Errorf("stacklock: refusing to force-release: lock is now held by a different holder (%q, not %q) — a new windsor process acquired it", info.ID, lockID)
+		holders := s.readHolders()
+		if len(holders) > 0 && !holdsID(holders, lockID) {
+			return fmt.Errorf("stacklock: refusing to force-release: lock is now held by a different holder (%q, not %q) — a new windsor process acquired it", holders[0].ID, lockID)
 		}
 	}
 	var errs []error
-	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
-		errs = append(errs, err)
-	}
-	if err := os.Remove(infoPath); err != nil && !os.IsNotExist(err) {
-		errs = append(errs, err)
+	for _, p := range append([]string{s.path, infoPath}, readers...) {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
 	}
 	if len(errs) > 0 {
 		return fmt.Errorf("stacklock: force-release (%s): %w", reason, errors.Join(errs...))
@@ -307,18 +414,51 @@ func (s *localFlockLock) ForceRelease(ctx context.Context, lockID string, reason
 	return nil
 }
 
+// readHolder returns the holder to name in a busy error: the writer when one is
+// recorded, otherwise the first reader, or nil when no sidecar is readable.
+func (s *localFlockLock) readHolder() *LockInfo {
+	if holders := s.readHolders(); len(holders) > 0 {
+		return &holders[0]
+	}
+	return nil
+}
+
+// readHolders returns every readable holder sidecar for the lock, the writer's first.
+func (s *localFlockLock) readHolders() []LockInfo {
+	var holders []LockInfo
+	if info := readHolderInfo(s.path + stackLockInfoSuffix); info != nil {
+		holders = append(holders, *info)
+	}
+	for _, reader := range s.readerInfoPaths() {
+		if info := readHolderInfo(reader); info != nil {
+			holders = append(holders, *info)
+		}
+	}
+	return holders
+}
+
+// readerInfoPaths returns the paths of every reader sidecar currently on disk.
+func (s *localFlockLock) readerInfoPaths() []string {
+	matches, _ := filepath.Glob(readerInfoPath(s.path, "*"))
+	return matches
+}
+
 // makeRelease returns the closure handed back from Acquire. flk, infoPath,
 // and the once guard are scoped to this Release, not to the localFlockLock
 // receiver, so reusing the same lock instance for a second Acquire produces
 // an independent Release that does not interfere with this one. The first
-// call removes the holder-info sidecar (best-effort) and returns the
-// underlying Unlock result; subsequent calls return nil so callers may safely
-// defer release alongside an explicit earlier release without double-unlocking.
-func makeRelease(flk *flock.Flock, infoPath string) Release {
+// call stops the heartbeat, removes the holder-info sidecar (best-effort) and
+// returns the underlying Unlock result; subsequent calls return nil so callers
+// may safely defer release alongside an explicit earlier release without
+// double-unlocking.
+func makeRelease(flk *flock.Flock, infoPath string, stopHeartbeat func()) Release {
 	var once sync.Once
 	return func() error {
 		var err error
 		once.Do(func() {
+			if stopHeartbeat != nil {
+				stopHeartbeat()
+			}
 			if infoPath != "" {
 				_ = os.Remove(infoPath)
 			}
@@ -328,10 +468,187 @@ func makeRelease(flk *flock.Flock, infoPath string) Release {
 	}
 }
 
+// fallbackLock is a remote StackLock that defers to a local one whenever the remote backend
+// is unavailable. The fallback is decided per call, so a backend that comes up between
+// commands is used from the next command on.
+type fallbackLock struct {
+	remote StackLock
+	local  StackLock
+}
+
+// newFallbackLock returns a StackLock that uses remote, or local while remote reports
+// errBackendUnavailable.
+func newFallbackLock(remote, local StackLock) StackLock {
+	return &fallbackLock{remote: remote, local: local}
+}
+
+// Acquire acquires the remote lock, or the local flock with a notice on stderr when the
+// remote backend cannot be reached.
+func (f *fallbackLock) Acquire(ctx context.Context, info LockInfo, timeout time.Duration) (Release, error) {
+	release, err := f.remote.Acquire(ctx, info, timeout)
+	if !errors.Is(err, errBackendUnavailable) {
+		return release, err
+	}
+	fmt.Fprintf(os.Stderr, "warning: %v; using the local stack lock\n", err)
+	return f.local.Acquire(ctx, info, timeout)
+}
+
+// Inspect inspects the remote lock, or the local flock when the remote backend cannot be
+// reached.
+func (f *fallbackLock) Inspect(ctx context.Context) (*LockInfo, error) {
+	info, err := f.remote.Inspect(ctx)
+	if !errors.Is(err, errBackendUnavailable) {
+		return info, err
+	}
+	return f.local.Inspect(ctx)
+}
+
+// ForceRelease force-releases the remote lock, or the local flock when the remote backend
+// cannot be reached.
+func (f *fallbackLock) ForceRelease(ctx context.Context, lockID string, reason string) error {
+	err := f.remote.ForceRelease(ctx, lockID, reason)
+	if !errors.Is(err, errBackendUnavailable) {
+		return err
+	}
+	return f.local.ForceRelease(ctx, lockID, reason)
+}
+
 // =============================================================================
 // Helpers
 // =============================================================================
 
+// confirmTakeover asks whether to take over a lock whose holder appears stale. A variable
+// so tests can answer without a terminal.
+var confirmTakeover = promptTakeover
+
+// retryAcquire drives a remote lock's acquisition attempts with the contract shared by every
+// StackLock: attempt reports whether the lock is now held, with the holder best-effort on
+// contention. A busy lock fails immediately when timeout <= 0 and is otherwise retried every
+// interval, with one waiting notice, until it is held, the timeout elapses, or ctx is
+// cancelled. An attempt failing with errBackendUnreachable is retried the same way for at least
+// unreachable, then returned; any other error is returned at once.
+func retryAcquire(ctx context.Context, describe string, timeout, interval, unreachable time.Duration, attempt func() (bool, *LockInfo, error)) error {
+	start := time.Now()
+	announced := false
+	for {
+		held, holder, err := attempt()
+		switch {
+		case err != nil:
+			if !errors.Is(err, errBackendUnreachable) || time.Since(start) >= max(timeout, unreachable) {
+				return err
+			}
+		case held:
+			return nil
+		case timeout <= 0 || time.Since(start) >= timeout:
+			return newBusyError(describe, holder)
+		case !announced:
+			announceWaiting(describe, holder)
+			announced = true
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// acquireOrTakeOver acquires lock for info and, when it is busy with a stale holder the
+// operator agrees to take over, force-releases that holder's lock and makes one immediate
+// attempt. The force-release is guarded by the stale holder's lock ID, so a lock that
+// changed hands in the meantime is left alone.
+func acquireOrTakeOver(ctx context.Context, lock StackLock, info LockInfo, timeout time.Duration) (Release, error) {
+	release, err := lock.Acquire(ctx, info, timeout)
+	var busy *LockBusyError
+	if !errors.As(err, &busy) || busy.Stale == "" || busy.Holder == nil || !confirmTakeover(busy) {
+		return release, err
+	}
+	if err := lock.ForceRelease(ctx, busy.Holder.ID, "stale holder takeover"); err != nil {
+		return nil, err
+	}
+	return lock.Acquire(ctx, info, 0)
+}
+
+// promptTakeover names the stale holder on stderr and reads a y/N answer from stdin.
+// Anything other than "y" or "yes" (case-insensitive) declines. When stdin or stderr is
+// not a terminal it declines without prompting, so scripted runs fail with the busy error
+// rather than hang.
+func promptTakeover(busy *LockBusyError) bool {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) { // #nosec G115 -- file descriptors are small, safe to cast to int
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "The stack lock at %s is held by %s (PID=%d, operation=%s), which appears stale: %s.\n",
+		busy.Path, busy.Holder.Who, busy.Holder.PID, busy.Holder.Operation, busy.Stale)
+	fmt.Fprint(os.Stderr, "Take over the lock? [y/N]: ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// newBusyError returns the LockBusyError for a contended lock at path, marking the holder
+// stale when staleReason finds it dead.
+func newBusyError(path string, holder *LockInfo) *LockBusyError {
+	return &LockBusyError{Path: path, Holder: holder, Stale: staleReason(holder, time.Now())}
+}
+
+// staleReason returns why holder looks dead, or "" when it looks alive or is unknown. A
+// holder recorded on this host whose PID no longer runs is stale; so is any holder whose
+// recorded heartbeat is older than staleHeartbeatAfter. Holders written before hosts and
+// heartbeats were recorded are never reported stale.
+func staleReason(holder *LockInfo, now time.Time) string {
+	if holder == nil {
+		return ""
+	}
+	if holder.PID > 0 && holder.Host != "" && holder.Host != "unknown" && holder.Host == hostname() && !processAlive(holder.PID) {
+		return fmt.Sprintf("PID %d is no longer running on %s", holder.PID, holder.Host)
+	}
+	if !holder.Heartbeat.IsZero() && now.Sub(holder.Heartbeat) > staleHeartbeatAfter {
+		return fmt.Sprintf("no heartbeat since %s", holder.Heartbeat.Format(time.RFC3339))
+	}
+	return ""
+}
+
+// startHeartbeat rewrites the sidecar at infoPath with a fresh heartbeat every
+// heartbeatInterval and returns the func that stops it, waiting for any rewrite in
+// flight so Release never races a rewrite that would resurrect the sidecar.
+func startHeartbeat(infoPath string, info LockInfo) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				info.Heartbeat = time.Now().UTC()
+				writeHolderInfo(infoPath, info)
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// readerInfoPath returns the sidecar path for the shared holder with lock ID id.
+func readerInfoPath(lockPath, id string) string {
+	return lockPath + "." + id + stackLockInfoSuffix
+}
+
+// holdsID reports whether any of holders has lock ID id.
+func holdsID(holders []LockInfo, id string) bool {
+	for _, h := range holders {
+		if h.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // announceWaiting prints a one-line notice to stderr the first time Acquire is
 // about to retry against a contended lock, naming the holder when known. Only
 // called when timeout > 0 (the caller opted into waiting), so a fail-fast
@@ -389,18 +706,25 @@ func newLockID() string {
 	return hex.EncodeToString(b[:])
 }
 
-// holderIdentity returns "<user>@<host>" with safe fallbacks so the lock
-// always carries some identifier even on hosts where the lookups fail.
-func holderIdentity() string {
+// HolderIdentity returns "<user>@<host>" with safe fallbacks so the lock
+// always carries some identifier even on hosts where the lookups fail. It is
+// exported so other records of who acted on a context, such as kustomization
+// suspensions, name the operator the same way the lock does.
+func HolderIdentity() string {
 	u := "unknown"
 	if usr, err := user.Current(); err == nil && usr.Username != "" {
 		u = usr.Username
 	}
+	return fmt.Sprintf("%s@%s", u, hostname())
+}
+
+// hostname returns the local host name, or "unknown" when it cannot be determined.
+func hostname() string {
 	h, err := os.Hostname()
 	if err != nil || h == "" {
-		h = "unknown"
+		return "unknown"
 	}
-	return fmt.Sprintf("%s@%s", u, h)
+	return h
 }
 
 // hashProjectRoot returns a stable short hash of the project root path so
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -469,6 +470,22 @@ func TestLockBusyError_Error(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("calls out a stale holder and the recovery command", func(t *testing.T) {
+		// Given a busy error whose holder is stale
+		info := newTestLockInfo()
+		e := &LockBusyError{Path: "/tmp/x.stacklock", Holder: &info, Stale: "PID 12345 is no longer running on host"}
+
+		// When formatting
+		msg := e.Error()
+
+		// Then the reason and windsor unlock are named
+		for _, want := range []string{"appears stale", "PID 12345 is no longer running", "windsor unlock"} {
+			if !strings.Contains(msg, want) {
+				t.Fatalf("expected %q in message, got %q", want, msg)
+			}
+		}
+	})
 }
 
 func TestWith(t *testing.T) {
@@ -837,3 +854,357 @@ func TestLocalFlockLock_ForceRelease(t *testing.T) {
 		}
 	})
 }
+
+// =============================================================================
+// Test Shared Mode
+// =============================================================================
+
+func TestLocalFlockLock_Shared(t *testing.T) {
+	readerInfo := func(id string) LockInfo {
+		info := newTestLockInfo()
+		info.ID = id
+		info.Operation = "plan"
+		info.Mode = Shared
+		return info
+	}
+
+	t.Run("lets shared holders coexist", func(t *testing.T) {
+		// Given a reader holding the lock
+		path := filepath.Join(t.TempDir(), ".stacklock")
+		release1, err := NewLocalFlockLock(path).Acquire(context.Background(), readerInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("first reader: %v", err)
+		}
+		t.Cleanup(func() { _ = release1() })
+
+		// When a second reader acquires without waiting
+		release2, err := NewLocalFlockLock(path).Acquire(context.Background(), readerInfo("reader-2"), 0)
+
+		// Then it succeeds and each reader has its own sidecar
+		if err != nil {
+			t.Fatalf("second reader: %v", err)
+		}
+		t.Cleanup(func() { _ = release2() })
+		for _, id := range []string{"reader-1", "reader-2"} {
+			if _, err := os.Stat(readerInfoPath(path, id)); err != nil {
+				t.Errorf("expected sidecar for %s, got %v", id, err)
+			}
+		}
+	})
+
+	t.Run("blocks a writer while a reader holds the lock", func(t *testing.T) {
+		// Given a reader holding the lock
+		path := filepath.Join(t.TempDir(), ".stacklock")
+		release, err := NewLocalFlockLock(path).Acquire(context.Background(), readerInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("reader: %v", err)
+		}
+		t.Cleanup(func() { _ = release() })
+
+		// When a writer acquires without waiting
+		_, err = NewLocalFlockLock(path).Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then it is busy and names the reader
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected *LockBusyError, got %T: %v", err, err)
+		}
+		if busy.Holder == nil || busy.Holder.ID != "reader-1" || busy.Holder.Operation != "plan" {
+			t.Fatalf("expected holder reader-1/plan, got %+v", busy.Holder)
+		}
+	})
+
+	t.Run("blocks a reader while a writer holds the lock", func(t *testing.T) {
+		// Given a writer holding the lock
+		path := filepath.Join(t.TempDir(), ".stacklock")
+		release, err := NewLocalFlockLock(path).Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("writer: %v", err)
+		}
+		t.Cleanup(func() { _ = release() })
+
+		// When a reader acquires without waiting
+		_, err = NewLocalFlockLock(path).Acquire(context.Background(), readerInfo("reader-1"), 0)
+
+		// Then it is busy
+		var busy *LockBusyError
+		if !errors.As(err, &busy) {
+			t.Fatalf("expected *LockBusyError, got %T: %v", err, err)
+		}
+	})
+
+	t.Run("removes only its own sidecar on release", func(t *testing.T) {
+		// Given two readers holding the lock
+		path := filepath.Join(t.TempDir(), ".stacklock")
+		release1, err := NewLocalFlockLock(path).Acquire(context.Background(), readerInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("first reader: %v", err)
+		}
+		release2, err := NewLocalFlockLock(path).Acquire(context.Background(), readerInfo("reader-2"), 0)
+		if err != nil {
+			t.Fatalf("second reader: %v", err)
+		}
+		t.Cleanup(func() { _ = release2() })
+
+		// When the first reader releases
+		if err := release1(); err != nil {
+			t.Fatalf("release: %v", err)
+		}
+
+		// Then Inspect still reports the second reader
+		holder, err := NewLocalFlockLock(path).Inspect(context.Background())
+		if err != nil || holder == nil || holder.ID != "reader-2" {
+			t.Fatalf("expected reader-2 to remain, got %+v, %v", holder, err)
+		}
+	})
+
+	t.Run("clears a dead writer's sidecar when a reader acquires", func(t *testing.T) {
+		// Given a sidecar left by a writer that died without releasing
+		path := filepath.Join(t.TempDir(), ".stacklock")
+		writeHolderInfo(path+stackLockInfoSuffix, newTestLockInfo())
+
+		// When a reader acquires
+		release, err := NewLocalFlockLock(path).Acquire(context.Background(), readerInfo("reader-1"), 0)
+		if err != nil {
+			t.Fatalf("reader: %v", err)
+		}
+		t.Cleanup(func() { _ = release() })
+
+		// Then the writer's sidecar is gone
+		if _, err := os.Stat(path + stackLockInfoSuffix); !os.IsNotExist(err) {
+			t.Fatalf("expected writer sidecar removed, stat err=%v", err)
+		}
+	})
+}
+
+// =============================================================================
+// Test Stale Holders
+// =============================================================================
+
+func TestStaleReason(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("reports a holder whose heartbeat has stopped", func(t *testing.T) {
+		// Given a holder whose last heartbeat is older than the threshold
+		info := newTestLockInfo()
+		info.Heartbeat = now.Add(-2 * staleHeartbeatAfter)
+
+		// When checking staleness
+		reason := staleReason(&info, now)
+
+		// Then it is stale because of the heartbeat
+		if !strings.Contains(reason, "no heartbeat since") {
+			t.Fatalf("expected heartbeat staleness, got %q", reason)
+		}
+	})
+
+	t.Run("does not report a live holder on this host", func(t *testing.T) {
+		// Given a holder that is this process with a recent heartbeat
+		info := newTestLockInfo()
+		info.Host = hostname()
+		info.PID = os.Getpid()
+		info.Heartbeat = now.Add(-time.Second)
+
+		// When checking staleness
+		reason := staleReason(&info, now)
+
+		// Then it is not stale
+		if reason != "" {
+			t.Fatalf("expected live holder, got %q", reason)
+		}
+	})
+
+	t.Run("ignores PIDs recorded on another host", func(t *testing.T) {
+		// Given a holder on a different host whose PID does not exist here
+		info := newTestLockInfo()
+		info.Host = "elsewhere-" + hostname()
+		info.PID = 1 << 30
+
+		// When checking staleness
+		reason := staleReason(&info, now)
+
+		// Then the PID is not checked
+		if reason != "" {
+			t.Fatalf("expected no staleness for a remote PID, got %q", reason)
+		}
+	})
+
+	t.Run("reports a holder whose PID is gone from this host", func(t *testing.T) {
+		// Given a holder on this host whose PID does not exist
+		info := newTestLockInfo()
+		info.Host = hostname()
+		info.PID = 1 << 30
+
+		// When checking staleness
+		reason := staleReason(&info, now)
+
+		// Then it is stale because the process is gone
+		if !strings.Contains(reason, "no longer running") {
+			t.Fatalf("expected PID staleness, got %q", reason)
+		}
+	})
+}
+
+// staleLock is a StackLock whose first Acquire is busy with a stale holder and whose
+// later Acquires succeed once the holder is force-released.
+type staleLock struct {
+	holder       LockInfo
+	released     string
+	acquireCalls int
+}
+
+func (l *staleLock) Acquire(ctx context.Context, info LockInfo, timeout time.Duration) (Release, error) {
+	l.acquireCalls++
+	if l.released == "" {
+		return nil, newBusyError("/tmp/x.stacklock", &l.holder)
+	}
+	return func() error { return nil }, nil
+}
+
+func (l *staleLock) Inspect(ctx context.Context) (*LockInfo, error) { return &l.holder, nil }
+
+func (l *staleLock) ForceRelease(ctx context.Context, lockID string, reason string) error {
+	l.released = lockID
+	return nil
+}
+
+func TestAcquireOrTakeOver(t *testing.T) {
+	newStaleLock := func() *staleLock {
+		holder := newTestLockInfo()
+		holder.ID = "dead-holder"
+		holder.Heartbeat = time.Now().Add(-2 * staleHeartbeatAfter)
+		return &staleLock{holder: holder}
+	}
+	answer := func(t *testing.T, yes bool) {
+		t.Helper()
+		orig := confirmTakeover
+		confirmTakeover = func(*LockBusyError) bool { return yes }
+		t.Cleanup(func() { confirmTakeover = orig })
+	}
+
+	t.Run("takes over a stale holder when confirmed", func(t *testing.T) {
+		// Given a lock held by a stale holder and an operator who accepts
+		lock := newStaleLock()
+		answer(t, true)
+
+		// When acquiring
+		release, err := acquireOrTakeOver(context.Background(), lock, newTestLockInfo(), 0)
+
+		// Then the stale holder is force-released by ID and the lock is acquired
+		if err != nil || release == nil {
+			t.Fatalf("expected takeover, got %v", err)
+		}
+		if lock.released != "dead-holder" || lock.acquireCalls != 2 {
+			t.Fatalf("expected force-release of dead-holder then a second attempt, got %q after %d attempts", lock.released, lock.acquireCalls)
+		}
+	})
+
+	t.Run("returns the busy error when the operator declines", func(t *testing.T) {
+		// Given a lock held by a stale holder and an operator who declines
+		lock := newStaleLock()
+		answer(t, false)
+
+		// When acquiring
+		_, err := acquireOrTakeOver(context.Background(), lock, newTestLockInfo(), 0)
+
+		// Then the stale busy error surfaces and nothing is released
+		var busy *LockBusyError
+		if !errors.As(err, &busy) || busy.Stale == "" {
+			t.Fatalf("expected stale *LockBusyError, got %v", err)
+		}
+		if lock.released != "" {
+			t.Fatalf("expected no force-release, got %q", lock.released)
+		}
+	})
+
+	t.Run("does not offer takeover for a live holder", func(t *testing.T) {
+		// Given a lock held by a live holder
+		lock := newStaleLock()
+		lock.holder.Heartbeat = time.Now()
+		asked := false
+		orig := confirmTakeover
+		confirmTakeover = func(*LockBusyError) bool { asked = true; return true }
+		t.Cleanup(func() { confirmTakeover = orig })
+
+		// When acquiring
+		_, err := acquireOrTakeOver(context.Background(), lock, newTestLockInfo(), 0)
+
+		// Then the busy error surfaces without a prompt
+		var busy *LockBusyError
+		if !errors.As(err, &busy) || asked {
+			t.Fatalf("expected busy error without a prompt, got %v (asked=%v)", err, asked)
+		}
+	})
+}
+
+// failingLock is a StackLock whose every call fails with err.
+type failingLock struct {
+	err error
+}
+
+func (l *failingLock) Acquire(ctx context.Context, info LockInfo, timeout time.Duration) (Release, error) {
+	return nil, l.err
+}
+
+func (l *failingLock) Inspect(ctx context.Context) (*LockInfo, error) { return nil, l.err }
+
+func (l *failingLock) ForceRelease(ctx context.Context, lockID string, reason string) error {
+	return l.err
+}
+
+func TestFallbackLock(t *testing.T) {
+	t.Run("uses the local flock while the remote backend is unavailable", func(t *testing.T) {
+		// Given a remote lock whose backend cannot be reached
+		path := filepath.Join(t.TempDir(), ".stacklock")
+		unavailable := fmt.Errorf("stacklock: reading lease: %w: connection refused", errBackendUnavailable)
+		lock := newFallbackLock(&failingLock{err: unavailable}, NewLocalFlockLock(path))
+
+		// When acquiring and inspecting
+		release, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		defer release()
+		info, err := lock.Inspect(context.Background())
+
+		// Then the local flock holds the lock and reports the holder
+		if err != nil || info == nil || info.ID != "test-id-1" {
+			t.Fatalf("expected the local holder, got (%+v, %v)", info, err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected the local lock file, got %v", err)
+		}
+	})
+
+	t.Run("does not fall back while the remote backend is unreachable", func(t *testing.T) {
+		// Given a remote lock whose backend exists but does not answer
+		path := filepath.Join(t.TempDir(), ".stacklock")
+		unreachable := fmt.Errorf("stacklock: reading lease: %w: connection refused", errBackendUnreachable)
+		lock := newFallbackLock(&failingLock{err: unreachable}, NewLocalFlockLock(path))
+
+		// When acquiring
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the error surfaces and no local lock is taken
+		if !errors.Is(err, errBackendUnreachable) {
+			t.Fatalf("expected the unreachable error, got %v", err)
+		}
+		if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+			t.Errorf("expected no local lock file, got %v", statErr)
+		}
+	})
+
+	t.Run("returns other remote errors as is", func(t *testing.T) {
+		// Given a remote lock that refuses for another reason
+		refused := errors.New("stacklock: reading lease: forbidden")
+		lock := newFallbackLock(&failingLock{err: refused}, NewLocalFlockLock(filepath.Join(t.TempDir(), ".stacklock")))
+
+		// When acquiring
+		_, err := lock.Acquire(context.Background(), newTestLockInfo(), 0)
+
+		// Then the refusal surfaces instead of a silent fallback
+		if !errors.Is(err, refused) {
+			t.Fatalf("expected the remote error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package provisioner
+
+import (
+	"context"
+	"fmt"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Suspend suspends the named blueprint kustomizations and the HelmReleases they own, recording
+// suspension on each object so apply and install can report who suspended it and why. An empty
+// names slice suspends every kustomization the blueprint applies that is on the cluster. Returns
+// the objects suspended; an error is returned for a name the blueprint does not declare or the
+// cluster does not hold, when no kubeconfig exists, or when a patch fails, in which case the
+// objects suspended so far are still returned.
+func (i *Provisioner) Suspend(blueprint *blueprintv1alpha1.Blueprint, names []string, suspension kubernetes.Suspension) ([]kubernetes.SuspendedObject, error) {
+	explicit := len(names) > 0
+	names, err := i.suspendTargets(blueprint, names)
+	if err != nil {
+		return nil, err
+	}
+	var suspended []kubernetes.SuspendedObject
+	namespaces := i.kustomizationNamespaces(blueprint)
+	for _, name := range names {
+		objs, err := i.KubernetesManager.SuspendKustomization(name, i.kustomizationNamespace(name, namespaces), suspension)
+		suspended = append(suspended, objs...)
+		if err != nil {
+			return suspended, err
+		}
+		if explicit && len(objs) == 0 {
+			return suspended, fmt.Errorf("kustomization %q is not on the cluster", name)
+		}
+	}
+	return suspended, nil
+}
+
+// Resume lifts the suspension of the named blueprint kustomizations and the HelmReleases they own,
+// then requests an immediate flux reconcile of the kustomizations that were resumed so the cluster
+// catches up without waiting for the next interval. The reconcile request is best-effort. An empty
+// names slice resumes every kustomization the blueprint applies. Returns the objects resumed.
+func (i *Provisioner) Resume(ctx context.Context, blueprint *blueprintv1alpha1.Blueprint, names []string) ([]kubernetes.SuspendedObject, error) {
+	names, err := i.suspendTargets(blueprint, names)
+	if err != nil {
+		return nil, err
+	}
+	var resumed []kubernetes.SuspendedObject
+	var reconcile []string
+	namespaces := i.kustomizationNamespaces(blueprint)
+	for _, name := range names {
+		objs, err := i.KubernetesManager.ResumeKustomization(name, i.kustomizationNamespace(name, namespaces))
+		resumed = append(resumed, objs...)
+		if err != nil {
+			i.reconcileKustomizations(ctx, reconcile, namespaces)
+			return resumed, err
+		}
+		if len(objs) > 0 {
+			reconcile = append(reconcile, name)
+		}
+	}
+	i.reconcileKustomizations(ctx, reconcile, namespaces)
+	return resumed, nil
+}
+
+// SuspendedObjects returns the suspended Kustomizations the blueprint applies and the suspended
+// HelmReleases they own. It returns nothing when no kubeconfig exists yet, since there is no
+// cluster holding a suspension to undo.
+func (i *Provisioner) SuspendedObjects(blueprint *blueprintv1alpha1.Blueprint) ([]kubernetes.SuspendedObject, error) {
+	if blueprint == nil {
+		return nil, fmt.Errorf("blueprint not provided")
+	}
+	if i.KubernetesManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not configured")
+	}
+	if !i.kubeconfigPresent() {
+		return nil, nil
+	}
+	return i.KubernetesManager.GetSuspendedObjects(appliedKustomizationNames(blueprint), i.kustomizationNamespaces(blueprint))
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// suspendTargets validates a suspend or resume request and resolves the kustomization names it
+// targets: names as given when each is declared by the blueprint, or every applied kustomization
+// when names is empty.
+func (i *Provisioner) suspendTargets(blueprint *blueprintv1alpha1.Blueprint, names []string) ([]string, error) {
+	if blueprint == nil {
+		return nil, fmt.Errorf("blueprint not provided")
+	}
+	if i.KubernetesManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not configured")
+	}
+	if !i.kubeconfigPresent() {
+		return nil, fmt.Errorf("no kubeconfig found for this context; bootstrap the cluster first")
+	}
+	applied := appliedKustomizationNames(blueprint)
+	if len(names) == 0 {
+		return applied, nil
+	}
+	declared := make(map[string]bool, len(applied))
+	for _, name := range applied {
+		declared[name] = true
+	}
+	for _, name := range names {
+		if !declared[name] {
+			return nil, fmt.Errorf("kustomization %q not found in blueprint", name)
+		}
+	}
+	return names, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// appliedKustomizationNames returns the names of the kustomizations the blueprint applies to the
+// cluster, including the CRD layer and excluding destroy-only kustomizations.
+func appliedKustomizationNames(blueprint *blueprintv1alpha1.Blueprint) []string {
+	var names []string
+	for _, k := range withCrdLayer(blueprint).AllKustomizations() {
+		if k.DestroyOnly != nil && *k.DestroyOnly {
+			continue
+		}
+		names = append(names, k.Name)
+	}
+	return names
+}
@@ -0,0 +1,248 @@
+package provisioner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// suspendBlueprint declares dns and ingress plus a destroy-only cleanup kustomization.
+func suspendBlueprint() *blueprintv1alpha1.Blueprint {
+	destroyOnly := true
+	return &blueprintv1alpha1.Blueprint{Kustomizations: []blueprintv1alpha1.Kustomization{
+		{Name: "dns"},
+		{Name: "ingress"},
+		{Name: "cleanup", DestroyOnly: &destroyOnly},
+	}}
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestProvisioner_Suspend(t *testing.T) {
+	t.Run("SuspendsEveryAppliedKustomization", func(t *testing.T) {
+		// Given a blueprint with two applied kustomizations, only dns on the cluster
+		mocks := setupProvisionerMocks(t)
+		var names []string
+		mocks.KubernetesManager.SuspendKustomizationFunc = func(name, namespace string, s kubernetes.Suspension) ([]kubernetes.SuspendedObject, error) {
+			names = append(names, name)
+			if name != "dns" {
+				return nil, nil
+			}
+			return []kubernetes.SuspendedObject{{Kind: "Kustomization", Namespace: namespace, Name: name}}, nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When every kustomization is suspended
+		objs, err := p.Suspend(suspendBlueprint(), nil, kubernetes.Suspension{By: "alice@laptop"})
+
+		// Then each applied kustomization is suspended, skipping the destroy-only one and the absent one
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if strings.Join(names, ",") != "dns,ingress" {
+			t.Errorf("Expected dns and ingress suspended, got %v", names)
+		}
+		if len(objs) != 1 || objs[0].Name != "dns" {
+			t.Errorf("Expected only dns reported, got %+v", objs)
+		}
+	})
+
+	t.Run("SuspendsKustomizationInItsOwnNamespace", func(t *testing.T) {
+		// Given dns declared in team-dns rather than the gitops namespace
+		mocks := setupProvisionerMocks(t)
+		namespaces := make(map[string]string)
+		mocks.KubernetesManager.SuspendKustomizationFunc = func(name, namespace string, s kubernetes.Suspension) ([]kubernetes.SuspendedObject, error) {
+			namespaces[name] = namespace
+			return []kubernetes.SuspendedObject{{Kind: "Kustomization", Namespace: namespace, Name: name}}, nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+		blueprint := suspendBlueprint()
+		blueprint.Kustomizations[0].Namespace = "team-dns"
+
+		// When every kustomization is suspended
+		if _, err := p.Suspend(blueprint, nil, kubernetes.Suspension{By: "alice@laptop"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then dns is patched in team-dns and ingress in the gitops namespace
+		if namespaces["dns"] != "team-dns" || namespaces["ingress"] != "system-gitops" {
+			t.Errorf("Expected dns in team-dns and ingress in system-gitops, got %v", namespaces)
+		}
+	})
+
+	t.Run("ErrorsForNamedKustomizationNotOnCluster", func(t *testing.T) {
+		// Given a declared kustomization the cluster does not hold
+		mocks := setupProvisionerMocks(t)
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When it is suspended by name
+		_, err := p.Suspend(suspendBlueprint(), []string{"ingress"}, kubernetes.Suspension{By: "alice@laptop"})
+
+		// Then the operator is told it is not on the cluster
+		if err == nil || !strings.Contains(err.Error(), "not on the cluster") {
+			t.Errorf("Expected not-on-cluster error, got %v", err)
+		}
+	})
+
+	t.Run("RejectsUndeclaredAndDestroyOnlyNames", func(t *testing.T) {
+		mocks := setupProvisionerMocks(t)
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+		for _, name := range []string{"monitoring", "cleanup"} {
+			if _, err := p.Suspend(suspendBlueprint(), []string{name}, kubernetes.Suspension{}); err == nil || !strings.Contains(err.Error(), "not found in blueprint") {
+				t.Errorf("Expected %s rejected, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("ErrorsWithoutKubeconfig", func(t *testing.T) {
+		// Given a context whose config root has no kubeconfig
+		mocks := setupProvisionerMocks(t)
+		mocks.Runtime.ConfigRoot = t.TempDir()
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When a kustomization is suspended
+		_, err := p.Suspend(suspendBlueprint(), []string{"dns"}, kubernetes.Suspension{})
+
+		// Then it points at bootstrapping the cluster
+		if err == nil || !strings.Contains(err.Error(), "bootstrap") {
+			t.Errorf("Expected no-kubeconfig error, got %v", err)
+		}
+	})
+}
+
+func TestProvisioner_Resume(t *testing.T) {
+	t.Run("ReconcilesResumedKustomizations", func(t *testing.T) {
+		// Given dns suspended and ingress not
+		mocks := setupProvisionerMocks(t)
+		mocks.KubernetesManager.ResumeKustomizationFunc = func(name, namespace string) ([]kubernetes.SuspendedObject, error) {
+			if name != "dns" {
+				return nil, nil
+			}
+			return []kubernetes.SuspendedObject{{Kind: "Kustomization", Namespace: namespace, Name: name}}, nil
+		}
+		notifier := fluxinfra.NewMockNotifier()
+		var reconciled []string
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
+			reconciled = kustomizationRefNames(refs)
+			return fmt.Errorf("webhook unreachable")
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager, Notifier: notifier})
+
+		// When every kustomization is resumed
+		objs, err := p.Resume(context.Background(), suspendBlueprint(), nil)
+
+		// Then only dns is reconciled, and a failed reconcile request does not fail the resume
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(objs) != 1 || strings.Join(reconciled, ",") != "dns" {
+			t.Errorf("Expected dns resumed and reconciled, got %+v and %v", objs, reconciled)
+		}
+	})
+
+	t.Run("ReconcilesWhatWasResumedBeforeAnError", func(t *testing.T) {
+		// Given dns resumes and ingress fails to
+		mocks := setupProvisionerMocks(t)
+		mocks.KubernetesManager.ResumeKustomizationFunc = func(name, namespace string) ([]kubernetes.SuspendedObject, error) {
+			if name == "ingress" {
+				return nil, fmt.Errorf("patch failed")
+			}
+			return []kubernetes.SuspendedObject{{Kind: "Kustomization", Namespace: namespace, Name: name}}, nil
+		}
+		notifier := fluxinfra.NewMockNotifier()
+		var reconciled []string
+		notifier.ReconcileKustomizationsFunc = func(ctx context.Context, refs []fluxinfra.KustomizationRef) error {
+			reconciled = kustomizationRefNames(refs)
+			return nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager, Notifier: notifier})
+
+		// When every kustomization is resumed
+		_, err := p.Resume(context.Background(), suspendBlueprint(), nil)
+
+		// Then the error is returned and dns is still reconciled
+		if err == nil || !strings.Contains(err.Error(), "patch failed") {
+			t.Errorf("Expected patch error, got %v", err)
+		}
+		if strings.Join(reconciled, ",") != "dns" {
+			t.Errorf("Expected dns reconciled, got %v", reconciled)
+		}
+	})
+}
+
+func TestProvisioner_SuspendedObjects(t *testing.T) {
+	t.Run("QueriesAppliedKustomizations", func(t *testing.T) {
+		// Given a blueprint with a destroy-only kustomization
+		mocks := setupProvisionerMocks(t)
+		var requested []string
+		mocks.KubernetesManager.GetSuspendedObjectsFunc = func(names []string, namespaces map[string]string) ([]kubernetes.SuspendedObject, error) {
+			requested = names
+			return nil, nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When suspended objects are read
+		if _, err := p.SuspendedObjects(suspendBlueprint()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then only the applied kustomizations are queried
+		if strings.Join(requested, ",") != "dns,ingress" {
+			t.Errorf("Expected dns and ingress queried, got %v", requested)
+		}
+	})
+
+	t.Run("QueriesEachKustomizationInItsOwnNamespace", func(t *testing.T) {
+		// Given dns declared in team-dns rather than the gitops namespace
+		mocks := setupProvisionerMocks(t)
+		var requested map[string]string
+		mocks.KubernetesManager.GetSuspendedObjectsFunc = func(names []string, namespaces map[string]string) ([]kubernetes.SuspendedObject, error) {
+			requested = namespaces
+			return nil, nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+		blueprint := suspendBlueprint()
+		blueprint.Kustomizations[0].Namespace = "team-dns"
+
+		// When suspended objects are read
+		if _, err := p.SuspendedObjects(blueprint); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then dns is looked up in team-dns
+		if requested["dns"] != "team-dns" || requested["ingress"] != "system-gitops" {
+			t.Errorf("Expected dns in team-dns and ingress in system-gitops, got %v", requested)
+		}
+	})
+
+	t.Run("ReturnsNothingWithoutKubeconfig", func(t *testing.T) {
+		// Given a context whose config root has no kubeconfig
+		mocks := setupProvisionerMocks(t)
+		mocks.Runtime.ConfigRoot = t.TempDir()
+		called := false
+		mocks.KubernetesManager.GetSuspendedObjectsFunc = func(names []string, namespaces map[string]string) ([]kubernetes.SuspendedObject, error) {
+			called = true
+			return nil, nil
+		}
+		p := NewProvisioner(mocks.Runtime, mocks.BlueprintHandler, &Provisioner{KubernetesManager: mocks.KubernetesManager})
+
+		// When suspended objects are read
+		objs, err := p.SuspendedObjects(suspendBlueprint())
+
+		// Then the cluster is not queried
+		if err != nil || objs != nil || called {
+			t.Errorf("Expected no query without a kubeconfig, got %+v, %v, called=%v", objs, err, called)
+		}
+	})
+}
@@ -23,17 +23,26 @@ type MockStack struct {
 	InitComponentFunc              func(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
 	RemoveLocalStateFunc           func(componentID string) error
 	PostApplyFunc             func(fns ...func(id string) error)
+	SetMaxConcurrencyFunc     func(n int)
+	SetPlanCheckFunc          func(fn func(componentID string, changes []ResourceChange) error)
 	DestroyAllFunc            func(blueprint *blueprintv1alpha1.Blueprint, continueOnError bool, excludeIDs ...string) (DestroyOutcome, error)
 	PlanFunc                  func(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
 	PlanAllFunc               func(blueprint *blueprintv1alpha1.Blueprint) error
 	PlanJSONFunc              func(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
 	PlanAllJSONFunc           func(blueprint *blueprintv1alpha1.Blueprint) error
 	ApplyFunc                 func(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
+	ApplyWithOptionsFunc      func(blueprint *blueprintv1alpha1.Blueprint, componentID string, opts ApplyOptions) error
 	DestroyFunc               func(blueprint *blueprintv1alpha1.Blueprint, componentID string) (bool, error)
 	PlanSummaryFunc                 func(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan
 	PlanComponentSummaryFunc        func(blueprint *blueprintv1alpha1.Blueprint, componentID string) TerraformComponentPlan
 	PlanDestroySummaryFunc          func(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan
 	PlanDestroyComponentSummaryFunc func(blueprint *blueprintv1alpha1.Blueprint, componentID string) TerraformComponentPlan
+	DriftSummaryFunc                func(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan
+	StateFunc                       func(blueprint *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error)
+	SnapshotStateFunc               func(blueprint *blueprintv1alpha1.Blueprint, componentID string) (string, error)
+	RestoreStateFunc                func(blueprint *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error)
+	SavePlansFunc                   func(blueprint *blueprintv1alpha1.Blueprint, dir string) ([]TerraformComponentPlan, []SavedPlan, error)
+	ApplySavedPlansFunc             func(blueprint *blueprintv1alpha1.Blueprint, dir string, plans []SavedPlan) error
 }
 
 // =============================================================================
@@ -112,6 +121,20 @@ func (m *MockStack) PostApply(fns ...func(id string) error) {
 	}
 }
 
+// SetMaxConcurrency is a mock implementation of the SetMaxConcurrency method.
+func (m *MockStack) SetMaxConcurrency(n int) {
+	if m.SetMaxConcurrencyFunc != nil {
+		m.SetMaxConcurrencyFunc(n)
+	}
+}
+
+// SetPlanCheck is a mock implementation of the SetPlanCheck method.
+func (m *MockStack) SetPlanCheck(fn func(componentID string, changes []ResourceChange) error) {
+	if m.SetPlanCheckFunc != nil {
+		m.SetPlanCheckFunc(fn)
+	}
+}
+
 // DestroyAll is a mock implementation of the DestroyAll method.
 func (m *MockStack) DestroyAll(blueprint *blueprintv1alpha1.Blueprint, continueOnError bool, excludeIDs ...string) (DestroyOutcome, error) {
 	if m.DestroyAllFunc != nil {
@@ -160,6 +183,16 @@ func (m *MockStack) Apply(blueprint *blueprintv1alpha1.Blueprint, componentID st
 	return nil
 }
 
+// ApplyWithOptions is a mock implementation of the ApplyWithOptions method. Without
+// ApplyWithOptionsFunc it falls back to Apply, as the real stack's Apply is ApplyWithOptions
+// with no options.
+func (m *MockStack) ApplyWithOptions(blueprint *blueprintv1alpha1.Blueprint, componentID string, opts ApplyOptions) error {
+	if m.ApplyWithOptionsFunc != nil {
+		return m.ApplyWithOptionsFunc(blueprint, componentID, opts)
+	}
+	return m.Apply(blueprint, componentID)
+}
+
 // Destroy is a mock implementation of the Destroy method.
 func (m *MockStack) Destroy(blueprint *blueprintv1alpha1.Blueprint, componentID string) (bool, error) {
 	if m.DestroyFunc != nil {
@@ -200,6 +233,54 @@ func (m *MockStack) PlanDestroyComponentSummary(blueprint *blueprintv1alpha1.Blu
 	return TerraformComponentPlan{ComponentID: componentID}
 }
 
+// DriftSummary is a mock implementation of the DriftSummary method.
+func (m *MockStack) DriftSummary(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan {
+	if m.DriftSummaryFunc != nil {
+		return m.DriftSummaryFunc(blueprint)
+	}
+	return nil
+}
+
+// State is a mock implementation of the State method.
+func (m *MockStack) State(blueprint *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+	if m.StateFunc != nil {
+		return m.StateFunc(blueprint, componentID, args...)
+	}
+	return "", nil
+}
+
+// SnapshotState is a mock implementation of the SnapshotState method.
+func (m *MockStack) SnapshotState(blueprint *blueprintv1alpha1.Blueprint, componentID string) (string, error) {
+	if m.SnapshotStateFunc != nil {
+		return m.SnapshotStateFunc(blueprint, componentID)
+	}
+	return "", nil
+}
+
+// RestoreState is a mock implementation of the RestoreState method.
+func (m *MockStack) RestoreState(blueprint *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error) {
+	if m.RestoreStateFunc != nil {
+		return m.RestoreStateFunc(blueprint, componentID, snapshotID)
+	}
+	return "", nil
+}
+
+// SavePlans is a mock implementation of the SavePlans method.
+func (m *MockStack) SavePlans(blueprint *blueprintv1alpha1.Blueprint, dir string) ([]TerraformComponentPlan, []SavedPlan, error) {
+	if m.SavePlansFunc != nil {
+		return m.SavePlansFunc(blueprint, dir)
+	}
+	return nil, nil, nil
+}
+
+// ApplySavedPlans is a mock implementation of the ApplySavedPlans method.
+func (m *MockStack) ApplySavedPlans(blueprint *blueprintv1alpha1.Blueprint, dir string, plans []SavedPlan) error {
+	if m.ApplySavedPlansFunc != nil {
+		return m.ApplySavedPlansFunc(blueprint, dir, plans)
+	}
+	return nil
+}
+
 // =============================================================================
 // Interface Compliance
 // =============================================================================
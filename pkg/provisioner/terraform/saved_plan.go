@@ -0,0 +1,354 @@
+package terraform
+
+// The TerraformStack saved-plan operations write every component's binary plan to a directory
+// the operator chooses and later apply exactly those plan files. Each saved plan carries
+// digests of the component's blueprint inputs and module source, so ApplySavedPlans can refuse
+// a plan directory whose components changed after it was written instead of applying changes
+// nobody reviewed.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/tui"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// savedPlanExt is the file extension of every component plan written by SavePlans.
+const savedPlanExt = ".tfplan"
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// SavedPlan records one component's binary plan written by SavePlans. PlanFile is relative to
+// the plan directory. InputsDigest covers the component's blueprint inputs; SourceDigest covers
+// its resolved module source and the terraform files in its module directory.
+type SavedPlan struct {
+	ComponentID  string `json:"componentId"`
+	PlanFile     string `json:"planFile"`
+	InputsDigest string `json:"inputsDigest"`
+	SourceDigest string `json:"sourceDigest"`
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// SavePlans runs terraform init and plan for every enabled component in dependency order and
+// copies each binary plan into dir as <componentID>.tfplan. Components that have never been
+// applied are planned too, since a saved plan must cover everything apply will touch. Returns
+// the per-component summaries alongside the saved plan records, both in dependency order.
+// Stops on the first failure: a partial plan directory cannot be applied. Refuses to save when
+// a component reads terraform_output() from a component with pending changes: its plan was
+// computed against outputs that apply will change, and a saved plan cannot be re-planned
+// against the new values.
+func (s *TerraformStack) SavePlans(blueprint *blueprintv1alpha1.Blueprint, dir string) ([]TerraformComponentPlan, []SavedPlan, error) {
+	if blueprint == nil {
+		return nil, nil, fmt.Errorf("blueprint not provided")
+	}
+	if dir == "" {
+		return nil, nil, fmt.Errorf("plan directory not provided")
+	}
+
+	projectRoot := s.runtime.ProjectRoot
+	if projectRoot == "" {
+		return nil, nil, fmt.Errorf("error getting project root: project root is empty")
+	}
+
+	components, err := applyOrder(blueprint, s.resolveTerraformComponents(blueprint, projectRoot))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.shims.MkdirAll(dir, 0o700); err != nil {
+		return nil, nil, fmt.Errorf("error creating plan directory %s: %w", dir, err)
+	}
+
+	results := make([]TerraformComponentPlan, 0, len(components))
+	saved := make([]SavedPlan, 0, len(components))
+	for i := range components {
+		result, plan, err := s.saveOnePlan(&components[i], dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		results = append(results, result)
+		saved = append(saved, plan)
+	}
+	markKnownAfterApply(components, results)
+	var stale []string
+	for _, result := range results {
+		if result.KnownAfterApply {
+			stale = append(stale, result.ComponentID)
+		}
+	}
+	if len(stale) > 0 {
+		return nil, nil, fmt.Errorf("cannot save plans: inputs of %s read outputs of components with pending changes; apply those components first, then save the plans again", strings.Join(stale, ", "))
+	}
+	return results, saved, nil
+}
+
+// ApplySavedPlans applies the plan files SavePlans wrote to dir, one component at a time in
+// dependency order. Before anything is applied, every enabled component must have a saved plan,
+// every saved plan must name an enabled component, and each component's inputs and module source
+// must still match the digests recorded when the plan was saved. Each plan file is staged as the
+// component's current plan so the registered plan check and the usual apply arguments see it;
+// terraform itself refuses a plan whose state changed since it was written. Stops on the first
+// failure.
+func (s *TerraformStack) ApplySavedPlans(blueprint *blueprintv1alpha1.Blueprint, dir string, plans []SavedPlan) error {
+	if blueprint == nil {
+		return fmt.Errorf("blueprint not provided")
+	}
+	if dir == "" {
+		return fmt.Errorf("plan directory not provided")
+	}
+
+	projectRoot := s.runtime.ProjectRoot
+	if projectRoot == "" {
+		return fmt.Errorf("error getting project root: project root is empty")
+	}
+
+	components, err := applyOrder(blueprint, s.resolveTerraformComponents(blueprint, projectRoot))
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]SavedPlan, len(plans))
+	for _, plan := range plans {
+		byID[plan.ComponentID] = plan
+	}
+	present := make(map[string]bool, len(components))
+	for i := range components {
+		id := components[i].GetID()
+		present[id] = true
+		plan, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("no saved plan for terraform component %q", id)
+		}
+		if err := s.verifySavedPlan(&components[i], plan); err != nil {
+			return err
+		}
+	}
+	for _, plan := range plans {
+		if !present[plan.ComponentID] {
+			return fmt.Errorf("saved plan for terraform component %q does not match any enabled component in the blueprint", plan.ComponentID)
+		}
+	}
+
+	for i := range components {
+		if err := s.applyOneSavedPlan(&components[i], dir, byID[components[i].GetID()]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// saveOnePlan runs init and plan for component, copies the resulting binary plan into dir, and
+// returns the component's summary with the record of the saved file. Resource values are attached
+// to the summary while a plan check is registered, matching planOneTerraformSummary.
+func (s *TerraformStack) saveOnePlan(component *blueprintv1alpha1.TerraformComponent, dir string) (TerraformComponentPlan, SavedPlan, error) {
+	result := TerraformComponentPlan{ComponentID: component.GetID(), Path: component.Path}
+
+	terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentEnv(component)
+	if err != nil {
+		return result, SavedPlan{}, err
+	}
+	defer cleanup()
+	terraformVars["TF_VAR_operation"] = "apply"
+
+	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+		return result, SavedPlan{}, err
+	}
+
+	hasState, err := s.hasStateResources(component, terraformVars, scopedKeys)
+	if err != nil {
+		return result, SavedPlan{}, err
+	}
+	result.IsNew = !hasState
+
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	planArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "plan", "-json", "-no-color"}
+	planArgs = append(planArgs, terraformArgs.PlanArgs...)
+	planEnv := selectTerraformCommandEnv(terraformVars, true, scopedKeys)
+	planOutput, err := s.runtime.Shell.ExecCaptureWithEnv(terraformCommand, planEnv, planArgs...)
+	if err != nil {
+		return result, SavedPlan{}, fmt.Errorf("error running terraform plan for %s: %w", component.Path, err)
+	}
+	result.Add, result.Change, result.Destroy, result.NoChanges, result.Resources = parseTerraformPlanJSON(planOutput)
+	if s.planCheck != nil {
+		changes, err := s.showPlanChanges(component, terraformVars, scopedKeys, terraformArgs)
+		if err != nil {
+			return result, SavedPlan{}, err
+		}
+		result.Resources = attachPlanValues(result.Resources, changes)
+	}
+
+	inputsDigest, err := componentInputsDigest(component)
+	if err != nil {
+		return result, SavedPlan{}, err
+	}
+	sourceDigest, err := s.componentSourceDigest(component)
+	if err != nil {
+		return result, SavedPlan{}, err
+	}
+
+	data, err := s.shims.ReadFile(planFilePath(terraformArgs))
+	if err != nil {
+		return result, SavedPlan{}, fmt.Errorf("error reading terraform plan file for %s: %w", component.Path, err)
+	}
+	plan := SavedPlan{
+		ComponentID:  component.GetID(),
+		PlanFile:     component.GetID() + savedPlanExt,
+		InputsDigest: inputsDigest,
+		SourceDigest: sourceDigest,
+	}
+	target := filepath.Join(dir, filepath.FromSlash(plan.PlanFile))
+	if err := s.shims.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return result, SavedPlan{}, fmt.Errorf("error creating plan directory for %s: %w", component.Path, err)
+	}
+	if err := s.shims.WriteFile(target, data, 0o600); err != nil {
+		return result, SavedPlan{}, fmt.Errorf("error writing saved plan for %s: %w", component.Path, err)
+	}
+
+	return result, plan, nil
+}
+
+// verifySavedPlan compares component's current inputs and module source against the digests
+// recorded in plan. Returns an error naming what changed when either differs.
+func (s *TerraformStack) verifySavedPlan(component *blueprintv1alpha1.TerraformComponent, plan SavedPlan) error {
+	inputsDigest, err := componentInputsDigest(component)
+	if err != nil {
+		return err
+	}
+	if inputsDigest != plan.InputsDigest {
+		return fmt.Errorf("inputs of terraform component %q changed since the plan was saved", plan.ComponentID)
+	}
+	sourceDigest, err := s.componentSourceDigest(component)
+	if err != nil {
+		return err
+	}
+	if sourceDigest != plan.SourceDigest {
+		return fmt.Errorf("module source of terraform component %q changed since the plan was saved", plan.ComponentID)
+	}
+	return nil
+}
+
+// applyOneSavedPlan stages a saved plan file as component's current plan and applies it. The
+// state is snapshotted first, as for any apply; no refresh runs, since a refresh would move the
+// state past the one the plan was computed against and terraform would reject the plan as stale.
+func (s *TerraformStack) applyOneSavedPlan(component *blueprintv1alpha1.TerraformComponent, dir string, plan SavedPlan) error {
+	terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentEnv(component)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	terraformVars["TF_VAR_operation"] = "apply"
+
+	return tui.WithProgress(fmt.Sprintf("Applying %s", component.Path), func() error {
+		if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+			return err
+		}
+
+		source := filepath.Join(dir, filepath.FromSlash(plan.PlanFile))
+		data, err := s.shims.ReadFile(source)
+		if err != nil {
+			return fmt.Errorf("error reading saved plan for %s: %w", component.Path, err)
+		}
+		staged := filepath.FromSlash(planFilePath(terraformArgs))
+		if err := s.shims.MkdirAll(filepath.Dir(staged), 0o700); err != nil {
+			return fmt.Errorf("error creating terraform data directory for %s: %w", component.Path, err)
+		}
+		if err := s.shims.WriteFile(staged, data, 0o600); err != nil {
+			return fmt.Errorf("error staging saved plan for %s: %w", component.Path, err)
+		}
+
+		if err := s.snapshotBeforeChange(component, terraformVars, scopedKeys); err != nil {
+			return err
+		}
+
+		if err := s.checkPlan(component, terraformVars, scopedKeys, terraformArgs); err != nil {
+			return err
+		}
+
+		terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+		applyArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "apply"}
+		applyArgs = append(applyArgs, terraformArgs.ApplyArgs...)
+		applyEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
+		if _, err := s.runtime.Shell.ExecProgressWithEnv(fmt.Sprintf("Applying Terraform changes in %s", component.Path), terraformCommand, applyEnv, applyArgs...); err != nil {
+			return fmt.Errorf("error running terraform apply for %s: %w", component.Path, err)
+		}
+		_ = s.runtime.TerraformProvider.CacheOutputs(component.GetID())
+
+		return nil
+	})
+}
+
+// componentSourceDigest hashes component's resolved module source together with every terraform
+// file under its module directory. Hidden directories such as .terraform and the generated
+// backend_override.tf are skipped, since neither is part of the module a reviewer approved.
+func (s *TerraformStack) componentSourceDigest(component *blueprintv1alpha1.TerraformComponent) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(component.Source))
+	h.Write([]byte{0})
+	var walk func(dir, rel string) error
+	walk = func(dir, rel string) error {
+		entries, err := s.shims.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("error reading module directory %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() {
+				if strings.HasPrefix(name, ".") {
+					continue
+				}
+				if err := walk(filepath.Join(dir, name), rel+name+"/"); err != nil {
+					return err
+				}
+				continue
+			}
+			if name == "backend_override.tf" || (!strings.HasSuffix(name, ".tf") && !strings.HasSuffix(name, ".tf.json")) {
+				continue
+			}
+			data, err := s.shims.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				return fmt.Errorf("error reading module file %s: %w", filepath.Join(dir, name), err)
+			}
+			h.Write([]byte(rel + name))
+			h.Write([]byte{0})
+			h.Write(data)
+			h.Write([]byte{0})
+		}
+		return nil
+	}
+	if err := walk(component.FullPath, ""); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// componentInputsDigest hashes component's blueprint inputs. JSON encoding sorts map keys, so
+// equal inputs always produce the same digest.
+func componentInputsDigest(component *blueprintv1alpha1.TerraformComponent) (string, error) {
+	data, err := json.Marshal(component.Inputs)
+	if err != nil {
+		return "", fmt.Errorf("error encoding inputs of %s: %w", component.Path, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
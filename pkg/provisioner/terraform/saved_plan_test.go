@@ -0,0 +1,308 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// setupSavedPlanStack returns a stack whose file shims hit the real filesystem, except that
+// reading a component's terraform.tfplan returns a plan body naming the component directory.
+// The remote component's scratch directory is created so its module source can be hashed.
+func setupSavedPlanStack(t *testing.T) (*TerraformStack, *TerraformTestMocks) {
+	t.Helper()
+	mocks := setupWindsorStackMocks(t)
+	stack := NewStack(mocks.Runtime).(*TerraformStack)
+	mocks.Shims.MkdirAll = os.MkdirAll
+	mocks.Shims.WriteFile = os.WriteFile
+	mocks.Shims.ReadDir = os.ReadDir
+	mocks.Shims.ReadFile = func(path string) ([]byte, error) {
+		if filepath.Base(path) == "terraform.tfplan" {
+			if data, err := os.ReadFile(path); err == nil {
+				return data, nil
+			}
+			return []byte("plan:" + filepath.Base(filepath.Dir(path))), nil
+		}
+		return os.ReadFile(path)
+	}
+	stack.shims = mocks.Shims
+
+	projectRoot := os.Getenv("WINDSOR_PROJECT_ROOT")
+	dir := filepath.Join(projectRoot, ".windsor", "contexts", mocks.Runtime.ContextName, "terraform", "remote", "path")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create directory %s: %v", dir, err)
+	}
+	return stack, mocks
+}
+
+// createDependentBlueprint returns a blueprint whose first-declared component depends on the
+// second, so dependency order differs from declaration order.
+func createDependentBlueprint() *blueprintv1alpha1.Blueprint {
+	bp := createTestBlueprint()
+	bp.TerraformComponents[0].DependsOn = []string{"local/path"}
+	return bp
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestStack_SavePlans(t *testing.T) {
+	t.Run("WritesPlanFilesInDependencyOrder", func(t *testing.T) {
+		// Given a stack whose plans report one addition per component
+		stack, mocks := setupSavedPlanStack(t)
+		mocks.Shell.ExecCaptureWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			return `{"type":"change_summary","changes":{"add":1,"change":0,"remove":0}}`, nil
+		}
+		dir := t.TempDir()
+
+		// When saving plans for a blueprint whose first component depends on the second
+		results, saved, err := stack.SavePlans(createDependentBlueprint(), dir)
+
+		// Then both components are planned and saved with the dependency first
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(saved) != 2 || saved[0].ComponentID != "local/path" || saved[1].ComponentID != "remote/path" {
+			t.Fatalf("Expected local/path then remote/path, got %+v", saved)
+		}
+		if len(results) != 2 || results[0].Add != 1 || !results[0].IsNew {
+			t.Errorf("Expected new components with one addition, got %+v", results)
+		}
+		for _, plan := range saved {
+			if plan.PlanFile != plan.ComponentID+".tfplan" || plan.InputsDigest == "" || plan.SourceDigest == "" {
+				t.Errorf("Expected plan file and digests for %s, got %+v", plan.ComponentID, plan)
+			}
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(plan.PlanFile))); err != nil {
+				t.Errorf("Expected saved plan file for %s: %v", plan.ComponentID, err)
+			}
+		}
+	})
+
+	t.Run("PlanErrorStopsSaving", func(t *testing.T) {
+		// Given a stack whose terraform plan fails
+		stack, mocks := setupSavedPlanStack(t)
+		mocks.Shell.ExecCaptureWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) > 1 && args[1] == "plan" {
+				return "", os.ErrPermission
+			}
+			return mocks.Shell.ExecSilentWithEnv(command, env, args...)
+		}
+
+		// When saving plans
+		_, _, err := stack.SavePlans(createTestBlueprint(), t.TempDir())
+
+		// Then the plan error is returned
+		if err == nil || !strings.Contains(err.Error(), "error running terraform plan") {
+			t.Errorf("Expected plan error, got %v", err)
+		}
+	})
+
+	t.Run("RefusesInputsKnownAfterApply", func(t *testing.T) {
+		// Given a component reading outputs of a dependency whose plan has pending changes
+		stack, mocks := setupSavedPlanStack(t)
+		mocks.Shell.ExecCaptureWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			return `{"type":"change_summary","changes":{"add":1,"change":0,"remove":0}}`, nil
+		}
+		blueprint := createDependentBlueprint()
+		blueprint.TerraformComponents[0].Inputs = map[string]any{"vpc": `terraform_output("local/path", "vpc_id")`}
+
+		// When saving plans
+		_, saved, err := stack.SavePlans(blueprint, t.TempDir())
+
+		// Then saving is refused and names the consumer
+		if err == nil || !strings.Contains(err.Error(), "inputs of remote/path read outputs of components with pending changes") {
+			t.Errorf("Expected known-after-apply error, got %v", err)
+		}
+		if saved != nil {
+			t.Errorf("Expected no saved plans, got %+v", saved)
+		}
+	})
+
+	t.Run("MissingDirectory", func(t *testing.T) {
+		// Given a stack
+		stack, _ := setupSavedPlanStack(t)
+
+		// When saving plans without a directory
+		_, _, err := stack.SavePlans(createTestBlueprint(), "")
+
+		// Then an error should occur
+		if err == nil || !strings.Contains(err.Error(), "plan directory not provided") {
+			t.Errorf("Expected plan directory error, got %v", err)
+		}
+	})
+}
+
+func TestStack_ApplySavedPlans(t *testing.T) {
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks, string, []SavedPlan, *[]string) {
+		t.Helper()
+		stack, mocks := setupSavedPlanStack(t)
+		dir := t.TempDir()
+		_, saved, err := stack.SavePlans(createDependentBlueprint(), dir)
+		if err != nil {
+			t.Fatalf("Failed to save plans: %v", err)
+		}
+		var applied []string
+		mocks.Shell.ExecProgressWithEnvFunc = func(message string, command string, env map[string]string, args ...string) (string, error) {
+			if len(args) >= 2 && args[1] == "apply" {
+				applied = append(applied, args[len(args)-1])
+			}
+			return "", nil
+		}
+		return stack, mocks, dir, saved, &applied
+	}
+
+	t.Run("AppliesStagedPlansInDependencyOrder", func(t *testing.T) {
+		// Given plans saved for a blueprint whose first component depends on the second
+		stack, _, dir, saved, applied := setup(t)
+
+		// When applying the saved plans
+		err := stack.ApplySavedPlans(createDependentBlueprint(), dir, saved)
+
+		// Then each component applies its staged plan, dependency first
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(*applied) != 2 {
+			t.Fatalf("Expected two applies, got %v", *applied)
+		}
+		for i, id := range []string{"local/path", "remote/path"} {
+			staged, err := os.ReadFile((*applied)[i])
+			if err != nil {
+				t.Fatalf("Expected staged plan at %s: %v", (*applied)[i], err)
+			}
+			original, _ := os.ReadFile(filepath.Join(dir, filepath.FromSlash(id+".tfplan")))
+			if string(staged) != string(original) {
+				t.Errorf("Expected staged plan for %s to match the saved plan, got %q", id, staged)
+			}
+		}
+	})
+
+	t.Run("RefusesChangedInputs", func(t *testing.T) {
+		// Given saved plans and a blueprint whose inputs changed since
+		stack, _, dir, saved, applied := setup(t)
+		bp := createDependentBlueprint()
+		bp.TerraformComponents[1].Inputs["local_variable1"] = "changed"
+
+		// When applying the saved plans
+		err := stack.ApplySavedPlans(bp, dir, saved)
+
+		// Then nothing is applied and the changed component is named
+		if err == nil || !strings.Contains(err.Error(), `inputs of terraform component "local/path" changed`) {
+			t.Errorf("Expected changed inputs error, got %v", err)
+		}
+		if len(*applied) != 0 {
+			t.Errorf("Expected no applies, got %v", *applied)
+		}
+	})
+
+	t.Run("RefusesChangedModuleSource", func(t *testing.T) {
+		// Given saved plans and a module file added since
+		stack, _, dir, saved, applied := setup(t)
+		moduleDir := filepath.Join(stack.runtime.ProjectRoot, "terraform", "local", "path")
+		if err := os.WriteFile(filepath.Join(moduleDir, "main.tf"), []byte(`resource "null_resource" "a" {}`), 0o644); err != nil {
+			t.Fatalf("Failed to write module file: %v", err)
+		}
+
+		// When applying the saved plans
+		err := stack.ApplySavedPlans(createDependentBlueprint(), dir, saved)
+
+		// Then nothing is applied and the changed component is named
+		if err == nil || !strings.Contains(err.Error(), `module source of terraform component "local/path" changed`) {
+			t.Errorf("Expected changed source error, got %v", err)
+		}
+		if len(*applied) != 0 {
+			t.Errorf("Expected no applies, got %v", *applied)
+		}
+	})
+
+	t.Run("IgnoresBackendOverride", func(t *testing.T) {
+		// Given saved plans and a generated backend override written since
+		stack, _, dir, saved, _ := setup(t)
+		moduleDir := filepath.Join(stack.runtime.ProjectRoot, "terraform", "local", "path")
+		if err := os.WriteFile(filepath.Join(moduleDir, "backend_override.tf"), []byte(`terraform {}`), 0o644); err != nil {
+			t.Fatalf("Failed to write backend override: %v", err)
+		}
+
+		// When applying the saved plans
+		err := stack.ApplySavedPlans(createDependentBlueprint(), dir, saved)
+
+		// Then the override does not count as a module change
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("RefusesComponentWithoutPlan", func(t *testing.T) {
+		// Given saved plans missing one component
+		stack, _, dir, saved, applied := setup(t)
+
+		// When applying only the first saved plan
+		err := stack.ApplySavedPlans(createDependentBlueprint(), dir, saved[:1])
+
+		// Then nothing is applied and the unplanned component is named
+		if err == nil || !strings.Contains(err.Error(), `no saved plan for terraform component "remote/path"`) {
+			t.Errorf("Expected missing plan error, got %v", err)
+		}
+		if len(*applied) != 0 {
+			t.Errorf("Expected no applies, got %v", *applied)
+		}
+	})
+
+	t.Run("RefusesPlanForRemovedComponent", func(t *testing.T) {
+		// Given saved plans and a blueprint that dropped a component
+		stack, _, dir, saved, _ := setup(t)
+		bp := createDependentBlueprint()
+		bp.TerraformComponents = bp.TerraformComponents[1:]
+
+		// When applying the saved plans
+		err := stack.ApplySavedPlans(bp, dir, saved)
+
+		// Then the stale plan is reported
+		if err == nil || !strings.Contains(err.Error(), `saved plan for terraform component "remote/path" does not match`) {
+			t.Errorf("Expected removed component error, got %v", err)
+		}
+	})
+
+	t.Run("CheckErrorAbortsBeforeApply", func(t *testing.T) {
+		// Given saved plans and a plan check that rejects every plan
+		stack, mocks, dir, saved, applied := setup(t)
+		mocks.Shell.ExecCaptureWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			return `{"resource_changes":[]}`, nil
+		}
+		stack.SetPlanCheck(func(componentID string, changes []ResourceChange) error {
+			return os.ErrPermission
+		})
+
+		// When applying the saved plans
+		err := stack.ApplySavedPlans(createDependentBlueprint(), dir, saved)
+
+		// Then the check error is returned and nothing is applied
+		if err == nil || !strings.Contains(err.Error(), "plan check failed for local/path") {
+			t.Errorf("Expected plan check error, got %v", err)
+		}
+		if len(*applied) != 0 {
+			t.Errorf("Expected no applies, got %v", *applied)
+		}
+	})
+
+	t.Run("NilBlueprint", func(t *testing.T) {
+		// Given a stack
+		stack, _ := setupSavedPlanStack(t)
+
+		// When applying saved plans with a nil blueprint
+		err := stack.ApplySavedPlans(nil, t.TempDir(), nil)
+
+		// Then an error should occur
+		if err == nil || !strings.Contains(err.Error(), "blueprint not provided") {
+			t.Errorf("Expected blueprint not provided error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,635 @@
+package terraform
+
+// The ComponentScheduler is a dependency-aware executor for terraform component applies and plans.
+// It provides the DAG that Up and the multi-component plan paths walk when more than one
+// component may run at once, built from TerraformComponent.DependsOn, terraform_output()
+// references in component inputs, and the blueprint's backend tier, so independent
+// components run concurrently while every consumer still starts only after its producers.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	"github.com/windsorcli/cli/pkg/tui"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// terraformOutputRefPattern matches the component argument of a terraform_output() call
+// inside an input expression. Only literal string arguments are recognised; a computed
+// component name cannot be resolved before evaluation and is left to DependsOn.
+var terraformOutputRefPattern = regexp.MustCompile(`terraform_output\(\s*["']([^"']+)["']`)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// componentGraph is the apply-order DAG over a resolved component list. order preserves
+// blueprint declaration order and is the tie-breaker whenever several components become
+// ready at once, so runs are deterministic. deps maps a component ID to the IDs it must
+// wait for; dependents is the reverse edge set used to release components as their
+// producers finish. Edges to components outside the list (disabled, or filtered out by
+// the caller) are dropped — those components are not part of this run.
+type componentGraph struct {
+	order      []string
+	deps       map[string][]string
+	dependents map[string][]string
+}
+
+// UpOutcome reports what a concurrent Up pass did with each component. Applied lists
+// components whose apply completed, in completion order; Failed carries the per-component
+// errors; HookFailures carries the errors of onApply or PostApply hooks that failed after
+// their component applied, so those components are in Applied too; Skipped lists components
+// that never started because an upstream component failed, a hook failed, or an onApply
+// hook halted the run, in blueprint order.
+type UpOutcome struct {
+	Applied      []string
+	Failed       []ComponentFailure
+	HookFailures []ComponentFailure
+	Skipped      []string
+}
+
+// UpError is returned by a concurrent Up pass when one or more components or post-apply
+// hooks failed. It carries the full outcome so callers can render which components ran,
+// failed, or were skipped, and unwraps to the individual errors for errors.Is/As matching.
+type UpError struct {
+	Outcome UpOutcome
+}
+
+// upResult is the message a worker goroutine sends back to the scheduler loop. hookErr is
+// an onApply hook failure after a successful apply; err is a failure of the apply itself.
+type upResult struct {
+	id      string
+	halted  bool
+	output  string
+	hookErr error
+	err     error
+}
+
+// planResult is the message a plan worker goroutine sends back to the scheduler loop.
+type planResult struct {
+	index int
+	plan  TerraformComponentPlan
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Error renders a one-line summary of the failed pass naming every failed component and
+// failed post-apply hook with its error, followed by the applied and skipped sets so
+// operators know where to resume.
+func (e *UpError) Error() string {
+	var parts []string
+	if len(e.Outcome.Failed) > 0 {
+		parts = append(parts, fmt.Sprintf("terraform apply failed for %d component(s): %s", len(e.Outcome.Failed), joinFailures(e.Outcome.Failed)))
+	}
+	if len(e.Outcome.HookFailures) > 0 {
+		parts = append(parts, fmt.Sprintf("post-apply hook failed for %d applied component(s): %s", len(e.Outcome.HookFailures), joinFailures(e.Outcome.HookFailures)))
+	}
+	msg := strings.Join(parts, "; ")
+	if len(e.Outcome.Applied) > 0 {
+		msg += fmt.Sprintf(" (applied: %s)", strings.Join(e.Outcome.Applied, ", "))
+	}
+	if len(e.Outcome.Skipped) > 0 {
+		msg += fmt.Sprintf(" (skipped: %s)", strings.Join(e.Outcome.Skipped, ", "))
+	}
+	return msg
+}
+
+// Unwrap returns the per-component and hook errors so errors.Is/As see through the aggregate.
+func (e *UpError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Outcome.Failed)+len(e.Outcome.HookFailures))
+	for _, f := range e.Outcome.Failed {
+		errs = append(errs, f.Err)
+	}
+	for _, f := range e.Outcome.HookFailures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// upConcurrent applies components as a DAG with at most s.maxConcurrency applies in flight.
+// The scheduler loop runs on the caller's goroutine and owns all bookkeeping; workers only
+// run applyComponentConcurrent and report back. When a component fails, its in-flight
+// siblings are allowed to finish but nothing downstream of the failure starts; independent
+// branches keep going. When an onApply hook signals halt, no new component starts and the
+// pass returns (true, nil) once in-flight work drains. PostApply hooks run on the scheduler
+// goroutine with the spinner paused, so interactive hooks never race each other or the
+// progress line. A failed onApply or PostApply hook leaves its component applied, with its
+// outputs cached, and is recorded in HookFailures; no new component starts after one, and
+// the pass returns an *UpError carrying the outcome once in-flight work drains. The whole pass renders as one progress line whose suffix tracks the
+// in-flight set; per-component terraform output is captured rather than streamed so
+// concurrent applies cannot interleave on the terminal, and a failed component's captured
+// plan and apply output is written to s.warningWriter as one block, spinner paused, when
+// its failure is recorded.
+func (s *TerraformStack) upConcurrent(blueprint *blueprintv1alpha1.Blueprint, components []blueprintv1alpha1.TerraformComponent, postApply []func(id string) error, onApply []func(id string) (bool, error), backendOverridePaths *[]string) (bool, error) {
+	graph, err := buildComponentGraph(blueprint, components)
+	if err != nil {
+		return false, err
+	}
+
+	byID := make(map[string]*blueprintv1alpha1.TerraformComponent, len(components))
+	for i := range components {
+		byID[components[i].GetID()] = &components[i]
+	}
+	position := make(map[string]int, len(graph.order))
+	pending := make(map[string]int, len(graph.order))
+	var ready []string
+	for i, id := range graph.order {
+		position[id] = i
+		pending[id] = len(graph.deps[id])
+		if pending[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	var outcome UpOutcome
+	blocked := make(map[string]bool)
+	finished := make(map[string]bool)
+	running := make(map[string]bool)
+	results := make(chan upResult)
+	halted := false
+
+	label := fmt.Sprintf("Applying %d terraform components", len(components))
+	progressErr := tui.WithProgress(label, func() error {
+		for {
+			for !halted && len(outcome.HookFailures) == 0 && len(running) < s.maxConcurrency && len(ready) > 0 {
+				id := ready[0]
+				ready = ready[1:]
+				running[id] = true
+				go func(component *blueprintv1alpha1.TerraformComponent) {
+					componentHalted, output, hookErr, err := s.applyComponentConcurrent(component, onApply, backendOverridePaths)
+					results <- upResult{id: component.GetID(), halted: componentHalted, output: output, hookErr: hookErr, err: err}
+				}(byID[id])
+			}
+			if len(running) == 0 {
+				break
+			}
+			tui.Update(fmt.Sprintf("%s (%d/%d done, running: %s)", label, len(finished), len(graph.order), strings.Join(sortedByPosition(running, position), ", ")))
+
+			result := <-results
+			delete(running, result.id)
+			finished[result.id] = true
+			if result.err != nil {
+				if trimmed := strings.TrimSpace(result.output); trimmed != "" {
+					tui.Pause()
+					fmt.Fprintf(s.warningWriter, "terraform apply output for %s:\n%s\n", result.id, trimmed)
+					tui.Resume()
+				}
+				outcome.Failed = append(outcome.Failed, ComponentFailure{ID: result.id, Err: result.err})
+				markDownstreamBlocked(graph, result.id, blocked)
+				continue
+			}
+			outcome.Applied = append(outcome.Applied, result.id)
+			if result.halted {
+				halted = true
+			}
+			if result.hookErr != nil {
+				outcome.HookFailures = append(outcome.HookFailures, ComponentFailure{ID: result.id, Err: result.hookErr})
+			}
+
+			if len(postApply) > 0 && len(outcome.HookFailures) == 0 {
+				tui.Pause()
+				for _, fn := range postApply {
+					if fn == nil {
+						continue
+					}
+					if err := fn(result.id); err != nil {
+						outcome.HookFailures = append(outcome.HookFailures, ComponentFailure{ID: result.id, Err: err})
+						break
+					}
+				}
+				tui.Resume()
+			}
+
+			for _, dependent := range graph.dependents[result.id] {
+				pending[dependent]--
+				if pending[dependent] == 0 && !blocked[dependent] {
+					ready = append(ready, dependent)
+				}
+			}
+			slices.SortFunc(ready, func(a, b string) int { return position[a] - position[b] })
+		}
+
+		for _, id := range graph.order {
+			if !finished[id] {
+				outcome.Skipped = append(outcome.Skipped, id)
+			}
+		}
+		if len(outcome.Failed) > 0 || len(outcome.HookFailures) > 0 {
+			return &UpError{Outcome: outcome}
+		}
+		return nil
+	})
+	if progressErr != nil {
+		return false, progressErr
+	}
+	return halted, nil
+}
+
+// applyComponentConcurrent runs init, refresh, plan, and apply for one component from an
+// Up worker goroutine. The terraform invocations themselves run unlocked; every step that
+// touches shared runtime state — environment setup (which may evaluate terraform_output()
+// and temporarily mutate process env), backend override bookkeeping, the plan check, output
+// caching, and onApply hooks — runs under s.envMu. The apply runs through ExecSilentWithEnv rather than
+// ExecProgressWithEnv because the progress variant drives the single shared spinner. The
+// captured plan and apply output is returned alongside the error so the scheduler can show
+// what terraform reported for a failed component. An onApply hook failure is returned as
+// hookErr with a nil err, since the component itself applied and its outputs are cached.
+func (s *TerraformStack) applyComponentConcurrent(component *blueprintv1alpha1.TerraformComponent, onApply []func(id string) (bool, error), backendOverridePaths *[]string) (bool, string, error, error) {
+	if _, err := s.shims.Stat(component.FullPath); os.IsNotExist(err) {
+		return false, "", nil, fmt.Errorf("directory %s does not exist", component.FullPath)
+	}
+
+	s.envMu.Lock()
+	terraformVars, scopedKeys, terraformArgs, err := s.setupTerraformEnvironment(*component)
+	backendOverridePath := filepath.Join(component.FullPath, "backend_override.tf")
+	if _, statErr := s.shims.Stat(backendOverridePath); statErr == nil {
+		*backendOverridePaths = append(*backendOverridePaths, backendOverridePath)
+	}
+	s.envMu.Unlock()
+	if err != nil {
+		return false, "", nil, err
+	}
+	terraformVars["TF_VAR_operation"] = "apply"
+
+	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+		return false, "", nil, err
+	}
+
+	if err := s.refreshIfStateNonEmpty(component, terraformVars, scopedKeys, terraformArgs); err != nil {
+		return false, "", nil, err
+	}
+
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	planArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "plan", "-refresh=false"}
+	planArgs = append(planArgs, terraformArgs.PlanArgs...)
+	planEnv := selectTerraformCommandEnv(terraformVars, true, scopedKeys)
+	planOutput, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, planEnv, planArgs...)
+	if err != nil {
+		return false, planOutput, nil, fmt.Errorf("error running terraform plan for %s: %w", component.Path, err)
+	}
+
+	if err := s.checkPlan(component, terraformVars, scopedKeys, terraformArgs); err != nil {
+		return false, planOutput, nil, err
+	}
+
+	applyArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "apply"}
+	applyArgs = append(applyArgs, terraformArgs.ApplyArgs...)
+	applyEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
+	applyOutput, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, applyEnv, applyArgs...)
+	if err != nil {
+		return false, planOutput + applyOutput, nil, fmt.Errorf("error running terraform apply for %s: %w", component.Path, err)
+	}
+
+	s.envMu.Lock()
+	defer s.envMu.Unlock()
+	componentID := component.GetID()
+	_ = s.runtime.TerraformProvider.CacheOutputs(componentID)
+	halted := false
+	for _, fn := range onApply {
+		if fn == nil {
+			continue
+		}
+		haltAfter, err := fn(componentID)
+		if err != nil {
+			return halted, "", err, nil
+		}
+		if haltAfter {
+			halted = true
+		}
+	}
+	return halted, "", nil, nil
+}
+
+// planConcurrent plans components as a DAG with at most s.maxConcurrency plans in flight and
+// returns one result per component, indexed like components. run plans the component at the
+// given index and is called from a worker goroutine; the shared-state steps it performs go
+// through prepareComponentEnv, which serialises them on envMu. Consumers wait for their
+// producers to finish planning, because evaluating terraform_output() against a producer
+// writes and removes that producer's backend override. emit, when non-nil, is called on the
+// caller's goroutine for each finished component in blueprint order, as soon as it and every
+// component before it have finished, so callers can flush captured output as whole blocks.
+// When stopOnError is true no new component starts after the first failure and components
+// that never started are left as zero values and are not emitted. Returns an error only when
+// the graph has a cycle, before anything runs.
+func (s *TerraformStack) planConcurrent(blueprint *blueprintv1alpha1.Blueprint, components []blueprintv1alpha1.TerraformComponent, stopOnError bool, run func(i int) TerraformComponentPlan, emit func(i int, result TerraformComponentPlan)) ([]TerraformComponentPlan, error) {
+	graph, err := buildComponentGraph(blueprint, components)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(graph.order))
+	pending := make(map[string]int, len(graph.order))
+	var ready []int
+	for i, id := range graph.order {
+		index[id] = i
+		pending[id] = len(graph.deps[id])
+		if pending[id] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	results := make([]TerraformComponentPlan, len(components))
+	finished := make([]bool, len(components))
+	messages := make(chan planResult)
+	running := 0
+	failed := false
+	next := 0
+
+	for {
+		for !(stopOnError && failed) && running < s.maxConcurrency && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			running++
+			go func(i int) {
+				messages <- planResult{index: i, plan: run(i)}
+			}(i)
+		}
+		if running == 0 {
+			break
+		}
+
+		message := <-messages
+		running--
+		results[message.index] = message.plan
+		finished[message.index] = true
+		if message.plan.Err != nil {
+			failed = true
+		}
+
+		for _, dependent := range graph.dependents[graph.order[message.index]] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, index[dependent])
+			}
+		}
+		slices.Sort(ready)
+
+		for next < len(components) && finished[next] {
+			if emit != nil {
+				emit(next, results[next])
+			}
+			next++
+		}
+	}
+
+	for ; next < len(components); next++ {
+		if finished[next] && emit != nil {
+			emit(next, results[next])
+		}
+	}
+	return results, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// buildComponentGraph derives the apply DAG for components. Edges come from three places:
+// explicit DependsOn entries; terraform_output("<id>", ...) references anywhere in a
+// component's inputs, since a consumer evaluated before its producer has applied would
+// silently fall back to defaults; and the backend tier, whose members apply strictly in
+// declaration order ahead of every non-tier component so the state backend exists before
+// anything that stores state in it. Self-references and edges to components outside the
+// list are ignored. Returns an error naming the components involved when the edges form a
+// cycle, since no apply order exists.
+func buildComponentGraph(blueprint *blueprintv1alpha1.Blueprint, components []blueprintv1alpha1.TerraformComponent) (*componentGraph, error) {
+	graph := &componentGraph{
+		order:      make([]string, 0, len(components)),
+		deps:       make(map[string][]string, len(components)),
+		dependents: make(map[string][]string, len(components)),
+	}
+	present := make(map[string]bool, len(components))
+	for i := range components {
+		id := components[i].GetID()
+		graph.order = append(graph.order, id)
+		present[id] = true
+	}
+
+	addEdge := func(from, to string) {
+		if from == to || !present[to] || slices.Contains(graph.deps[from], to) {
+			return
+		}
+		graph.deps[from] = append(graph.deps[from], to)
+		graph.dependents[to] = append(graph.dependents[to], from)
+	}
+
+	for i := range components {
+		id := components[i].GetID()
+		for _, dep := range components[i].DependsOn {
+			addEdge(id, dep)
+		}
+		for _, producer := range terraformOutputReferences(components[i].Inputs) {
+			addEdge(id, producer)
+		}
+	}
+
+	var tierIDs []string
+	for _, member := range blueprint.BackendTier() {
+		if present[member.GetID()] {
+			tierIDs = append(tierIDs, member.GetID())
+		}
+	}
+	for i := 1; i < len(tierIDs); i++ {
+		addEdge(tierIDs[i], tierIDs[i-1])
+	}
+	if len(tierIDs) > 0 {
+		last := tierIDs[len(tierIDs)-1]
+		for _, id := range graph.order {
+			if !slices.Contains(tierIDs, id) {
+				addEdge(id, last)
+			}
+		}
+	}
+
+	if cycle := graph.findCycle(); len(cycle) > 0 {
+		return nil, fmt.Errorf("terraform components form a dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return graph, nil
+}
+
+// applyOrder returns components reordered so each follows every component it depends on per
+// buildComponentGraph, with declaration order breaking ties. Returns an error when the
+// dependencies form a cycle.
+func applyOrder(blueprint *blueprintv1alpha1.Blueprint, components []blueprintv1alpha1.TerraformComponent) ([]blueprintv1alpha1.TerraformComponent, error) {
+	graph, err := buildComponentGraph(blueprint, components)
+	if err != nil {
+		return nil, err
+	}
+	placed := make([]bool, len(components))
+	done := make(map[string]bool, len(components))
+	ordered := make([]blueprintv1alpha1.TerraformComponent, 0, len(components))
+	for len(ordered) < len(components) {
+		next := -1
+		for i := range components {
+			if placed[i] {
+				continue
+			}
+			ready := true
+			for _, dep := range graph.deps[components[i].GetID()] {
+				if !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("terraform components have no valid apply order")
+		}
+		placed[next] = true
+		done[components[next].GetID()] = true
+		ordered = append(ordered, components[next])
+	}
+	return ordered, nil
+}
+
+// findCycle returns the component IDs along one dependency cycle, closed with its first
+// element repeated at the end, or nil when the graph is acyclic. Walks in declaration order
+// so the reported cycle is stable across runs.
+func (g *componentGraph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(g.order))
+	var stack []string
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, dep := range g.deps[id] {
+			switch state[dep] {
+			case visiting:
+				start := slices.Index(stack, dep)
+				cycle := slices.Clone(stack[start:])
+				return append(cycle, dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+		return nil
+	}
+	for _, id := range g.order {
+		if state[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// terraformOutputReferences returns the distinct component IDs named by terraform_output()
+// calls anywhere in inputs, walking nested maps and slices. Order follows first appearance
+// within sorted top-level keys so the result is deterministic.
+func terraformOutputReferences(inputs map[string]any) []string {
+	var refs []string
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case string:
+			for _, match := range terraformOutputRefPattern.FindAllStringSubmatch(v, -1) {
+				if !slices.Contains(refs, match[1]) {
+					refs = append(refs, match[1])
+				}
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				walk(v[key])
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(map[string]any(inputs))
+	return refs
+}
+
+// markKnownAfterApply sets KnownAfterApply on each result whose component reads
+// terraform_output() from a producer whose own result in the same pass is new, has pending
+// changes, or failed. results must be indexed like components.
+func markKnownAfterApply(components []blueprintv1alpha1.TerraformComponent, results []TerraformComponentPlan) {
+	byID := make(map[string]*TerraformComponentPlan, len(results))
+	for i := range results {
+		byID[results[i].ComponentID] = &results[i]
+	}
+	for i := range components {
+		for _, producer := range terraformOutputReferences(components[i].Inputs) {
+			p, ok := byID[producer]
+			if !ok || producer == components[i].GetID() {
+				continue
+			}
+			if p.IsNew || p.Err != nil || p.Add+p.Change+p.Destroy > 0 {
+				results[i].KnownAfterApply = true
+				break
+			}
+		}
+	}
+}
+
+// joinFailures renders failures as "id: err" pairs separated by semicolons.
+func joinFailures(failures []ComponentFailure) string {
+	parts := make([]string, 0, len(failures))
+	for _, f := range failures {
+		parts = append(parts, fmt.Sprintf("%s: %v", f.ID, f.Err))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// markDownstreamBlocked marks every component transitively depending on id as blocked so
+// the scheduler never releases it, even if its other producers later succeed.
+func markDownstreamBlocked(graph *componentGraph, id string, blocked map[string]bool) {
+	for _, dependent := range graph.dependents[id] {
+		if blocked[dependent] {
+			continue
+		}
+		blocked[dependent] = true
+		markDownstreamBlocked(graph, dependent, blocked)
+	}
+}
+
+// sortedByPosition returns the keys of set ordered by their blueprint position.
+func sortedByPosition(set map[string]bool, position map[string]int) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b string) int { return position[a] - position[b] })
+	return ids
+}
+
+// =============================================================================
+// Interface Compliance
+// =============================================================================
+
+// Ensure UpError implements the error interface
+var _ error = (*UpError)(nil)
@@ -0,0 +1,461 @@
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	terraformRuntime "github.com/windsorcli/cli/pkg/runtime/terraform"
+)
+
+// =============================================================================
+// Test Setup
+// =============================================================================
+
+// setupConcurrentStack builds a stack configured for concurrent runs over the given
+// components, creates each component's scratch directory, and records the component ID of
+// every terraform apply in completion order. failIDs names components whose apply fails
+// after reporting "<id>: creating resources" on stdout.
+func setupConcurrentStack(t *testing.T, components []blueprintv1alpha1.TerraformComponent, failIDs ...string) (*TerraformStack, *blueprintv1alpha1.Blueprint, func() []string) {
+	t.Helper()
+	mocks := setupWindsorStackMocks(t)
+	stack := NewStack(mocks.Runtime).(*TerraformStack)
+	stack.shims = mocks.Shims
+	stack.SetMaxConcurrency(4)
+
+	projectRoot := os.Getenv("WINDSOR_PROJECT_ROOT")
+	for _, c := range components {
+		dir := filepath.Join(projectRoot, ".windsor", "contexts", mocks.Runtime.ContextName, "terraform", c.GetID())
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+	}
+
+	mocks.Runtime.TerraformProvider = &terraformRuntime.MockTerraformProvider{
+		GetEnvVarsFunc: func(componentID string, interactive bool) (map[string]string, []string, *terraformRuntime.TerraformArgs, error) {
+			return map[string]string{}, nil, &terraformRuntime.TerraformArgs{}, nil
+		},
+	}
+
+	var mu sync.Mutex
+	var applied []string
+	mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+		if len(args) >= 3 && args[1] == "show" && args[2] == "-json" {
+			return `{"values":{"root_module":{"resources":[]}}}`, nil
+		}
+		if len(args) >= 2 && args[1] == "apply" {
+			id := filepath.Base(strings.TrimPrefix(args[0], "-chdir="))
+			if slices.Contains(failIDs, id) {
+				return id + ": creating resources\n", fmt.Errorf("apply failed for %s", id)
+			}
+			mu.Lock()
+			applied = append(applied, id)
+			mu.Unlock()
+		}
+		return "", nil
+	}
+
+	blueprint := &blueprintv1alpha1.Blueprint{TerraformComponents: components}
+	return stack, blueprint, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(applied)
+	}
+}
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestStack_UpConcurrent(t *testing.T) {
+	t.Run("AppliesProducersBeforeConsumers", func(t *testing.T) {
+		// Given a consumer that depends on one producer and reads another's outputs
+		stack, blueprint, applied := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "consumer", Path: "consumer", DependsOn: []string{"network"}, Inputs: map[string]any{
+				"zone": `${terraform_output("dns", "zone_id")}`,
+			}},
+			{Name: "network", Path: "network"},
+			{Name: "dns", Path: "dns"},
+		})
+
+		// When Up runs concurrently
+		halted, err := stack.Up(blueprint)
+
+		// Then every component applies and the consumer applies last
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if halted {
+			t.Error("Expected halted=false")
+		}
+		order := applied()
+		if len(order) != 3 || order[2] != "consumer" {
+			t.Errorf("Expected consumer to apply after network and dns, got %v", order)
+		}
+	})
+
+	t.Run("FailureSkipsDownstreamAndContinuesIndependentBranches", func(t *testing.T) {
+		// Given a failing component with a dependent and an unrelated sibling
+		stack, blueprint, applied := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "network", Path: "network"},
+			{Name: "cluster", Path: "cluster", DependsOn: []string{"network"}},
+			{Name: "registry", Path: "registry"},
+		}, "network")
+
+		// When Up runs concurrently
+		_, err := stack.Up(blueprint)
+
+		// Then the error reports the failure, skips the dependent, and keeps the sibling
+		var upErr *UpError
+		if !errors.As(err, &upErr) {
+			t.Fatalf("Expected *UpError, got %v", err)
+		}
+		if len(upErr.Outcome.Failed) != 1 || upErr.Outcome.Failed[0].ID != "network" {
+			t.Errorf("Expected network to fail, got %v", upErr.Outcome.Failed)
+		}
+		if !slices.Equal(upErr.Outcome.Skipped, []string{"cluster"}) {
+			t.Errorf("Expected cluster to be skipped, got %v", upErr.Outcome.Skipped)
+		}
+		if !slices.Equal(applied(), []string{"registry"}) {
+			t.Errorf("Expected only registry to apply, got %v", applied())
+		}
+		if !strings.Contains(err.Error(), "skipped: cluster") {
+			t.Errorf("Expected error summary to name skipped components, got %q", err.Error())
+		}
+	})
+
+	t.Run("FailureWritesCapturedApplyOutput", func(t *testing.T) {
+		// Given a failing component and a sibling that applies cleanly
+		stack, blueprint, _ := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "network", Path: "network"},
+			{Name: "registry", Path: "registry"},
+		}, "network")
+		var warnings bytes.Buffer
+		stack.warningWriter = &warnings
+
+		// When Up runs concurrently
+		if _, err := stack.Up(blueprint); err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+
+		// Then only the failed component's captured apply output is written
+		if !strings.Contains(warnings.String(), "terraform apply output for network:\nnetwork: creating resources") {
+			t.Errorf("Expected network's apply output, got %q", warnings.String())
+		}
+		if strings.Contains(warnings.String(), "registry") {
+			t.Errorf("Expected no output for the successful component, got %q", warnings.String())
+		}
+	})
+
+	t.Run("HaltStopsSchedulingNewComponents", func(t *testing.T) {
+		// Given a chain where the first component's hook signals halt
+		stack, blueprint, applied := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "workstation", Path: "workstation"},
+			{Name: "cluster", Path: "cluster", DependsOn: []string{"workstation"}},
+		})
+		hook := func(id string) (bool, error) { return id == "workstation", nil }
+
+		// When Up runs concurrently
+		halted, err := stack.Up(blueprint, hook)
+
+		// Then the run halts cleanly after the first component
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !halted {
+			t.Error("Expected halted=true")
+		}
+		if !slices.Equal(applied(), []string{"workstation"}) {
+			t.Errorf("Expected only workstation to apply, got %v", applied())
+		}
+	})
+
+	t.Run("OnApplyHookFailureKeepsComponentApplied", func(t *testing.T) {
+		// Given a chain whose first component's onApply hook fails after terraform applied it
+		stack, blueprint, applied := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "network", Path: "network"},
+			{Name: "cluster", Path: "cluster", DependsOn: []string{"network"}},
+		})
+		var cached []string
+		stack.runtime.TerraformProvider.(*terraformRuntime.MockTerraformProvider).CacheOutputsFunc = func(componentID string) error {
+			cached = append(cached, componentID)
+			return nil
+		}
+		hook := func(id string) (bool, error) { return false, fmt.Errorf("configure network failed") }
+
+		// When Up runs concurrently
+		_, err := stack.Up(blueprint, hook)
+
+		// Then network is reported applied with its outputs cached and its hook failure separate
+		var upErr *UpError
+		if !errors.As(err, &upErr) {
+			t.Fatalf("Expected *UpError, got %v", err)
+		}
+		if len(upErr.Outcome.Failed) != 0 {
+			t.Errorf("Expected no failed components, got %v", upErr.Outcome.Failed)
+		}
+		if !slices.Equal(upErr.Outcome.Applied, []string{"network"}) || !slices.Equal(cached, []string{"network"}) {
+			t.Errorf("Expected network applied with outputs cached, got applied %v, cached %v", upErr.Outcome.Applied, cached)
+		}
+		if len(upErr.Outcome.HookFailures) != 1 || upErr.Outcome.HookFailures[0].ID != "network" {
+			t.Errorf("Expected network's hook failure, got %v", upErr.Outcome.HookFailures)
+		}
+		if !slices.Equal(upErr.Outcome.Skipped, []string{"cluster"}) || !slices.Equal(applied(), []string{"network"}) {
+			t.Errorf("Expected cluster not to start after the hook failure, got skipped %v, applied %v", upErr.Outcome.Skipped, applied())
+		}
+		if !strings.Contains(err.Error(), "post-apply hook failed for 1 applied component(s): network: configure network failed") {
+			t.Errorf("Expected the hook failure in the summary, got %q", err.Error())
+		}
+	})
+
+	t.Run("PostApplyHookFailureReturnsOutcome", func(t *testing.T) {
+		// Given a chain with a PostApply hook that fails for the first component
+		stack, blueprint, _ := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "network", Path: "network"},
+			{Name: "cluster", Path: "cluster", DependsOn: []string{"network"}},
+		})
+		stack.PostApply(func(id string) error { return fmt.Errorf("prompt aborted") })
+
+		// When Up runs concurrently
+		_, err := stack.Up(blueprint)
+
+		// Then the error carries the outcome with network applied and cluster skipped
+		var upErr *UpError
+		if !errors.As(err, &upErr) {
+			t.Fatalf("Expected *UpError, got %v", err)
+		}
+		if !slices.Equal(upErr.Outcome.Applied, []string{"network"}) || !slices.Equal(upErr.Outcome.Skipped, []string{"cluster"}) {
+			t.Errorf("Expected network applied and cluster skipped, got %+v", upErr.Outcome)
+		}
+		if len(upErr.Outcome.HookFailures) != 1 || upErr.Outcome.HookFailures[0].ID != "network" {
+			t.Errorf("Expected network's hook failure, got %v", upErr.Outcome.HookFailures)
+		}
+	})
+
+	t.Run("RunsPostApplyHooksForEachComponent", func(t *testing.T) {
+		// Given two independent components and a registered PostApply hook
+		stack, blueprint, _ := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "a", Path: "a"},
+			{Name: "b", Path: "b"},
+		})
+		var seen []string
+		stack.PostApply(func(id string) error {
+			seen = append(seen, id)
+			return nil
+		})
+
+		// When Up runs concurrently
+		if _, err := stack.Up(blueprint); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the hook ran once per component
+		slices.Sort(seen)
+		if !slices.Equal(seen, []string{"a", "b"}) {
+			t.Errorf("Expected post-apply hooks for a and b, got %v", seen)
+		}
+	})
+}
+
+func TestStack_PlanConcurrent(t *testing.T) {
+	t.Run("PlanSummaryReturnsResultsInBlueprintOrder", func(t *testing.T) {
+		// Given a consumer declared first that reads a producer's outputs
+		stack, blueprint, _ := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "cluster", Path: "cluster", Inputs: map[string]any{
+				"vpc_id": `${terraform_output("network", "vpc_id")}`,
+			}},
+			{Name: "network", Path: "network"},
+			{Name: "dns", Path: "dns"},
+		})
+
+		// When the summary is planned concurrently
+		results := stack.PlanSummary(blueprint)
+
+		// Then results follow blueprint order and the consumer of a new producer is provisional
+		ids := make([]string, 0, len(results))
+		for _, r := range results {
+			ids = append(ids, r.ComponentID)
+		}
+		if !slices.Equal(ids, []string{"cluster", "network", "dns"}) {
+			t.Errorf("Expected results in blueprint order, got %v", ids)
+		}
+		if !results[0].KnownAfterApply {
+			t.Error("Expected cluster to be marked KnownAfterApply")
+		}
+		if results[2].KnownAfterApply {
+			t.Error("Expected dns not to be marked KnownAfterApply")
+		}
+	})
+
+	t.Run("StopOnErrorLeavesLaterComponentsUnstarted", func(t *testing.T) {
+		// Given three independent components planned one at a time, the first of which fails
+		stack, blueprint, _ := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "a", Path: "a"},
+			{Name: "b", Path: "b"},
+			{Name: "c", Path: "c"},
+		})
+		stack.maxConcurrency = 1
+		var ran []string
+		run := func(i int) TerraformComponentPlan {
+			id := blueprint.TerraformComponents[i].GetID()
+			ran = append(ran, id)
+			if id == "a" {
+				return TerraformComponentPlan{ComponentID: id, Err: fmt.Errorf("plan failed")}
+			}
+			return TerraformComponentPlan{ComponentID: id}
+		}
+		var emitted []string
+		emit := func(i int, result TerraformComponentPlan) {
+			emitted = append(emitted, result.ComponentID)
+		}
+
+		// When planConcurrent runs with stopOnError
+		_, err := stack.planConcurrent(blueprint, blueprint.TerraformComponents, true, run, emit)
+
+		// Then only the failed component ran and was emitted
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !slices.Equal(ran, []string{"a"}) || !slices.Equal(emitted, []string{"a"}) {
+			t.Errorf("Expected only a to run and emit, got ran=%v emitted=%v", ran, emitted)
+		}
+	})
+
+	t.Run("EmitsInBlueprintOrderAfterProducersFinish", func(t *testing.T) {
+		// Given a consumer declared before its producer
+		stack, blueprint, _ := setupConcurrentStack(t, []blueprintv1alpha1.TerraformComponent{
+			{Name: "cluster", Path: "cluster", DependsOn: []string{"network"}},
+			{Name: "network", Path: "network"},
+		})
+		var mu sync.Mutex
+		var ran []string
+		run := func(i int) TerraformComponentPlan {
+			id := blueprint.TerraformComponents[i].GetID()
+			mu.Lock()
+			ran = append(ran, id)
+			mu.Unlock()
+			return TerraformComponentPlan{ComponentID: id}
+		}
+		var emitted []string
+		emit := func(i int, result TerraformComponentPlan) {
+			emitted = append(emitted, result.ComponentID)
+		}
+
+		// When planConcurrent runs
+		if _, err := stack.planConcurrent(blueprint, blueprint.TerraformComponents, false, run, emit); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the producer plans first but output is emitted in declaration order
+		if !slices.Equal(ran, []string{"network", "cluster"}) {
+			t.Errorf("Expected network to plan before cluster, got %v", ran)
+		}
+		if !slices.Equal(emitted, []string{"cluster", "network"}) {
+			t.Errorf("Expected emission in blueprint order, got %v", emitted)
+		}
+	})
+}
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func TestBuildComponentGraph(t *testing.T) {
+	t.Run("CollectsDependsOnAndTerraformOutputEdges", func(t *testing.T) {
+		// Given components linked by DependsOn and a nested terraform_output reference
+		components := []blueprintv1alpha1.TerraformComponent{
+			{Path: "network"},
+			{Path: "dns"},
+			{Path: "cluster", DependsOn: []string{"network", "disabled"}, Inputs: map[string]any{
+				"settings": map[string]any{"zone": `terraform_output('dns', 'zone')`},
+			}},
+		}
+
+		// When the graph is built
+		graph, err := buildComponentGraph(&blueprintv1alpha1.Blueprint{}, components)
+
+		// Then cluster depends on network and dns, and the unknown dependency is dropped
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !slices.Equal(graph.deps["cluster"], []string{"network", "dns"}) {
+			t.Errorf("Expected cluster deps [network dns], got %v", graph.deps["cluster"])
+		}
+	})
+
+	t.Run("OrdersBackendTierAheadOfEverythingElse", func(t *testing.T) {
+		// Given a blueprint whose backend tier is its first two components
+		components := []blueprintv1alpha1.TerraformComponent{
+			{Path: "bootstrap"},
+			{Path: "backend"},
+			{Path: "network"},
+		}
+		blueprint := &blueprintv1alpha1.Blueprint{Backend: "backend", TerraformComponents: components}
+
+		// When the graph is built
+		graph, err := buildComponentGraph(blueprint, components)
+
+		// Then tier members chain in order and non-members wait on the last tier member
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !slices.Equal(graph.deps["backend"], []string{"bootstrap"}) {
+			t.Errorf("Expected backend to depend on bootstrap, got %v", graph.deps["backend"])
+		}
+		if !slices.Equal(graph.deps["network"], []string{"backend"}) {
+			t.Errorf("Expected network to depend on backend, got %v", graph.deps["network"])
+		}
+	})
+
+	t.Run("RejectsCycles", func(t *testing.T) {
+		// Given two components that depend on each other
+		components := []blueprintv1alpha1.TerraformComponent{
+			{Path: "a", DependsOn: []string{"b"}},
+			{Path: "b", DependsOn: []string{"a"}},
+		}
+
+		// When the graph is built
+		_, err := buildComponentGraph(&blueprintv1alpha1.Blueprint{}, components)
+
+		// Then the cycle is reported
+		if err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+			t.Errorf("Expected cycle error naming a -> b -> a, got %v", err)
+		}
+	})
+}
+
+func TestMarkKnownAfterApply(t *testing.T) {
+	t.Run("FlagsConsumersOfProducersWithPendingChanges", func(t *testing.T) {
+		// Given one consumer of a changing producer and one of an unchanged producer
+		components := []blueprintv1alpha1.TerraformComponent{
+			{Path: "network"},
+			{Path: "dns"},
+			{Path: "cluster", Inputs: map[string]any{"vpc": `terraform_output("network", "vpc_id")`}},
+			{Path: "ingress", Inputs: map[string]any{"zone": `terraform_output("dns", "zone")`}},
+		}
+		results := []TerraformComponentPlan{
+			{ComponentID: "network", Change: 1},
+			{ComponentID: "dns", NoChanges: true},
+			{ComponentID: "cluster"},
+			{ComponentID: "ingress"},
+		}
+
+		// When known-after-apply marking runs
+		markKnownAfterApply(components, results)
+
+		// Then only the consumer of the changing producer is flagged
+		if !results[2].KnownAfterApply {
+			t.Error("Expected cluster to be marked KnownAfterApply")
+		}
+		if results[3].KnownAfterApply {
+			t.Error("Expected ingress not to be marked KnownAfterApply")
+		}
+	})
+}
@@ -25,6 +25,8 @@ type Shims struct {
 	RemoveAll func(string) error
 	WriteFile func(string, []byte, os.FileMode) error
 	ReadFile  func(string) ([]byte, error)
+	MkdirAll  func(string, os.FileMode) error
+	ReadDir   func(string) ([]os.DirEntry, error)
 }
 
 // =============================================================================
@@ -44,5 +46,7 @@ func NewShims() *Shims {
 		RemoveAll: os.RemoveAll,
 		WriteFile: os.WriteFile,
 		ReadFile:  os.ReadFile,
+		MkdirAll:  os.MkdirAll,
+		ReadDir:   os.ReadDir,
 	}
 }
@@ -10,9 +10,11 @@ package terraform
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -57,14 +59,25 @@ var defaultInitFlags = []string{"-upgrade", "-input=false"}
 // Routing warnings through this field rather than os.Stderr directly keeps tests off the
 // fragile os.Stderr-redirect-with-pipe pattern, which deadlocks on Windows when the TUI
 // spinner shares the redirected stream.
+//
+// maxConcurrency caps how many components Up applies, and the multi-component plan paths
+// plan, at once. Values of 1 or less keep the sequential per-component loops; larger values
+// switch to the DAG scheduler in schedule.go. envMu serialises the shared-state steps
+// (environment setup, output caching, hooks) of concurrent applies and plans.
+//
+// planCheck, when set, inspects each component's saved plan before it is applied; see
+// SetPlanCheck.
 type TerraformStack struct {
-	runtime       *runtime.Runtime
-	shims         *Shims
-	terraformEnv  *envvars.TerraformEnvPrinter
-	postApply     []func(id string) error
-	warningWriter io.Writer
-	initCache     map[initCacheKey]struct{}
-	initCacheMu   sync.Mutex
+	runtime        *runtime.Runtime
+	shims          *Shims
+	terraformEnv   *envvars.TerraformEnvPrinter
+	postApply      []func(id string) error
+	warningWriter  io.Writer
+	initCache      map[initCacheKey]struct{}
+	initCacheMu    sync.Mutex
+	maxConcurrency int
+	envMu          sync.Mutex
+	planCheck      func(componentID string, changes []ResourceChange) error
 }
 
 // initCacheKey identifies a previously-completed `terraform init`. Three
@@ -113,6 +126,11 @@ type TerraformComponentPlan struct {
 	NoChanges   bool
 	IsNew       bool
 	Resources   []ResourceChange
+	// KnownAfterApply is true when the component's inputs read terraform_output() from
+	// a producer in the same pass that is new, has pending changes, or failed to plan.
+	// The plan was computed against the producer's current outputs, which will change
+	// once the producer applies, so the counts are provisional.
+	KnownAfterApply bool
 	// Protected lists resource addresses that terraform reports cannot be
 	// destroyed because their config sets `lifecycle { prevent_destroy = true }`.
 	// Populated only on destroy-plan paths; nil on apply-plan paths.
@@ -143,10 +161,23 @@ const (
 // ResourceChange identifies one resource changed by a plan along with its action.
 // Address is the terraform resource address with the leading "module.main." wrapper
 // stripped, since every Windsor component wraps its resources in a single module
-// and the prefix would otherwise be repeated noise on every line.
+// and the prefix would otherwise be repeated noise on every line. Type is the
+// resource type (e.g. "aws_s3_bucket") when terraform reported it. Values is only
+// populated while a plan check is registered (see SetPlanCheck), because reading the
+// attribute values costs an extra `terraform show` per component.
 type ResourceChange struct {
 	Address string
 	Action  Action
+	Type    string
+	Values  *ResourceValues
+}
+
+// ResourceValues holds a planned resource's attribute values before and after the
+// change, as decoded from `terraform show -json`. Before is nil for a create and After
+// is nil for a delete.
+type ResourceValues struct {
+	Before any
+	After  any
 }
 
 // tfStateModule is a node in the module tree emitted by `terraform show -json`. Windsor
@@ -205,17 +236,26 @@ type Stack interface {
 	InitComponent(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
 	RemoveLocalState(componentID string) error
 	PostApply(fns ...func(id string) error)
+	SetMaxConcurrency(n int)
+	SetPlanCheck(fn func(componentID string, changes []ResourceChange) error)
 	DestroyAll(blueprint *blueprintv1alpha1.Blueprint, continueOnError bool, excludeIDs ...string) (DestroyOutcome, error)
 	Plan(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
 	PlanAll(blueprint *blueprintv1alpha1.Blueprint) error
 	PlanJSON(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
 	PlanAllJSON(blueprint *blueprintv1alpha1.Blueprint) error
 	Apply(blueprint *blueprintv1alpha1.Blueprint, componentID string) error
+	ApplyWithOptions(blueprint *blueprintv1alpha1.Blueprint, componentID string, opts ApplyOptions) error
 	Destroy(blueprint *blueprintv1alpha1.Blueprint, componentID string) (bool, error)
 	PlanSummary(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan
 	PlanComponentSummary(blueprint *blueprintv1alpha1.Blueprint, componentID string) TerraformComponentPlan
 	PlanDestroySummary(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan
 	PlanDestroyComponentSummary(blueprint *blueprintv1alpha1.Blueprint, componentID string) TerraformComponentPlan
+	DriftSummary(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan
+	State(blueprint *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error)
+	SnapshotState(blueprint *blueprintv1alpha1.Blueprint, componentID string) (string, error)
+	RestoreState(blueprint *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error)
+	SavePlans(blueprint *blueprintv1alpha1.Blueprint, dir string) ([]TerraformComponentPlan, []SavedPlan, error)
+	ApplySavedPlans(blueprint *blueprintv1alpha1.Blueprint, dir string, plans []SavedPlan) error
 }
 
 // =============================================================================
@@ -229,9 +269,10 @@ func NewStack(rt *runtime.Runtime, opts ...*TerraformStack) Stack {
 	}
 
 	stack := &TerraformStack{
-		runtime:       rt,
-		shims:         NewShims(),
-		warningWriter: os.Stderr,
+		runtime:        rt,
+		shims:          NewShims(),
+		warningWriter:  os.Stderr,
+		maxConcurrency: 1,
 	}
 
 	if len(opts) > 0 && opts[0] != nil {
@@ -242,6 +283,9 @@ func NewStack(rt *runtime.Runtime, opts ...*TerraformStack) Stack {
 		if overrides.warningWriter != nil {
 			stack.warningWriter = overrides.warningWriter
 		}
+		if overrides.maxConcurrency > 0 {
+			stack.maxConcurrency = overrides.maxConcurrency
+		}
 	}
 
 	if stack.terraformEnv == nil && rt.EnvPrinters.TerraformEnv != nil {
@@ -263,6 +307,25 @@ func (s *TerraformStack) PostApply(fns ...func(id string) error) {
 	s.postApply = append(s.postApply, fns...)
 }
 
+// SetMaxConcurrency sets how many components Up may apply at once. Values below 1 are
+// clamped to 1, which keeps the sequential per-component loop. Retained across Up calls.
+func (s *TerraformStack) SetMaxConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	s.maxConcurrency = n
+}
+
+// SetPlanCheck registers fn to inspect each component's saved plan before it is applied. Up
+// and Apply read the plan file with `terraform show -json` after planning and pass its
+// resource changes, with their before/after values, to fn; a non-nil error aborts before
+// terraform apply runs. While a check is registered the summary plan paths attach the same
+// values to their resource lists so callers can evaluate what would be applied. fn runs
+// under envMu, so it is never called concurrently. Passing nil removes the check.
+func (s *TerraformStack) SetPlanCheck(fn func(componentID string, changes []ResourceChange) error) {
+	s.planCheck = fn
+}
+
 // Up runs init/plan/apply for each component in order. Backend override files are cleaned up
 // after all components complete so terraform_output() calls between components keep working.
 // onApply hooks run inside each spinner; PostApply hooks run after each Done line and are
@@ -274,6 +337,11 @@ func (s *TerraformStack) PostApply(fns ...func(id string) error) {
 // a clean stop — the TUI marks the component Done, no error is returned, and the caller can
 // surface a "deferred work" summary at the cmd layer. A non-nil err is a real failure as
 // before.
+//
+// When maxConcurrency is greater than 1, components are scheduled as a DAG over DependsOn,
+// terraform_output() references, and the backend tier, with up to maxConcurrency applies in
+// flight; see upConcurrent. A failure there, of a component or of a hook after its component
+// applied, returns an *UpError listing the components that applied, failed, and were skipped.
 func (s *TerraformStack) Up(blueprint *blueprintv1alpha1.Blueprint, onApply ...func(id string) (bool, error)) (bool, error) {
 	if blueprint == nil {
 		return false, fmt.Errorf("blueprint not provided")
@@ -304,6 +372,10 @@ func (s *TerraformStack) Up(blueprint *blueprintv1alpha1.Blueprint, onApply ...f
 		}
 	}()
 
+	if s.maxConcurrency > 1 && len(components) > 1 {
+		return s.upConcurrent(blueprint, components, postApply, onApply, &backendOverridePaths)
+	}
+
 	for _, component := range components {
 		var componentHalted bool
 		if err := tui.WithProgress(fmt.Sprintf("Applying %s", component.Path), func() error {
@@ -337,6 +409,10 @@ func (s *TerraformStack) Up(blueprint *blueprintv1alpha1.Blueprint, onApply ...f
 				return fmt.Errorf("error running terraform plan for %s: %w", component.Path, err)
 			}
 
+			if err := s.checkPlan(&component, terraformVars, scopedKeys, terraformArgs); err != nil {
+				return err
+			}
+
 			applyArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "apply"}
 			applyArgs = append(applyArgs, terraformArgs.ApplyArgs...)
 			applyEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
@@ -561,7 +637,7 @@ func (s *TerraformStack) MigrateComponentState(blueprint *blueprintv1alpha1.Blue
 }
 
 // DestroyAll destroys components in reverse dependency order using the idempotent flow:
-// init → pre-refresh state check → refresh → post-refresh state check → destroy, skipping
+// init → pre-refresh state check → snapshot → refresh → post-refresh state check → destroy, skipping
 // the rest when state is empty at either check. Components with Destroy=false are skipped.
 // excludeIDs are skipped entirely (used by symmetric-destroy flow at the cmd layer to peel
 // off the backend component from the bulk pass — it gets destroyed last, after its state
@@ -652,6 +728,9 @@ func (s *TerraformStack) DestroyAll(blueprint *blueprintv1alpha1.Blueprint, cont
 				componentSkipped = true
 				return nil
 			}
+			if err := s.snapshotBeforeChange(&component, terraformVars, scopedKeys); err != nil {
+				return err
+			}
 
 			// Tolerate refresh failures for non-empty-state components. A transient refresh
 			// issue (network blip, credential rotation, provider API hiccup) must not make a
@@ -816,51 +895,11 @@ func (s *TerraformStack) PlanAllJSON(blueprint *blueprintv1alpha1.Blueprint) err
 // init, plan, then apply in sequence. Returns an error if the component is not found,
 // the directory does not exist, or any terraform operation fails.
 func (s *TerraformStack) Apply(blueprint *blueprintv1alpha1.Blueprint, componentID string) error {
-	if blueprint == nil {
-		return fmt.Errorf("blueprint not provided")
-	}
-	if componentID == "" {
-		return fmt.Errorf("component ID not provided")
-	}
-
-	component, terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentOp(blueprint, componentID)
-	if err != nil {
-		return err
-	}
-	defer cleanup()
-	terraformVars["TF_VAR_operation"] = "apply"
-
-	return tui.WithProgress(fmt.Sprintf("Applying %s", component.Path), func() error {
-		if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
-			return err
-		}
-
-		if err := s.refreshIfStateNonEmpty(component, terraformVars, scopedKeys, terraformArgs); err != nil {
-			return err
-		}
-
-		terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
-		planArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "plan", "-refresh=false"}
-		planArgs = append(planArgs, terraformArgs.PlanArgs...)
-		planEnv := selectTerraformCommandEnv(terraformVars, true, scopedKeys)
-		if _, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, planEnv, planArgs...); err != nil {
-			return fmt.Errorf("error running terraform plan for %s: %w", component.Path, err)
-		}
-
-		applyArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "apply"}
-		applyArgs = append(applyArgs, terraformArgs.ApplyArgs...)
-		applyEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
-		if _, err := s.runtime.Shell.ExecProgressWithEnv(fmt.Sprintf("Applying Terraform changes in %s", component.Path), terraformCommand, applyEnv, applyArgs...); err != nil {
-			return fmt.Errorf("error running terraform apply for %s: %w", component.Path, err)
-		}
-		_ = s.runtime.TerraformProvider.CacheOutputs(component.GetID())
-
-		return nil
-	})
+	return s.ApplyWithOptions(blueprint, componentID, ApplyOptions{})
 }
 
 // Destroy tears down a single component idempotently: init → pre-refresh state check →
-// refresh → post-refresh state check → destroy, skipping the rest when state is empty at
+// snapshot → refresh → post-refresh state check → destroy, skipping the rest when state is empty at
 // either check. Returns (true, nil) when skipped, (false, nil) on success, (false, err) on
 // failure. Destroy-mode config (S3 force_destroy, etc.) is honored via TF_VAR_operation;
 // no prep-apply is run because that could recreate resources refresh just dropped.
@@ -902,6 +941,9 @@ func (s *TerraformStack) Destroy(blueprint *blueprintv1alpha1.Blueprint, compone
 			skipped = true
 			return nil
 		}
+		if err := s.snapshotBeforeChange(component, terraformVars, scopedKeys); err != nil {
+			return err
+		}
 
 		refreshFailed := false
 		if err := s.refreshComponentState(component, terraformVars, scopedKeys, terraformArgs); err != nil {
@@ -949,6 +991,12 @@ func (s *TerraformStack) Destroy(blueprint *blueprintv1alpha1.Blueprint, compone
 // capturing output to parse add/change/destroy counts rather than printing them.
 // Errors are recorded per-component; the summary continues even if a component fails,
 // so callers receive partial results for independent layers. Returns nil if blueprint is nil.
+//
+// When maxConcurrency is greater than 1, components are planned as a DAG (see
+// planConcurrent); a dependency cycle falls back to the sequential loop, since a summary
+// has no error return and declaration order is still a valid plan order. In both modes a
+// component reading terraform_output() from a producer with pending changes is marked
+// KnownAfterApply.
 func (s *TerraformStack) PlanSummary(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan {
 	if blueprint == nil {
 		return nil
@@ -960,10 +1008,21 @@ func (s *TerraformStack) PlanSummary(blueprint *blueprintv1alpha1.Blueprint) []T
 	}
 
 	components := s.resolveTerraformComponents(blueprint, projectRoot)
+	if s.maxConcurrency > 1 && len(components) > 1 {
+		results, err := s.planConcurrent(blueprint, components, false, func(i int) TerraformComponentPlan {
+			return s.planOneTerraformSummary(&components[i])
+		}, nil)
+		if err == nil {
+			markKnownAfterApply(components, results)
+			return results
+		}
+	}
+
 	results := make([]TerraformComponentPlan, 0, len(components))
 	for i := range components {
 		results = append(results, s.planOneTerraformSummary(&components[i]))
 	}
+	markKnownAfterApply(components, results)
 	return results
 }
 
@@ -1036,6 +1095,31 @@ func (s *TerraformStack) PlanDestroyComponentSummary(blueprint *blueprintv1alpha
 	return s.planOneTerraformDestroySummary(component)
 }
 
+// DriftSummary runs terraform init and a refresh-only plan for every enabled component in
+// the blueprint, reporting the resources whose real infrastructure no longer matches state.
+// Drifted resources are listed with the action terraform would record on refresh: updates
+// for attributes changed out of band and deletes for objects removed out of band. IsNew is
+// set when a component has no state, since there is nothing to compare against. As with
+// PlanSummary, errors are recorded per-component so independent layers are still checked.
+// Returns nil if blueprint is nil or projectRoot is unset.
+func (s *TerraformStack) DriftSummary(blueprint *blueprintv1alpha1.Blueprint) []TerraformComponentPlan {
+	if blueprint == nil {
+		return nil
+	}
+
+	projectRoot := s.runtime.ProjectRoot
+	if projectRoot == "" {
+		return nil
+	}
+
+	components := s.resolveTerraformComponents(blueprint, projectRoot)
+	results := make([]TerraformComponentPlan, 0, len(components))
+	for i := range components {
+		results = append(results, s.driftOneTerraformComponent(&components[i]))
+	}
+	return results
+}
+
 // PlanComponentSummary runs terraform init and plan for a single component and returns its
 // structured plan result. It resolves only the requested component from the blueprint,
 // so no other components are initialised or planned. If the component is not found, a
@@ -1076,7 +1160,8 @@ func (s *TerraformStack) PlanComponentSummary(blueprint *blueprintv1alpha1.Bluep
 
 // migrateOneComponent runs `terraform init -migrate-state -force-copy` for a single
 // component, registering any generated backend_override.tf for cleanup via
-// backendOverridePaths. Returns (false, nil) when the component's directory does not
+// backendOverridePaths. The component's local state file is snapshotted first (see
+// snapshotLocalState). Returns (false, nil) when the component's directory does not
 // exist (so callers can decide whether absence is an error or a skip), (true, nil) on
 // success, and (false, err) on any other failure. Shared by MigrateState (which
 // tolerates missing dirs by collecting skipped IDs) and MigrateComponentState (which
@@ -1099,6 +1184,10 @@ func (s *TerraformStack) migrateOneComponent(component *blueprintv1alpha1.Terraf
 		return false, err
 	}
 
+	if err := s.snapshotLocalState(component); err != nil {
+		return false, err
+	}
+
 	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, "-migrate-state", "-force-copy"); err != nil {
 		return false, err
 	}
@@ -1122,6 +1211,11 @@ func (m tfStateModule) hasResources() bool {
 // When jsonMode is true, -json and -no-color are appended to the plan args so that output
 // is machine-readable JSON lines; otherwise human-readable output is streamed. Stops on
 // the first error. Returns an error if blueprint is nil or any component's init or plan fails.
+//
+// When maxConcurrency is greater than 1, components are planned as a DAG (see
+// planConcurrent) and each component's header and captured plan output are written as one
+// block, in blueprint order, once it and every component before it have finished, so
+// concurrent plans never interleave on the terminal and JSON lines stay whole.
 func (s *TerraformStack) planComponents(blueprint *blueprintv1alpha1.Blueprint, jsonMode bool) error {
 	if blueprint == nil {
 		return fmt.Errorf("blueprint not provided")
@@ -1134,34 +1228,37 @@ func (s *TerraformStack) planComponents(blueprint *blueprintv1alpha1.Blueprint,
 
 	components := s.resolveTerraformComponents(blueprint, projectRoot)
 
+	if s.maxConcurrency > 1 && len(components) > 1 {
+		outputs := make([]string, len(components))
+		var firstErr error
+		_, err := s.planConcurrent(blueprint, components, true, func(i int) TerraformComponentPlan {
+			result := TerraformComponentPlan{ComponentID: components[i].GetID(), Path: components[i].Path}
+			outputs[i], result.Err = s.planOneTerraformOutput(&components[i], jsonMode)
+			return result
+		}, func(i int, result TerraformComponentPlan) {
+			fmt.Fprintf(os.Stderr, "\n%s\n", tui.SectionHeader("Terraform: "+components[i].Path))
+			if outputs[i] != "" {
+				fmt.Fprint(os.Stdout, outputs[i])
+			}
+			if result.Err != nil && firstErr == nil {
+				firstErr = result.Err
+			}
+		})
+		if err != nil {
+			return err
+		}
+		return firstErr
+	}
+
 	for i := range components {
 		component := &components[i]
 
 		fmt.Fprintf(os.Stderr, "\n%s\n", tui.SectionHeader("Terraform: "+component.Path))
 
-		terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentEnv(component)
+		planOutput, err := s.planOneTerraformOutput(component, jsonMode)
 		if err != nil {
 			return err
 		}
-		terraformVars["TF_VAR_operation"] = "apply"
-
-		if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
-			cleanup()
-			return err
-		}
-
-		terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
-		planArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "plan"}
-		if jsonMode {
-			planArgs = append(planArgs, "-json", "-no-color")
-		}
-		planArgs = append(planArgs, terraformArgs.PlanArgs...)
-		planEnv := selectTerraformCommandEnv(terraformVars, true, scopedKeys)
-		planOutput, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, planEnv, planArgs...)
-		cleanup()
-		if err != nil {
-			return fmt.Errorf("error running terraform plan for %s: %w", component.Path, err)
-		}
 		if planOutput != "" {
 			fmt.Fprint(os.Stdout, planOutput)
 		}
@@ -1177,8 +1274,11 @@ func (s *TerraformStack) planComponents(blueprint *blueprintv1alpha1.Blueprint,
 // is logged to s.warningWriter and swallowed — the caller's plan/apply will run anyway, and
 // any persistent problem will resurface there with a more actionable message. The state-check
 // itself failing is propagated; callers can't safely proceed without knowing whether state is
-// empty. Used by Up and Apply; Destroy retains its own refresh handling because its fallback
-// (-refresh=true on destroy) is destroy-specific.
+// empty. Non-empty state is snapshotted before the refresh, since refresh is the first step
+// that rewrites it, unless it is unchanged since the newest snapshot; a snapshot failure is
+// propagated like a state-check failure. Used by Up
+// and Apply; Destroy retains its own refresh handling because its fallback (-refresh=true on
+// destroy) is destroy-specific.
 func (s *TerraformStack) refreshIfStateNonEmpty(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string, terraformArgs *envvars.TerraformArgs) error {
 	hasResources, err := s.hasStateResources(component, terraformVars, scopedKeys)
 	if err != nil {
@@ -1187,6 +1287,9 @@ func (s *TerraformStack) refreshIfStateNonEmpty(component *blueprintv1alpha1.Ter
 	if !hasResources {
 		return nil
 	}
+	if err := s.snapshotBeforeChange(component, terraformVars, scopedKeys); err != nil {
+		return err
+	}
 	if err := s.refreshComponentState(component, terraformVars, scopedKeys, terraformArgs); err != nil {
 		fmt.Fprintf(s.warningWriter, "warning: terraform refresh failed for %s; continuing with plan against the last-known state (plan runs with -refresh=false; any persistent state divergence will surface as a plan error): %v\n", component.Path, err)
 	}
@@ -1341,6 +1444,35 @@ func (s *TerraformStack) resolveComponentPaths(blueprint *blueprintv1alpha1.Blue
 	blueprint.TerraformComponents = resolvedComponents
 }
 
+// planOneTerraformOutput runs terraform init and plan for a single component and returns
+// the captured plan output for the caller to print. It is shared by the sequential and
+// concurrent branches of planComponents; jsonMode appends -json and -no-color.
+func (s *TerraformStack) planOneTerraformOutput(component *blueprintv1alpha1.TerraformComponent, jsonMode bool) (string, error) {
+	terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentEnv(component)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	terraformVars["TF_VAR_operation"] = "apply"
+
+	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+		return "", err
+	}
+
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	planArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "plan"}
+	if jsonMode {
+		planArgs = append(planArgs, "-json", "-no-color")
+	}
+	planArgs = append(planArgs, terraformArgs.PlanArgs...)
+	planEnv := selectTerraformCommandEnv(terraformVars, true, scopedKeys)
+	planOutput, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, planEnv, planArgs...)
+	if err != nil {
+		return planOutput, fmt.Errorf("error running terraform plan for %s: %w", component.Path, err)
+	}
+	return planOutput, nil
+}
+
 // planOneTerraformSummary runs terraform init and plan -no-color for a single component
 // and returns its structured result. It is shared by PlanSummary and PlanComponentSummary
 // to avoid duplicating the per-component setup, init, plan, and cleanup logic.
@@ -1390,6 +1522,14 @@ func (s *TerraformStack) planOneTerraformSummary(component *blueprintv1alpha1.Te
 	}
 
 	result.Add, result.Change, result.Destroy, result.NoChanges, result.Resources = parseTerraformPlanJSON(planOutput)
+	if s.planCheck != nil {
+		changes, err := s.showPlanChanges(component, terraformVars, scopedKeys, terraformArgs)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Resources = attachPlanValues(result.Resources, changes)
+	}
 	return result
 }
 
@@ -1463,6 +1603,101 @@ func (s *TerraformStack) planOneTerraformDestroySummary(component *blueprintv1al
 	return result
 }
 
+// driftOneTerraformComponent computes the drift report for one component by running
+// `plan -refresh-only -detailed-exitcode`. Terraform exits 2 when refresh found differences,
+// which is reported as drift rather than as an error. RefreshArgs are used instead of
+// PlanArgs so the saved plan file consumed by apply is never overwritten by a drift check.
+func (s *TerraformStack) driftOneTerraformComponent(component *blueprintv1alpha1.TerraformComponent) TerraformComponentPlan {
+	result := TerraformComponentPlan{ComponentID: component.GetID(), Path: component.Path}
+
+	terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentEnv(component)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer cleanup()
+	terraformVars["TF_VAR_operation"] = "apply"
+
+	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+		result.Err = err
+		return result
+	}
+
+	hasState, err := s.hasStateResources(component, terraformVars, scopedKeys)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if !hasState {
+		result.IsNew = true
+		return result
+	}
+
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	planArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "plan", "-refresh-only", "-detailed-exitcode", "-json", "-no-color"}
+	planArgs = append(planArgs, terraformArgs.RefreshArgs...)
+	planEnv := selectTerraformCommandEnv(terraformVars, true, scopedKeys)
+	planOutput, err := s.runtime.Shell.ExecCaptureWithEnv(terraformCommand, planEnv, planArgs...)
+	drifted := false
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 2 {
+			result.Err = fmt.Errorf("error running terraform plan -refresh-only for %s: %w", component.Path, err)
+			return result
+		}
+		drifted = true
+	}
+
+	result.Resources = parseTerraformDriftJSON(planOutput)
+	for _, r := range result.Resources {
+		switch r.Action {
+		case ActionCreate:
+			result.Add++
+		case ActionDelete:
+			result.Destroy++
+		default:
+			result.Change++
+		}
+	}
+	result.NoChanges = !drifted && len(result.Resources) == 0
+	return result
+}
+
+// checkPlan passes the resource changes in component's saved plan to the registered plan
+// check. It is a no-op when no check is registered. The plan file is read without holding
+// envMu; only the check itself runs under it.
+func (s *TerraformStack) checkPlan(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string, terraformArgs *envvars.TerraformArgs) error {
+	if s.planCheck == nil {
+		return nil
+	}
+	changes, err := s.showPlanChanges(component, terraformVars, scopedKeys, terraformArgs)
+	if err != nil {
+		return err
+	}
+	s.envMu.Lock()
+	defer s.envMu.Unlock()
+	if err := s.planCheck(component.GetID(), changes); err != nil {
+		return fmt.Errorf("plan check failed for %s: %w", component.Path, err)
+	}
+	return nil
+}
+
+// showPlanChanges reads the plan file written by the last `terraform plan` for component
+// and returns its resource changes with before/after values.
+func (s *TerraformStack) showPlanChanges(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string, terraformArgs *envvars.TerraformArgs) ([]ResourceChange, error) {
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	showArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "show", "-json", planFilePath(terraformArgs)}
+	output, err := s.runtime.Shell.ExecCaptureWithEnv(terraformCommand, selectTerraformCommandEnv(terraformVars, true, scopedKeys), showArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading terraform plan JSON for %s: %w", component.Path, err)
+	}
+	changes, err := parseTerraformPlanFileJSON(output)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing terraform plan JSON for %s: %w", component.Path, err)
+	}
+	return changes, nil
+}
+
 // componentDestroyEnabled reports whether a component should be included in a
 // destroy plan. A component is included unless its Destroy field is set and
 // resolves to false; absent or true means "destroy normally." This mirrors the
@@ -1480,10 +1715,15 @@ func componentDestroyEnabled(component *blueprintv1alpha1.TerraformComponent) bo
 
 // prepareComponentEnv saves the current directory, validates the component's directory exists,
 // sets up the terraform environment, and returns a cleanup func that restores the working directory
-// and removes any backend_override.tf. It is the shared setup used by planComponents,
+// and removes any backend_override.tf. It is the shared setup used by planOneTerraformOutput,
 // planOneTerraformSummary, and prepareComponentOp. scopedKeys names the
-// contexts/<context>/terraform/.env keys selectTerraformCommandEnv must pass through.
+// contexts/<context>/terraform/.env keys selectTerraformCommandEnv must pass through. Setup
+// and cleanup hold envMu so concurrent plans never evaluate terraform_output() or touch the
+// working directory at the same time.
 func (s *TerraformStack) prepareComponentEnv(component *blueprintv1alpha1.TerraformComponent) (map[string]string, []string, *envvars.TerraformArgs, func(), error) {
+	s.envMu.Lock()
+	defer s.envMu.Unlock()
+
 	currentDir, err := s.shims.Getwd()
 	if err != nil {
 		return nil, nil, nil, func() {}, fmt.Errorf("error getting current directory: %w", err)
@@ -1507,6 +1747,8 @@ func (s *TerraformStack) prepareComponentEnv(component *blueprintv1alpha1.Terraf
 	}
 
 	cleanup := func() {
+		s.envMu.Lock()
+		defer s.envMu.Unlock()
 		_ = s.shims.Chdir(currentDir)
 		removeBackendOverride()
 	}
@@ -1555,8 +1797,9 @@ func (s *TerraformStack) prepareComponentOp(blueprint *blueprintv1alpha1.Bluepri
 // (component, backend, migrate-state) for the process so repeated callers in
 // a single CLI run share one terraform invocation; failed inits aren't
 // cached. On cache miss without -migrate-state, clearStaleBackendPointer
-// drops a stale pointer file before init runs. Dedup assumes sequential
-// callers; parallel iteration would need per-key sync.Once. A failure
+// drops a stale pointer file before init runs. Dedup assumes one caller per
+// key at a time; concurrent Up workers satisfy this because each key is a
+// distinct component. A failure
 // matching isStaleProviderLockError gets staleProviderLockHint appended
 // rather than passing Terraform's raw error through unexplained.
 func (s *TerraformStack) runTerraformInit(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string, terraformArgs *envvars.TerraformArgs, extraFlags ...string) error {
@@ -1739,13 +1982,32 @@ func staleProviderLockHint(component *blueprintv1alpha1.TerraformComponent) stri
 // "plan said nothing changes". Lines that are blank, non-JSON, or fail to parse
 // are silently skipped, since terraform may emit non-event diagnostics.
 func parseTerraformPlanJSON(output string) (add, change, destroy int, noChanges bool, resources []ResourceChange) {
+	return parseTerraformEvents(output, "planned_change")
+}
+
+// parseTerraformDriftJSON parses the event stream emitted by `terraform plan -refresh-only
+// -json` and returns the resources terraform reported as changed outside of terraform. The
+// refresh-only change_summary always counts zero resource changes, so only the
+// resource_drift events carry the drift.
+func parseTerraformDriftJSON(output string) []ResourceChange {
+	_, _, _, _, resources := parseTerraformEvents(output, "resource_drift")
+	return resources
+}
+
+// parseTerraformEvents is the shared body of parseTerraformPlanJSON and
+// parseTerraformDriftJSON. changeEvent selects which per-resource event type populates the
+// resource list: planned_change for a normal plan, resource_drift for a refresh-only plan.
+// A normal plan also emits resource_drift events for out-of-band changes it will
+// overwrite; keying on the event type keeps those out of the planned resource list.
+func parseTerraformEvents(output, changeEvent string) (add, change, destroy int, noChanges bool, resources []ResourceChange) {
 	type changeSummary struct {
 		Add    int `json:"add"`
 		Change int `json:"change"`
 		Remove int `json:"remove"`
 	}
 	type resourceAddr struct {
-		Addr string `json:"addr"`
+		Addr         string `json:"addr"`
+		ResourceType string `json:"resource_type"`
 	}
 	type planChange struct {
 		Resource resourceAddr `json:"resource"`
@@ -1776,7 +2038,7 @@ func parseTerraformPlanJSON(output string) (add, change, destroy int, noChanges
 			change = ev.Changes.Change
 			destroy = ev.Changes.Remove
 			sawSummary = true
-		case "planned_change":
+		case changeEvent:
 			if ev.Change == nil {
 				continue
 			}
@@ -1787,6 +2049,7 @@ func parseTerraformPlanJSON(output string) (add, change, destroy int, noChanges
 			resources = append(resources, ResourceChange{
 				Address: stripModuleMain(ev.Change.Resource.Addr),
 				Action:  action,
+				Type:    ev.Change.Resource.ResourceType,
 			})
 		}
 	}
@@ -1796,6 +2059,70 @@ func parseTerraformPlanJSON(output string) (add, change, destroy int, noChanges
 	return
 }
 
+// parseTerraformPlanFileJSON decodes the resource_changes of a saved plan rendered by
+// `terraform show -json <planfile>`. Unlike the plan -json event stream, this document
+// carries each resource's before/after attribute values. Entries whose actions map to
+// ActionUnknown (no-op, read) are dropped, as in parseTerraformPlanJSON.
+func parseTerraformPlanFileJSON(output string) ([]ResourceChange, error) {
+	var plan struct {
+		ResourceChanges []struct {
+			Address string `json:"address"`
+			Type    string `json:"type"`
+			Change  struct {
+				Actions []string `json:"actions"`
+				Before  any      `json:"before"`
+				After   any      `json:"after"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+	}
+	if err := json.Unmarshal([]byte(output), &plan); err != nil {
+		return nil, err
+	}
+
+	var changes []ResourceChange
+	for _, rc := range plan.ResourceChanges {
+		action := ActionUnknown
+		switch len(rc.Change.Actions) {
+		case 1:
+			action = mapTerraformAction(rc.Change.Actions[0])
+		case 2:
+			action = ActionReplace
+		}
+		if action == ActionUnknown {
+			continue
+		}
+		changes = append(changes, ResourceChange{
+			Address: stripModuleMain(rc.Address),
+			Action:  action,
+			Type:    rc.Type,
+			Values:  &ResourceValues{Before: rc.Change.Before, After: rc.Change.After},
+		})
+	}
+	return changes, nil
+}
+
+// attachPlanValues copies the type and before/after values from a saved plan's resource
+// changes onto the matching entries of an event-stream resource list.
+func attachPlanValues(resources, changes []ResourceChange) []ResourceChange {
+	byAddress := make(map[string]ResourceChange, len(changes))
+	for _, c := range changes {
+		byAddress[c.Address] = c
+	}
+	for i, r := range resources {
+		if c, ok := byAddress[r.Address]; ok {
+			resources[i].Type = c.Type
+			resources[i].Values = c.Values
+		}
+	}
+	return resources
+}
+
+// planFilePath returns the saved plan file path that GenerateTerraformArgs passes to plan as
+// -out and to apply as its final argument.
+func planFilePath(terraformArgs *envvars.TerraformArgs) string {
+	return filepath.ToSlash(filepath.Join(terraformArgs.TFDataDir, "terraform.tfplan"))
+}
+
 // extractPreventDestroyAddresses scans the line-delimited JSON event stream
 // emitted by `terraform plan -destroy -json` for diagnostic events that
 // terraform produces when a resource has `lifecycle { prevent_destroy = true }`.
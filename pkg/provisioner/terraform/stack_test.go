@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -171,6 +172,21 @@ contexts:
 	shims.ReadFile = func(_ string) ([]byte, error) {
 		return nil, os.ErrNotExist
 	}
+	shims.ReadDir = func(_ string) ([]os.DirEntry, error) {
+		return nil, os.ErrNotExist
+	}
+	shims.MkdirAll = func(_ string, _ os.FileMode) error {
+		return nil
+	}
+	shims.WriteFile = func(_ string, _ []byte, _ os.FileMode) error {
+		return nil
+	}
+	shims.MkdirTemp = func(_, _ string) (string, error) {
+		return t.TempDir(), nil
+	}
+	shims.RemoveAll = func(_ string) error {
+		return nil
+	}
 
 	t.Cleanup(func() {
 		os.Unsetenv("WINDSOR_PROJECT_ROOT")
@@ -1638,8 +1654,9 @@ func TestStack_DestroyAll(t *testing.T) {
 
 		// Then components are walked in reverse dependency order, and each component is
 		// taken through the idempotent destroy flow end-to-end before the next one begins:
-		// init → show (pre-refresh state JSON) → refresh → show (post-refresh state JSON) →
-		// destroy. The pre-refresh show-json is load-bearing for the empty-state skip; refresh
+		// init → show (pre-refresh state JSON) → state pull (snapshot) → refresh → show
+		// (post-refresh state JSON) → destroy. The pre-refresh show-json is load-bearing for
+		// the empty-state skip; the snapshot is taken before refresh rewrites state; refresh
 		// reconciles state with cloud reality for partial-destroy cases; the post-refresh
 		// show-json drives the second skip check. TF_VAR_operation=destroy is forwarded to
 		// commands that include TF_VAR_* in their env (show, refresh, destroy); init does
@@ -1651,11 +1668,13 @@ func TestStack_DestroyAll(t *testing.T) {
 		expected := []ordered{
 			{component: "local/path", subcommand: "init"},
 			{component: "local/path", subcommand: "show"},
+			{component: "local/path", subcommand: "state"},
 			{component: "local/path", subcommand: "refresh"},
 			{component: "local/path", subcommand: "show"},
 			{component: "local/path", subcommand: "destroy"},
 			{component: "remote/path", subcommand: "init"},
 			{component: "remote/path", subcommand: "show"},
+			{component: "remote/path", subcommand: "state"},
 			{component: "remote/path", subcommand: "refresh"},
 			{component: "remote/path", subcommand: "show"},
 			{component: "remote/path", subcommand: "destroy"},
@@ -2125,7 +2144,7 @@ func TestTerraformStack_setupTerraformEnvironment(t *testing.T) {
 			FullPath: filepath.Join(os.Getenv("WINDSOR_PROJECT_ROOT"), "terraform", "test", "path"),
 		}
 
-		mocks.ConfigHandler.Set("terraform.backend.type", "unsupported")
+		mocks.ConfigHandler.Set("terraform.backend.type", "not-a-backend")
 
 		_, _, _, err := stack.setupTerraformEnvironment(component)
 		if err == nil {
@@ -3055,6 +3074,128 @@ func TestStack_Apply(t *testing.T) {
 	})
 }
 
+func TestStack_SetPlanCheck(t *testing.T) {
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks) {
+		t.Helper()
+		mocks := setupWindsorStackMocks(t)
+		stack := NewStack(mocks.Runtime).(*TerraformStack)
+		stack.shims = mocks.Shims
+		return stack, mocks
+	}
+	planFileJSON := `{"resource_changes":[` +
+		`{"address":"module.main.aws_db_instance.main","type":"aws_db_instance","change":{"actions":["delete"],"before":{"identifier":"main"},"after":null}},` +
+		`{"address":"module.main.aws_s3_bucket.logs","type":"aws_s3_bucket","change":{"actions":["no-op"],"before":{},"after":{}}}` +
+		`]}`
+	showPlan := func(args []string) bool {
+		return len(args) > 3 && args[1] == "show" && args[2] == "-json"
+	}
+
+	t.Run("PassesSavedPlanChangesToCheckBeforeApply", func(t *testing.T) {
+		// Given a registered plan check and a saved plan that deletes a database
+		stack, mocks := setup(t)
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if showPlan(args) {
+				return planFileJSON, nil
+			}
+			if len(args) == 3 && args[1] == "show" {
+				return `{"values":{"root_module":{"resources":[]}}}`, nil
+			}
+			return "", nil
+		}
+		var gotID string
+		var got []ResourceChange
+		stack.SetPlanCheck(func(componentID string, changes []ResourceChange) error {
+			gotID = componentID
+			got = changes
+			return nil
+		})
+
+		// When applying the component
+		if err := stack.Apply(createTestBlueprint(), "local/path"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the check saw the delete with its type and before values, and the no-op was dropped
+		if gotID != "local/path" {
+			t.Errorf("Expected check for local/path, got %q", gotID)
+		}
+		if len(got) != 1 {
+			t.Fatalf("Expected 1 change, got %#v", got)
+		}
+		if got[0].Address != "aws_db_instance.main" || got[0].Action != ActionDelete || got[0].Type != "aws_db_instance" {
+			t.Errorf("Unexpected change %#v", got[0])
+		}
+		before, ok := got[0].Values.Before.(map[string]any)
+		if !ok || before["identifier"] != "main" || got[0].Values.After != nil {
+			t.Errorf("Expected before/after values from the plan file, got %#v", got[0].Values)
+		}
+	})
+
+	t.Run("CheckErrorAbortsBeforeApply", func(t *testing.T) {
+		// Given a plan check that rejects the plan
+		stack, mocks := setup(t)
+		applied := false
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if showPlan(args) {
+				return planFileJSON, nil
+			}
+			if len(args) == 3 && args[1] == "show" {
+				return `{"values":{"root_module":{"resources":[]}}}`, nil
+			}
+			return "", nil
+		}
+		mocks.Shell.ExecProgressWithEnvFunc = func(message string, command string, env map[string]string, args ...string) (string, error) {
+			if len(args) > 1 && args[1] == "apply" {
+				applied = true
+			}
+			return "", nil
+		}
+		stack.SetPlanCheck(func(componentID string, changes []ResourceChange) error {
+			return fmt.Errorf("denied by policy")
+		})
+
+		// When applying the component
+		err := stack.Apply(createTestBlueprint(), "local/path")
+
+		// Then the check error is returned and terraform apply never runs
+		if err == nil || !strings.Contains(err.Error(), "denied by policy") {
+			t.Errorf("Expected plan check error, got %v", err)
+		}
+		if applied {
+			t.Error("Expected terraform apply not to run after a failed plan check")
+		}
+	})
+
+	t.Run("SummaryAttachesValuesWhileCheckRegistered", func(t *testing.T) {
+		// Given a registered plan check and a component with state
+		stack, mocks := setup(t)
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if showPlan(args) {
+				return planFileJSON, nil
+			}
+			if len(args) > 2 && args[1] == "show" && args[2] == "-json" {
+				return `{"values":{"root_module":{"resources":[{"address":"aws_db_instance.main"}]}}}`, nil
+			}
+			if len(args) > 1 && args[1] == "plan" {
+				return `{"type":"planned_change","change":{"resource":{"addr":"module.main.aws_db_instance.main","resource_type":"aws_db_instance"},"action":"delete"}}` + "\n", nil
+			}
+			return "", nil
+		}
+		stack.SetPlanCheck(func(string, []ResourceChange) error { return nil })
+
+		// When summarising the plan
+		results := stack.PlanSummary(createTestBlueprint())
+
+		// Then the resource carries the values from the saved plan
+		if len(results) == 0 || len(results[0].Resources) != 1 {
+			t.Fatalf("Expected one resource, got %#v", results)
+		}
+		if results[0].Resources[0].Values == nil {
+			t.Errorf("Expected values to be attached, got %#v", results[0].Resources[0])
+		}
+	})
+}
+
 func TestStack_Destroy(t *testing.T) {
 	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks) {
 		t.Helper()
@@ -3401,14 +3542,15 @@ func TestStack_Destroy(t *testing.T) {
 		}
 
 		// Then the sequence is the idempotent destroy flow end-to-end: init, show
-		// (pre-refresh state JSON), refresh, show (post-refresh state JSON), destroy. The
-		// pre-refresh show-json drives the empty-state skip; refresh reconciles state with
+		// (pre-refresh state JSON), state pull (snapshot), refresh, show (post-refresh state
+		// JSON), destroy. The pre-refresh show-json drives the empty-state skip; the snapshot
+		// is taken before refresh rewrites state; refresh reconciles state with
 		// reality for partial-destroy reconciliation; the post-refresh show-json drives the
 		// second skip check. No intermediate plan or apply runs; terraform destroy plans
 		// internally. TF_VAR_operation=destroy is forwarded to commands that include
 		// TF_VAR_* in their env (show, refresh, destroy); init does not forward TF_VAR_*
 		// and relies on the process env.
-		expected := []string{"init", "show", "refresh", "show", "destroy"}
+		expected := []string{"init", "show", "state", "refresh", "show", "destroy"}
 		if len(sequence) < len(expected) {
 			t.Fatalf("Expected at least %d terraform steps, got %d: %+v", len(expected), len(sequence), sequence)
 		}
@@ -4798,6 +4940,172 @@ func TestStack_PlanDestroyComponentSummary(t *testing.T) {
 	})
 }
 
+func TestStack_DriftSummary(t *testing.T) {
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks) {
+		t.Helper()
+		mocks := setupWindsorStackMocks(t)
+		stack := NewStack(mocks.Runtime).(*TerraformStack)
+		stack.shims = mocks.Shims
+		return stack, mocks
+	}
+	driftExit := func(t *testing.T) error {
+		t.Helper()
+		err := exec.Command("sh", "-c", "exit 2").Run()
+		if err == nil {
+			t.Fatal("expected non-zero exit")
+		}
+		return fmt.Errorf("command execution failed: %w", err)
+	}
+
+	t.Run("ReturnsNilForNilBlueprint", func(t *testing.T) {
+		stack, _ := setup(t)
+		if got := stack.DriftSummary(nil); got != nil {
+			t.Errorf("expected nil, got %v", got)
+		}
+	})
+
+	t.Run("ReportsDriftedResourcesOnDetailedExitCodeTwo", func(t *testing.T) {
+		// Given a refresh-only plan that exits 2 with drift events
+		stack, mocks := setup(t)
+		var capturedArgs []string
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) > 2 && args[1] == "show" && args[2] == "-json" {
+				return `{"values":{"root_module":{"resources":[{"address":"aws_s3_bucket.example"}]}}}`, nil
+			}
+			if len(args) > 1 && args[1] == "plan" {
+				capturedArgs = append([]string{}, args...)
+				return strings.Join([]string{
+					`{"type":"resource_drift","change":{"resource":{"addr":"module.main.aws_s3_bucket.logs"},"action":"update"}}`,
+					`{"type":"resource_drift","change":{"resource":{"addr":"module.main.aws_iam_role.eks"},"action":"delete"}}`,
+					`{"type":"change_summary","changes":{"add":0,"change":0,"remove":0}}`,
+					``,
+				}, "\n"), driftExit(t)
+			}
+			return "", nil
+		}
+
+		// When DriftSummary runs
+		results := stack.DriftSummary(createTestBlueprint())
+
+		// Then the plan ran in refresh-only mode without writing the saved plan file
+		for _, want := range []string{"-refresh-only", "-detailed-exitcode", "-json"} {
+			if !slices.Contains(capturedArgs, want) {
+				t.Errorf("expected %s in plan args, got %v", want, capturedArgs)
+			}
+		}
+		for _, a := range capturedArgs {
+			if strings.HasPrefix(a, "-out=") {
+				t.Errorf("drift plan must not write a plan file, got %v", capturedArgs)
+			}
+		}
+		// And the exit code 2 is reported as drift, not as an error
+		if len(results) == 0 {
+			t.Fatal("expected at least one result")
+		}
+		r := results[0]
+		if r.Err != nil {
+			t.Fatalf("expected no error, got %v", r.Err)
+		}
+		if r.NoChanges || r.Change != 1 || r.Destroy != 1 {
+			t.Errorf("expected ~1 -1 with drift, got %#v", r)
+		}
+		want := []ResourceChange{
+			{Address: "aws_s3_bucket.logs", Action: ActionUpdate},
+			{Address: "aws_iam_role.eks", Action: ActionDelete},
+		}
+		if len(r.Resources) != len(want) {
+			t.Fatalf("expected %d resources, got %#v", len(want), r.Resources)
+		}
+		for i := range want {
+			if r.Resources[i] != want[i] {
+				t.Errorf("resource[%d]: expected %#v, got %#v", i, want[i], r.Resources[i])
+			}
+		}
+	})
+
+	t.Run("ReportsNoDriftOnCleanExit", func(t *testing.T) {
+		// Given a refresh-only plan that exits 0
+		stack, mocks := setup(t)
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) > 2 && args[1] == "show" && args[2] == "-json" {
+				return `{"values":{"root_module":{"resources":[{"address":"aws_s3_bucket.example"}]}}}`, nil
+			}
+			if len(args) > 1 && args[1] == "plan" {
+				return `{"type":"change_summary","changes":{"add":0,"change":0,"remove":0}}` + "\n", nil
+			}
+			return "", nil
+		}
+
+		// When DriftSummary runs
+		results := stack.DriftSummary(createTestBlueprint())
+
+		// Then every component reports no drift
+		for _, r := range results {
+			if r.Err != nil || !r.NoChanges || len(r.Resources) != 0 {
+				t.Errorf("expected no drift for %q, got %#v", r.ComponentID, r)
+			}
+		}
+	})
+
+	t.Run("MarksComponentNewWhenStateIsEmpty", func(t *testing.T) {
+		// Given empty state
+		stack, mocks := setup(t)
+		var planCalled bool
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) > 2 && args[1] == "show" && args[2] == "-json" {
+				return "{}", nil
+			}
+			if len(args) > 1 && args[1] == "plan" {
+				planCalled = true
+			}
+			return "", nil
+		}
+
+		// When DriftSummary runs
+		results := stack.DriftSummary(createTestBlueprint())
+
+		// Then components are IsNew and plan is skipped
+		for _, r := range results {
+			if !r.IsNew {
+				t.Errorf("expected IsNew=true for %q", r.ComponentID)
+			}
+		}
+		if planCalled {
+			t.Error("plan must not be invoked when state is empty")
+		}
+	})
+
+	t.Run("RecordsPlanFailurePerComponent", func(t *testing.T) {
+		// Given a refresh-only plan that fails with a non-drift error
+		stack, mocks := setup(t)
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) > 2 && args[1] == "show" && args[2] == "-json" {
+				return `{"values":{"root_module":{"resources":[{"address":"aws_s3_bucket.example"}]}}}`, nil
+			}
+			if len(args) > 1 && args[1] == "plan" {
+				return "", fmt.Errorf("credentials expired")
+			}
+			return "", nil
+		}
+
+		// When DriftSummary runs
+		results := stack.DriftSummary(createTestBlueprint())
+
+		// Then each component carries the error and none is reported as drift-free
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		for _, r := range results {
+			if r.Err == nil || !strings.Contains(r.Err.Error(), "credentials expired") {
+				t.Errorf("expected plan error for %q, got %v", r.ComponentID, r.Err)
+			}
+			if r.NoChanges {
+				t.Errorf("expected NoChanges=false on error for %q", r.ComponentID)
+			}
+		}
+	})
+}
+
 func TestParseTerraformPlanJSON(t *testing.T) {
 	t.Run("ParsesCountsAndResourcesFromEventStream", func(t *testing.T) {
 		// Given a terraform plan -json event stream with summary + planned changes
@@ -4903,6 +5211,70 @@ func TestParseTerraformPlanJSON(t *testing.T) {
 	})
 }
 
+func TestParseTerraformPlanFileJSON(t *testing.T) {
+	t.Run("MapsActionListsAndKeepsValues", func(t *testing.T) {
+		// Given a saved plan with create, replace, and read entries
+		output := `{"resource_changes":[` +
+			`{"address":"module.main.aws_s3_bucket.logs","type":"aws_s3_bucket","change":{"actions":["create"],"before":null,"after":{"acl":"public-read"}}},` +
+			`{"address":"module.main.aws_instance.web","type":"aws_instance","change":{"actions":["delete","create"],"before":{},"after":{}}},` +
+			`{"address":"module.main.data.aws_caller_identity.current","type":"aws_caller_identity","change":{"actions":["read"]}}` +
+			`]}`
+
+		// When parsed
+		changes, err := parseTerraformPlanFileJSON(output)
+
+		// Then creates and replaces are kept with their values and reads are dropped
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(changes) != 2 {
+			t.Fatalf("Expected 2 changes, got %#v", changes)
+		}
+		if changes[0].Action != ActionCreate || changes[0].Type != "aws_s3_bucket" {
+			t.Errorf("Unexpected first change %#v", changes[0])
+		}
+		after, _ := changes[0].Values.After.(map[string]any)
+		if after["acl"] != "public-read" {
+			t.Errorf("Expected after values, got %#v", changes[0].Values)
+		}
+		if changes[1].Action != ActionReplace {
+			t.Errorf("Expected replace for delete+create, got %v", changes[1].Action)
+		}
+	})
+
+	t.Run("ReturnsErrorForInvalidJSON", func(t *testing.T) {
+		if _, err := parseTerraformPlanFileJSON("not json"); err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+func TestParseTerraformDriftJSON(t *testing.T) {
+	t.Run("ReturnsResourceDriftEventsOnly", func(t *testing.T) {
+		// Given an event stream carrying both drift and planned changes
+		output := strings.Join([]string{
+			`{"type":"resource_drift","change":{"resource":{"addr":"module.main.aws_s3_bucket.logs"},"action":"update"}}`,
+			`{"type":"planned_change","change":{"resource":{"addr":"module.main.aws_iam_role.eks"},"action":"create"}}`,
+			`{"type":"change_summary","changes":{"add":1,"change":0,"remove":0}}`,
+			``,
+		}, "\n")
+
+		// When parsed for drift
+		resources := parseTerraformDriftJSON(output)
+
+		// Then only the resource_drift event is returned
+		if len(resources) != 1 || resources[0] != (ResourceChange{Address: "aws_s3_bucket.logs", Action: ActionUpdate}) {
+			t.Errorf("expected the drifted bucket only, got %#v", resources)
+		}
+
+		// And the normal plan parser ignores the drift event
+		_, _, _, _, planned := parseTerraformPlanJSON(output)
+		if len(planned) != 1 || planned[0].Address != "aws_iam_role.eks" {
+			t.Errorf("expected the planned role only, got %#v", planned)
+		}
+	})
+}
+
 func TestExtractPreventDestroyAddresses(t *testing.T) {
 	t.Run("ReturnsNilForEmptyOrNonDiagnosticOutput", func(t *testing.T) {
 		// Given an event stream with only planned_change and change_summary events
@@ -0,0 +1,416 @@
+package terraform
+
+// The TerraformStack state operations wrap `terraform state` subcommands for a single
+// component. They reuse the component environment setup shared with plan and apply so
+// state commands run against the same TF_DATA_DIR, backend config, and scoped env as
+// every other windsor operation. They also own the per-component snapshot store under
+// the context's .windsor directory: point-in-time state copies taken before apply, destroy,
+// migration, restore, and destructive state edits, which RestoreState can push back. The
+// automatic apply and destroy snapshots rotate; the others are kept until removed by hand.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+)
+
+// =============================================================================
+// Constants
+// =============================================================================
+
+// stateSnapshotDir is the directory under the context scratch path that holds per-component
+// state snapshots, one subdirectory per component ID.
+const stateSnapshotDir = "state-snapshots"
+
+// stateSnapshotTimeFormat names snapshot files. UTC with nanoseconds keeps names unique
+// within a run and makes lexical order match chronological order.
+const stateSnapshotTimeFormat = "20060102T150405.000000000Z"
+
+// stateSnapshotExt is the file extension of every snapshot in the store. The snapshot ID
+// operators pass to `windsor state restore` is the file name without it.
+const stateSnapshotExt = ".tfstate"
+
+// defaultStateSnapshotKeep is the number of automatic snapshots retained per component when
+// terraform.snapshots.keep is unset.
+const defaultStateSnapshotKeep = 10
+
+// Snapshot kinds suffix the timestamp of a snapshot taken for a one-off operation, so
+// "<timestamp>-migrate" marks the copy taken before a backend migration. Snapshots with a kind
+// are exempt from rotation; the automatic snapshots taken before every apply and destroy carry
+// none and are the only ones terraform.snapshots.keep prunes.
+const (
+	stateSnapshotKindEdit    = "edit"
+	stateSnapshotKindMigrate = "migrate"
+	stateSnapshotKindRestore = "restore"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// tfStateHeader is the minimal shape of a raw state document needed to compare two
+// states: lineage identifies the state's history, serial orders versions within it.
+type tfStateHeader struct {
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// State runs `terraform state <args...>` for the component identified by componentID and
+// returns its output. The component environment is prepared exactly as for plan and apply,
+// and init runs first so the backend is configured. `state pull` output is returned raw
+// rather than secret-scrubbed, since it is a state document callers persist or pipe into
+// `state push`; every other subcommand's output is scrubbed as usual.
+func (s *TerraformStack) State(blueprint *blueprintv1alpha1.Blueprint, componentID string, args ...string) (string, error) {
+	if blueprint == nil {
+		return "", fmt.Errorf("blueprint not provided")
+	}
+	if componentID == "" {
+		return "", fmt.Errorf("component ID not provided")
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("state subcommand not provided")
+	}
+
+	component, terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentOp(blueprint, componentID)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+		return "", err
+	}
+
+	if args[0] == "pull" {
+		return s.pullComponentState(component, terraformVars, scopedKeys)
+	}
+
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	stateArgs := append([]string{fmt.Sprintf("-chdir=%s", component.FullPath), "state"}, args...)
+	stateEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
+	output, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, stateEnv, stateArgs...)
+	if err != nil {
+		return "", fmt.Errorf("error running terraform state %s for %s: %w", args[0], component.Path, err)
+	}
+
+	return output, nil
+}
+
+// SnapshotState pulls the current state of the component identified by componentID and
+// writes it to the component's snapshot directory. Returns the snapshot path, or an empty
+// string when the component has no state to snapshot. Unlike the automatic snapshots taken
+// before apply and destroy, this runs even when terraform.snapshots.enabled is false, since
+// the caller asked for it explicitly, and the snapshot is exempt from rotation.
+func (s *TerraformStack) SnapshotState(blueprint *blueprintv1alpha1.Blueprint, componentID string) (string, error) {
+	if blueprint == nil {
+		return "", fmt.Errorf("blueprint not provided")
+	}
+	if componentID == "" {
+		return "", fmt.Errorf("component ID not provided")
+	}
+
+	component, terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentOp(blueprint, componentID)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+		return "", err
+	}
+
+	state, err := s.pullComponentState(component, terraformVars, scopedKeys)
+	if err != nil || strings.TrimSpace(state) == "" {
+		return "", err
+	}
+	return s.writeStateSnapshot(component, []byte(state), stateSnapshotKindEdit)
+}
+
+// RestoreState pushes the snapshot identified by snapshotID back as the current state of the
+// component identified by componentID. The snapshot must share the current state's lineage;
+// a snapshot from an unrelated state history is refused. Because a snapshot is normally
+// older than the state it replaces, its serial is raised above the current serial so
+// terraform accepts the push. The current state is itself snapshotted first, exempt from
+// rotation, so a restore can be undone; that snapshot's path is returned (empty when the
+// backend held no state).
+func (s *TerraformStack) RestoreState(blueprint *blueprintv1alpha1.Blueprint, componentID, snapshotID string) (string, error) {
+	if blueprint == nil {
+		return "", fmt.Errorf("blueprint not provided")
+	}
+	if componentID == "" {
+		return "", fmt.Errorf("component ID not provided")
+	}
+
+	snapshot, err := s.readStateSnapshot(componentID, snapshotID)
+	if err != nil {
+		return "", err
+	}
+	var snapshotHeader tfStateHeader
+	if err := json.Unmarshal(snapshot, &snapshotHeader); err != nil {
+		return "", fmt.Errorf("error parsing state snapshot %s for %s: %w", snapshotID, componentID, err)
+	}
+	if snapshotHeader.Lineage == "" {
+		return "", fmt.Errorf("state snapshot %s for %s has no lineage", snapshotID, componentID)
+	}
+
+	component, terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentOp(blueprint, componentID)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+		return "", err
+	}
+
+	current, err := s.pullComponentState(component, terraformVars, scopedKeys)
+	if err != nil {
+		return "", err
+	}
+	serial := snapshotHeader.Serial
+	if strings.TrimSpace(current) != "" {
+		var currentHeader tfStateHeader
+		if err := json.Unmarshal([]byte(current), &currentHeader); err != nil {
+			return "", fmt.Errorf("error parsing current state for %s: %w", component.Path, err)
+		}
+		if currentHeader.Lineage != "" && currentHeader.Lineage != snapshotHeader.Lineage {
+			return "", fmt.Errorf("state snapshot %s for %s has lineage %s, but the current state has lineage %s; refusing to replace an unrelated state", snapshotID, component.Path, snapshotHeader.Lineage, currentHeader.Lineage)
+		}
+		if currentHeader.Serial >= serial {
+			serial = currentHeader.Serial + 1
+		}
+	}
+
+	restored, err := setStateSerial(snapshot, serial)
+	if err != nil {
+		return "", fmt.Errorf("error preparing state snapshot %s for %s: %w", snapshotID, component.Path, err)
+	}
+
+	backupPath := ""
+	if strings.TrimSpace(current) != "" {
+		if backupPath, err = s.writeStateSnapshot(component, []byte(current), stateSnapshotKindRestore); err != nil {
+			return "", err
+		}
+	}
+
+	tmpDir, err := s.shims.MkdirTemp("", "windsor-state-restore-")
+	if err != nil {
+		return backupPath, fmt.Errorf("error creating temporary directory for state restore: %w", err)
+	}
+	defer func() {
+		_ = s.shims.RemoveAll(tmpDir)
+	}()
+	restorePath := filepath.Join(tmpDir, "terraform"+stateSnapshotExt)
+	if err := s.shims.WriteFile(restorePath, restored, 0o600); err != nil {
+		return backupPath, fmt.Errorf("error writing state restore file for %s: %w", component.Path, err)
+	}
+
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	pushArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "state", "push", restorePath}
+	pushEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
+	if _, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, pushEnv, pushArgs...); err != nil {
+		return backupPath, fmt.Errorf("error running terraform state push for %s: %w", component.Path, err)
+	}
+
+	return backupPath, nil
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// pullComponentState runs `terraform state pull` for an initialized component and returns the
+// state document unmodified. Output is routed through ExecToWriterWithEnv so secret scrubbing
+// cannot corrupt the JSON.
+func (s *TerraformStack) pullComponentState(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string) (string, error) {
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	pullArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "state", "pull"}
+	pullEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
+	var buf bytes.Buffer
+	if err := s.runtime.Shell.ExecToWriterWithEnv(terraformCommand, pullEnv, &buf, pullArgs...); err != nil {
+		return "", fmt.Errorf("error running terraform state pull for %s: %w", component.Path, err)
+	}
+	return buf.String(), nil
+}
+
+// snapshotBeforeChange takes the automatic snapshot that precedes apply and destroy of an
+// initialized component. It is a no-op when terraform.snapshots.enabled is false, and writes
+// nothing when the state is unchanged since the newest snapshot, so an apply with nothing to
+// change does not rotate an earlier, meaningful snapshot out. A failed snapshot is returned as
+// an error rather than logged: the snapshot is the rollback point for the change about to
+// run, and a backend that cannot be read would fail that change anyway.
+func (s *TerraformStack) snapshotBeforeChange(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string) error {
+	if !s.runtime.ConfigHandler.GetBool("terraform.snapshots.enabled", true) {
+		return nil
+	}
+	state, err := s.pullComponentState(component, terraformVars, scopedKeys)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(state) == "" || s.matchesNewestSnapshot(component.GetID(), []byte(state)) {
+		return nil
+	}
+	_, err = s.writeStateSnapshot(component, []byte(state), "")
+	return err
+}
+
+// snapshotLocalState copies the component's local-backend state file into the snapshot store
+// before `terraform init -migrate-state` runs. Migration re-points the component at a new
+// backend, so `terraform state pull` cannot read the source state at this point; the local
+// file is read directly instead. It covers both migration directions windsor drives: the
+// local file is the source when bootstrap moves state to a remote backend, and the
+// destination -force-copy overwrites when teardown pulls remote state back to local.
+// The snapshot is exempt from rotation. Missing or empty files are skipped; disabled
+// snapshots make this a no-op.
+func (s *TerraformStack) snapshotLocalState(component *blueprintv1alpha1.TerraformComponent) error {
+	if !s.runtime.ConfigHandler.GetBool("terraform.snapshots.enabled", true) {
+		return nil
+	}
+	statePath, err := s.runtime.TerraformProvider.GetStatePath(component.GetID())
+	if err != nil {
+		return fmt.Errorf("error resolving local state path for %s: %w", component.Path, err)
+	}
+	data, err := s.shims.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error reading local state file %s: %w", statePath, err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	_, err = s.writeStateSnapshot(component, data, stateSnapshotKindMigrate)
+	return err
+}
+
+// writeStateSnapshot writes state to <scratch>/state-snapshots/<component>/<timestamp>.tfstate
+// with owner-only permissions, since state routinely carries secrets. A non-empty kind is
+// appended to the timestamp and exempts the snapshot from rotation; an automatic snapshot
+// (empty kind) instead prunes the component's oldest automatic snapshots beyond
+// terraform.snapshots.keep. Pruning is best-effort: a leftover snapshot costs disk space, not
+// correctness.
+func (s *TerraformStack) writeStateSnapshot(component *blueprintv1alpha1.TerraformComponent, state []byte, kind string) (string, error) {
+	dir := s.stateSnapshotPath(component.GetID())
+	if err := s.shims.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("error creating state snapshot directory for %s: %w", component.Path, err)
+	}
+	id := time.Now().UTC().Format(stateSnapshotTimeFormat)
+	if kind != "" {
+		id += "-" + kind
+	}
+	path := filepath.Join(dir, id+stateSnapshotExt)
+	if err := s.shims.WriteFile(path, state, 0o600); err != nil {
+		return "", fmt.Errorf("error writing state snapshot for %s: %w", component.Path, err)
+	}
+	if kind != "" {
+		return path, nil
+	}
+
+	keep := max(s.runtime.ConfigHandler.GetInt("terraform.snapshots.keep", defaultStateSnapshotKeep), 1)
+	var automatic []string
+	for _, id := range s.stateSnapshotIDs(component.GetID()) {
+		if !strings.Contains(id, "-") {
+			automatic = append(automatic, id)
+		}
+	}
+	for len(automatic) > keep {
+		_ = s.shims.Remove(filepath.Join(dir, automatic[0]+stateSnapshotExt))
+		automatic = automatic[1:]
+	}
+
+	return path, nil
+}
+
+// matchesNewestSnapshot reports whether state has the same lineage and serial as the newest
+// snapshot recorded for componentID. Terraform raises the serial on every change it writes, so
+// a match means the state has not changed since that snapshot. A state or snapshot that cannot
+// be parsed never matches.
+func (s *TerraformStack) matchesNewestSnapshot(componentID string, state []byte) bool {
+	ids := s.stateSnapshotIDs(componentID)
+	if len(ids) == 0 {
+		return false
+	}
+	newest, err := s.readStateSnapshot(componentID, ids[len(ids)-1])
+	if err != nil {
+		return false
+	}
+	var current, previous tfStateHeader
+	if json.Unmarshal(state, &current) != nil || json.Unmarshal(newest, &previous) != nil {
+		return false
+	}
+	return current.Lineage != "" && current.Lineage == previous.Lineage && current.Serial == previous.Serial
+}
+
+// readStateSnapshot returns the contents of the named snapshot for componentID. snapshotID is
+// the snapshot's file name, with or without the .tfstate extension; anything that would
+// resolve outside the component's snapshot directory is rejected. A missing snapshot is
+// reported together with the IDs that do exist.
+func (s *TerraformStack) readStateSnapshot(componentID, snapshotID string) ([]byte, error) {
+	id := strings.TrimSuffix(snapshotID, stateSnapshotExt)
+	if id == "" || id == "." || id == ".." || filepath.Base(id) != id || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid state snapshot ID %q", snapshotID)
+	}
+	data, err := s.shims.ReadFile(filepath.Join(s.stateSnapshotPath(componentID), id+stateSnapshotExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			available := s.stateSnapshotIDs(componentID)
+			if len(available) == 0 {
+				return nil, fmt.Errorf("state snapshot %q not found for %s: no snapshots recorded", id, componentID)
+			}
+			return nil, fmt.Errorf("state snapshot %q not found for %s; available: %s", id, componentID, strings.Join(available, ", "))
+		}
+		return nil, fmt.Errorf("error reading state snapshot %s for %s: %w", id, componentID, err)
+	}
+	return data, nil
+}
+
+// stateSnapshotIDs lists the snapshot IDs recorded for componentID, oldest first. A missing or
+// unreadable directory yields no IDs.
+func (s *TerraformStack) stateSnapshotIDs(componentID string) []string {
+	entries, err := s.shims.ReadDir(s.stateSnapshotPath(componentID))
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), stateSnapshotExt) {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(entry.Name(), stateSnapshotExt))
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// stateSnapshotPath returns the snapshot directory for the given component ID.
+func (s *TerraformStack) stateSnapshotPath(componentID string) string {
+	return filepath.Join(s.runtime.WindsorScratchPath, stateSnapshotDir, filepath.FromSlash(componentID))
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// setStateSerial returns the state document with its serial replaced. Every other field is
+// carried through as raw JSON so resource data and large numbers round-trip unchanged.
+func setStateSerial(state []byte, serial int64) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(state, &doc); err != nil {
+		return nil, err
+	}
+	doc["serial"] = json.RawMessage(strconv.FormatInt(serial, 10))
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -0,0 +1,579 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestStack_State(t *testing.T) {
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks) {
+		t.Helper()
+		mocks := setupWindsorStackMocks(t)
+		stack := NewStack(mocks.Runtime).(*TerraformStack)
+		stack.shims = mocks.Shims
+		return stack, mocks
+	}
+
+	t.Run("RunsStateSubcommandInComponentDirectory", func(t *testing.T) {
+		// Given a stack whose shell records terraform state invocations
+		stack, mocks := setup(t)
+		var stateArgs []string
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) >= 2 && args[1] == "state" {
+				stateArgs = args
+				return "module.main.null_resource.a\n", nil
+			}
+			return "", nil
+		}
+
+		// When listing state for the local component
+		output, err := stack.State(createTestBlueprint(), "local/path", "list")
+
+		// Then the state command runs against the component directory and its output is returned
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if output != "module.main.null_resource.a\n" {
+			t.Errorf("Expected state list output, got %q", output)
+		}
+		if len(stateArgs) != 3 || !strings.HasPrefix(stateArgs[0], "-chdir=") || stateArgs[2] != "list" {
+			t.Errorf("Expected [-chdir=<path> state list], got %v", stateArgs)
+		}
+	})
+
+	t.Run("PullBypassesScrubbedCapture", func(t *testing.T) {
+		// Given a stack whose raw writer path returns a state document
+		stack, mocks := setup(t)
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			_, err := io.WriteString(w, `{"serial":3}`)
+			return err
+		}
+
+		// When pulling state
+		output, err := stack.State(createTestBlueprint(), "local/path", "pull")
+
+		// Then the raw document is returned
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if output != `{"serial":3}` {
+			t.Errorf("Expected raw state document, got %q", output)
+		}
+	})
+
+	t.Run("NilBlueprint", func(t *testing.T) {
+		// Given a stack
+		stack, _ := setup(t)
+
+		// When running a state command with a nil blueprint
+		_, err := stack.State(nil, "local/path", "list")
+
+		// Then an error should occur
+		if err == nil || !strings.Contains(err.Error(), "blueprint not provided") {
+			t.Errorf("Expected blueprint not provided error, got %v", err)
+		}
+	})
+
+	t.Run("MissingSubcommand", func(t *testing.T) {
+		// Given a stack
+		stack, _ := setup(t)
+
+		// When running a state command without a subcommand
+		_, err := stack.State(createTestBlueprint(), "local/path")
+
+		// Then an error should occur
+		if err == nil || !strings.Contains(err.Error(), "state subcommand not provided") {
+			t.Errorf("Expected missing subcommand error, got %v", err)
+		}
+	})
+
+	t.Run("ComponentNotFound", func(t *testing.T) {
+		// Given a stack
+		stack, _ := setup(t)
+
+		// When running a state command for an unknown component
+		_, err := stack.State(createTestBlueprint(), "nonexistent", "list")
+
+		// Then a not-found error should occur
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("StateCommandError", func(t *testing.T) {
+		// Given a stack whose terraform state command fails
+		stack, mocks := setup(t)
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) >= 2 && args[1] == "state" {
+				return "", fmt.Errorf("no such resource")
+			}
+			return "", nil
+		}
+
+		// When removing a resource from state
+		_, err := stack.State(createTestBlueprint(), "local/path", "rm", "null_resource.a")
+
+		// Then the error names the subcommand and component
+		if err == nil || !strings.Contains(err.Error(), "error running terraform state rm for local/path") {
+			t.Errorf("Expected wrapped state rm error, got %v", err)
+		}
+	})
+}
+
+func TestStack_SnapshotState(t *testing.T) {
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks) {
+		t.Helper()
+		mocks := setupWindsorStackMocks(t)
+		mocks.Runtime.WindsorScratchPath = filepath.Join(mocks.Runtime.ProjectRoot, ".windsor", "contexts", "local")
+		mocks.Shims.MkdirAll = os.MkdirAll
+		mocks.Shims.WriteFile = os.WriteFile
+		stack := NewStack(mocks.Runtime).(*TerraformStack)
+		stack.shims = mocks.Shims
+		return stack, mocks
+	}
+
+	t.Run("WritesSnapshotUnderScratchPath", func(t *testing.T) {
+		// Given a component whose backend holds state
+		stack, mocks := setup(t)
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			_, err := io.WriteString(w, `{"serial":7,"lineage":"abc"}`)
+			return err
+		}
+
+		// When snapshotting the component
+		path, err := stack.SnapshotState(createTestBlueprint(), "local/path")
+
+		// Then the state is written to the component's snapshot directory
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		wantDir := filepath.Join(mocks.Runtime.WindsorScratchPath, "state-snapshots", "local", "path")
+		if filepath.Dir(path) != wantDir {
+			t.Errorf("Expected snapshot in %s, got %s", wantDir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected snapshot file to exist, got %v", err)
+		}
+		if string(data) != `{"serial":7,"lineage":"abc"}` {
+			t.Errorf("Expected snapshot to hold pulled state, got %q", string(data))
+		}
+	})
+
+	t.Run("IsExemptFromRotation", func(t *testing.T) {
+		// Given a context that keeps two automatic snapshots per component
+		stack, mocks := setup(t)
+		if err := mocks.ConfigHandler.Set("terraform.snapshots.keep", 2); err != nil {
+			t.Fatalf("Failed to set snapshot keep: %v", err)
+		}
+		mocks.Shims.ReadDir = os.ReadDir
+		mocks.Shims.Remove = os.Remove
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			_, err := io.WriteString(w, `{"serial":1,"lineage":"abc"}`)
+			return err
+		}
+
+		// When snapshotting the component three times
+		for range 3 {
+			path, err := stack.SnapshotState(createTestBlueprint(), "local/path")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !strings.HasSuffix(path, "-"+stateSnapshotKindEdit+stateSnapshotExt) {
+				t.Errorf("Expected snapshot to carry the edit kind, got %s", path)
+			}
+		}
+
+		// Then every snapshot remains
+		if ids := stack.stateSnapshotIDs("local/path"); len(ids) != 3 {
+			t.Errorf("Expected 3 snapshots to remain, got %v", ids)
+		}
+	})
+
+	t.Run("SkipsEmptyState", func(t *testing.T) {
+		// Given a component with no state in its backend
+		stack, mocks := setup(t)
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			return nil
+		}
+
+		// When snapshotting the component
+		path, err := stack.SnapshotState(createTestBlueprint(), "local/path")
+
+		// Then nothing is written and no path is returned
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != "" {
+			t.Errorf("Expected empty path for empty state, got %q", path)
+		}
+	})
+
+	t.Run("PullError", func(t *testing.T) {
+		// Given a component whose state pull fails
+		stack, mocks := setup(t)
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			return fmt.Errorf("backend unreachable")
+		}
+
+		// When snapshotting the component
+		_, err := stack.SnapshotState(createTestBlueprint(), "local/path")
+
+		// Then the pull error is returned
+		if err == nil || !strings.Contains(err.Error(), "error running terraform state pull for local/path") {
+			t.Errorf("Expected wrapped pull error, got %v", err)
+		}
+	})
+
+	t.Run("WriteError", func(t *testing.T) {
+		// Given a snapshot directory that cannot be written
+		stack, mocks := setup(t)
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			_, err := io.WriteString(w, `{"serial":1}`)
+			return err
+		}
+		mocks.Shims.WriteFile = func(string, []byte, os.FileMode) error {
+			return fmt.Errorf("disk full")
+		}
+
+		// When snapshotting the component
+		_, err := stack.SnapshotState(createTestBlueprint(), "local/path")
+
+		// Then the write error is returned
+		if err == nil || !strings.Contains(err.Error(), "error writing state snapshot") {
+			t.Errorf("Expected write error, got %v", err)
+		}
+	})
+}
+
+func TestStack_RestoreState(t *testing.T) {
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks) {
+		t.Helper()
+		mocks := setupWindsorStackMocks(t)
+		mocks.Runtime.WindsorScratchPath = filepath.Join(mocks.Runtime.ProjectRoot, ".windsor", "contexts", "local")
+		mocks.Shims.MkdirAll = os.MkdirAll
+		mocks.Shims.WriteFile = os.WriteFile
+		mocks.Shims.ReadFile = os.ReadFile
+		mocks.Shims.ReadDir = os.ReadDir
+		mocks.Shims.MkdirTemp = os.MkdirTemp
+		mocks.Shims.RemoveAll = os.RemoveAll
+		stack := NewStack(mocks.Runtime).(*TerraformStack)
+		stack.shims = mocks.Shims
+		return stack, mocks
+	}
+
+	writeSnapshot := func(t *testing.T, stack *TerraformStack, id, content string) {
+		t.Helper()
+		dir := stack.stateSnapshotPath("local/path")
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatalf("Failed to create snapshot directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, id+stateSnapshotExt), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write snapshot: %v", err)
+		}
+	}
+
+	t.Run("PushesSnapshotWithRaisedSerial", func(t *testing.T) {
+		// Given a snapshot older than the current state of the same lineage
+		stack, mocks := setup(t)
+		writeSnapshot(t, stack, "20260101T000000.000000000Z", `{"version":4,"serial":3,"lineage":"abc","resources":[]}`)
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			_, err := io.WriteString(w, `{"version":4,"serial":9,"lineage":"abc","resources":[]}`)
+			return err
+		}
+		var pushed map[string]any
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) == 4 && args[1] == "state" && args[2] == "push" {
+				data, err := os.ReadFile(args[3])
+				if err != nil {
+					return "", err
+				}
+				return "", json.Unmarshal(data, &pushed)
+			}
+			return "", nil
+		}
+
+		// When restoring the snapshot
+		backupPath, err := stack.RestoreState(createTestBlueprint(), "local/path", "20260101T000000.000000000Z")
+
+		// Then the snapshot is pushed with a serial above the current one and the replaced state is kept
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if pushed == nil || pushed["serial"] != float64(10) || pushed["lineage"] != "abc" {
+			t.Errorf("Expected pushed state with serial 10 and lineage abc, got %v", pushed)
+		}
+		data, err := os.ReadFile(backupPath)
+		if err != nil {
+			t.Fatalf("Expected backup snapshot to exist, got %v", err)
+		}
+		if !strings.Contains(string(data), `"serial":9`) {
+			t.Errorf("Expected backup to hold the replaced state, got %q", string(data))
+		}
+	})
+
+	t.Run("RefusesDifferentLineage", func(t *testing.T) {
+		// Given a snapshot whose lineage differs from the current state
+		stack, mocks := setup(t)
+		writeSnapshot(t, stack, "1", `{"serial":3,"lineage":"abc"}`)
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			_, err := io.WriteString(w, `{"serial":9,"lineage":"xyz"}`)
+			return err
+		}
+		pushed := false
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) >= 3 && args[1] == "state" && args[2] == "push" {
+				pushed = true
+			}
+			return "", nil
+		}
+
+		// When restoring the snapshot
+		_, err := stack.RestoreState(createTestBlueprint(), "local/path", "1")
+
+		// Then the restore is refused without pushing
+		if err == nil || !strings.Contains(err.Error(), "refusing to replace an unrelated state") {
+			t.Errorf("Expected lineage mismatch error, got %v", err)
+		}
+		if pushed {
+			t.Error("Expected no state push on lineage mismatch")
+		}
+	})
+
+	t.Run("SnapshotNotFoundListsAvailable", func(t *testing.T) {
+		// Given a component with one recorded snapshot
+		stack, _ := setup(t)
+		writeSnapshot(t, stack, "20260101T000000.000000000Z", `{"serial":3,"lineage":"abc"}`)
+
+		// When restoring a snapshot that does not exist
+		_, err := stack.RestoreState(createTestBlueprint(), "local/path", "20250101T000000.000000000Z")
+
+		// Then the error lists the recorded snapshot IDs
+		if err == nil || !strings.Contains(err.Error(), "available: 20260101T000000.000000000Z") {
+			t.Errorf("Expected not found error listing available snapshots, got %v", err)
+		}
+	})
+
+	t.Run("RejectsPathTraversal", func(t *testing.T) {
+		// Given a stack
+		stack, _ := setup(t)
+
+		// When restoring a snapshot ID that escapes the snapshot directory
+		_, err := stack.RestoreState(createTestBlueprint(), "local/path", "../../other")
+
+		// Then the ID is rejected
+		if err == nil || !strings.Contains(err.Error(), "invalid state snapshot ID") {
+			t.Errorf("Expected invalid snapshot ID error, got %v", err)
+		}
+	})
+
+	t.Run("NilBlueprint", func(t *testing.T) {
+		// Given a stack
+		stack, _ := setup(t)
+
+		// When restoring with a nil blueprint
+		_, err := stack.RestoreState(nil, "local/path", "1")
+
+		// Then an error should occur
+		if err == nil || !strings.Contains(err.Error(), "blueprint not provided") {
+			t.Errorf("Expected blueprint not provided error, got %v", err)
+		}
+	})
+}
+
+func TestStack_AutomaticSnapshots(t *testing.T) {
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks, *[]string) {
+		t.Helper()
+		mocks := setupWindsorStackMocks(t)
+		mocks.Runtime.WindsorScratchPath = filepath.Join(mocks.Runtime.ProjectRoot, ".windsor", "contexts", "local")
+		var written []string
+		mocks.Shims.WriteFile = func(path string, _ []byte, _ os.FileMode) error {
+			if strings.HasSuffix(path, stateSnapshotExt) {
+				written = append(written, path)
+			}
+			return nil
+		}
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) >= 3 && args[1] == "show" && args[2] == "-json" {
+				return `{"values":{"root_module":{"resources":[{"address":"null_resource.a"}]}}}`, nil
+			}
+			return "", nil
+		}
+		mocks.Shell.ExecToWriterWithEnvFunc = func(command string, env map[string]string, w io.Writer, args ...string) error {
+			_, err := io.WriteString(w, `{"serial":1,"lineage":"abc"}`)
+			return err
+		}
+		stack := NewStack(mocks.Runtime).(*TerraformStack)
+		stack.shims = mocks.Shims
+		return stack, mocks, &written
+	}
+
+	t.Run("ApplySnapshotsNonEmptyState", func(t *testing.T) {
+		// Given a component whose state holds resources
+		stack, _, written := setup(t)
+
+		// When applying the component
+		if err := stack.Apply(createTestBlueprint(), "local/path"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then one snapshot is written to the component's snapshot directory
+		if len(*written) != 1 || filepath.Dir((*written)[0]) != stack.stateSnapshotPath("local/path") {
+			t.Errorf("Expected one snapshot under %s, got %v", stack.stateSnapshotPath("local/path"), *written)
+		}
+	})
+
+	t.Run("DestroySnapshotsNonEmptyState", func(t *testing.T) {
+		// Given a component whose state holds resources
+		stack, _, written := setup(t)
+
+		// When destroying the component
+		if _, err := stack.Destroy(createTestBlueprint(), "local/path"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the state is snapshotted before it is torn down
+		if len(*written) != 1 {
+			t.Errorf("Expected one snapshot before destroy, got %v", *written)
+		}
+	})
+
+	t.Run("UnchangedStateSkipsSnapshot", func(t *testing.T) {
+		// Given a component whose newest snapshot holds its current lineage and serial
+		stack, mocks, written := setup(t)
+		mocks.Shims.ReadDir = os.ReadDir
+		mocks.Shims.ReadFile = os.ReadFile
+		dir := stack.stateSnapshotPath("local/path")
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatalf("Failed to create snapshot directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "20260101T000000.000000000Z"+stateSnapshotExt), []byte(`{"serial":1,"lineage":"abc"}`), 0o600); err != nil {
+			t.Fatalf("Failed to write snapshot: %v", err)
+		}
+
+		// When applying the component
+		if err := stack.Apply(createTestBlueprint(), "local/path"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then no snapshot is written
+		if len(*written) != 0 {
+			t.Errorf("Expected no snapshot of unchanged state, got %v", *written)
+		}
+	})
+
+	t.Run("ChangedStateSnapshots", func(t *testing.T) {
+		// Given a component whose newest snapshot holds an older serial
+		stack, mocks, written := setup(t)
+		mocks.Shims.ReadDir = os.ReadDir
+		mocks.Shims.ReadFile = os.ReadFile
+		dir := stack.stateSnapshotPath("local/path")
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatalf("Failed to create snapshot directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "20260101T000000.000000000Z"+stateSnapshotExt), []byte(`{"serial":0,"lineage":"abc"}`), 0o600); err != nil {
+			t.Fatalf("Failed to write snapshot: %v", err)
+		}
+
+		// When applying the component
+		if err := stack.Apply(createTestBlueprint(), "local/path"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the changed state is snapshotted
+		if len(*written) != 1 {
+			t.Errorf("Expected one snapshot of changed state, got %v", *written)
+		}
+	})
+
+	t.Run("RotationPrunesOnlyAutomaticSnapshots", func(t *testing.T) {
+		// Given a context that keeps two automatic snapshots and a pinned migration snapshot
+		stack, mocks, _ := setup(t)
+		if err := mocks.ConfigHandler.Set("terraform.snapshots.keep", 2); err != nil {
+			t.Fatalf("Failed to set snapshot keep: %v", err)
+		}
+		mocks.Shims.ReadDir = os.ReadDir
+		mocks.Shims.MkdirAll = os.MkdirAll
+		mocks.Shims.WriteFile = os.WriteFile
+		mocks.Shims.Remove = os.Remove
+		component := &createTestBlueprint().TerraformComponents[0]
+		pinned, err := stack.writeStateSnapshot(component, []byte(`{"serial":1,"lineage":"abc"}`), stateSnapshotKindMigrate)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// When writing three automatic snapshots
+		var paths []string
+		for range 3 {
+			path, err := stack.writeStateSnapshot(component, []byte(`{"serial":1,"lineage":"abc"}`), "")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			paths = append(paths, path)
+		}
+
+		// Then the oldest automatic snapshot is pruned and the migration snapshot remains
+		if ids := stack.stateSnapshotIDs(component.GetID()); len(ids) != 3 {
+			t.Errorf("Expected 3 snapshots to remain, got %v", ids)
+		}
+		if _, err := os.Stat(paths[0]); !os.IsNotExist(err) {
+			t.Errorf("Expected oldest automatic snapshot to be pruned, got %v", err)
+		}
+		if _, err := os.Stat(pinned); err != nil {
+			t.Errorf("Expected migration snapshot to remain, got %v", err)
+		}
+	})
+
+	t.Run("DisabledSkipsSnapshot", func(t *testing.T) {
+		// Given a context with automatic snapshots disabled
+		stack, mocks, written := setup(t)
+		if err := mocks.ConfigHandler.Set("terraform.snapshots.enabled", false); err != nil {
+			t.Fatalf("Failed to disable snapshots: %v", err)
+		}
+
+		// When applying the component
+		if err := stack.Apply(createTestBlueprint(), "local/path"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then no snapshot is written
+		if len(*written) != 0 {
+			t.Errorf("Expected no snapshots when disabled, got %v", *written)
+		}
+	})
+
+	t.Run("MigrationSnapshotsLocalStateFile", func(t *testing.T) {
+		// Given a component with a local state file
+		stack, mocks, written := setup(t)
+		blueprint := createTestBlueprint()
+		localStatePath, err := mocks.Runtime.TerraformProvider.GetStatePath(blueprint.TerraformComponents[1].GetID())
+		if err != nil {
+			t.Fatalf("Failed to resolve local state path: %v", err)
+		}
+		mocks.Shims.ReadFile = func(path string) ([]byte, error) {
+			if path == localStatePath {
+				return []byte(`{"serial":2,"lineage":"abc"}`), nil
+			}
+			return nil, os.ErrNotExist
+		}
+
+		// When migrating the component's state
+		if err := stack.MigrateComponentState(blueprint, blueprint.TerraformComponents[1].GetID()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then the local state file is snapshotted before migration
+		if len(*written) != 1 {
+			t.Errorf("Expected one snapshot before migration, got %v", *written)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	blueprintv1alpha1 "github.com/windsorcli/cli/api/v1alpha1"
+	envvars "github.com/windsorcli/cli/pkg/runtime/env"
+	"github.com/windsorcli/cli/pkg/tui"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// ApplyOptions narrows a single-component apply. Targets and Replace are terraform resource
+// addresses passed to plan as -target and -replace; RefreshOnly plans with -refresh-only so
+// only state is reconciled. The options are planning flags, so they are added to the plan
+// arguments and carried into apply by the saved plan file — terraform rejects them when they
+// are passed alongside a plan file. Confirmed acknowledges that a targeted apply may leave
+// other changes in the component unapplied; without it, ApplyWithOptions refuses with a
+// PartialApplyError when it would.
+type ApplyOptions struct {
+	Targets     []string
+	Replace     []string
+	RefreshOnly bool
+	Confirmed   bool
+}
+
+// PartialApplyError reports that a targeted apply would leave changes outside its targets
+// pending, so the component would be only partially converged with its configuration.
+// Pending lists those changes as the untargeted plan reported them.
+type PartialApplyError struct {
+	ComponentID string
+	Pending     []ResourceChange
+}
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Error names the component and every change the targeted apply would leave pending.
+func (e *PartialApplyError) Error() string {
+	addrs := make([]string, 0, len(e.Pending))
+	for _, rc := range e.Pending {
+		addrs = append(addrs, rc.Address)
+	}
+	return fmt.Sprintf("targeted apply would leave %s partially converged: %d other change(s) pending: %s", e.ComponentID, len(e.Pending), strings.Join(addrs, ", "))
+}
+
+// IsZero reports whether the options leave the apply unchanged from a plain Apply.
+func (o ApplyOptions) IsZero() bool {
+	return len(o.Targets) == 0 && len(o.Replace) == 0 && !o.RefreshOnly
+}
+
+// ApplyWithOptions runs terraform init, plan, and apply for a single component as Apply does,
+// with the plan narrowed by opts. A refresh-only apply skips the pre-plan refresh, since the
+// refresh-only plan performs it, but still snapshots non-empty state first. When targets are
+// given and opts.Confirmed is false, the component is first planned without targets and the
+// apply is refused with a PartialApplyError if that plan has changes the targeted plan does
+// not. Returns an error if the component is not found or any terraform operation fails.
+func (s *TerraformStack) ApplyWithOptions(blueprint *blueprintv1alpha1.Blueprint, componentID string, opts ApplyOptions) error {
+	if blueprint == nil {
+		return fmt.Errorf("blueprint not provided")
+	}
+	if componentID == "" {
+		return fmt.Errorf("component ID not provided")
+	}
+
+	component, terraformVars, scopedKeys, terraformArgs, cleanup, err := s.prepareComponentOp(blueprint, componentID)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	terraformVars["TF_VAR_operation"] = "apply"
+
+	return tui.WithProgress(applyProgressLabel(component.Path, opts), func() error {
+		if err := s.runTerraformInit(component, terraformVars, scopedKeys, terraformArgs, defaultInitFlags...); err != nil {
+			return err
+		}
+
+		if opts.RefreshOnly {
+			hasResources, err := s.hasStateResources(component, terraformVars, scopedKeys)
+			if err != nil {
+				return err
+			}
+			if hasResources {
+				if err := s.snapshotBeforeChange(component, terraformVars, scopedKeys); err != nil {
+					return err
+				}
+			}
+		} else if err := s.refreshIfStateNonEmpty(component, terraformVars, scopedKeys, terraformArgs); err != nil {
+			return err
+		}
+
+		if len(opts.Targets) > 0 && !opts.Confirmed {
+			if err := s.checkTargetedConvergence(component, terraformVars, scopedKeys, terraformArgs, opts); err != nil {
+				return err
+			}
+		} else if err := s.runApplyPlan(component, terraformVars, scopedKeys, terraformArgs, opts); err != nil {
+			return err
+		}
+
+		if err := s.checkPlan(component, terraformVars, scopedKeys, terraformArgs); err != nil {
+			return err
+		}
+
+		terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+		applyArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "apply"}
+		applyArgs = append(applyArgs, terraformArgs.ApplyArgs...)
+		applyEnv := selectTerraformCommandEnv(terraformVars, false, scopedKeys)
+		if _, err := s.runtime.Shell.ExecProgressWithEnv(fmt.Sprintf("Applying Terraform changes in %s", component.Path), terraformCommand, applyEnv, applyArgs...); err != nil {
+			return fmt.Errorf("error running terraform apply for %s: %w", component.Path, err)
+		}
+		_ = s.runtime.TerraformProvider.CacheOutputs(component.GetID())
+
+		return nil
+	})
+}
+
+// =============================================================================
+// Private Methods
+// =============================================================================
+
+// runApplyPlan writes component's saved plan with the planning flags from opts appended to
+// the generated plan arguments. State was refreshed beforehand, so the plan runs with
+// -refresh=false unless it is a refresh-only plan, where terraform rejects that flag.
+func (s *TerraformStack) runApplyPlan(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string, terraformArgs *envvars.TerraformArgs, opts ApplyOptions) error {
+	terraformCommand := s.runtime.ToolsManager.GetTerraformCommand()
+	planArgs := []string{fmt.Sprintf("-chdir=%s", component.FullPath), "plan"}
+	if !opts.RefreshOnly {
+		planArgs = append(planArgs, "-refresh=false")
+	}
+	planArgs = append(planArgs, terraformArgs.PlanArgs...)
+	planArgs = append(planArgs, applyOptionFlags(opts)...)
+	planEnv := selectTerraformCommandEnv(terraformVars, true, scopedKeys)
+	if _, err := s.runtime.Shell.ExecSilentWithEnv(terraformCommand, planEnv, planArgs...); err != nil {
+		return fmt.Errorf("error running terraform plan for %s: %w", component.Path, err)
+	}
+	return nil
+}
+
+// checkTargetedConvergence plans component once without its targets and once with them, and
+// returns a PartialApplyError listing the untargeted plan's changes that the targeted plan
+// leaves out. The targeted plan runs second, so it is the saved plan left for apply.
+func (s *TerraformStack) checkTargetedConvergence(component *blueprintv1alpha1.TerraformComponent, terraformVars map[string]string, scopedKeys []string, terraformArgs *envvars.TerraformArgs, opts ApplyOptions) error {
+	untargeted := opts
+	untargeted.Targets = nil
+	if err := s.runApplyPlan(component, terraformVars, scopedKeys, terraformArgs, untargeted); err != nil {
+		return err
+	}
+	full, err := s.showPlanChanges(component, terraformVars, scopedKeys, terraformArgs)
+	if err != nil {
+		return err
+	}
+
+	if err := s.runApplyPlan(component, terraformVars, scopedKeys, terraformArgs, opts); err != nil {
+		return err
+	}
+	targeted, err := s.showPlanChanges(component, terraformVars, scopedKeys, terraformArgs)
+	if err != nil {
+		return err
+	}
+
+	covered := make(map[string]bool, len(targeted))
+	for _, rc := range targeted {
+		covered[rc.Address] = true
+	}
+	var pending []ResourceChange
+	for _, rc := range full {
+		if !covered[rc.Address] {
+			pending = append(pending, rc)
+		}
+	}
+	if len(pending) > 0 {
+		return &PartialApplyError{ComponentID: component.GetID(), Pending: pending}
+	}
+	return nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// applyOptionFlags returns the terraform plan flags for opts: one -target and one -replace
+// per address, in the order given, followed by -refresh-only when requested.
+func applyOptionFlags(opts ApplyOptions) []string {
+	var flags []string
+	for _, addr := range opts.Targets {
+		flags = append(flags, "-target="+addr)
+	}
+	for _, addr := range opts.Replace {
+		flags = append(flags, "-replace="+addr)
+	}
+	if opts.RefreshOnly {
+		flags = append(flags, "-refresh-only")
+	}
+	return flags
+}
+
+// applyProgressLabel returns the progress line for a single-component apply, naming any
+// targets, replacements, and refresh-only mode so a narrowed apply is visibly distinct
+// from a full one.
+func applyProgressLabel(path string, opts ApplyOptions) string {
+	if opts.IsZero() {
+		return fmt.Sprintf("Applying %s", path)
+	}
+	var parts []string
+	if len(opts.Targets) > 0 {
+		parts = append(parts, "target: "+strings.Join(opts.Targets, ", "))
+	}
+	if len(opts.Replace) > 0 {
+		parts = append(parts, "replace: "+strings.Join(opts.Replace, ", "))
+	}
+	if opts.RefreshOnly {
+		parts = append(parts, "refresh-only")
+	}
+	return fmt.Sprintf("Applying %s (%s)", path, strings.Join(parts, "; "))
+}
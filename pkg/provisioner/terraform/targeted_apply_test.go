@@ -0,0 +1,168 @@
+package terraform
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Test Public Methods
+// =============================================================================
+
+func TestStack_ApplyWithOptions(t *testing.T) {
+	// fullPlan and targetedPlan are the `terraform show -json <planfile>` bodies returned after
+	// an untargeted and a targeted plan respectively.
+	const fullPlan = `{"resource_changes":[
+		{"address":"module.main.aws_route53_record.api","change":{"actions":["update"]}},
+		{"address":"module.main.aws_route53_record.www","change":{"actions":["create"]}}]}`
+	const targetedPlan = `{"resource_changes":[
+		{"address":"module.main.aws_route53_record.api","change":{"actions":["update"]}}]}`
+
+	setup := func(t *testing.T) (*TerraformStack, *TerraformTestMocks, *[][]string, *bool) {
+		t.Helper()
+		mocks := setupWindsorStackMocks(t)
+		stack := NewStack(mocks.Runtime).(*TerraformStack)
+		stack.shims = mocks.Shims
+		var plans [][]string
+		mocks.Shell.ExecSilentWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) >= 2 && args[1] == "plan" {
+				plans = append(plans, args)
+			}
+			return "", nil
+		}
+		mocks.Shell.ExecCaptureWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) == 4 && args[1] == "show" && len(plans) > 0 {
+				if slices.ContainsFunc(plans[len(plans)-1], func(a string) bool { return strings.HasPrefix(a, "-target=") }) {
+					return targetedPlan, nil
+				}
+				return fullPlan, nil
+			}
+			return `{}`, nil
+		}
+		applied := false
+		mocks.Shell.ExecProgressWithEnvFunc = func(message string, command string, env map[string]string, args ...string) (string, error) {
+			if len(args) >= 2 && args[1] == "apply" {
+				applied = true
+			}
+			return "", nil
+		}
+		return stack, mocks, &plans, &applied
+	}
+
+	t.Run("AppendsPlanningFlags", func(t *testing.T) {
+		// Given a stack
+		stack, _, plans, applied := setup(t)
+
+		// When applying with confirmed targets and a replacement
+		err := stack.ApplyWithOptions(createTestBlueprint(), "local/path", ApplyOptions{
+			Targets:   []string{"aws_route53_record.api"},
+			Replace:   []string{`aws_instance.node["a"]`},
+			Confirmed: true,
+		})
+
+		// Then a single plan carries the flags after the generated plan arguments and is applied
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(*plans) != 1 {
+			t.Fatalf("Expected one plan, got %v", *plans)
+		}
+		plan := (*plans)[0]
+		if !slices.Contains(plan, "-refresh=false") || !slices.Contains(plan, "-target=aws_route53_record.api") || !slices.Contains(plan, `-replace=aws_instance.node["a"]`) {
+			t.Errorf("Expected refresh, target and replace flags, got %v", plan)
+		}
+		if !*applied {
+			t.Error("Expected apply to run")
+		}
+	})
+
+	t.Run("RefreshOnlyDropsRefreshFalse", func(t *testing.T) {
+		// Given a stack
+		stack, _, plans, _ := setup(t)
+
+		// When applying refresh-only
+		err := stack.ApplyWithOptions(createTestBlueprint(), "local/path", ApplyOptions{RefreshOnly: true})
+
+		// Then the plan is refresh-only and does not disable refresh
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		plan := (*plans)[len(*plans)-1]
+		if !slices.Contains(plan, "-refresh-only") || slices.Contains(plan, "-refresh=false") {
+			t.Errorf("Expected -refresh-only without -refresh=false, got %v", plan)
+		}
+	})
+
+	t.Run("RefusesPartialConvergence", func(t *testing.T) {
+		// Given a component whose full plan has a change outside the target
+		stack, _, _, applied := setup(t)
+
+		// When applying the target without confirmation
+		err := stack.ApplyWithOptions(createTestBlueprint(), "local/path", ApplyOptions{Targets: []string{"aws_route53_record.api"}})
+
+		// Then the apply is refused and the pending change is named
+		var partial *PartialApplyError
+		if !errors.As(err, &partial) {
+			t.Fatalf("Expected PartialApplyError, got %v", err)
+		}
+		if len(partial.Pending) != 1 || partial.Pending[0].Address != "aws_route53_record.www" {
+			t.Errorf("Expected www record pending, got %+v", partial.Pending)
+		}
+		if *applied {
+			t.Error("Expected apply not to run")
+		}
+	})
+
+	t.Run("AppliesTargetCoveringAllChanges", func(t *testing.T) {
+		// Given a target whose plan covers every change in the component
+		stack, mocks, plans, applied := setup(t)
+		mocks.Shell.ExecCaptureWithEnvFunc = func(command string, env map[string]string, args ...string) (string, error) {
+			if len(args) == 4 && args[1] == "show" {
+				return targetedPlan, nil
+			}
+			return `{}`, nil
+		}
+
+		// When applying the target without confirmation
+		err := stack.ApplyWithOptions(createTestBlueprint(), "local/path", ApplyOptions{Targets: []string{"aws_route53_record.api"}})
+
+		// Then the targeted plan, planned last, is applied
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(*plans) != 2 || !slices.Contains((*plans)[1], "-target=aws_route53_record.api") {
+			t.Errorf("Expected an untargeted then a targeted plan, got %v", *plans)
+		}
+		if !*applied {
+			t.Error("Expected apply to run")
+		}
+	})
+}
+
+func TestApplyProgressLabel(t *testing.T) {
+	t.Run("NamesOptions", func(t *testing.T) {
+		// Given options with targets, replacements, and refresh-only
+		opts := ApplyOptions{Targets: []string{"a", "b"}, Replace: []string{"c"}, RefreshOnly: true}
+
+		// When building the progress label
+		label := applyProgressLabel("dns", opts)
+
+		// Then every option is shown
+		if label != "Applying dns (target: a, b; replace: c; refresh-only)" {
+			t.Errorf("Unexpected label %q", label)
+		}
+	})
+
+	t.Run("PlainApply", func(t *testing.T) {
+		// Given no options
+		// When building the progress label
+		label := applyProgressLabel("dns", ApplyOptions{})
+
+		// Then the label matches a plain apply
+		if label != "Applying dns" {
+			t.Errorf("Unexpected label %q", label)
+		}
+	})
+}
@@ -159,4 +159,48 @@ func TestSchemaArtifacts_BlueprintFieldsPresent(t *testing.T) {
 			t.Error("expected invalid for unknown flux field")
 		}
 	})
+
+	t.Run("flux.install.healthChecks validates", func(t *testing.T) {
+		// Given the production blueprint schema artifact
+		validator := loadArtifactValidator(t, "blueprint.yaml")
+
+		// When validating an install tier with a health check and a CEL rule
+		result, err := validator.Validate(baseBlueprint(map[string]any{
+			"name": "database",
+			"install": map[string]any{
+				"healthChecks": []any{map[string]any{"apiVersion": "postgresql.cnpg.io/v1", "kind": "Cluster", "name": "db"}},
+				"healthCheckExprs": []any{map[string]any{
+					"apiVersion": "postgresql.cnpg.io/v1",
+					"kind":       "Cluster",
+					"current":    "status.readyInstances == status.instances",
+				}},
+			},
+		}))
+
+		// Then it validates without error
+		if err != nil {
+			t.Fatalf("Validate returned error: %v", err)
+		}
+		if !result.Valid {
+			t.Errorf("expected valid, got errors: %v", result.Errors)
+		}
+	})
+
+	t.Run("flux.install.healthCheckExprs requires current", func(t *testing.T) {
+		// Given the production blueprint schema artifact
+		validator := loadArtifactValidator(t, "blueprint.yaml")
+
+		// When validating a CEL rule without a current expression
+		result, _ := validator.Validate(baseBlueprint(map[string]any{
+			"name": "database",
+			"install": map[string]any{
+				"healthCheckExprs": []any{map[string]any{"apiVersion": "postgresql.cnpg.io/v1", "kind": "Cluster"}},
+			},
+		}))
+
+		// Then it is rejected
+		if result.Valid {
+			t.Error("expected invalid for healthCheckExprs entry without current")
+		}
+	})
 }
@@ -51,7 +51,9 @@ func NewGcpEnvPrinter(shell shell.Shell, configHandler config.ConfigHandler) *Gc
 // is only emitted when gcp.credentials_path is set explicitly. The project
 // identifiers (GOOGLE_CLOUD_PROJECT, GCLOUD_PROJECT, GOOGLE_CLOUD_QUOTA_PROJECT)
 // are still emitted because they describe which GCP project the context
-// targets, not whose credentials are used.
+// targets, not whose credentials are used. When the terraform backend is gcs and
+// gcp.credentials_path is set, GOOGLE_BACKEND_CREDENTIALS points the backend at
+// the same key file so state access uses the context's credentials.
 func (e *GcpEnvPrinter) GetEnvVars() (map[string]string, error) {
 	envVars := make(map[string]string)
 	global := e.shell.IsGlobal()
@@ -84,6 +86,12 @@ func (e *GcpEnvPrinter) GetEnvVars() (map[string]string, error) {
 			}
 		}
 
+		if config.GCP.CredentialsPath != nil && e.configHandler.GetString("terraform.backend.type", "") == "gcs" {
+			if _, exists := e.shims.LookupEnv("GOOGLE_BACKEND_CREDENTIALS"); !exists {
+				envVars["GOOGLE_BACKEND_CREDENTIALS"] = *config.GCP.CredentialsPath
+			}
+		}
+
 		if config.GCP.ProjectID != nil {
 			envVars["GOOGLE_CLOUD_PROJECT"] = *config.GCP.ProjectID
 			envVars["GCLOUD_PROJECT"] = *config.GCP.ProjectID
@@ -392,6 +392,48 @@ contexts:
 		}
 	})
 
+	t.Run("GcsBackendUsesContextCredentials", func(t *testing.T) {
+		// Given a context with explicit GCP credentials and a gcs terraform backend
+		mocks := setupGcpEnvMocks(t)
+		configStr := `
+version: v1alpha1
+contexts:
+  test-context:
+    gcp:
+      enabled: true
+      credentials_path: "/path/to/credentials.json"
+`
+		if err := mocks.ConfigHandler.LoadConfigString(configStr); err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		mocks.ConfigHandler.(*config.MockConfigHandler).GetStringFunc = func(key string, defaultValue ...string) string {
+			if key == "terraform.backend.type" {
+				return "gcs"
+			}
+			if len(defaultValue) > 0 {
+				return defaultValue[0]
+			}
+			return ""
+		}
+		printer := NewGcpEnvPrinter(mocks.Shell, mocks.ConfigHandler)
+		printer.shims = mocks.Shims
+
+		mocks.Shims.LookupEnv = func(key string) (string, bool) {
+			return "", false
+		}
+
+		// When getting environment variables
+		envVars, err := printer.GetEnvVars()
+
+		// Then the backend should be pointed at the context's credentials
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if envVars["GOOGLE_BACKEND_CREDENTIALS"] != "/path/to/credentials.json" {
+			t.Errorf("GetEnvVars returned GOOGLE_BACKEND_CREDENTIALS=%v, want /path/to/credentials.json", envVars["GOOGLE_BACKEND_CREDENTIALS"])
+		}
+	})
+
 	t.Run("ServiceAccountFileExists", func(t *testing.T) {
 		mocks := setupGcpEnvMocks(t)
 		configStr := `
@@ -1060,7 +1060,7 @@ func TestTerraformEnv_generateBackendOverrideTf(t *testing.T) {
 	t.Run("UnsupportedBackend", func(t *testing.T) {
 		// Given a TerraformEnvPrinter with unsupported backend configuration
 		printer, mocks := setup(t)
-		mocks.ConfigHandler.Set("terraform.backend.type", "unsupported")
+		mocks.ConfigHandler.Set("terraform.backend.type", "not-a-backend")
 
 		testDir := filepath.Join("test", "terraform", "module")
 		mocks.Shims.Getwd = func() (string, error) {
@@ -1080,8 +1080,8 @@ func TestTerraformEnv_generateBackendOverrideTf(t *testing.T) {
 		if err == nil {
 			t.Errorf("Expected error, got nil")
 		}
-		if !strings.Contains(err.Error(), "unsupported backend: unsupported") {
-			t.Errorf("Expected error message to contain 'unsupported backend: unsupported', got %v", err)
+		if !strings.Contains(err.Error(), "unsupported backend: not-a-backend") {
+			t.Errorf("Expected error message to contain 'unsupported backend: not-a-backend', got %v", err)
 		}
 	})
 
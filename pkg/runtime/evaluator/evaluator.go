@@ -17,6 +17,7 @@ import (
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/builtin"
 	"github.com/google/go-jsonnet"
 	"github.com/windsorcli/cli/pkg/runtime/config"
 	secretsRuntime "github.com/windsorcli/cli/pkg/runtime/secrets"
@@ -130,6 +131,7 @@ type ExpressionEvaluator interface {
 	SetEnvLookup(lookup func(name string) (string, bool))
 	SetConfigScope(scope map[string]any)
 	Evaluate(expression string, facetPath string, scope map[string]any, evaluateDeferred bool) (any, error)
+	EvaluateShadowingBuiltins(expression string, scope map[string]any) (any, error)
 	EvaluateMap(values map[string]any, facetPath string, scope map[string]any, evaluateDeferred bool) (map[string]any, error)
 }
 
@@ -210,7 +212,15 @@ func (e *expressionEvaluator) Register(name string, helper func(params []any, de
 // that scope (with runtime keys injected so helpers have context and paths). Returns the fully evaluated value,
 // or an error if evaluation fails or the input is malformed.
 func (e *expressionEvaluator) Evaluate(s string, facetPath string, scope map[string]any, evaluateDeferred bool) (any, error) {
-	return e.evaluate(s, facetPath, scope, evaluateDeferred)
+	return e.evaluate(s, facetPath, scope, evaluateDeferred, false)
+}
+
+// EvaluateShadowingBuiltins is Evaluate with deferred expressions resolved, for callers whose scope
+// defines its own vocabulary, such as policy variables: each scope key named like an expr builtin
+// resolves to its scope value, so a policy's `type == "aws_db_instance"` compares the resource type
+// rather than the type() function. Evaluate leaves every builtin intact whatever its scope holds.
+func (e *expressionEvaluator) EvaluateShadowingBuiltins(s string, scope map[string]any) (any, error) {
+	return e.evaluate(s, "", scope, true, true)
 }
 
 // EvaluateMap evaluates a map of values using this expression evaluator. Each string value is evaluated as an
@@ -245,8 +255,9 @@ func (e *expressionEvaluator) EvaluateMap(values map[string]any, facetPath strin
 // When scope is nil the config context is used; when non-nil (e.g. for yaml(path, input)) the given
 // scope is used. Stops after 20 iterations to avoid infinite loops on circular or pathological input.
 // When an expression returns DeferredError it is left in place and the loop advances to the next
-// ${...} so other expressions in the same string can still be resolved.
-func (e *expressionEvaluator) evaluate(s string, facetPath string, scope map[string]any, evaluateDeferred bool) (any, error) {
+// ${...} so other expressions in the same string can still be resolved. shadowBuiltins is passed
+// through to evaluateExpression.
+func (e *expressionEvaluator) evaluate(s string, facetPath string, scope map[string]any, evaluateDeferred, shadowBuiltins bool) (any, error) {
 	if !strings.Contains(s, "${") {
 		return s, nil
 	}
@@ -267,7 +278,7 @@ func (e *expressionEvaluator) evaluate(s string, facetPath string, scope map[str
 		if expr == "" {
 			return nil, fmt.Errorf("expression cannot be empty")
 		}
-		value, err := e.evaluateExpression(expr, facetPath, scope, evaluateDeferred)
+		value, err := e.evaluateExpression(expr, facetPath, scope, evaluateDeferred, shadowBuiltins)
 		if err != nil {
 			if !evaluateDeferred {
 				var deferredErr *DeferredError
@@ -319,8 +330,9 @@ func (e *expressionEvaluator) evaluate(s string, facetPath string, scope map[str
 // includes facet config blocks, so nil-scope consumers like secret resolution see computed config);
 // otherwise enriched context config alone (pre-composition, or when no composed scope was published).
 // Runtime keys (context, project_root, etc.) are injected so helpers like file() and jsonnet() have
-// context and paths. The expression should not include ${} bookends. Returns the result or an error.
-func (e *expressionEvaluator) evaluateExpression(expression string, facetPath string, scope map[string]any, evaluateDeferred bool) (any, error) {
+// context and paths. When shadowBuiltins is set, scope keys named like expr builtins replace them.
+// The expression should not include ${} bookends. Returns the result or an error.
+func (e *expressionEvaluator) evaluateExpression(expression string, facetPath string, scope map[string]any, evaluateDeferred, shadowBuiltins bool) (any, error) {
 	if rewritten, ok := secretsRuntime.NormalizeExpression(expression); ok {
 		expression = rewritten
 	}
@@ -338,6 +350,9 @@ func (e *expressionEvaluator) evaluateExpression(expression string, facetPath st
 		merged = e.enrichConfig(e.getConfig())
 	}
 	env := e.buildExprEnvironment(merged, facetPath, evaluateDeferred)
+	if shadowBuiltins {
+		env = append(env, shadowedBuiltins(scope)...)
+	}
 	program, err := expr.Compile(expression, env...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile expression '%s': %w", expression, err)
@@ -657,7 +672,7 @@ func (e *expressionEvaluator) buildExprEnvironment(config map[string]any, facetP
 func (e *expressionEvaluator) evaluateValue(value any, facetPath string, evaluateDeferred bool, scope map[string]any) (any, error) {
 	switch v := value.(type) {
 	case string:
-		evaluated, err := e.evaluate(v, facetPath, scope, evaluateDeferred)
+		evaluated, err := e.evaluate(v, facetPath, scope, evaluateDeferred, false)
 		if err != nil {
 			if !evaluateDeferred {
 				var deferredErr *DeferredError
@@ -959,7 +974,7 @@ func (e *expressionEvaluator) evaluateYamlStringFromFile(pathArg string, facetPa
 		scope := make(map[string]any)
 		maps.Copy(scope, enrichedConfig)
 		scope["input"] = input
-		interpolated, err := e.evaluate(content, facetPath, scope, true)
+		interpolated, err := e.evaluate(content, facetPath, scope, true, false)
 		if err != nil {
 			return "", fmt.Errorf("yamlString() failed to template: %w", err)
 		}
@@ -1031,7 +1046,7 @@ func (e *expressionEvaluator) evaluateYamlFunction(arg string, facetPath string,
 		scope := make(map[string]any)
 		maps.Copy(scope, enrichedConfig)
 		scope["input"] = input
-		interpolated, err := e.evaluate(content, facetPath, scope, true)
+		interpolated, err := e.evaluate(content, facetPath, scope, true, false)
 		if err != nil {
 			return nil, fmt.Errorf("yaml() failed to template: %w", err)
 		}
@@ -1459,6 +1474,19 @@ var _ HelperRegistrar = (*expressionEvaluator)(nil)
 // Helper Functions
 // =============================================================================
 
+// shadowedBuiltins disables each expr builtin whose name is a key of scope, so a caller-supplied
+// variable such as a policy's `type` resolves to its value rather than to the builtin function of
+// the same name.
+func shadowedBuiltins(scope map[string]any) []expr.Option {
+	var opts []expr.Option
+	for _, name := range builtin.Names {
+		if _, ok := scope[name]; ok {
+			opts = append(opts, expr.DisableBuiltin(name))
+		}
+	}
+	return opts
+}
+
 // valueToInterpolationString converts an expression result to a string for embedding in
 // interpolated output. Maps and slices (any kind, including map[interface{}]interface{} from
 // expr/YAML) are serialized as YAML so yamlString() output and facet templates get valid YAML.
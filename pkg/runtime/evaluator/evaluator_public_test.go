@@ -182,6 +182,23 @@ func TestExpressionEvaluator_Evaluate(t *testing.T) {
 		}
 	})
 
+	t.Run("ScopeVariableLeavesBuiltinIntact", func(t *testing.T) {
+		// Given an explicit scope with a key named like the len() builtin
+		evaluator, _, _, _ := setupEvaluatorTest(t)
+		scope := map[string]any{"len": 3, "items": []any{"a", "b"}}
+
+		// When evaluating an expression calling the builtin
+		result, err := evaluator.Evaluate(`${len(items)}`, "", scope, true)
+
+		// Then the builtin still resolves
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result != 2 {
+			t.Errorf("Expected 2, got %v", result)
+		}
+	})
+
 	t.Run("EvaluatesArithmeticExpression", func(t *testing.T) {
 		// Given an evaluator and config with values
 		evaluator, mockConfigHandler, _, _ := setupEvaluatorTest(t)
@@ -821,6 +838,42 @@ func TestExpressionEvaluator_Evaluate(t *testing.T) {
 	})
 }
 
+func TestExpressionEvaluator_EvaluateShadowingBuiltins(t *testing.T) {
+	t.Run("ScopeVariableShadowsBuiltin", func(t *testing.T) {
+		// Given an explicit scope with a key named like the type() builtin
+		evaluator, _, _, _ := setupEvaluatorTest(t)
+		scope := map[string]any{"type": "aws_db_instance"}
+
+		// When evaluating an expression comparing that key
+		result, err := evaluator.EvaluateShadowingBuiltins(`${type == "aws_db_instance"}`, scope)
+
+		// Then the scope value is used rather than the builtin
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result != true {
+			t.Errorf("Expected true, got %v", result)
+		}
+	})
+
+	t.Run("InterpolatesScopeVariables", func(t *testing.T) {
+		// Given an explicit scope with a key named like the type() builtin
+		evaluator, _, _, _ := setupEvaluatorTest(t)
+		scope := map[string]any{"type": "aws_s3_bucket"}
+
+		// When evaluating a message interpolating that key
+		result, err := evaluator.EvaluateShadowingBuiltins(`deny ${type}`, scope)
+
+		// Then the scope value is interpolated
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result != "deny aws_s3_bucket" {
+			t.Errorf("Expected %q, got %v", "deny aws_s3_bucket", result)
+		}
+	})
+}
+
 func TestExpressionEvaluator_EvaluateMap(t *testing.T) {
 	t.Run("HandlesEmptyMap", func(t *testing.T) {
 		evaluator, _, _, _ := setupEvaluatorTest(t)
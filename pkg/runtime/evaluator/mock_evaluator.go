@@ -5,12 +5,13 @@ package evaluator
 // It allows for controlled testing of evaluator-dependent functionality by providing mock implementations
 // of all ExpressionEvaluator interface methods.
 type MockExpressionEvaluator struct {
-	SetTemplateDataFunc func(templateData map[string][]byte)
-	SetEnvLookupFunc    func(lookup func(name string) (string, bool))
-	SetConfigScopeFunc  func(scope map[string]any)
-	RegisterFunc        func(name string, helper func(params []any, deferred bool) (any, error), signature any)
-	EvaluateFunc        func(expression string, facetPath string, scope map[string]any, evaluateDeferred bool) (any, error)
-	EvaluateMapFunc     func(values map[string]any, facetPath string, scope map[string]any, evaluateDeferred bool) (map[string]any, error)
+	SetTemplateDataFunc           func(templateData map[string][]byte)
+	SetEnvLookupFunc              func(lookup func(name string) (string, bool))
+	SetConfigScopeFunc            func(scope map[string]any)
+	RegisterFunc                  func(name string, helper func(params []any, deferred bool) (any, error), signature any)
+	EvaluateFunc                  func(expression string, facetPath string, scope map[string]any, evaluateDeferred bool) (any, error)
+	EvaluateShadowingBuiltinsFunc func(expression string, scope map[string]any) (any, error)
+	EvaluateMapFunc               func(values map[string]any, facetPath string, scope map[string]any, evaluateDeferred bool) (map[string]any, error)
 }
 
 // =============================================================================
@@ -62,6 +63,14 @@ func (m *MockExpressionEvaluator) Evaluate(expression string, facetPath string,
 	return nil, nil
 }
 
+// EvaluateShadowingBuiltins calls the mock EvaluateShadowingBuiltinsFunc if set, otherwise returns nil, nil.
+func (m *MockExpressionEvaluator) EvaluateShadowingBuiltins(expression string, scope map[string]any) (any, error) {
+	if m.EvaluateShadowingBuiltinsFunc != nil {
+		return m.EvaluateShadowingBuiltinsFunc(expression, scope)
+	}
+	return nil, nil
+}
+
 // EvaluateMap calls the mock EvaluateMapFunc if set, otherwise returns an empty map and nil error.
 func (m *MockExpressionEvaluator) EvaluateMap(values map[string]any, facetPath string, scope map[string]any, evaluateDeferred bool) (map[string]any, error) {
 	if m.EvaluateMapFunc != nil {
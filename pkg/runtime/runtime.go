@@ -752,7 +752,8 @@ func (rt *Runtime) initializeEnvPrinters() {
 	if rt.EnvPrinters.AzureEnv == nil && (hasAzureConfig || platform == "azure") {
 		rt.EnvPrinters.AzureEnv = env.NewAzureEnvPrinter(rt.Shell, rt.ConfigHandler)
 	}
-	if rt.EnvPrinters.GcpEnv == nil && gcpEnabled && hasGCPConfig {
+	gcsBackend := rt.ConfigHandler.GetString("terraform.backend.type", "") == "gcs"
+	if rt.EnvPrinters.GcpEnv == nil && (gcpEnabled || gcsBackend) && hasGCPConfig {
 		rt.EnvPrinters.GcpEnv = env.NewGcpEnvPrinter(rt.Shell, rt.ConfigHandler)
 	}
 	if rt.EnvPrinters.VsphereEnv == nil && (hasVSphereConfig || platform == "vsphere") {
@@ -2,6 +2,7 @@ package shell
 
 import (
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -23,6 +24,7 @@ type MockShell struct {
 	ExecSilentFunc                  func(command string, args ...string) (string, error)
 	ExecSilentWithEnvFunc           func(command string, env map[string]string, args ...string) (string, error)
 	ExecCaptureWithEnvFunc          func(command string, env map[string]string, args ...string) (string, error)
+	ExecToWriterWithEnvFunc         func(command string, env map[string]string, w io.Writer, args ...string) error
 	ExecSilentWithTimeoutFunc       func(command string, args []string, timeout time.Duration) (string, error)
 	ExecSilentWithEnvAndTimeoutFunc func(command string, env map[string]string, args []string, timeout time.Duration) (string, error)
 	ExecProgressFunc                func(message string, command string, args ...string) (string, error)
@@ -113,6 +115,20 @@ func (s *MockShell) ExecCaptureWithEnv(command string, env map[string]string, ar
 	return s.ExecSilentWithEnv(command, env, args...)
 }
 
+// ExecToWriterWithEnv calls the custom ExecToWriterWithEnvFunc if provided, otherwise writes the
+// output of ExecCaptureWithEnv to w.
+func (s *MockShell) ExecToWriterWithEnv(command string, env map[string]string, w io.Writer, args ...string) error {
+	if s.ExecToWriterWithEnvFunc != nil {
+		return s.ExecToWriterWithEnvFunc(command, env, w, args...)
+	}
+	output, err := s.ExecCaptureWithEnv(command, env, args...)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, output)
+	return err
+}
+
 // ExecSilentWithTimeout calls the custom ExecSilentWithTimeoutFunc if provided, otherwise delegates to ExecSilent.
 func (s *MockShell) ExecSilentWithTimeout(command string, args []string, timeout time.Duration) (string, error) {
 	if s.ExecSilentWithTimeoutFunc != nil {
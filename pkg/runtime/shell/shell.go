@@ -61,6 +61,7 @@ type Shell interface {
 	ExecSilent(command string, args ...string) (string, error)
 	ExecSilentWithEnv(command string, env map[string]string, args ...string) (string, error)
 	ExecCaptureWithEnv(command string, env map[string]string, args ...string) (string, error)
+	ExecToWriterWithEnv(command string, env map[string]string, w io.Writer, args ...string) error
 	ExecSilentWithTimeout(command string, args []string, timeout time.Duration) (string, error)
 	ExecSilentWithEnvAndTimeout(command string, env map[string]string, args []string, timeout time.Duration) (string, error)
 	ExecSudo(message string, command string, args ...string) (string, error)
@@ -284,6 +285,25 @@ func (s *DefaultShell) ExecCaptureWithEnv(command string, env map[string]string,
 	return s.scrubString(stdoutBuf.String()), nil
 }
 
+// ExecToWriterWithEnv runs a command with merged environment variables and copies its stdout to w
+// byte-for-byte. Unlike the capturing variants, stdout is neither scrubbed nor echoed: it is meant for
+// payloads that must round-trip exactly (e.g. `terraform state pull` into a snapshot), where replacing
+// a registered secret with a mask would corrupt the data. Stderr is captured and scrubbed into the error.
+func (s *DefaultShell) ExecToWriterWithEnv(command string, env map[string]string, w io.Writer, args ...string) error {
+	var stderrBuf bytes.Buffer
+	cmd := s.shims.Command(command, args...)
+	if cmd == nil {
+		return fmt.Errorf("failed to create command")
+	}
+	cmd.Env = mergeEnvVars(s.shims.Environ(), env)
+	cmd.Stdout = w
+	cmd.Stderr = &stderrBuf
+	if err := s.shims.CmdRun(cmd); err != nil {
+		return fmt.Errorf("command execution failed: %w\n%s", err, s.scrubString(stderrBuf.String()))
+	}
+	return nil
+}
+
 // ExecSilentWithTimeout executes a command with a timeout and returns the output.
 // If the command takes longer than the timeout, it kills the process and returns an error.
 // Uses ExecSilent internally but wraps it with a timeout mechanism.
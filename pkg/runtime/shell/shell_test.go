@@ -1461,6 +1461,70 @@ func TestShell_ExecCaptureWithEnv(t *testing.T) {
 	})
 }
 
+func TestShell_ExecToWriterWithEnv(t *testing.T) {
+	setup := func(t *testing.T) (*DefaultShell, *ShellTestMocks) {
+		t.Helper()
+		mocks := setupShellMocks(t)
+		shell := NewDefaultShell()
+		shell.shims = mocks.Shims
+		return shell, mocks
+	}
+
+	t.Run("WritesStdoutUnscrubbed", func(t *testing.T) {
+		// Given a shell with a registered secret whose command emits that secret
+		shell, mocks := setup(t)
+		shell.RegisterSecret("super-secret-state-value")
+		mocks.Shims.CmdRun = func(cmd *exec.Cmd) error {
+			_, err := cmd.Stdout.Write([]byte(`{"token":"super-secret-state-value"}`))
+			return err
+		}
+
+		// When writing the command's output to a buffer
+		var buf bytes.Buffer
+		err := shell.ExecToWriterWithEnv("test", nil, &buf, "arg")
+
+		// Then the payload is written byte-for-byte
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if buf.String() != `{"token":"super-secret-state-value"}` {
+			t.Errorf("Expected unscrubbed payload, got %q", buf.String())
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given a shell whose CmdRun returns an error
+		shell, mocks := setup(t)
+		mocks.Shims.CmdRun = func(cmd *exec.Cmd) error {
+			return fmt.Errorf("command failed")
+		}
+
+		// When writing the command's output
+		err := shell.ExecToWriterWithEnv("test", nil, io.Discard, "arg")
+
+		// Then the error is returned
+		if err == nil || !strings.Contains(err.Error(), "command failed") {
+			t.Errorf("Expected 'command failed' error, got %v", err)
+		}
+	})
+
+	t.Run("CommandNil", func(t *testing.T) {
+		// Given a shell whose Command shim returns nil
+		shell, mocks := setup(t)
+		mocks.Shims.Command = func(name string, args ...string) *exec.Cmd {
+			return nil
+		}
+
+		// When writing the command's output
+		err := shell.ExecToWriterWithEnv("test", nil, io.Discard, "arg")
+
+		// Then an error about command creation is returned
+		if err == nil || !strings.Contains(err.Error(), "failed to create command") {
+			t.Errorf("Expected error about command creation, got: %v", err)
+		}
+	})
+}
+
 func TestShell_GetSessionToken(t *testing.T) {
 	setup := func(t *testing.T) (*DefaultShell, *ShellTestMocks) {
 		t.Helper()
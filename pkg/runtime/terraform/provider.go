@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -25,6 +26,25 @@ import (
 	"github.com/windsorcli/cli/pkg/runtime/tools"
 )
 
+// =============================================================================
+// Constants
+// =============================================================================
+
+// passthroughStateArgs maps untyped backend types to the -backend-config key that scopes
+// state per component, and the separator used to join an operator-supplied base value
+// with the component's state key.
+var passthroughStateArgs = map[string]struct{ key, sep string }{
+	"consul": {key: "path", sep: "/"},
+	"cos":    {key: "prefix", sep: "/"},
+	"oss":    {key: "prefix", sep: "/"},
+	"pg":     {key: "schema_name", sep: "_"},
+}
+
+// passthroughBackends lists the terraform backends without a typed block that are passed
+// through with their settings from terraform.backend.config. Any other backend type is
+// rejected, so a misspelled type fails before it reaches backend_override.tf.
+var passthroughBackends = []string{"consul", "cos", "http", "oss", "pg", "remote"}
+
 // =============================================================================
 // Types
 // =============================================================================
@@ -201,7 +221,8 @@ func (p *terraformProvider) IsInTerraformProject() bool {
 // based on the configured backend type. This file is used to override Terraform backend configuration
 // at runtime without modifying the original Terraform files. If the backend type is 'none', it removes
 // the override file if it exists. Otherwise, it writes a backend_override.tf file with the appropriate
-// backend stanza for local, s3, kubernetes, or azurerm backends. Returns an error for unsupported backend types.
+// backend stanza for the configured type. The typed backends and those in passthroughBackends are
+// written as-is. Returns an error for any other backend type.
 func (p *terraformProvider) GenerateBackendOverride(directory string) error {
 	backend := p.configHandler.GetString("terraform.backend.type", "local")
 
@@ -230,9 +251,18 @@ func (p *terraformProvider) GenerateBackendOverride(directory string) error {
 	case "azurerm":
 		backendConfig = `terraform {
   backend "azurerm" {}
+}`
+	case "gcs":
+		backendConfig = `terraform {
+  backend "gcs" {}
 }`
 	default:
-		return fmt.Errorf("unsupported backend: %s", backend)
+		if !slices.Contains(passthroughBackends, backend) {
+			return fmt.Errorf("unsupported backend: %s", backend)
+		}
+		backendConfig = fmt.Sprintf(`terraform {
+  backend %q {}
+}`, backend)
 	}
 
 	backendOverridePath := filepath.Join(directory, "backend_override.tf")
@@ -713,7 +743,7 @@ func (p *terraformProvider) GetStatePath(componentID string) (string, error) {
 //
 // Default for unrecognized backend types is true: the predicate's job is to
 // catch the well-understood first-time-bootstrap case for the backends
-// Windsor knows about. For anything else (gcs, http, consul, remote, cos),
+// Windsor knows about. For anything else (http, consul, remote, cos),
 // we don't know what's required — let the probe run and surface real init
 // failures via the warning path rather than silently disable detection.
 func (p *terraformProvider) BackendConfigComplete() bool {
@@ -748,6 +778,9 @@ func (p *terraformProvider) BackendConfigComplete() bool {
 	case "s3":
 		return cfg != nil && cfg.Backend != nil && cfg.Backend.S3 != nil &&
 			cfg.Backend.S3.Bucket != nil
+	case "gcs":
+		return cfg != nil && cfg.Backend != nil && cfg.Backend.GCS != nil &&
+			cfg.Backend.GCS.Bucket != nil
 	}
 
 	return true
@@ -1113,7 +1146,9 @@ func (p *terraformProvider) restoreEnvVars(originalEnvVars map[string]string) {
 
 // generateBackendConfigArgs constructs the -backend-config CLI arguments for Terraform based on project configuration.
 // This method determines the backend type from the configuration and assembles key-value argument pairs for supported
-// backend types (local, s3, kubernetes, azurerm). It also detects the presence of backend.tfvars in the context root
+// backend types (local, s3, kubernetes, azurerm, gcs) and passes the backends in passthroughBackends through
+// using the free-form terraform.backend.config map, deriving a per-component state key for backends whose keying
+// argument is known. It also detects the presence of backend.tfvars in the context root
 // or a terraform/ fallback subdirectory, and includes a -backend-config pointing to that file if found.
 // Returns raw CLI arguments without shell quoting; formatting for environment variables is handled by the calling context.
 // Returns a slice of backend configuration arguments or an error if required configuration or paths are unavailable.
@@ -1187,13 +1222,69 @@ func (p *terraformProvider) generateBackendConfigArgs(projectPath, configRoot st
 				return nil, fmt.Errorf("error processing AzureRM backend config: %w", err)
 			}
 		}
+	case "gcs":
+		appendBackendTfvars()
+		statePrefix := fmt.Sprintf("%s%s", prefix, filepath.ToSlash(projectPath))
+		backend := p.configHandler.GetConfig().Terraform.Backend.GCS
+		if backend != nil && backend.Prefix != nil && *backend.Prefix != "" {
+			statePrefix = fmt.Sprintf("%s/%s", strings.TrimSuffix(*backend.Prefix, "/"), statePrefix)
+		}
+		addBackendConfigArg("prefix", statePrefix)
+		if backend != nil {
+			gcsConfig := *backend
+			gcsConfig.Prefix = nil
+			if err := p.processBackendConfig(gcsConfig, addBackendConfigArg); err != nil {
+				return nil, fmt.Errorf("error processing GCS backend config: %w", err)
+			}
+		}
 	default:
-		return nil, fmt.Errorf("unsupported backend: %s", backend)
+		if !slices.Contains(passthroughBackends, backend) {
+			return nil, fmt.Errorf("unsupported backend: %s", backend)
+		}
+		appendBackendTfvars()
+		stateKey := fmt.Sprintf("%s%s", prefix, filepath.ToSlash(projectPath))
+		backendConfig, err := p.evaluatePassthroughBackendConfig(stateKey)
+		if err != nil {
+			return nil, fmt.Errorf("error processing %s backend config: %w", backend, err)
+		}
+		if stateArg, ok := passthroughStateArgs[backend]; ok {
+			base, _ := backendConfig[stateArg.key].(string)
+			delete(backendConfig, stateArg.key)
+			addBackendConfigArg(stateArg.key, joinStateKey(base, stateKey, stateArg.sep))
+		}
+		processMap("", backendConfig, addBackendConfigArg)
 	}
 
 	return backendConfigArgs, nil
 }
 
+// evaluatePassthroughBackendConfig returns terraform.backend.config with every expression resolved, so
+// secret() values reach terraform only through -backend-config arguments and never through a file. The
+// evaluation scope is the provider's config scope (or the context values) plus state_key, the component's
+// prefixed state path, letting backends without a known keying argument (http, for example) build
+// per-component addresses. Returns an empty map when no config block is set.
+func (p *terraformProvider) evaluatePassthroughBackendConfig(stateKey string) (map[string]any, error) {
+	cfg := p.configHandler.GetConfig().Terraform
+	if cfg == nil || cfg.Backend == nil || len(cfg.Backend.Config) == 0 {
+		return map[string]any{}, nil
+	}
+	if p.evaluator == nil {
+		return maps.Clone(cfg.Backend.Config), nil
+	}
+
+	p.mu.RLock()
+	base := p.configScope
+	p.mu.RUnlock()
+	if base == nil {
+		base, _ = p.configHandler.GetContextValues()
+	}
+	scope := make(map[string]any, len(base)+1)
+	maps.Copy(scope, base)
+	scope["state_key"] = stateKey
+
+	return p.evaluator.EvaluateMap(cfg.Backend.Config, "", scope, true)
+}
+
 // processBackendConfig processes backend configuration and applies each key-value pair to the provided addArg function.
 // It marshals the provided backendConfig to YAML, then unmarshals it into a map structure to normalize the format.
 // It traverses the resulting map, applying each key-value pair to the addArg function. Nested configuration
@@ -1253,6 +1344,18 @@ func processMap(prefix string, configMap map[string]any, addArg func(key, value
 	}
 }
 
+// joinStateKey joins an operator-supplied base value with a component's state key using sep. Slashes in
+// the state key are rewritten to sep so backends that key state by identifier (pg schema names) get a
+// flat name. An empty base yields the state key alone.
+func joinStateKey(base, stateKey, sep string) string {
+	key := strings.ReplaceAll(stateKey, "/", sep)
+	base = strings.TrimSuffix(base, sep)
+	if base == "" {
+		return key
+	}
+	return base + sep + key
+}
+
 // sanitizeForK8s ensures a string is compatible with Kubernetes naming conventions by converting
 // to lowercase, replacing invalid characters, and trimming to a maximum length of 63 characters.
 func sanitizeForK8s(input string) string {
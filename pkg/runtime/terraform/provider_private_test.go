@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -723,8 +724,191 @@ func TestTerraformProvider_generateBackendConfigArgs(t *testing.T) {
 		}
 	})
 
+	t.Run("GeneratesGcsBackendArgs", func(t *testing.T) {
+		// Given a provider with gcs backend configuration pointing at a fake endpoint
+		mocks := setupMocks(t)
+		provider := mocks.Provider
+		mockConfig := provider.configHandler.(*config.MockConfigHandler)
+
+		configRoot := "/test/config"
+		mockConfig.GetConfigRootFunc = func() (string, error) {
+			return configRoot, nil
+		}
+
+		mockConfig.GetStringFunc = func(key string, defaultValue ...string) string {
+			if key == "terraform.backend.type" {
+				return "gcs"
+			}
+			if key == "terraform.backend.prefix" {
+				return "team/"
+			}
+			if len(defaultValue) > 0 {
+				return defaultValue[0]
+			}
+			return ""
+		}
+
+		mockConfig.GetContextFunc = func() string {
+			return "default"
+		}
+
+		mockConfig.GetConfigFunc = func() *v1alpha1.Context {
+			bucket := "state-bucket"
+			prefix := "windsor/"
+			endpoint := "http://127.0.0.1:4443/storage/v1/"
+			return &v1alpha1.Context{
+				Terraform: &terraform.TerraformConfig{
+					Backend: &terraform.BackendConfig{
+						GCS: &terraform.GCSBackend{
+							Bucket:                &bucket,
+							Prefix:                &prefix,
+							StorageCustomEndpoint: &endpoint,
+						},
+					},
+				},
+			}
+		}
+
+		provider.Shims.Stat = func(path string) (os.FileInfo, error) {
+			return nil, os.ErrNotExist
+		}
+
+		// When generating backend config args
+		args, err := provider.generateBackendConfigArgs("test/path", configRoot)
+
+		// Then it should generate a per-component prefix and pass the remaining gcs settings through
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{
+			"-backend-config=prefix=windsor/team/test/path",
+			"-backend-config=bucket=state-bucket",
+			"-backend-config=storage_custom_endpoint=http://127.0.0.1:4443/storage/v1/",
+		}
+		for _, want := range expected {
+			if !slices.Contains(args, want) {
+				t.Errorf("Expected args to contain %q, got %v", want, args)
+			}
+		}
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "-backend-config=prefix=") && arg != expected[0] {
+				t.Errorf("Expected a single prefix arg, got %v", args)
+			}
+		}
+	})
+
+	t.Run("GeneratesPassthroughBackendArgs", func(t *testing.T) {
+		// Given a pg backend whose connection string comes from a secret
+		mocks := setupMocks(t)
+		provider := mocks.Provider
+		mockConfig := provider.configHandler.(*config.MockConfigHandler)
+
+		configRoot := "/test/config"
+		mockConfig.GetConfigRootFunc = func() (string, error) {
+			return configRoot, nil
+		}
+
+		mockConfig.GetStringFunc = func(key string, defaultValue ...string) string {
+			if key == "terraform.backend.type" {
+				return "pg"
+			}
+			if key == "terraform.backend.prefix" {
+				return "team/"
+			}
+			if len(defaultValue) > 0 {
+				return defaultValue[0]
+			}
+			return ""
+		}
+
+		mockConfig.GetConfigFunc = func() *v1alpha1.Context {
+			return &v1alpha1.Context{
+				Terraform: &terraform.TerraformConfig{
+					Backend: &terraform.BackendConfig{
+						Type: "pg",
+						Config: map[string]any{
+							"conn_str":    `${secret("vault", "pg", "conn")}`,
+							"schema_name": "windsor",
+						},
+					},
+				},
+			}
+		}
+
+		provider.evaluator.Register("secret", func(params []any, deferred bool) (any, error) {
+			return "postgres://user:pass@db/state", nil
+		}, new(func(string, string, string) any))
+
+		provider.Shims.Stat = func(path string) (os.FileInfo, error) {
+			return nil, os.ErrNotExist
+		}
+
+		// When generating backend config args
+		args, err := provider.generateBackendConfigArgs("test/path", configRoot)
+
+		// Then the secret should be resolved and the schema scoped to the component
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := []string{
+			"-backend-config=conn_str=postgres://user:pass@db/state",
+			"-backend-config=schema_name=windsor_team_test_path",
+		}
+		if !slices.Equal(args, []string{expected[1], expected[0]}) {
+			t.Errorf("Expected args %v, got %v", []string{expected[1], expected[0]}, args)
+		}
+	})
+
+	t.Run("ExposesStateKeyToPassthroughConfig", func(t *testing.T) {
+		// Given an http backend whose address is built from state_key
+		mocks := setupMocks(t)
+		provider := mocks.Provider
+		mockConfig := provider.configHandler.(*config.MockConfigHandler)
+
+		configRoot := "/test/config"
+		mockConfig.GetStringFunc = func(key string, defaultValue ...string) string {
+			if key == "terraform.backend.type" {
+				return "http"
+			}
+			if len(defaultValue) > 0 {
+				return defaultValue[0]
+			}
+			return ""
+		}
+
+		mockConfig.GetConfigFunc = func() *v1alpha1.Context {
+			return &v1alpha1.Context{
+				Terraform: &terraform.TerraformConfig{
+					Backend: &terraform.BackendConfig{
+						Type: "http",
+						Config: map[string]any{
+							"address": "https://state.example.com/${state_key}",
+						},
+					},
+				},
+			}
+		}
+
+		provider.Shims.Stat = func(path string) (os.FileInfo, error) {
+			return nil, os.ErrNotExist
+		}
+
+		// When generating backend config args
+		args, err := provider.generateBackendConfigArgs("test/path", configRoot)
+
+		// Then the address should carry the component's state key
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := "-backend-config=address=https://state.example.com/test/path"
+		if !slices.Equal(args, []string{want}) {
+			t.Errorf("Expected args [%s], got %v", want, args)
+		}
+	})
+
 	t.Run("ReturnsErrorForUnsupportedBackend", func(t *testing.T) {
-		// Given a provider with unsupported backend type
+		// Given a provider with a backend type that is not a valid identifier
 		mocks := setupMocks(t)
 		provider := mocks.Provider
 		mockConfig := provider.configHandler.(*config.MockConfigHandler)
@@ -736,7 +920,7 @@ func TestTerraformProvider_generateBackendConfigArgs(t *testing.T) {
 
 		mockConfig.GetStringFunc = func(key string, defaultValue ...string) string {
 			if key == "terraform.backend.type" {
-				return "unsupported"
+				return "Not A Backend"
 			}
 			if len(defaultValue) > 0 {
 				return defaultValue[0]
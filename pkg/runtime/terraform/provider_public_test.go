@@ -556,6 +556,50 @@ func TestTerraformProvider_GenerateBackendOverride(t *testing.T) {
 		}
 	})
 
+	t.Run("CreatesGcsBackendOverride", func(t *testing.T) {
+		// Given a provider with gcs backend type
+		mocks := setupMocks(t, &SetupOptions{BackendType: "gcs"})
+
+		var written string
+		mocks.Provider.Shims.WriteFile = func(path string, data []byte, perm os.FileMode) error {
+			written = string(data)
+			return nil
+		}
+
+		// When generating backend override
+		err := mocks.Provider.GenerateBackendOverride("/test/dir")
+
+		// Then it should write a gcs backend stanza
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(written, `backend "gcs" {}`) {
+			t.Errorf("Expected gcs backend stanza, got %q", written)
+		}
+	})
+
+	t.Run("CreatesPassthroughBackendOverride", func(t *testing.T) {
+		// Given a provider with an untyped pg backend type
+		mocks := setupMocks(t, &SetupOptions{BackendType: "pg"})
+
+		var written string
+		mocks.Provider.Shims.WriteFile = func(path string, data []byte, perm os.FileMode) error {
+			written = string(data)
+			return nil
+		}
+
+		// When generating backend override
+		err := mocks.Provider.GenerateBackendOverride("/test/dir")
+
+		// Then it should write a pg backend stanza
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(written, `backend "pg" {}`) {
+			t.Errorf("Expected pg backend stanza, got %q", written)
+		}
+	})
+
 	t.Run("RemovesBackendOverrideForNone", func(t *testing.T) {
 		// Given a provider with none backend type
 		mocks := setupMocks(t, &SetupOptions{BackendType: "none"})
@@ -584,12 +628,12 @@ func TestTerraformProvider_GenerateBackendOverride(t *testing.T) {
 	})
 
 	t.Run("HandlesUnsupportedBackend", func(t *testing.T) {
-		// Given a provider with unsupported backend type
+		// Given a provider with a backend type that is not a valid identifier
 		mocks := setupMocks(t)
 
 		mocks.ConfigHandler.GetStringFunc = func(key string, defaultValue ...string) string {
 			if key == "terraform.backend.type" {
-				return "unsupported"
+				return "not-a-backend"
 			}
 			if len(defaultValue) > 0 {
 				return defaultValue[0]
@@ -605,11 +649,32 @@ func TestTerraformProvider_GenerateBackendOverride(t *testing.T) {
 			t.Fatal("Expected error for unsupported backend")
 		}
 
-		if err.Error() != "unsupported backend: unsupported" {
+		if err.Error() != "unsupported backend: not-a-backend" {
 			t.Errorf("Expected unsupported backend error, got %v", err)
 		}
 	})
 
+	t.Run("RejectsUnknownBackendIdentifier", func(t *testing.T) {
+		// Given a provider with a well-formed backend type terraform does not ship
+		mocks := setupMocks(t, &SetupOptions{BackendType: "s3x"})
+		wrote := false
+		mocks.Provider.Shims.WriteFile = func(path string, data []byte, perm os.FileMode) error {
+			wrote = true
+			return nil
+		}
+
+		// When generating backend override
+		err := mocks.Provider.GenerateBackendOverride("/test/dir")
+
+		// Then it is rejected before anything is written
+		if err == nil || err.Error() != "unsupported backend: s3x" {
+			t.Errorf("Expected unsupported backend error, got %v", err)
+		}
+		if wrote {
+			t.Error("Expected no backend_override.tf to be written")
+		}
+	})
+
 	t.Run("HandlesWriteFileError", func(t *testing.T) {
 		// Given a provider with WriteFile that fails
 		mocks := setupMocks(t, &SetupOptions{BackendType: "local"})
@@ -954,7 +1019,7 @@ terraform:
   - path: test/path
     name: test-component`
 
-		mocks := setupMocks(t, &SetupOptions{BlueprintYAML: blueprintYAML, BackendType: "unsupported"})
+		mocks := setupMocks(t, &SetupOptions{BlueprintYAML: blueprintYAML, BackendType: "not-a-backend"})
 
 		// When getting output
 		output, err := mocks.Provider.getOutput("test-component", "any-key", `terraform_output("test-component", "any-key")`, true)
@@ -2208,7 +2273,7 @@ terraform:
 	})
 
 	t.Run("ReturnsErrorWhenGenerateBackendConfigArgsFails", func(t *testing.T) {
-		// Given a provider with invalid backend type
+		// Given a provider with a malformed backend type
 		mocks := setupMocks(t)
 
 		configRoot := "/test/config"
@@ -2223,7 +2288,7 @@ terraform:
 
 		mocks.ConfigHandler.GetStringFunc = func(key string, defaultValue ...string) string {
 			if key == "terraform.backend.type" {
-				return "invalid"
+				return "not a backend!"
 			}
 			if len(defaultValue) > 0 {
 				return defaultValue[0]
@@ -2659,14 +2724,14 @@ func TestTerraformProvider_BackendConfigComplete(t *testing.T) {
 	t.Run("UnrecognizedBackendDefaultsToComplete", func(t *testing.T) {
 		// Regression: the trailing default previously returned false, which
 		// permanently disabled the probe for any backend Windsor doesn't
-		// special-case (gcs, http, consul, remote, cos, ...). Default-allow
+		// special-case (http, consul, remote, cos, ...). Default-allow
 		// lets the probe run; real init failures surface via the warning path.
-		mocks := setupMocks(t, &SetupOptions{BackendType: "gcs"})
+		mocks := setupMocks(t, &SetupOptions{BackendType: "http"})
 		mocks.ConfigHandler.GetConfigFunc = func() *blueprintv1alpha1.Context {
 			return &blueprintv1alpha1.Context{}
 		}
 		if !mocks.Provider.BackendConfigComplete() {
-			t.Error("Expected unrecognized backend type 'gcs' to default to complete")
+			t.Error("Expected unrecognized backend type 'http' to default to complete")
 		}
 	})
 
@@ -2734,6 +2799,30 @@ func TestTerraformProvider_BackendConfigComplete(t *testing.T) {
 			t.Error("Expected s3 with bucket to be complete")
 		}
 	})
+
+	t.Run("GCSRequiresBucket", func(t *testing.T) {
+		mocks := setupMocks(t, &SetupOptions{BackendType: "gcs"})
+
+		mocks.ConfigHandler.GetConfigFunc = func() *blueprintv1alpha1.Context {
+			return &blueprintv1alpha1.Context{
+				Terraform: &terraformcfg.TerraformConfig{Backend: &terraformcfg.BackendConfig{}},
+			}
+		}
+		if mocks.Provider.BackendConfigComplete() {
+			t.Error("Expected gcs with no nested config to be incomplete")
+		}
+
+		mocks.ConfigHandler.GetConfigFunc = func() *blueprintv1alpha1.Context {
+			return &blueprintv1alpha1.Context{
+				Terraform: &terraformcfg.TerraformConfig{Backend: &terraformcfg.BackendConfig{
+					GCS: &terraformcfg.GCSBackend{Bucket: stringPtr("b")},
+				}},
+			}
+		}
+		if !mocks.Provider.BackendConfigComplete() {
+			t.Error("Expected gcs with bucket to be complete")
+		}
+	})
 }
 
 func TestTerraformProvider_GetTerraformOutputs(t *testing.T) {
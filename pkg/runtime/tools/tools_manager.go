@@ -42,13 +42,16 @@ type ToolsManager interface {
 // still respects the configHandler gates (e.g. Docker=true only triggers a docker check when
 // docker.enabled is set or workstation.runtime is a docker-family driver). Over-requesting is
 // safe — checks no-op when the underlying config gate is off; under-requesting is the bug to
-// avoid because it lets a stale tool slip through to the actual command.
+// avoid because it lets a stale tool slip through to the actual command. StackLock is requested
+// by commands that take the stack lock, which needs the aws CLI when the lock is an object in
+// the context's s3 state bucket.
 type Requirements struct {
 	Docker    bool
 	Colima    bool
 	Terraform bool
 	Secrets   bool
 	Kubelogin bool
+	StackLock bool
 }
 
 // AllRequirements returns a Requirements with every field true. Used by `windsor check` (the
@@ -61,6 +64,7 @@ func AllRequirements() Requirements {
 		Terraform: true,
 		Secrets:   true,
 		Kubelogin: true,
+		StackLock: true,
 	}
 }
 
@@ -140,7 +144,8 @@ func (t *BaseToolsManager) Check() error {
 // command paths including `windsor init` / `windsor env` — at those points the operator has
 // no obligation to have the aws CLI installed OR to be authed. Both cloud-CLI presence and
 // credential resolution belong to CheckAuth, which runs from bootstrap and from
-// `windsor check`.
+// `windsor check`. The one exception is StackLock: a command that takes a stack lock held in
+// an s3 bucket cannot run without the aws CLI, so its presence is checked up front.
 func (t *BaseToolsManager) CheckRequirements(reqs Requirements) error {
 	rt := t.configHandler.GetString("workstation.runtime")
 	dockerEnabled := t.configHandler.GetBool("docker.enabled", false)
@@ -186,6 +191,12 @@ func (t *BaseToolsManager) CheckRequirements(reqs Requirements) error {
 			return err
 		}
 	}
+
+	if reqs.StackLock && t.configHandler.GetString("terraform.backend.type") == "s3" && t.configHandler.GetString("terraform.backend.s3.bucket") != "" {
+		if err := t.checkAWSStackLock(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -643,23 +654,42 @@ func hasAmbientAWSCredentials() bool {
 
 // checkAWSBinary verifies the AWS CLI is available in PATH and meets the minimum version.
 func (t *BaseToolsManager) checkAWSBinary() error {
+	_, err := t.awsVersion()
+	return err
+}
+
+// checkAWSStackLock verifies the AWS CLI is recent enough for the s3 stack lock, whose
+// conditional writes need flags older CLIs reject.
+func (t *BaseToolsManager) checkAWSStackLock() error {
+	version, err := t.awsVersion()
+	if err != nil {
+		return err
+	}
+	if compareVersion(version, constants.MinimumVersionAWSStackLock) < 0 {
+		return fmt.Errorf("AWS CLI %s is below %s, the minimum for the s3 stack lock's conditional writes.\n  Install: %s",
+			version, constants.MinimumVersionAWSStackLock, toolRegistry["aws"].download)
+	}
+	return nil
+}
+
+// awsVersion returns the version of the AWS CLI on PATH, verifying it meets the minimum version.
+func (t *BaseToolsManager) awsVersion() (string, error) {
 	if _, err := execLookPath("aws"); err != nil {
-		return missingToolError("aws")
+		return "", missingToolError("aws")
 	}
 
 	out, err := t.shell.ExecSilentWithTimeout("aws", []string{"--version"}, 10*time.Second)
 	if err != nil {
-		return fmt.Errorf("aws --version failed: %v", err)
+		return "", fmt.Errorf("aws --version failed: %v", err)
 	}
 	version := extractVersion(out)
 	if version == "" {
-		return fmt.Errorf("failed to extract aws CLI version")
+		return "", fmt.Errorf("failed to extract aws CLI version")
 	}
 	if compareVersion(version, constants.MinimumVersionAWS) < 0 {
-		return outdatedToolError("aws", version)
+		return "", outdatedToolError("aws", version)
 	}
-
-	return nil
+	return version, nil
 }
 
 // awsAuthHint returns an actionable next-step message tailored to the context's AWS config
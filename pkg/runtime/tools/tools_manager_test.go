@@ -778,6 +778,82 @@ contexts:
 		}
 	})
 
+	t.Run("StackLockChecksAWSForS3Lock", func(t *testing.T) {
+		// Given an s3 backend with a bucket, whose stack lock is an object in that bucket
+		_, toolsManager := setup(t, `
+contexts:
+  test:
+    terraform:
+      backend:
+        type: s3
+        s3:
+          bucket: state
+`)
+		originalExecLookPath := execLookPath
+		execLookPath = func(name string) (string, error) {
+			return "", exec.ErrNotFound
+		}
+		t.Cleanup(func() { execLookPath = originalExecLookPath })
+
+		// When CheckRequirements runs with StackLock requested
+		err := toolsManager.CheckRequirements(Requirements{StackLock: true})
+
+		// Then the missing aws CLI is reported
+		if err == nil || !strings.Contains(err.Error(), "aws") {
+			t.Errorf("Expected the aws CLI to be required, got: %v", err)
+		}
+	})
+
+	t.Run("StackLockRequiresConditionalWriteVersion", func(t *testing.T) {
+		// Given an s3 backend and an aws CLI older than the s3 stack lock's conditional writes
+		mocks, toolsManager := setup(t, `
+contexts:
+  test:
+    terraform:
+      backend:
+        type: s3
+        s3:
+          bucket: state
+`)
+		originalExecLookPath := execLookPath
+		execLookPath = func(name string) (string, error) {
+			return "/usr/bin/" + name, nil
+		}
+		t.Cleanup(func() { execLookPath = originalExecLookPath })
+		mocks.Shell.ExecSilentWithTimeoutFunc = func(command string, args []string, timeout time.Duration) (string, error) {
+			if command == "aws" && len(args) > 0 && args[0] == "--version" {
+				return fmt.Sprintf("aws-cli/%s Python/3.11.8 Darwin/24.1.0", constants.MinimumVersionAWS), nil
+			}
+			return "", nil
+		}
+
+		// When CheckRequirements runs with StackLock requested
+		err := toolsManager.CheckRequirements(Requirements{StackLock: true})
+
+		// Then the CLI is reported as too old for the lock
+		if err == nil || !strings.Contains(err.Error(), constants.MinimumVersionAWSStackLock) {
+			t.Errorf("Expected the stack lock minimum aws version to be required, got: %v", err)
+		}
+	})
+
+	t.Run("StackLockSkipsAWSForLocalLock", func(t *testing.T) {
+		// Given a context whose stack lock is the local flock
+		_, toolsManager := setup(t, defaultConfig)
+		originalExecLookPath := execLookPath
+		execLookPath = func(name string) (string, error) {
+			return "", exec.ErrNotFound
+		}
+		t.Cleanup(func() { execLookPath = originalExecLookPath })
+
+		// When CheckRequirements runs with StackLock requested
+		err := toolsManager.CheckRequirements(Requirements{StackLock: true})
+
+		// Then nothing is required
+		if err != nil {
+			t.Errorf("Expected no error for a local lock, got: %v", err)
+		}
+	})
+
 	t.Run("OverRequestIsHarmlessWhenConfigGateOff", func(t *testing.T) {
 		// Given a context with every tool-config gate explicitly off (workstation.runtime
 		// pinned to "none" so the docker-needsDocker side-channel is also off)
@@ -724,10 +724,12 @@ func (r *TestRunner) matchTerraformComponent(actual *blueprintv1alpha1.Terraform
 
 // matchKustomization compares an actual Kustomization against expected properties and returns a list
 // of differences. It uses partial matching: only properties explicitly set in the expect kustomization
-// are validated. The function checks path, source, dependsOn, components, and substitutions fields. For
+// are validated. The function checks path, source, dependsOn, components, substitutions, healthChecks, and healthCheckExprs fields. For
 // dependsOn and components, it verifies that all expected items are present in the actual kustomization's
 // lists. For substitutions, it performs strict value equality checking - each expected key must exist with
-// the exact expected value. label prefixes each diff message so callers nested under a flux system (Install,
+// the exact expected value. Expected healthChecks must each match an actual entry on kind and name, and on
+// apiVersion and namespace where set; expected healthCheckExprs are found by apiVersion and kind, then
+// compared on whichever expressions they set. label prefixes each diff message so callers nested under a flux system (Install,
 // a Resources variant) can identify the field's origin without borrowing the kustomization's own Name.
 // Returns an empty slice if all specified properties match.
 func (r *TestRunner) matchKustomization(actual *blueprintv1alpha1.Kustomization, expect blueprintv1alpha1.Kustomization, label string) []string {
@@ -770,6 +772,29 @@ func (r *TestRunner) matchKustomization(actual *blueprintv1alpha1.Kustomization,
 		}
 	}
 
+	for _, hc := range expect.HealthChecks {
+		if !containsHealthCheck(actual.HealthChecks, hc) {
+			diffs = append(diffs, fmt.Sprintf("kustomize[%s].healthChecks: missing %s", label, healthCheckLabel(hc)))
+		}
+	}
+
+	for _, expr := range expect.HealthCheckExprs {
+		got := findHealthCheckExpr(actual.HealthCheckExprs, expr.APIVersion, expr.Kind)
+		if got == nil {
+			diffs = append(diffs, fmt.Sprintf("kustomize[%s].healthCheckExprs: missing %s %s", label, expr.APIVersion, expr.Kind))
+			continue
+		}
+		for _, field := range []struct{ name, expected, actual string }{
+			{"current", expr.Current, got.Current},
+			{"inProgress", expr.InProgress, got.InProgress},
+			{"failed", expr.Failed, got.Failed},
+		} {
+			if field.expected != "" && field.expected != field.actual {
+				diffs = append(diffs, fmt.Sprintf("kustomize[%s].healthCheckExprs[%s %s].%s: expected %q, got %q", label, expr.APIVersion, expr.Kind, field.name, field.expected, field.actual))
+			}
+		}
+	}
+
 	return diffs
 }
 
@@ -987,8 +1012,9 @@ func (r *TestRunner) validateDuplicateKustomizationComponents(bp *blueprintv1alp
 // blueprint application, so they must be caught and reported. The Kustomization graph covers both
 // plain kustomize: entries and the tiers compiled from flux: systems, since dependsOn edges can
 // target or originate from either. The function validates Terraform components separately from
-// Kustomizations, as they have independent dependency graphs. Returns a slice of error messages
-// describing each circular dependency found, including the full cycle path.
+// Kustomizations, as they have independent dependency graphs. Qualified "namespace/name" kustomization
+// dependencies are followed by their name part. Returns a slice of error messages describing each
+// circular dependency found, including the full cycle path.
 func (r *TestRunner) validateCircularDependencies(bp *blueprintv1alpha1.Blueprint) []string {
 	var errs []string
 
@@ -1005,7 +1031,9 @@ func (r *TestRunner) validateCircularDependencies(bp *blueprintv1alpha1.Blueprin
 	kNames := make(map[string]struct{})
 	for _, k := range bp.AllKustomizations() {
 		kNames[k.Name] = struct{}{}
-		kGraph[k.Name] = k.DependsOn
+		for _, dep := range k.DependsOn {
+			kGraph[k.Name] = append(kGraph[k.Name], blueprintv1alpha1.KustomizationDependencyName(dep))
+		}
 	}
 	errs = append(errs, detectCycles(kGraph, kNames, "kustomization")...)
 
@@ -1017,9 +1045,11 @@ func (r *TestRunner) validateCircularDependencies(bp *blueprintv1alpha1.Blueprin
 // names, then validates that every dependency reference points to an existing component. After blueprint
 // composition completes, invalid dependencies should have been filtered out by the composer's validation
 // logic, so any remaining invalid dependencies indicate a bug in the composition or validation code.
-// This check serves as a safety net to catch composition errors. Returns a slice of error messages
-// describing each invalid dependency found, identifying both the component with the invalid dependency
-// and the non-existent component it references.
+// This check serves as a safety net to catch composition errors. A kustomization dependency on a
+// kustomization with its own namespace must name that namespace as "namespace/name"; the test context
+// does not pin the gitops namespace, so qualifiers on kustomizations living there are not checked.
+// Returns a slice of error messages describing each invalid dependency found, identifying both the
+// component with the invalid dependency and the non-existent component it references.
 func (r *TestRunner) validateInvalidDependencies(bp *blueprintv1alpha1.Blueprint) []string {
 	var errs []string
 
@@ -1029,12 +1059,12 @@ func (r *TestRunner) validateInvalidDependencies(bp *blueprintv1alpha1.Blueprint
 	}
 
 	allK := bp.AllKustomizations()
-	kNames := make(map[string]struct{}, len(allK))
+	kNamespaces := make(map[string]string, len(allK))
 	for _, k := range allK {
-		kNames[k.Name] = struct{}{}
+		kNamespaces[k.Name] = k.Namespace
 	}
 	for _, layer := range blueprint.CrdLayers(bp) {
-		kNames[blueprintv1alpha1.CrdKustomizationName(layer.Source)] = struct{}{}
+		kNamespaces[blueprintv1alpha1.CrdKustomizationName(layer.Source)] = ""
 	}
 
 	for _, tf := range bp.TerraformComponents {
@@ -1047,8 +1077,18 @@ func (r *TestRunner) validateInvalidDependencies(bp *blueprintv1alpha1.Blueprint
 
 	for _, k := range allK {
 		for _, dep := range k.DependsOn {
-			if _, exists := kNames[dep]; !exists {
+			depNamespace, depName, err := blueprintv1alpha1.ParseKustomizationDependency(dep)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("kustomization %q: %v", k.Name, err))
+				continue
+			}
+			actual, exists := kNamespaces[depName]
+			if !exists {
 				errs = append(errs, fmt.Sprintf("kustomization %q depends on non-existent kustomization %q", k.Name, dep))
+				continue
+			}
+			if actual != "" && depNamespace != actual {
+				errs = append(errs, fmt.Sprintf("kustomization %q depends on %q, but kustomization %q lives in namespace %q", k.Name, dep, depName, actual))
 			}
 		}
 	}
@@ -1181,6 +1221,43 @@ func containsAll(haystack []string, needles []string) bool {
 	return true
 }
 
+// containsHealthCheck reports whether actual holds a health check matching expect on kind and name,
+// and on apiVersion and namespace when expect sets them.
+func containsHealthCheck(actual []blueprintv1alpha1.HealthCheck, expect blueprintv1alpha1.HealthCheck) bool {
+	for _, hc := range actual {
+		if hc.Kind != expect.Kind || hc.Name != expect.Name {
+			continue
+		}
+		if expect.APIVersion != "" && hc.APIVersion != expect.APIVersion {
+			continue
+		}
+		if expect.Namespace != "" && hc.Namespace != expect.Namespace {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// healthCheckLabel renders a health check for diff messages as Kind/name, or Kind/namespace/name
+// when the namespace is set.
+func healthCheckLabel(hc blueprintv1alpha1.HealthCheck) string {
+	if hc.Namespace != "" {
+		return fmt.Sprintf("%s/%s/%s", hc.Kind, hc.Namespace, hc.Name)
+	}
+	return fmt.Sprintf("%s/%s", hc.Kind, hc.Name)
+}
+
+// findHealthCheckExpr returns the expression rule for apiVersion and kind, or nil when exprs holds none.
+func findHealthCheckExpr(exprs []blueprintv1alpha1.HealthCheckExpr, apiVersion, kind string) *blueprintv1alpha1.HealthCheckExpr {
+	for i := range exprs {
+		if exprs[i].APIVersion == apiVersion && exprs[i].Kind == kind {
+			return &exprs[i]
+		}
+	}
+	return nil
+}
+
 // blueprintInstallsCrd reports whether the composed blueprint installs ref — either from its own
 // (default/project) crds list or from any source that vendors it. A test asserts a CRD's presence with
 // the bare ref; the source it rides on is a composition detail the assertion need not name.
@@ -2314,6 +2314,73 @@ func TestTestRunner_matchKustomization(t *testing.T) {
 			t.Errorf("Expected 1 diff, got: %d", len(diffs))
 		}
 	})
+
+	t.Run("MatchesHealthChecksByContains", func(t *testing.T) {
+		// Given a kustomization with two health checks and a Certificate rule
+		mocks := setupTestRunnerMocks(t)
+		runner := createRunnerWithMockGenerator(mocks)
+
+		actual := &blueprintv1alpha1.Kustomization{
+			Name: "ingress",
+			HealthChecks: []blueprintv1alpha1.HealthCheck{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "nginx", Namespace: "ingress"},
+				{Kind: "Service", Name: "nginx"},
+			},
+			HealthCheckExprs: []blueprintv1alpha1.HealthCheckExpr{
+				{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Current: "status.ready", Failed: "status.failed"},
+			},
+		}
+
+		expect := blueprintv1alpha1.Kustomization{
+			Name:             "ingress",
+			HealthChecks:     []blueprintv1alpha1.HealthCheck{{Kind: "Deployment", Name: "nginx"}},
+			HealthCheckExprs: []blueprintv1alpha1.HealthCheckExpr{{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Current: "status.ready"}},
+		}
+
+		// When matching an expectation that omits apiVersion, namespace and the failed expression
+		diffs := runner.matchKustomization(actual, expect, expect.Name)
+
+		// Then no diffs should be returned
+		if len(diffs) != 0 {
+			t.Errorf("Expected no diffs, got: %v", diffs)
+		}
+	})
+
+	t.Run("ReturnsDiffsWhenHealthChecksDiffer", func(t *testing.T) {
+		// Given a kustomization with one health check and a Certificate rule
+		mocks := setupTestRunnerMocks(t)
+		runner := createRunnerWithMockGenerator(mocks)
+
+		actual := &blueprintv1alpha1.Kustomization{
+			Name:             "ingress",
+			HealthChecks:     []blueprintv1alpha1.HealthCheck{{Kind: "Deployment", Name: "nginx", Namespace: "ingress"}},
+			HealthCheckExprs: []blueprintv1alpha1.HealthCheckExpr{{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Current: "status.ready"}},
+		}
+
+		expect := blueprintv1alpha1.Kustomization{
+			Name: "ingress",
+			HealthChecks: []blueprintv1alpha1.HealthCheck{
+				{Kind: "Deployment", Name: "nginx", Namespace: "system"},
+			},
+			HealthCheckExprs: []blueprintv1alpha1.HealthCheckExpr{
+				{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Current: "status.other"},
+				{APIVersion: "cert-manager.io/v1", Kind: "Issuer", Current: "status.ready"},
+			},
+		}
+
+		// When matching
+		diffs := runner.matchKustomization(actual, expect, expect.Name)
+
+		// Then the namespace mismatch, the changed expression and the missing rule are reported
+		want := []string{
+			"kustomize[ingress].healthChecks: missing Deployment/system/nginx",
+			`kustomize[ingress].healthCheckExprs[cert-manager.io/v1 Certificate].current: expected "status.other", got "status.ready"`,
+			"kustomize[ingress].healthCheckExprs: missing cert-manager.io/v1 Issuer",
+		}
+		if strings.Join(diffs, "\n") != strings.Join(want, "\n") {
+			t.Errorf("Expected diffs %v, got: %v", want, diffs)
+		}
+	})
 }
 
 func TestTestRunner_findFluxSystem(t *testing.T) {
@@ -2884,6 +2951,48 @@ func TestTestRunner_validateBlueprint(t *testing.T) {
 		}
 	})
 
+	t.Run("AcceptsQualifiedKustomizationDependency", func(t *testing.T) {
+		// Given a kustomization depending on one in another namespace by namespace/name
+		mocks := setupTestRunnerMocks(t)
+		runner := createRunnerWithMockGenerator(mocks)
+
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "platform", Path: "platform", Namespace: "tenants"},
+				{Name: "app", Path: "app", DependsOn: []string{"tenants/platform"}},
+			},
+		}
+
+		// When validating
+		errors := runner.validateBlueprint(blueprint)
+
+		// Then the dependency resolves
+		if len(errors) != 0 {
+			t.Errorf("Expected no errors, got: %v", errors)
+		}
+	})
+
+	t.Run("DetectsKustomizationDependencyNamespaceMismatch", func(t *testing.T) {
+		// Given a qualified dependency naming the wrong namespace for its target
+		mocks := setupTestRunnerMocks(t)
+		runner := createRunnerWithMockGenerator(mocks)
+
+		blueprint := &blueprintv1alpha1.Blueprint{
+			Kustomizations: []blueprintv1alpha1.Kustomization{
+				{Name: "platform", Path: "platform", Namespace: "tenants"},
+				{Name: "app", Path: "app", DependsOn: []string{"other/platform"}},
+			},
+		}
+
+		// When validating
+		errors := runner.validateBlueprint(blueprint)
+
+		// Then the mismatch is reported
+		if len(errors) == 0 || !strings.Contains(errors[0], `lives in namespace "tenants"`) {
+			t.Errorf("Expected namespace mismatch error, got: %v", errors)
+		}
+	})
+
 	t.Run("DetectsInvalidTerraformDependencies", func(t *testing.T) {
 		mocks := setupTestRunnerMocks(t)
 		runner := createRunnerWithMockGenerator(mocks)
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	"github.com/windsorcli/cli/pkg/provisioner/policy"
 	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
 )
 
@@ -37,10 +38,15 @@ const resourceListCap = 20
 // entries per component to keep large kustomizations from drowning the
 // summary; the cap is followed by a "… and N more" line. When at least one
 // component has actual changes, a footer hint points the user at the streaming
-// `windsor plan terraform/kustomize <name>` form for full diffs. Any upgrade
-// hints from missing CLI tools are printed in a footnote block at the bottom
-// when present.
-func Summary(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPlans []fluxinfra.KustomizePlan, hints []string, noColor bool) {
+// `windsor plan terraform/kustomize <name>` form for full diffs. A Kustomize
+// row that migrates objects between kustomizations, or whose source moves to a
+// new ref, notes it after the status; kustomizations the blueprint no longer
+// declares are listed as "(reclaimed)" with the objects pruning them deletes.
+// Policy violations are listed in their own block after the components, each with
+// the policy name, the offending component and address, and the policy
+// message. Any upgrade hints from missing CLI tools are printed in a footnote
+// block at the bottom when present.
+func Summary(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPlans []fluxinfra.KustomizePlan, violations []policy.Violation, hints []string, noColor bool) {
 	nameWidth := 20
 	for _, p := range tfPlans {
 		if n := len(terraformDisplayName(p)); n > nameWidth {
@@ -60,7 +66,7 @@ func Summary(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPl
 	if len(tfPlans) > 0 {
 		fmt.Fprintln(w, "\nTerraform")
 		for _, p := range tfPlans {
-			fmt.Fprintf(w, "  %-*s  %s\n", nameWidth, terraformDisplayName(p), formatTerraformPlan(p, noColor))
+			fmt.Fprintf(w, "  %-*s  %s\n", nameWidth, terraformDisplayName(p), formatTerraformPlan(p, noColor)+formatKnownAfterApply(p, noColor))
 			if p.Err != nil {
 				lines := strings.Split(strings.TrimSpace(p.Err.Error()), "\n")
 				for _, line := range lines[1:] {
@@ -74,7 +80,7 @@ func Summary(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPl
 	if len(k8sPlans) > 0 {
 		fmt.Fprintln(w, "\nKustomize")
 		for _, p := range k8sPlans {
-			fmt.Fprintf(w, "  %-*s  %s\n", nameWidth, p.Name, formatKustomizePlan(p, noColor))
+			fmt.Fprintf(w, "  %-*s  %s\n", nameWidth, p.Name, formatKustomizePlan(p, noColor)+formatTransition(p))
 			if p.Err != nil {
 				lines := strings.Split(strings.TrimSpace(p.Err.Error()), "\n")
 				for _, line := range lines[1:] {
@@ -89,6 +95,8 @@ func Summary(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPl
 		fmt.Fprintln(w, "\n  (no components in blueprint)")
 	}
 
+	writeViolations(w, violations, noColor)
+
 	footerSep := strings.Repeat("─", nameWidth+26)
 	if planHasChanges(tfPlans, k8sPlans) {
 		fmt.Fprintf(w, "\n%s\n", footerSep)
@@ -114,46 +122,67 @@ func Summary(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPl
 // change/destroy/no_changes are zero/false. Consumers detecting "pending work"
 // from this output must check is_new alongside the counts — using add+change+
 // destroy>0 alone will silently miss never-applied components.
-func SummaryJSON(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPlans []fluxinfra.KustomizePlan) error {
+//
+// violations lists policy denials; the key is omitted when there are none, so
+// a non-empty "violations" array means apply will be refused.
+func SummaryJSON(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPlans []fluxinfra.KustomizePlan, violations []policy.Violation) error {
 	type resourceRow struct {
 		Address string `json:"address"`
 		Action  string `json:"action"`
 	}
 	type tfRow struct {
-		Component string        `json:"component"`
-		Path      string        `json:"path,omitempty"`
-		Add       int           `json:"add"`
-		Change    int           `json:"change"`
-		Destroy   int           `json:"destroy"`
-		NoChanges bool          `json:"no_changes"`
-		IsNew     bool          `json:"is_new"`
-		Resources []resourceRow `json:"resources,omitempty"`
-		Error     string        `json:"error,omitempty"`
+		Component       string        `json:"component"`
+		Path            string        `json:"path,omitempty"`
+		Add             int           `json:"add"`
+		Change          int           `json:"change"`
+		Destroy         int           `json:"destroy"`
+		NoChanges       bool          `json:"no_changes"`
+		IsNew           bool          `json:"is_new"`
+		KnownAfterApply bool          `json:"known_after_apply,omitempty"`
+		Resources       []resourceRow `json:"resources,omitempty"`
+		Error           string        `json:"error,omitempty"`
 	}
 	type k8sRow struct {
-		Name      string        `json:"name"`
-		Added     int           `json:"added"`
-		Removed   int           `json:"removed"`
-		IsNew     bool          `json:"is_new"`
-		Degraded  bool          `json:"degraded"`
-		Resources []resourceRow `json:"resources,omitempty"`
-		Error     string        `json:"error,omitempty"`
+		Name         string        `json:"name"`
+		Added        int           `json:"added"`
+		Removed      int           `json:"removed"`
+		IsNew        bool          `json:"is_new"`
+		Degraded     bool          `json:"degraded"`
+		Transition   string        `json:"transition,omitempty"`
+		MigratedFrom []string      `json:"migrated_from,omitempty"`
+		MigratedTo   []string      `json:"migrated_to,omitempty"`
+		AppliedRef   string        `json:"applied_ref,omitempty"`
+		TargetRef    string        `json:"target_ref,omitempty"`
+		Resources    []resourceRow `json:"resources,omitempty"`
+		Error        string        `json:"error,omitempty"`
+	}
+	type violationRow struct {
+		Policy    string `json:"policy"`
+		Layer     string `json:"layer"`
+		Component string `json:"component"`
+		Address   string `json:"address"`
+		Message   string `json:"message"`
 	}
 	type output struct {
-		Terraform []tfRow  `json:"terraform,omitempty"`
-		Kustomize []k8sRow `json:"kustomize,omitempty"`
+		Terraform  []tfRow        `json:"terraform,omitempty"`
+		Kustomize  []k8sRow       `json:"kustomize,omitempty"`
+		Violations []violationRow `json:"violations,omitempty"`
 	}
 
 	out := output{}
+	for _, v := range violations {
+		out.Violations = append(out.Violations, violationRow{Policy: v.Policy, Layer: v.Layer, Component: v.Component, Address: v.Address, Message: v.Message})
+	}
 	for _, p := range tfPlans {
 		row := tfRow{
-			Component: p.ComponentID,
-			Path:      p.Path,
-			Add:       p.Add,
-			Change:    p.Change,
-			Destroy:   p.Destroy,
-			NoChanges: p.NoChanges,
-			IsNew:     p.IsNew,
+			Component:       p.ComponentID,
+			Path:            p.Path,
+			Add:             p.Add,
+			Change:          p.Change,
+			Destroy:         p.Destroy,
+			NoChanges:       p.NoChanges,
+			IsNew:           p.IsNew,
+			KnownAfterApply: p.KnownAfterApply,
 		}
 		for _, r := range p.Resources {
 			row.Resources = append(row.Resources, resourceRow{Address: r.Address, Action: terraformActionString(r.Action)})
@@ -164,7 +193,18 @@ func SummaryJSON(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k
 		out.Terraform = append(out.Terraform, row)
 	}
 	for _, p := range k8sPlans {
-		row := k8sRow{Name: p.Name, Added: p.Added, Removed: p.Removed, IsNew: p.IsNew, Degraded: p.Degraded}
+		row := k8sRow{
+			Name:         p.Name,
+			Added:        p.Added,
+			Removed:      p.Removed,
+			IsNew:        p.IsNew,
+			Degraded:     p.Degraded,
+			Transition:   string(p.Transition),
+			MigratedFrom: p.MigratedFrom,
+			MigratedTo:   p.MigratedTo,
+			AppliedRef:   p.AppliedRef,
+			TargetRef:    p.TargetRef,
+		}
 		for _, r := range p.Resources {
 			row.Resources = append(row.Resources, resourceRow{Address: r.Address, Action: kustomizeActionString(r.Action)})
 		}
@@ -179,6 +219,26 @@ func SummaryJSON(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k
 	return enc.Encode(out)
 }
 
+// Transitions writes one line per kustomization whose transition is known and is not unchanged:
+// the transition, the name, and the migration or source ref move behind it. It is the compact
+// form upgrade prints before reconciling; it writes nothing when no kustomization transitions.
+func Transitions(w io.Writer, k8sPlans []fluxinfra.KustomizePlan) {
+	var lines []string
+	for _, p := range k8sPlans {
+		if p.Transition == "" || p.Transition == fluxinfra.TransitionUnchanged {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %-9s  %s%s", p.Transition, p.Name, formatTransition(p)))
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Kustomizations:")
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
+
 // =============================================================================
 // Internal types
 // =============================================================================
@@ -250,11 +310,26 @@ func formatTerraformPlan(p terraforminfra.TerraformComponentPlan, noColor bool)
 	return formatRawCounts(p.Add, p.Change, p.Destroy, 0, noColor)
 }
 
+// formatKnownAfterApply returns the suffix appended to a Terraform row whose
+// terraform_output() inputs come from a producer with pending changes, so the
+// operator reads its counts as provisional. Empty when the flag is unset or
+// the row already reports an error or "(new)".
+func formatKnownAfterApply(p terraforminfra.TerraformComponentPlan, noColor bool) string {
+	if !p.KnownAfterApply || p.Err != nil || p.IsNew {
+		return ""
+	}
+	if noColor {
+		return " (inputs known after apply)"
+	}
+	return " \033[33m(inputs known after apply)\033[0m"
+}
+
 // formatKustomizePlan returns the right-hand-side status string for one
 // Kustomize component. Counts are derived from the Resources slice when
 // populated, giving per-resource accounting consistent with the indented list
 // below the row. Without Resources, the diff-line counts (Added/Removed) are
-// used as a fallback for the Degraded path.
+// used as a fallback for the Degraded path. A reclaimed kustomization renders
+// as "(reclaimed)" with the count of objects pruning it deletes.
 func formatKustomizePlan(p fluxinfra.KustomizePlan, noColor bool) string {
 	if p.Err != nil {
 		msg := truncateFirstLine(p.Err.Error())
@@ -263,6 +338,12 @@ func formatKustomizePlan(p fluxinfra.KustomizePlan, noColor bool) string {
 		}
 		return fmt.Sprintf("\033[31m(error: %s)\033[0m", msg)
 	}
+	if p.Transition == fluxinfra.TransitionReclaimed {
+		if noColor {
+			return "(reclaimed)  " + formatDestroyCount(len(p.Resources), noColor)
+		}
+		return "\033[33m(reclaimed)\033[0m  " + formatDestroyCount(len(p.Resources), noColor)
+	}
 	if p.Degraded {
 		if p.IsNew {
 			return "(new)"
@@ -292,6 +373,35 @@ func formatKustomizePlan(p fluxinfra.KustomizePlan, noColor bool) string {
 	return fmt.Sprintf("\033[32m+%d\033[0m  \033[31m-%d\033[0m  lines", p.Added, p.Removed)
 }
 
+// formatTransition returns the suffix noting how a Kustomize row relates to the
+// other kustomizations and to the applied version: the kustomizations it
+// migrates objects from or to, and its source's move from the applied ref to
+// the blueprint's. It is empty when there is nothing to note.
+func formatTransition(p fluxinfra.KustomizePlan) string {
+	var notes []string
+	if len(p.MigratedFrom) > 0 {
+		notes = append(notes, "migrated from "+strings.Join(p.MigratedFrom, ", "))
+	}
+	if len(p.MigratedTo) > 0 {
+		notes = append(notes, "objects migrated to "+strings.Join(p.MigratedTo, ", "))
+	}
+	if p.AppliedRef != p.TargetRef {
+		notes = append(notes, fmt.Sprintf("%s → %s", refOrNone(p.AppliedRef), refOrNone(p.TargetRef)))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(notes, "; ") + ")"
+}
+
+// refOrNone renders an empty source ref as "<none>".
+func refOrNone(ref string) string {
+	if ref == "" {
+		return "<none>"
+	}
+	return ref
+}
+
 // formatResourceCounts buckets a resource list into the four action categories
 // and renders them as "+a  ~c  -d" with " ±r" appended when r > 0. The ±
 // bucket is omitted when zero so rows without replaces look identical to the
@@ -407,6 +517,25 @@ func writeResourceList(w io.Writer, resources []planResource, noColor bool) {
 	}
 }
 
+// writeViolations renders the "Policy Violations" block: one line per
+// violation naming the policy, component, and address, with the policy
+// message indented beneath it. Writes nothing when violations is empty.
+func writeViolations(w io.Writer, violations []policy.Violation, noColor bool) {
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nPolicy Violations")
+	for _, v := range violations {
+		name := v.Policy
+		if !noColor {
+			name = "\033[31m" + name + "\033[0m"
+		}
+		fmt.Fprintf(w, "  %s  %s %s\n", name, v.Component, v.Address)
+		fmt.Fprintf(w, "      %s\n", v.Message)
+	}
+	fmt.Fprintln(w, "\n  Apply will be refused until these violations are resolved.")
+}
+
 // actionSymbol returns the colored single-character notation for a planAction.
 // Symbols mirror terraform's own +/~/- vocabulary, plus ± for replace, so the
 // notation is familiar to anyone who has read a terraform plan.
@@ -706,3 +835,240 @@ func formatDestroyCount(n int, noColor bool) string {
 	}
 	return fmt.Sprintf("\033[31m-%d\033[0m", n)
 }
+
+// =============================================================================
+// Drift renderer
+// =============================================================================
+
+// DriftSummary writes a drift report to w. The layout mirrors DestroySummary:
+// IsNew renders as "(no state)" / "(not deployed)" because there is nothing
+// deployed to compare against, components that match their recorded state
+// render as "(no drift)", and drifted components show counts followed by the
+// drifted resources. Resource actions describe what happened out of band: an
+// update is an attribute edited outside windsor, a delete is an object removed
+// outside windsor. Kustomize rows only cover objects deleted or edited by hand
+// (see KubernetesManager.GetInventoryManualEdits), so the section says so and a
+// row with updates reads "manual edits detected". A closing line states whether
+// any drift was found.
+func DriftSummary(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPlans []fluxinfra.KustomizePlan, noColor bool) {
+	nameWidth := 20
+	for _, p := range tfPlans {
+		if n := len(terraformDisplayName(p)); n > nameWidth {
+			nameWidth = n
+		}
+	}
+	for _, p := range k8sPlans {
+		if len(p.Name) > nameWidth {
+			nameWidth = len(p.Name)
+		}
+	}
+	nameWidth += 2
+
+	sep := strings.Repeat("═", nameWidth+26)
+	fmt.Fprintf(w, "\nWindsor Drift Report\n%s\n", sep)
+
+	if len(tfPlans) > 0 {
+		fmt.Fprintln(w, "\nTerraform")
+		for _, p := range tfPlans {
+			fmt.Fprintf(w, "  %-*s  %s\n", nameWidth, terraformDisplayName(p), formatTerraformDrift(p, noColor))
+			if p.Err != nil {
+				lines := strings.Split(strings.TrimSpace(p.Err.Error()), "\n")
+				for _, line := range lines[1:] {
+					fmt.Fprintf(w, "  %s  %s\n", strings.Repeat(" ", nameWidth), line)
+				}
+			}
+			writeResourceList(w, terraformResourceChanges(p.Resources), noColor)
+		}
+	}
+
+	if len(k8sPlans) > 0 {
+		fmt.Fprintln(w, "\nKustomize (deletions and manual edits)")
+		for _, p := range k8sPlans {
+			fmt.Fprintf(w, "  %-*s  %s\n", nameWidth, p.Name, formatKustomizeDrift(p, noColor))
+			if p.Err != nil {
+				lines := strings.Split(strings.TrimSpace(p.Err.Error()), "\n")
+				for _, line := range lines[1:] {
+					fmt.Fprintf(w, "  %s  %s\n", strings.Repeat(" ", nameWidth), line)
+				}
+			}
+			writeResourceList(w, kustomizeResourceChanges(p.Resources), noColor)
+		}
+	}
+
+	if len(tfPlans) == 0 && len(k8sPlans) == 0 {
+		fmt.Fprintln(w, "\n  (no components in blueprint)")
+	}
+
+	if HasDrift(tfPlans, k8sPlans) {
+		fmt.Fprintln(w, "\nDrift detected. Run 'windsor apply' to restore the declared state.")
+	} else {
+		fmt.Fprintln(w, "\nNo drift detected.")
+	}
+	fmt.Fprintln(w)
+}
+
+// DriftSummaryJSON encodes drift results as JSON to w. Each row carries a
+// drifted flag so consumers need not re-derive it from the counts, and the
+// top-level drifted field matches HasDrift. no_state and not_deployed carry the
+// drift-side meaning of IsNew, and manual_edits marks kustomizations with objects
+// edited by hand.
+func DriftSummaryJSON(w io.Writer, tfPlans []terraforminfra.TerraformComponentPlan, k8sPlans []fluxinfra.KustomizePlan) error {
+	type resourceRow struct {
+		Address string `json:"address"`
+		Action  string `json:"action"`
+	}
+	type tfRow struct {
+		Component string        `json:"component"`
+		Path      string        `json:"path,omitempty"`
+		Drifted   bool          `json:"drifted"`
+		NoState   bool          `json:"no_state"`
+		Resources []resourceRow `json:"resources,omitempty"`
+		Error     string        `json:"error,omitempty"`
+	}
+	type k8sRow struct {
+		Name        string        `json:"name"`
+		Drifted     bool          `json:"drifted"`
+		NotDeployed bool          `json:"not_deployed"`
+		ManualEdits bool          `json:"manual_edits"`
+		Resources   []resourceRow `json:"resources,omitempty"`
+		Error       string        `json:"error,omitempty"`
+	}
+	type output struct {
+		Drifted   bool     `json:"drifted"`
+		Terraform []tfRow  `json:"terraform,omitempty"`
+		Kustomize []k8sRow `json:"kustomize,omitempty"`
+	}
+
+	out := output{Drifted: HasDrift(tfPlans, k8sPlans)}
+	for _, p := range tfPlans {
+		row := tfRow{
+			Component: p.ComponentID,
+			Path:      p.Path,
+			Drifted:   terraformDrifted(p),
+			NoState:   p.IsNew,
+		}
+		for _, r := range p.Resources {
+			row.Resources = append(row.Resources, resourceRow{Address: r.Address, Action: terraformActionString(r.Action)})
+		}
+		if p.Err != nil {
+			row.Error = p.Err.Error()
+		}
+		out.Terraform = append(out.Terraform, row)
+	}
+	for _, p := range k8sPlans {
+		row := k8sRow{Name: p.Name, Drifted: kustomizeDrifted(p), NotDeployed: p.IsNew, ManualEdits: kustomizeManuallyEdited(p)}
+		for _, r := range p.Resources {
+			row.Resources = append(row.Resources, resourceRow{Address: r.Address, Action: kustomizeActionString(r.Action)})
+		}
+		if p.Err != nil {
+			row.Error = p.Err.Error()
+		}
+		out.Kustomize = append(out.Kustomize, row)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// HasDrift reports whether any component in a drift result differs from its
+// recorded state. Errored components and components with nothing deployed do
+// not count as drift; callers check for errors separately.
+func HasDrift(tfPlans []terraforminfra.TerraformComponentPlan, k8sPlans []fluxinfra.KustomizePlan) bool {
+	for _, p := range tfPlans {
+		if terraformDrifted(p) {
+			return true
+		}
+	}
+	for _, p := range k8sPlans {
+		if kustomizeDrifted(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// terraformDrifted reports whether a refresh-only result found drift. NoChanges
+// is false whenever terraform's detailed exit code signalled changes, which also
+// covers drift confined to outputs that produces no resource_drift events.
+func terraformDrifted(p terraforminfra.TerraformComponentPlan) bool {
+	return p.Err == nil && !p.IsNew && !p.NoChanges
+}
+
+// kustomizeDrifted reports whether an inventory comparison found drifted objects.
+func kustomizeDrifted(p fluxinfra.KustomizePlan) bool {
+	return p.Err == nil && !p.IsNew && len(p.Resources) > 0
+}
+
+// kustomizeManuallyEdited reports whether an inventory comparison found objects
+// edited by hand, which DriftSummary reports as updates.
+func kustomizeManuallyEdited(p fluxinfra.KustomizePlan) bool {
+	if !kustomizeDrifted(p) {
+		return false
+	}
+	for _, r := range p.Resources {
+		if r.Action == fluxinfra.ActionUpdate {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTerraformDrift returns the right-hand-side status string for one
+// Terraform component in a drift report. A drifted component without
+// resource_drift events (output-only drift) renders as "(drifted)".
+func formatTerraformDrift(p terraforminfra.TerraformComponentPlan, noColor bool) string {
+	if p.Err != nil {
+		msg := truncateFirstLine(p.Err.Error())
+		if noColor {
+			return fmt.Sprintf("(error: %s)", msg)
+		}
+		return fmt.Sprintf("\033[31m(error: %s)\033[0m", msg)
+	}
+	if p.IsNew {
+		if noColor {
+			return "(no state)"
+		}
+		return "\033[36m(no state)\033[0m"
+	}
+	if !terraformDrifted(p) {
+		return "(no drift)"
+	}
+	if len(p.Resources) > 0 {
+		return formatResourceCounts(terraformResourceChanges(p.Resources), noColor)
+	}
+	if noColor {
+		return "(drifted)"
+	}
+	return "\033[33m(drifted)\033[0m"
+}
+
+// formatKustomizeDrift returns the right-hand-side status string for one Flux
+// kustomization in a drift report. Counts are followed by "(manual edits
+// detected)" when any object was edited by hand.
+func formatKustomizeDrift(p fluxinfra.KustomizePlan, noColor bool) string {
+	if p.Err != nil {
+		msg := truncateFirstLine(p.Err.Error())
+		if noColor {
+			return fmt.Sprintf("(error: %s)", msg)
+		}
+		return fmt.Sprintf("\033[31m(error: %s)\033[0m", msg)
+	}
+	if p.IsNew {
+		if noColor {
+			return "(not deployed)"
+		}
+		return "\033[36m(not deployed)\033[0m"
+	}
+	if !kustomizeDrifted(p) {
+		return "(no drift)"
+	}
+	counts := formatResourceCounts(kustomizeResourceChanges(p.Resources), noColor)
+	if !kustomizeManuallyEdited(p) {
+		return counts
+	}
+	if noColor {
+		return counts + "  (manual edits detected)"
+	}
+	return counts + "  \033[33m(manual edits detected)\033[0m"
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	fluxinfra "github.com/windsorcli/cli/pkg/provisioner/flux"
+	"github.com/windsorcli/cli/pkg/provisioner/policy"
 	terraforminfra "github.com/windsorcli/cli/pkg/provisioner/terraform"
 )
 
@@ -89,6 +90,34 @@ func TestFormatTerraformPlan(t *testing.T) {
 	})
 }
 
+func TestFormatKnownAfterApply(t *testing.T) {
+	t.Run("RendersSuffixWhenSet", func(t *testing.T) {
+		// A consumer planned against a producer with pending changes carries
+		// a provisional marker after its counts.
+		got := formatKnownAfterApply(terraforminfra.TerraformComponentPlan{
+			ComponentID:     "cluster",
+			Add:             1,
+			KnownAfterApply: true,
+		}, true)
+		if got != " (inputs known after apply)" {
+			t.Errorf("expected known-after-apply suffix, got %q", got)
+		}
+	})
+
+	t.Run("OmitsSuffixForNewOrErroredRows", func(t *testing.T) {
+		// "(new)" and "(error: ...)" already say the counts are not meaningful.
+		for _, p := range []terraforminfra.TerraformComponentPlan{
+			{ComponentID: "cluster", IsNew: true, KnownAfterApply: true},
+			{ComponentID: "cluster", Err: fmt.Errorf("boom"), KnownAfterApply: true},
+			{ComponentID: "cluster"},
+		} {
+			if got := formatKnownAfterApply(p, true); got != "" {
+				t.Errorf("expected no suffix, got %q", got)
+			}
+		}
+	})
+}
+
 func TestTerraformDisplayName(t *testing.T) {
 	t.Run("PrefersPathWhenSet", func(t *testing.T) {
 		// Path locates the underlying module; ComponentID is the short alias.
@@ -152,13 +181,148 @@ func TestFormatKustomizePlan(t *testing.T) {
 	})
 }
 
+func TestFormatKustomizeReclaimed(t *testing.T) {
+	t.Run("RendersReclaimedWithDeleteCount", func(t *testing.T) {
+		// A kustomization the blueprint no longer declares renders as reclaimed
+		// with the count of objects pruning it deletes, not as a diff.
+		got := formatKustomizePlan(fluxinfra.KustomizePlan{
+			Name:       "old-ingress",
+			Transition: fluxinfra.TransitionReclaimed,
+			Resources: []fluxinfra.ResourceChange{
+				{Address: "ConfigMap/ingress/legacy", Action: fluxinfra.ActionDelete},
+			},
+		}, true)
+		if !strings.HasPrefix(got, "(reclaimed)") || !strings.Contains(got, "-1") {
+			t.Errorf("expected reclaimed row with -1, got %q", got)
+		}
+	})
+}
+
+func TestFormatTransition(t *testing.T) {
+	t.Run("NotesMigrationsAndRefMove", func(t *testing.T) {
+		got := formatTransition(fluxinfra.KustomizePlan{
+			MigratedFrom: []string{"old-ingress", "old-lb"},
+			AppliedRef:   "v0.5.0",
+			TargetRef:    "v0.6.0",
+		})
+		want := " (migrated from old-ingress, old-lb; v0.5.0 → v0.6.0)"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("RendersMissingRefAsNone", func(t *testing.T) {
+		got := formatTransition(fluxinfra.KustomizePlan{AppliedRef: "main"})
+		if got != " (main → <none>)" {
+			t.Errorf("expected <none> target, got %q", got)
+		}
+	})
+
+	t.Run("EmptyWhenNothingToNote", func(t *testing.T) {
+		if got := formatTransition(fluxinfra.KustomizePlan{Name: "dns"}); got != "" {
+			t.Errorf("expected empty suffix, got %q", got)
+		}
+	})
+}
+
+func TestTransitions(t *testing.T) {
+	t.Run("ListsTransitionsExceptUnchanged", func(t *testing.T) {
+		var buf strings.Builder
+		Transitions(&buf, []fluxinfra.KustomizePlan{
+			{Name: "dns", Transition: fluxinfra.TransitionUnchanged},
+			{Name: "ingress", Transition: fluxinfra.TransitionMigrated, MigratedFrom: []string{"old-ingress"}},
+			{Name: "old-ingress", Transition: fluxinfra.TransitionReclaimed, MigratedTo: []string{"ingress"}},
+			{Name: "broken", Err: fmt.Errorf("boom")},
+		})
+		out := buf.String()
+		for _, want := range []string{
+			"Kustomizations:",
+			"  migrated   ingress (migrated from old-ingress)",
+			"  reclaimed  old-ingress (objects migrated to ingress)",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "dns") || strings.Contains(out, "broken") {
+			t.Errorf("expected unchanged and unclassified rows omitted, got:\n%s", out)
+		}
+	})
+
+	t.Run("WritesNothingWhenNothingTransitions", func(t *testing.T) {
+		var buf strings.Builder
+		Transitions(&buf, []fluxinfra.KustomizePlan{{Name: "dns", Transition: fluxinfra.TransitionUnchanged}})
+		if buf.Len() != 0 {
+			t.Errorf("expected no output, got %q", buf.String())
+		}
+	})
+}
+
 func TestRenderPlanSummaryJSON(t *testing.T) {
+	t.Run("EmitsViolations", func(t *testing.T) {
+		var buf strings.Builder
+		err := SummaryJSON(&buf, nil,
+			[]fluxinfra.KustomizePlan{{Name: "apps"}},
+			[]policy.Violation{{Policy: "keep-namespaces", Layer: "kustomize", Component: "apps", Address: "Namespace/apps", Message: "namespaces may not be deleted"}},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{
+			`"violations": [`,
+			`"policy": "keep-namespaces"`,
+			`"layer": "kustomize"`,
+			`"address": "Namespace/apps"`,
+			`"message": "namespaces may not be deleted"`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected JSON to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("OmitsViolationsKeyWhenNone", func(t *testing.T) {
+		var buf strings.Builder
+		if err := SummaryJSON(&buf, []terraforminfra.TerraformComponentPlan{{ComponentID: "vpc"}}, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(buf.String(), "violations") {
+			t.Errorf("expected no violations key, got %s", buf.String())
+		}
+	})
+
+	t.Run("EmitsKustomizeTransitions", func(t *testing.T) {
+		var buf strings.Builder
+		err := SummaryJSON(&buf, nil, []fluxinfra.KustomizePlan{
+			{Name: "ingress", Transition: fluxinfra.TransitionMigrated, MigratedFrom: []string{"old-ingress"}, AppliedRef: "v0.5.0", TargetRef: "v0.6.0"},
+			{Name: "dns"},
+		}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{
+			`"transition": "migrated"`,
+			`"migrated_from": [`,
+			`"applied_ref": "v0.5.0"`,
+			`"target_ref": "v0.6.0"`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected JSON to contain %q, got:\n%s", want, out)
+			}
+		}
+		if strings.Count(out, `"transition"`) != 1 {
+			t.Errorf("expected transition omitted for unclassified row, got %s", out)
+		}
+	})
+
 	t.Run("EmitsIsNewFlag", func(t *testing.T) {
 		var buf strings.Builder
 		err := SummaryJSON(&buf, []terraforminfra.TerraformComponentPlan{
 			{ComponentID: "vpc", IsNew: true},
 			{ComponentID: "ec2", Add: 5},
-		}, nil)
+		}, nil, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -191,6 +355,7 @@ func TestRenderPlanSummaryJSON(t *testing.T) {
 					{Address: "ConfigMap/monitoring/grafana-config", Action: fluxinfra.ActionUpdate},
 				},
 			}},
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -214,6 +379,34 @@ func TestRenderPlanSummaryJSON(t *testing.T) {
 }
 
 func TestRenderPlanSummary(t *testing.T) {
+	t.Run("ListsPolicyViolations", func(t *testing.T) {
+		var buf strings.Builder
+		Summary(&buf,
+			[]terraforminfra.TerraformComponentPlan{{ComponentID: "database", Destroy: 1}},
+			nil,
+			[]policy.Violation{{Policy: "no-db-destroy", Layer: "terraform", Component: "database", Address: "aws_db_instance.main", Message: "databases may not be destroyed"}},
+			nil, true)
+		out := buf.String()
+		for _, want := range []string{
+			"Policy Violations",
+			"no-db-destroy  database aws_db_instance.main",
+			"databases may not be destroyed",
+			"Apply will be refused",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("OmitsViolationsBlockWhenNone", func(t *testing.T) {
+		var buf strings.Builder
+		Summary(&buf, []terraforminfra.TerraformComponentPlan{{ComponentID: "vpc", Add: 1}}, nil, nil, nil, true)
+		if strings.Contains(buf.String(), "Policy Violations") {
+			t.Errorf("expected no violations block, got:\n%s", buf.String())
+		}
+	})
+
 	t.Run("ListsTerraformResourcesUnderComponentSortedDestructiveFirst", func(t *testing.T) {
 		// Operators read top-down — destructive changes (delete, replace)
 		// deserve to surface above creates and updates.
@@ -229,7 +422,7 @@ func TestRenderPlanSummary(t *testing.T) {
 					{Address: "aws_security_group.legacy", Action: terraforminfra.ActionDelete},
 				},
 			}},
-			nil, nil, true)
+			nil, nil, nil, true)
 
 		out := buf.String()
 		for _, want := range []string{
@@ -261,7 +454,7 @@ func TestRenderPlanSummary(t *testing.T) {
 					{Address: "Service/monitoring/legacy-exporter", Action: fluxinfra.ActionDelete},
 				},
 			}},
-			nil, true)
+			nil, nil, true)
 
 		out := buf.String()
 		for _, want := range []string{
@@ -290,7 +483,7 @@ func TestRenderPlanSummary(t *testing.T) {
 					{Address: "azurerm_federated_identity_credential.external_dns[0]", Action: terraforminfra.ActionReplace},
 				},
 			}},
-			nil, nil, true)
+			nil, nil, nil, true)
 		out := buf.String()
 		if !strings.Contains(out, "±1") {
 			t.Errorf("expected header to show ±1 for the single replace, got:\n%s", out)
@@ -304,7 +497,7 @@ func TestRenderPlanSummary(t *testing.T) {
 		var buf strings.Builder
 		Summary(&buf,
 			[]terraforminfra.TerraformComponentPlan{{ComponentID: "vpc", Add: 1}},
-			nil, nil, true)
+			nil, nil, nil, true)
 		out := buf.String()
 		if !strings.Contains(out, "windsor plan terraform <name>") {
 			t.Errorf("expected footer hint in output, got:\n%s", out)
@@ -316,7 +509,7 @@ func TestRenderPlanSummary(t *testing.T) {
 		Summary(&buf,
 			[]terraforminfra.TerraformComponentPlan{{ComponentID: "vpc", NoChanges: true}},
 			[]fluxinfra.KustomizePlan{{Name: "monitoring"}},
-			nil, true)
+			nil, nil, true)
 		out := buf.String()
 		if strings.Contains(out, "for full diffs") {
 			t.Errorf("did not expect footer hint, got:\n%s", out)
@@ -327,7 +520,7 @@ func TestRenderPlanSummary(t *testing.T) {
 		var buf strings.Builder
 		Summary(&buf,
 			[]terraforminfra.TerraformComponentPlan{{ComponentID: "vpc", IsNew: true}},
-			nil, nil, true)
+			nil, nil, nil, true)
 		out := buf.String()
 		if !strings.Contains(out, "for full diffs") {
 			t.Errorf("expected footer hint for new component, got:\n%s", out)
@@ -345,7 +538,7 @@ func TestRenderPlanSummary(t *testing.T) {
 		var buf strings.Builder
 		Summary(&buf, nil,
 			[]fluxinfra.KustomizePlan{{Name: "policy", Added: 25, Resources: resources}},
-			nil, true)
+			nil, nil, true)
 		out := buf.String()
 		if !strings.Contains(out, "… and 5 more") {
 			t.Errorf("expected truncation marker, got:\n%s", out)
@@ -576,3 +769,140 @@ func TestDestroySummaryJSON(t *testing.T) {
 		}
 	})
 }
+
+func TestDriftSummary(t *testing.T) {
+	t.Run("RendersNoDriftAndNotDeployedRows", func(t *testing.T) {
+		// Components matching their recorded state read "(no drift)"; IsNew keeps
+		// its destroy-side meaning rather than the apply-side "(new)".
+		var buf strings.Builder
+		DriftSummary(&buf,
+			[]terraforminfra.TerraformComponentPlan{
+				{ComponentID: "network", NoChanges: true},
+				{ComponentID: "vpc", IsNew: true},
+			},
+			[]fluxinfra.KustomizePlan{{Name: "monitoring", IsNew: true}},
+			true)
+		out := buf.String()
+		for _, want := range []string{"Windsor Drift Report", "(no drift)", "(no state)", "(not deployed)", "No drift detected."} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "(new)") {
+			t.Errorf("drift renderer must not emit (new), got:\n%s", out)
+		}
+	})
+
+	t.Run("EnumeratesDriftedResources", func(t *testing.T) {
+		var buf strings.Builder
+		DriftSummary(&buf,
+			[]terraforminfra.TerraformComponentPlan{{
+				ComponentID: "cluster", Path: "cluster/aws-eks", Change: 1,
+				Resources: []terraforminfra.ResourceChange{
+					{Address: "aws_eks_cluster.main", Action: terraforminfra.ActionUpdate},
+				},
+			}},
+			[]fluxinfra.KustomizePlan{{
+				Name: "monitoring",
+				Resources: []fluxinfra.ResourceChange{
+					{Address: "Deployment/monitoring/grafana", Action: fluxinfra.ActionDelete},
+				},
+			}},
+			true)
+		out := buf.String()
+		for _, want := range []string{
+			"~ aws_eks_cluster.main",
+			"- Deployment/monitoring/grafana",
+			"Drift detected.",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("LabelsKustomizeUpdatesAsManualEdits", func(t *testing.T) {
+		var buf strings.Builder
+		DriftSummary(&buf, nil,
+			[]fluxinfra.KustomizePlan{
+				{Name: "monitoring", Resources: []fluxinfra.ResourceChange{
+					{Address: "Deployment/monitoring/grafana", Action: fluxinfra.ActionUpdate},
+				}},
+				{Name: "dns", Resources: []fluxinfra.ResourceChange{
+					{Address: "ConfigMap/dns/coredns", Action: fluxinfra.ActionDelete},
+				}},
+			},
+			true)
+		out := buf.String()
+		if !strings.Contains(out, "Kustomize (deletions and manual edits)") {
+			t.Errorf("expected the section to name what it covers, got:\n%s", out)
+		}
+		if n := strings.Count(out, "(manual edits detected)"); n != 1 {
+			t.Errorf("expected only the edited kustomization labelled, got %d in:\n%s", n, out)
+		}
+	})
+
+	t.Run("RendersOutputOnlyDriftAsDrifted", func(t *testing.T) {
+		var buf strings.Builder
+		DriftSummary(&buf, []terraforminfra.TerraformComponentPlan{{ComponentID: "dns"}}, nil, true)
+		if out := buf.String(); !strings.Contains(out, "(drifted)") {
+			t.Errorf("expected (drifted) for drift without resource events, got:\n%s", out)
+		}
+	})
+}
+
+func TestDriftSummaryJSON(t *testing.T) {
+	t.Run("FlagsDriftedRowsAndTopLevel", func(t *testing.T) {
+		var buf strings.Builder
+		err := DriftSummaryJSON(&buf,
+			[]terraforminfra.TerraformComponentPlan{{ComponentID: "network", NoChanges: true}},
+			[]fluxinfra.KustomizePlan{{
+				Name: "monitoring",
+				Resources: []fluxinfra.ResourceChange{
+					{Address: "ConfigMap/monitoring/grafana", Action: fluxinfra.ActionUpdate},
+				},
+			}},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{
+			`"drifted": true`,
+			`"drifted": false`,
+			`"address": "ConfigMap/monitoring/grafana"`,
+			`"action": "update"`,
+			`"manual_edits": true`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected JSON to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+}
+
+func TestHasDrift(t *testing.T) {
+	t.Run("IgnoresErroredAndUndeployedComponents", func(t *testing.T) {
+		tf := []terraforminfra.TerraformComponentPlan{
+			{ComponentID: "a", Err: fmt.Errorf("boom")},
+			{ComponentID: "b", IsNew: true},
+			{ComponentID: "c", NoChanges: true},
+		}
+		k8s := []fluxinfra.KustomizePlan{
+			{Name: "d", Err: fmt.Errorf("boom"), Resources: []fluxinfra.ResourceChange{{Address: "x", Action: fluxinfra.ActionDelete}}},
+		}
+		if HasDrift(tf, k8s) {
+			t.Error("expected no drift")
+		}
+	})
+
+	t.Run("DetectsDriftOnEitherLayer", func(t *testing.T) {
+		if !HasDrift([]terraforminfra.TerraformComponentPlan{{ComponentID: "a"}}, nil) {
+			t.Error("expected terraform drift")
+		}
+		k8s := []fluxinfra.KustomizePlan{{Name: "d", Resources: []fluxinfra.ResourceChange{{Address: "x", Action: fluxinfra.ActionDelete}}}}
+		if !HasDrift(nil, k8s) {
+			t.Error("expected kustomize drift")
+		}
+	})
+}
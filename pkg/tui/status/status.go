@@ -0,0 +1,281 @@
+// Package status renders the reconciliation dashboard printed by `windsor status`.
+// It lives under pkg/tui beside the plan renderers: the provisioner gathers a
+// StatusSummary from the cluster and the cmd layer calls Summary or SummaryJSON,
+// so the layout and color vocabulary stay out of the command file.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/windsorcli/cli/pkg/provisioner"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// =============================================================================
+// Public Methods
+// =============================================================================
+
+// Summary writes the status dashboard to w. A header block names the applied-version
+// marker's phase and sources. Each kustomization follows on one row with its state and
+// last applied revision; a kustomization waiting on a dependency shows what it waits on,
+// and a failed or reconciling one shows flux's message. Indented beneath each row are its
+// source and the HelmReleases in its inventory, each with its own state and revision. A
+// closing line counts kustomizations by state.
+func Summary(w io.Writer, summary *provisioner.StatusSummary, noColor bool) {
+	nameWidth := 20
+	for _, k := range summary.Kustomizations {
+		if len(k.Name) > nameWidth {
+			nameWidth = len(k.Name)
+		}
+	}
+	nameWidth += 2
+
+	sep := strings.Repeat("═", nameWidth+26)
+	fmt.Fprintf(w, "\nWindsor Status\n%s\n", sep)
+	writeMarker(w, summary.Marker)
+
+	if len(summary.Kustomizations) == 0 {
+		fmt.Fprintln(w, "\n  (no kustomizations in blueprint)")
+		fmt.Fprintln(w)
+		return
+	}
+
+	fmt.Fprintln(w, "\nKustomizations")
+	for _, k := range summary.Kustomizations {
+		fmt.Fprintf(w, "  %-*s  %s%s\n", nameWidth, k.Name, formatState(k.State, noColor), formatRevision(k.Revision))
+		if detail := kustomizationDetail(k); detail != "" {
+			fmt.Fprintf(w, "  %-*s  %s\n", nameWidth, "", detail)
+		}
+		if k.Source != nil {
+			label := fmt.Sprintf("source %s/%s", k.Source.Kind, k.Source.Name)
+			writeChild(w, label, *k.Source, noColor)
+		}
+		for _, hr := range k.HelmReleases {
+			label := fmt.Sprintf("helm   %s/%s", hr.Namespace, hr.Name)
+			writeChild(w, label, hr, noColor)
+		}
+		if k.Err != nil {
+			fmt.Fprintf(w, "    %s\n", colorize(fmt.Sprintf("(error: %s)", truncateFirstLine(k.Err.Error())), "31", noColor))
+		}
+	}
+
+	fmt.Fprintf(w, "\n%s\n\n", formatCounts(summary.Kustomizations))
+}
+
+// SummaryJSON encodes the status dashboard as JSON to w. Every kustomization carries a
+// ready flag alongside its state so consumers can gate on readiness without knowing the
+// state vocabulary, and the top-level ready field is true only when every kustomization is
+// Ready.
+func SummaryJSON(w io.Writer, summary *provisioner.StatusSummary) error {
+	type objectRow struct {
+		Kind      string `json:"kind"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+		State     string `json:"state"`
+		Revision  string `json:"revision,omitempty"`
+		Reason    string `json:"reason,omitempty"`
+		Message   string `json:"message,omitempty"`
+	}
+	type kustomizationRow struct {
+		Name         string      `json:"name"`
+		State        string      `json:"state"`
+		Ready        bool        `json:"ready"`
+		Revision     string      `json:"revision,omitempty"`
+		Reason       string      `json:"reason,omitempty"`
+		Message      string      `json:"message,omitempty"`
+		BlockedBy    string      `json:"blocked_by,omitempty"`
+		Source       *objectRow  `json:"source,omitempty"`
+		HelmReleases []objectRow `json:"helm_releases,omitempty"`
+		Error        string      `json:"error,omitempty"`
+	}
+	type markerRow struct {
+		Phase   string            `json:"phase"`
+		Sources map[string]string `json:"sources,omitempty"`
+	}
+	type output struct {
+		Ready          bool               `json:"ready"`
+		VersionMarker  *markerRow         `json:"version_marker,omitempty"`
+		Kustomizations []kustomizationRow `json:"kustomizations"`
+	}
+
+	toRow := func(s kubernetes.FluxStatus) objectRow {
+		return objectRow{Kind: s.Kind, Name: s.Name, Namespace: s.Namespace, State: s.State, Revision: s.Revision, Reason: s.Reason, Message: s.Message}
+	}
+
+	out := output{Ready: AllReady(summary), Kustomizations: []kustomizationRow{}}
+	if summary.Marker != nil {
+		out.VersionMarker = &markerRow{Phase: summary.Marker.Phase, Sources: markerSources(summary.Marker)}
+	}
+	for _, k := range summary.Kustomizations {
+		row := kustomizationRow{
+			Name:      k.Name,
+			State:     k.State,
+			Ready:     k.State == kubernetes.FluxStateReady,
+			Revision:  k.Revision,
+			Reason:    k.Reason,
+			Message:   k.Message,
+			BlockedBy: k.BlockedBy,
+		}
+		if k.Source != nil {
+			source := toRow(*k.Source)
+			row.Source = &source
+		}
+		for _, hr := range k.HelmReleases {
+			row.HelmReleases = append(row.HelmReleases, toRow(hr))
+		}
+		if k.Err != nil {
+			row.Error = k.Err.Error()
+		}
+		out.Kustomizations = append(out.Kustomizations, row)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// AllReady reports whether every kustomization in the summary is Ready.
+func AllReady(summary *provisioner.StatusSummary) bool {
+	for _, k := range summary.Kustomizations {
+		if k.State != kubernetes.FluxStateReady {
+			return false
+		}
+	}
+	return true
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// writeMarker writes the version-marker block: its phase and one line per applied source,
+// or a note that the cluster carries no marker.
+func writeMarker(w io.Writer, marker *kubernetes.VersionMarker) {
+	if marker == nil {
+		fmt.Fprintln(w, "Version  (no version marker)")
+		return
+	}
+	phase := marker.Phase
+	if phase == "" {
+		phase = kubernetes.VersionMarkerPhaseIdle
+	}
+	fmt.Fprintf(w, "Version  %s\n", phase)
+	sources := markerSources(marker)
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s  %s\n", name, sources[name])
+	}
+}
+
+// markerSources returns each applied source in the marker as "url@ref", or the URL alone when
+// no ref was recorded.
+func markerSources(marker *kubernetes.VersionMarker) map[string]string {
+	if len(marker.AppliedSources) == 0 {
+		return nil
+	}
+	sources := make(map[string]string, len(marker.AppliedSources))
+	for name, ref := range marker.AppliedSources {
+		if ref.Ref == "" {
+			sources[name] = ref.URL
+			continue
+		}
+		sources[name] = ref.URL + "@" + ref.Ref
+	}
+	return sources
+}
+
+// writeChild writes one indented source or HelmRelease row, followed by flux's message when
+// it is not Ready.
+func writeChild(w io.Writer, label string, s kubernetes.FluxStatus, noColor bool) {
+	fmt.Fprintf(w, "    %s  %s%s\n", label, formatState(s.State, noColor), formatRevision(s.Revision))
+	if s.State != kubernetes.FluxStateReady && s.Message != "" {
+		fmt.Fprintf(w, "      %s\n", truncateFirstLine(s.Message))
+	}
+}
+
+// kustomizationDetail returns the explanatory line shown under a kustomization row: what it
+// is blocked on when waiting on a dependency, flux's message when it is not Ready, and
+// nothing otherwise.
+func kustomizationDetail(k provisioner.KustomizationStatusEntry) string {
+	if k.BlockedBy != "" {
+		return "blocked: " + truncateFirstLine(k.BlockedBy)
+	}
+	if k.State == kubernetes.FluxStateReady || k.State == kubernetes.FluxStateNotFound || k.Message == "" {
+		return ""
+	}
+	return truncateFirstLine(k.Message)
+}
+
+// formatState returns a state padded to a fixed column and colored by outcome: green for
+// Ready, yellow for Reconciling, red for Failed, cyan for Suspended and NotFound.
+func formatState(state string, noColor bool) string {
+	padded := fmt.Sprintf("%-12s", state)
+	switch state {
+	case kubernetes.FluxStateReady:
+		return colorize(padded, "32", noColor)
+	case kubernetes.FluxStateReconciling:
+		return colorize(padded, "33", noColor)
+	case kubernetes.FluxStateFailed:
+		return colorize(padded, "31", noColor)
+	}
+	return colorize(padded, "36", noColor)
+}
+
+// formatRevision returns the revision suffix for a row, or "" when none is recorded.
+func formatRevision(revision string) string {
+	if revision == "" {
+		return ""
+	}
+	return "  " + revision
+}
+
+// formatCounts returns the closing tally, e.g. "3 Ready, 1 Reconciling, 1 Failed", listing
+// states in a fixed order and omitting those with no kustomizations.
+func formatCounts(entries []provisioner.KustomizationStatusEntry) string {
+	counts := make(map[string]int)
+	for _, k := range entries {
+		counts[k.State]++
+	}
+	var parts []string
+	for _, state := range []string{
+		kubernetes.FluxStateReady,
+		kubernetes.FluxStateReconciling,
+		kubernetes.FluxStateFailed,
+		kubernetes.FluxStateSuspended,
+		kubernetes.FluxStateNotFound,
+	} {
+		if counts[state] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[state], state))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// colorize wraps s in the given ANSI color code unless noColor is set.
+func colorize(s, code string, noColor bool) string {
+	if noColor {
+		return s
+	}
+	return fmt.Sprintf("\033[%sm%s\033[0m", code, s)
+}
+
+// truncateFirstLine returns the first line of s, trimmed and capped at 120 characters,
+// so multi-line flux messages stay on one dashboard row.
+func truncateFirstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if len(s) > 120 {
+		s = s[:117] + "..."
+	}
+	return s
+}
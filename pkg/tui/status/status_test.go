@@ -0,0 +1,135 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/windsorcli/cli/pkg/provisioner"
+	"github.com/windsorcli/cli/pkg/provisioner/kubernetes"
+)
+
+// sampleSummary returns a summary with a ready, a blocked, and a failed kustomization.
+func sampleSummary() *provisioner.StatusSummary {
+	entry := func(name, state string) provisioner.KustomizationStatusEntry {
+		return provisioner.KustomizationStatusEntry{KustomizationStatus: kubernetes.KustomizationStatus{
+			FluxStatus: kubernetes.FluxStatus{Kind: "Kustomization", Name: name, State: state},
+		}}
+	}
+	ready := entry("crds", kubernetes.FluxStateReady)
+	ready.Revision = "v1.0.0@sha256:abc"
+	ready.Source = &kubernetes.FluxStatus{Kind: "OCIRepository", Name: "core", State: kubernetes.FluxStateReady, Revision: "v1.0.0@sha256:abc"}
+	ready.HelmReleases = []kubernetes.FluxStatus{{Kind: "HelmRelease", Name: "cert-manager", Namespace: "system-pki", State: kubernetes.FluxStateReady}}
+
+	blocked := entry("ingress", kubernetes.FluxStateReconciling)
+	blocked.BlockedBy = "dependency 'system-gitops/crds' is not ready"
+
+	failed := entry("dns", kubernetes.FluxStateFailed)
+	failed.Message = "kustomize build failed\nstack trace"
+	failed.Err = fmt.Errorf("inventory unreadable")
+
+	return &provisioner.StatusSummary{
+		Marker: &kubernetes.VersionMarker{
+			Phase:          kubernetes.VersionMarkerPhaseIdle,
+			AppliedSources: map[string]kubernetes.SourceRef{"core": {URL: "oci://ghcr.io/windsorcli/core", Ref: "v1.0.0"}},
+		},
+		Kustomizations: []provisioner.KustomizationStatusEntry{ready, blocked, failed},
+	}
+}
+
+func TestSummary(t *testing.T) {
+	t.Run("RendersKustomizationsWithChildrenAndCounts", func(t *testing.T) {
+		var buf strings.Builder
+		Summary(&buf, sampleSummary(), true)
+		out := buf.String()
+		for _, want := range []string{
+			"Windsor Status",
+			"Version  idle",
+			"core  oci://ghcr.io/windsorcli/core@v1.0.0",
+			"source OCIRepository/core",
+			"helm   system-pki/cert-manager",
+			"blocked: dependency 'system-gitops/crds' is not ready",
+			"kustomize build failed",
+			"(error: inventory unreadable)",
+			"1 Ready, 1 Reconciling, 1 Failed",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "stack trace") {
+			t.Errorf("expected multi-line messages cut to their first line, got:\n%s", out)
+		}
+		if strings.Contains(out, "\033[") {
+			t.Errorf("expected no ANSI codes with noColor, got:\n%s", out)
+		}
+	})
+
+	t.Run("NotesMissingMarkerAndEmptyBlueprint", func(t *testing.T) {
+		var buf strings.Builder
+		Summary(&buf, &provisioner.StatusSummary{}, true)
+		out := buf.String()
+		for _, want := range []string{"(no version marker)", "(no kustomizations in blueprint)"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+}
+
+func TestSummaryJSON(t *testing.T) {
+	t.Run("FlagsReadinessPerRowAndTopLevel", func(t *testing.T) {
+		var buf strings.Builder
+		if err := SummaryJSON(&buf, sampleSummary()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var out struct {
+			Ready         bool `json:"ready"`
+			VersionMarker struct {
+				Phase   string            `json:"phase"`
+				Sources map[string]string `json:"sources"`
+			} `json:"version_marker"`
+			Kustomizations []struct {
+				Name         string            `json:"name"`
+				Ready        bool              `json:"ready"`
+				BlockedBy    string            `json:"blocked_by"`
+				Source       *json.RawMessage  `json:"source"`
+				HelmReleases []json.RawMessage `json:"helm_releases"`
+				Error        string            `json:"error"`
+			} `json:"kustomizations"`
+		}
+		if err := json.Unmarshal([]byte(buf.String()), &out); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+		}
+		if out.Ready {
+			t.Error("expected top-level ready=false with a failed kustomization")
+		}
+		if out.VersionMarker.Sources["core"] != "oci://ghcr.io/windsorcli/core@v1.0.0" {
+			t.Errorf("expected marker source, got %+v", out.VersionMarker)
+		}
+		if len(out.Kustomizations) != 3 {
+			t.Fatalf("expected 3 kustomizations, got %d", len(out.Kustomizations))
+		}
+		crds, ingress, dns := out.Kustomizations[0], out.Kustomizations[1], out.Kustomizations[2]
+		if !crds.Ready || crds.Source == nil || len(crds.HelmReleases) != 1 {
+			t.Errorf("expected crds ready with source and helm release, got %+v", crds)
+		}
+		if ingress.Ready || ingress.BlockedBy == "" {
+			t.Errorf("expected ingress blocked, got %+v", ingress)
+		}
+		if dns.Error != "inventory unreadable" {
+			t.Errorf("expected dns error, got %+v", dns)
+		}
+	})
+
+	t.Run("EmptySummaryIsReadyWithEmptyList", func(t *testing.T) {
+		var buf strings.Builder
+		if err := SummaryJSON(&buf, &provisioner.StatusSummary{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), `"kustomizations": []`) || !strings.Contains(buf.String(), `"ready": true`) {
+			t.Errorf("expected ready empty list, got:\n%s", buf.String())
+		}
+	})
+}
@@ -60,6 +60,10 @@ var Active Spinner = &termSpinner{}
 // produces a terminal output line.
 var activeDepth int32
 
+// noColor disables the ANSI color on lines built by Warning. It is set from --no-color and
+// NO_COLOR through SetNoColor.
+var noColor bool
+
 // Init configures Active for the current run mode.
 // In verbose mode, Active is set to a verboseSpinner that prints messages without animation.
 func Init(verbose bool) {
@@ -106,6 +110,17 @@ func SectionHeader(label string) string {
 	return s
 }
 
+// SetNoColor turns off the ANSI color on lines built by Warning when disabled is true.
+func SetNoColor(disabled bool) { noColor = disabled }
+
+// Warning returns message prefixed with a warning marker, colored yellow unless color is disabled.
+func Warning(message string) string {
+	if noColor {
+		return "⚠ " + message
+	}
+	return "\033[33m⚠\033[0m " + message
+}
+
 // Fail stops the active spinner and prints a failure line.
 // When called inside a WithProgress block, it is a no-op.
 func Fail() {
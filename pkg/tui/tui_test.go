@@ -119,6 +119,36 @@ func TestFail(t *testing.T) {
 	})
 }
 
+// Tests for Warning color handling
+func TestWarning(t *testing.T) {
+	t.Run("ColorsMarkerByDefault", func(t *testing.T) {
+		// Given color is enabled
+		SetNoColor(false)
+
+		// When a warning is built
+		got := Warning("apps: image pull back-off")
+
+		// Then the marker is yellow
+		if got != "\033[33m⚠\033[0m apps: image pull back-off" {
+			t.Errorf("expected a yellow marker, got %q", got)
+		}
+	})
+
+	t.Run("OmitsColorWhenDisabled", func(t *testing.T) {
+		// Given color is disabled
+		SetNoColor(true)
+		t.Cleanup(func() { SetNoColor(false) })
+
+		// When a warning is built
+		got := Warning("apps: image pull back-off")
+
+		// Then no escape codes are written
+		if got != "⚠ apps: image pull back-off" {
+			t.Errorf("expected a plain marker, got %q", got)
+		}
+	})
+}
+
 // Tests for WithProgress success and error flows
 func TestWithProgress(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {